@@ -0,0 +1,183 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+const (
+	defaultSegmentInterval  = time.Minute
+	defaultMaxSegmentEvents = 1000
+)
+
+// ErrWatchClosed is returned by Run if the client closes the watch on the
+// committed events.
+var ErrWatchClosed = errors.New("backup: watch closed")
+
+// Config holds the configuration of a Backup.
+type Config struct {
+	// Sink stores the base snapshots and the archived segments.
+	Sink Sink
+
+	// SnapshotInterval is the interval between base snapshots. A base
+	// snapshot is only taken at start and after compactions if zero.
+	SnapshotInterval time.Duration
+
+	// SegmentInterval is the longest time archived events are buffered
+	// before being written out as a segment. Defaults to one minute.
+	SegmentInterval time.Duration
+
+	// MaxSegmentEvents is the number of buffered events that has them
+	// written out as a segment before SegmentInterval. Defaults to 1000.
+	MaxSegmentEvents int
+}
+
+// Backup takes base snapshots of a cluster and archives the events
+// committed after them.
+type Backup struct {
+	c   *clientv3.Client
+	cfg Config
+}
+
+// New creates a Backup of the cluster of the given client. Since a base
+// snapshot is the backend of a single member, the client should be
+// configured with exactly one endpoint.
+func New(c *clientv3.Client, cfg Config) *Backup {
+	if cfg.SegmentInterval == 0 {
+		cfg.SegmentInterval = defaultSegmentInterval
+	}
+	if cfg.MaxSegmentEvents == 0 {
+		cfg.MaxSegmentEvents = defaultMaxSegmentEvents
+	}
+	return &Backup{c: c, cfg: cfg}
+}
+
+// Run takes a base snapshot and archives the committed events until the
+// context is canceled or an error occurs. Buffered events not yet written
+// out as a segment are dropped on return.
+func (b *Backup) Run(ctx context.Context) error {
+	for {
+		rev, err := b.snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		// a compacted watch leaves a gap in the archive; start over
+		// with a base snapshot following it
+		if err = b.archive(ctx, rev); err != rpctypes.ErrCompacted {
+			return err
+		}
+	}
+}
+
+// snapshot stores a base snapshot, named by a revision it covers.
+func (b *Backup) snapshot(ctx context.Context) (int64, error) {
+	resp, err := b.c.Get(ctx, "foo")
+	if err != nil {
+		return 0, err
+	}
+	rc, err := b.c.Snapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	rev := resp.Header.Revision
+	if err = b.cfg.Sink.Put(ctx, snapshotName(rev), rc); err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
+
+// archive writes out the events following the given revision as segments.
+func (b *Backup) archive(ctx context.Context, rev int64) error {
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch := b.c.Watch(wctx, "", clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+
+	var snapc <-chan time.Time
+	if b.cfg.SnapshotInterval > 0 {
+		snapt := time.NewTicker(b.cfg.SnapshotInterval)
+		defer snapt.Stop()
+		snapc = snapt.C
+	}
+	segt := time.NewTicker(b.cfg.SegmentInterval)
+	defer segt.Stop()
+
+	seg := segment{start: rev + 1}
+	var evs []*mvccpb.Event
+	flush := func() error {
+		if len(evs) == 0 {
+			return nil
+		}
+		data, err := encodeEvents(evs)
+		if err != nil {
+			return err
+		}
+		seg.end = evs[len(evs)-1].Kv.ModRevision
+		if err = b.cfg.Sink.Put(ctx, seg.name(), bytes.NewReader(data)); err != nil {
+			return err
+		}
+		seg, evs = segment{start: seg.end + 1}, nil
+		return nil
+	}
+
+	for {
+		select {
+		case wr, ok := <-wch:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return ErrWatchClosed
+			}
+			if err := wr.Err(); err != nil {
+				if ferr := flush(); ferr != nil {
+					return ferr
+				}
+				return err
+			}
+			// a watch response never splits the events of a revision,
+			// so segments always hold whole revisions
+			for _, ev := range wr.Events {
+				evs = append(evs, (*mvccpb.Event)(ev))
+			}
+			if len(evs) >= b.cfg.MaxSegmentEvents {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-segt.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case <-snapc:
+			// the watch keeps archiving past the snapshot, so older
+			// base snapshots still restore to any later revision
+			if err := flush(); err != nil {
+				return err
+			}
+			if _, err := b.snapshot(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup implements incremental backups of an etcd cluster.
+//
+// A Backup takes a base snapshot of the backend and then continuously
+// archives the events committed after it to a Sink, in segments that each
+// hold a contiguous range of revisions. New base snapshots are taken
+// periodically, and whenever the archived events are compacted away before
+// being read.
+//
+// Restore rebuilds the backend database as of any archived revision from
+// the newest base snapshot preceding it and the segments that follow,
+// without requiring a full snapshot for every restore point:
+//
+//	b := backup.New(cli, backup.Config{
+//		Sink:             backup.NewDirSink("/var/backup/etcd"),
+//		SnapshotInterval: time.Hour,
+//	})
+//	go b.Run(ctx)
+//
+//	// later
+//	err := backup.Restore(ctx, backup.NewDirSink("/var/backup/etcd"), "snapshot.db", rev)
+//
+// The restored database can be bootstrapped into a new cluster with
+// "etcdctl snapshot restore --skip-hash-check".
+package backup
@@ -0,0 +1,202 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/coreos/etcd/snap"
+)
+
+var (
+	ErrNoSnapshot     = errors.New("backup: no base snapshot precedes the revision")
+	ErrMissingSegment = errors.New("backup: archived segments do not reach the revision")
+
+	errSnapshotTooNew = errors.New("backup: base snapshot follows the revision")
+)
+
+// Restore writes the backend database as of the given revision to dbPath,
+// from the newest base snapshot preceding the revision and the segments
+// archived after it. A zero revision restores the latest archived one.
+func Restore(ctx context.Context, s Sink, dbPath string, rev int64) error {
+	names, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		snaps []int64
+		segs  []segment
+	)
+	for _, name := range names {
+		snapRev, seg, ok := parseName(name)
+		switch {
+		case !ok:
+		case snapRev != 0:
+			snaps = append(snaps, snapRev)
+		default:
+			segs = append(segs, seg)
+		}
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i] < snaps[j] })
+	sort.Slice(segs, func(i, j int) bool { return segs[i].start < segs[j].start })
+
+	if rev == 0 && len(snaps) > 0 {
+		rev = snaps[len(snaps)-1]
+		if len(segs) > 0 && segs[len(segs)-1].end > rev {
+			rev = segs[len(segs)-1].end
+		}
+	}
+	for i := len(snaps) - 1; i >= 0; i-- {
+		if snaps[i] > rev {
+			continue
+		}
+		// a snapshot is taken after its named revision, so it may
+		// still turn out too new
+		if err = restore(ctx, s, dbPath, snaps[i], segs, rev); err != errSnapshotTooNew {
+			return err
+		}
+	}
+	return ErrNoSnapshot
+}
+
+func restore(ctx context.Context, s Sink, dbPath string, snapRev int64, segs []segment, rev int64) (err error) {
+	if err = fetchSnapshot(ctx, s, snapshotName(snapRev), dbPath); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(dbPath)
+		}
+	}()
+
+	be := backend.NewDefaultBackend(dbPath)
+	defer be.Close()
+	// a lessor never timeouts leases
+	le := lease.NewLessor(be, math.MaxInt64)
+	defer le.Stop()
+	st := mvcc.NewStore(be, le, nil)
+	defer st.Close()
+
+	if st.Rev() > rev {
+		return errSnapshotTooNew
+	}
+	for _, seg := range segs {
+		if st.Rev() >= rev {
+			break
+		}
+		if seg.end <= st.Rev() {
+			continue
+		}
+		if seg.start > st.Rev()+1 {
+			break
+		}
+		evs, err := fetchSegment(ctx, s, seg)
+		if err != nil {
+			return err
+		}
+		if err = replay(st, le, evs, rev); err != nil {
+			return err
+		}
+	}
+	if st.Rev() < rev {
+		return ErrMissingSegment
+	}
+	return nil
+}
+
+// fetchSnapshot writes the base snapshot of the given name to dbPath once
+// its integrity hash is verified.
+func fetchSnapshot(ctx context.Context, s Sink, name, dbPath string) error {
+	rc, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = io.Copy(f, rc); err == nil {
+		var size int64
+		if size, err = snap.VerifyDBHash(f); err == nil {
+			err = f.Truncate(size)
+		}
+	}
+	if err != nil {
+		os.Remove(dbPath)
+		return fmt.Errorf("backup: %s: %v", name, err)
+	}
+	return nil
+}
+
+func fetchSegment(ctx context.Context, s Sink, seg segment) ([]mvccpb.Event, error) {
+	rc, err := s.Get(ctx, seg.name())
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	evs, err := decodeEvents(rc)
+	if err != nil {
+		return nil, fmt.Errorf("backup: %s: %v", seg.name(), err)
+	}
+	return evs, nil
+}
+
+// replay applies the events of the revisions following the store's up to
+// the given revision, one write per revision so that every replayed
+// revision keeps its number.
+func replay(st mvcc.KV, le lease.Lessor, evs []mvccpb.Event, rev int64) error {
+	for i := 0; i < len(evs); {
+		r := evs[i].Kv.ModRevision
+		if r <= st.Rev() {
+			i++
+			continue
+		}
+		if r > rev {
+			return nil
+		}
+		txn := st.Write()
+		for ; i < len(evs) && evs[i].Kv.ModRevision == r; i++ {
+			kv := evs[i].Kv
+			if evs[i].Type == mvccpb.DELETE {
+				txn.DeleteRange(kv.Key, nil)
+				continue
+			}
+			// leases granted after the base snapshot are not archived
+			id := lease.LeaseID(kv.Lease)
+			if le.Lookup(id) == nil {
+				id = lease.NoLease
+			}
+			txn.Put(kv.Key, kv.Value, id)
+		}
+		txn.End()
+		if st.Rev() != r {
+			return fmt.Errorf("backup: replayed revision %d as %d", r, st.Rev())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+const (
+	snapshotFormat = "snapshot-%016x.db"
+	segmentFormat  = "segment-%016x-%016x"
+)
+
+// segment names an archived segment holding the events of the revisions
+// in [start, end].
+type segment struct {
+	start, end int64
+}
+
+func (seg segment) name() string { return fmt.Sprintf(segmentFormat, seg.start, seg.end) }
+
+func snapshotName(rev int64) string { return fmt.Sprintf(snapshotFormat, rev) }
+
+// parseName parses an object name in the sink. ok is false for objects
+// that do not belong to a backup.
+func parseName(name string) (snapRev int64, seg segment, ok bool) {
+	if _, err := fmt.Sscanf(name, snapshotFormat, &snapRev); err == nil && name == snapshotName(snapRev) {
+		return snapRev, segment{}, true
+	}
+	if _, err := fmt.Sscanf(name, segmentFormat, &seg.start, &seg.end); err == nil && name == seg.name() {
+		return 0, seg, true
+	}
+	return 0, segment{}, false
+}
+
+// encodeEvents encodes events as a sequence of length-prefixed protobufs.
+func encodeEvents(evs []*mvccpb.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	lb := make([]byte, binary.MaxVarintLen64)
+	for _, ev := range evs {
+		b, err := ev.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(lb[:binary.PutUvarint(lb, uint64(len(b)))])
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeEvents(r io.Reader) ([]mvccpb.Event, error) {
+	br := bufio.NewReader(r)
+	var evs []mvccpb.Event
+	for {
+		n, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return evs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		if _, err = io.ReadFull(br, b); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		var ev mvccpb.Event
+		if err = ev.Unmarshal(b); err != nil {
+			return nil, err
+		}
+		evs = append(evs, ev)
+	}
+}
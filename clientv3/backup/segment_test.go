@@ -0,0 +1,72 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name string
+
+		wsnapRev int64
+		wseg     segment
+		wok      bool
+	}{
+		{snapshotName(10), 10, segment{}, true},
+		{segment{11, 20}.name(), 0, segment{11, 20}, true},
+		{"snapshot-10.db", 0, segment{}, false},
+		{snapshotName(10) + ".part", 0, segment{}, false},
+		{".tmp123", 0, segment{}, false},
+	}
+	for i, tt := range tests {
+		snapRev, seg, ok := parseName(tt.name)
+		if snapRev != tt.wsnapRev || seg != tt.wseg || ok != tt.wok {
+			t.Errorf("#%d: parseName(%q) = %d, %v, %v, want %d, %v, %v", i, tt.name, snapRev, seg, ok, tt.wsnapRev, tt.wseg, tt.wok)
+		}
+	}
+}
+
+func TestEncodeDecodeEvents(t *testing.T) {
+	evs := []*mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: 3}},
+	}
+	data, err := encodeEvents(evs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	devs, err := decodeEvents(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devs) != len(evs) {
+		t.Fatalf("len(events) = %d, want %d", len(devs), len(evs))
+	}
+	for i := range evs {
+		if !reflect.DeepEqual(&devs[i], evs[i]) {
+			t.Errorf("#%d: event = %+v, want %+v", i, devs[i], *evs[i])
+		}
+	}
+
+	if _, err = decodeEvents(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Errorf("expected error decoding truncated segment")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/etcd/pkg/fileutil"
+)
+
+// Sink stores the objects of a backup. Implementations may store them in a
+// local directory or in a remote object store, such as an S3-compatible
+// endpoint.
+type Sink interface {
+	// Put stores the object of the given name with the data read from r.
+	// An object is either stored completely or not at all.
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Get returns a reader for the object of the given name.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of all stored objects in lexical order.
+	List(ctx context.Context) ([]string, error)
+}
+
+// NewDirSink returns a Sink storing objects as files in the given directory.
+func NewDirSink(dir string) Sink {
+	return &dirSink{dir: dir}
+}
+
+type dirSink struct {
+	dir string
+}
+
+func (s *dirSink) Put(ctx context.Context, name string, r io.Reader) error {
+	if err := fileutil.TouchDirAll(s.dir); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(s.dir, ".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	f.Close()
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(s.dir, name))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func (s *dirSink) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, name))
+}
+
+func (s *dirSink) List(ctx context.Context) ([]string, error) {
+	names, err := fileutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return names, err
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/fileutil"
+)
+
+// Cursor durably records the revision up to which a feed delivered events.
+type Cursor interface {
+	// Load returns the recorded revision, or 0 if there is none.
+	Load(ctx context.Context) (int64, error)
+	// Store records rev.
+	Store(ctx context.Context, rev int64) error
+}
+
+type kvCursor struct {
+	kv  clientv3.KV
+	key string
+}
+
+// NewKVCursor creates a Cursor that records the revision as the value of key.
+func NewKVCursor(kv clientv3.KV, key string) Cursor {
+	return &kvCursor{kv: kv, key: key}
+}
+
+func (c *kvCursor) Load(ctx context.Context) (int64, error) {
+	resp, err := c.kv.Get(ctx, c.key)
+	if err != nil || len(resp.Kvs) == 0 {
+		return 0, err
+	}
+	return strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+}
+
+func (c *kvCursor) Store(ctx context.Context, rev int64) error {
+	_, err := c.kv.Put(ctx, c.key, strconv.FormatInt(rev, 10))
+	return err
+}
+
+type fileCursor struct {
+	path string
+}
+
+// NewFileCursor creates a Cursor that records the revision in the file at path.
+func NewFileCursor(path string) Cursor {
+	return &fileCursor{path: path}
+}
+
+func (c *fileCursor) Load(ctx context.Context) (int64, error) {
+	b, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// Store writes the revision to a temporary file and renames it over the
+// cursor file, so a crash leaves either the old or the new revision.
+func (c *fileCursor) Store(ctx context.Context, rev int64) error {
+	tmp := c.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	if _, err = f.WriteString(strconv.FormatInt(rev, 10)); err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, c.path); err != nil {
+		return err
+	}
+	dir, err := fileutil.OpenDir(filepath.Dir(c.path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return fileutil.Fsync(dir)
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileCursor(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "changefeed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := NewFileCursor(filepath.Join(dir, "cursor"))
+	if rev, lerr := c.Load(context.TODO()); lerr != nil || rev != 0 {
+		t.Fatalf("rev, err = %d, %v, want 0, nil", rev, lerr)
+	}
+	for _, wrev := range []int64{5, 12} {
+		if err = c.Store(context.TODO(), wrev); err != nil {
+			t.Fatal(err)
+		}
+		// a new cursor reads what the old one stored
+		rev, lerr := NewFileCursor(filepath.Join(dir, "cursor")).Load(context.TODO())
+		if lerr != nil || rev != wrev {
+			t.Fatalf("rev, err = %d, %v, want %d, nil", rev, lerr, wrev)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changefeed exports the events of an etcd key range to external
+// systems, so they need not each hold their own watches.
+//
+// A feed watches a prefix from the revision recorded by its cursor, hands
+// the events of each revision to a sink in revision order, and advances the
+// cursor once the sink accepts them. A restarted feed resumes from the
+// cursor, so sinks see each event at least once.
+//
+// First, create a client:
+//
+//	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{"localhost:2379"}})
+//	if err != nil {
+//		// handle error!
+//	}
+//
+// Next, create a feed that posts the events under "app/" to a webhook, with
+// its cursor kept in etcd outside of the watched prefix:
+//
+//	f := changefeed.New(cli, changefeed.Config{
+//		Prefix: "app/",
+//		Sink:   changefeed.NewWebhookSink("http://localhost:8080/events", nil),
+//		Cursor: changefeed.NewKVCursor(cli, "changefeed/app"),
+//	})
+//
+// Finally, run the feed until it fails or the context is canceled:
+//
+//	if err := f.Run(context.TODO()); err != nil {
+//		// handle error!
+//	}
+//
+package changefeed
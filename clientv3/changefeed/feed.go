@@ -0,0 +1,133 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+const defaultRetryInterval = time.Second
+
+// ErrCompacted is returned by Run when the revision after the cursor was
+// compacted away, so the events since the cursor can no longer be delivered.
+var ErrCompacted = errors.New("changefeed: cursor revision compacted")
+
+// Config configures a Feed.
+type Config struct {
+	// Prefix is the key prefix to export the events of. An empty prefix
+	// exports the events of all keys.
+	Prefix string
+	// Sink receives the events.
+	Sink Sink
+	// Cursor records the revision up to which the sink accepted events. A
+	// cursor kept in etcd should be outside of Prefix, or its own updates
+	// are exported.
+	Cursor Cursor
+	// RetryInterval is the wait before resending events the sink failed to
+	// accept. Defaults to one second.
+	RetryInterval time.Duration
+}
+
+// Feed delivers the events of a key prefix to a sink.
+type Feed struct {
+	c   *clientv3.Client
+	cfg Config
+}
+
+// New creates a Feed that watches through c.
+func New(c *clientv3.Client, cfg Config) *Feed {
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	return &Feed{c: c, cfg: cfg}
+}
+
+// Run delivers events until ctx is canceled or an error that retrying cannot
+// resolve, such as ErrCompacted or a cursor failure, occurs. A feed whose
+// cursor has no revision yet starts from the current revision.
+func (f *Feed) Run(ctx context.Context) error {
+	rev, err := f.cfg.Cursor.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if rev == 0 {
+		resp, gerr := f.c.Get(ctx, "foo")
+		if gerr != nil {
+			return gerr
+		}
+		rev = resp.Header.Revision
+		if err = f.cfg.Cursor.Store(ctx, rev); err != nil {
+			return err
+		}
+	}
+
+	for {
+		// cancel the watch on leaving, since the watch chan is not drained
+		wctx, wcancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+		rev, err = f.deliver(wctx, rev)
+		wcancel()
+		if err != nil {
+			return err
+		}
+		// the watch closed on losing its leader; wait before reopening it
+		select {
+		case <-time.After(f.cfg.RetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// deliver watches from rev+1 and hands the events to the sink until the
+// watch closes, returning the revision the cursor is at.
+func (f *Feed) deliver(ctx context.Context, rev int64) (int64, error) {
+	opts := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithRev(rev + 1), clientv3.WithPrevKV()}
+	for wr := range f.c.Watch(ctx, f.cfg.Prefix, opts...) {
+		if wr.CompactRevision != 0 {
+			return rev, ErrCompacted
+		}
+		if len(wr.Events) == 0 {
+			// progress notifications and errors; a failed watch is reopened
+			continue
+		}
+		if err := f.send(ctx, wr.Events); err != nil {
+			return rev, err
+		}
+		rev = wr.Events[len(wr.Events)-1].Kv.ModRevision
+		if err := f.cfg.Cursor.Store(ctx, rev); err != nil {
+			return rev, err
+		}
+	}
+	return rev, nil
+}
+
+// send hands evs to the sink until it accepts them or ctx is canceled.
+func (f *Feed) send(ctx context.Context, evs []*clientv3.Event) error {
+	for {
+		err := f.cfg.Sink.Send(ctx, evs)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-time.After(f.cfg.RetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+// Sink receives the events of a feed.
+type Sink interface {
+	// Send delivers the events of one or more whole revisions, in revision
+	// order. Events the sink failed to accept are sent again, as are the
+	// events after the cursor when a feed restarts, so sinks should
+	// tolerate duplicates of events they already accepted.
+	Send(ctx context.Context, evs []*clientv3.Event) error
+}
+
+// Event is the JSON encoding of an event sent to external systems. Keys and
+// values are base64 encoded, as in the JSON gateway.
+type Event struct {
+	Type           string `json:"type"`
+	Key            []byte `json:"key"`
+	Value          []byte `json:"value,omitempty"`
+	CreateRevision int64  `json:"create_revision,omitempty"`
+	ModRevision    int64  `json:"mod_revision"`
+	Version        int64  `json:"version,omitempty"`
+	Lease          int64  `json:"lease,omitempty"`
+	PrevValue      []byte `json:"prev_value,omitempty"`
+}
+
+// NewEvent converts a watch event into an Event.
+func NewEvent(ev *clientv3.Event) Event {
+	e := Event{
+		Type:           ev.Type.String(),
+		Key:            ev.Kv.Key,
+		Value:          ev.Kv.Value,
+		CreateRevision: ev.Kv.CreateRevision,
+		ModRevision:    ev.Kv.ModRevision,
+		Version:        ev.Kv.Version,
+		Lease:          ev.Kv.Lease,
+	}
+	if ev.PrevKv != nil {
+		e.PrevValue = ev.PrevKv.Value
+	}
+	return e
+}
+
+// webhookRequest is the body posted to a webhook.
+type webhookRequest struct {
+	Events []Event `json:"events"`
+}
+
+type webhookSink struct {
+	url string
+	hc  *http.Client
+}
+
+// NewWebhookSink creates a Sink that posts the events of each Send to url as
+// a JSON object with an "events" array, and takes any 2xx status as accepted.
+// A nil hc uses http.DefaultClient.
+func NewWebhookSink(url string, hc *http.Client) Sink {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &webhookSink{url: url, hc: hc}
+}
+
+func (s *webhookSink) Send(ctx context.Context, evs []*clientv3.Event) error {
+	wr := webhookRequest{Events: make([]Event, len(evs))}
+	for i, ev := range evs {
+		wr.Events[i] = NewEvent(ev)
+	}
+	b, err := json.Marshal(&wr)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("changefeed: webhook %s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// Producer publishes messages to a Kafka topic. It is satisfied by a thin
+// wrapper around the synchronous producer of any Kafka client.
+type Producer interface {
+	// Produce publishes a message and returns once the brokers acknowledge it.
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+type kafkaSink struct {
+	p     Producer
+	topic string
+}
+
+// NewKafkaSink creates a Sink that publishes each event to topic through p,
+// with the etcd key as the message key, so the events of a key stay in order
+// within its partition, and the JSON encoded Event as the message value.
+func NewKafkaSink(p Producer, topic string) Sink {
+	return &kafkaSink{p: p, topic: topic}
+}
+
+func (s *kafkaSink) Send(ctx context.Context, evs []*clientv3.Event) error {
+	for _, ev := range evs {
+		b, err := json.Marshal(NewEvent(ev))
+		if err != nil {
+			return err
+		}
+		if err = s.p.Produce(ctx, s.topic, ev.Kv.Key, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
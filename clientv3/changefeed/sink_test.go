@@ -0,0 +1,96 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+var testEvents = []*clientv3.Event{
+	{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), Value: []byte("1"), CreateRevision: 2, ModRevision: 2, Version: 1}},
+	{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("b"), ModRevision: 3}, PrevKv: &mvccpb.KeyValue{Key: []byte("b"), Value: []byte("2")}},
+}
+
+var testJSONEvents = []Event{
+	{Type: "PUT", Key: []byte("a"), Value: []byte("1"), CreateRevision: 2, ModRevision: 2, Version: 1},
+	{Type: "DELETE", Key: []byte("b"), ModRevision: 3, PrevValue: []byte("2")},
+}
+
+func TestWebhookSink(t *testing.T) {
+	status := http.StatusOK
+	var got webhookRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSink(srv.URL, nil)
+	if err := s.Send(context.TODO(), testEvents); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Events, testJSONEvents) {
+		t.Fatalf("events = %+v, want %+v", got.Events, testJSONEvents)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := s.Send(context.TODO(), testEvents); err == nil {
+		t.Fatalf("expected error on status %d", status)
+	}
+}
+
+type recordingProducer struct {
+	topics, keys []string
+	values       [][]byte
+}
+
+func (p *recordingProducer) Produce(ctx context.Context, topic string, key, value []byte) error {
+	p.topics = append(p.topics, topic)
+	p.keys = append(p.keys, string(key))
+	p.values = append(p.values, value)
+	return nil
+}
+
+func TestKafkaSink(t *testing.T) {
+	p := &recordingProducer{}
+	if err := NewKafkaSink(p, "etcd").Send(context.TODO(), testEvents); err != nil {
+		t.Fatal(err)
+	}
+	if wtopics := []string{"etcd", "etcd"}; !reflect.DeepEqual(p.topics, wtopics) {
+		t.Errorf("topics = %v, want %v", p.topics, wtopics)
+	}
+	if wkeys := []string{"a", "b"}; !reflect.DeepEqual(p.keys, wkeys) {
+		t.Errorf("keys = %v, want %v", p.keys, wkeys)
+	}
+	for i, v := range p.values {
+		var ev Event
+		if err := json.Unmarshal(v, &ev); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ev, testJSONEvents[i]) {
+			t.Errorf("#%d: event = %+v, want %+v", i, ev, testJSONEvents[i])
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -57,6 +58,10 @@ type Client struct {
 	creds    *credentials.TransportCredentials
 	balancer *healthBalancer
 	mu       sync.Mutex
+	// hedger is nil unless reads are hedged.
+	hedger *hedger
+	// zoneRouter is nil unless requests are routed by zone.
+	zoneRouter *zoneRouter
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -96,6 +101,12 @@ func (c *Client) Close() error {
 	c.cancel()
 	c.Watcher.Close()
 	c.Lease.Close()
+	if c.hedger != nil {
+		c.hedger.Close()
+	}
+	if c.zoneRouter != nil {
+		c.zoneRouter.Close()
+	}
 	if c.conn != nil {
 		return toErr(c.ctx, c.conn.Close())
 	}
@@ -109,6 +120,8 @@ func (c *Client) Ctx() context.Context { return c.ctx }
 
 // Endpoints lists the registered endpoints for the client.
 func (c *Client) Endpoints() (eps []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	// copy the slice; protect original endpoints from being changed
 	eps = make([]string, len(c.cfg.Endpoints))
 	copy(eps, c.cfg.Endpoints)
@@ -124,6 +137,7 @@ func (c *Client) SetEndpoints(eps ...string) {
 }
 
 // Sync synchronizes client's endpoints with the known endpoints from the etcd membership.
+// Members not yet started have no endpoints; if no member has any, the endpoints are kept.
 func (c *Client) Sync(ctx context.Context) error {
 	mresp, err := c.MemberList(ctx)
 	if err != nil {
@@ -133,6 +147,12 @@ func (c *Client) Sync(ctx context.Context) error {
 	for _, m := range mresp.Members {
 		eps = append(eps, m.ClientURLs...)
 	}
+	if len(eps) == 0 {
+		return ErrNoAvailableEndpoints
+	}
+	if reflect.DeepEqual(eps, c.Endpoints()) {
+		return nil
+	}
 	c.SetEndpoints(eps...)
 	return nil
 }
@@ -348,6 +368,30 @@ func WithRequireLeader(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
+// WithOpID tags client requests with an operation ID. Servers running with
+// an operation log record the ID with each request, so external checkers
+// can match the server's history against the client's.
+func WithOpID(ctx context.Context, id string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md[rpctypes.MetadataOpIDKey] = []string{id}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// WithWatchAck makes watchers created with the context acknowledge each
+// response once it is received from the watch channel. Servers configured
+// with a watch ack window then stop sending responses that are not yet
+// acknowledged past the window, bounding the responses buffered for slow
+// receivers. Such watchers share a grpc stream separate from others, and
+// each has its own window: a watcher whose channel is not received from
+// holds back none of the other watchers of the context.
+func WithWatchAck(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md[rpctypes.MetadataWatchAckKey] = []string{rpctypes.MetadataWatchAck}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 func newClient(cfg *Config) (*Client, error) {
 	if cfg == nil {
 		cfg = &Config{}
@@ -415,6 +459,13 @@ func newClient(cfg *Config) (*Client, error) {
 		}
 	}
 
+	if cfg.HedgeDelay > 0 {
+		client.hedger = newHedger(client, cfg.HedgeDelay)
+	}
+	if cfg.Zone != "" {
+		client.zoneRouter = newZoneRouter(client, cfg.Zone, cfg.EndpointZones)
+	}
+
 	client.Cluster = NewCluster(client)
 	client.KV = NewKV(client)
 	client.Lease = NewLease(client)
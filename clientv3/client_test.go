@@ -23,6 +23,8 @@ import (
 
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/pkg/testutil"
+
+	"google.golang.org/grpc/metadata"
 )
 
 func TestDialCancel(t *testing.T) {
@@ -151,3 +153,19 @@ func TestIsHaltErr(t *testing.T) {
 		t.Errorf("cancel on context should be Halted")
 	}
 }
+
+func TestWithOpID(t *testing.T) {
+	ctx := WithOpID(WithRequireLeader(context.TODO()), "a")
+	ctx = WithOpID(ctx, "b")
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		t.Fatal("expected outgoing metadata")
+	}
+	if ids := md[rpctypes.MetadataOpIDKey]; len(ids) != 1 || ids[0] != "b" {
+		t.Errorf("op IDs = %v, want [b]", ids)
+	}
+	if v := md[rpctypes.MetadataRequireLeaderKey]; len(v) != 1 || v[0] != rpctypes.MetadataHasLeader {
+		t.Errorf("require leader = %v, want [%s]", v, rpctypes.MetadataHasLeader)
+	}
+}
@@ -16,6 +16,7 @@ package clientv3util_test
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/coreos/etcd/clientv3"
@@ -64,3 +65,25 @@ func ExampleKeyExists_delete() {
 		log.Fatal(err)
 	}
 }
+
+func ExampleGetMulti() {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints: []string{"127.0.0.1:2379"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cli.Close()
+
+	// read the keys at the same revision, so a concurrent update
+	// of both keys is either seen in full or not at all
+	resp, err := clientv3util.GetMulti(context.Background(), cli, []string{"/config/host", "/config/port"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range resp.Responses {
+		for _, kv := range r.Kvs {
+			fmt.Printf("%s=%s at revision %d\n", kv.Key, kv.Value, resp.Revision)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3util
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+// MaxGetMultiOps is the most keys GetMulti reads in one request. It matches
+// the default --max-txn-ops of the server; lower it for servers configured
+// with fewer operations per txn.
+var MaxGetMultiOps = 128
+
+// MultiGetResponse holds the responses of GetMulti.
+type MultiGetResponse struct {
+	// Revision is the store revision every key was read at.
+	Revision int64
+	// Responses holds the response for each key, in the order of the keys.
+	Responses []*clientv3.GetResponse
+}
+
+// GetMulti gets the given keys at a single revision, so a view assembled from
+// scattered keys is never torn by a concurrent write. The keys are read in a
+// txn; if there are more than MaxGetMultiOps, the remaining keys are read in
+// further txns at the revision of the first. The options apply to every key,
+// e.g. WithPrefix reads each key as a prefix and WithRev reads every key at
+// the given revision instead of the current one.
+func GetMulti(ctx context.Context, kv clientv3.KV, keys []string, opts ...clientv3.OpOption) (*MultiGetResponse, error) {
+	resp := &MultiGetResponse{Responses: make([]*clientv3.GetResponse, 0, len(keys))}
+	for len(keys) > 0 || resp.Revision == 0 {
+		n := len(keys)
+		if n > MaxGetMultiOps {
+			n = MaxGetMultiOps
+		}
+		ops := make([]clientv3.Op, n)
+		for i, k := range keys[:n] {
+			ops[i] = clientv3.OpGet(k, opts...)
+			if resp.Revision != 0 {
+				ops[i] = clientv3.OpGet(k, append(opts, clientv3.WithRev(resp.Revision))...)
+			}
+		}
+		txnResp, err := kv.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Revision == 0 {
+			resp.Revision = txnResp.Header.Revision
+			if op := clientv3.OpGet("", opts...); op.Rev() > 0 {
+				resp.Revision = op.Rev()
+			}
+		}
+		for _, r := range txnResp.Responses {
+			resp.Responses = append(resp.Responses, (*clientv3.GetResponse)(r.GetResponseRange()))
+		}
+		keys = keys[n:]
+	}
+	return resp, nil
+}
@@ -50,6 +50,19 @@ type Config struct {
 	// Password is a password for authentication.
 	Password string `json:"password"`
 
+	// HedgeDelay is the time after which a serializable read not yet answered
+	// by the pinned endpoint is also sent to another endpoint, taking the
+	// first response. 0 disables hedging.
+	HedgeDelay time.Duration `json:"hedge-delay"`
+
+	// Zone is the zone the client runs in. When set, serializable reads are
+	// sent to an endpoint tagged with this zone in EndpointZones, and writes
+	// and linearizable reads to the leader's endpoint.
+	Zone string `json:"zone"`
+
+	// EndpointZones maps endpoints to the zones they run in.
+	EndpointZones map[string]string `json:"endpoint-zones"`
+
 	// RejectOldCluster when set will refuse to create a client against an outdated cluster.
 	RejectOldCluster bool `json:"reject-old-cluster"`
 
@@ -0,0 +1,143 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+
+	"google.golang.org/grpc"
+)
+
+// hedger holds the connections hedged reads are sent over, one for each
+// endpoint besides the pinned one a hedged read was sent to.
+type hedger struct {
+	c     *Client
+	delay time.Duration
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newHedger(c *Client, delay time.Duration) *hedger {
+	return &hedger{c: c, delay: delay, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// conn returns a connection to an endpoint other than the pinned one,
+// or nil if there is no other endpoint.
+func (h *hedger) conn() (*grpc.ClientConn, error) {
+	pinned := h.c.balancer.pinned()
+	eps := h.c.Endpoints()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ep, conn := range h.conns {
+		if !hasEndpoint(eps, ep) {
+			conn.Close()
+			delete(h.conns, ep)
+		}
+	}
+	for _, ep := range eps {
+		if getHost(ep) == pinned {
+			continue
+		}
+		if conn, ok := h.conns[ep]; ok {
+			return conn, nil
+		}
+		conn, err := h.c.dial(ep)
+		if err != nil {
+			return nil, err
+		}
+		h.conns[ep] = conn
+		return conn, nil
+	}
+	return nil, nil
+}
+
+func hasEndpoint(eps []string, ep string) bool {
+	for _, e := range eps {
+		if e == ep {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *hedger) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ep, conn := range h.conns {
+		conn.Close()
+		delete(h.conns, ep)
+	}
+}
+
+// hedgeKVClient sends a serializable range still unanswered after the
+// hedge delay to a second endpoint as well, taking the first response.
+type hedgeKVClient struct {
+	pb.KVClient
+	h *hedger
+}
+
+func (hkv *hedgeKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	if !in.Serializable {
+		return hkv.KVClient.Range(ctx, in, opts...)
+	}
+
+	type rangeResult struct {
+		resp *pb.RangeResponse
+		err  error
+	}
+	// cancel the slower request once a response is taken
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resc := make(chan rangeResult, 2)
+	go func() {
+		resp, err := hkv.KVClient.Range(ctx, in, opts...)
+		resc <- rangeResult{resp, err}
+	}()
+
+	timer := time.NewTimer(hkv.h.delay)
+	defer timer.Stop()
+	select {
+	case res := <-resc:
+		return res.resp, res.err
+	case <-timer.C:
+	}
+
+	conn, err := hkv.h.conn()
+	if conn == nil || err != nil {
+		if err != nil && logger.V(4) {
+			logger.Infof("clientv3/hedge: cannot hedge range (%v)", err)
+		}
+		res := <-resc
+		return res.resp, res.err
+	}
+	go func() {
+		resp, err := pb.NewKVClient(conn).Range(ctx, in, opts...)
+		resc <- rangeResult{resp, err}
+	}()
+
+	// take the first success, or the last error if both fail
+	res := <-resc
+	if res.err == nil {
+		return res.resp, nil
+	}
+	res = <-resc
+	return res.resp, res.err
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3/backup"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// TestBackupRestore ensures a backup restores the key-value state as of
+// any revision archived after its base snapshot.
+func TestBackupRestore(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sink := backup.NewDirSink(dir)
+
+	c := clus.Client(0)
+	if _, err = c.Put(context.TODO(), "foo", "bar1"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := backup.New(c, backup.Config{Sink: sink, SegmentInterval: 10 * time.Millisecond})
+	donec := make(chan error, 1)
+	go func() { donec <- b.Run(ctx) }()
+
+	// base snapshot at revision 2
+	waitBackupObject(t, sink, "snapshot-")
+
+	if _, err = c.Put(context.TODO(), "foo", "bar2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Put(context.TODO(), "foo2", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Delete(context.TODO(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+	// segments ending at revision 5
+	waitBackupObject(t, sink, "000000000005")
+
+	cancel()
+	if err = <-donec; err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	tests := []struct {
+		rev int64
+
+		wrev int64
+		wkvs map[string]string
+	}{
+		{3, 3, map[string]string{"foo": "bar2"}},
+		{4, 4, map[string]string{"foo": "bar2", "foo2": "bar"}},
+		{0, 5, map[string]string{"foo2": "bar"}},
+	}
+	for i, tt := range tests {
+		dbPath := filepath.Join(dir, "restored.db")
+		if err = backup.Restore(context.TODO(), sink, dbPath, tt.rev); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		be := backend.NewDefaultBackend(dbPath)
+		s := mvcc.NewStore(be, &lease.FakeLessor{}, nil)
+		r, err := s.Range(context.TODO(), []byte("foo"), []byte("fop"), mvcc.RangeOptions{})
+		s.Close()
+		be.Close()
+		os.Remove(dbPath)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		if r.Rev != tt.wrev {
+			t.Errorf("#%d: rev = %d, want %d", i, r.Rev, tt.wrev)
+		}
+		kvs := make(map[string]string)
+		for _, kv := range r.KVs {
+			kvs[string(kv.Key)] = string(kv.Value)
+		}
+		if len(kvs) != len(tt.wkvs) {
+			t.Errorf("#%d: kvs = %v, want %v", i, kvs, tt.wkvs)
+		}
+		for k, v := range tt.wkvs {
+			if kvs[k] != v {
+				t.Errorf("#%d: kvs = %v, want %v", i, kvs, tt.wkvs)
+			}
+		}
+	}
+
+	// no base snapshot precedes revision 1
+	if err = backup.Restore(context.TODO(), sink, filepath.Join(dir, "restored.db"), 1); err != backup.ErrNoSnapshot {
+		t.Fatalf("expected %v, got %v", backup.ErrNoSnapshot, err)
+	}
+}
+
+func waitBackupObject(t *testing.T, sink backup.Sink, substr string) {
+	for i := 0; i < 100; i++ {
+		names, err := sink.List(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			if strings.Contains(name, substr) {
+				return
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for backup object %q", substr)
+}
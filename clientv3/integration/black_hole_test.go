@@ -67,3 +67,110 @@ func TestBlackholePutWithoutKeealiveEnabled(t *testing.T) {
 		t.Errorf("put failed with error %v", err)
 	}
 }
+
+// TestBlackholeGetHedged ensures a serializable get is answered by another
+// endpoint when the pinned endpoint does not respond within the hedge delay.
+func TestBlackholeGetHedged(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{
+		Size:               2,
+		SkipCreatingClient: true,
+	})
+	defer clus.Terminate(t)
+
+	ccfg := clientv3.Config{
+		Endpoints:   []string{clus.Members[0].GRPCAddr()},
+		DialTimeout: 1 * time.Second,
+		HedgeDelay:  100 * time.Millisecond,
+	}
+	cli, err := clientv3.New(ccfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	// wait for ep[0] to be pinned
+	waitPinReady(t, cli)
+
+	if _, err = cli.Put(context.TODO(), "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+
+	cli.SetEndpoints(clus.Members[0].GRPCAddr(), clus.Members[1].GRPCAddr())
+	clus.Members[0].Blackhole()
+	defer clus.Members[0].Unblackhole()
+
+	// the bridge may pass through one more request before it blackholes
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		resp, err := cli.Get(ctx, "foo", clientv3.WithSerializable())
+		cancel()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+			t.Fatalf("#%d: got %+v, want foo=bar", i, resp.Kvs)
+		}
+	}
+}
+
+// TestBlackholeZoneRouting ensures serializable gets are sent to the endpoint
+// in the client's zone and puts to the leader, bypassing a blackholed
+// pinned endpoint.
+func TestBlackholeZoneRouting(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{
+		Size:               3,
+		SkipCreatingClient: true,
+	})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	pinned, local := clus.Members[(lead+1)%3], clus.Members[(lead+2)%3]
+	leader := clus.Members[lead]
+
+	ccfg := clientv3.Config{
+		Endpoints:   []string{pinned.GRPCAddr()},
+		DialTimeout: 1 * time.Second,
+		Zone:        "b",
+		EndpointZones: map[string]string{
+			pinned.GRPCAddr(): "a",
+			local.GRPCAddr():  "b",
+			leader.GRPCAddr(): "a",
+		},
+	}
+	cli, err := clientv3.New(ccfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	// wait for ep[0] to be pinned
+	waitPinReady(t, cli)
+
+	cli.SetEndpoints(pinned.GRPCAddr(), local.GRPCAddr(), leader.GRPCAddr())
+	pinned.Blackhole()
+	defer pinned.Unblackhole()
+
+	// the bridge may pass through one more request before it blackholes
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err = cli.Put(ctx, "foo", "bar")
+		cancel()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		resp, err := cli.Get(ctx, "foo", clientv3.WithSerializable())
+		cancel()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+			t.Fatalf("#%d: got %+v, want foo=bar", i, resp.Kvs)
+		}
+	}
+}
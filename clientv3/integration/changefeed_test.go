@@ -0,0 +1,159 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/changefeed"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// recordingSink records the keys sent to it once its first fails sends failed.
+type recordingSink struct {
+	mu    sync.Mutex
+	keys  []string
+	fails int
+	sentc chan struct{}
+}
+
+func newRecordingSink(fails int) *recordingSink {
+	return &recordingSink{fails: fails, sentc: make(chan struct{}, 100)}
+}
+
+func (s *recordingSink) Send(ctx context.Context, evs []*clientv3.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fails > 0 {
+		s.fails--
+		return fmt.Errorf("sink unavailable")
+	}
+	for _, ev := range evs {
+		s.keys = append(s.keys, string(ev.Kv.Key))
+	}
+	s.sentc <- struct{}{}
+	return nil
+}
+
+func (s *recordingSink) waitKeys(t *testing.T, n int) []string {
+	for {
+		s.mu.Lock()
+		keys := s.keys
+		s.mu.Unlock()
+		if len(keys) >= n {
+			return keys
+		}
+		select {
+		case <-s.sentc:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %d keys, got %v", n, keys)
+		}
+	}
+}
+
+// waitCursor waits for cursor to reach rev.
+func waitCursor(t *testing.T, cursor changefeed.Cursor, rev int64) {
+	for i := 0; i < 500; i++ {
+		crev, err := cursor.Load(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crev >= rev {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for cursor to reach %d", rev)
+}
+
+func TestChangeFeed(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	cursor := changefeed.NewKVCursor(cli, "feed/cursor")
+	sink := newRecordingSink(1)
+	ctx, cancel := context.WithCancel(context.TODO())
+	f := changefeed.New(cli, changefeed.Config{Prefix: "app/", Sink: sink, Cursor: cursor, RetryInterval: 10 * time.Millisecond})
+	donec := make(chan error, 1)
+	go func() { donec <- f.Run(ctx) }()
+
+	// wait for the feed to record its starting revision
+	waitCursor(t, cursor, 1)
+	var resp *clientv3.PutResponse
+	var err error
+	for _, k := range []string{"app/a", "other", "app/b"} {
+		if resp, err = cli.Put(context.TODO(), k, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if keys := sink.waitKeys(t, 2); fmt.Sprint(keys) != "[app/a app/b]" {
+		t.Fatalf("keys = %v, want [app/a app/b]", keys)
+	}
+	waitCursor(t, cursor, resp.Header.Revision)
+	cancel()
+	if err = <-donec; err != context.Canceled {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+
+	// a restarted feed resumes from its cursor
+	if _, err = cli.Put(context.TODO(), "app/c", "v"); err != nil {
+		t.Fatal(err)
+	}
+	sink = newRecordingSink(0)
+	ctx, cancel = context.WithCancel(context.TODO())
+	defer cancel()
+	f = changefeed.New(cli, changefeed.Config{Prefix: "app/", Sink: sink, Cursor: cursor})
+	go func() { donec <- f.Run(ctx) }()
+	if keys := sink.waitKeys(t, 1); fmt.Sprint(keys) != "[app/c]" {
+		t.Fatalf("keys = %v, want [app/c]", keys)
+	}
+}
+
+func TestChangeFeedCompacted(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	cursor := changefeed.NewKVCursor(cli, "feed/cursor")
+	if err := cursor.Store(context.TODO(), 1); err != nil {
+		t.Fatal(err)
+	}
+	var resp *clientv3.PutResponse
+	var err error
+	for i := 0; i < 3; i++ {
+		if resp, err = cli.Put(context.TODO(), "app/a", "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = cli.Compact(context.TODO(), resp.Header.Revision); err != nil {
+		t.Fatal(err)
+	}
+
+	f := changefeed.New(cli, changefeed.Config{Prefix: "app/", Sink: newRecordingSink(0), Cursor: cursor})
+	if err = f.Run(context.TODO()); err != changefeed.ErrCompacted {
+		t.Fatalf("err = %v, want %v", err, changefeed.ErrCompacted)
+	}
+}
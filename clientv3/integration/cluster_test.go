@@ -17,8 +17,11 @@ package integration
 import (
 	"context"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/integration"
 	"github.com/coreos/etcd/pkg/testutil"
 	"github.com/coreos/etcd/pkg/types"
@@ -126,3 +129,34 @@ func TestMemberUpdate(t *testing.T) {
 		t.Errorf("urls = %v, want %v", urls, resp.Members[0].PeerURLs)
 	}
 }
+
+func TestMemberAutoSync(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 2, SkipCreatingClient: true})
+	defer clus.Terminate(t)
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:        []string{clus.Members[0].GRPCAddr()},
+		AutoSyncInterval: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	var weps []string
+	for _, m := range clus.Members {
+		weps = append(weps, m.ClientURLs.StringSlice()...)
+	}
+	sort.Strings(weps)
+	for i := 0; i < 50; i++ {
+		eps := cli.Endpoints()
+		sort.Strings(eps)
+		if reflect.DeepEqual(eps, weps) {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("endpoints = %v, want %v", cli.Endpoints(), weps)
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/clientv3util"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+func TestGetMulti(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	defer func(n int) { clientv3util.MaxGetMultiOps = n }(clientv3util.MaxGetMultiOps)
+	clientv3util.MaxGetMultiOps = 2
+
+	kv := clus.Client(0)
+	ctx := context.TODO()
+	var keys []string
+	for i := 0; i < 5; i++ {
+		keys = append(keys, fmt.Sprintf("key%d", i))
+		if _, err := kv.Put(ctx, keys[i], "a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	keys = append(keys, "missing")
+	presp, err := kv.Put(ctx, "key0", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := clientv3util.GetMulti(ctx, kv, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Revision != presp.Header.Revision {
+		t.Errorf("revision = %d, want %d", resp.Revision, presp.Header.Revision)
+	}
+	if len(resp.Responses) != len(keys) {
+		t.Fatalf("got %d responses, want %d", len(resp.Responses), len(keys))
+	}
+	for i, r := range resp.Responses {
+		if i == len(keys)-1 {
+			if len(r.Kvs) != 0 {
+				t.Errorf("#%d: kvs = %+v, want none", i, r.Kvs)
+			}
+			continue
+		}
+		if len(r.Kvs) != 1 || string(r.Kvs[0].Key) != keys[i] {
+			t.Fatalf("#%d: kvs = %+v, want %q", i, r.Kvs, keys[i])
+		}
+		w := "a"
+		if i == 0 {
+			w = "b"
+		}
+		if string(r.Kvs[0].Value) != w {
+			t.Errorf("#%d: value = %q, want %q", i, r.Kvs[0].Value, w)
+		}
+	}
+
+	// every chunk is read at the given revision
+	resp, err = clientv3util.GetMulti(ctx, kv, keys, clientv3.WithRev(presp.Header.Revision-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Revision != presp.Header.Revision-1 {
+		t.Errorf("revision = %d, want %d", resp.Revision, presp.Header.Revision-1)
+	}
+	if v := string(resp.Responses[0].Kvs[0].Value); v != "a" {
+		t.Errorf("value = %q, want %q", v, "a")
+	}
+	for i, r := range resp.Responses[:len(keys)-1] {
+		if len(r.Kvs) != 1 {
+			t.Errorf("#%d: kvs = %+v, want one key", i, r.Kvs)
+		}
+	}
+}
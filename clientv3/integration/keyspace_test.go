@@ -0,0 +1,156 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/keyspace"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// TestKeyspaceExportImport exports a prefix at a past revision and imports
+// it under another prefix with remapped leases.
+func TestKeyspaceExportImport(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := context.TODO()
+
+	lresp, err := cli.Grant(ctx, 100, clientv3.WithLeaseMetadata([]byte("m")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Put(ctx, "app/a", "1", clientv3.WithLease(lresp.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Put(ctx, "other", "1"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := cli.Put(ctx, "app/b", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev := resp.Header.Revision
+	// not in the export at rev
+	if _, err = cli.Put(ctx, "app/c", "3"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []keyspace.Format{keyspace.FormatProtobuf, keyspace.FormatJSON} {
+		var buf bytes.Buffer
+		eres, err := keyspace.Export(ctx, cli, &buf, keyspace.ExportConfig{Prefix: "app/", Revision: rev, Format: f})
+		if err != nil {
+			t.Fatalf("%v: %v", f, err)
+		}
+		if eres.Keys != 2 || eres.Leases != 1 || eres.Header.Revision != rev {
+			t.Fatalf("%v: export = %+v, want 2 keys and 1 lease at %d", f, eres, rev)
+		}
+
+		dst := "clone-" + f.String() + "/"
+		ires, err := keyspace.Import(ctx, cli, &buf, keyspace.ImportConfig{Prefix: dst, Format: f, Leases: keyspace.LeasesRemap})
+		if err != nil {
+			t.Fatalf("%v: %v", f, err)
+		}
+		if ires.Keys != 2 || len(ires.Leases) != 1 {
+			t.Fatalf("%v: import = %+v, want 2 keys and 1 lease", f, ires)
+		}
+		id := ires.Leases[lresp.ID]
+		if id == 0 || id == lresp.ID {
+			t.Fatalf("%v: lease %x remapped to %x", f, lresp.ID, id)
+		}
+
+		gresp, err := cli.Get(ctx, dst, clientv3.WithPrefix())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s string
+		for _, kv := range gresp.Kvs {
+			s += fmt.Sprintf("%s=%s/%x ", kv.Key, kv.Value, kv.Lease)
+		}
+		if want := fmt.Sprintf("%sa=1/%x %sb=2/0 ", dst, id, dst); s != want {
+			t.Fatalf("%v: keys = %q, want %q", f, s, want)
+		}
+		tresp, err := cli.TimeToLive(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tresp.GrantedTTL != 100 || string(tresp.Metadata) != "m" {
+			t.Fatalf("%v: lease = %+v, want granted ttl 100 and metadata %q", f, tresp, "m")
+		}
+	}
+}
+
+// TestKeyspaceImportDropsExpiredLeases skips the keys of leases that expired
+// before the export, unless leases are dropped.
+func TestKeyspaceImportDropsExpiredLeases(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := context.TODO()
+
+	lresp, err := cli.Grant(ctx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := cli.Put(ctx, "app/a", "1", clientv3.WithLease(lresp.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Revoke(ctx, lresp.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err = keyspace.Export(ctx, cli, &buf, keyspace.ExportConfig{Prefix: "app/", Revision: resp.Header.Revision}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	ires, err := keyspace.Import(ctx, cli, bytes.NewReader(b), keyspace.ImportConfig{Prefix: "remap/", Leases: keyspace.LeasesRemap})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ires.Keys != 0 || ires.Skipped != 1 {
+		t.Fatalf("import = %+v, want 0 keys and 1 skipped", ires)
+	}
+	ires, err = keyspace.Import(ctx, cli, bytes.NewReader(b), keyspace.ImportConfig{Prefix: "drop/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ires.Keys != 1 || ires.Skipped != 0 {
+		t.Fatalf("import = %+v, want 1 key and 0 skipped", ires)
+	}
+	gresp, err := cli.Get(ctx, "drop/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gresp.Kvs) != 1 || gresp.Kvs[0].Lease != 0 {
+		t.Fatalf("kvs = %+v, want drop/a without a lease", gresp.Kvs)
+	}
+
+	if _, err = keyspace.Import(ctx, cli, bytes.NewReader(nil), keyspace.ImportConfig{}); err != keyspace.ErrNoHeader {
+		t.Fatalf("err = %v, want %v", err, keyspace.ErrNoHeader)
+	}
+}
@@ -186,6 +186,102 @@ func TestKVPutWithIgnoreLease(t *testing.T) {
 	}
 }
 
+// TestKVPutWithExpireAt ensures a key put with an expiry is deleted once the
+// expiry passes, with a DELETE event, and keys not yet expired are kept.
+func TestKVPutWithExpireAt(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	wch := clus.Client(1).Watch(context.TODO(), "foo")
+
+	now := time.Now()
+	if _, err := kv.Put(context.TODO(), "bar", "v", clientv3.WithExpireAt(now.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kv.Put(context.TODO(), "foo", "v", clientv3.WithExpireAt(now.Add(time.Second))); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := kv.Get(context.TODO(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || resp.Kvs[0].ExpireAt != now.Add(time.Second).Unix() {
+		t.Fatalf("unexpected get response %+v", resp)
+	}
+
+	var evs []*clientv3.Event
+	for len(evs) < 2 {
+		select {
+		case wresp := <-wch:
+			evs = append(evs, wresp.Events...)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for the expired key to be deleted, got %+v", evs)
+		}
+	}
+	if evs[0].Type != mvccpb.PUT || evs[1].Type != mvccpb.DELETE {
+		t.Fatalf("expected put and delete events, got %+v", evs)
+	}
+
+	if resp, err = kv.Get(context.TODO(), "", clientv3.WithPrefix()); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Key) != "bar" {
+		t.Fatalf("expected only key bar, got %+v", resp.Kvs)
+	}
+}
+
+func TestKVDryRun(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	presp, err := kv.Put(context.TODO(), "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev := presp.Header.Revision
+
+	if presp, err = kv.Put(context.TODO(), "foo", "baz", clientv3.WithDryRun()); err != nil {
+		t.Fatal(err)
+	}
+	if presp.Header.Revision != rev+1 {
+		t.Fatalf("put revision = %d, want %d", presp.Header.Revision, rev+1)
+	}
+	dresp, err := kv.Delete(context.TODO(), "foo", clientv3.WithPrefix(), clientv3.WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 1 {
+		t.Fatalf("deleted = %d, want 1", dresp.Deleted)
+	}
+	op := clientv3.OpTxn(
+		[]clientv3.Cmp{clientv3.Compare(clientv3.Value("foo"), "=", "bar")},
+		[]clientv3.Op{clientv3.OpDelete("foo")},
+		nil,
+		clientv3.WithDryRun(),
+	)
+	tresp, err := kv.Do(context.TODO(), op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tresp.Txn().Succeeded || tresp.Txn().Header.Revision != rev+1 {
+		t.Fatalf("unexpected txn response %+v", tresp.Txn())
+	}
+
+	resp, err := kv.Get(context.TODO(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Revision != rev || len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+		t.Fatalf("store changed by dry runs: %+v", resp)
+	}
+}
+
 func TestKVPutWithRequireLeader(t *testing.T) {
 	defer testutil.AfterTest(t)
 
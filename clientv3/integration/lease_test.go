@@ -15,6 +15,7 @@
 package integration
 
 import (
+	"bytes"
 	"context"
 	"reflect"
 	"sort"
@@ -66,6 +67,71 @@ func TestLeaseGrant(t *testing.T) {
 	}
 }
 
+func TestLeaseGrantWithID(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lapi := clus.RandClient()
+
+	id := clientv3.LeaseID(0x1234abcd)
+	resp, err := lapi.Grant(context.Background(), 10, clientv3.WithLeaseID(id))
+	if err != nil {
+		t.Fatalf("failed to create lease %v", err)
+	}
+	if resp.ID != id {
+		t.Fatalf("lease ID = %x, want %x", resp.ID, id)
+	}
+
+	_, err = clus.RandClient().Grant(context.Background(), 10, clientv3.WithLeaseID(id))
+	if err != rpctypes.ErrLeaseExist {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrLeaseExist)
+	}
+}
+
+// TestLeaseGrantWithMetadata ensures the metadata of a lease is returned
+// by TimeToLive and Leases from every member.
+func TestLeaseGrantWithMetadata(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	md := []byte("scheduler@node-1")
+	resp, err := clus.RandClient().Grant(context.Background(), 10, clientv3.WithLeaseMetadata(md))
+	if err != nil {
+		t.Fatalf("failed to create lease %v", err)
+	}
+
+	for i := range clus.Members {
+		cli := clus.Client(i)
+		tresp, err := cli.TimeToLive(context.Background(), resp.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(tresp.Metadata, md) {
+			t.Errorf("#%d: TimeToLive metadata = %q, want %q", i, tresp.Metadata, md)
+		}
+		// linearized read so the member has applied the grant
+		if _, err = cli.Get(context.Background(), "abc"); err != nil {
+			t.Fatal(err)
+		}
+		lresp, err := cli.Leases(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lresp.Leases) != 1 || !bytes.Equal(lresp.Leases[0].Metadata, md) {
+			t.Errorf("#%d: leases = %+v, want one lease with metadata %q", i, lresp.Leases, md)
+		}
+	}
+
+	_, err = clus.RandClient().Grant(context.Background(), 10, clientv3.WithLeaseMetadata(make([]byte, 257)))
+	if err != rpctypes.ErrLeaseMetadataTooLarge {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrLeaseMetadataTooLarge)
+	}
+}
+
 func TestLeaseRevoke(t *testing.T) {
 	defer testutil.AfterTest(t)
 
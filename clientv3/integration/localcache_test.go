@@ -0,0 +1,143 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/localcache"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// runLocalCache opens the cache at path and runs it until the returned
+// func is called.
+func runLocalCache(t *testing.T, cli *clientv3.Client, path string) (*localcache.Cache, func()) {
+	c, err := localcache.Open(cli, localcache.Config{
+		Path:          path,
+		Prefixes:      []string{"app/"},
+		RetryInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	donec := make(chan error, 1)
+	go func() { donec <- c.Run(ctx) }()
+	return c, func() {
+		cancel()
+		if err := <-donec; err != context.Canceled {
+			t.Fatalf("err = %v, want %v", err, context.Canceled)
+		}
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// waitLocalCache waits for the cache to reach rev and returns its keys.
+func waitLocalCache(t *testing.T, c *localcache.Cache, rev int64) string {
+	for i := 0; i < 500; i++ {
+		crev, err := c.Revision("app/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crev >= rev {
+			kvs, err := c.List("app/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var s string
+			for _, kv := range kvs {
+				s += fmt.Sprintf("%s=%s ", kv.Key, kv.Value)
+			}
+			return s
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for cache to reach %d", rev)
+	return ""
+}
+
+func TestLocalCache(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	dir, err := ioutil.TempDir(os.TempDir(), "localcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache.db")
+
+	for _, k := range []string{"app/a", "other"} {
+		if _, err = cli.Put(context.TODO(), k, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, stop := runLocalCache(t, cli, path)
+	// loaded keys
+	if keys := waitLocalCache(t, c, 3); keys != "app/a=1 " {
+		t.Fatalf("keys = %q, want %q", keys, "app/a=1 ")
+	}
+	// watched keys
+	resp, err := cli.Put(context.TODO(), "app/b", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := waitLocalCache(t, c, resp.Header.Revision); keys != "app/a=1 app/b=1 " {
+		t.Fatalf("keys = %q, want %q", keys, "app/a=1 app/b=1 ")
+	}
+	stop()
+
+	// a reopened cache resumes from its revision
+	if _, err = cli.Delete(context.TODO(), "app/a"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err = cli.Put(context.TODO(), "app/c", "1"); err != nil {
+		t.Fatal(err)
+	}
+	c, stop = runLocalCache(t, cli, path)
+	if keys := waitLocalCache(t, c, resp.Header.Revision); keys != "app/b=1 app/c=1 " {
+		t.Fatalf("keys = %q, want %q", keys, "app/b=1 app/c=1 ")
+	}
+	stop()
+
+	// a reopened cache whose revision was compacted reloads its keys
+	if _, err = cli.Delete(context.TODO(), "app/b"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err = cli.Put(context.TODO(), "app/d", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Compact(context.TODO(), resp.Header.Revision); err != nil {
+		t.Fatal(err)
+	}
+	c, stop = runLocalCache(t, cli, path)
+	if keys := waitLocalCache(t, c, resp.Header.Revision); keys != "app/c=1 app/d=1 " {
+		t.Fatalf("keys = %q, want %q", keys, "app/c=1 app/d=1 ")
+	}
+	stop()
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/clientv3/namespace"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/integration"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/coreos/etcd/pkg/testutil"
@@ -55,6 +56,53 @@ func TestNamespacePutGet(t *testing.T) {
 	}
 }
 
+// TestNamespaceRangeAll ensures prefix and from-key requests on the empty key
+// cover exactly the keys inside the namespace.
+func TestNamespaceRangeAll(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(c.KV, "foo/")
+
+	for _, k := range []string{"fo", "foo/a", "foo/b", "fop"} {
+		if _, err := c.Put(context.TODO(), k, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, opt := range []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithFromKey()} {
+		resp, err := nsKV.Get(context.TODO(), "", opt)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if len(resp.Kvs) != 2 || string(resp.Kvs[0].Key) != "a" || string(resp.Kvs[1].Key) != "b" {
+			t.Fatalf("#%d: expected keys [a b], got %+v", i, resp.Kvs)
+		}
+	}
+
+	if _, err := nsKV.Get(context.TODO(), ""); err != rpctypes.ErrEmptyKey {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrEmptyKey, err)
+	}
+
+	dresp, err := nsKV.Delete(context.TODO(), "", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 2 {
+		t.Fatalf("expected 2 deleted keys, got %d", dresp.Deleted)
+	}
+	resp, err := c.Get(context.TODO(), "f", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 2 {
+		t.Fatalf("expected keys outside namespace to remain, got %+v", resp.Kvs)
+	}
+}
+
 func TestNamespaceWatch(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -0,0 +1,174 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/replicator"
+	"github.com/coreos/etcd/integration"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// runReplicator runs r until the returned cancel func is called.
+func runReplicator(t *testing.T, r *replicator.Replicator) (cancel func()) {
+	ctx, cancelCtx := context.WithCancel(context.TODO())
+	donec := make(chan error, 1)
+	go func() { donec <- r.Run(ctx) }()
+	return func() {
+		cancelCtx()
+		if err := <-donec; err != context.Canceled {
+			t.Fatalf("err = %v, want %v", err, context.Canceled)
+		}
+	}
+}
+
+// waitReplicated waits for r to replicate up to rev.
+func waitReplicated(t *testing.T, r *replicator.Replicator, rev int64) {
+	for i := 0; i < 500; i++ {
+		if r.Rev() >= rev {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for revision %d, got %d", rev, r.Rev())
+}
+
+// replicatedKeys returns the key-values under prefix, without the
+// checkpoint keys, as "key=value" strings.
+func replicatedKeys(t *testing.T, cli *clientv3.Client, prefix string) string {
+	resp, err := cli.Get(context.TODO(), prefix, clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kvs []string
+	for _, kv := range resp.Kvs {
+		if !strings.HasPrefix(string(kv.Key), prefix+replicator.DefaultMetaPrefix+"/") {
+			kvs = append(kvs, fmt.Sprintf("%s=%s", kv.Key, kv.Value))
+		}
+	}
+	return strings.Join(kvs, " ")
+}
+
+func TestReplicator(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	primary := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer primary.Terminate(t)
+	standby := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer standby.Terminate(t)
+	src, dst := primary.RandClient(), standby.RandClient()
+
+	for _, k := range []string{"app/a", "app/b", "other"} {
+		if _, err := src.Put(context.TODO(), k, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := replicator.Config{Prefix: "app/", DestPrefix: "dr/", MaxTxnOps: 2}
+	r := replicator.New(src, dst, cfg)
+	cancel := runReplicator(t, r)
+
+	// the events of a revision are applied in more than one transaction
+	resp, err := src.Txn(context.TODO()).Then(
+		clientv3.OpPut("app/c", "1"),
+		clientv3.OpPut("app/d", "1"),
+		clientv3.OpDelete("app/a"),
+	).Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitReplicated(t, r, resp.Header.Revision)
+	if kvs, wkvs := replicatedKeys(t, dst, "dr/"), "dr/b=1 dr/c=1 dr/d=1"; kvs != wkvs {
+		t.Fatalf("standby keys = %q, want %q", kvs, wkvs)
+	}
+	if kvs := replicatedKeys(t, dst, "other"); kvs != "" {
+		t.Fatalf("standby keys = %q, want none", kvs)
+	}
+	cancel()
+
+	// a restarted replicator resumes from its checkpoint instead of copying
+	// the keys again, which would leave the deleted key on the standby
+	dresp, err := src.Delete(context.TODO(), "app/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = replicator.New(src, dst, cfg)
+	cancel = runReplicator(t, r)
+	defer cancel()
+	waitReplicated(t, r, dresp.Header.Revision)
+	if kvs, wkvs := replicatedKeys(t, dst, "dr/"), "dr/c=1 dr/d=1"; kvs != wkvs {
+		t.Fatalf("standby keys = %q, want %q", kvs, wkvs)
+	}
+}
+
+// TestReplicatorBidirectional ensures two clusters replicating to each other
+// do not send the replicated writes back.
+func TestReplicatorBidirectional(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clusA := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clusA.Terminate(t)
+	clusB := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clusB.Terminate(t)
+	a, b := clusA.RandClient(), clusB.RandClient()
+
+	cfg := replicator.Config{Prefix: "app/"}
+	ab, ba := replicator.New(a, b, cfg), replicator.New(b, a, cfg)
+	defer runReplicator(t, ab)()
+	defer runReplicator(t, ba)()
+
+	aresp, err := a.Put(context.TODO(), "app/a", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bresp, err := b.Put(context.TODO(), "app/b", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitReplicated(t, ab, aresp.Header.Revision)
+	waitReplicated(t, ba, bresp.Header.Revision)
+	for _, cli := range []*clientv3.Client{a, b} {
+		if kvs, wkvs := replicatedKeys(t, cli, "app/"), "app/a=1 app/b=1"; kvs != wkvs {
+			t.Fatalf("keys = %q, want %q", kvs, wkvs)
+		}
+	}
+
+	// once each replicator skipped the other's writes, neither cluster
+	// receives more writes
+	time.Sleep(500 * time.Millisecond)
+	var revs []int64
+	for _, cli := range []*clientv3.Client{a, b} {
+		resp, err := cli.Get(context.TODO(), "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		revs = append(revs, resp.Header.Revision)
+	}
+	time.Sleep(500 * time.Millisecond)
+	for i, cli := range []*clientv3.Client{a, b} {
+		resp, err := cli.Get(context.TODO(), "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Header.Revision != revs[i] {
+			t.Fatalf("#%d: revision = %d, want %d", i, resp.Header.Revision, revs[i])
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"math/rand"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -911,6 +912,79 @@ func TestWatchCancelOnServer(t *testing.T) {
 	}
 }
 
+// TestWatchMaxWatchersPerStream ensures watches past the per-stream watcher
+// limit are canceled with ErrTooManyWatchers.
+func TestWatchMaxWatchersPerStream(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1, MaxWatchersPerStream: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if wresp := <-client.Watch(ctx, "a", clientv3.WithCreatedNotify()); wresp.Err() != nil {
+		t.Fatal(wresp.Err())
+	}
+
+	wresp, ok := <-client.Watch(ctx, "b", clientv3.WithCreatedNotify())
+	if !ok {
+		t.Fatal("expected canceled watch response, got closed channel")
+	}
+	if wresp.Err() != rpctypes.ErrTooManyWatchers {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrTooManyWatchers, wresp.Err())
+	}
+}
+
+// TestWatchAck ensures watchers acknowledge their responses so a server with
+// a small ack window keeps sending them, and that a watcher not received from
+// holds back none of the others.
+func TestWatchAck(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1, WatchAckWindow: 2})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx, cancel := context.WithCancel(clientv3.WithWatchAck(context.Background()))
+	defer cancel()
+
+	keys := []string{"a", "b"}
+	var wchs []clientv3.WatchChan
+	for _, k := range keys {
+		wch := client.Watch(ctx, k, clientv3.WithCreatedNotify())
+		if wresp := <-wch; wresp.Err() != nil {
+			t.Fatal(wresp.Err())
+		}
+		wchs = append(wchs, wch)
+	}
+
+	n := 10
+	for i := 0; i < n; i++ {
+		for _, k := range keys {
+			if _, err := client.Put(ctx, k, strconv.Itoa(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// each watcher has its own window, so drain one after the other
+	for i, wch := range wchs {
+		got := 0
+		for got < n {
+			select {
+			case wresp := <-wch:
+				if wresp.Err() != nil {
+					t.Fatal(wresp.Err())
+				}
+				got += len(wresp.Events)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("watcher %d got %d events, want %d", i, got, n)
+			}
+		}
+	}
+}
+
 // TestWatchOverlapContextCancel stresses the watcher stream teardown path by
 // creating/canceling watchers to ensure that new watchers are not taken down
 // by a torn down watch stream. The sort of race that's being detected:
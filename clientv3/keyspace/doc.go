@@ -0,0 +1,44 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keyspace exports the keys under a prefix to a portable file and
+// imports them into another cluster, for cloning an environment.
+//
+// An export is a stream of records: a header naming the prefix and the
+// revision the keys were read at, the leases attached to the keys, then the
+// keys in key order. It is encoded either as protobufs, each prefixed by its
+// uvarint length, or as one JSON object per line with keys and values base64
+// encoded. Unlike a snapshot, an export carries no revision history; the
+// imported keys are written at new revisions of the destination cluster.
+//
+// First, export the keys under "app/" from the source cluster:
+//
+//	f, err := os.Create("app.export")
+//	if err != nil {
+//		// handle error!
+//	}
+//	_, err = keyspace.Export(context.TODO(), src, f, keyspace.ExportConfig{Prefix: "app/"})
+//	f.Close()
+//
+// Next, import them into the destination cluster, granting new leases in
+// place of the exported ones:
+//
+//	f, err = os.Open("app.export")
+//	if err != nil {
+//		// handle error!
+//	}
+//	defer f.Close()
+//	_, err = keyspace.Import(context.TODO(), dst, f, keyspace.ImportConfig{Leases: keyspace.LeasesRemap})
+//
+package keyspace
@@ -0,0 +1,158 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sort"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// batchLimit is the number of keys read per range request.
+const batchLimit = 1000
+
+// ExportConfig configures an export.
+type ExportConfig struct {
+	// Prefix is the prefix of the keys to export; an empty prefix exports
+	// all keys.
+	Prefix string
+	// Revision is the revision to read the keys at. Defaults to the current
+	// revision.
+	Revision int64
+	// Format is the encoding of the export.
+	Format Format
+}
+
+// ExportResult summarizes an export.
+type ExportResult struct {
+	Header Header
+	// Keys is the number of keys exported.
+	Keys int
+	// Leases is the number of leases exported.
+	Leases int
+}
+
+// Export writes the keys under the prefix, as of the revision, to w along
+// with the leases attached to them. Leases are exported as of the export,
+// so a lease that expired after the revision has no record, though its keys
+// are exported with its ID.
+func Export(ctx context.Context, c *clientv3.Client, w io.Writer, cfg ExportConfig) (*ExportResult, error) {
+	rev := cfg.Revision
+	if rev == 0 {
+		resp, err := c.Get(ctx, rangeBegin(cfg.Prefix))
+		if err != nil {
+			return nil, err
+		}
+		rev = resp.Header.Revision
+	}
+
+	// the leases go ahead of the keys, so find them in a first pass
+	ids := make(map[int64]struct{})
+	err := rangeKeys(ctx, c, cfg.Prefix, rev, []clientv3.OpOption{clientv3.WithKeysOnly()}, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			if kv.Lease != 0 {
+				ids[kv.Lease] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var leases []*Lease
+	for id := range ids {
+		resp, err := c.TimeToLive(ctx, clientv3.LeaseID(id))
+		if err != nil {
+			return nil, err
+		}
+		if resp.TTL < 0 {
+			continue
+		}
+		leases = append(leases, &Lease{ID: id, GrantedTTL: resp.GrantedTTL, TTL: resp.TTL, Metadata: resp.Metadata})
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].ID < leases[j].ID })
+
+	bw := bufio.NewWriter(w)
+	enc := NewEncoder(bw, cfg.Format)
+	res := &ExportResult{Header: Header{Prefix: []byte(cfg.Prefix), Revision: rev}, Leases: len(leases)}
+	if err = enc.Encode(&Record{Header: &res.Header}); err != nil {
+		return nil, err
+	}
+	for _, l := range leases {
+		if err = enc.Encode(&Record{Lease: l}); err != nil {
+			return nil, err
+		}
+	}
+	err = rangeKeys(ctx, c, cfg.Prefix, rev, nil, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			r := &Record{KV: &KV{
+				Key:            kv.Key,
+				Value:          kv.Value,
+				Lease:          kv.Lease,
+				ExpireAt:       kv.ExpireAt,
+				CreateRevision: kv.CreateRevision,
+				ModRevision:    kv.ModRevision,
+				Version:        kv.Version,
+			}}
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		res.Keys += len(kvs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = bw.Flush(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// rangeBegin returns the first key of the range of keys under prefix.
+func rangeBegin(prefix string) string {
+	if prefix == "" {
+		return "\x00"
+	}
+	return prefix
+}
+
+// rangeKeys calls f with each page of the keys under prefix at rev.
+func rangeKeys(ctx context.Context, c *clientv3.Client, prefix string, rev int64, opts []clientv3.OpOption, f func([]*mvccpb.KeyValue) error) error {
+	key := rangeBegin(prefix)
+	opts = append([]clientv3.OpOption{
+		clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix)),
+		clientv3.WithRev(rev),
+		clientv3.WithLimit(batchLimit),
+	}, opts...)
+	for {
+		resp, err := c.Get(ctx, key, opts...)
+		if err != nil {
+			return err
+		}
+		if err = f(resp.Kvs); err != nil {
+			return err
+		}
+		if !resp.More {
+			return nil
+		}
+		key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+	}
+}
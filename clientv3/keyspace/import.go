@@ -0,0 +1,193 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+// defaultBatchSize is the number of keys put per transaction, matching the
+// server's default limit on operations in a transaction.
+const defaultBatchSize = 128
+
+var (
+	// ErrNoHeader is returned when an export does not start with a header.
+	ErrNoHeader = errors.New("keyspace: export does not start with a header")
+)
+
+// LeaseMode is how an import treats the exported leases.
+type LeaseMode int
+
+const (
+	// LeasesDrop imports the keys without leases.
+	LeasesDrop LeaseMode = iota
+	// LeasesKeep grants each exported lease under its exported ID. The
+	// import fails if the destination cluster already has the lease.
+	LeasesKeep
+	// LeasesRemap grants a new lease, with a new ID, for each exported lease.
+	LeasesRemap
+)
+
+func (m LeaseMode) String() string {
+	switch m {
+	case LeasesDrop:
+		return "drop"
+	case LeasesKeep:
+		return "keep"
+	case LeasesRemap:
+		return "remap"
+	}
+	return fmt.Sprintf("LeaseMode(%d)", int(m))
+}
+
+// ParseLeaseMode parses a lease mode name, "drop", "keep" or "remap".
+func ParseLeaseMode(s string) (LeaseMode, error) {
+	switch s {
+	case "drop":
+		return LeasesDrop, nil
+	case "keep":
+		return LeasesKeep, nil
+	case "remap":
+		return LeasesRemap, nil
+	}
+	return 0, fmt.Errorf("keyspace: unknown lease mode %q", s)
+}
+
+// ImportConfig configures an import.
+type ImportConfig struct {
+	// Prefix, if set, replaces the exported prefix of each key.
+	Prefix string
+	// Format is the encoding of the export.
+	Format Format
+	// Leases is how the exported leases are imported.
+	Leases LeaseMode
+	// BatchSize is the number of keys put per transaction. Defaults to 128.
+	BatchSize int
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	// Header is the header of the export.
+	Header Header
+	// Keys is the number of keys imported.
+	Keys int
+	// Leases maps each exported lease ID to the ID of the lease granted in
+	// its place.
+	Leases map[clientv3.LeaseID]clientv3.LeaseID
+	// Skipped is the number of keys not imported because their lease had
+	// expired by the time of the export.
+	Skipped int
+}
+
+// Import puts the keys of an export read from r. Imported leases are granted
+// with their exported granted TTL, so they start afresh on the destination
+// cluster. Keys are put in batches, so an import that fails partway leaves
+// the keys of the committed batches in place.
+func Import(ctx context.Context, c *clientv3.Client, r io.Reader, cfg ImportConfig) (*ImportResult, error) {
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	dec := NewDecoder(r, cfg.Format)
+	rec, err := dec.Decode()
+	if err == io.EOF || (err == nil && rec.Header == nil) {
+		return nil, ErrNoHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &ImportResult{Header: *rec.Header, Leases: make(map[clientv3.LeaseID]clientv3.LeaseID)}
+	prefix := res.Header.Prefix
+	if cfg.Prefix != "" {
+		prefix = []byte(cfg.Prefix)
+	}
+
+	var ops []clientv3.Op
+	flush := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		if _, err := c.Txn(ctx).Then(ops...).Commit(); err != nil {
+			return err
+		}
+		res.Keys += len(ops)
+		ops = ops[:0]
+		return nil
+	}
+	for {
+		rec, err = dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case rec.Lease != nil:
+			if cfg.Leases == LeasesDrop {
+				continue
+			}
+			var opts []clientv3.LeaseOption
+			if len(rec.Lease.Metadata) != 0 {
+				opts = append(opts, clientv3.WithLeaseMetadata(rec.Lease.Metadata))
+			}
+			if cfg.Leases == LeasesKeep {
+				opts = append(opts, clientv3.WithLeaseID(clientv3.LeaseID(rec.Lease.ID)))
+			}
+			resp, err := c.Grant(ctx, rec.Lease.GrantedTTL, opts...)
+			if err != nil {
+				return nil, err
+			}
+			res.Leases[clientv3.LeaseID(rec.Lease.ID)] = resp.ID
+		case rec.KV != nil:
+			kv := rec.KV
+			if !bytes.HasPrefix(kv.Key, res.Header.Prefix) {
+				return nil, fmt.Errorf("keyspace: key %q is not under the exported prefix %q", kv.Key, res.Header.Prefix)
+			}
+			var opts []clientv3.OpOption
+			if kv.Lease != 0 && cfg.Leases != LeasesDrop {
+				id, ok := res.Leases[clientv3.LeaseID(kv.Lease)]
+				if !ok {
+					res.Skipped++
+					continue
+				}
+				opts = append(opts, clientv3.WithLease(id))
+			}
+			if kv.ExpireAt != 0 {
+				opts = append(opts, clientv3.WithExpireAt(time.Unix(kv.ExpireAt, 0)))
+			}
+			key := string(prefix) + string(kv.Key[len(res.Header.Prefix):])
+			ops = append(ops, clientv3.OpPut(key, string(kv.Value), opts...))
+			if len(ops) >= batchSize {
+				if err = flush(); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("keyspace: unexpected record %v", rec)
+		}
+	}
+	if err = flush(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
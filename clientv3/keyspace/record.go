@@ -0,0 +1,183 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// maxRecordBytes bounds the length of a protobuf record, so a corrupt
+// length prefix fails the decode rather than the allocation.
+const maxRecordBytes = 64 * 1024 * 1024
+
+// Format is the encoding of an export.
+type Format int
+
+const (
+	// FormatProtobuf encodes each record as a protobuf prefixed by its
+	// uvarint length.
+	FormatProtobuf Format = iota
+	// FormatJSON encodes each record as a JSON object on its own line.
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatProtobuf:
+		return "protobuf"
+	case FormatJSON:
+		return "json"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat parses a format name, "protobuf" or "json".
+func ParseFormat(s string) (Format, error) {
+	switch s {
+	case "protobuf":
+		return FormatProtobuf, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return 0, fmt.Errorf("keyspace: unknown format %q", s)
+}
+
+// Record is an entry of an export. Exactly one of its fields is set.
+type Record struct {
+	Header *Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Lease  *Lease  `protobuf:"bytes,2,opt,name=lease" json:"lease,omitempty"`
+	KV     *KV     `protobuf:"bytes,3,opt,name=kv" json:"kv,omitempty"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+
+// Header is the first record of an export.
+type Header struct {
+	// Prefix is the prefix of the exported keys.
+	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Revision is the revision the keys were read at.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *Header) Reset()         { *m = Header{} }
+func (m *Header) String() string { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()    {}
+
+// Lease is a lease attached to exported keys, as of the export.
+type Lease struct {
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// GrantedTTL is the TTL, in seconds, the lease was granted with.
+	GrantedTTL int64 `protobuf:"varint,2,opt,name=granted_ttl,json=grantedTtl,proto3" json:"granted_ttl,omitempty"`
+	// TTL is the TTL, in seconds, the lease had left.
+	TTL      int64  `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+
+// KV is an exported key. Its revisions and version are as of the export
+// and are not carried over by an import.
+type KV struct {
+	Key            []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value          []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Lease          int64  `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"`
+	ExpireAt       int64  `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	CreateRevision int64  `protobuf:"varint,5,opt,name=create_revision,json=createRevision,proto3" json:"create_revision,omitempty"`
+	ModRevision    int64  `protobuf:"varint,6,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
+	Version        int64  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *KV) Reset()         { *m = KV{} }
+func (m *KV) String() string { return proto.CompactTextString(m) }
+func (*KV) ProtoMessage()    {}
+
+// Encoder writes the records of an export.
+type Encoder struct {
+	w  io.Writer
+	f  Format
+	je *json.Encoder
+	lb []byte
+}
+
+// NewEncoder creates an Encoder writing records to w in the given format.
+func NewEncoder(w io.Writer, f Format) *Encoder {
+	return &Encoder{w: w, f: f, je: json.NewEncoder(w), lb: make([]byte, binary.MaxVarintLen64)}
+}
+
+// Encode writes a record.
+func (e *Encoder) Encode(r *Record) error {
+	if e.f == FormatJSON {
+		return e.je.Encode(r)
+	}
+	b, err := proto.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err = e.w.Write(e.lb[:binary.PutUvarint(e.lb, uint64(len(b)))]); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+// Decoder reads the records of an export.
+type Decoder struct {
+	br *bufio.Reader
+	f  Format
+	jd *json.Decoder
+}
+
+// NewDecoder creates a Decoder reading records in the given format from r.
+func NewDecoder(r io.Reader, f Format) *Decoder {
+	br := bufio.NewReader(r)
+	return &Decoder{br: br, f: f, jd: json.NewDecoder(br)}
+}
+
+// Decode reads the next record. It returns io.EOF once the export is
+// exhausted.
+func (d *Decoder) Decode() (*Record, error) {
+	var r Record
+	if d.f == FormatJSON {
+		if err := d.jd.Decode(&r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	}
+	n, err := binary.ReadUvarint(d.br)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxRecordBytes {
+		return nil, fmt.Errorf("keyspace: record of %d bytes exceeds %d", n, maxRecordBytes)
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(d.br, b); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err = proto.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
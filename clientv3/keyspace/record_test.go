@@ -0,0 +1,76 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var testRecords = []*Record{
+	{Header: &Header{Prefix: []byte("app/"), Revision: 5}},
+	{Lease: &Lease{ID: 7, GrantedTTL: 60, TTL: 42, Metadata: []byte("m")}},
+	{KV: &KV{Key: []byte("app/a"), Value: []byte("1"), Lease: 7, CreateRevision: 2, ModRevision: 4, Version: 2}},
+	{KV: &KV{Key: []byte("app/b"), Value: []byte{0, 0xff}, ExpireAt: 1500000000, CreateRevision: 5, ModRevision: 5, Version: 1}},
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, f := range []Format{FormatProtobuf, FormatJSON} {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf, f)
+		for _, r := range testRecords {
+			if err := enc.Encode(r); err != nil {
+				t.Fatalf("%v: %v", f, err)
+			}
+		}
+		dec := NewDecoder(&buf, f)
+		for i, want := range testRecords {
+			r, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("%v: #%d: %v", f, i, err)
+			}
+			if !reflect.DeepEqual(r, want) {
+				t.Errorf("%v: #%d: record = %v, want %v", f, i, r, want)
+			}
+		}
+		if _, err := dec.Decode(); err != io.EOF {
+			t.Errorf("%v: err = %v, want %v", f, err, io.EOF)
+		}
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, FormatProtobuf).Encode(testRecords[2]); err != nil {
+		t.Fatal(err)
+	}
+	dec := NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), FormatProtobuf)
+	if _, err := dec.Decode(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("err = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{FormatProtobuf, FormatJSON} {
+		if pf, err := ParseFormat(f.String()); err != nil || pf != f {
+			t.Errorf("ParseFormat(%q) = %v, %v, want %v", f.String(), pf, err, f)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error on unknown format")
+	}
+}
@@ -92,7 +92,14 @@ type kv struct {
 }
 
 func NewKV(c *Client) KV {
-	return &kv{remote: RetryKVClient(c)}
+	remote := RetryKVClient(c)
+	if c.zoneRouter != nil {
+		remote = &zoneKVClient{remote, c.zoneRouter}
+	}
+	if c.hedger != nil {
+		remote = &hedgeKVClient{remote, c.hedger}
+	}
+	return &kv{remote: remote}
 }
 
 func NewKVFromKVClient(remote pb.KVClient) KV {
@@ -140,14 +147,14 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 		}
 	case tPut:
 		var resp *pb.PutResponse
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, ExpireAt: op.expireAt, ExpectedVersion: op.expectedVer, ExpectedModRevision: op.expectedRev, DryRun: op.dryRun}
 		resp, err = kv.remote.Put(ctx, r)
 		if err == nil {
 			return OpResponse{put: (*PutResponse)(resp)}, nil
 		}
 	case tDeleteRange:
 		var resp *pb.DeleteRangeResponse
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, DryRun: op.dryRun}
 		resp, err = kv.remote.DeleteRange(ctx, r)
 		if err == nil {
 			return OpResponse{del: (*DeleteResponse)(resp)}, nil
@@ -58,12 +58,18 @@ type LeaseTimeToLiveResponse struct {
 
 	// Keys is the list of keys attached to this lease.
 	Keys [][]byte `json:"keys"`
+
+	// Metadata is the opaque data the lease was granted with.
+	Metadata []byte `json:"metadata"`
 }
 
 // LeaseStatus represents a lease status.
 type LeaseStatus struct {
 	ID LeaseID `json:"id"`
 	// TODO: TTL int64
+
+	// Metadata is the opaque data the lease was granted with.
+	Metadata []byte `json:"metadata"`
 }
 
 // LeaseLeasesResponse wraps the protobuf message LeaseLeasesResponse.
@@ -102,7 +108,7 @@ func (e ErrKeepAliveHalted) Error() string {
 
 type Lease interface {
 	// Grant creates a new lease.
-	Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error)
+	Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error)
 
 	// Revoke revokes the given lease.
 	Revoke(ctx context.Context, id LeaseID) (*LeaseRevokeResponse, error)
@@ -113,7 +119,17 @@ type Lease interface {
 	// Leases retrieves all leases.
 	Leases(ctx context.Context) (*LeaseLeasesResponse, error)
 
-	// KeepAlive keeps the given lease alive forever.
+	// KeepAlive keeps the given lease alive forever. Keep alive requests are
+	// sent roughly every TTL/3 and each response is posted to the returned
+	// channel. If the responses are not consumed promptly, the channel may
+	// become full; the lease is still renewed in the background but the
+	// unconsumed responses are dropped.
+	//
+	// The returned channel is closed once renewal is no longer possible: the
+	// lease expired or was revoked, no response arrived before the last known
+	// TTL elapsed, the context was canceled, or the keep alive loop halted.
+	// Callers holding resources tied to the lease should treat a closed
+	// channel as loss of ownership.
 	KeepAlive(ctx context.Context, id LeaseID) (<-chan *LeaseKeepAliveResponse, error)
 
 	// KeepAliveOnce renews the lease once. In most of the cases, KeepAlive
@@ -181,8 +197,10 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, keepAliveTimeout time.Durati
 	return l
 }
 
-func (l *lessor) Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error) {
-	r := &pb.LeaseGrantRequest{TTL: ttl}
+func (l *lessor) Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error) {
+	op := &LeaseOp{}
+	op.applyOpts(opts)
+	r := &pb.LeaseGrantRequest{TTL: ttl, ID: int64(op.id), Metadata: op.metadata, KeepKeys: op.keepKeys}
 	resp, err := l.remote.LeaseGrant(ctx, r)
 	if err == nil {
 		gresp := &LeaseGrantResponse{
@@ -215,6 +233,7 @@ func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption
 			TTL:            resp.TTL,
 			GrantedTTL:     resp.GrantedTTL,
 			Keys:           resp.Keys,
+			Metadata:       resp.Metadata,
 		}
 		return gresp, nil
 	}
@@ -226,7 +245,7 @@ func (l *lessor) Leases(ctx context.Context) (*LeaseLeasesResponse, error) {
 	if err == nil {
 		leases := make([]LeaseStatus, len(resp.Leases))
 		for i := range resp.Leases {
-			leases[i] = LeaseStatus{ID: LeaseID(resp.Leases[i].ID)}
+			leases[i] = LeaseStatus{ID: LeaseID(resp.Leases[i].ID), Metadata: resp.Leases[i].Metadata}
 		}
 		return &LeaseLeasesResponse{ResponseHeader: resp.GetHeader(), Leases: leases}, nil
 	}
@@ -360,7 +379,7 @@ func (l *lessor) closeRequireLeader() {
 			if ka.chs[i] == nil {
 				continue
 			}
-			newChs[newIdx], newCtxs[newIdx] = ka.chs[i], ka.ctxs[newIdx]
+			newChs[newIdx], newCtxs[newIdx] = ka.chs[i], ka.ctxs[i]
 			newIdx++
 		}
 		ka.chs, ka.ctxs = newChs, newCtxs
@@ -0,0 +1,56 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"testing"
+)
+
+// TestLeaseCloseRequireLeader ensures only the keep alive channels opened
+// with a require leader context are closed on leader loss, and that the
+// remaining channels stay paired with their own contexts.
+func TestLeaseCloseRequireLeader(t *testing.T) {
+	type ctxKey struct{}
+	reqLeaderCtx := WithRequireLeader(context.Background())
+	plainCtx1 := context.WithValue(context.Background(), ctxKey{}, 1)
+	plainCtx2 := context.WithValue(context.Background(), ctxKey{}, 2)
+
+	chReq := make(chan *LeaseKeepAliveResponse, 1)
+	ch1 := make(chan *LeaseKeepAliveResponse, 1)
+	ch2 := make(chan *LeaseKeepAliveResponse, 1)
+
+	l := &lessor{keepAlives: make(map[LeaseID]*keepAlive)}
+	l.keepAlives[1] = &keepAlive{
+		chs:  []chan<- *LeaseKeepAliveResponse{chReq, ch1, ch2},
+		ctxs: []context.Context{reqLeaderCtx, plainCtx1, plainCtx2},
+	}
+
+	l.closeRequireLeader()
+
+	if _, ok := <-chReq; ok {
+		t.Fatal("expected require leader channel to be closed")
+	}
+	ka := l.keepAlives[1]
+	if len(ka.chs) != 2 || len(ka.ctxs) != 2 {
+		t.Fatalf("expected 2 remaining channels, got %d channels and %d contexts", len(ka.chs), len(ka.ctxs))
+	}
+	if ka.chs[0] != ch1 || ka.ctxs[0] != plainCtx1 {
+		t.Fatalf("expected first channel paired with its context")
+	}
+	if ka.chs[1] != ch2 || ka.ctxs[1] != plainCtx2 {
+		t.Fatalf("expected second channel paired with its context")
+	}
+}
@@ -45,6 +45,9 @@ type leasingKV struct {
 	sessionc    chan struct{}
 }
 
+// sessionRetryWait is how long to wait before retrying a failed session creation.
+const sessionRetryWait = 500 * time.Millisecond
+
 var closedCh chan struct{}
 
 func init() {
@@ -106,6 +109,10 @@ func (lkv *leasingKV) Do(ctx context.Context, op v3.Op) (v3.OpResponse, error) {
 		resp, err := lkv.delete(ctx, op)
 		return resp.OpResponse(), err
 	case op.IsTxn():
+		if op.IsDryRun() {
+			// a dry run writes nothing, so there are no leases to revoke
+			return lkv.kv.Do(ctx, op)
+		}
 		cmps, thenOps, elseOps := op.Txn()
 		resp, err := lkv.Txn(ctx).If(cmps...).Then(thenOps...).Else(elseOps...).Commit()
 		return resp.OpResponse(), err
@@ -141,6 +148,12 @@ func (lkv *leasingKV) monitorSession() {
 
 		s, err := concurrency.NewSession(lkv.cl, lkv.sessionOpts...)
 		if err != nil {
+			// avoid spinning on errors that halt lease grant retries
+			select {
+			case <-time.After(sessionRetryWait):
+			case <-lkv.ctx.Done():
+				return
+			}
 			continue
 		}
 
@@ -229,6 +242,10 @@ func (lkv *leasingKV) tryModifyOp(ctx context.Context, op v3.Op) (*v3.TxnRespons
 }
 
 func (lkv *leasingKV) put(ctx context.Context, op v3.Op) (pr *v3.PutResponse, err error) {
+	if op.IsDryRun() {
+		resp, err := lkv.kv.Do(ctx, op)
+		return resp.Put(), err
+	}
 	if err := lkv.waitSession(ctx); err != nil {
 		return nil, err
 	}
@@ -372,6 +389,10 @@ func (lkv *leasingKV) deleteRange(ctx context.Context, op v3.Op) (*v3.DeleteResp
 }
 
 func (lkv *leasingKV) delete(ctx context.Context, op v3.Op) (dr *v3.DeleteResponse, err error) {
+	if op.IsDryRun() {
+		resp, err := lkv.kv.Do(ctx, op)
+		return resp.Del(), err
+	}
 	if err := lkv.waitSession(ctx); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,280 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/mirror"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/coreos/etcd/pkg/fileutil"
+
+	bolt "github.com/coreos/bbolt"
+)
+
+const defaultRetryInterval = time.Second
+
+var (
+	keysBucket = []byte("keys")
+	revsBucket = []byte("revs")
+)
+
+// Config configures a Cache.
+type Config struct {
+	// Path is the path of the bolt database file.
+	Path string
+	// Prefixes are the key prefixes to replicate. They must not overlap; an
+	// empty prefix replicates all keys.
+	Prefixes []string
+	// RetryInterval is the wait before reconnecting a watch that failed.
+	// Defaults to one second.
+	RetryInterval time.Duration
+}
+
+// Cache is a local replica of etcd key prefixes.
+type Cache struct {
+	c   *clientv3.Client
+	cfg Config
+	db  *bolt.DB
+}
+
+// Open opens the cache database at cfg.Path, creating it if needed. The
+// cache is kept current through c while Run runs.
+func Open(c *clientv3.Client, cfg Config) (*Cache, error) {
+	for i, p := range cfg.Prefixes {
+		for _, q := range cfg.Prefixes[i+1:] {
+			if strings.HasPrefix(p, q) || strings.HasPrefix(q, p) {
+				return nil, fmt.Errorf("localcache: prefixes %q and %q overlap", p, q)
+			}
+		}
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	db, err := bolt.Open(cfg.Path, fileutil.PrivateFileMode, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{keysBucket, revsBucket} {
+			if _, berr := tx.CreateBucketIfNotExists(b); berr != nil {
+				return berr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Cache{c: c, cfg: cfg, db: db}, nil
+}
+
+// Close closes the cache database. Run must have returned.
+func (c *Cache) Close() error { return c.db.Close() }
+
+// Get returns the cached key-value pair of key, or nil if it is not cached.
+func (c *Cache) Get(key string) (*mvccpb.KeyValue, error) {
+	var kv *mvccpb.KeyValue
+	err := c.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(keysBucket).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		kv = &mvccpb.KeyValue{}
+		return kv.Unmarshal(v)
+	})
+	return kv, err
+}
+
+// List returns the cached key-value pairs with the given key prefix, in
+// key order.
+func (c *Cache) List(prefix string) ([]*mvccpb.KeyValue, error) {
+	var kvs []*mvccpb.KeyValue
+	err := c.db.View(func(tx *bolt.Tx) error {
+		cur := tx.Bucket(keysBucket).Cursor()
+		for k, v := cur.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, v = cur.Next() {
+			kv := &mvccpb.KeyValue{}
+			if err := kv.Unmarshal(v); err != nil {
+				return err
+			}
+			kvs = append(kvs, kv)
+		}
+		return nil
+	})
+	return kvs, err
+}
+
+// Revision returns the revision the cached keys of prefix are current
+// as of, or 0 if prefix was not loaded yet.
+func (c *Cache) Revision(prefix string) (int64, error) {
+	var rev int64
+	err := c.db.View(func(tx *bolt.Tx) error {
+		rev = decodeRev(tx.Bucket(revsBucket).Get([]byte(prefix)))
+		return nil
+	})
+	return rev, err
+}
+
+// Run keeps the cached prefixes current until ctx is canceled or writing
+// to the cache database fails. Failures to reach the cluster are retried;
+// the cache keeps serving the keys it has meanwhile.
+func (c *Cache) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errc := make(chan error, len(c.cfg.Prefixes))
+	for _, p := range c.cfg.Prefixes {
+		go func(prefix string) { errc <- c.runPrefix(ctx, prefix) }(p)
+	}
+	var err error
+	for range c.cfg.Prefixes {
+		if perr := <-errc; err == nil {
+			err = perr
+			// stop the other prefixes
+			cancel()
+		}
+	}
+	return err
+}
+
+// runPrefix loads prefix if it has no revision yet and applies its events
+// until ctx is canceled or a cache write fails.
+func (c *Cache) runPrefix(ctx context.Context, prefix string) error {
+	rev, err := c.Revision(prefix)
+	if err != nil {
+		return err
+	}
+	for {
+		if rev == 0 {
+			rev, err = c.load(ctx, prefix)
+		} else {
+			// cancel the watch on leaving, since the watch chan is not drained
+			wctx, wcancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+			rev, err = c.watch(wctx, prefix, rev)
+			wcancel()
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-time.After(c.cfg.RetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// load replaces the cached keys of prefix with those at the current
+// revision, returning that revision. It returns a zero revision and no
+// error if the cluster could not be read.
+func (c *Cache) load(ctx context.Context, prefix string) (int64, error) {
+	resp, err := c.c.Get(ctx, "foo")
+	if err != nil {
+		return 0, ctx.Err()
+	}
+	rev := resp.Header.Revision
+
+	var kvs []*mvccpb.KeyValue
+	respc, errc := mirror.NewSyncer(c.c, prefix, rev).SyncBase(ctx)
+	for r := range respc {
+		kvs = append(kvs, r.Kvs...)
+	}
+	if err = <-errc; err != nil {
+		return 0, ctx.Err()
+	}
+
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(keysBucket)
+		cur := b.Cursor()
+		for k, _ := cur.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = cur.Seek([]byte(prefix)) {
+			if derr := b.Delete(k); derr != nil {
+				return derr
+			}
+		}
+		for _, kv := range kvs {
+			if perr := putKV(b, kv); perr != nil {
+				return perr
+			}
+		}
+		return tx.Bucket(revsBucket).Put([]byte(prefix), encodeRev(rev))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
+
+// watch applies the events of prefix after rev until the watch closes,
+// returning the revision the cache is at. It returns a zero revision if
+// rev was compacted away, so the prefix is loaded again.
+func (c *Cache) watch(ctx context.Context, prefix string, rev int64) (int64, error) {
+	for wr := range c.c.Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1)) {
+		if wr.CompactRevision != 0 {
+			return 0, nil
+		}
+		if len(wr.Events) == 0 {
+			// progress notifications and errors; a failed watch is reopened
+			continue
+		}
+		wrev := wr.Events[len(wr.Events)-1].Kv.ModRevision
+		err := c.db.Update(func(tx *bolt.Tx) error {
+			b := tx.Bucket(keysBucket)
+			for _, ev := range wr.Events {
+				var err error
+				if ev.Type == mvccpb.DELETE {
+					err = b.Delete(ev.Kv.Key)
+				} else {
+					err = putKV(b, ev.Kv)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return tx.Bucket(revsBucket).Put([]byte(prefix), encodeRev(wrev))
+		})
+		if err != nil {
+			return rev, err
+		}
+		rev = wrev
+	}
+	return rev, ctx.Err()
+}
+
+func putKV(b *bolt.Bucket, kv *mvccpb.KeyValue) error {
+	v, err := kv.Marshal()
+	if err != nil {
+		return err
+	}
+	return b.Put(kv.Key, v)
+}
+
+func encodeRev(rev int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(rev))
+	return b
+}
+
+func decodeRev(b []byte) int64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
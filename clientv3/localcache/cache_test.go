@@ -0,0 +1,49 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenPrefixes(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "localcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		prefixes []string
+		werr     bool
+	}{
+		{[]string{"a/", "b/"}, false},
+		{[]string{"a/", "a/b/"}, true},
+		{[]string{"a/b/", "b/", "a/"}, true},
+		{[]string{"", "a/"}, true},
+	}
+	for i, tt := range tests {
+		c, err := Open(nil, Config{Path: filepath.Join(dir, "cache.db"), Prefixes: tt.prefixes})
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if c != nil {
+			c.Close()
+		}
+	}
+}
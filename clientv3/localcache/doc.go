@@ -0,0 +1,54 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localcache keeps a local replica of etcd key prefixes in a bolt
+// database, for processes that must keep reading their configuration while
+// the cluster is unreachable.
+//
+// A cache loads each prefix at a single revision, then watches it from the
+// next revision and applies the events of each watch response in one bolt
+// transaction along with the revision they bring the prefix to. A cache
+// reopened on a restart resumes each watch from its stored revision, and
+// reloads a prefix whose revision was compacted away.
+//
+// First, create a client:
+//
+//	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{"localhost:2379"}})
+//	if err != nil {
+//		// handle error!
+//	}
+//
+// Next, open a cache of the keys under "config/":
+//
+//	c, err := localcache.Open(cli, localcache.Config{
+//		Path:     "/var/lib/app/config.db",
+//		Prefixes: []string{"config/"},
+//	})
+//	if err != nil {
+//		// handle error!
+//	}
+//	defer c.Close()
+//
+// Next, keep the cache current until the context is canceled:
+//
+//	go c.Run(context.TODO())
+//
+// Finally, read keys from the cache, whether or not the cluster is reachable:
+//
+//	kv, err := c.Get("config/feature-flags")
+//	if err != nil {
+//		// handle error!
+//	}
+//
+package localcache
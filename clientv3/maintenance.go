@@ -28,6 +28,22 @@ type (
 	StatusResponse     pb.StatusResponse
 	HashKVResponse     pb.HashKVResponse
 	MoveLeaderResponse pb.MoveLeaderResponse
+	DowngradeResponse  pb.DowngradeResponse
+
+	DowngradeAction pb.DowngradeRequest_DowngradeAction
+)
+
+const (
+	// DowngradeStatus reports the cluster version and the downgrade state.
+	DowngradeStatus = DowngradeAction(pb.DowngradeRequest_STATUS)
+	// DowngradeValidate checks that the cluster version may be downgraded to
+	// the given version.
+	DowngradeValidate = DowngradeAction(pb.DowngradeRequest_VALIDATE)
+	// DowngradeEnable starts downgrading the cluster version to the given
+	// version.
+	DowngradeEnable = DowngradeAction(pb.DowngradeRequest_ENABLE)
+	// DowngradeCancel cancels the ongoing downgrade.
+	DowngradeCancel = DowngradeAction(pb.DowngradeRequest_CANCEL)
 )
 
 type Maintenance interface {
@@ -60,6 +76,11 @@ type Maintenance interface {
 	// MoveLeader requests current leader to transfer its leadership to the transferee.
 	// Request must be made to the leader.
 	MoveLeader(ctx context.Context, transfereeID uint64) (*MoveLeaderResponse, error)
+
+	// Downgrade queries, validates, enables or cancels the downgrade of the
+	// cluster version by one minor version. The version is only used to
+	// validate and enable a downgrade.
+	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
 }
 
 type maintenance struct {
@@ -203,3 +224,9 @@ func (m *maintenance) MoveLeader(ctx context.Context, transfereeID uint64) (*Mov
 	resp, err := m.remote.MoveLeader(ctx, &pb.MoveLeaderRequest{TargetID: transfereeID})
 	return (*MoveLeaderResponse)(resp), toErr(ctx, err)
 }
+
+func (m *maintenance) Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error) {
+	req := &pb.DowngradeRequest{Action: pb.DowngradeRequest_DowngradeAction(action), Version: version}
+	resp, err := m.remote.Downgrade(ctx, req)
+	return (*DowngradeResponse)(resp), toErr(ctx, err)
+}
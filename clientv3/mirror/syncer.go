@@ -28,10 +28,13 @@ const (
 // Syncer syncs with the key-value state of an etcd cluster.
 type Syncer interface {
 	// SyncBase syncs the base state of the key-value state.
-	// The key-value state are sent through the returned chan.
+	// The key-value state are sent through the returned chan in pages
+	// read at a single revision. Both chans are closed once the base
+	// state is exhausted, an error occurs, or the context is canceled.
 	SyncBase(ctx context.Context) (<-chan clientv3.GetResponse, chan error)
 	// SyncUpdates syncs the updates of the key-value state.
-	// The update events are sent through the returned chan.
+	// The update events are sent through the returned chan, starting
+	// right after the revision used by SyncBase.
 	SyncUpdates(ctx context.Context) clientv3.WatchChan
 }
 
@@ -90,7 +93,13 @@ func (s *syncer) SyncBase(ctx context.Context) (<-chan clientv3.GetResponse, cha
 				return
 			}
 
-			respchan <- (clientv3.GetResponse)(*resp)
+			select {
+			case respchan <- (clientv3.GetResponse)(*resp):
+			case <-ctx.Done():
+				// caller stopped receiving; do not block on a full chan
+				errchan <- ctx.Err()
+				return
+			}
 
 			if !resp.More {
 				return
@@ -48,10 +48,11 @@ func (kv *kvPrefix) Put(ctx context.Context, key, val string, opts ...clientv3.O
 }
 
 func (kv *kvPrefix) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
-	if len(key) == 0 {
+	op := clientv3.OpGet(key, opts...)
+	if isEmptyKeyOp(op) {
 		return nil, rpctypes.ErrEmptyKey
 	}
-	r, err := kv.KV.Do(ctx, kv.prefixOp(clientv3.OpGet(key, opts...)))
+	r, err := kv.KV.Do(ctx, kv.prefixOp(op))
 	if err != nil {
 		return nil, err
 	}
@@ -61,10 +62,11 @@ func (kv *kvPrefix) Get(ctx context.Context, key string, opts ...clientv3.OpOpti
 }
 
 func (kv *kvPrefix) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
-	if len(key) == 0 {
+	op := clientv3.OpDelete(key, opts...)
+	if isEmptyKeyOp(op) {
 		return nil, rpctypes.ErrEmptyKey
 	}
-	r, err := kv.KV.Do(ctx, kv.prefixOp(clientv3.OpDelete(key, opts...)))
+	r, err := kv.KV.Do(ctx, kv.prefixOp(op))
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +76,7 @@ func (kv *kvPrefix) Delete(ctx context.Context, key string, opts ...clientv3.OpO
 }
 
 func (kv *kvPrefix) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
-	if len(op.KeyBytes()) == 0 && !op.IsTxn() {
+	if !op.IsTxn() && isEmptyKeyOp(op) {
 		return clientv3.OpResponse{}, rpctypes.ErrEmptyKey
 	}
 	r, err := kv.KV.Do(ctx, kv.prefixOp(op))
@@ -127,6 +129,12 @@ func (txn *txnPrefix) Commit() (*clientv3.TxnResponse, error) {
 	return resp, nil
 }
 
+// isEmptyKeyOp returns true if the op targets the empty key without a range.
+// A prefix or from-key range on the empty key spans the entire namespace.
+func isEmptyKeyOp(op clientv3.Op) bool {
+	return len(op.KeyBytes()) == 0 && len(op.RangeBytes()) == 0
+}
+
 func (kv *kvPrefix) prefixOp(op clientv3.Op) clientv3.Op {
 	if !op.IsTxn() {
 		begin, end := kv.prefixInterval(op.KeyBytes(), op.RangeBytes())
@@ -135,7 +143,11 @@ func (kv *kvPrefix) prefixOp(op clientv3.Op) clientv3.Op {
 		return op
 	}
 	cmps, thenOps, elseOps := op.Txn()
-	return clientv3.OpTxn(kv.prefixCmps(cmps), kv.prefixOps(thenOps), kv.prefixOps(elseOps))
+	opts := []clientv3.OpOption{}
+	if op.IsDryRun() {
+		opts = append(opts, clientv3.WithDryRun())
+	}
+	return clientv3.OpTxn(kv.prefixCmps(cmps), kv.prefixOps(thenOps), kv.prefixOps(elseOps), opts...)
 }
 
 func (kv *kvPrefix) unprefixGetResponse(resp *clientv3.GetResponse) {
@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+import (
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
 
 type opType int
 
@@ -46,6 +50,8 @@ type Op struct {
 	maxModRev    int64
 	minCreateRev int64
 	maxCreateRev int64
+	index        string
+	indexValue   []byte
 
 	// for range, watch
 	rev int64
@@ -62,12 +68,19 @@ type Op struct {
 	// createdNotify is for created event
 	createdNotify bool
 	// filters for watchers
-	filterPut    bool
-	filterDelete bool
+	filterPut         bool
+	filterDelete      bool
+	filterLeaseDelete bool
 
 	// for put
-	val     []byte
-	leaseID LeaseID
+	val         []byte
+	leaseID     LeaseID
+	expireAt    int64
+	expectedVer int64
+	expectedRev int64
+
+	// for put, delete, txn
+	dryRun bool
 
 	// txn
 	cmps    []Cmp
@@ -110,6 +123,9 @@ func (op Op) IsKeysOnly() bool { return op.keysOnly == true }
 // IsCountOnly returns whether countOnly is set.
 func (op Op) IsCountOnly() bool { return op.countOnly == true }
 
+// IsDryRun returns whether dryRun is set.
+func (op Op) IsDryRun() bool { return op.dryRun }
+
 // MinModRev returns the operation's minimum modify revision.
 func (op Op) MinModRev() int64 { return op.minModRev }
 
@@ -147,6 +163,8 @@ func (op Op) toRangeRequest() *pb.RangeRequest {
 		MaxModRevision:    op.maxModRev,
 		MinCreateRevision: op.minCreateRev,
 		MaxCreateRevision: op.maxCreateRev,
+		Index:             op.index,
+		IndexValue:        op.indexValue,
 	}
 	if op.sort != nil {
 		r.SortOrder = pb.RangeRequest_SortOrder(op.sort.Order)
@@ -168,7 +186,7 @@ func (op Op) toTxnRequest() *pb.TxnRequest {
 	for i := range op.cmps {
 		cmps[i] = (*pb.Compare)(&op.cmps[i])
 	}
-	return &pb.TxnRequest{Compare: cmps, Success: thenOps, Failure: elseOps}
+	return &pb.TxnRequest{Compare: cmps, Success: thenOps, Failure: elseOps, DryRun: op.dryRun}
 }
 
 func (op Op) toRequestOp() *pb.RequestOp {
@@ -176,7 +194,7 @@ func (op Op) toRequestOp() *pb.RequestOp {
 	case tRange:
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: op.toRangeRequest()}}
 	case tPut:
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, ExpireAt: op.expireAt, ExpectedVersion: op.expectedVer, ExpectedModRevision: op.expectedRev}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: r}}
 	case tDeleteRange:
 		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
@@ -217,6 +235,10 @@ func OpDelete(key string, opts ...OpOption) Op {
 	switch {
 	case ret.leaseID != 0:
 		panic("unexpected lease in delete")
+	case ret.expireAt != 0:
+		panic("unexpected expiry in delete")
+	case ret.expectedVer != 0, ret.expectedRev != 0:
+		panic("unexpected expected version or mod revision in delete")
 	case ret.limit != 0:
 		panic("unexpected limit in delete")
 	case ret.rev != 0:
@@ -227,11 +249,13 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected serializable in delete")
 	case ret.countOnly:
 		panic("unexpected countOnly in delete")
+	case ret.index != "":
+		panic("unexpected index in delete")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in delete")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in delete")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterLeaseDelete:
 		panic("unexpected filter in delete")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in delete")
@@ -255,11 +279,13 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected serializable in put")
 	case ret.countOnly:
 		panic("unexpected countOnly in put")
+	case ret.index != "":
+		panic("unexpected index in put")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in put")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in put")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterLeaseDelete:
 		panic("unexpected filter in put")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in put")
@@ -267,8 +293,12 @@ func OpPut(key, val string, opts ...OpOption) Op {
 	return ret
 }
 
-func OpTxn(cmps []Cmp, thenOps []Op, elseOps []Op) Op {
-	return Op{t: tTxn, cmps: cmps, thenOps: thenOps, elseOps: elseOps}
+// OpTxn returns "txn" operation based on given transaction conditions.
+// WithDryRun is the only option a "txn" operation takes.
+func OpTxn(cmps []Cmp, thenOps []Op, elseOps []Op, opts ...OpOption) Op {
+	ret := Op{t: tTxn, cmps: cmps, thenOps: thenOps, elseOps: elseOps}
+	ret.applyOpts(opts)
+	return ret
 }
 
 func opWatch(key string, opts ...OpOption) Op {
@@ -285,6 +315,8 @@ func opWatch(key string, opts ...OpOption) Op {
 		panic("unexpected serializable in watch")
 	case ret.countOnly:
 		panic("unexpected countOnly in watch")
+	case ret.index != "":
+		panic("unexpected index in watch")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in watch")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
@@ -307,6 +339,34 @@ func WithLease(leaseID LeaseID) OpOption {
 	return func(op *Op) { op.leaseID = leaseID }
 }
 
+// WithExpireAt has the cluster delete the key in 'Put' request once the
+// given time has passed. The expiry has a resolution of one second.
+func WithExpireAt(t time.Time) OpOption {
+	return func(op *Op) { op.expireAt = t.Unix() }
+}
+
+// WithExpectedVersion makes 'Put' request apply only if the key has the given
+// version; a version of -1 expects the key to not exist. A put that is not
+// applied has Conflict set in its response, and the current key-value pair,
+// if any, as PrevKv.
+func WithExpectedVersion(ver int64) OpOption {
+	return func(op *Op) { op.expectedVer = ver }
+}
+
+// WithExpectedModRev makes 'Put' request apply only if the key was last
+// modified at the given revision; a revision of -1 expects the key to not
+// exist. A put that is not applied has Conflict set in its response, and the
+// current key-value pair, if any, as PrevKv.
+func WithExpectedModRev(rev int64) OpOption {
+	return func(op *Op) { op.expectedRev = rev }
+}
+
+// WithDryRun makes 'Put', 'Delete' and 'Txn' requests report what they would
+// do, including the revision they would create, without applying them. The
+// request goes through the same permission, quota and admission checks as
+// if it were applied. It is ignored on the operations within a 'Txn'.
+func WithDryRun() OpOption { return func(op *Op) { op.dryRun = true } }
+
 // WithLimit limits the number of results to return from 'Get' request.
 // If WithLimit is given a 0 limit, it is treated as no limit.
 func WithLimit(n int64) OpOption { return func(op *Op) { op.limit = n } }
@@ -386,6 +446,13 @@ func WithSerializable() OpOption {
 	return func(op *Op) { op.serializable = true }
 }
 
+// WithIndex makes the 'Get' request return only the keys whose values have
+// the given field in the named value index. The index must be configured on
+// the server, and the request must read the current revision.
+func WithIndex(name string, value []byte) OpOption {
+	return func(op *Op) { op.index, op.indexValue = name, value }
+}
+
 // WithKeysOnly makes the 'Get' request return only the keys and the corresponding
 // values will be omitted.
 func WithKeysOnly() OpOption {
@@ -458,6 +525,12 @@ func WithFilterDelete() OpOption {
 	return func(op *Op) { op.filterDelete = true }
 }
 
+// WithFilterLeaseDelete discards DELETE events of keys deleted by revoking
+// or expiring their lease from the watcher, keeping requested deletes.
+func WithFilterLeaseDelete() OpOption {
+	return func(op *Op) { op.filterLeaseDelete = true }
+}
+
 // WithPrevKV gets the previous key-value pair before the event happens. If the previous KV is already compacted,
 // nothing will be returned.
 func WithPrevKV() OpOption {
@@ -486,7 +559,14 @@ func WithIgnoreLease() OpOption {
 
 // LeaseOp represents an Operation that lease can execute.
 type LeaseOp struct {
+	// id is the ID of the lease to create for Grant, or 0 to have the
+	// server choose one
 	id LeaseID
+	// metadata is opaque data to keep with the lease created by Grant
+	metadata []byte
+	// keepKeys detaches the keys of the lease created by Grant instead of
+	// deleting them when it expires
+	keepKeys bool
 
 	// for TimeToLive
 	attachedKeys bool
@@ -506,6 +586,30 @@ func WithAttachedKeys() LeaseOption {
 	return func(op *LeaseOp) { op.attachedKeys = true }
 }
 
+// WithLeaseID makes Grant create the lease with the given ID instead of one
+// chosen by the server, so the ID can be known ahead, for instance to
+// restore or mirror leases. Grant fails with rpctypes.ErrLeaseExist if a
+// lease with the ID already exists.
+func WithLeaseID(id LeaseID) LeaseOption {
+	return func(op *LeaseOp) { op.id = id }
+}
+
+// WithLeaseMetadata makes Grant keep the given opaque metadata, such as the
+// name or host of the owning client, with the lease. TimeToLive and Leases
+// return it so leaked leases can be traced back to their owner. Grant fails
+// with rpctypes.ErrLeaseMetadataTooLarge if the metadata is larger than
+// 256 bytes.
+func WithLeaseMetadata(metadata []byte) LeaseOption {
+	return func(op *LeaseOp) { op.metadata = metadata }
+}
+
+// WithKeepKeys makes Grant create a lease that only signals liveness: when it
+// expires or is revoked, its keys are detached from it instead of deleted.
+// Watchers observe the expiry as a put of each key that clears its lease.
+func WithKeepKeys() LeaseOption {
+	return func(op *LeaseOp) { op.keepKeys = true }
+}
+
 func toLeaseTimeToLiveRequest(id LeaseID, opts ...LeaseOption) *pb.LeaseTimeToLiveRequest {
 	ret := &LeaseOp{id: id}
 	ret.applyOpts(opts)
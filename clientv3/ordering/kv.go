@@ -50,27 +50,67 @@ func (kv *kvOrdering) setPrevRev(currRev int64) {
 }
 
 func (kv *kvOrdering) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	r, err := kv.do(ctx, clientv3.OpGet(key, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(), nil
+}
+
+func (kv *kvOrdering) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	r, err := kv.do(ctx, clientv3.OpPut(key, val, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Put(), nil
+}
+
+func (kv *kvOrdering) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	r, err := kv.do(ctx, clientv3.OpDelete(key, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return r.Del(), nil
+}
+
+func (kv *kvOrdering) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	return kv.do(ctx, op)
+}
+
+// do issues a read until its response revision is no less than the
+// highest revision observed before the call. A write is issued once, since
+// retrying it would apply it again; its revision is recorded so that a
+// later serializable read cannot observe a revision older than the
+// client's own writes.
+func (kv *kvOrdering) do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	if op.IsDryRun() {
+		// the revision of a dry run is never created
+		return kv.KV.Do(ctx, op)
+	}
+	if isWrite(op) {
+		r, err := kv.KV.Do(ctx, op)
+		if err != nil {
+			return r, err
+		}
+		kv.setPrevRev(responseRevision(r))
+		return r, nil
+	}
 	// prevRev is stored in a local variable in order to record the prevRev
-	// at the beginning of the Get operation, because concurrent
+	// at the beginning of the operation, because concurrent
 	// access to kvOrdering could change the prevRev field in the
-	// middle of the Get operation.
+	// middle of the operation.
 	prevRev := kv.getPrevRev()
-	op := clientv3.OpGet(key, opts...)
 	for {
 		r, err := kv.KV.Do(ctx, op)
 		if err != nil {
-			return nil, err
+			return r, err
 		}
-		resp := r.Get()
-		if resp.Header.Revision == prevRev {
-			return resp, nil
-		} else if resp.Header.Revision > prevRev {
-			kv.setPrevRev(resp.Header.Revision)
-			return resp, nil
+		if rev := responseRevision(r); rev >= prevRev {
+			kv.setPrevRev(rev)
+			return r, nil
 		}
-		err = kv.orderViolationFunc(op, r, prevRev)
-		if err != nil {
-			return nil, err
+		if err = kv.orderViolationFunc(op, r, prevRev); err != nil {
+			return clientv3.OpResponse{}, err
 		}
 	}
 }
@@ -125,25 +165,10 @@ func (txn *txnOrdering) Else(ops ...clientv3.Op) clientv3.Txn {
 }
 
 func (txn *txnOrdering) Commit() (*clientv3.TxnResponse, error) {
-	// prevRev is stored in a local variable in order to record the prevRev
-	// at the beginning of the Commit operation, because concurrent
-	// access to txnOrdering could change the prevRev field in the
-	// middle of the Commit operation.
-	prevRev := txn.getPrevRev()
 	opTxn := clientv3.OpTxn(txn.cmps, txn.thenOps, txn.elseOps)
-	for {
-		opResp, err := txn.KV.Do(txn.ctx, opTxn)
-		if err != nil {
-			return nil, err
-		}
-		txnResp := opResp.Txn()
-		if txnResp.Header.Revision >= prevRev {
-			txn.setPrevRev(txnResp.Header.Revision)
-			return txnResp, nil
-		}
-		err = txn.orderViolationFunc(opTxn, opResp, prevRev)
-		if err != nil {
-			return nil, err
-		}
+	r, err := txn.do(txn.ctx, opTxn)
+	if err != nil {
+		return nil, err
 	}
+	return r.Txn(), nil
 }
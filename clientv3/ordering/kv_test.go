@@ -166,9 +166,11 @@ func TestDetectTxnOrderViolation(t *testing.T) {
 type mockKV struct {
 	clientv3.KV
 	response clientv3.OpResponse
+	calls    int
 }
 
 func (kv *mockKV) Do(ctx gContext.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	kv.calls++
 	return kv.response, nil
 }
 
@@ -204,7 +206,7 @@ var rangeTests = []struct {
 
 func TestKvOrdering(t *testing.T) {
 	for i, tt := range rangeTests {
-		mKV := &mockKV{clientv3.NewKVFromKVClient(nil), tt.response.OpResponse()}
+		mKV := &mockKV{KV: clientv3.NewKVFromKVClient(nil), response: tt.response.OpResponse()}
 		kv := &kvOrdering{
 			mKV,
 			func(r *clientv3.GetResponse) OrderViolationFunc {
@@ -258,7 +260,7 @@ var txnTests = []struct {
 
 func TestTxnOrdering(t *testing.T) {
 	for i, tt := range txnTests {
-		mKV := &mockKV{clientv3.NewKVFromKVClient(nil), tt.response.OpResponse()}
+		mKV := &mockKV{KV: clientv3.NewKVFromKVClient(nil), response: tt.response.OpResponse()}
 		kv := &kvOrdering{
 			mKV,
 			func(r *clientv3.TxnResponse) OrderViolationFunc {
@@ -288,3 +290,43 @@ func TestTxnOrdering(t *testing.T) {
 		}
 	}
 }
+
+// TestKvOrderingWrites ensures the revision of a write bounds later reads.
+func TestKvOrderingWrites(t *testing.T) {
+	mKV := &mockKV{KV: clientv3.NewKVFromKVClient(nil), response: (&clientv3.PutResponse{Header: &pb.ResponseHeader{Revision: 7}}).OpResponse()}
+	violations := 0
+	kv := NewKV(mKV, func(op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
+		violations++
+		return errors.New("order violation")
+	})
+	if _, err := kv.Put(context.TODO(), "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if rev := kv.getPrevRev(); rev != 7 {
+		t.Fatalf("expected previous revision 7, got %d", rev)
+	}
+
+	mKV.response = (&clientv3.GetResponse{Header: &pb.ResponseHeader{Revision: 6}}).OpResponse()
+	if _, err := kv.Get(context.TODO(), "foo"); err == nil {
+		t.Fatal("expected order violation on stale read after write")
+	}
+	if violations != 1 {
+		t.Fatalf("expected 1 violation, got %d", violations)
+	}
+
+	// a write older than the previous revision is not retried
+	mKV.calls = 0
+	mKV.response = (&clientv3.DeleteResponse{Header: &pb.ResponseHeader{Revision: 5}}).OpResponse()
+	if _, err := kv.Delete(context.TODO(), "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if mKV.calls != 1 {
+		t.Fatalf("expected 1 delete request, got %d", mKV.calls)
+	}
+	if violations != 1 {
+		t.Fatalf("expected no violation on write, got %d", violations-1)
+	}
+	if rev := kv.getPrevRev(); rev != 7 {
+		t.Fatalf("expected previous revision 7, got %d", rev)
+	}
+}
@@ -26,16 +26,21 @@ type OrderViolationFunc func(op clientv3.Op, resp clientv3.OpResponse, prevRev i
 
 var ErrNoGreaterRev = errors.New("etcdclient: no cluster members have a revision higher than the previously received revision")
 
-func NewOrderViolationSwitchEndpointClosure(c clientv3.Client) OrderViolationFunc {
+// NewOrderViolationSwitchEndpointClosure returns an OrderViolationFunc that
+// pins the client to each of its current endpoints in turn so the request can
+// be reissued against a member that may be more up to date. The endpoints are
+// captured when the closure is created. ErrNoGreaterRev is returned once every
+// endpoint has been tried.
+func NewOrderViolationSwitchEndpointClosure(c *clientv3.Client) OrderViolationFunc {
 	var mu sync.Mutex
 	violationCount := 0
+	eps := c.Endpoints()
 	return func(op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
-		if violationCount > len(c.Endpoints()) {
-			return ErrNoGreaterRev
-		}
 		mu.Lock()
 		defer mu.Unlock()
-		eps := c.Endpoints()
+		if violationCount > len(eps) {
+			return ErrNoGreaterRev
+		}
 		// force client to connect to given endpoint by limiting to a single endpoint
 		c.SetEndpoints(eps[violationCount%len(eps)])
 		// give enough time for operation
@@ -47,3 +52,34 @@ func NewOrderViolationSwitchEndpointClosure(c clientv3.Client) OrderViolationFun
 		return nil
 	}
 }
+
+// responseRevision returns the store revision in the header of the response.
+func responseRevision(r clientv3.OpResponse) int64 {
+	switch {
+	case r.Get() != nil:
+		return r.Get().Header.Revision
+	case r.Put() != nil:
+		return r.Put().Header.Revision
+	case r.Del() != nil:
+		return r.Del().Header.Revision
+	case r.Txn() != nil:
+		return r.Txn().Header.Revision
+	}
+	return 0
+}
+
+// isWrite returns true if the op, or any op of a txn, modifies the store.
+func isWrite(op clientv3.Op) bool {
+	if !op.IsTxn() {
+		return !op.IsGet()
+	}
+	_, thenOps, elseOps := op.Txn()
+	for _, ops := range [][]clientv3.Op{thenOps, elseOps} {
+		for _, tOp := range ops {
+			if isWrite(tOp) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replicator asynchronously replicates the keys of a primary etcd
+// cluster to a standby cluster, for failing over to another site.
+//
+// A replicator first copies the keys of the primary to the standby, then
+// tails the change feed of the primary and applies each primary revision to
+// the standby in a single transaction. That transaction also writes the
+// primary revision to a checkpoint key on the standby, so a restarted
+// replicator resumes right after the last applied revision.
+//
+// The checkpoint keys live under the replicated prefix and mark the standby
+// revisions a replicator wrote. A replicator skips the primary revisions
+// holding such a marker, so two clusters replicating to each other do not
+// send writes back and forth.
+//
+// First, create clients of both clusters:
+//
+//	src, err := clientv3.New(clientv3.Config{Endpoints: []string{"primary:2379"}})
+//	if err != nil {
+//		// handle error!
+//	}
+//	dst, err := clientv3.New(clientv3.Config{Endpoints: []string{"standby:2379"}})
+//	if err != nil {
+//		// handle error!
+//	}
+//
+// Next, create a replicator of the keys under "app/":
+//
+//	r := replicator.New(src, dst, replicator.Config{Prefix: "app/"})
+//
+// Finally, run the replicator until it fails or the context is canceled:
+//
+//	if err := r.Run(context.TODO()); err != nil {
+//		// handle error!
+//	}
+//
+package replicator
@@ -0,0 +1,58 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicator
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	appliedRevision = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replicator",
+		Name:      "applied_revision",
+		Help:      "The primary revision the standby is replicated up to.",
+	})
+	sourceRevision = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replicator",
+		Name:      "source_revision",
+		Help:      "The latest sampled revision of the primary.",
+	})
+	lagRevisions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replicator",
+		Name:      "lag_revisions",
+		Help:      "The number of primary revisions not yet replicated to the standby.",
+	})
+	eventsApplied = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "replicator",
+		Name:      "events_applied_total",
+		Help:      "Total number of primary events applied to the standby.",
+	})
+	revisionsSkipped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "replicator",
+		Name:      "revisions_skipped_total",
+		Help:      "Total number of primary revisions skipped for holding a replicator marker.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(appliedRevision)
+	prometheus.MustRegister(sourceRevision)
+	prometheus.MustRegister(lagRevisions)
+	prometheus.MustRegister(eventsApplied)
+	prometheus.MustRegister(revisionsSkipped)
+}
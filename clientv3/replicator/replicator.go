@@ -0,0 +1,336 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/changefeed"
+	"github.com/coreos/etcd/clientv3/mirror"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+const (
+	// DefaultMetaPrefix is the default prefix of the checkpoint keys,
+	// relative to the replicated prefix.
+	DefaultMetaPrefix = "__replicator"
+
+	defaultMaxTxnOps   = 128
+	defaultLagInterval = 5 * time.Second
+)
+
+// errCheckpointMoved is returned when the checkpoint of the standby was
+// written by another replicator since it was loaded.
+var errCheckpointMoved = errors.New("replicator: checkpoint moved")
+
+// Config configures a Replicator.
+type Config struct {
+	// Name identifies the replication in the name of its checkpoint key.
+	// Defaults to the hex ID of the primary cluster.
+	Name string
+	// Prefix is the key prefix to replicate. An empty prefix replicates
+	// all keys.
+	Prefix string
+	// DestPrefix replaces Prefix in the keys written to the standby.
+	// Defaults to Prefix.
+	DestPrefix string
+	// MetaPrefix is the prefix of the checkpoint keys, relative to
+	// DestPrefix on the standby. Keys under it on the primary, relative to
+	// Prefix, are markers of another replicator and are not replicated.
+	// Defaults to DefaultMetaPrefix.
+	MetaPrefix string
+	// MaxTxnOps is the most operations sent in a single transaction; it
+	// must not exceed the max-txn-ops of the standby. A primary revision
+	// with more events is applied in several transactions. Defaults to 128.
+	MaxTxnOps int
+	// LagInterval is how often the revision of the primary is sampled for
+	// the lag metrics. Defaults to five seconds.
+	LagInterval time.Duration
+	// RetryInterval is the wait before retrying a failed transaction on
+	// the standby. Defaults to one second.
+	RetryInterval time.Duration
+}
+
+// Replicator replicates the keys of a primary cluster to a standby cluster.
+type Replicator struct {
+	src *clientv3.Client
+	cfg Config
+	a   *applier
+}
+
+// New creates a Replicator that reads through src and writes through dst.
+func New(src, dst *clientv3.Client, cfg Config) *Replicator {
+	if cfg.DestPrefix == "" {
+		cfg.DestPrefix = cfg.Prefix
+	}
+	if cfg.MetaPrefix == "" {
+		cfg.MetaPrefix = DefaultMetaPrefix
+	}
+	if cfg.MaxTxnOps == 0 {
+		cfg.MaxTxnOps = defaultMaxTxnOps
+	}
+	if cfg.LagInterval == 0 {
+		cfg.LagInterval = defaultLagInterval
+	}
+	r := &Replicator{src: src, cfg: cfg}
+	r.a = &applier{dst: dst, cfg: &r.cfg}
+	return r
+}
+
+// Rev returns the primary revision the standby is replicated up to.
+func (r *Replicator) Rev() int64 { return r.a.loadRev() }
+
+// Run replicates until ctx is canceled or an error that retrying cannot
+// resolve, such as changefeed.ErrCompacted, occurs. A standby without a
+// checkpoint first receives a copy of the keys of the primary, which is
+// restarted from scratch if interrupted; its existing keys are left as is.
+func (r *Replicator) Run(ctx context.Context) error {
+	resp, err := r.src.Get(ctx, "foo")
+	if err != nil {
+		return err
+	}
+	name := r.cfg.Name
+	if name == "" {
+		name = fmt.Sprintf("%x", resp.Header.ClusterId)
+	}
+	r.a.key = r.cfg.DestPrefix + r.cfg.MetaPrefix + "/" + name
+	if err = r.a.load(ctx); err != nil {
+		return err
+	}
+	if r.a.loadRev() == 0 {
+		if err = r.syncBase(ctx, resp.Header.Revision); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go r.sampleLag(ctx)
+
+	f := changefeed.New(r.src, changefeed.Config{
+		Prefix:        r.cfg.Prefix,
+		Sink:          r.a,
+		Cursor:        r.a,
+		RetryInterval: r.cfg.RetryInterval,
+	})
+	return f.Run(ctx)
+}
+
+// syncBase copies the keys of the primary at rev to the standby and then
+// checkpoints rev.
+func (r *Replicator) syncBase(ctx context.Context, rev int64) error {
+	// cancel the sync on leaving, since its chan is not drained on errors
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rc, errc := mirror.NewSyncer(r.src, r.cfg.Prefix, rev).SyncBase(ctx)
+	for resp := range rc {
+		var ops []clientv3.Op
+		for _, kv := range resp.Kvs {
+			if r.a.isMarker(kv.Key) {
+				continue
+			}
+			ops = append(ops, clientv3.OpPut(r.a.destKey(kv.Key), string(kv.Value)))
+		}
+		if err := r.a.commitOps(ctx, ops, 0); err != nil {
+			return err
+		}
+		eventsApplied.Add(float64(len(ops)))
+	}
+	if err := <-errc; err != nil {
+		return err
+	}
+	return r.a.commit(ctx, nil, rev)
+}
+
+// sampleLag periodically records the revision of the primary and how far
+// the standby is behind it.
+func (r *Replicator) sampleLag(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.LagInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := r.src.Get(ctx, "foo")
+		if err == nil {
+			atomic.StoreInt64(&r.a.srcRev, resp.Header.Revision)
+			sourceRevision.Set(float64(resp.Header.Revision))
+			r.a.recordLag()
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// applier applies the events of the primary to the standby. It is both the
+// sink and the cursor of the change feed: each transaction on the standby
+// also writes the checkpoint, so the cursor has nothing left to store.
+type applier struct {
+	dst *clientv3.Client
+	cfg *Config
+	// key is the checkpoint key on the standby.
+	key string
+	// modRev is the standby revision that last wrote key, used to detect
+	// other replicators writing the same checkpoint.
+	modRev int64
+
+	// rev and srcRev are accessed atomically.
+
+	// rev is the primary revision the standby is replicated up to.
+	rev int64
+	// srcRev is the latest sampled revision of the primary.
+	srcRev int64
+}
+
+func (a *applier) loadRev() int64 { return atomic.LoadInt64(&a.rev) }
+
+func (a *applier) setRev(rev int64) {
+	atomic.StoreInt64(&a.rev, rev)
+	appliedRevision.Set(float64(rev))
+	a.recordLag()
+}
+
+func (a *applier) recordLag() {
+	if lag := atomic.LoadInt64(&a.srcRev) - a.loadRev(); lag > 0 {
+		lagRevisions.Set(float64(lag))
+	} else {
+		lagRevisions.Set(0)
+	}
+}
+
+// load reads the checkpoint from the standby.
+func (a *applier) load(ctx context.Context) error {
+	resp, err := a.dst.Get(ctx, a.key)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) == 0 {
+		a.modRev = 0
+		a.setRev(0)
+		return nil
+	}
+	rev, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+	if err != nil {
+		return fmt.Errorf("replicator: bad checkpoint %q: %v", resp.Kvs[0].Value, err)
+	}
+	a.modRev = resp.Kvs[0].ModRevision
+	a.setRev(rev)
+	return nil
+}
+
+func (a *applier) Load(ctx context.Context) (int64, error) { return a.loadRev(), nil }
+
+func (a *applier) Store(ctx context.Context, rev int64) error { return nil }
+
+func (a *applier) Send(ctx context.Context, evs []*clientv3.Event) error {
+	for len(evs) > 0 {
+		n := 1
+		for n < len(evs) && evs[n].Kv.ModRevision == evs[0].Kv.ModRevision {
+			n++
+		}
+		if err := a.apply(ctx, evs[:n]); err != nil {
+			return err
+		}
+		evs = evs[n:]
+	}
+	return nil
+}
+
+// apply applies the events of a single primary revision.
+func (a *applier) apply(ctx context.Context, evs []*clientv3.Event) error {
+	rev := evs[0].Kv.ModRevision
+	if rev <= a.loadRev() {
+		// already applied before a failed send was retried
+		return nil
+	}
+	ops := make([]clientv3.Op, 0, len(evs))
+	for _, ev := range evs {
+		if a.isMarker(ev.Kv.Key) {
+			// written by a replicator to this cluster; do not send it back
+			revisionsSkipped.Inc()
+			a.setRev(rev)
+			return nil
+		}
+		switch ev.Type {
+		case mvccpb.PUT:
+			ops = append(ops, clientv3.OpPut(a.destKey(ev.Kv.Key), string(ev.Kv.Value)))
+		case mvccpb.DELETE:
+			ops = append(ops, clientv3.OpDelete(a.destKey(ev.Kv.Key)))
+		}
+	}
+	err := a.commitOps(ctx, ops, rev)
+	if err == errCheckpointMoved {
+		// resume from the revision the other replicator applied
+		if lerr := a.load(ctx); lerr != nil {
+			return lerr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	eventsApplied.Add(float64(len(evs)))
+	return nil
+}
+
+// commitOps sends ops in transactions of at most MaxTxnOps operations and
+// checkpoints rev with the last one. The earlier transactions keep the
+// current checkpoint, so every transaction writes a marker.
+func (a *applier) commitOps(ctx context.Context, ops []clientv3.Op, rev int64) error {
+	n := a.cfg.MaxTxnOps - 1
+	for len(ops) > n {
+		if err := a.commit(ctx, ops[:n], a.loadRev()); err != nil {
+			return err
+		}
+		ops = ops[n:]
+	}
+	return a.commit(ctx, ops, rev)
+}
+
+// commit sends ops and the checkpoint of rev in a single transaction.
+func (a *applier) commit(ctx context.Context, ops []clientv3.Op, rev int64) error {
+	// copy ops, since appending to a chunk would overwrite the next one
+	tops := append(append(make([]clientv3.Op, 0, len(ops)+1), ops...), clientv3.OpPut(a.key, strconv.FormatInt(rev, 10)))
+	resp, err := a.dst.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(a.key), "=", a.modRev)).
+		Then(tops...).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return errCheckpointMoved
+	}
+	a.modRev = resp.Header.Revision
+	a.setRev(rev)
+	return nil
+}
+
+// isMarker returns true if key, a key of the primary, is a checkpoint key.
+func (a *applier) isMarker(key []byte) bool {
+	return strings.HasPrefix(string(key), a.cfg.Prefix+a.cfg.MetaPrefix+"/")
+}
+
+// destKey returns the standby key of key, a key of the primary.
+func (a *applier) destKey(key []byte) string {
+	return a.cfg.DestPrefix + string(key[len(a.cfg.Prefix):])
+}
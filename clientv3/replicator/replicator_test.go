@@ -0,0 +1,44 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicator
+
+import "testing"
+
+func TestApplierKeys(t *testing.T) {
+	tests := []struct {
+		cfg Config
+		key string
+
+		wmarker bool
+		wkey    string
+	}{
+		{Config{}, "foo", false, "foo"},
+		{Config{}, "__replicator/1", true, "__replicator/1"},
+		{Config{Prefix: "app/"}, "app/foo", false, "app/foo"},
+		{Config{Prefix: "app/"}, "app/__replicator/1", true, "app/__replicator/1"},
+		{Config{Prefix: "app/"}, "app/__replicatorx", false, "app/__replicatorx"},
+		{Config{Prefix: "app/", DestPrefix: "dr/"}, "app/foo", false, "dr/foo"},
+		{Config{Prefix: "app/", MetaPrefix: "meta"}, "app/meta/1", true, "app/meta/1"},
+	}
+	for i, tt := range tests {
+		a := New(nil, nil, tt.cfg).a
+		if m := a.isMarker([]byte(tt.key)); m != tt.wmarker {
+			t.Errorf("#%d: marker = %v, want %v", i, m, tt.wmarker)
+		}
+		if k := a.destKey([]byte(tt.key)); k != tt.wkey {
+			t.Errorf("#%d: dest key = %q, want %q", i, k, tt.wkey)
+		}
+	}
+}
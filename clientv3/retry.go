@@ -131,6 +131,37 @@ func (rkv *retryKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts .
 	return resp, err
 }
 
+// Txn retries read-only transactions like Range, since they can be safely
+// reissued against another endpoint; transactions with writes are retried
+// only while no request could have reached the server.
+func (rkv *retryKVClient) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (resp *pb.TxnResponse, err error) {
+	if !isReadonlyTxn(in) {
+		return rkv.nonRepeatableKVClient.Txn(ctx, in, opts...)
+	}
+	err = rkv.repeatableRetry(ctx, func(rctx context.Context) error {
+		resp, err = rkv.kc.Txn(rctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
+// isReadonlyTxn returns true if every operation of the transaction,
+// including those of nested transactions, is a range.
+func isReadonlyTxn(r *pb.TxnRequest) bool {
+	for _, ops := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, u := range ops {
+			if u.GetRequestRange() != nil {
+				continue
+			}
+			if t := u.GetRequestTxn(); t != nil && isReadonlyTxn(t) {
+				continue
+			}
+			return false
+		}
+	}
+	return true
+}
+
 type nonRepeatableKVClient struct {
 	kc                 pb.KVClient
 	nonRepeatableRetry retryRPCFunc
@@ -153,7 +184,6 @@ func (rkv *nonRepeatableKVClient) DeleteRange(ctx context.Context, in *pb.Delete
 }
 
 func (rkv *nonRepeatableKVClient) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (resp *pb.TxnResponse, err error) {
-	// TODO: repeatableRetry if read-only txn
 	err = rkv.nonRepeatableRetry(ctx, func(rctx context.Context) error {
 		resp, err = rkv.kc.Txn(rctx, in, opts...)
 		return err
@@ -348,6 +378,14 @@ func (rmc *nonRepeatableMaintenanceClient) Defragment(ctx context.Context, in *p
 	return resp, err
 }
 
+func (rmc *nonRepeatableMaintenanceClient) Downgrade(ctx context.Context, in *pb.DowngradeRequest, opts ...grpc.CallOption) (resp *pb.DowngradeResponse, err error) {
+	err = rmc.nonRepeatableRetry(ctx, func(rctx context.Context) error {
+		resp, err = rmc.mc.Downgrade(rctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
 type retryAuthClient struct {
 	*nonRepeatableAuthClient
 	repeatableRetry retryRPCFunc
@@ -0,0 +1,123 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures the retries of RetryUnaryInterceptor.
+type RetryPolicy struct {
+	// MaxRetries bounds the retries of a call. 0 retries until the
+	// context of the call is done.
+	MaxRetries int
+	// Backoff is the wait before the first retry; it doubles with each
+	// following retry, up to MaxBackoff if set. Each wait is jittered by
+	// up to half its length. 0 means defaultRetryBackoff, so a zero
+	// RetryPolicy does not retry in a busy loop.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// defaultRetryBackoff is the wait before the first retry when the
+// RetryPolicy sets no Backoff.
+const defaultRetryBackoff = 50 * time.Millisecond
+
+// safeRetryMethods are the unary RPCs without side effects, so they can be
+// retried whether or not a failed call reached the server.
+var safeRetryMethods = map[string]bool{
+	"/etcdserverpb.KV/Range":              true,
+	"/etcdserverpb.Lease/LeaseTimeToLive": true,
+	"/etcdserverpb.Lease/LeaseLeases":     true,
+	"/etcdserverpb.Cluster/MemberList":    true,
+	"/etcdserverpb.Maintenance/Status":    true,
+	"/etcdserverpb.Maintenance/Hash":      true,
+	"/etcdserverpb.Maintenance/HashKV":    true,
+	"/etcdserverpb.Auth/UserGet":          true,
+	"/etcdserverpb.Auth/UserList":         true,
+	"/etcdserverpb.Auth/RoleGet":          true,
+	"/etcdserverpb.Auth/RoleList":         true,
+}
+
+// IsRetryableError returns true if err is transient, such as the member
+// being unreachable, having no leader, or timing out on a leader change,
+// so the call may succeed if retried. Errors like ErrCompacted or
+// ErrPermissionDenied are not retryable.
+func IsRetryableError(err error) bool {
+	if eErr, ok := rpctypes.Error(err).(rpctypes.EtcdError); ok {
+		return eErr.Code() == codes.Unavailable
+	}
+	ev, ok := status.FromError(err)
+	return ok && ev.Code() == codes.Unavailable
+}
+
+// RetryUnaryInterceptor returns a grpc interceptor retrying unary calls on
+// transient errors with backoff, e.g. given to a client by
+// Config.DialOptions with grpc.WithUnaryInterceptor. Calls without side
+// effects, including read-only transactions, are retried on any retryable
+// error; other calls are retried only when they could not have reached a
+// server.
+func RetryUnaryInterceptor(p RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		retry := isNonRepeatableRetryable
+		if isSafeRetry(method, req) {
+			retry = IsRetryableError
+		}
+		backoff := p.Backoff
+		if backoff <= 0 {
+			backoff = defaultRetryBackoff
+		}
+		for n := 0; ; n++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !retry(err) || (p.MaxRetries > 0 && n >= p.MaxRetries) {
+				return err
+			}
+			if logger.V(4) {
+				logger.Infof("clientv3/retry: retrying %s on error %q", method, err.Error())
+			}
+			wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+			if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+				backoff = p.MaxBackoff
+			}
+		}
+	}
+}
+
+func isSafeRetry(method string, req interface{}) bool {
+	if safeRetryMethods[method] {
+		return true
+	}
+	txn, ok := req.(*pb.TxnRequest)
+	return ok && method == "/etcdserverpb.KV/Txn" && isReadonlyTxn(txn)
+}
+
+// isNonRepeatableRetryable returns true if err shows a call was not sent
+// to any server.
+func isNonRepeatableRetryable(err error) bool { return !isNonRepeatableStopError(err) }
@@ -0,0 +1,110 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	noAddr := status.Error(codes.Unavailable, "there is no address available")
+	put := &pb.TxnRequest{Success: []*pb.RequestOp{OpPut("foo", "bar").toRequestOp()}}
+
+	tests := []struct {
+		method     string
+		req        interface{}
+		errs       []error
+		maxRetries int
+
+		wcalls int
+		werr   error
+	}{
+		// retried until success
+		{"/etcdserverpb.KV/Range", &pb.RangeRequest{}, []error{rpctypes.ErrGRPCNoLeader, rpctypes.ErrGRPCTimeoutDueToLeaderFail, nil}, 0, 3, nil},
+		// not retryable
+		{"/etcdserverpb.KV/Range", &pb.RangeRequest{}, []error{rpctypes.ErrGRPCCompacted}, 0, 1, rpctypes.ErrGRPCCompacted},
+		{"/etcdserverpb.KV/Range", &pb.RangeRequest{}, []error{rpctypes.ErrGRPCPermissionDenied}, 0, 1, rpctypes.ErrGRPCPermissionDenied},
+		// bounded retries
+		{"/etcdserverpb.KV/Range", &pb.RangeRequest{}, []error{rpctypes.ErrGRPCNoLeader, rpctypes.ErrGRPCNoLeader, rpctypes.ErrGRPCNoLeader}, 1, 2, rpctypes.ErrGRPCNoLeader},
+		// writes are only retried if not sent
+		{"/etcdserverpb.KV/Put", &pb.PutRequest{}, []error{rpctypes.ErrGRPCNoLeader}, 0, 1, rpctypes.ErrGRPCNoLeader},
+		{"/etcdserverpb.KV/Put", &pb.PutRequest{}, []error{noAddr, nil}, 0, 2, nil},
+		{"/etcdserverpb.KV/Txn", put, []error{rpctypes.ErrGRPCNoLeader}, 0, 1, rpctypes.ErrGRPCNoLeader},
+		{"/etcdserverpb.KV/Txn", &pb.TxnRequest{}, []error{rpctypes.ErrGRPCNoLeader, nil}, 0, 2, nil},
+	}
+	for i, tt := range tests {
+		calls := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			err := tt.errs[calls]
+			calls++
+			return err
+		}
+		ri := RetryUnaryInterceptor(RetryPolicy{MaxRetries: tt.maxRetries, Backoff: time.Millisecond})
+		if err := ri(context.TODO(), tt.method, tt.req, nil, nil, invoker); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if calls != tt.wcalls {
+			t.Errorf("#%d: calls = %d, want %d", i, calls, tt.wcalls)
+		}
+	}
+}
+
+func TestRetryUnaryInterceptorDefaultBackoff(t *testing.T) {
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if calls++; calls < 3 {
+			return rpctypes.ErrGRPCNoLeader
+		}
+		return nil
+	}
+	ri := RetryUnaryInterceptor(RetryPolicy{})
+	start := time.Now()
+	if err := ri(context.TODO(), "/etcdserverpb.KV/Range", &pb.RangeRequest{}, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	// waits defaultRetryBackoff, then twice that
+	if took := time.Since(start); took < 3*defaultRetryBackoff {
+		t.Errorf("two retries took %v, want at least %v", took, 3*defaultRetryBackoff)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		err error
+		w   bool
+	}{
+		{rpctypes.ErrGRPCNoLeader, true},
+		{rpctypes.ErrNoLeader, true},
+		{status.Error(codes.Unavailable, "transport is closing"), true},
+		{rpctypes.ErrGRPCCompacted, false},
+		{rpctypes.ErrCompacted, false},
+		{rpctypes.ErrPermissionDenied, false},
+		{context.Canceled, false},
+	}
+	for i, tt := range tests {
+		if r := IsRetryableError(tt.err); r != tt.w {
+			t.Errorf("#%d: retryable = %v, want %v", i, r, tt.w)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestIsReadonlyTxn(t *testing.T) {
+	get := OpGet("foo").toRequestOp()
+	put := OpPut("foo", "bar").toRequestOp()
+	del := OpDelete("foo").toRequestOp()
+	nested := func(ops ...*pb.RequestOp) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Success: ops}}}
+	}
+
+	tests := []struct {
+		txn *pb.TxnRequest
+		ro  bool
+	}{
+		{&pb.TxnRequest{}, true},
+		{&pb.TxnRequest{Success: []*pb.RequestOp{get}, Failure: []*pb.RequestOp{get}}, true},
+		{&pb.TxnRequest{Success: []*pb.RequestOp{get, put}}, false},
+		{&pb.TxnRequest{Success: []*pb.RequestOp{get}, Failure: []*pb.RequestOp{del}}, false},
+		{&pb.TxnRequest{Success: []*pb.RequestOp{nested(get)}}, true},
+		{&pb.TxnRequest{Success: []*pb.RequestOp{nested(get, put)}}, false},
+	}
+	for i, tt := range tests {
+		if ro := isReadonlyTxn(tt.txn); ro != tt.ro {
+			t.Errorf("#%d: expected read-only %v, got %v", i, tt.ro, ro)
+		}
+	}
+}
@@ -147,6 +147,16 @@ type watchGrpcStream struct {
 	resumec chan struct{}
 	// closeErr is the error that closed the watch stream
 	closeErr error
+
+	// acks is set if the stream acknowledges received watch responses
+	acks bool
+	// ackMu protects ackRevs
+	ackMu sync.Mutex
+	// ackRevs holds the revision of the latest received response of each
+	// substream that is not yet acknowledged
+	ackRevs map[*watcherStream]int64
+	// ackc signals run() to send the acknowledgements in ackRevs
+	ackc chan struct{}
 }
 
 // watchRequest is issued by the subscriber to start a new watcher
@@ -182,6 +192,8 @@ type watcherStream struct {
 	closing bool
 	// id is the registered watch id on the grpc stream
 	id int64
+	// cancelReason is why the server refused to create the watcher.
+	cancelReason string
 
 	// buf holds all events received from etcd but not yet consumed by the client
 	buf []*WatchResponse
@@ -224,6 +236,9 @@ func (w *watcher) newWatcherGrpcStream(inctx context.Context) *watchGrpcStream {
 		errc:       make(chan error, 1),
 		closingc:   make(chan *watcherStream),
 		resumec:    make(chan struct{}),
+		acks:       watchAcksRequested(inctx),
+		ackRevs:    make(map[*watcherStream]int64),
+		ackc:       make(chan struct{}, 1),
 	}
 	go wgs.run()
 	return wgs
@@ -240,6 +255,9 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	if ow.filterDelete {
 		filters = append(filters, pb.WatchCreateRequest_NODELETE)
 	}
+	if ow.filterLeaseDelete {
+		filters = append(filters, pb.WatchCreateRequest_NOLEASEDELETE)
+	}
 
 	wr := &watchRequest{
 		ctx:            ctx,
@@ -347,6 +365,7 @@ func (w *watcher) closeStream(wgs *watchGrpcStream) {
 func (w *watchGrpcStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream) {
 	if resp.WatchId == -1 {
 		// failed; no channel
+		ws.cancelReason = resp.CancelReason
 		close(ws.recvc)
 		return
 	}
@@ -372,6 +391,8 @@ func (w *watchGrpcStream) closeSubstream(ws *watcherStream) {
 	// close subscriber's channel
 	if closeErr := w.closeErr; closeErr != nil && ws.initReq.ctx.Err() == nil {
 		go w.sendCloseSubstream(ws, &WatchResponse{closeErr: w.closeErr})
+	} else if ws.cancelReason != "" && ws.initReq.ctx.Err() == nil {
+		go w.sendCloseSubstream(ws, &WatchResponse{Canceled: true, cancelReason: ws.cancelReason})
 	} else if ws.outc != nil {
 		close(ws.outc)
 	}
@@ -500,6 +521,8 @@ func (w *watchGrpcStream) run() {
 				wc.Send(ws.initReq.toPB())
 			}
 			cancelSet = make(map[int64]struct{})
+		case <-w.ackc:
+			w.sendAcks(wc)
 		case <-w.ctx.Done():
 			return
 		case ws := <-w.closingc:
@@ -513,6 +536,35 @@ func (w *watchGrpcStream) run() {
 	}
 }
 
+// sendAcks acknowledges the responses received by the substreams.
+func (w *watchGrpcStream) sendAcks(wc pb.Watch_WatchClient) {
+	w.ackMu.Lock()
+	revs := w.ackRevs
+	w.ackRevs = make(map[*watcherStream]int64)
+	w.ackMu.Unlock()
+	for ws, rev := range revs {
+		if ws.id == -1 || w.substreams[ws.id] != ws {
+			// resuming or closed
+			continue
+		}
+		ar := &pb.WatchRequest_AckRequest{
+			AckRequest: &pb.WatchAckRequest{WatchId: ws.id, Revision: rev},
+		}
+		wc.Send(&pb.WatchRequest{RequestUnion: ar})
+	}
+}
+
+// ack records that the subscriber received a response of a substream.
+func (w *watchGrpcStream) ack(ws *watcherStream, rev int64) {
+	w.ackMu.Lock()
+	w.ackRevs[ws] = rev
+	w.ackMu.Unlock()
+	select {
+	case w.ackc <- struct{}{}:
+	default:
+	}
+}
+
 // nextResume chooses the next resuming to register with the grpc stream. Abandoned
 // streams are marked as nil in the queue since the head must wait for its inflight registration.
 func (w *watchGrpcStream) nextResume() *watcherStream {
@@ -605,6 +657,9 @@ func (w *watchGrpcStream) serveSubstream(ws *watcherStream, resumec chan struct{
 			if ws.buf[0].Err() != nil {
 				return
 			}
+			if w.acks {
+				w.ack(ws, curWr.Header.Revision)
+			}
 			ws.buf[0] = nil
 			ws.buf = ws.buf[1:]
 		case wr, ok := <-ws.recvc:
@@ -672,6 +727,10 @@ func (w *watchGrpcStream) newWatchClient() (pb.Watch_WatchClient, error) {
 	close(w.resumec)
 	w.resumec = make(chan struct{})
 	w.joinSubstreams()
+	// acknowledgements are per grpc stream; the new stream starts afresh
+	w.ackMu.Lock()
+	w.ackRevs = make(map[*watcherStream]int64)
+	w.ackMu.Unlock()
 	for _, ws := range w.substreams {
 		ws.id = -1
 		w.resuming = append(w.resuming, ws)
@@ -798,6 +857,17 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 	return &pb.WatchRequest{RequestUnion: cr}
 }
 
+// watchAcksRequested reports whether watchers created with ctx acknowledge
+// the responses they receive.
+func watchAcksRequested(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return false
+	}
+	ks := md[v3rpc.MetadataWatchAckKey]
+	return len(ks) > 0 && ks[0] == v3rpc.MetadataWatchAck
+}
+
 func streamKeyFromCtx(ctx context.Context) string {
 	if md, ok := metadata.FromOutgoingContext(ctx); ok {
 		return fmt.Sprintf("%+v", md)
@@ -0,0 +1,251 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+
+	"google.golang.org/grpc"
+)
+
+// leaderLookupTimeout bounds the status requests sent to find the leader.
+const leaderLookupTimeout = 2 * time.Second
+
+// zoneRouter holds the connections requests are routed over by zone:
+// serializable reads to an endpoint in the client's zone, writes and
+// linearizable reads to the leader's endpoint.
+type zoneRouter struct {
+	c     *Client
+	zone  string
+	zones map[string]string
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+	// leader is the endpoint of the last known leader, "" if unknown.
+	leader string
+}
+
+func newZoneRouter(c *Client, zone string, zones map[string]string) *zoneRouter {
+	return &zoneRouter{c: c, zone: zone, zones: zones, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// localConn returns a connection to an endpoint in the client's zone,
+// or nil if the pinned endpoint is in the zone or no endpoint is.
+func (zr *zoneRouter) localConn() (*grpc.ClientConn, error) {
+	pinned := zr.c.balancer.pinned()
+	eps := zr.c.Endpoints()
+	for _, ep := range eps {
+		if zr.zones[ep] == zr.zone && getHost(ep) == pinned {
+			return nil, nil
+		}
+	}
+
+	zr.mu.Lock()
+	defer zr.mu.Unlock()
+	zr.prune(eps)
+	for _, ep := range eps {
+		if zr.zones[ep] != zr.zone {
+			continue
+		}
+		return zr.connLocked(ep)
+	}
+	return nil, nil
+}
+
+// leaderConn returns a connection to the leader's endpoint, or nil if the
+// pinned endpoint is the leader or the leader cannot be found.
+func (zr *zoneRouter) leaderConn(ctx context.Context) (*grpc.ClientConn, error) {
+	eps := zr.c.Endpoints()
+
+	zr.mu.Lock()
+	zr.prune(eps)
+	leader := zr.leader
+	zr.mu.Unlock()
+
+	if leader == "" {
+		var err error
+		if leader, err = zr.findLeader(ctx, eps); leader == "" {
+			return nil, err
+		}
+	}
+
+	zr.mu.Lock()
+	defer zr.mu.Unlock()
+	zr.leader = leader
+	if getHost(leader) == zr.c.balancer.pinned() {
+		return nil, nil
+	}
+	return zr.connLocked(leader)
+}
+
+// findLeader asks every endpoint for its status and returns the endpoint
+// of the first member that reports itself as the leader.
+func (zr *zoneRouter) findLeader(ctx context.Context, eps []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, leaderLookupTimeout)
+	defer cancel()
+
+	type statusResult struct {
+		ep  string
+		err error
+	}
+	resc := make(chan statusResult, len(eps))
+	for _, ep := range eps {
+		zr.mu.Lock()
+		conn, err := zr.connLocked(ep)
+		zr.mu.Unlock()
+		if err != nil {
+			resc <- statusResult{ep, err}
+			continue
+		}
+		go func(ep string) {
+			resp, err := pb.NewMaintenanceClient(conn).Status(ctx, &pb.StatusRequest{})
+			if err == nil && resp.Header.MemberId != resp.Leader {
+				ep = ""
+			}
+			resc <- statusResult{ep, err}
+		}(ep)
+	}
+
+	var lerr error
+	for range eps {
+		res := <-resc
+		if res.err != nil {
+			lerr = res.err
+			continue
+		}
+		if res.ep != "" {
+			return res.ep, nil
+		}
+	}
+	return "", lerr
+}
+
+// resetLeader forgets the leader so the next write looks it up again.
+func (zr *zoneRouter) resetLeader() {
+	zr.mu.Lock()
+	zr.leader = ""
+	zr.mu.Unlock()
+}
+
+// connLocked returns the connection to ep, dialing it if needed.
+func (zr *zoneRouter) connLocked(ep string) (*grpc.ClientConn, error) {
+	if conn, ok := zr.conns[ep]; ok {
+		return conn, nil
+	}
+	conn, err := zr.c.dial(ep)
+	if err != nil {
+		return nil, err
+	}
+	zr.conns[ep] = conn
+	return conn, nil
+}
+
+// prune closes the connections to endpoints no longer in eps.
+func (zr *zoneRouter) prune(eps []string) {
+	for ep, conn := range zr.conns {
+		if !hasEndpoint(eps, ep) {
+			conn.Close()
+			delete(zr.conns, ep)
+			if zr.leader == ep {
+				zr.leader = ""
+			}
+		}
+	}
+}
+
+func (zr *zoneRouter) Close() {
+	zr.mu.Lock()
+	defer zr.mu.Unlock()
+	for ep, conn := range zr.conns {
+		conn.Close()
+		delete(zr.conns, ep)
+	}
+}
+
+// zoneKVClient sends serializable ranges to an endpoint in the client's
+// zone and all other requests to the leader, falling back to the pinned
+// endpoint when there is no such endpoint.
+type zoneKVClient struct {
+	pb.KVClient
+	zr *zoneRouter
+}
+
+func (zkv *zoneKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	if !in.Serializable {
+		resp, err := zkv.toLeader(ctx, func(kvc pb.KVClient) (interface{}, error) {
+			return kvc.Range(ctx, in, opts...)
+		})
+		return resp.(*pb.RangeResponse), err
+	}
+	conn, err := zkv.zr.localConn()
+	if conn == nil || err != nil {
+		if err != nil && logger.V(4) {
+			logger.Infof("clientv3/zone: cannot route range to zone %q (%v)", zkv.zr.zone, err)
+		}
+		return zkv.KVClient.Range(ctx, in, opts...)
+	}
+	return pb.NewKVClient(conn).Range(ctx, in, opts...)
+}
+
+func (zkv *zoneKVClient) Put(ctx context.Context, in *pb.PutRequest, opts ...grpc.CallOption) (*pb.PutResponse, error) {
+	resp, err := zkv.toLeader(ctx, func(kvc pb.KVClient) (interface{}, error) {
+		return kvc.Put(ctx, in, opts...)
+	})
+	return resp.(*pb.PutResponse), err
+}
+
+func (zkv *zoneKVClient) DeleteRange(ctx context.Context, in *pb.DeleteRangeRequest, opts ...grpc.CallOption) (*pb.DeleteRangeResponse, error) {
+	resp, err := zkv.toLeader(ctx, func(kvc pb.KVClient) (interface{}, error) {
+		return kvc.DeleteRange(ctx, in, opts...)
+	})
+	return resp.(*pb.DeleteRangeResponse), err
+}
+
+func (zkv *zoneKVClient) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (*pb.TxnResponse, error) {
+	resp, err := zkv.toLeader(ctx, func(kvc pb.KVClient) (interface{}, error) {
+		return kvc.Txn(ctx, in, opts...)
+	})
+	return resp.(*pb.TxnResponse), err
+}
+
+func (zkv *zoneKVClient) Compact(ctx context.Context, in *pb.CompactionRequest, opts ...grpc.CallOption) (*pb.CompactionResponse, error) {
+	resp, err := zkv.toLeader(ctx, func(kvc pb.KVClient) (interface{}, error) {
+		return kvc.Compact(ctx, in, opts...)
+	})
+	return resp.(*pb.CompactionResponse), err
+}
+
+// toLeader calls f with a client of the leader's endpoint, or of the
+// pinned endpoint if that is the leader or the leader is unknown. A failed
+// call makes the next one look up the leader again.
+func (zkv *zoneKVClient) toLeader(ctx context.Context, f func(pb.KVClient) (interface{}, error)) (interface{}, error) {
+	kvc := zkv.KVClient
+	conn, err := zkv.zr.leaderConn(ctx)
+	if err != nil && logger.V(4) {
+		logger.Infof("clientv3/zone: cannot route request to leader (%v)", err)
+	}
+	if conn != nil {
+		kvc = pb.NewKVClient(conn)
+	}
+	resp, err := f(kvc)
+	if err != nil {
+		zkv.zr.resetLeader()
+	}
+	return resp, err
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// checkWindowInterval is how often Scheduled checks whether its
+// maintenance windows opened or closed.
+const checkWindowInterval = time.Minute
+
+// Scheduled runs a Compactor only inside its maintenance windows, and
+// only while it is neither paused by the server nor held by an operator.
+type Scheduled struct {
+	clock   clockwork.Clock
+	c       Compactor
+	windows Windows
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu protects paused, held and closed
+	mu sync.Mutex
+	// paused is set by Pause, e.g. when the member loses leadership.
+	paused bool
+	// held is set by Hold, when an operator suspends auto compaction.
+	held bool
+	// closed is set when the time is outside of the windows.
+	closed bool
+}
+
+// NewScheduled creates a Scheduled compactor running c inside the
+// windows, or at any time if there are none.
+func NewScheduled(c Compactor, windows Windows) *Scheduled {
+	return &Scheduled{
+		clock:   clockwork.NewRealClock(),
+		c:       c,
+		windows: windows,
+	}
+}
+
+func (t *Scheduled) Run() {
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	t.checkWindows()
+	t.c.Run()
+	if len(t.windows) == 0 {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-t.clock.After(checkWindowInterval):
+			}
+			t.checkWindows()
+		}
+	}()
+}
+
+func (t *Scheduled) Stop() {
+	t.cancel()
+	t.c.Stop()
+}
+
+func (t *Scheduled) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paused = true
+	t.update()
+}
+
+func (t *Scheduled) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paused = false
+	t.update()
+}
+
+// Hold suspends auto compaction until Release, regardless of the
+// windows and of Resume.
+func (t *Scheduled) Hold() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.held {
+		plog.Noticef("auto-compaction is held")
+	}
+	t.held = true
+	t.update()
+}
+
+// Release undoes Hold.
+func (t *Scheduled) Release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.held {
+		plog.Noticef("auto-compaction is released")
+	}
+	t.held = false
+	t.update()
+}
+
+// Held returns true if auto compaction is held.
+func (t *Scheduled) Held() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.held
+}
+
+func (t *Scheduled) checkWindows() {
+	closed := !t.windows.Contains(t.clock.Now())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if closed != t.closed && len(t.windows) != 0 {
+		if closed {
+			plog.Infof("auto-compaction window closed")
+		} else {
+			plog.Infof("auto-compaction window opened")
+		}
+	}
+	t.closed = closed
+	t.update()
+}
+
+// update must be called holding t.mu.
+func (t *Scheduled) update() {
+	if t.paused || t.held || t.closed {
+		t.c.Pause()
+	} else {
+		t.c.Resume()
+	}
+}
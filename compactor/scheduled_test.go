@@ -0,0 +1,100 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+type fakeCompactor struct {
+	mu     sync.Mutex
+	paused bool
+}
+
+func (c *fakeCompactor) Run()  {}
+func (c *fakeCompactor) Stop() {}
+func (c *fakeCompactor) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+}
+func (c *fakeCompactor) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+}
+func (c *fakeCompactor) isPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+func TestScheduledPauseHold(t *testing.T) {
+	fc := &fakeCompactor{}
+	tb := NewScheduled(fc, nil)
+	tb.Run()
+	defer tb.Stop()
+
+	if fc.isPaused() {
+		t.Fatal("compactor paused without windows")
+	}
+	tb.Hold()
+	tb.Resume()
+	if !fc.isPaused() {
+		t.Fatal("held compactor resumed")
+	}
+	tb.Pause()
+	tb.Release()
+	if !fc.isPaused() {
+		t.Fatal("paused compactor resumed on release")
+	}
+	tb.Resume()
+	if fc.isPaused() {
+		t.Fatal("compactor still paused after resume and release")
+	}
+}
+
+func TestScheduledWindows(t *testing.T) {
+	ws, err := ParseWindows("01:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := clockwork.NewFakeClockAt(time.Date(2017, 10, 2, 0, 30, 0, 0, time.Local))
+	fc := &fakeCompactor{}
+	tb := NewScheduled(fc, ws)
+	tb.clock = clock
+	tb.Run()
+	defer tb.Stop()
+
+	if !fc.isPaused() {
+		t.Fatal("compactor not paused outside of the windows")
+	}
+	for i, wpaused := range []bool{false, true} {
+		// advance 1 hour, one window check at a time
+		for j := 0; j < 60; j++ {
+			clock.BlockUntil(1)
+			clock.Advance(checkWindowInterval)
+		}
+		// wait for the last window check
+		clock.BlockUntil(1)
+		if g := fc.isPaused(); g != wpaused {
+			t.Errorf("#%d: paused = %v, want %v", i, g, wpaused)
+		}
+	}
+}
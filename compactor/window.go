@@ -0,0 +1,155 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Windows is a set of weekly maintenance windows. An empty Windows
+// contains every time.
+type Windows []window
+
+type window struct {
+	// days are the week days the window starts on.
+	days [7]bool
+	// start and end are the offsets of the window from midnight.
+	// end exceeds 24 hours for windows that go past midnight.
+	start, end time.Duration
+}
+
+// ParseWindows parses a ';' separated list of windows of the form
+// "[days ]HH:MM-HH:MM", where days is a ',' separated list of week days
+// or week day ranges, e.g. "mon-fri 01:00-05:00;sat,sun 00:00-24:00".
+// A window without days applies to every day. A window whose end is not
+// after its start ends on the next day.
+func ParseWindows(spec string) (Windows, error) {
+	var ws Windows
+	for _, s := range strings.Split(spec, ";") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		w, err := parseWindow(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window %q (%v)", s, err)
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
+
+func parseWindow(s string) (w window, err error) {
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		for i := range w.days {
+			w.days[i] = true
+		}
+	case 2:
+		if w.days, err = parseDays(fields[0]); err != nil {
+			return w, err
+		}
+		fields = fields[1:]
+	default:
+		return w, fmt.Errorf("expected [days ]HH:MM-HH:MM")
+	}
+
+	times := strings.Split(fields[0], "-")
+	if len(times) != 2 {
+		return w, fmt.Errorf("expected HH:MM-HH:MM")
+	}
+	if w.start, err = parseClock(times[0]); err != nil {
+		return w, err
+	}
+	if w.end, err = parseClock(times[1]); err != nil {
+		return w, err
+	}
+	if w.start == 24*time.Hour {
+		return w, fmt.Errorf("window cannot start at 24:00")
+	}
+	if w.end <= w.start {
+		w.end += 24 * time.Hour
+	}
+	return w, nil
+}
+
+func parseDays(s string) (days [7]bool, err error) {
+	for _, d := range strings.Split(s, ",") {
+		r := strings.Split(strings.ToLower(d), "-")
+		if len(r) > 2 {
+			return days, fmt.Errorf("invalid day range %q", d)
+		}
+		from, ok := weekdays[r[0]]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q", r[0])
+		}
+		to := from
+		if len(r) == 2 {
+			if to, ok = weekdays[r[1]]; !ok {
+				return days, fmt.Errorf("unknown day %q", r[1])
+			}
+		}
+		// ranges may wrap around the week, e.g. "fri-mon"
+		for wd := from; ; wd = (wd + 1) % 7 {
+			days[wd] = true
+			if wd == to {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	hm := strings.Split(s, ":")
+	if len(hm) != 2 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, herr := strconv.Atoi(hm[0])
+	m, merr := strconv.Atoi(hm[1])
+	if herr != nil || merr != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// Contains returns true if t, in its location, is in one of the windows.
+func (ws Windows) Contains(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	h, m, s := t.Clock()
+	off := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	day, prev := t.Weekday(), (t.Weekday()+6)%7
+	for _, w := range ws {
+		if w.days[day] && w.start <= off && off < w.end {
+			return true
+		}
+		// windows that started on the previous day
+		if w.days[prev] && off+24*time.Hour < w.end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindowsError(t *testing.T) {
+	tests := []string{
+		"01:00",
+		"01:00-25:00",
+		"01:60-02:00",
+		"24:00-01:00",
+		"mon 01:00-02:00 x",
+		"funday 01:00-02:00",
+		"mon-fri-sat 01:00-02:00",
+	}
+	for i, tt := range tests {
+		if _, err := ParseWindows(tt); err == nil {
+			t.Errorf("#%d: %q expected error", i, tt)
+		}
+	}
+}
+
+func TestWindowsContains(t *testing.T) {
+	// 2017-10-02 is a Monday
+	at := func(day, h, m int) time.Time { return time.Date(2017, 10, 2+day, h, m, 0, 0, time.UTC) }
+	tests := []struct {
+		spec string
+		t    time.Time
+		w    bool
+	}{
+		{"", at(0, 12, 0), true},
+		{"01:00-05:00", at(3, 1, 0), true},
+		{"01:00-05:00", at(3, 5, 0), false},
+		{"01:00-05:00", at(3, 0, 59), false},
+		{"mon-fri 01:00-05:00", at(4, 2, 0), true},
+		{"mon-fri 01:00-05:00", at(5, 2, 0), false},
+		{"sat,sun 00:00-24:00", at(6, 23, 59), true},
+		{"sat,sun 00:00-24:00", at(7, 0, 0), false},
+		{"fri-mon 01:00-02:00", at(6, 1, 30), true},
+		{"fri-mon 01:00-02:00", at(1, 1, 30), false},
+		// past midnight, belongs to the start day
+		{"sun 22:00-02:00", at(7, 1, 0), true},
+		{"sun 22:00-02:00", at(6, 23, 0), true},
+		{"sun 22:00-02:00", at(6, 1, 0), false},
+		{"mon 01:00-02:00; wed 03:00-04:00", at(2, 3, 30), true},
+	}
+	for i, tt := range tests {
+		ws, err := ParseWindows(tt.spec)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if g := ws.Contains(tt.t); g != tt.w {
+			t.Errorf("#%d: %q contains %v = %v, want %v", i, tt.spec, tt.t, g, tt.w)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/coreos/etcd/etcdserver"
 )
@@ -116,6 +117,8 @@ type etcdProcessClusterConfig struct {
 	initialToken      string
 	quotaBackendBytes int64
 	noStrictReconfig  bool
+
+	keyIndexSaveInterval time.Duration
 }
 
 // newEtcdProcessCluster launches a new cluster from etcd processes, returning
@@ -224,6 +227,9 @@ func (cfg *etcdProcessClusterConfig) etcdServerProcessConfigs() []*etcdServerPro
 		if cfg.noStrictReconfig {
 			args = append(args, "--strict-reconfig-check=false")
 		}
+		if cfg.keyIndexSaveInterval > 0 {
+			args = append(args, "--experimental-key-index-save-interval", cfg.keyIndexSaveInterval.String())
+		}
 		var murl string
 		if cfg.metricsURLScheme != "" {
 			murl = (&url.URL{
@@ -31,6 +31,8 @@ func TestCtlV3Elect(t *testing.T) {
 	testCtl(t, testElect)
 }
 
+func TestCtlV3ElectWithCmd(t *testing.T) { testCtl(t, testElectWithCmd) }
+
 func testElect(cx ctlCtx) {
 	name := "a"
 
@@ -99,6 +101,17 @@ func testElect(cx ctlCtx) {
 	}
 }
 
+func testElectWithCmd(cx ctlCtx) {
+	cmdArgs := append(cx.PrefixArgs(), "elect", "a", "p1", "env")
+	if err := spawnWithExpect(cmdArgs, "ETCD_ELECT_KEY=a/"); err != nil {
+		cx.t.Fatal(err)
+	}
+	cmdArgs = append(cx.PrefixArgs(), "elect", "a", "p1", "awk", "BEGIN{exit 3}")
+	if err := spawnWithExpect(cmdArgs, "Error: exit status 3"); err != nil {
+		cx.t.Fatal(err)
+	}
+}
+
 // ctlV3Elect creates a elect process with a channel listening for when it wins the election.
 func ctlV3Elect(cx ctlCtx, name, proposal string) (*expect.ExpectProcess, <-chan string, error) {
 	cmdArgs := append(cx.PrefixArgs(), "elect", name, proposal)
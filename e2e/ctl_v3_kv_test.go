@@ -31,6 +31,7 @@ func TestCtlV3PutClientTLSFlagByEnv(t *testing.T) {
 }
 func TestCtlV3PutIgnoreValue(t *testing.T) { testCtl(t, putTestIgnoreValue) }
 func TestCtlV3PutIgnoreLease(t *testing.T) { testCtl(t, putTestIgnoreLease) }
+func TestCtlV3PutExpected(t *testing.T)    { testCtl(t, putTestExpected) }
 
 func TestCtlV3Get(t *testing.T)              { testCtl(t, getTest) }
 func TestCtlV3GetNoTLS(t *testing.T)         { testCtl(t, getTest, withCfg(configNoTLS)) }
@@ -128,6 +129,25 @@ func putTestIgnoreLease(cx ctlCtx) {
 	}
 }
 
+func putTestExpected(cx ctlCtx) {
+	if err := ctlV3Put(cx, "foo", "bar", "", "--expected-version=-1"); err != nil {
+		cx.t.Fatal(err)
+	}
+	cmdArgs := append(cx.PrefixArgs(), "put", "foo", "bar1", "--expected-version=2")
+	if err := spawnWithExpects(cmdArgs, "CONFLICT", "foo", "bar"); err != nil {
+		cx.t.Fatal(err)
+	}
+	if err := ctlV3Get(cx, []string{"foo"}, kv{"foo", "bar"}); err != nil {
+		cx.t.Fatal(err)
+	}
+	if err := ctlV3Put(cx, "foo", "bar1", "", "--expected-version=1"); err != nil {
+		cx.t.Fatal(err)
+	}
+	if err := ctlV3Get(cx, []string{"foo"}, kv{"foo", "bar1"}); err != nil {
+		cx.t.Fatal(err)
+	}
+}
+
 func getTest(cx ctlCtx) {
 	var (
 		kvs    = []kv{{"key1", "val1"}, {"key2", "val2"}, {"key3", "val3"}}
@@ -149,6 +169,7 @@ func getTest(cx ctlCtx) {
 		{[]string{"", "--from-key"}, kvs},
 		{[]string{"key", "--prefix"}, kvs},
 		{[]string{"key", "--prefix", "--limit=2"}, kvs[:2]},
+		{[]string{"key2", "--from-key", "--limit=2"}, kvs[1:]},
 		{[]string{"key", "--prefix", "--order=ASCEND", "--sort-by=MODIFY"}, kvs},
 		{[]string{"key", "--prefix", "--order=ASCEND", "--sort-by=VERSION"}, kvs},
 		{[]string{"key", "--prefix", "--sort-by=CREATE"}, kvs}, // ASCEND by default
@@ -232,6 +253,10 @@ func getKeysOnlyTest(cx ctlCtx) {
 	if err := spawnWithExpects(cmdArgs, "val"); err == nil {
 		cx.t.Fatalf("got value but passed --keys-only")
 	}
+	cmdArgs = append(cx.PrefixArgs(), []string{"get", "--keys-only", "--print-value-only", "key"}...)
+	if err := spawnWithExpect(cmdArgs, "cannot be set at the same time"); err != nil {
+		cx.t.Fatal(err)
+	}
 }
 
 func delTest(cx ctlCtx) {
@@ -21,10 +21,12 @@ import (
 	"testing"
 )
 
-func TestCtlV3LeaseGrantTimeToLive(t *testing.T) { testCtl(t, leaseTestGrantTimeToLive) }
-func TestCtlV3LeaseGrantLeases(t *testing.T)     { testCtl(t, leaseTestGrantLeasesList) }
-func TestCtlV3LeaseKeepAlive(t *testing.T)       { testCtl(t, leaseTestKeepAlive) }
-func TestCtlV3LeaseRevoke(t *testing.T)          { testCtl(t, leaseTestRevoke) }
+func TestCtlV3LeaseGrantTimeToLive(t *testing.T)   { testCtl(t, leaseTestGrantTimeToLive) }
+func TestCtlV3LeaseGrantLeases(t *testing.T)       { testCtl(t, leaseTestGrantLeasesList) }
+func TestCtlV3LeaseKeepAlive(t *testing.T)         { testCtl(t, leaseTestKeepAlive) }
+func TestCtlV3LeaseRevoke(t *testing.T)            { testCtl(t, leaseTestRevoke) }
+func TestCtlV3LeaseGrantWithID(t *testing.T)       { testCtl(t, leaseTestGrantWithID) }
+func TestCtlV3LeaseGrantWithMetadata(t *testing.T) { testCtl(t, leaseTestGrantWithMetadata) }
 
 func leaseTestGrantTimeToLive(cx ctlCtx) {
 	id, err := ctlV3LeaseGrant(cx, 10)
@@ -106,6 +108,31 @@ func leaseTestRevoke(cx ctlCtx) {
 	}
 }
 
+func leaseTestGrantWithID(cx ctlCtx) {
+	cmdArgs := append(cx.PrefixArgs(), "lease", "grant", "10", "--id", "1234abcd")
+	if err := spawnWithExpect(cmdArgs, "lease 000000001234abcd granted with TTL(10s)"); err != nil {
+		cx.t.Fatalf("leaseTestGrantWithID: grant error (%v)", err)
+	}
+	if err := spawnWithExpect(cmdArgs, "lease already exists"); err != nil {
+		cx.t.Fatalf("leaseTestGrantWithID: regrant error (%v)", err)
+	}
+}
+
+func leaseTestGrantWithMetadata(cx ctlCtx) {
+	cmdArgs := append(cx.PrefixArgs(), "lease", "grant", "10", "--id", "1234abcd", "--metadata", "scheduler@node-1")
+	if err := spawnWithExpect(cmdArgs, "lease 000000001234abcd granted with TTL(10s)"); err != nil {
+		cx.t.Fatalf("leaseTestGrantWithMetadata: grant error (%v)", err)
+	}
+	cmdArgs = append(cx.PrefixArgs(), "lease", "timetolive", "1234abcd")
+	if err := spawnWithExpect(cmdArgs, `metadata("scheduler@node-1")`); err != nil {
+		cx.t.Fatalf("leaseTestGrantWithMetadata: timetolive error (%v)", err)
+	}
+	cmdArgs = append(cx.PrefixArgs(), "lease", "list")
+	if err := spawnWithExpect(cmdArgs, `000000001234abcd metadata("scheduler@node-1")`); err != nil {
+		cx.t.Fatalf("leaseTestGrantWithMetadata: list error (%v)", err)
+	}
+}
+
 func ctlV3LeaseGrant(cx ctlCtx, ttl int) (string, error) {
 	cmdArgs := append(cx.PrefixArgs(), "lease", "grant", strconv.Itoa(ttl))
 	proc, err := spawnCmd(cmdArgs)
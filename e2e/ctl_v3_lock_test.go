@@ -31,6 +31,8 @@ func TestCtlV3Lock(t *testing.T) {
 	testCtl(t, testLock)
 }
 
+func TestCtlV3LockWithCmd(t *testing.T) { testCtl(t, testLockWithCmd) }
+
 func testLock(cx ctlCtx) {
 	name := "a"
 
@@ -99,6 +101,18 @@ func testLock(cx ctlCtx) {
 	}
 }
 
+func testLockWithCmd(cx ctlCtx) {
+	// exec command with the lock key in its environment
+	if err := ctlV3LockWithCmd(cx, []string{"env"}, "ETCD_LOCK_KEY=a/"); err != nil {
+		cx.t.Fatal(err)
+	}
+	// exec command with non-zero exit code
+	awkCmd := []string{"awk", "BEGIN{exit 3}"}
+	if err := ctlV3LockWithCmd(cx, awkCmd, "Error: exit status 3"); err != nil {
+		cx.t.Fatal(err)
+	}
+}
+
 // ctlV3Lock creates a lock process with a channel listening for when it acquires the lock.
 func ctlV3Lock(cx ctlCtx, name string) (*expect.ExpectProcess, <-chan string, error) {
 	cmdArgs := append(cx.PrefixArgs(), "lock", name)
@@ -117,3 +131,10 @@ func ctlV3Lock(cx ctlCtx, name string) (*expect.ExpectProcess, <-chan string, er
 	}()
 	return proc, outc, err
 }
+
+// ctlV3LockWithCmd acquires the lock "a" and runs execCmd while holding it.
+func ctlV3LockWithCmd(cx ctlCtx, execCmd []string, expect string) error {
+	cmdArgs := append(cx.PrefixArgs(), "lock", "a")
+	cmdArgs = append(cmdArgs, execCmd...)
+	return spawnWithExpect(cmdArgs, expect)
+}
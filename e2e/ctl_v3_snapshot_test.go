@@ -93,8 +93,126 @@ func snapshotCorruptTest(cx ctlCtx) {
 	}
 }
 
+func TestCtlV3SnapshotRestoreRevision(t *testing.T) { testCtl(t, snapshotRestoreRevisionTest) }
+
+func snapshotRestoreRevisionTest(cx ctlCtx) {
+	maintenanceInitKeys(cx)
+
+	fpath := "test.snapshot"
+	defer os.RemoveAll(fpath)
+
+	if err := ctlV3SnapshotSave(cx, fpath); err != nil {
+		cx.t.Fatalf("snapshotRestoreRevisionTest ctlV3SnapshotSave error (%v)", err)
+	}
+
+	defer os.RemoveAll("snap.etcd")
+	if err := ctlV3SnapshotRestoreRevision(cx, fpath, "snap.etcd", 3, "membership: added member"); err != nil {
+		cx.t.Fatal(err)
+	}
+	st, err := getSnapshotStatus(cx, filepath.Join("snap.etcd", "member", "snap", "db"))
+	if err != nil {
+		cx.t.Fatalf("snapshotRestoreRevisionTest getSnapshotStatus error (%v)", err)
+	}
+	if st.Revision != 3 {
+		cx.t.Fatalf("expected 3, got %d", st.Revision)
+	}
+
+	// revisions before the compaction are gone
+	if err = ctlV3Compact(cx, 4, cx.compactPhysical); err != nil {
+		cx.t.Fatal(err)
+	}
+	if err = ctlV3SnapshotSave(cx, fpath); err != nil {
+		cx.t.Fatalf("snapshotRestoreRevisionTest ctlV3SnapshotSave error (%v)", err)
+	}
+	defer os.RemoveAll("snap2.etcd")
+	if err = ctlV3SnapshotRestoreRevision(cx, fpath, "snap2.etcd", 3, "revision 3 is compacted"); err != nil {
+		cx.t.Fatal(err)
+	}
+}
+
+// TestCtlV3SnapshotRestoreRevisionKeyIndex ensures a member restored as of a
+// revision does not load the key index saved in the snapshot, which knows
+// of the revisions after it.
+func TestCtlV3SnapshotRestoreRevisionKeyIndex(t *testing.T) {
+	defer testutil.AfterTest(t)
+	mustEtcdctl(t)
+	os.Setenv("ETCDCTL_API", "3")
+	defer os.Unsetenv("ETCDCTL_API")
+
+	epc, err := newEtcdProcessCluster(&etcdProcessClusterConfig{
+		clusterSize:          1,
+		initialToken:         "new",
+		keepDataDir:          true,
+		keyIndexSaveInterval: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("could not start etcd process cluster (%v)", err)
+	}
+	defer func() {
+		if errC := epc.Close(); errC != nil {
+			t.Fatalf("error closing etcd processes (%v)", errC)
+		}
+	}()
+
+	prefixArgs := []string{ctlBinPath, "--endpoints", strings.Join(epc.EndpointsV3(), ","), "--dial-timeout", "7s"}
+
+	// revisions 2 to 4
+	kvs := []kv{{"foo1", "val1"}, {"foo2", "val2"}, {"foo3", "val3"}}
+	for i := range kvs {
+		if err = spawnWithExpect(append(prefixArgs, "put", kvs[i].key, kvs[i].val), "OK"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// let the key index of revision 4 be saved
+	time.Sleep(time.Second)
+
+	fpath := filepath.Join(os.TempDir(), "test.snapshot")
+	defer os.RemoveAll(fpath)
+	if err = spawnWithExpect(append(prefixArgs, "snapshot", "save", fpath), fmt.Sprintf("Snapshot saved at %s", fpath)); err != nil {
+		t.Fatal(err)
+	}
+	if err = epc.procs[0].Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	newDataDir := filepath.Join(os.TempDir(), "test.data")
+	defer os.RemoveAll(newDataDir)
+	cfg := epc.procs[0].Config()
+	err = spawnWithExpect([]string{ctlBinPath, "snapshot", "restore", fpath, "--revision", "3", "--name", cfg.name, "--initial-cluster", cfg.initialCluster, "--initial-cluster-token", cfg.initialToken, "--initial-advertise-peer-urls", cfg.purl.String(), "--data-dir", newDataDir}, "membership: added member")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.dataDirPath = newDataDir
+	for i := range cfg.args {
+		if cfg.args[i] == "--data-dir" {
+			cfg.args[i+1] = newDataDir
+		}
+	}
+	if err = epc.procs[0].Restart(); err != nil {
+		t.Fatal(err)
+	}
+
+	// foo3 is gone; writing it again reuses revision 4, which a stale
+	// key index would reject
+	if err = spawnWithExpects(append(prefixArgs, "get", "foo", "--prefix"), "foo1", "val1", "foo2", "val2"); err != nil {
+		t.Fatal(err)
+	}
+	if err = spawnWithExpect(append(prefixArgs, "put", "foo3", "new"), "OK"); err != nil {
+		t.Fatal(err)
+	}
+	if err = spawnWithExpect(append(prefixArgs, "get", "foo3"), "new"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func ctlV3SnapshotRestoreRevision(cx ctlCtx, fpath, dataDir string, rev int64, expected string) error {
+	cmdArgs := append(cx.PrefixArgs(), "snapshot", "restore", "--data-dir", dataDir, "--revision", fmt.Sprint(rev), fpath)
+	return spawnWithExpect(cmdArgs, expected)
+}
+
 func ctlV3SnapshotSave(cx ctlCtx, fpath string) error {
-	cmdArgs := append(cx.PrefixArgs(), "snapshot", "save", fpath)
+	cmdArgs := append(cx.prefixArgs([]string{cx.epc.EndpointsV3()[0]}), "snapshot", "save", fpath)
 	return spawnWithExpect(cmdArgs, fmt.Sprintf("Snapshot saved at %s", fpath))
 }
 
@@ -114,6 +114,46 @@ func TestV3CurlWatch(t *testing.T) {
 	}
 }
 
+func TestV3CurlDeleteRange(t *testing.T) {
+	defer testutil.AfterTest(t)
+	epc, err := newEtcdProcessCluster(&configNoTLS)
+	if err != nil {
+		t.Fatalf("could not start etcd process cluster (%v)", err)
+	}
+	defer func() {
+		if cerr := epc.Close(); err != nil {
+			t.Fatalf("error closing etcd processes (%v)", cerr)
+		}
+	}()
+
+	for _, k := range []string{"foo", "foo1", "fop"} {
+		putreq, jerr := json.Marshal(&pb.PutRequest{Key: []byte(k), Value: []byte("bar")})
+		if jerr != nil {
+			t.Fatal(jerr)
+		}
+		if err = cURLPost(epc, cURLReq{endpoint: "/v3alpha/kv/put", value: string(putreq), expected: "revision"}); err != nil {
+			t.Fatalf("failed put with curl (%v)", err)
+		}
+	}
+
+	// delete the keys prefixed with "foo" and return them
+	delreq, err := json.Marshal(&pb.DeleteRangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), PrevKv: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cURLPost(epc, cURLReq{endpoint: "/v3alpha/kv/deleterange", value: string(delreq), expected: `"deleted":"2","prev_kvs":[{"key":"Zm9v"`}); err != nil {
+		t.Fatalf("failed delete range with curl (%v)", err)
+	}
+
+	rangereq, err := json.Marshal(&pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fp")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cURLPost(epc, cURLReq{endpoint: "/v3alpha/kv/range", value: string(rangereq), expected: `"kvs":[{"key":"Zm9w"`}); err != nil {
+		t.Fatalf("failed range with curl (%v)", err)
+	}
+}
+
 func TestV3CurlTxn(t *testing.T) {
 	defer testutil.AfterTest(t)
 	epc, err := newEtcdProcessCluster(&configNoTLS)
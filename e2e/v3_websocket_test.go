@@ -0,0 +1,104 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestV3WebsocketWatch ensures a watch stream over a websocket takes create
+// and cancel requests as frames and returns each response as a frame.
+func TestV3WebsocketWatch(t *testing.T) {
+	defer testutil.AfterTest(t)
+	epc, err := newEtcdProcessCluster(&configNoTLS)
+	if err != nil {
+		t.Fatalf("could not start etcd process cluster (%v)", err)
+	}
+	defer func() {
+		if cerr := epc.Close(); err != nil {
+			t.Fatalf("error closing etcd processes (%v)", cerr)
+		}
+	}()
+
+	wsurl := strings.Replace(epc.procs[0].Config().acurl, "http://", "ws://", 1) + "/v3alpha/watch"
+	conn, _, err := websocket.DefaultDialer.Dial(wsurl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	type watchResult struct {
+		Result struct {
+			WatchID  int64 `json:"watch_id,string"`
+			Created  bool  `json:"created"`
+			Canceled bool  `json:"canceled"`
+			Events   []struct {
+				Kv struct {
+					Key   []byte `json:"key"`
+					Value []byte `json:"value"`
+				} `json:"kv"`
+			} `json:"events"`
+		} `json:"result"`
+	}
+	recv := func() watchResult {
+		var wr watchResult
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if rerr := conn.ReadJSON(&wr); rerr != nil {
+			t.Fatal(rerr)
+		}
+		return wr
+	}
+
+	wreq, err := json.Marshal(&pb.WatchCreateRequest{Key: []byte("foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.WriteMessage(websocket.TextMessage, []byte(`{"create_request":`+string(wreq)+`}`)); err != nil {
+		t.Fatal(err)
+	}
+	wr := recv()
+	if !wr.Result.Created {
+		t.Fatalf("expected created watch, got %+v", wr)
+	}
+
+	putreq, err := json.Marshal(&pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cURLPost(epc, cURLReq{endpoint: "/v3alpha/kv/put", value: string(putreq), expected: "revision"}); err != nil {
+		t.Fatalf("failed put with curl (%v)", err)
+	}
+	wr = recv()
+	if len(wr.Result.Events) != 1 || string(wr.Result.Events[0].Kv.Value) != "bar" {
+		t.Fatalf("expected put event of bar, got %+v", wr)
+	}
+
+	creq := fmt.Sprintf(`{"cancel_request":{"watch_id":"%d"}}`, wr.Result.WatchID)
+	if err = conn.WriteMessage(websocket.TextMessage, []byte(creq)); err != nil {
+		t.Fatal(err)
+	}
+	if wr = recv(); !wr.Result.Canceled {
+		t.Fatalf("expected canceled watch, got %+v", wr)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver"
+)
+
+// clientConn releases the connection its client acquired when closed.
+type clientConn struct {
+	net.Conn
+	l *clientConnListener
+
+	mu      sync.Mutex
+	closed  bool
+	release func()
+}
+
+func (c *clientConn) Close() error {
+	c.mu.Lock()
+	release := c.release
+	c.closed, c.release = true, nil
+	c.mu.Unlock()
+	c.l.remove(c)
+	if release != nil {
+		release()
+	}
+	return c.Conn.Close()
+}
+
+// setRelease sets the function releasing the connection of its client. It
+// returns false if the connection is already closed.
+func (c *clientConn) setRelease(release func()) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.release = release
+	return true
+}
+
+// clientConnListener wraps the accepted connections in clientConns. Until
+// they are claimed or closed, it holds them by remote address, so the
+// clientConn a TLS connection is layered on can be found once its handshake
+// completes.
+type clientConnListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns map[string][]*clientConn
+}
+
+// clientConns returns l wrapping its connections in clientConns if the
+// connections of each client are limited.
+func clientConns(l net.Listener, cl *etcdserver.ClientLimiter) net.Listener {
+	if !cl.Limited(etcdserver.ClientConnection) {
+		return l
+	}
+	return &clientConnListener{Listener: l, conns: make(map[string][]*clientConn)}
+}
+
+func (l *clientConnListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	cc := &clientConn{Conn: c, l: l}
+	addr := c.RemoteAddr().String()
+	l.mu.Lock()
+	l.conns[addr] = append(l.conns[addr], cc)
+	l.mu.Unlock()
+	return cc, nil
+}
+
+// take returns the unclaimed clientConn from addr accepted first, if any.
+// Connections sharing an address, such as those of a unix socket, may be
+// claimed out of order; each is still released once when closed.
+func (l *clientConnListener) take(addr net.Addr) (*clientConn, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ccs := l.conns[addr.String()]
+	if len(ccs) == 0 {
+		return nil, false
+	}
+	l.removeLocked(addr.String(), 0)
+	return ccs[0], true
+}
+
+func (l *clientConnListener) remove(c *clientConn) {
+	addr := c.RemoteAddr().String()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, cc := range l.conns[addr] {
+		if cc == c {
+			l.removeLocked(addr, i)
+			return
+		}
+	}
+}
+
+func (l *clientConnListener) removeLocked(addr string, i int) {
+	ccs := l.conns[addr]
+	if len(ccs) == 1 {
+		delete(l.conns, addr)
+		return
+	}
+	l.conns[addr] = append(ccs[:i:i], ccs[i+1:]...)
+}
+
+// gatewayConns holds the local addresses of the connections the gateway
+// dials to its own listener, so they are not limited as client connections.
+type gatewayConns struct {
+	mu    sync.Mutex
+	addrs map[string]struct{}
+}
+
+func newGatewayConns() *gatewayConns {
+	return &gatewayConns{addrs: make(map[string]struct{})}
+}
+
+func (g *gatewayConns) dial(addr string, timeout time.Duration) (net.Conn, error) {
+	c, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	g.addrs[c.LocalAddr().String()] = struct{}{}
+	g.mu.Unlock()
+	return c, nil
+}
+
+// take returns true if addr is the address of a gateway connection, once
+// for each connection.
+func (g *gatewayConns) take(addr net.Addr) bool {
+	if g == nil || addr == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.addrs[addr.String()]
+	delete(g.addrs, addr.String())
+	return ok
+}
+
+// clientLimitListener closes the accepted connections of clients that
+// already hold as many connections as their limit allows.
+type clientLimitListener struct {
+	net.Listener
+	conns *clientConnListener
+	cl    *etcdserver.ClientLimiter
+	gw    *gatewayConns
+}
+
+// limitClientConns returns l limiting the connections of each client. The
+// connections of l, or for a TLS listener the connections they are layered
+// on, must be accepted from conns, as returned by clientConns; TLS
+// connections are accepted after the handshake so clients can be identified
+// by their certificates.
+func limitClientConns(l, conns net.Listener, cl *etcdserver.ClientLimiter, gw *gatewayConns) net.Listener {
+	ccl, ok := conns.(*clientConnListener)
+	if !ok || !cl.Limited(etcdserver.ClientConnection) {
+		return l
+	}
+	return &clientLimitListener{Listener: l, conns: ccl, cl: cl, gw: gw}
+}
+
+func (l *clientLimitListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.gw.take(c.RemoteAddr()) {
+			return c, nil
+		}
+		cc, ok := c.(*clientConn)
+		var cs *tls.ConnectionState
+		if tc, isTLS := c.(*tls.Conn); isTLS {
+			st := tc.ConnectionState()
+			cs = &st
+			cc, ok = l.conns.take(c.RemoteAddr())
+		}
+		if !ok {
+			// not set up for limits; serve it unlimited
+			plog.Warningf("cannot limit the connections of %q", c.RemoteAddr().String())
+			return c, nil
+		}
+
+		id := etcdserver.ClientConnIdentity(cs, c.RemoteAddr())
+		if err = l.cl.Acquire(etcdserver.ClientConnection, id); err != nil {
+			plog.Infof("rejected connection from %q (%v)", c.RemoteAddr().String(), err)
+			c.Close()
+			continue
+		}
+		release := func() { l.cl.Release(etcdserver.ClientConnection, id) }
+		if !cc.setRelease(release) {
+			release()
+			continue
+		}
+		return c, nil
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver"
+	"github.com/coreos/etcd/pkg/transport"
+)
+
+func TestClientLimitListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := etcdserver.NewClientLimiter(1, 0, 0)
+	ccl := clientConns(ln, cl)
+	testClientLimitListener(t, limitClientConns(ccl, ccl, cl, nil), func() (net.Conn, error) {
+		return net.Dial("tcp", ln.Addr().String())
+	})
+}
+
+// TestClientLimitListenerTLS ensures the connections a TLS listener layers
+// on clientConns are limited and released.
+func TestClientLimitListenerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcd_client_limits_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tlsinfo, err := transport.SelfCert(dir, []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := etcdserver.NewClientLimiter(1, 0, 0)
+	ccl := clientConns(ln, cl)
+	tlsl, err := transport.NewTLSListener(ccl, &tlsinfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testClientLimitListener(t, limitClientConns(tlsl, ccl, cl, nil), func() (net.Conn, error) {
+		return tls.Dial("tcp", ln.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	})
+}
+
+func testClientLimitListener(t *testing.T, l net.Listener, dialer func() (net.Conn, error)) {
+	defer l.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			c, aerr := l.Accept()
+			if aerr != nil {
+				close(accepted)
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	dial := func() net.Conn {
+		c, derr := dialer()
+		if derr != nil {
+			t.Fatal(derr)
+		}
+		return c
+	}
+	c1 := dial()
+	defer c1.Close()
+	s1 := <-accepted
+
+	// the second connection of the client is closed once accepted
+	c2 := dial()
+	defer c2.Close()
+	c2.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c2.Read(make([]byte, 1)); err == nil {
+		t.Fatalf("expected second connection to be closed")
+	}
+	select {
+	case c := <-accepted:
+		t.Fatalf("unexpected accepted connection from %s", c.RemoteAddr())
+	default:
+	}
+
+	// closing the first connection frees the limit
+	s1.Close()
+	c3 := dial()
+	defer c3.Close()
+	select {
+	case c := <-accepted:
+		c.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected third connection to be accepted")
+	}
+}
@@ -15,19 +15,30 @@
 package embed
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/coreos/etcd/etcdserver"
+	"github.com/coreos/etcd/etcdserver/admission"
+	"github.com/coreos/etcd/etcdserver/valueschema"
+	"github.com/coreos/etcd/mvcc"
 	"github.com/coreos/etcd/pkg/cors"
+	"github.com/coreos/etcd/pkg/logutil"
 	"github.com/coreos/etcd/pkg/netutil"
 	"github.com/coreos/etcd/pkg/srv"
+	"github.com/coreos/etcd/pkg/tlsutil"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
 
@@ -47,6 +58,7 @@ const (
 	DefaultGRPCKeepAliveMinTime  = 5 * time.Second
 	DefaultGRPCKeepAliveInterval = 2 * time.Hour
 	DefaultGRPCKeepAliveTimeout  = 20 * time.Second
+	DefaultMaxConcurrentStreams  = math.MaxUint32
 
 	DefaultListenPeerURLs   = "http://localhost:2380"
 	DefaultListenClientURLs = "http://localhost:2379"
@@ -86,6 +98,9 @@ type Config struct {
 	SnapCount               uint64 `json:"snapshot-count"`
 	AutoCompactionRetention string `json:"auto-compaction-retention"`
 	AutoCompactionMode      string `json:"auto-compaction-mode"`
+	// AutoCompactionWindows is a ';' separated list of maintenance windows
+	// like "mon-fri 01:00-05:00" auto compaction runs in. Any time if empty.
+	AutoCompactionWindows string `json:"auto-compaction-windows"`
 
 	// TickMs is the number of milliseconds between heartbeat ticks.
 	// TODO: decouple tickMs and heartbeat tick (current heartbeat tick = 1).
@@ -95,6 +110,38 @@ type Config struct {
 	QuotaBackendBytes int64 `json:"quota-backend-bytes"`
 	MaxTxnOps         uint  `json:"max-txn-ops"`
 	MaxRequestBytes   uint  `json:"max-request-bytes"`
+	// MaxValueBytes is the maximum size of a value a put may store,
+	// separate from MaxRequestBytes. 0 means no limit.
+	MaxValueBytes uint `json:"max-value-bytes"`
+	// MaxProposalBacklogBytes is the size of the proposals waiting to be
+	// applied past which client proposals are rejected. 0 means no limit.
+	MaxProposalBacklogBytes uint `json:"max-proposal-backlog-bytes"`
+
+	// MaxWatchers is the maximum number of watchers of the member.
+	// 0 means no limit.
+	MaxWatchers uint `json:"max-watchers"`
+	// MaxWatchersPerStream is the maximum number of watchers of a single
+	// watch stream. 0 means no limit.
+	MaxWatchersPerStream uint `json:"max-watchers-per-stream"`
+
+	// MaxConnectionsPerClient, MaxStreamsPerClient and MaxWatchersPerClient
+	// are the maximum number of client connections, gRPC streams and
+	// watchers each client may hold. Clients are identified by their auth
+	// user, their certificate common name or else their remote IP.
+	// 0 means no limit.
+	MaxConnectionsPerClient uint `json:"max-connections-per-client"`
+	MaxStreamsPerClient     uint `json:"max-streams-per-client"`
+	MaxWatchersPerClient    uint `json:"max-watchers-per-client"`
+
+	// BackendBatchInterval is the maximum time before committing the
+	// backend transaction. 0 means use the default.
+	BackendBatchInterval time.Duration `json:"backend-batch-interval"`
+	// BackendBatchLimit is the maximum operations before committing the
+	// backend transaction. 0 means use the default.
+	BackendBatchLimit int `json:"backend-batch-limit"`
+	// BackendPreallocSize is the size of the chunks the disk space of the
+	// backend file is preallocated in as it grows. 0 disables preallocation.
+	BackendPreallocSize int64 `json:"backend-prealloc-size"`
 
 	// gRPC server options
 
@@ -103,8 +150,12 @@ type Config struct {
 	// sends goaway and closes the connection (errors: too_many_pings,
 	// http2.ErrCodeEnhanceYourCalm). When too slow, nothing happens.
 	// Server expects client pings only when there is any active streams
-	// (PermitWithoutStream is set false).
+	// unless GRPCKeepAlivePermitWithoutStream is set.
 	GRPCKeepAliveMinTime time.Duration `json:"grpc-keepalive-min-time"`
+	// GRPCKeepAlivePermitWithoutStream allows clients to ping the server
+	// while they have no active streams. When false, such pings count as
+	// too fast and the server closes the connection.
+	GRPCKeepAlivePermitWithoutStream bool `json:"grpc-keepalive-permit-without-stream"`
 	// GRPCKeepAliveInterval is the frequency of server-to-client ping
 	// to check if a connection is alive. Close a non-responsive connection
 	// after an additional duration of Timeout. 0 to disable.
@@ -112,6 +163,21 @@ type Config struct {
 	// GRPCKeepAliveTimeout is the additional duration of wait
 	// before closing a non-responsive connection. 0 to disable.
 	GRPCKeepAliveTimeout time.Duration `json:"grpc-keepalive-timeout"`
+	// MaxConcurrentStreams is the maximum number of concurrent streams
+	// each client connection may open, bounding the streams a single
+	// misbehaving client can leak.
+	MaxConcurrentStreams uint32 `json:"max-concurrent-streams"`
+	// GRPCMaxRecvMsgSize is the maximum size in bytes of a message the
+	// gRPC server receives. It must be at least MaxRequestBytes. 0 uses
+	// MaxRequestBytes plus room for the gRPC overhead.
+	GRPCMaxRecvMsgSize uint `json:"grpc-max-recv-msg-size"`
+	// GRPCMaxSendMsgSize is the maximum size in bytes of a message the
+	// gRPC server sends, such as a range response. 0 means math.MaxInt32.
+	GRPCMaxSendMsgSize uint `json:"grpc-max-send-msg-size"`
+	// GracefulShutdownTimeout is how long Close waits for in-flight client
+	// requests before closing their connections. 0 uses the request timeout
+	// derived from the election timeout.
+	GracefulShutdownTimeout time.Duration `json:"graceful-shutdown-timeout"`
 
 	// clustering
 
@@ -124,6 +190,19 @@ type Config struct {
 	InitialClusterToken string `json:"initial-cluster-token"`
 	StrictReconfigCheck bool   `json:"strict-reconfig-check"`
 	EnableV2            bool   `json:"enable-v2"`
+	// ReadOnly starts the member refusing client writes. The mode can be
+	// changed at runtime through the /config/local/read-only endpoint.
+	ReadOnly bool `json:"read-only"`
+
+	// PrefixQuotas is a ',' separated list of 'prefix=bytes:keys' quotas
+	// limiting the keys stored under each prefix, e.g.
+	// '/tenant-a/=1048576:1000'. A limit of 0 is unlimited.
+	PrefixQuotas string `json:"prefix-quotas"`
+
+	// MemoryBudget is the approximate memory in bytes the key index, watch
+	// buffers and raft log may hold before the member rejects new watchers
+	// and large ranges. 0 is unlimited.
+	MemoryBudget int64 `json:"memory-budget"`
 
 	// security
 
@@ -132,6 +211,21 @@ type Config struct {
 	PeerTLSInfo   transport.TLSInfo
 	PeerAutoTLS   bool
 
+	// ClientURLTLSInfo overrides ClientTLSInfo for the listen client URLs it
+	// holds, keyed by URL, so that e.g. a localhost URL for a sidecar can
+	// skip client cert auth while external URLs require it.
+	ClientURLTLSInfo map[string]transport.TLSInfo `json:"-"`
+
+	// CipherSuites is a list of supported TLS cipher suites between
+	// client/server and peers. If empty, Go auto-populates the list.
+	// Note that cipher suites are prioritized in the given order.
+	CipherSuites []string `json:"cipher-suites"`
+	// TLSMinVersion and TLSMaxVersion bound the TLS versions, e.g. "TLS1.2",
+	// between client/server and peers. Empty leaves the minimum at TLS1.2
+	// and the maximum at the latest version Go supports.
+	TLSMinVersion string `json:"tls-min-version"`
+	TLSMaxVersion string `json:"tls-max-version"`
+
 	// debug
 
 	Debug                 bool   `json:"debug"`
@@ -157,6 +251,24 @@ type Config struct {
 	//	}
 	//	embed.StartEtcd(cfg)
 	ServiceRegister func(*grpc.Server) `json:"-"`
+	// TraceExporter receives traces of client requests through the server
+	// stages (raft agreement, proposal, apply) for embedding applications
+	// to forward to their tracing system. Tracing is disabled if nil.
+	TraceExporter traceutil.Exporter `json:"-"`
+	// Logger receives the log entries of the server, with their structured
+	// fields (e.g., member ID, revision), for embedding applications to
+	// forward to their logging system. capnslog formats the entries of the
+	// whole process, so the Logger also receives those of other servers
+	// embedded in the same process. The capnslog formatter is left as is if
+	// nil.
+	Logger logutil.Logger `json:"-"`
+	// ValueIndexes are value indexes with their own extract functions for
+	// embedding applications, maintained along with the
+	// ExperimentalValueIndexes. Experimental.
+	ValueIndexes []mvcc.ValueIndex `json:"-"`
+	// AdmissionHooks are admission hooks of embedding applications, run on
+	// client writes after the ExperimentalAdmissionHooks. Experimental.
+	AdmissionHooks []admission.Hook `json:"-"`
 
 	// auth
 
@@ -166,6 +278,67 @@ type Config struct {
 
 	ExperimentalCorruptCheckTime time.Duration `json:"experimental-corrupt-check-time"`
 	ExperimentalEnableV2V3       string        `json:"experimental-enable-v2v3"`
+	// ExperimentalWarningApplyDuration is the time after which a client
+	// request is logged as slow. Every request is traced while it is set, so
+	// it is 0, disabled, by default.
+	ExperimentalWarningApplyDuration time.Duration `json:"experimental-warning-apply-duration"`
+	// ExperimentalFeatures is a comma separated list of Feature=bool pairs
+	// toggling experimental server features, e.g. "NoLeaderHeader=false".
+	ExperimentalFeatures string `json:"experimental-feature"`
+	// ExperimentalOpLog is the path of a file where the server appends a
+	// JSON record of the invocation and the return of every unary client
+	// request. Empty to disable.
+	ExperimentalOpLog string `json:"experimental-op-log"`
+	// ExperimentalKeyIndexSaveInterval is the time between saves of the
+	// key index to the backend, so restarts only replay the revisions
+	// written since the last save. 0 to disable.
+	ExperimentalKeyIndexSaveInterval time.Duration `json:"experimental-key-index-save-interval"`
+	// ExperimentalMaxUnsyncedWatchers is the number of watchers catching up
+	// with past revisions past which new watches on past revisions are
+	// refused with a retry hint. 0 means no limit.
+	ExperimentalMaxUnsyncedWatchers uint `json:"experimental-max-unsynced-watchers"`
+	// ExperimentalWatchEventHistorySize is the number of events of the
+	// latest revisions kept in memory, so that watchers a few revisions
+	// behind sync without reading the backend. 0 to disable.
+	ExperimentalWatchEventHistorySize uint `json:"experimental-watch-event-history-size"`
+	// ExperimentalWatchEventHistoryMaxAge drops events older than it from
+	// the event history. 0 means no age limit.
+	ExperimentalWatchEventHistoryMaxAge time.Duration `json:"experimental-watch-event-history-max-age"`
+	// ExperimentalWatchAckWindow is the number of unacknowledged responses
+	// the server sends to each watcher of watch streams whose client
+	// acknowledges the responses it processed. 0 to disable.
+	ExperimentalWatchAckWindow uint `json:"experimental-watch-ack-window"`
+	// ExperimentalWALDSync opens the WAL files appended to with O_DSYNC
+	// where supported, so each write reaches stable storage as it returns.
+	ExperimentalWALDSync bool `json:"experimental-wal-dsync"`
+	// ExperimentalBackendDSync opens the backend file with O_DSYNC where
+	// supported, so each page boltdb writes reaches stable storage as it
+	// returns.
+	ExperimentalBackendDSync bool `json:"experimental-backend-dsync"`
+	// ExperimentalValueIndexes is a ',' separated list of value indexes of
+	// the form 'name=prefix:pointer', indexing the JSON values of the keys
+	// under prefix by the field at the JSON pointer, e.g.
+	// 'by-owner=/jobs/:/owner'.
+	ExperimentalValueIndexes string `json:"experimental-value-indexes"`
+	// ExperimentalAdmissionHooks is a ',' separated list of the names of
+	// the compiled-in admission hooks to run on client writes, in order.
+	ExperimentalAdmissionHooks string `json:"experimental-admission-hooks"`
+	// ExperimentalValueSchemas is a ',' separated list of schemas of the
+	// form 'prefix=file' the values of the keys under prefix must conform
+	// to. The file is a JSON Schema document, or a protobuf descriptor set
+	// if suffixed by '#' and the name of a message type.
+	ExperimentalValueSchemas string `json:"experimental-value-schemas"`
+	// ExperimentalApplyWorkers is the number of goroutines decoding
+	// committed entries and evaluating the compares of independent txns
+	// before the entries are applied in order. Less than 2 to disable.
+	ExperimentalApplyWorkers int `json:"experimental-apply-workers"`
+	// ExperimentalSnapshotCatchUpEntries is the number of entries kept in
+	// the raft log behind the index it is compacted to, for slow followers
+	// to catch up from without a snapshot.
+	ExperimentalSnapshotCatchUpEntries uint64 `json:"experimental-snapshot-catchup-entries"`
+	// ExperimentalWitness starts the member as a witness, which votes but
+	// stores no key-value data and never becomes the leader.
+	ExperimentalWitness bool `json:"experimental-witness"`
 }
 
 // configYAML holds the config suitable for yaml parsing
@@ -183,6 +356,8 @@ type configJSON struct {
 	ACUrlsJSON         string         `json:"advertise-client-urls"`
 	ClientSecurityJSON securityConfig `json:"client-transport-security"`
 	PeerSecurityJSON   securityConfig `json:"peer-transport-security"`
+
+	ClientURLSecurityJSON map[string]securityConfig `json:"client-url-transport-security"`
 }
 
 type securityConfig struct {
@@ -211,6 +386,7 @@ func NewConfig() *Config {
 		GRPCKeepAliveMinTime:  DefaultGRPCKeepAliveMinTime,
 		GRPCKeepAliveInterval: DefaultGRPCKeepAliveInterval,
 		GRPCKeepAliveTimeout:  DefaultGRPCKeepAliveTimeout,
+		MaxConcurrentStreams:  DefaultMaxConcurrentStreams,
 		TickMs:                100,
 		ElectionMs:            1000,
 		LPUrls:                []url.URL{*lpurl},
@@ -223,6 +399,8 @@ func NewConfig() *Config {
 		Metrics:               "basic",
 		EnableV2:              true,
 		AuthToken:             "simple",
+
+		ExperimentalSnapshotCatchUpEntries: etcdserver.DefaultSnapshotCatchUpEntries,
 	}
 	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
 	return cfg
@@ -236,6 +414,61 @@ func ConfigFromFile(path string) (*Config, error) {
 	return &cfg.Config, nil
 }
 
+// UnknownConfigFileKeys returns the top level keys of the YAML configuration
+// b that are neither Config options nor fields of the given extra structs,
+// so misspelled options can be rejected instead of silently ignored.
+func UnknownConfigFileKeys(b []byte, extra ...interface{}) ([]string, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err = json.Unmarshal(j, &m); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]struct{})
+	for _, v := range append([]interface{}{&configYAML{}}, extra...) {
+		addJSONKeys(known, reflect.TypeOf(v))
+	}
+	var unknown []string
+	for k := range m {
+		if _, ok := known[strings.ToLower(k)]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// addJSONKeys adds the lower cased keys encoding/json decodes into the
+// fields of struct type t.
+func addJSONKeys(keys map[string]struct{}, t reflect.Type) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addJSONKeys(keys, f.Type)
+			continue
+		}
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[strings.ToLower(name)] = struct{}{}
+	}
+}
+
 func (cfg *configYAML) configFromFile(path string) error {
 	b, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -314,6 +547,17 @@ func (cfg *configYAML) configFromFile(path string) error {
 	copySecurityDetails(&cfg.PeerTLSInfo, &cfg.PeerSecurityJSON)
 	cfg.ClientAutoTLS = cfg.ClientSecurityJSON.AutoTLS
 	cfg.PeerAutoTLS = cfg.PeerSecurityJSON.AutoTLS
+	for u, ysc := range cfg.ClientURLSecurityJSON {
+		if ysc.AutoTLS {
+			return fmt.Errorf("client-url-transport-security: auto-tls is not supported for %s", u)
+		}
+		var tls transport.TLSInfo
+		copySecurityDetails(&tls, &ysc)
+		if cfg.ClientURLTLSInfo == nil {
+			cfg.ClientURLTLSInfo = make(map[string]transport.TLSInfo)
+		}
+		cfg.ClientURLTLSInfo[u] = tls
+	}
 
 	return cfg.Validate()
 }
@@ -368,6 +612,34 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("--election-timeout[%vms] is too long, and should be set less than %vms", cfg.ElectionMs, maxElectionMs)
 	}
 
+	if cfg.GRPCMaxRecvMsgSize != 0 && cfg.GRPCMaxRecvMsgSize < cfg.MaxRequestBytes {
+		return fmt.Errorf("--grpc-max-recv-msg-size[%v] should be at least --max-request-bytes[%v]", cfg.GRPCMaxRecvMsgSize, cfg.MaxRequestBytes)
+	}
+	if cfg.GRPCMaxRecvMsgSize > math.MaxInt32 || cfg.GRPCMaxSendMsgSize > math.MaxInt32 {
+		return fmt.Errorf("--grpc-max-recv-msg-size and --grpc-max-send-msg-size should be at most %v", math.MaxInt32)
+	}
+
+	if err := etcdserver.NewFeatureGate().Set(cfg.ExperimentalFeatures); err != nil {
+		return fmt.Errorf("--experimental-feature: %v", err)
+	}
+	if _, err := etcdserver.ParseValueIndexes(cfg.ExperimentalValueIndexes); err != nil {
+		return fmt.Errorf("--experimental-value-indexes: %v", err)
+	}
+	if _, err := admission.Lookup(cfg.ExperimentalAdmissionHooks); err != nil {
+		return fmt.Errorf("--experimental-admission-hooks: %v", err)
+	}
+	if _, err := valueschema.Parse(cfg.ExperimentalValueSchemas); err != nil {
+		return fmt.Errorf("--experimental-value-schemas: %v", err)
+	}
+	if err := cfg.updateTLSSettings(&transport.TLSInfo{}); err != nil {
+		return err
+	}
+	for u := range cfg.ClientURLTLSInfo {
+		if !containsURL(cfg.LCUrls, u) {
+			return fmt.Errorf("TLS settings given for %s, which is not a listen client url", u)
+		}
+	}
+
 	// check this last since proxying in etcdmain may make this OK
 	if cfg.LCUrls != nil && cfg.ACUrls == nil {
 		return ErrUnsetAdvertiseClientURLsFlag
@@ -376,6 +648,83 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// updateTLSSettings sets the cipher suites and TLS versions of the
+// configuration on info.
+func (cfg *Config) updateTLSSettings(info *transport.TLSInfo) error {
+	var suites []uint16
+	for _, s := range cfg.CipherSuites {
+		v, ok := tlsutil.GetCipherSuite(s)
+		if !ok {
+			return fmt.Errorf("--cipher-suites: unknown cipher suite %q", s)
+		}
+		suites = append(suites, v)
+	}
+	min, max, err := parseTLSVersions(cfg.TLSMinVersion, cfg.TLSMaxVersion)
+	if err != nil {
+		return err
+	}
+	if tlsutil.FIPSMode {
+		if err = tlsutil.CheckFIPS(suites, min); err != nil {
+			return err
+		}
+	}
+	info.CipherSuites, info.MinVersion, info.MaxVersion = suites, min, max
+	return nil
+}
+
+func parseTLSVersions(minStr, maxStr string) (min, max uint16, err error) {
+	var ok bool
+	if minStr != "" {
+		if min, ok = tlsutil.GetTLSVersion(minStr); !ok {
+			return 0, 0, fmt.Errorf("--tls-min-version: unknown TLS version %q", minStr)
+		}
+	}
+	if maxStr != "" {
+		if max, ok = tlsutil.GetTLSVersion(maxStr); !ok {
+			return 0, 0, fmt.Errorf("--tls-max-version: unknown TLS version %q", maxStr)
+		}
+		if max < min || (min == 0 && max < tls.VersionTLS12) {
+			return 0, 0, fmt.Errorf("--tls-max-version %q is older than the minimum TLS version", maxStr)
+		}
+	}
+	return min, max, nil
+}
+
+// clientTLSInfo returns the TLS settings for the listen client URL u.
+func (cfg *Config) clientTLSInfo(u url.URL) transport.TLSInfo {
+	info, ok := cfg.ClientURLTLSInfo[u.String()]
+	if !ok {
+		return cfg.ClientTLSInfo
+	}
+	if info.HandshakeFailure == nil {
+		info.HandshakeFailure = cfg.ClientTLSInfo.HandshakeFailure
+	}
+	return info
+}
+
+// clientCertAuthEnabled returns true if any listen client URL requires
+// client certificates.
+func (cfg *Config) clientCertAuthEnabled() bool {
+	if cfg.ClientTLSInfo.ClientCertAuth {
+		return true
+	}
+	for _, info := range cfg.ClientURLTLSInfo {
+		if info.ClientCertAuth {
+			return true
+		}
+	}
+	return false
+}
+
+func containsURL(urls []url.URL, s string) bool {
+	for _, u := range urls {
+		if u.String() == s {
+			return true
+		}
+	}
+	return false
+}
+
 // PeerURLsMapAndToken sets up an initial peer URLsMap and cluster token for bootstrap or discovery.
 func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, token string, err error) {
 	token = cfg.InitialClusterToken
@@ -396,12 +745,19 @@ func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, tok
 			plog.Noticef("got bootstrap from DNS for etcd-server at %s", s)
 		}
 		clusterStr := strings.Join(clusterStrs, ",")
-		if strings.Contains(clusterStr, "https://") && cfg.PeerTLSInfo.CAFile == "" {
-			// SRV targets have subdomains under the given DNSCluster, so wildcard matching
-			// is needed.
-			cfg.PeerTLSInfo.ServerName = "*." + cfg.DNSCluster
-		}
 		urlsmap, err = types.NewURLsMap(clusterStr)
+		if err != nil {
+			return nil, "", err
+		}
+		if cfg.PeerTLSInfo.CAFile == "" {
+			// SRV targets must have subdomains under the given DNSCluster so
+			// forged records cannot point to a host with a valid certificate
+			// in another domain. Each peer certificate is then verified
+			// against its own SRV target.
+			if err = checkSRVTargetDomain(urlsmap, cfg.DNSCluster); err != nil {
+				return nil, "", err
+			}
+		}
 		// only etcd member must belong to the discovered cluster.
 		// proxy does not need to belong to the discovered cluster.
 		if which == "etcd" {
@@ -416,6 +772,24 @@ func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, tok
 	return urlsmap, token, err
 }
 
+// checkSRVTargetDomain ensures the hosts of all discovered https peer URLs are
+// under the given discovery domain.
+func checkSRVTargetDomain(urlsmap types.URLsMap, domain string) error {
+	domain = strings.TrimSuffix(domain, ".")
+	for name, urls := range urlsmap {
+		for _, u := range urls {
+			if u.Scheme != "https" {
+				continue
+			}
+			host := u.Hostname()
+			if host != domain && !strings.HasSuffix(host, "."+domain) {
+				return fmt.Errorf("SRV target %q for %q is not under discovery domain %q", host, name, domain)
+			}
+		}
+	}
+	return nil
+}
+
 func (cfg Config) InitialClusterFromName(name string) (ret string) {
 	if len(cfg.APUrls) == 0 {
 		return ""
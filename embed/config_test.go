@@ -15,13 +15,16 @@
 package embed
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/coreos/etcd/pkg/transport"
+	"github.com/coreos/etcd/pkg/types"
 
 	"github.com/ghodss/yaml"
 )
@@ -148,3 +151,134 @@ func mustCreateCfgFile(t *testing.T, b []byte) *os.File {
 	}
 	return tmpfile
 }
+
+func TestCheckSRVTargetDomain(t *testing.T) {
+	tests := []struct {
+		cluster string
+		domain  string
+		werr    bool
+	}{
+		{"a=https://infra0.example.com:2380,b=https://infra1.example.com:2380", "example.com", false},
+		{"a=https://infra0.example.com:2380", "example.com.", false},
+		{"a=https://example.com:2380", "example.com", false},
+		{"a=http://infra0.other.com:2380", "example.com", false},
+		{"a=https://infra0.other.com:2380", "example.com", true},
+		{"a=https://infra0.badexample.com:2380", "example.com", true},
+	}
+	for i, tt := range tests {
+		urlsmap, err := types.NewURLsMap(tt.cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = checkSRVTargetDomain(urlsmap, tt.domain)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: expected error %v, got %v", i, tt.werr, err)
+		}
+	}
+}
+
+func TestUnknownConfigFileKeys(t *testing.T) {
+	b := []byte("name: infra0\nsnapshot-count: 10\nsnapshot-cnt: 10\nproxy: off\nclient-transport-security:\n  cert-file: a\n")
+	unknown, err := UnknownConfigFileKeys(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := []string{"proxy", "snapshot-cnt"}; !reflect.DeepEqual(unknown, w) {
+		t.Fatalf("unknown = %v, want %v", unknown, w)
+	}
+	extra := struct {
+		ProxyJSON string `json:"proxy"`
+	}{}
+	unknown, err = UnknownConfigFileKeys(b, &extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := []string{"snapshot-cnt"}; !reflect.DeepEqual(unknown, w) {
+		t.Fatalf("unknown = %v, want %v", unknown, w)
+	}
+}
+
+func TestUpdateTLSSettings(t *testing.T) {
+	tests := []struct {
+		suites   []string
+		min, max string
+
+		winfo transport.TLSInfo
+		werr  bool
+	}{
+		{nil, "", "", transport.TLSInfo{}, false},
+		{
+			[]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_AES_128_GCM_SHA256"}, "TLS1.1", "TLS1.2",
+			transport.TLSInfo{
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_AES_128_GCM_SHA256},
+				MinVersion:   tls.VersionTLS11,
+				MaxVersion:   tls.VersionTLS12,
+			},
+			false,
+		},
+		{[]string{"TLS_UNKNOWN"}, "", "", transport.TLSInfo{}, true},
+		{nil, "TLS9", "", transport.TLSInfo{}, true},
+		{nil, "TLS1.2", "TLS1.1", transport.TLSInfo{}, true},
+		// the default minimum is TLS1.2
+		{nil, "", "TLS1.1", transport.TLSInfo{}, true},
+	}
+	for i, tt := range tests {
+		cfg := NewConfig()
+		cfg.CipherSuites, cfg.TLSMinVersion, cfg.TLSMaxVersion = tt.suites, tt.min, tt.max
+		var info transport.TLSInfo
+		err := cfg.updateTLSSettings(&info)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if err == nil && !reflect.DeepEqual(info, tt.winfo) {
+			t.Errorf("#%d: info = %+v, want %+v", i, info, tt.winfo)
+		}
+	}
+}
+
+func TestConfigFileClientURLSecurity(t *testing.T) {
+	ctls := securityConfig{CertFile: "ccert", KeyFile: "ckey", CertAuth: true, TrustedCAFile: "cca"}
+	ltls := securityConfig{CertFile: "lcert", KeyFile: "lkey"}
+	yc := struct {
+		LCUrls         string                    `json:"listen-client-urls"`
+		ACUrls         string                    `json:"advertise-client-urls"`
+		ClientSecurity securityConfig            `json:"client-transport-security"`
+		URLSecurity    map[string]securityConfig `json:"client-url-transport-security"`
+	}{
+		"https://10.0.0.1:2379,https://127.0.0.1:2379",
+		"https://10.0.0.1:2379",
+		ctls,
+		map[string]securityConfig{"https://127.0.0.1:2379": ltls},
+	}
+	b, err := yaml.Marshal(&yc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile := mustCreateCfgFile(t, b)
+	defer os.Remove(tmpfile.Name())
+
+	cfg, err := ConfigFromFile(tmpfile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info := cfg.clientTLSInfo(cfg.LCUrls[0]); !ctls.equals(&info) {
+		t.Errorf("TLS for %s = %v, want %v", cfg.LCUrls[0].String(), info, ctls)
+	}
+	if info := cfg.clientTLSInfo(cfg.LCUrls[1]); !ltls.equals(&info) || info.ClientCertAuth {
+		t.Errorf("TLS for %s = %v, want %v", cfg.LCUrls[1].String(), info, ltls)
+	}
+	if !cfg.clientCertAuthEnabled() {
+		t.Errorf("expected client cert auth to be enabled")
+	}
+
+	// overrides must name a listen client url
+	yc.URLSecurity = map[string]securityConfig{"https://127.0.0.1:2479": ltls}
+	if b, err = yaml.Marshal(&yc); err != nil {
+		t.Fatal(err)
+	}
+	tmpfile2 := mustCreateCfgFile(t, b)
+	defer os.Remove(tmpfile2.Name())
+	if _, err = ConfigFromFile(tmpfile2.Name()); err == nil {
+		t.Fatalf("expected error for TLS settings of an unknown url")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"sync"
 	"time"
@@ -35,6 +36,8 @@ import (
 	"github.com/coreos/etcd/etcdserver/api/v3rpc"
 	"github.com/coreos/etcd/pkg/cors"
 	"github.com/coreos/etcd/pkg/debugutil"
+	"github.com/coreos/etcd/pkg/fileutil"
+	"github.com/coreos/etcd/pkg/logutil"
 	runtimeutil "github.com/coreos/etcd/pkg/runtime"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
@@ -69,6 +72,8 @@ type Etcd struct {
 	metricsListeners []net.Listener
 	Server           *etcdserver.EtcdServer
 
+	opLog *os.File
+
 	cfg   Config
 	stopc chan struct{}
 	errc  chan error
@@ -90,6 +95,9 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	if err = inCfg.Validate(); err != nil {
 		return nil, err
 	}
+	if inCfg.Logger != nil {
+		capnslog.SetFormatter(logutil.NewLoggerFormatter(inCfg.Logger, logutil.Field{Key: "name", Value: inCfg.Name}))
+	}
 	serving := false
 	e = &Etcd{cfg: *inCfg, stopc: make(chan struct{})}
 	cfg := &e.cfg
@@ -147,6 +155,12 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		}
 	}
 
+	if len(cfg.ExperimentalOpLog) != 0 {
+		if e.opLog, err = os.OpenFile(cfg.ExperimentalOpLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, fileutil.PrivateFileMode); err != nil {
+			return e, fmt.Errorf("error opening operation log: %v", err)
+		}
+	}
+
 	srvcfg := etcdserver.ServerConfig{
 		Name:                    cfg.Name,
 		ClientURLs:              cfg.ACUrls,
@@ -167,14 +181,55 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		ElectionTicks:           cfg.ElectionTicks(),
 		AutoCompactionRetention: autoCompactionRetention,
 		AutoCompactionMode:      cfg.AutoCompactionMode,
+		AutoCompactionWindows:   cfg.AutoCompactionWindows,
 		QuotaBackendBytes:       cfg.QuotaBackendBytes,
+		BackendBatchInterval:    cfg.BackendBatchInterval,
+		BackendBatchLimit:       cfg.BackendBatchLimit,
+		BackendPreallocSize:     cfg.BackendPreallocSize,
 		MaxTxnOps:               cfg.MaxTxnOps,
 		MaxRequestBytes:         cfg.MaxRequestBytes,
+		MaxValueBytes:           cfg.MaxValueBytes,
+		MaxProposalBacklogBytes: cfg.MaxProposalBacklogBytes,
+		MaxWatchers:             cfg.MaxWatchers,
+		MaxWatchersPerStream:    cfg.MaxWatchersPerStream,
+		MaxConnectionsPerClient: cfg.MaxConnectionsPerClient,
+		MaxStreamsPerClient:     cfg.MaxStreamsPerClient,
+		MaxWatchersPerClient:    cfg.MaxWatchersPerClient,
+		MaxConcurrentStreams:    cfg.MaxConcurrentStreams,
+		MaxRecvMsgSize:          cfg.GRPCMaxRecvMsgSize,
+		MaxSendMsgSize:          cfg.GRPCMaxSendMsgSize,
 		StrictReconfigCheck:     cfg.StrictReconfigCheck,
-		ClientCertAuthEnabled:   cfg.ClientTLSInfo.ClientCertAuth,
+		ReadOnly:                cfg.ReadOnly,
+		PrefixQuotas:            cfg.PrefixQuotas,
+		MemoryBudget:            cfg.MemoryBudget,
+		ClientCertAuthEnabled:   cfg.clientCertAuthEnabled(),
 		AuthToken:               cfg.AuthToken,
 		CorruptCheckTime:        cfg.ExperimentalCorruptCheckTime,
-	}
+		KeyIndexSaveInterval:    cfg.ExperimentalKeyIndexSaveInterval,
+		MaxUnsyncedWatchers:     cfg.ExperimentalMaxUnsyncedWatchers,
+		WatchEventHistorySize:   cfg.ExperimentalWatchEventHistorySize,
+		WatchEventHistoryMaxAge: cfg.ExperimentalWatchEventHistoryMaxAge,
+		WatchAckWindow:          cfg.ExperimentalWatchAckWindow,
+		WALDSync:                cfg.ExperimentalWALDSync,
+		BackendDSync:            cfg.ExperimentalBackendDSync,
+		ApplyWorkers:            cfg.ExperimentalApplyWorkers,
+		SnapshotCatchUpEntries:  cfg.ExperimentalSnapshotCatchUpEntries,
+		Witness:                 cfg.ExperimentalWitness,
+		ValueIndexes:            cfg.ExperimentalValueIndexes,
+		CustomValueIndexes:      cfg.ValueIndexes,
+		AdmissionHooks:          cfg.ExperimentalAdmissionHooks,
+		CustomAdmissionHooks:    cfg.AdmissionHooks,
+		ValueSchemas:            cfg.ExperimentalValueSchemas,
+		TraceExporter:           cfg.TraceExporter,
+		WarningApplyDuration:    cfg.ExperimentalWarningApplyDuration,
+		FeatureGate:             etcdserver.NewFeatureGate(),
+	}
+	// a nil *os.File must not become a non-nil io.Writer
+	if e.opLog != nil {
+		srvcfg.OpLog = e.opLog
+	}
+	// validated by cfg.Validate
+	srvcfg.FeatureGate.Set(cfg.ExperimentalFeatures)
 
 	if e.Server, err = etcdserver.NewServer(srvcfg); err != nil {
 		return
@@ -225,9 +280,16 @@ func (e *Etcd) Config() Config {
 	return e.cfg
 }
 
+// Close gracefully shuts down the server and all its listeners. Leadership
+// is transferred away and watch and lease keepalive streams are closed
+// before the gRPC servers drain in-flight requests.
 func (e *Etcd) Close() {
 	e.closeOnce.Do(func() { close(e.stopc) })
 
+	if e.Server != nil {
+		e.Server.Drain()
+	}
+
 	for _, sctx := range e.sctxs {
 		for gs := range sctx.grpcServerC {
 			e.stopGRPCServer(gs)
@@ -259,11 +321,17 @@ func (e *Etcd) Close() {
 			cancel()
 		}
 	}
+
+	if e.opLog != nil {
+		e.opLog.Close()
+	}
 }
 
 func (e *Etcd) stopGRPCServer(gs *grpc.Server) {
 	timeout := 2 * time.Second
-	if e.Server != nil {
+	if e.cfg.GracefulShutdownTimeout > 0 {
+		timeout = e.cfg.GracefulShutdownTimeout
+	} else if e.Server != nil {
 		timeout = e.Server.Cfg.ReqTimeout()
 	}
 	ch := make(chan struct{})
@@ -291,6 +359,9 @@ func startPeerListeners(cfg *Config) (peers []*peerListener, err error) {
 	if err = cfg.PeerSelfCert(); err != nil {
 		plog.Fatalf("could not get certs (%v)", err)
 	}
+	if err = cfg.updateTLSSettings(&cfg.PeerTLSInfo); err != nil {
+		return nil, err
+	}
 	if !cfg.PeerTLSInfo.Empty() {
 		plog.Infof("peerTLS: %s", cfg.PeerTLSInfo)
 	}
@@ -335,6 +406,9 @@ func startClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err error) {
 	if err = cfg.ClientSelfCert(); err != nil {
 		plog.Fatalf("could not get certs (%v)", err)
 	}
+	if err = cfg.updateTLSSettings(&cfg.ClientTLSInfo); err != nil {
+		return nil, err
+	}
 	if cfg.EnablePprof {
 		plog.Infof("pprof is enabled under %s", debugutil.HTTPPrefixPProf)
 	}
@@ -343,15 +417,25 @@ func startClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err error) {
 	for _, u := range cfg.LCUrls {
 		sctx := newServeCtx()
 
+		tlsinfo := &cfg.ClientTLSInfo
+		if _, ok := cfg.ClientURLTLSInfo[u.String()]; ok {
+			info := cfg.clientTLSInfo(u)
+			if err = cfg.updateTLSSettings(&info); err != nil {
+				return nil, err
+			}
+			tlsinfo = &info
+			plog.Infof("ClientTLS for %s: %s", u.String(), info)
+		}
+
 		if u.Scheme == "http" || u.Scheme == "unix" {
-			if !cfg.ClientTLSInfo.Empty() {
+			if !tlsinfo.Empty() {
 				plog.Warningf("The scheme of client url %s is HTTP while peer key/cert files are presented. Ignored key/cert files.", u.String())
 			}
-			if cfg.ClientTLSInfo.ClientCertAuth {
+			if tlsinfo.ClientCertAuth {
 				plog.Warningf("The scheme of client url %s is HTTP while client cert auth (--client-cert-auth) is enabled. Ignored client cert auth for this url.", u.String())
 			}
 		}
-		if (u.Scheme == "https" || u.Scheme == "unixs") && cfg.ClientTLSInfo.Empty() {
+		if (u.Scheme == "https" || u.Scheme == "unixs") && tlsinfo.Empty() {
 			return nil, fmt.Errorf("TLS key/cert (--cert-file, --key-file) must be provided for client url %s with HTTPs scheme", u.String())
 		}
 
@@ -364,13 +448,28 @@ func startClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err error) {
 
 		sctx.secure = u.Scheme == "https" || u.Scheme == "unixs"
 		sctx.insecure = !sctx.secure
+		if sctx.secure {
+			sctx.tlsinfo = tlsinfo
+		}
 		if oldctx := sctxs[addr]; oldctx != nil {
+			if sctx.secure && oldctx.secure && oldctx.tlsinfo != sctx.tlsinfo {
+				return nil, fmt.Errorf("client url %s shares its address with another url with different TLS settings", u.String())
+			}
+			if sctx.secure {
+				oldctx.tlsinfo = sctx.tlsinfo
+			}
 			oldctx.secure = oldctx.secure || sctx.secure
 			oldctx.insecure = oldctx.insecure || sctx.insecure
 			continue
 		}
 
-		if sctx.l, err = net.Listen(proto, addr); err != nil {
+		if proto == "unix" {
+			// remove any socket file left behind by an unclean shutdown
+			sctx.l, err = transport.NewUnixListener(addr)
+		} else {
+			sctx.l, err = net.Listen(proto, addr)
+		}
+		if err != nil {
 			return nil, err
 		}
 		// net.Listener will rewrite ipv4 0.0.0.0 to ipv6 [::], breaking
@@ -428,6 +527,20 @@ func (e *Etcd) serve() (err error) {
 		}(pl)
 	}
 
+	// listen for metrics before serving clients so a bad metrics url
+	// fails StartEtcd without leaving client handlers waiting on the server
+	for _, murl := range e.cfg.ListenMetricsUrls {
+		tlsInfo := &e.cfg.ClientTLSInfo
+		if murl.Scheme == "http" {
+			tlsInfo = nil
+		}
+		ml, err := transport.NewListener(murl.Host, murl.Scheme, tlsInfo)
+		if err != nil {
+			return err
+		}
+		e.metricsListeners = append(e.metricsListeners, ml)
+	}
+
 	// Start a client server goroutine for each listen address
 	var h http.Handler
 	if e.Config().EnableV2 {
@@ -448,7 +561,7 @@ func (e *Etcd) serve() (err error) {
 	if e.cfg.GRPCKeepAliveMinTime > time.Duration(0) {
 		gopts = append(gopts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             e.cfg.GRPCKeepAliveMinTime,
-			PermitWithoutStream: false,
+			PermitWithoutStream: e.cfg.GRPCKeepAlivePermitWithoutStream,
 		}))
 	}
 	if e.cfg.GRPCKeepAliveInterval > time.Duration(0) &&
@@ -460,7 +573,7 @@ func (e *Etcd) serve() (err error) {
 	}
 	for _, sctx := range e.sctxs {
 		go func(s *serveCtx) {
-			e.errHandler(s.serve(e.Server, &e.cfg.ClientTLSInfo, h, e.errHandler, gopts...))
+			e.errHandler(s.serve(e.Server, s.tlsinfo, h, e.errHandler, gopts...))
 		}(sctx)
 	}
 
@@ -468,20 +581,11 @@ func (e *Etcd) serve() (err error) {
 		metricsMux := http.NewServeMux()
 		etcdhttp.HandleMetricsHealth(metricsMux, e.Server)
 
-		for _, murl := range e.cfg.ListenMetricsUrls {
-			tlsInfo := &e.cfg.ClientTLSInfo
-			if murl.Scheme == "http" {
-				tlsInfo = nil
-			}
-			ml, err := transport.NewListener(murl.Host, murl.Scheme, tlsInfo)
-			if err != nil {
-				return err
-			}
-			e.metricsListeners = append(e.metricsListeners, ml)
+		for i, murl := range e.cfg.ListenMetricsUrls {
 			go func(u url.URL, ln net.Listener) {
 				plog.Info("listening for metrics on ", u.String())
 				e.errHandler(http.Serve(ln, metricsMux))
-			}(murl, ml)
+			}(murl, e.metricsListeners[i])
 		}
 	}
 
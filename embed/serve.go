@@ -48,6 +48,10 @@ type serveCtx struct {
 	addr     string
 	secure   bool
 	insecure bool
+	// tlsinfo holds the TLS settings of the secure client urls on addr
+	tlsinfo *transport.TLSInfo
+	// gwConns is set while client connections are limited.
+	gwConns *gatewayConns
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -81,6 +85,10 @@ func (sctx *serveCtx) serve(
 	v3c := v3client.New(s)
 	servElection := v3election.NewElectionServer(v3c)
 	servLock := v3lock.NewLockServer(v3c)
+	cl := s.ClientLimiter()
+	if cl.Limited(etcdserver.ClientConnection) {
+		sctx.gwConns = newGatewayConns()
+	}
 
 	if sctx.insecure {
 		gs := v3rpc.Server(s, nil, gopts...)
@@ -90,7 +98,8 @@ func (sctx *serveCtx) serve(
 		if sctx.serviceRegister != nil {
 			sctx.serviceRegister(gs)
 		}
-		grpcl := m.Match(cmux.HTTP2())
+		grpccl := clientConns(m.Match(cmux.HTTP2()), cl)
+		grpcl := limitClientConns(grpccl, grpccl, cl, sctx.gwConns)
 		go func() { errHandler(gs.Serve(grpcl)) }()
 
 		opts := []grpc.DialOption{
@@ -107,7 +116,8 @@ func (sctx *serveCtx) serve(
 			Handler:  httpmux,
 			ErrorLog: logger, // do not log user error
 		}
-		httpl := m.Match(cmux.HTTP1())
+		httpcl := clientConns(m.Match(cmux.HTTP1()), cl)
+		httpl := limitClientConns(httpcl, httpcl, cl, sctx.gwConns)
 		go func() { errHandler(srvhttp.Serve(httpl)) }()
 		plog.Noticef("serving insecure client requests on %s, this is strongly discouraged!", sctx.l.Addr().String())
 	}
@@ -136,10 +146,12 @@ func (sctx *serveCtx) serve(
 			return err
 		}
 
-		tlsl, lerr := transport.NewTLSListener(m.Match(cmux.Any()), tlsinfo)
+		tlscl := clientConns(m.Match(cmux.Any()), cl)
+		tlsl, lerr := transport.NewTLSListener(tlscl, tlsinfo)
 		if lerr != nil {
 			return lerr
 		}
+		tlsl = limitClientConns(tlsl, tlscl, cl, sctx.gwConns)
 		// TODO: add debug flag; enable logging when debug flag is set
 		httpmux := sctx.createMux(gwmux, handler)
 
@@ -178,6 +190,9 @@ type registerHandlerFunc func(context.Context, *gw.ServeMux, *grpc.ClientConn) e
 
 func (sctx *serveCtx) registerGateway(opts []grpc.DialOption) (*gw.ServeMux, error) {
 	ctx := sctx.ctx
+	if sctx.gwConns != nil {
+		opts = append(opts, grpc.WithDialer(sctx.gwConns.dial))
+	}
 	conn, err := grpc.DialContext(ctx, sctx.addr, opts...)
 	if err != nil {
 		return nil, err
@@ -219,13 +234,7 @@ func (sctx *serveCtx) createMux(gwmux *gw.ServeMux, handler http.Handler) *http.
 		"/v3alpha/",
 		wsproxy.WebsocketProxy(
 			gwmux,
-			wsproxy.WithRequestMutator(
-				// Default to the POST method for streams
-				func(incoming *http.Request, outgoing *http.Request) *http.Request {
-					outgoing.Method = "POST"
-					return outgoing
-				},
-			),
+			wsproxy.WithRequestMutator(wsRequestMutator),
 		),
 	)
 	if handler != nil {
@@ -234,6 +243,25 @@ func (sctx *serveCtx) createMux(gwmux *gw.ServeMux, handler http.Handler) *http.
 	return httpmux
 }
 
+// wsRequestMutator prepares the gateway request of a websocket stream, such
+// as a watch, whose frames are the JSON requests and responses of the stream.
+func wsRequestMutator(incoming *http.Request, outgoing *http.Request) *http.Request {
+	// Default to the POST method for streams
+	outgoing.Method = "POST"
+	// Browsers cannot set headers on websockets, so the auth token is sent as
+	// the "Bearer, <token>" subprotocol or the "token" cookie, which the proxy
+	// turns into a bearer token. etcd takes the bare token.
+	token := incoming.Header.Get("Authorization")
+	if t := outgoing.Header.Get("Authorization"); strings.HasPrefix(t, "Bearer ") {
+		token = strings.TrimPrefix(t, "Bearer ")
+	}
+	outgoing.Header.Del("Authorization")
+	if token != "" {
+		outgoing.Header.Set("Authorization", token)
+	}
+	return outgoing
+}
+
 func (sctx *serveCtx) registerUserHandler(s string, h http.Handler) {
 	if sctx.userHandlers[s] != nil {
 		plog.Warningf("path %s already registered by user handler", s)
@@ -16,6 +16,7 @@ package embed
 
 import (
 	"io/ioutil"
+	"net/http"
 	"os"
 	"testing"
 
@@ -36,3 +37,34 @@ func TestStartEtcdWrongToken(t *testing.T) {
 		t.Fatalf("expected %v, got %v", auth.ErrInvalidAuthOpts, err)
 	}
 }
+
+func TestWSRequestMutator(t *testing.T) {
+	tests := []struct {
+		incoming, outgoing string
+
+		wauth string
+	}{
+		{"", "", ""},
+		{"", "Bearer abc.1", "abc.1"},
+		{"abc.1", "", "abc.1"},
+		{"abc.1", "chat", "abc.1"},
+		{"", "chat", ""},
+	}
+	for i, tt := range tests {
+		in, _ := http.NewRequest("GET", "/v3alpha/watch", nil)
+		out, _ := http.NewRequest("GET", "/v3alpha/watch", nil)
+		if tt.incoming != "" {
+			in.Header.Set("Authorization", tt.incoming)
+		}
+		if tt.outgoing != "" {
+			out.Header.Set("Authorization", tt.outgoing)
+		}
+		out = wsRequestMutator(in, out)
+		if out.Method != "POST" {
+			t.Errorf("#%d: method = %s, want POST", i, out.Method)
+		}
+		if auth := out.Header.Get("Authorization"); auth != tt.wauth {
+			t.Errorf("#%d: authorization = %q, want %q", i, auth, tt.wauth)
+		}
+	}
+}
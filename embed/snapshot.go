@@ -0,0 +1,112 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/coreos/etcd/snap"
+)
+
+// LoadSnapshot writes the keys of a snapshot file, as saved by
+// "etcdctl snapshot save" or copied from a member's data directory, into
+// the running server, returning the number of keys written. Only the
+// latest value of each key is loaded, without its lease; the history,
+// leases, members and auth of the snapshot are discarded. It is meant for
+// tests and local debugging against copies of production data, on a
+// server just started without auth.
+func (e *Etcd) LoadSnapshot(ctx context.Context, path string) (int, error) {
+	kvs, err := readSnapshotKVs(path)
+	if err != nil {
+		return 0, err
+	}
+
+	batch := int(e.Server.Cfg.MaxTxnOps)
+	if batch <= 0 {
+		batch = int(DefaultMaxTxnOps)
+	}
+	for i := 0; i < len(kvs); i += batch {
+		end := i + batch
+		if end > len(kvs) {
+			end = len(kvs)
+		}
+		txn := &pb.TxnRequest{Success: make([]*pb.RequestOp, 0, end-i)}
+		for _, kv := range kvs[i:end] {
+			put := &pb.PutRequest{Key: kv.Key, Value: kv.Value}
+			txn.Success = append(txn.Success, &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: put}})
+		}
+		if _, err = e.Server.Txn(ctx, txn); err != nil {
+			return i, err
+		}
+	}
+	return len(kvs), nil
+}
+
+// readSnapshotKVs returns the latest key-values of a snapshot file. The
+// snapshot is read from a copy, so the file itself is left untouched.
+func readSnapshotKVs(path string) ([]mvccpb.KeyValue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	size, err := snap.VerifyDBHash(f)
+	switch err {
+	case nil:
+	case snap.ErrNoDBHash:
+		// copied from a data directory
+		if size, err = f.Seek(0, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	tmp, err := ioutil.TempFile("", "etcd-snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.CopyN(tmp, f, size)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	be := backend.NewDefaultBackend(tmp.Name())
+	defer be.Close()
+	s := mvcc.NewStore(be, &lease.FakeLessor{}, nil)
+	defer s.Close()
+	// an empty end ranges over every key from the key on
+	rr, err := s.Range(context.TODO(), []byte{0}, []byte{}, mvcc.RangeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return rr.KVs, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestLoadSnapshot(t *testing.T) {
+	tdir, err := ioutil.TempDir(os.TempDir(), "load-snapshot-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	e := startTestEtcd(t, filepath.Join(tdir, "src"), 32380)
+	for i := 0; i < 3; i++ {
+		put := &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: []byte("bar")}
+		if _, err = e.Server.Put(context.TODO(), put); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e.Close()
+	dbpath := filepath.Join(tdir, "src", "member", "snap", "db")
+
+	e = startTestEtcd(t, filepath.Join(tdir, "dst"), 32390)
+	defer e.Close()
+	// small batches to load over several txns
+	e.Server.Cfg.MaxTxnOps = 2
+	n, err := e.LoadSnapshot(context.TODO(), dbpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("loaded %d keys, want 3", n)
+	}
+	rr, err := e.Server.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rr.Kvs) != 3 {
+		t.Fatalf("got %d keys, want 3", len(rr.Kvs))
+	}
+}
+
+// startTestEtcd starts a single member server in dir listening on the
+// given peer port and the port after it for clients.
+func startTestEtcd(t *testing.T, dir string, port int) *Etcd {
+	cfg := NewConfig()
+	cfg.Dir = dir
+	purl, _ := url.Parse(fmt.Sprintf("http://localhost:%d", port))
+	curl, _ := url.Parse(fmt.Sprintf("http://localhost:%d", port+1))
+	cfg.LPUrls, cfg.APUrls = []url.URL{*purl}, []url.URL{*purl}
+	cfg.LCUrls, cfg.ACUrls = []url.URL{*curl}, []url.URL{*curl}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+	e, err := StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-e.Server.ReadyNotify()
+	return e
+}
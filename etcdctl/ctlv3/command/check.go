@@ -18,9 +18,13 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"math/rand"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,8 +37,11 @@ import (
 )
 
 var (
-	checkPerfLoad   string
-	checkPerfPrefix string
+	checkPerfLoad        string
+	checkPerfPrefix      string
+	checkDatascaleLoad   string
+	checkDatascalePrefix string
+	checkDatascaleKeys   int
 )
 
 type checkPerfCfg struct {
@@ -75,6 +82,7 @@ func NewCheckCommand() *cobra.Command {
 	}
 
 	cc.AddCommand(NewCheckPerfCommand())
+	cc.AddCommand(NewCheckDatascaleCommand())
 
 	return cc
 }
@@ -216,3 +224,226 @@ func newCheckPerfCommand(cmd *cobra.Command, args []string) {
 		os.Exit(ExitError)
 	}
 }
+
+type checkDatascaleCfg struct {
+	limit   int
+	kvSize  int
+	clients int
+}
+
+var checkDatascaleCfgMap = map[string]checkDatascaleCfg{
+	"s": {
+		limit:   10000,
+		kvSize:  1024,
+		clients: 50,
+	},
+	"m": {
+		limit:   100000,
+		kvSize:  1024,
+		clients: 200,
+	},
+	"l": {
+		limit:   1000000,
+		kvSize:  1024,
+		clients: 500,
+	},
+	"xl": {
+		limit:   3000000,
+		kvSize:  1024,
+		clients: 1000,
+	},
+}
+
+// NewCheckDatascaleCommand returns the cobra command for "check datascale".
+func NewCheckDatascaleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "datascale [options]",
+		Short: "Check the memory and storage usage of holding data for a workload on a given server endpoint",
+		Long: `Writes the workload's key-value pairs through the first endpoint, then reports the
+growth of the server's resident memory (read from its /metrics endpoint) and of its
+backend database size. The keys are deleted afterwards.
+`,
+		Run: newCheckDatascaleCommand,
+	}
+
+	cmd.Flags().StringVar(&checkDatascaleLoad, "load", "s", "The datascale check's workload model. Accepted workloads: s(small), m(medium), l(large), xl(xLarge)")
+	cmd.Flags().StringVar(&checkDatascalePrefix, "prefix", "/etcdctl-check-datascale/", "The prefix for writing the datascale check's keys.")
+	cmd.Flags().IntVar(&checkDatascaleKeys, "keys", 0, "Number of key-value pairs to write, overriding the workload model's count.")
+
+	return cmd
+}
+
+// newCheckDatascaleCommand executes the "check datascale" command.
+func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
+	var checkDatascaleAlias = map[string]string{
+		"s": "s", "small": "s",
+		"m": "m", "medium": "m",
+		"l": "l", "large": "l",
+		"xl": "xl", "xLarge": "xl",
+	}
+
+	model, ok := checkDatascaleAlias[checkDatascaleLoad]
+	if !ok {
+		ExitWithError(ExitBadFeature, fmt.Errorf("unknown load option %v", checkDatascaleLoad))
+	}
+	cfg := checkDatascaleCfgMap[model]
+	if checkDatascaleKeys < 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("invalid number of keys %d", checkDatascaleKeys))
+	}
+	if checkDatascaleKeys > 0 {
+		cfg.limit = checkDatascaleKeys
+	}
+
+	// measure a single member; memory and db size are per-server properties
+	cc := clientConfigFromCmd(cmd)
+	if len(cc.endpoints) == 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("no endpoint given"))
+	}
+	ep := cc.endpoints[0]
+	cc.endpoints = []string{ep}
+
+	requests := make(chan v3.Op, cfg.clients)
+	clients := make([]*v3.Client, cfg.clients)
+	for i := 0; i < cfg.clients; i++ {
+		clients[i] = cc.mustClient()
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := clients[0].Get(ctx, checkDatascalePrefix, v3.WithPrefix(), v3.WithLimit(1))
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	if len(resp.Kvs) > 0 {
+		ExitWithError(ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with etcdctl del --prefix %s first.", checkDatascalePrefix, checkDatascalePrefix))
+	}
+
+	metricsClient := newMetricsHTTPClient(cc)
+	memBefore, err := endpointMemoryMetrics(metricsClient, metricsURL(cc, ep))
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	dbBefore := endpointDBSize(cmd, clients[0], ep)
+
+	ksize, vsize := 256, cfg.kvSize-256
+	k, v := make([]byte, ksize), string(make([]byte, vsize))
+
+	fmt.Printf("Start data scale check for workload [%d key-value pairs, %d bytes per key-value, %d concurrent clients].\n", cfg.limit, cfg.kvSize, cfg.clients)
+	bar := pb.New(cfg.limit)
+	bar.Format("Bom !")
+	bar.Start()
+
+	r := report.NewReport("%4.4f")
+	var wg sync.WaitGroup
+
+	wg.Add(len(clients))
+	for i := range clients {
+		go func(c *v3.Client) {
+			defer wg.Done()
+			for op := range requests {
+				st := time.Now()
+				_, derr := c.Do(context.Background(), op)
+				r.Results() <- report.Result{Err: derr, Start: st, End: time.Now()}
+				bar.Increment()
+			}
+		}(clients[i])
+	}
+
+	go func() {
+		for i := 0; i < cfg.limit; i++ {
+			binary.PutVarint(k, int64(rand.Int63n(math.MaxInt64)))
+			requests <- v3.OpPut(checkDatascalePrefix+string(k), v)
+		}
+		close(requests)
+	}()
+
+	sc := r.Stats()
+	wg.Wait()
+	close(r.Results())
+	bar.Finish()
+	s := <-sc
+
+	memAfter, merr := endpointMemoryMetrics(metricsClient, metricsURL(cc, ep))
+	dbAfter := endpointDBSize(cmd, clients[0], ep)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	_, err = clients[0].Delete(ctx, checkDatascalePrefix, v3.WithPrefix())
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	if merr != nil {
+		ExitWithError(ExitError, merr)
+	}
+
+	if len(s.ErrorDist) != 0 {
+		fmt.Println("FAIL: too many errors")
+		for k, v := range s.ErrorDist {
+			fmt.Printf("FAIL: ERROR(%v) -> %d\n", k, v)
+		}
+		os.Exit(ExitError)
+	}
+
+	mbUsed := (memAfter - memBefore) / (1024 * 1024)
+	dbUsed := float64(dbAfter-dbBefore) / (1024 * 1024)
+	fmt.Printf("PASS: Approximate system memory used : %.2f MB.\n", mbUsed)
+	fmt.Printf("PASS: Approximate backend database growth : %.2f MB.\n", dbUsed)
+}
+
+// endpointDBSize returns the backend database size of the given endpoint.
+func endpointDBSize(cmd *cobra.Command, c *v3.Client, ep string) int64 {
+	ctx, cancel := commandCtx(cmd)
+	resp, err := c.Status(ctx, ep)
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	return resp.DbSize
+}
+
+// newMetricsHTTPClient returns an HTTP client sharing the command's TLS settings.
+func newMetricsHTTPClient(cc *clientConfig) *http.Client {
+	cfg, err := newClientCfg(cc.endpoints, cc.dialTimeout, cc.keepAliveTime, cc.keepAliveTimeout, cc.scfg, nil)
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+	return &http.Client{
+		Timeout:   cc.dialTimeout + 5*time.Second,
+		Transport: &http.Transport{TLSClientConfig: cfg.TLS},
+	}
+}
+
+// metricsURL returns the URL of the metrics endpoint served on the client URL.
+func metricsURL(cc *clientConfig, ep string) string {
+	if !strings.Contains(ep, "://") {
+		scheme := "http://"
+		if cc.scfg.cert != "" || cc.scfg.cacert != "" {
+			scheme = "https://"
+		}
+		ep = scheme + ep
+	}
+	return strings.TrimSuffix(ep, "/") + "/metrics"
+}
+
+// endpointMemoryMetrics returns the process resident memory in bytes
+// reported by the metrics endpoint at the given URL.
+func endpointMemoryMetrics(hc *http.Client, url string) (float64, error) {
+	const residentMemoryKey = "process_resident_memory_bytes"
+	resp, err := hc.Get(url)
+	if err != nil {
+		return 0, fmt.Errorf("could not fetch %s (%v)", url, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("could not read %s (%v)", url, err)
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if !strings.HasPrefix(line, residentMemoryKey+" ") {
+			continue
+		}
+		v := strings.TrimSpace(strings.TrimPrefix(line, residentMemoryKey))
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("could not find %s in %s", residentMemoryKey, url)
+}
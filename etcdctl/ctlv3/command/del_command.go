@@ -25,6 +25,7 @@ var (
 	delPrefix  bool
 	delPrevKV  bool
 	delFromKey bool
+	delDryRun  bool
 )
 
 // NewDelCommand returns the cobra command for "del".
@@ -38,6 +39,7 @@ func NewDelCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&delPrefix, "prefix", false, "delete keys with matching prefix")
 	cmd.Flags().BoolVar(&delPrevKV, "prev-kv", false, "return deleted key-value pairs")
 	cmd.Flags().BoolVar(&delFromKey, "from-key", false, "delete keys that are greater than or equal to the given key using byte compare")
+	cmd.Flags().BoolVar(&delDryRun, "dry-run", false, "reports the outcome of the delete without applying it")
 	return cmd
 }
 
@@ -89,6 +91,9 @@ func getDelOp(cmd *cobra.Command, args []string) (string, []clientv3.OpOption) {
 		}
 		opts = append(opts, clientv3.WithFromKey())
 	}
+	if delDryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
 
 	return key, opts
 }
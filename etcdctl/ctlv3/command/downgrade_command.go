@@ -0,0 +1,92 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	v3 "github.com/coreos/etcd/clientv3"
+	"github.com/spf13/cobra"
+)
+
+// NewDowngradeCommand returns the cobra command for "downgrade".
+func NewDowngradeCommand() *cobra.Command {
+	dc := &cobra.Command{
+		Use:   "downgrade <subcommand>",
+		Short: "Downgrade related commands",
+	}
+
+	dc.AddCommand(NewDowngradeStatusCommand())
+	dc.AddCommand(NewDowngradeValidateCommand())
+	dc.AddCommand(NewDowngradeEnableCommand())
+	dc.AddCommand(NewDowngradeCancelCommand())
+
+	return dc
+}
+
+func NewDowngradeStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Prints the cluster version and the downgrade state",
+		Run:   downgradeCommandFunc("status", v3.DowngradeStatus, false),
+	}
+}
+
+func NewDowngradeValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate <target-version>",
+		Short: "Checks that the cluster version can be downgraded to the target version",
+		Run:   downgradeCommandFunc("validate", v3.DowngradeValidate, true),
+	}
+}
+
+func NewDowngradeEnableCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable <target-version>",
+		Short: "Starts downgrading the cluster version to the target version",
+		Run:   downgradeCommandFunc("enable", v3.DowngradeEnable, true),
+	}
+}
+
+func NewDowngradeCancelCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancels the ongoing downgrade",
+		Run:   downgradeCommandFunc("cancel", v3.DowngradeCancel, false),
+	}
+}
+
+// downgradeCommandFunc returns the function that executes the "downgrade <name>" command.
+func downgradeCommandFunc(name string, action v3.DowngradeAction, needsVersion bool) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		var ver string
+		if needsVersion {
+			if len(args) != 1 {
+				ExitWithError(ExitBadArgs, fmt.Errorf("downgrade %s command needs 1 argument", name))
+			}
+			ver = args[0]
+		} else if len(args) != 0 {
+			ExitWithError(ExitBadArgs, fmt.Errorf("downgrade %s command accepts no arguments", name))
+		}
+
+		ctx, cancel := commandCtx(cmd)
+		resp, err := mustClientFromCmd(cmd).Downgrade(ctx, action, ver)
+		cancel()
+		if err != nil {
+			ExitWithError(ExitError, err)
+		}
+		display.Downgrade(*resp)
+	}
+}
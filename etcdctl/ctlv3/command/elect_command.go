@@ -17,6 +17,7 @@ package command
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -34,7 +35,7 @@ var (
 // NewElectCommand returns the cobra command for "elect".
 func NewElectCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "elect <election-name> [proposal]",
+		Use:   "elect <election-name> [proposal] [exec-command arg1 arg2 ...]",
 		Short: "Observes and participates in leader election",
 		Run:   electCommandFunc,
 	}
@@ -43,8 +44,8 @@ func NewElectCommand() *cobra.Command {
 }
 
 func electCommandFunc(cmd *cobra.Command, args []string) {
-	if len(args) != 1 && len(args) != 2 {
-		ExitWithError(ExitBadArgs, errors.New("elect takes one election name argument and an optional proposal argument."))
+	if len(args) == 0 {
+		ExitWithError(ExitBadArgs, errors.New("elect takes one election name argument, an optional proposal argument and an optional command to execute."))
 	}
 	c := mustClientFromCmd(cmd)
 
@@ -58,10 +59,10 @@ func electCommandFunc(cmd *cobra.Command, args []string) {
 		if electListen {
 			ExitWithError(ExitBadArgs, errors.New("proposal given but -l is set"))
 		}
-		err = campaign(c, args[0], args[1])
+		err = campaign(c, args[0], args[1], args[2:])
 	}
 	if err != nil {
-		ExitWithError(ExitError, err)
+		ExitWithError(exitCodeFromError(err), err)
 	}
 }
 
@@ -99,7 +100,7 @@ func observe(c *clientv3.Client, election string) error {
 	return nil
 }
 
-func campaign(c *clientv3.Client, election string, prop string) error {
+func campaign(c *clientv3.Client, election string, prop string, cmdArgs []string) error {
 	s, err := concurrency.NewSession(c)
 	if err != nil {
 		return err
@@ -120,6 +121,18 @@ func campaign(c *clientv3.Client, election string, prop string) error {
 		return err
 	}
 
+	if len(cmdArgs) > 0 {
+		err = execWhileHeld(s, environElectResponse(e), cmdArgs)
+		if err == errSessionExpired {
+			return err
+		}
+		resignErr := e.Resign(context.TODO())
+		if err != nil {
+			return err
+		}
+		return resignErr
+	}
+
 	// print key since elected
 	resp, err := c.Get(ctx, e.Key())
 	if err != nil {
@@ -130,8 +143,15 @@ func campaign(c *clientv3.Client, election string, prop string) error {
 	select {
 	case <-donec:
 	case <-s.Done():
-		return errors.New("elect: session expired")
+		return errSessionExpired
 	}
 
 	return e.Resign(context.TODO())
 }
+
+func environElectResponse(e *concurrency.Election) []string {
+	return []string{
+		"ETCD_ELECT_KEY=" + e.Key(),
+		fmt.Sprintf("ETCD_ELECT_REV=%d", e.Rev()),
+	}
+}
@@ -70,7 +70,10 @@ func newEpHashKVCommand() *cobra.Command {
 	hc := &cobra.Command{
 		Use:   "hashkv",
 		Short: "Prints the KV history hash for each endpoint in --endpoints",
-		Run:   epHashKVCommandFunc,
+		Long: `Prints the KV history hash for each endpoint. If endpoints that hashed the same
+revision range report different hashes, the command fails with a mismatch error.
+`,
+		Run: epHashKVCommandFunc,
 	}
 	hc.PersistentFlags().Int64Var(&epHashKVRev, "rev", 0, "maximum revision to hash (default: all revisions)")
 	return hc
@@ -192,6 +195,34 @@ func epHashKVCommandFunc(cmd *cobra.Command, args []string) {
 	if err != nil {
 		ExitWithError(ExitError, err)
 	}
+	if merr := hashKVMismatch(hashList); merr != nil {
+		ExitWithError(ExitError, merr)
+	}
+}
+
+// hashKVMismatch returns an error if endpoints that hashed the same revision
+// range report different hashes, which indicates a diverged member. Hashes
+// taken at different revisions or compaction points are not comparable, so
+// the endpoints are grouped by the range they hashed and each endpoint is
+// compared with the first one of its group.
+func hashKVMismatch(hashList []epHashKV) error {
+	type hashRange struct{ rev, compactRev int64 }
+	first := make(map[hashRange]epHashKV)
+	for _, b := range hashList {
+		r := hashRange{compactRev: b.Resp.CompactRevision}
+		if epHashKVRev == 0 {
+			r.rev = b.Resp.Header.Revision
+		}
+		a, ok := first[r]
+		if !ok {
+			first[r] = b
+			continue
+		}
+		if a.Resp.Hash != b.Resp.Hash {
+			return fmt.Errorf("hash mismatch between %s (%d) and %s (%d)", a.Ep, a.Resp.Hash, b.Ep, b.Resp.Hash)
+		}
+	}
+	return nil
 }
 
 func endpointsFromCluster(cmd *cobra.Command) []string {
@@ -15,10 +15,15 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/coreos/etcd/client"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -33,10 +38,31 @@ const (
 	ExitBadArgs = 128
 )
 
+// ExitWithError prints the error and exits with the given code. A generic
+// ExitError caused by an unreachable cluster is reported as ExitBadConnection
+// so scripts can tell connectivity failures apart from rejected requests.
 func ExitWithError(code int, err error) {
 	fmt.Fprintln(os.Stderr, "Error:", err)
 	if cerr, ok := err.(*client.ClusterError); ok {
 		fmt.Fprintln(os.Stderr, cerr.Detail())
 	}
+	if code == ExitError && isConnectionError(err) {
+		code = ExitBadConnection
+	}
 	os.Exit(code)
 }
+
+// isConnectionError returns true if the request failed because no member
+// could serve it in time.
+func isConnectionError(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	var code codes.Code
+	if eerr, ok := err.(rpctypes.EtcdError); ok {
+		code = eerr.Code()
+	} else if ev, ok := status.FromError(err); ok {
+		code = ev.Code()
+	}
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
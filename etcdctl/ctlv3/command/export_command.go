@@ -0,0 +1,78 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/coreos/etcd/clientv3/keyspace"
+	"github.com/coreos/etcd/pkg/fileutil"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportPrefix string
+	exportRev    int64
+	exportFormat string
+)
+
+// NewExportCommand returns the cobra command for "export".
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [options] <filename>",
+		Short: "Exports the keys under a prefix to a file",
+		Run:   exportCommandFunc,
+	}
+	cmd.Flags().StringVar(&exportPrefix, "prefix", "", "Key prefix to export; exports all keys if empty")
+	cmd.Flags().Int64Var(&exportRev, "rev", 0, "Revision to export the keys at; defaults to the current revision")
+	cmd.Flags().StringVar(&exportFormat, "format", "protobuf", "Export format (protobuf, json)")
+	return cmd
+}
+
+// exportCommandFunc executes the "export" command.
+func exportCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("export command needs 1 argument."))
+	}
+	f, err := keyspace.ParseFormat(exportFormat)
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+
+	path := args[0]
+	partpath := path + ".part"
+	fp, err := os.Create(partpath)
+	if err != nil {
+		ExitWithError(ExitBadArgs, fmt.Errorf("could not open %s (%v)", partpath, err))
+	}
+
+	c := mustClientFromCmd(cmd)
+	res, err := keyspace.Export(context.TODO(), c, fp, keyspace.ExportConfig{Prefix: exportPrefix, Revision: exportRev, Format: f})
+	if err == nil {
+		err = fileutil.Fsync(fp)
+	}
+	fp.Close()
+	if err != nil {
+		os.RemoveAll(partpath)
+		ExitWithError(ExitInterrupted, err)
+	}
+	if err = os.Rename(partpath, path); err != nil {
+		ExitWithError(ExitIO, fmt.Errorf("could not rename %s to %s (%v)", partpath, path, err))
+	}
+	fmt.Printf("Exported %d keys and %d leases at revision %d to %s\n", res.Keys, res.Leases, res.Header.Revision, path)
+}
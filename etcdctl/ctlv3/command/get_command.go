@@ -71,6 +71,12 @@ func getCommandFunc(cmd *cobra.Command, args []string) {
 		}
 		dp.valueOnly = true
 	}
+	if getKeysOnly {
+		// print one key per line so the output can be piped to other tools
+		if dp, simple := (display).(*simplePrinter); simple {
+			dp.keysOnly = true
+		}
+	}
 	display.Get(*resp)
 }
 
@@ -83,6 +89,10 @@ func getGetOp(cmd *cobra.Command, args []string) (string, []clientv3.OpOption) {
 		ExitWithError(ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one."))
 	}
 
+	if getKeysOnly && printValueOnly {
+		ExitWithError(ExitBadArgs, fmt.Errorf("`--keys-only` and `--print-value-only` cannot be set at the same time, choose one."))
+	}
+
 	opts := []clientv3.OpOption{}
 	switch getConsistency {
 	case "s":
@@ -0,0 +1,75 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/coreos/etcd/clientv3/keyspace"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importPrefix string
+	importFormat string
+	importLeases string
+)
+
+// NewImportCommand returns the cobra command for "import".
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [options] <filename>",
+		Short: "Imports the keys of an export file",
+		Run:   importCommandFunc,
+	}
+	cmd.Flags().StringVar(&importPrefix, "prefix", "", "Prefix to import the keys under in place of the exported prefix")
+	cmd.Flags().StringVar(&importFormat, "format", "protobuf", "Export format (protobuf, json)")
+	cmd.Flags().StringVar(&importLeases, "leases", "drop", "How to import leases (drop, keep, remap)")
+	return cmd
+}
+
+// importCommandFunc executes the "import" command.
+func importCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("import command needs 1 argument."))
+	}
+	f, err := keyspace.ParseFormat(importFormat)
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+	leases, err := keyspace.ParseLeaseMode(importLeases)
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+
+	fp, err := os.Open(args[0])
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+	defer fp.Close()
+
+	c := mustClientFromCmd(cmd)
+	res, err := keyspace.Import(context.TODO(), c, fp, keyspace.ImportConfig{Prefix: importPrefix, Format: f, Leases: leases})
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	fmt.Printf("Imported %d keys and %d leases\n", res.Keys, len(res.Leases))
+	if res.Skipped != 0 {
+		fmt.Printf("Skipped %d keys of expired leases\n", res.Skipped)
+	}
+}
@@ -40,6 +40,12 @@ func NewLeaseCommand() *cobra.Command {
 	return lc
 }
 
+var (
+	leaseGrantID       string
+	leaseGrantMetadata string
+	leaseGrantKeepKeys bool
+)
+
 // NewLeaseGrantCommand returns the cobra command for "lease grant".
 func NewLeaseGrantCommand() *cobra.Command {
 	lc := &cobra.Command{
@@ -49,6 +55,9 @@ func NewLeaseGrantCommand() *cobra.Command {
 		Run: leaseGrantCommandFunc,
 	}
 
+	lc.Flags().StringVar(&leaseGrantID, "id", "", "Lease ID (in hexadecimal) to grant instead of a server-chosen one")
+	lc.Flags().StringVar(&leaseGrantMetadata, "metadata", "", "Opaque metadata to keep with the lease, such as the name of its owner")
+	lc.Flags().BoolVar(&leaseGrantKeepKeys, "keep-keys", false, "Detach the keys of the lease instead of deleting them when it expires or is revoked")
 	return lc
 }
 
@@ -63,8 +72,19 @@ func leaseGrantCommandFunc(cmd *cobra.Command, args []string) {
 		ExitWithError(ExitBadArgs, fmt.Errorf("bad TTL (%v)", err))
 	}
 
+	var opts []v3.LeaseOption
+	if leaseGrantID != "" {
+		opts = append(opts, v3.WithLeaseID(leaseFromArgs(leaseGrantID)))
+	}
+	if leaseGrantMetadata != "" {
+		opts = append(opts, v3.WithLeaseMetadata([]byte(leaseGrantMetadata)))
+	}
+	if leaseGrantKeepKeys {
+		opts = append(opts, v3.WithKeepKeys())
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl)
+	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl, opts...)
 	cancel()
 	if err != nil {
 		ExitWithError(ExitError, fmt.Errorf("failed to grant lease (%v)\n", err))
@@ -48,7 +48,7 @@ func lockCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	c := mustClientFromCmd(cmd)
 	if err := lockUntilSignal(c, args[0], args[1:]); err != nil {
-		ExitWithError(ExitError, err)
+		ExitWithError(exitCodeFromError(err), err)
 	}
 }
 
@@ -76,10 +76,10 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 	}
 
 	if len(cmdArgs) > 0 {
-		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-		cmd.Env = append(environLockResponse(m), os.Environ()...)
-		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-		err := cmd.Run()
+		err := execWhileHeld(s, environLockResponse(m), cmdArgs)
+		if err == errSessionExpired {
+			return err
+		}
 		unlockErr := m.Unlock(context.TODO())
 		if err != nil {
 			return err
@@ -102,7 +102,44 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 	case <-s.Done():
 	}
 
-	return errors.New("session expired")
+	return errSessionExpired
+}
+
+var errSessionExpired = errors.New("session expired")
+
+// execWhileHeld runs the given command with the extra environment variables
+// until it exits. The command is killed if the session expires, since
+// whatever the session was holding may then be acquired by someone else.
+func execWhileHeld(s *concurrency.Session, env []string, cmdArgs []string) error {
+	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+	cmd.Env = append(env, os.Environ()...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	waitc := make(chan error, 1)
+	go func() { waitc <- cmd.Wait() }()
+
+	select {
+	case err := <-waitc:
+		return err
+	case <-s.Done():
+		cmd.Process.Kill()
+		<-waitc
+		return errSessionExpired
+	}
+}
+
+// exitCodeFromError returns the exit status of a failed exec'd command so
+// scripts can act on it; any other error maps to ExitError.
+func exitCodeFromError(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.ExitStatus() > 0 {
+			return ws.ExitStatus()
+		}
+	}
+	return ExitError
 }
 
 func environLockResponse(m *concurrency.Mutex) []string {
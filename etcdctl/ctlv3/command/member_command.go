@@ -131,7 +131,7 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 			// quorum get to sync cluster list
 			gresp, gerr := cli.Get(ctx, "_")
 			if gerr != nil {
-				ExitWithError(ExitError, err)
+				ExitWithError(ExitError, gerr)
 			}
 			resp.Header.MemberId = gresp.Header.MemberId
 			listResp, err = cli.MemberList(ctx)
@@ -116,12 +116,18 @@ func (p *fieldsPrinter) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) {
 	for _, k := range r.Keys {
 		fmt.Printf("\"Key\" : %q\n", string(k))
 	}
+	if len(r.Metadata) > 0 {
+		fmt.Printf("\"Metadata\" : %q\n", string(r.Metadata))
+	}
 }
 
 func (p *fieldsPrinter) Leases(r v3.LeaseLeasesResponse) {
 	p.hdr(r.ResponseHeader)
 	for _, item := range r.Leases {
 		fmt.Println(`"ID" :`, item.ID)
+		if len(item.Metadata) > 0 {
+			fmt.Printf("\"Metadata\" : %q\n", string(item.Metadata))
+		}
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 type simplePrinter struct {
 	isHex     bool
 	valueOnly bool
+	keysOnly  bool
 }
 
 func (s *simplePrinter) Del(resp v3.DeleteResponse) {
@@ -37,12 +38,20 @@ func (s *simplePrinter) Del(resp v3.DeleteResponse) {
 
 func (s *simplePrinter) Get(resp v3.GetResponse) {
 	for _, kv := range resp.Kvs {
+		if s.keysOnly {
+			printKey(s.isHex, kv)
+			continue
+		}
 		printKV(s.isHex, s.valueOnly, kv)
 	}
 }
 
 func (s *simplePrinter) Put(r v3.PutResponse) {
-	fmt.Println("OK")
+	if r.Conflict {
+		fmt.Println("CONFLICT")
+	} else {
+		fmt.Println("OK")
+	}
 	if r.PrevKv != nil {
 		printKV(s.isHex, s.valueOnly, r.PrevKv)
 	}
@@ -101,12 +110,19 @@ func (s *simplePrinter) TimeToLive(resp v3.LeaseTimeToLiveResponse, keys bool) {
 		}
 		txt += fmt.Sprintf(", attached keys(%v)", ks)
 	}
+	if len(resp.Metadata) > 0 {
+		txt += fmt.Sprintf(", metadata(%q)", resp.Metadata)
+	}
 	fmt.Println(txt)
 }
 
 func (s *simplePrinter) Leases(resp v3.LeaseLeasesResponse) {
 	fmt.Printf("found %d leases\n", len(resp.Leases))
 	for _, item := range resp.Leases {
+		if len(item.Metadata) > 0 {
+			fmt.Printf("%016x metadata(%q)\n", item.ID, item.Metadata)
+			continue
+		}
 		fmt.Printf("%016x\n", item.ID)
 	}
 }
@@ -161,6 +177,14 @@ func (s *simplePrinter) MoveLeader(leader, target uint64, r v3.MoveLeaderRespons
 	fmt.Printf("Leadership transferred from %s to %s\n", types.ID(leader), types.ID(target))
 }
 
+func (s *simplePrinter) Downgrade(r v3.DowngradeResponse) {
+	if !r.Enabled {
+		fmt.Printf("Cluster version %s, no downgrade in progress\n", r.Version)
+		return
+	}
+	fmt.Printf("Cluster version %s, downgrading to %s\n", r.Version, r.TargetVersion)
+}
+
 func (s *simplePrinter) RoleAdd(role string, r v3.AuthRoleAddResponse) {
 	fmt.Printf("Role %s created\n", role)
 }
@@ -28,6 +28,9 @@ var (
 	putPrevKV      bool
 	putIgnoreVal   bool
 	putIgnoreLease bool
+	putExpectedVer int64
+	putExpectedRev int64
+	putDryRun      bool
 )
 
 // NewPutCommand returns the cobra command for "put".
@@ -47,6 +50,10 @@ $ put -- <key> <value>
 If <value> isn't given as a command line argument and '--ignore-value' is not specified,
 this command tries to read the value from standard input.
 
+If '--expected-version' or '--expected-mod-revision' is given, the key is only put if it
+has the given version or was last modified at the given revision; -1 expects the key to
+not exist. Otherwise CONFLICT is printed along with the current key-value pair.
+
 If <lease> isn't given as a command line argument and '--ignore-lease' is not specified,
 this command tries to read the value from standard input.
 
@@ -60,6 +67,9 @@ will store the content of the file to <key>.
 	cmd.Flags().BoolVar(&putPrevKV, "prev-kv", false, "return the previous key-value pair before modification")
 	cmd.Flags().BoolVar(&putIgnoreVal, "ignore-value", false, "updates the key using its current value")
 	cmd.Flags().BoolVar(&putIgnoreLease, "ignore-lease", false, "updates the key using its current lease")
+	cmd.Flags().Int64Var(&putExpectedVer, "expected-version", 0, "only puts the key if it has the given version (-1 for a missing key)")
+	cmd.Flags().Int64Var(&putExpectedRev, "expected-mod-revision", 0, "only puts the key if it was last modified at the given revision (-1 for a missing key)")
+	cmd.Flags().BoolVar(&putDryRun, "dry-run", false, "reports the outcome of the put without applying it")
 	return cmd
 }
 
@@ -113,6 +123,15 @@ func getPutOp(cmd *cobra.Command, args []string) (string, string, []clientv3.OpO
 	if putIgnoreLease {
 		opts = append(opts, clientv3.WithIgnoreLease())
 	}
+	if putExpectedVer != 0 {
+		opts = append(opts, clientv3.WithExpectedVersion(putExpectedVer))
+	}
+	if putExpectedRev != 0 {
+		opts = append(opts, clientv3.WithExpectedModRev(putExpectedRev))
+	}
+	if putDryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
 
 	return key, value, opts
 }
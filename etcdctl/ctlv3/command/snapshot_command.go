@@ -25,7 +25,6 @@ import (
 	"math"
 	"os"
 	"path/filepath"
-	"reflect"
 	"strings"
 
 	"github.com/coreos/etcd/etcdserver"
@@ -58,6 +57,7 @@ var (
 	restoreDataDir      string
 	restorePeerURLs     string
 	restoreName         string
+	restoreRevision     int64
 	skipHashCheck       bool
 )
 
@@ -77,6 +77,7 @@ func NewSnapshotSaveCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "save <filename>",
 		Short: "Stores an etcd node backend snapshot to a given file",
+		Long:  "Stores an etcd node backend snapshot to a given file. Exactly one endpoint must be given.",
 		Run:   snapshotSaveCommandFunc,
 	}
 }
@@ -104,6 +105,7 @@ func NewSnapshotRestoreCommand() *cobra.Command {
 	cmd.Flags().StringVar(&restorePeerURLs, "initial-advertise-peer-urls", defaultInitialAdvertisePeerURLs, "List of this member's peer URLs to advertise to the rest of the cluster")
 	cmd.Flags().StringVar(&restoreName, "name", defaultName, "Human-readable name for this member")
 	cmd.Flags().BoolVar(&skipHashCheck, "skip-hash-check", false, "Ignore snapshot integrity hash value (required if copied from data directory)")
+	cmd.Flags().Int64Var(&restoreRevision, "revision", 0, "Restore the keyspace as of this revision, discarding all later revisions")
 
 	return cmd
 }
@@ -114,6 +116,14 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 		ExitWithError(ExitBadArgs, err)
 	}
 
+	// a snapshot is a single member's backend; reading it through a
+	// balanced client could silently switch members mid-stream
+	cfg := clientConfigFromCmd(cmd)
+	if len(cfg.endpoints) != 1 {
+		err := fmt.Errorf("snapshot must be requested to one selected node, not multiple %v", cfg.endpoints)
+		ExitWithError(ExitBadArgs, err)
+	}
+
 	path := args[0]
 
 	partpath := path + ".part"
@@ -124,19 +134,28 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 		ExitWithError(ExitBadArgs, exiterr)
 	}
 
-	c := mustClientFromCmd(cmd)
+	c := cfg.mustClient()
 	r, serr := c.Snapshot(context.TODO())
 	if serr != nil {
+		f.Close()
 		os.RemoveAll(partpath)
 		ExitWithError(ExitInterrupted, serr)
 	}
 	if _, rerr := io.Copy(f, r); rerr != nil {
+		f.Close()
 		os.RemoveAll(partpath)
 		ExitWithError(ExitInterrupted, rerr)
 	}
 
 	fileutil.Fsync(f)
 
+	// refuse to keep a snapshot that would not restore
+	if _, verr := snap.VerifyDBHash(f); verr != nil {
+		f.Close()
+		os.RemoveAll(partpath)
+		ExitWithError(ExitInterrupted, verr)
+	}
+
 	f.Close()
 
 	if rerr := os.Rename(partpath, path); rerr != nil {
@@ -288,7 +307,7 @@ func makeWALAndSnap(waldir, snapdir string, cl *membership.RaftCluster) {
 	}
 	snapshotter := snap.New(snapdir)
 	if err := snapshotter.SaveSnap(raftSnap); err != nil {
-		panic(err)
+		ExitWithError(ExitIO, err)
 	}
 
 	if err := w.SaveSnapshot(walpb.Snapshot{Index: commit, Term: term}); err != nil {
@@ -310,18 +329,6 @@ func makeDB(snapdir, dbfile string, commit int) {
 	}
 	defer f.Close()
 
-	// get snapshot integrity hash
-	if _, err := f.Seek(-sha256.Size, io.SeekEnd); err != nil {
-		ExitWithError(ExitIO, err)
-	}
-	sha := make([]byte, sha256.Size)
-	if _, err := f.Read(sha); err != nil {
-		ExitWithError(ExitIO, err)
-	}
-	if _, err := f.Seek(0, io.SeekStart); err != nil {
-		ExitWithError(ExitIO, err)
-	}
-
 	if err := fileutil.CreateDirAll(snapdir); err != nil {
 		ExitWithError(ExitIO, err)
 	}
@@ -341,31 +348,25 @@ func makeDB(snapdir, dbfile string, commit int) {
 		ExitWithError(ExitIO, serr)
 	}
 	hasHash := (off % 512) == sha256.Size
-	if hasHash {
-		if err := db.Truncate(off - sha256.Size); err != nil {
-			ExitWithError(ExitIO, err)
+	if !skipHashCheck {
+		// refuse truncated or corrupted snapshots
+		if _, err := snap.VerifyDBHash(db); err != nil {
+			switch err {
+			case snap.ErrNoDBHash:
+				err = fmt.Errorf("snapshot missing hash but --skip-hash-check=false")
+				ExitWithError(ExitBadArgs, err)
+			case snap.ErrDBHashMismatch:
+				err = fmt.Errorf("expected sha256 of %s to match its integrity hash", dbfile)
+				ExitWithError(ExitInvalidInput, err)
+			default:
+				ExitWithError(ExitIO, err)
+			}
 		}
 	}
-
-	if !hasHash && !skipHashCheck {
-		err := fmt.Errorf("snapshot missing hash but --skip-hash-check=false")
-		ExitWithError(ExitBadArgs, err)
-	}
-
-	if hasHash && !skipHashCheck {
-		// check for match
-		if _, err := db.Seek(0, io.SeekStart); err != nil {
-			ExitWithError(ExitIO, err)
-		}
-		h := sha256.New()
-		if _, err := io.Copy(h, db); err != nil {
+	if hasHash {
+		if err := db.Truncate(off - sha256.Size); err != nil {
 			ExitWithError(ExitIO, err)
 		}
-		dbsha := h.Sum(nil)
-		if !reflect.DeepEqual(sha, dbsha) {
-			err := fmt.Errorf("expected sha256 %v, got %v", sha, dbsha)
-			ExitWithError(ExitInvalidInput, err)
-		}
 	}
 
 	// db hash is OK, can now modify DB so it can be part of a new cluster
@@ -374,6 +375,9 @@ func makeDB(snapdir, dbfile string, commit int) {
 	// update consistentIndex so applies go through on etcdserver despite
 	// having a new raft instance
 	be := backend.NewDefaultBackend(dbpath)
+	if restoreRevision > 0 {
+		truncateRevisions(be, restoreRevision)
+	}
 	// a lessor never timeouts leases
 	lessor := lease.NewLessor(be, math.MaxInt64)
 	s := mvcc.NewStore(be, lessor, (*initIndex)(&commit))
@@ -395,6 +399,43 @@ func makeDB(snapdir, dbfile string, commit int) {
 	be.Close()
 }
 
+// truncateRevisions discards the key revisions above rev, so the restored
+// keyspace is the keyspace as of rev.
+func truncateRevisions(be backend.Backend, rev int64) {
+	tx := be.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+
+	// history up to a scheduled compaction may already be gone
+	for _, k := range []string{"scheduledCompactRev", "finishedCompactRev"} {
+		_, vs := tx.UnsafeRange([]byte("meta"), []byte(k), nil, 0)
+		if len(vs) != 0 && bytesToRev(vs[0]).main > rev {
+			err := fmt.Errorf("revision %d is compacted in the snapshot (compacted at %d)", rev, bytesToRev(vs[0]).main)
+			ExitWithError(ExitInvalidInput, err)
+		}
+	}
+
+	deleteKeys := func(bucket string, f func(k []byte) bool) {
+		var keys [][]byte
+		tx.UnsafeForEach([]byte(bucket), func(k, v []byte) error {
+			if f(k) {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			return nil
+		})
+		for _, k := range keys {
+			tx.UnsafeDelete([]byte(bucket), k)
+		}
+	}
+	deleteKeys("key", func(k []byte) bool { return bytesToRev(k).main > rev })
+
+	// the saved key and value indexes are current to the snapshot's last
+	// revision; drop them so the store rebuilds them from the kept keys
+	deleteKeys("keyIndex", func(k []byte) bool { return true })
+	deleteKeys("valueIndex", func(k []byte) bool { return true })
+	tx.UnsafeDelete([]byte("meta"), []byte("keyIndexRev"))
+}
+
 type dbstatus struct {
 	Hash      uint32 `json:"hash"`
 	Revision  int64  `json:"revision"`
@@ -30,6 +30,7 @@ import (
 
 var (
 	txnInteractive bool
+	txnDryRun      bool
 )
 
 // NewTxnCommand returns the cobra command for "txn".
@@ -40,6 +41,7 @@ func NewTxnCommand() *cobra.Command {
 		Run:   txnCommandFunc,
 	}
 	cmd.Flags().BoolVarP(&txnInteractive, "interactive", "i", false, "Input transaction in interactive mode")
+	cmd.Flags().BoolVar(&txnDryRun, "dry-run", false, "Report the outcome of the transaction without applying it")
 	return cmd
 }
 
@@ -51,20 +53,23 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 
 	reader := bufio.NewReader(os.Stdin)
 
-	txn := mustClientFromCmd(cmd).Txn(context.Background())
 	promptInteractive("compares:")
-	txn.If(readCompares(reader)...)
+	cmps := readCompares(reader)
 	promptInteractive("success requests (get, put, del):")
-	txn.Then(readOps(reader)...)
+	thenOps := readOps(reader)
 	promptInteractive("failure requests (get, put, del):")
-	txn.Else(readOps(reader)...)
+	elseOps := readOps(reader)
 
-	resp, err := txn.Commit()
+	opts := []clientv3.OpOption{}
+	if txnDryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
+	resp, err := mustClientFromCmd(cmd).Do(context.Background(), clientv3.OpTxn(cmps, thenOps, elseOps, opts...))
 	if err != nil {
 		ExitWithError(ExitError, err)
 	}
 
-	display.Txn(*resp)
+	display.Txn(*resp.Txn())
 }
 
 func promptInteractive(s string) {
@@ -37,6 +37,14 @@ func printKV(isHex bool, valueOnly bool, kv *pb.KeyValue) {
 	fmt.Println(v)
 }
 
+func printKey(isHex bool, kv *pb.KeyValue) {
+	k := string(kv.Key)
+	if isHex {
+		k = addHexPrefix(hex.EncodeToString(kv.Key))
+	}
+	fmt.Println(k)
+}
+
 func addHexPrefix(s string) string {
 	ns := make([]byte, len(s)*2)
 	for i := 0; i < len(s); i += 2 {
@@ -76,12 +76,15 @@ func init() {
 		command.NewDefragCommand(),
 		command.NewEndpointCommand(),
 		command.NewMoveLeaderCommand(),
+		command.NewDowngradeCommand(),
 		command.NewWatchCommand(),
 		command.NewVersionCommand(),
 		command.NewLeaseCommand(),
 		command.NewMemberCommand(),
 		command.NewSnapshotCommand(),
 		command.NewMakeMirrorCommand(),
+		command.NewExportCommand(),
+		command.NewImportCommand(),
 		command.NewMigrateCommand(),
 		command.NewLockCommand(),
 		command.NewElectCommand(),
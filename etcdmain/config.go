@@ -26,6 +26,9 @@ import (
 	"strings"
 
 	"github.com/coreos/etcd/embed"
+	"github.com/coreos/etcd/etcdserver"
+	"github.com/coreos/etcd/pkg/cors"
+	"github.com/coreos/etcd/pkg/featuregate"
 	"github.com/coreos/etcd/pkg/flags"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/version"
@@ -81,6 +84,8 @@ type config struct {
 	printVersion bool
 	ignored      []string
 	logOutput    string
+	logFormat    string
+	cipherSuites string
 }
 
 // configFlags has the set of flags used for command line parsing a Config
@@ -89,6 +94,7 @@ type configFlags struct {
 	clusterState *flags.StringsFlag
 	fallback     *flags.StringsFlag
 	proxy        *flags.StringsFlag
+	featureGate  *featuregate.FeatureGate
 }
 
 func newConfig() *config {
@@ -118,6 +124,7 @@ func newConfig() *config {
 			proxyFlagReadonly,
 			proxyFlagOn,
 		),
+		featureGate: etcdserver.NewFeatureGate(),
 	}
 
 	fs := cfg.FlagSet
@@ -141,11 +148,28 @@ func newConfig() *config {
 	fs.UintVar(&cfg.TickMs, "heartbeat-interval", cfg.TickMs, "Time (in milliseconds) of a heartbeat interval.")
 	fs.UintVar(&cfg.ElectionMs, "election-timeout", cfg.ElectionMs, "Time (in milliseconds) for an election to timeout.")
 	fs.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "Raise alarms when backend size exceeds the given quota. 0 means use the default quota.")
+	fs.StringVar(&cfg.PrefixQuotas, "prefix-quotas", cfg.PrefixQuotas, "',' separated 'prefix=bytes:keys' quotas rejecting writes that take a prefix over its quota, e.g. '/tenant-a/=1048576:1000'. 0 means unlimited.")
+	fs.Int64Var(&cfg.MemoryBudget, "memory-budget", cfg.MemoryBudget, "Approximate memory in bytes the key index, watch buffers and raft log may hold before new watchers and large ranges are rejected. 0 means unlimited.")
+	fs.DurationVar(&cfg.BackendBatchInterval, "backend-batch-interval", cfg.BackendBatchInterval, "Maximum time before committing the backend transaction. 0 means use the default.")
+	fs.IntVar(&cfg.BackendBatchLimit, "backend-batch-limit", cfg.BackendBatchLimit, "Maximum operations before committing the backend transaction. 0 means use the default.")
+	fs.Int64Var(&cfg.BackendPreallocSize, "backend-prealloc-size", cfg.BackendPreallocSize, "Size in bytes of the chunks the backend file's disk space is preallocated in as it grows. 0 disables preallocation.")
 	fs.UintVar(&cfg.MaxTxnOps, "max-txn-ops", cfg.MaxTxnOps, "Maximum number of operations permitted in a transaction.")
 	fs.UintVar(&cfg.MaxRequestBytes, "max-request-bytes", cfg.MaxRequestBytes, "Maximum client request size in bytes the server will accept.")
+	fs.UintVar(&cfg.MaxValueBytes, "max-value-bytes", cfg.MaxValueBytes, "Maximum size in bytes of a value a put may store. 0 means no limit.")
+	fs.UintVar(&cfg.MaxProposalBacklogBytes, "max-proposal-backlog-bytes", cfg.MaxProposalBacklogBytes, "Maximum size in bytes of the proposals waiting to be applied before client proposals are rejected. 0 means no limit.")
+	fs.UintVar(&cfg.MaxWatchers, "max-watchers", cfg.MaxWatchers, "Maximum number of watchers the server will accept. 0 means no limit.")
+	fs.UintVar(&cfg.MaxWatchersPerStream, "max-watchers-per-stream", cfg.MaxWatchersPerStream, "Maximum number of watchers a single watch stream will accept. 0 means no limit.")
+	fs.UintVar(&cfg.MaxConnectionsPerClient, "max-connections-per-client", cfg.MaxConnectionsPerClient, "Maximum number of connections the server will accept from a single client. 0 means no limit.")
+	fs.UintVar(&cfg.MaxStreamsPerClient, "max-streams-per-client", cfg.MaxStreamsPerClient, "Maximum number of gRPC streams the server will accept from a single client. 0 means no limit.")
+	fs.UintVar(&cfg.MaxWatchersPerClient, "max-watchers-per-client", cfg.MaxWatchersPerClient, "Maximum number of watchers the server will accept from a single client. 0 means no limit.")
 	fs.DurationVar(&cfg.GRPCKeepAliveMinTime, "grpc-keepalive-min-time", cfg.Config.GRPCKeepAliveMinTime, "Minimum interval duration that a client should wait before pinging server.")
 	fs.DurationVar(&cfg.GRPCKeepAliveInterval, "grpc-keepalive-interval", cfg.Config.GRPCKeepAliveInterval, "Frequency duration of server-to-client ping to check if a connection is alive (0 to disable).")
 	fs.DurationVar(&cfg.GRPCKeepAliveTimeout, "grpc-keepalive-timeout", cfg.Config.GRPCKeepAliveTimeout, "Additional duration of wait before closing a non-responsive connection (0 to disable).")
+	fs.BoolVar(&cfg.GRPCKeepAlivePermitWithoutStream, "grpc-keepalive-permit-without-stream", cfg.Config.GRPCKeepAlivePermitWithoutStream, "Allow clients to ping the server while they have no active streams.")
+	fs.Var(flags.NewUint32Value(cfg.Config.MaxConcurrentStreams), "max-concurrent-streams", "Maximum concurrent streams that each client can open at a time.")
+	fs.UintVar(&cfg.GRPCMaxRecvMsgSize, "grpc-max-recv-msg-size", cfg.GRPCMaxRecvMsgSize, "Maximum size in bytes of a message the gRPC server receives. 0 means --max-request-bytes plus the gRPC overhead.")
+	fs.UintVar(&cfg.GRPCMaxSendMsgSize, "grpc-max-send-msg-size", cfg.GRPCMaxSendMsgSize, "Maximum size in bytes of a message the gRPC server sends. 0 means math.MaxInt32.")
+	fs.DurationVar(&cfg.GracefulShutdownTimeout, "graceful-shutdown-timeout", cfg.GracefulShutdownTimeout, "Maximum duration to wait for in-flight client requests on shutdown (0 to use the request timeout).")
 
 	// clustering
 	fs.Var(flags.NewURLsValue(embed.DefaultInitialAdvertisePeerURLs), "initial-advertise-peer-urls", "List of this member's peer URLs to advertise to the rest of the cluster.")
@@ -161,6 +185,7 @@ func newConfig() *config {
 
 	fs.BoolVar(&cfg.StrictReconfigCheck, "strict-reconfig-check", cfg.StrictReconfigCheck, "Reject reconfiguration requests that would cause quorum loss.")
 	fs.BoolVar(&cfg.EnableV2, "enable-v2", true, "Accept etcd V2 client requests.")
+	fs.BoolVar(&cfg.ReadOnly, "read-only", cfg.ReadOnly, "Start the member refusing v3 client writes while it keeps replicating the cluster.")
 	fs.StringVar(&cfg.ExperimentalEnableV2V3, "experimental-enable-v2v3", cfg.ExperimentalEnableV2V3, "v3 prefix for serving emulated v2 state.")
 
 	// proxy
@@ -188,11 +213,15 @@ func newConfig() *config {
 	fs.BoolVar(&cfg.PeerAutoTLS, "peer-auto-tls", false, "Peer TLS using generated certificates")
 	fs.StringVar(&cfg.PeerTLSInfo.CRLFile, "peer-crl-file", "", "Path to the peer certificate revocation list file.")
 	fs.StringVar(&cfg.PeerTLSInfo.AllowedCN, "peer-cert-allowed-cn", "", "Allowed CN for inter peer authentication.")
+	fs.StringVar(&cfg.cipherSuites, "cipher-suites", "", "Comma-separated list of supported TLS cipher suites between client/server and peers (empty will be auto-populated by Go).")
+	fs.StringVar(&cfg.TLSMinVersion, "tls-min-version", "", "Minimum TLS version between client/server and peers, e.g. 'TLS1.2' (empty defaults to TLS1.2).")
+	fs.StringVar(&cfg.TLSMaxVersion, "tls-max-version", "", "Maximum TLS version between client/server and peers, e.g. 'TLS1.2' (empty defaults to the latest version).")
 
 	// logging
 	fs.BoolVar(&cfg.Debug, "debug", false, "Enable debug-level logging for etcd.")
 	fs.StringVar(&cfg.LogPkgLevels, "log-package-levels", "", "Specify a particular log level for each etcd package (eg: 'etcdmain=CRITICAL,etcdserver=DEBUG').")
 	fs.StringVar(&cfg.logOutput, "log-output", "default", "Specify 'stdout' or 'stderr' to skip journald logging even when running under systemd.")
+	fs.StringVar(&cfg.logFormat, "log-format", "text", "Specify 'json' to write each log entry as a JSON object instead of text.")
 
 	// unsafe
 	fs.BoolVar(&cfg.ForceNewCluster, "force-new-cluster", false, "Force to create a new one member cluster.")
@@ -202,6 +231,7 @@ func newConfig() *config {
 
 	fs.StringVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", "0", "Auto compaction retention for mvcc key value store. 0 means disable auto compaction.")
 	fs.StringVar(&cfg.AutoCompactionMode, "auto-compaction-mode", "periodic", "interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.")
+	fs.StringVar(&cfg.AutoCompactionWindows, "auto-compaction-windows", "", "';' separated maintenance windows in local time auto compaction runs in, e.g. 'mon-fri 01:00-05:00;sat,sun 00:00-24:00'. Empty means any time.")
 
 	// pprof profiler via HTTP
 	fs.BoolVar(&cfg.EnablePprof, "enable-pprof", false, "Enable runtime profiling data via HTTP server. Address is at client URL + \"/debug/pprof/\"")
@@ -214,6 +244,22 @@ func newConfig() *config {
 
 	// experimental
 	fs.DurationVar(&cfg.ExperimentalCorruptCheckTime, "experimental-corrupt-check-time", cfg.ExperimentalCorruptCheckTime, "Duration of time between cluster corruption check passes.")
+	fs.DurationVar(&cfg.ExperimentalWarningApplyDuration, "experimental-warning-apply-duration", cfg.ExperimentalWarningApplyDuration, "Time duration after which a warning is logged for a slow request (0 to disable).")
+	fs.DurationVar(&cfg.ExperimentalKeyIndexSaveInterval, "experimental-key-index-save-interval", cfg.ExperimentalKeyIndexSaveInterval, "Duration of time between saves of the key index to the backend, to speed up restarts (0 to disable).")
+	fs.UintVar(&cfg.ExperimentalMaxUnsyncedWatchers, "experimental-max-unsynced-watchers", cfg.ExperimentalMaxUnsyncedWatchers, "Number of unsynced watchers past which watches on past revisions are refused with a retry hint (0 to disable).")
+	fs.UintVar(&cfg.ExperimentalWatchEventHistorySize, "experimental-watch-event-history-size", cfg.ExperimentalWatchEventHistorySize, "Number of recent events kept in memory so lagging watchers sync without reading the backend (0 to disable).")
+	fs.DurationVar(&cfg.ExperimentalWatchEventHistoryMaxAge, "experimental-watch-event-history-max-age", cfg.ExperimentalWatchEventHistoryMaxAge, "Maximum age of the events kept in the watch event history (0 for no limit).")
+	fs.UintVar(&cfg.ExperimentalWatchAckWindow, "experimental-watch-ack-window", cfg.ExperimentalWatchAckWindow, "Number of unacknowledged responses sent to each watcher of watch streams whose client acknowledges responses (0 to disable).")
+	fs.BoolVar(&cfg.ExperimentalWALDSync, "experimental-wal-dsync", cfg.ExperimentalWALDSync, "Open the WAL files with O_DSYNC where supported, so each write reaches stable storage as it returns.")
+	fs.BoolVar(&cfg.ExperimentalBackendDSync, "experimental-backend-dsync", cfg.ExperimentalBackendDSync, "Open the backend file with O_DSYNC where supported, so each page write reaches stable storage as it returns.")
+	fs.IntVar(&cfg.ExperimentalApplyWorkers, "experimental-apply-workers", cfg.ExperimentalApplyWorkers, "Number of goroutines preparing committed entries, by decoding them and evaluating the compares of independent txns, before they are applied in order (less than 2 to disable).")
+	fs.Uint64Var(&cfg.ExperimentalSnapshotCatchUpEntries, "experimental-snapshot-catchup-entries", cfg.ExperimentalSnapshotCatchUpEntries, "Number of entries kept in the raft log behind the index it is compacted to, for slow followers to catch up from.")
+	fs.BoolVar(&cfg.ExperimentalWitness, "experimental-witness", cfg.ExperimentalWitness, "Start the member as a witness, which votes but stores no key-value data and never becomes the leader.")
+	fs.StringVar(&cfg.ExperimentalValueIndexes, "experimental-value-indexes", cfg.ExperimentalValueIndexes, "',' separated 'name=prefix:pointer' indexes of the JSON values of the keys under prefix by the field at the JSON pointer, e.g. 'by-owner=/jobs/:/owner'.")
+	fs.StringVar(&cfg.ExperimentalAdmissionHooks, "experimental-admission-hooks", cfg.ExperimentalAdmissionHooks, "',' separated names of the compiled-in admission hooks to run on client writes, in order.")
+	fs.StringVar(&cfg.ExperimentalValueSchemas, "experimental-value-schemas", cfg.ExperimentalValueSchemas, "',' separated 'prefix=file' schemas the values of the keys under prefix must conform to, read from a JSON Schema file or, for 'file#message', a protobuf descriptor set.")
+	fs.StringVar(&cfg.ExperimentalOpLog, "experimental-op-log", cfg.ExperimentalOpLog, "Path of a file to append a JSON record of every client request's invocation and return to, for linearizability checkers.")
+	fs.Var(cfg.featureGate, "experimental-feature", "Comma-separated Feature=bool pairs toggling experimental features: "+strings.Join(cfg.featureGate.KnownFeatures(), ", ")+".")
 
 	// ignored
 	for _, f := range cfg.ignored {
@@ -247,13 +293,91 @@ func (cfg *config) parse(arguments []string) error {
 	var err error
 	if cfg.configFile != "" {
 		plog.Infof("Loading server configuration from %q", cfg.configFile)
-		err = cfg.configFromFile(cfg.configFile)
+		err = cfg.configFromFileAndCmdLine()
 	} else {
 		err = cfg.configFromCmdLine()
 	}
 	return err
 }
 
+// configFromFileAndCmdLine loads the configuration file and then applies the
+// flags and ETCD_* environment variables that are set on top of it. A flag
+// takes precedence over its environment variable, which takes precedence over
+// the configuration file. Combinations that are invalid whatever their source,
+// such as the discovery field with --initial-cluster, are rejected.
+func (cfg *config) configFromFileAndCmdLine() error {
+	err := flags.SetFlagsFromEnv("ETCD", cfg.FlagSet)
+	if err != nil {
+		plog.Fatalf("%v", err)
+	}
+
+	// loading the file overwrites the fields the flags are bound to, so
+	// remember the values given and set them again once it is loaded
+	set := make(map[string]string)
+	cfg.FlagSet.Visit(func(f *flag.Flag) {
+		if _, ok := f.Value.(*flags.IgnoredFlag); !ok {
+			set[f.Name] = f.Value.String()
+		}
+	})
+
+	if err = cfg.configFromFile(cfg.configFile); err != nil {
+		return err
+	}
+	for name, v := range set {
+		if err = cfg.FlagSet.Set(name, v); err != nil {
+			return fmt.Errorf("invalid value %q for flag --%s: %v", v, name, err)
+		}
+	}
+
+	isSet := func(name string) bool {
+		_, ok := set[name]
+		return ok
+	}
+	urlFlags := map[string]*[]url.URL{
+		"listen-peer-urls":            &cfg.LPUrls,
+		"initial-advertise-peer-urls": &cfg.APUrls,
+		"listen-client-urls":          &cfg.LCUrls,
+		"advertise-client-urls":       &cfg.ACUrls,
+	}
+	for name, us := range urlFlags {
+		if isSet(name) {
+			*us = flags.URLsFromFlag(cfg.FlagSet, name)
+		}
+	}
+	if isSet("listen-metrics-urls") {
+		u, err := types.NewURLs(strings.Split(cfg.ListenMetricsUrlsJSON, ","))
+		if err != nil {
+			return fmt.Errorf("invalid value for flag --listen-metrics-urls: %v", err)
+		}
+		cfg.ListenMetricsUrls = []url.URL(u)
+	}
+	if isSet("cors") {
+		cfg.CorsInfo = cfg.FlagSet.Lookup("cors").Value.(*cors.CORSInfo)
+	}
+	if isSet("max-concurrent-streams") {
+		cfg.MaxConcurrentStreams = flags.Uint32FromFlag(cfg.FlagSet, "max-concurrent-streams")
+	}
+	if isSet("experimental-feature") {
+		cfg.ExperimentalFeatures = cfg.featureGate.String()
+	}
+	if isSet("cipher-suites") {
+		cfg.CipherSuites = strings.Split(cfg.cipherSuites, ",")
+	}
+	if isSet("initial-cluster-state") {
+		cfg.ClusterState = cfg.clusterState.String()
+	}
+	cfg.Fallback = cfg.fallback.String()
+	cfg.Proxy = cfg.proxy.String()
+
+	// disable the default initial-cluster if discovery is set by a flag
+	if (isSet("discovery") || isSet("discovery-srv")) && !isSet("initial-cluster") &&
+		cfg.InitialCluster == embed.NewConfig().InitialCluster {
+		cfg.InitialCluster = ""
+	}
+
+	return cfg.validate()
+}
+
 func (cfg *config) configFromCmdLine() error {
 	err := flags.SetFlagsFromEnv("ETCD", cfg.FlagSet)
 	if err != nil {
@@ -264,6 +388,8 @@ func (cfg *config) configFromCmdLine() error {
 	cfg.APUrls = flags.URLsFromFlag(cfg.FlagSet, "initial-advertise-peer-urls")
 	cfg.LCUrls = flags.URLsFromFlag(cfg.FlagSet, "listen-client-urls")
 	cfg.ACUrls = flags.URLsFromFlag(cfg.FlagSet, "advertise-client-urls")
+	cfg.MaxConcurrentStreams = flags.Uint32FromFlag(cfg.FlagSet, "max-concurrent-streams")
+	cfg.ExperimentalFeatures = cfg.featureGate.String()
 
 	if len(cfg.ListenMetricsUrlsJSON) > 0 {
 		u, err := types.NewURLs(strings.Split(cfg.ListenMetricsUrlsJSON, ","))
@@ -273,6 +399,10 @@ func (cfg *config) configFromCmdLine() error {
 		cfg.ListenMetricsUrls = []url.URL(u)
 	}
 
+	if cfg.cipherSuites != "" {
+		cfg.CipherSuites = strings.Split(cfg.cipherSuites, ",")
+	}
+
 	cfg.ClusterState = cfg.clusterState.String()
 	cfg.Fallback = cfg.fallback.String()
 	cfg.Proxy = cfg.proxy.String()
@@ -292,6 +422,18 @@ func (cfg *config) configFromCmdLine() error {
 }
 
 func (cfg *config) configFromFile(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	unknown, err := embed.UnknownConfigFileKeys(b, &cfg.configProxy)
+	if err != nil {
+		return err
+	}
+	if len(unknown) != 0 {
+		return fmt.Errorf("unknown fields %q in configuration file %q", unknown, path)
+	}
+
 	eCfg, err := embed.ConfigFromFile(path)
 	if err != nil {
 		return err
@@ -299,10 +441,6 @@ func (cfg *config) configFromFile(path string) error {
 	cfg.Config = *eCfg
 
 	// load extra config information
-	b, rerr := ioutil.ReadFile(path)
-	if rerr != nil {
-		return rerr
-	}
 	if yerr := yaml.Unmarshal(b, &cfg.configProxy); yerr != nil {
 		return yerr
 	}
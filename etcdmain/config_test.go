@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/coreos/etcd/embed"
 	"github.com/ghodss/yaml"
@@ -196,7 +197,13 @@ func TestConfigFileClusteringFlags(t *testing.T) {
 }
 
 func TestConfigParsingOtherFlags(t *testing.T) {
-	args := []string{"-proxy=readonly"}
+	args := []string{
+		"-proxy=readonly",
+		"-max-concurrent-streams=10",
+		"-grpc-keepalive-permit-without-stream=true",
+		"-grpc-max-recv-msg-size=4194304",
+		"-grpc-max-send-msg-size=8388608",
+	}
 
 	cfg := newConfig()
 	err := cfg.parse(args)
@@ -209,9 +216,17 @@ func TestConfigParsingOtherFlags(t *testing.T) {
 
 func TestConfigFileOtherFields(t *testing.T) {
 	yc := struct {
-		ProxyCfgFile string `json:"proxy"`
+		ProxyCfgFile                     string `json:"proxy"`
+		MaxConcurrentStreams             uint32 `json:"max-concurrent-streams"`
+		GRPCKeepAlivePermitWithoutStream bool   `json:"grpc-keepalive-permit-without-stream"`
+		GRPCMaxRecvMsgSize               uint   `json:"grpc-max-recv-msg-size"`
+		GRPCMaxSendMsgSize               uint   `json:"grpc-max-send-msg-size"`
 	}{
 		"readonly",
+		10,
+		true,
+		4194304,
+		8388608,
 	}
 
 	b, err := yaml.Marshal(&yc)
@@ -483,6 +498,119 @@ func TestConfigFileElectionTimeout(t *testing.T) {
 	}
 }
 
+func TestConfigFileUnknownFields(t *testing.T) {
+	tmpfile := mustCreateCfgFile(t, []byte("name: infra0\nsnapshot-cnt: 10\nproxy: readonly\n"))
+	defer os.Remove(tmpfile.Name())
+
+	args := []string{fmt.Sprintf("--config-file=%s", tmpfile.Name())}
+	cfg := newConfig()
+	err := cfg.parse(args)
+	if err == nil || !strings.Contains(err.Error(), `unknown fields ["snapshot-cnt"]`) {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestConfigFileSample(t *testing.T) {
+	b, err := ioutil.ReadFile("../etcd.conf.yml.sample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := newConfig()
+	unknown, err := embed.UnknownConfigFileKeys(b, &cfg.configProxy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown) != 0 {
+		t.Fatalf("unexpected unknown fields %v in sample configuration", unknown)
+	}
+}
+
+func TestConfigFileWithFlags(t *testing.T) {
+	tmpfile := mustCreateCfgFile(t, []byte("name: infra0\nsnapshot-count: 10\nlisten-client-urls: http://localhost:7000\ndiscovery: http://example.com/abc\n"))
+	defer os.Remove(tmpfile.Name())
+
+	os.Setenv("ETCD_SNAPSHOT_COUNT", "20")
+	os.Setenv("ETCD_NAME", "infra-env")
+	defer os.Unsetenv("ETCD_SNAPSHOT_COUNT")
+	defer os.Unsetenv("ETCD_NAME")
+
+	args := []string{
+		fmt.Sprintf("--config-file=%s", tmpfile.Name()),
+		"--name=infra1",
+		"--listen-client-urls=http://localhost:8000",
+		"--advertise-client-urls=http://localhost:8000",
+	}
+	cfg := newConfig()
+	if err := cfg.parse(args); err != nil {
+		t.Fatal(err)
+	}
+	// flag over environment variable over file
+	if cfg.Name != "infra1" {
+		t.Errorf("name = %q, want %q", cfg.Name, "infra1")
+	}
+	// environment variable over file
+	if cfg.SnapCount != 20 {
+		t.Errorf("snapshot-count = %d, want %d", cfg.SnapCount, 20)
+	}
+	if len(cfg.LCUrls) != 1 || cfg.LCUrls[0].String() != "http://localhost:8000" {
+		t.Errorf("listen-client-urls = %v, want [http://localhost:8000]", cfg.LCUrls)
+	}
+	// file value kept when no flag or environment variable is set
+	if cfg.Durl != "http://example.com/abc" {
+		t.Errorf("discovery = %q, want %q", cfg.Durl, "http://example.com/abc")
+	}
+}
+
+func TestConfigFileWithConflictingFlags(t *testing.T) {
+	tmpfile := mustCreateCfgFile(t, []byte("name: infra0\ndiscovery: http://example.com/abc\n"))
+	defer os.Remove(tmpfile.Name())
+
+	args := []string{
+		fmt.Sprintf("--config-file=%s", tmpfile.Name()),
+		"--initial-cluster=infra0=http://localhost:2380",
+	}
+	cfg := newConfig()
+	if err := cfg.parse(args); err != embed.ErrConflictBootstrapFlags {
+		t.Fatalf("err = %v, want %v", err, embed.ErrConflictBootstrapFlags)
+	}
+}
+
+func TestConfigParsingExperimentalFeature(t *testing.T) {
+	cfg := newConfig()
+	args := []string{"--experimental-feature=NoLeaderHeader=false", "--experimental-feature", "NoLeaderHeader=true"}
+	if err := cfg.parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ExperimentalFeatures != "NoLeaderHeader=true" {
+		t.Fatalf("experimental features = %q, want %q", cfg.ExperimentalFeatures, "NoLeaderHeader=true")
+	}
+
+	tmpfile := mustCreateCfgFile(t, []byte("experimental-feature: Unknown=true\n"))
+	defer os.Remove(tmpfile.Name())
+	cfg = newConfig()
+	args = []string{fmt.Sprintf("--config-file=%s", tmpfile.Name())}
+	if err := cfg.parse(args); err == nil || !strings.Contains(err.Error(), `unrecognized feature "Unknown"`) {
+		t.Fatalf("expected unrecognized feature error, got %v", err)
+	}
+}
+
+func TestConfigParsingBackendBatch(t *testing.T) {
+	cfg := newConfig()
+	args := []string{"--backend-batch-interval=10ms", "--backend-batch-limit=100", "--backend-prealloc-size=67108864"}
+	if err := cfg.parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BackendBatchInterval != 10*time.Millisecond {
+		t.Errorf("backend batch interval = %v, want %v", cfg.BackendBatchInterval, 10*time.Millisecond)
+	}
+	if cfg.BackendBatchLimit != 100 {
+		t.Errorf("backend batch limit = %d, want %d", cfg.BackendBatchLimit, 100)
+	}
+	if cfg.BackendPreallocSize != 64*1024*1024 {
+		t.Errorf("backend prealloc size = %d, want %d", cfg.BackendPreallocSize, 64*1024*1024)
+	}
+}
+
 func mustCreateCfgFile(t *testing.T, b []byte) *os.File {
 	tmpfile, err := ioutil.TempFile("", "servercfg")
 	if err != nil {
@@ -570,4 +698,16 @@ func validateOtherFlags(t *testing.T, cfg *config) {
 	if cfg.proxy.String() != wcfg.proxy.String() {
 		t.Errorf("proxy = %v, want %v", cfg.proxy, wcfg.proxy)
 	}
+	if cfg.MaxConcurrentStreams != 10 {
+		t.Errorf("max-concurrent-streams = %d, want 10", cfg.MaxConcurrentStreams)
+	}
+	if !cfg.GRPCKeepAlivePermitWithoutStream {
+		t.Errorf("grpc-keepalive-permit-without-stream = false, want true")
+	}
+	if cfg.GRPCMaxRecvMsgSize != 4194304 {
+		t.Errorf("grpc-max-recv-msg-size = %d, want 4194304", cfg.GRPCMaxRecvMsgSize)
+	}
+	if cfg.GRPCMaxSendMsgSize != 8388608 {
+		t.Errorf("grpc-max-send-msg-size = %d, want 8388608", cfg.GRPCMaxSendMsgSize)
+	}
 }
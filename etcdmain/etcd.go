@@ -34,7 +34,9 @@ import (
 	"github.com/coreos/etcd/pkg/cors"
 	"github.com/coreos/etcd/pkg/fileutil"
 	pkgioutil "github.com/coreos/etcd/pkg/ioutil"
+	"github.com/coreos/etcd/pkg/logutil"
 	"github.com/coreos/etcd/pkg/osutil"
+	"github.com/coreos/etcd/pkg/tlsutil"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/proxy/httpproxy"
@@ -78,6 +80,9 @@ func startEtcdOrProxyV2() {
 	plog.Infof("Git SHA: %s\n", version.GitSHA)
 	plog.Infof("Go Version: %s\n", runtime.Version())
 	plog.Infof("Go OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	if tlsutil.FIPSMode {
+		plog.Infof("TLS restricted to FIPS 140-2 approved settings")
+	}
 
 	GoMaxProcs := runtime.GOMAXPROCS(0)
 	plog.Infof("setting maximum number of CPUs to %d, total number of available CPUs is %d", GoMaxProcs, runtime.NumCPU())
@@ -411,15 +416,31 @@ func setupLogging(cfg *config) {
 	// capnslog initially SetFormatter(NewDefaultFormatter(os.Stderr))
 	// where NewDefaultFormatter returns NewJournaldFormatter when syscall.Getppid() == 1
 	// specify 'stdout' or 'stderr' to skip journald logging even when running under systemd
+	// text entries carry their fields as key=value after the message
 	switch cfg.logOutput {
 	case "stdout":
-		capnslog.SetFormatter(capnslog.NewPrettyFormatter(os.Stdout, cfg.Debug))
+		capnslog.SetFormatter(logutil.NewFieldFormatter(capnslog.NewPrettyFormatter(os.Stdout, cfg.Debug)))
 	case "stderr":
-		capnslog.SetFormatter(capnslog.NewPrettyFormatter(os.Stderr, cfg.Debug))
+		capnslog.SetFormatter(logutil.NewFieldFormatter(capnslog.NewPrettyFormatter(os.Stderr, cfg.Debug)))
 	case "default":
+		capnslog.SetFormatter(logutil.NewFieldFormatter(capnslog.NewDefaultFormatter(os.Stderr)))
 	default:
 		plog.Panicf(`unknown log-output %q (only supports "default", "stdout", "stderr")`, cfg.logOutput)
 	}
+
+	switch cfg.logFormat {
+	case "text":
+	case "json":
+		// journald has its own structured format; json entries go to stderr
+		// unless stdout was requested explicitly
+		w := os.Stderr
+		if cfg.logOutput == "stdout" {
+			w = os.Stdout
+		}
+		capnslog.SetFormatter(logutil.NewJSONFormatter(w, map[string]string{"name": cfg.Name}))
+	default:
+		plog.Panicf(`unknown log-format %q (only supports "text", "json")`, cfg.logFormat)
+	}
 }
 
 func checkSupportArch() {
@@ -269,7 +269,7 @@ func mustListenCMux(tlsinfo *transport.TLSInfo) cmux.CMux {
 
 func newGRPCProxyServer(client *clientv3.Client) *grpc.Server {
 	if grpcProxyEnableOrdering {
-		vf := ordering.NewOrderViolationSwitchEndpointClosure(*client)
+		vf := ordering.NewOrderViolationSwitchEndpointClosure(client)
 		client.KV = ordering.NewKV(client.KV, vf)
 		plog.Infof("waiting for linearized read from cluster to recover ordering")
 		for {
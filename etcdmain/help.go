@@ -38,6 +38,9 @@ var (
 
        etcd grpc-proxy
        run the stateless etcd v3 gRPC L7 reverse proxy
+
+       etcd replicator
+       run the asynchronous replicator of a primary cluster to a standby cluster
 	`
 	flagsline = `
 member flags:
@@ -66,16 +69,50 @@ member flags:
 		comma-separated whitelist of origins for CORS (cross-origin resource sharing).
 	--quota-backend-bytes '0'
 		raise alarms when backend size exceeds the given quota (0 defaults to low space quota).
+	--prefix-quotas ''
+		',' separated 'prefix=bytes:keys' quotas rejecting writes that take a prefix over its quota, e.g. '/tenant-a/=1048576:1000'. 0 means unlimited.
+	--memory-budget '0'
+		approximate memory in bytes the key index, watch buffers and raft log may hold before new watchers and large ranges are rejected (0 is unlimited).
+	--backend-batch-interval '0s'
+		maximum time before committing the backend transaction (0 defaults to 100ms).
+	--backend-batch-limit '0'
+		maximum operations before committing the backend transaction (0 defaults to 10000).
+	--backend-prealloc-size '0'
+		size in bytes of the chunks the backend file's disk space is preallocated in as it grows (0 disables preallocation).
 	--max-txn-ops '128'
 		maximum number of operations permitted in a transaction.
 	--max-request-bytes '1572864'
 		maximum client request size in bytes the server will accept.
+	--max-value-bytes '0'
+		maximum size in bytes of a value a put may store. 0 means no limit.
+	--max-proposal-backlog-bytes '0'
+		maximum size in bytes of the proposals waiting to be applied before client proposals are rejected. 0 means no limit.
+	--max-watchers '0'
+		maximum number of watchers the server will accept. 0 means no limit.
+	--max-watchers-per-stream '0'
+		maximum number of watchers a single watch stream will accept. 0 means no limit.
+	--max-connections-per-client '0'
+		maximum number of connections the server will accept from a single client. 0 means no limit.
+	--max-streams-per-client '0'
+		maximum number of gRPC streams the server will accept from a single client. 0 means no limit.
+	--max-watchers-per-client '0'
+		maximum number of watchers the server will accept from a single client. 0 means no limit.
 	--grpc-keepalive-min-time '5s'
 		minimum duration interval that a client should wait before pinging server.
 	--grpc-keepalive-interval '2h'
 		frequency duration of server-to-client ping to check if a connection is alive (0 to disable).
 	--grpc-keepalive-timeout '20s'
 		additional duration of wait before closing a non-responsive connection (0 to disable).
+	--grpc-keepalive-permit-without-stream 'false'
+		allow clients to ping the server while they have no active streams.
+	--max-concurrent-streams 'math.MaxUint32'
+		maximum concurrent streams that each client can open at a time.
+	--grpc-max-recv-msg-size '0'
+		maximum size in bytes of a message the gRPC server receives. 0 means --max-request-bytes plus the gRPC overhead.
+	--grpc-max-send-msg-size '0'
+		maximum size in bytes of a message the gRPC server sends. 0 means math.MaxInt32.
+	--graceful-shutdown-timeout '0s'
+		maximum duration to wait for in-flight client requests on shutdown (0 to use the request timeout).
 
 clustering flags:
 
@@ -102,10 +139,14 @@ clustering flags:
 		dns srv domain used to bootstrap the cluster.
 	--strict-reconfig-check
 		reject reconfiguration requests that would cause quorum loss.
+	--read-only
+		start the member refusing v3 client writes while it keeps replicating the cluster.
 	--auto-compaction-retention '0'
 		auto compaction retention length. 0 means disable auto compaction.
 	--auto-compaction-mode 'periodic'
 		interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.
+	--auto-compaction-windows ''
+		';' separated maintenance windows in local time auto compaction runs in, e.g. 'mon-fri 01:00-05:00;sat,sun 00:00-24:00'. Empty means any time.
 	--enable-v2
 		Accept etcd V2 client requests.
 
@@ -156,6 +197,12 @@ security flags:
 		peer TLS using self-generated certificates if --peer-key-file and --peer-cert-file are not provided.
 	--peer-crl-file ''
 		path to the peer certificate revocation list file.
+	--cipher-suites ''
+		comma-separated list of supported TLS cipher suites between client/server and peers (empty will be auto-populated by Go).
+	--tls-min-version ''
+		minimum TLS version between client/server and peers, e.g. 'TLS1.2' (empty defaults to TLS1.2).
+	--tls-max-version ''
+		maximum TLS version between client/server and peers, e.g. 'TLS1.2' (empty defaults to the latest version).
 
 logging flags
 
@@ -165,6 +212,8 @@ logging flags
 		specify a particular log level for each etcd package (eg: 'etcdmain=CRITICAL,etcdserver=DEBUG').
 	--log-output 'default'
 		specify 'stdout' or 'stderr' to skip journald logging even when running under systemd.
+	--log-format 'text'
+		specify 'json' to write each log entry as a JSON object instead of text.
 
 unsafe flags:
 
@@ -191,5 +240,37 @@ experimental flags:
 	        duration of time between cluster corruption check passes.
 	--experimental-enable-v2v3 ''
 		serve v2 requests through the v3 backend under a given prefix.
+	--experimental-warning-apply-duration '0s'
+		time duration after which a warning is logged for a slow request (0 to disable).
+	--experimental-feature ''
+		comma-separated Feature=bool pairs toggling experimental features, may be repeated (e.g. 'NoLeaderHeader=false').
+	--experimental-key-index-save-interval '0s'
+		duration of time between saves of the key index to the backend, to speed up restarts (0 to disable).
+	--experimental-max-unsynced-watchers '0'
+		number of unsynced watchers past which watches on past revisions are refused with a retry hint (0 to disable).
+	--experimental-watch-event-history-size '0'
+		number of recent events kept in memory so lagging watchers sync without reading the backend (0 to disable).
+	--experimental-watch-event-history-max-age '0s'
+		maximum age of the events kept in the watch event history (0 for no limit).
+	--experimental-watch-ack-window '0'
+		number of unacknowledged responses sent to each watcher of watch streams whose client acknowledges responses (0 to disable).
+	--experimental-wal-dsync 'false'
+		open the WAL files with O_DSYNC where supported, so each write reaches stable storage as it returns.
+	--experimental-backend-dsync 'false'
+		open the backend file with O_DSYNC where supported, so each page write reaches stable storage as it returns.
+	--experimental-op-log ''
+		path of a file to append a JSON record of every client request's invocation and return to, for linearizability checkers.
+	--experimental-apply-workers '0'
+		number of goroutines preparing committed entries, by decoding them and evaluating the compares of independent txns, before they are applied in order (less than 2 to disable).
+	--experimental-snapshot-catchup-entries '5000'
+		number of entries kept in the raft log behind the index it is compacted to, for slow followers to catch up from.
+	--experimental-witness 'false'
+		start the member as a witness, which votes but stores no key-value data and never becomes the leader.
+	--experimental-value-indexes ''
+		',' separated 'name=prefix:pointer' indexes of the JSON values of the keys under prefix by the field at the JSON pointer, e.g. 'by-owner=/jobs/:/owner'.
+	--experimental-admission-hooks ''
+		',' separated names of the compiled-in admission hooks to run on client writes, in order.
+	--experimental-value-schemas ''
+		',' separated 'prefix=file' schemas the values of the keys under prefix must conform to, read from a JSON Schema file or, for 'file#message', a protobuf descriptor set.
 `
 )
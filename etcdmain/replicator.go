@@ -0,0 +1,172 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdmain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/changefeed"
+	"github.com/coreos/etcd/clientv3/replicator"
+	"github.com/coreos/etcd/etcdserver/api/etcdhttp"
+	"github.com/coreos/etcd/pkg/transport"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replicatorSourceEndpoints       []string
+	replicatorEndpoints             []string
+	replicatorMetricsAddr           string
+	replicatorName                  string
+	replicatorPrefix                string
+	replicatorDestPrefix            string
+	replicatorMetaPrefix            string
+	replicatorMaxTxnOps             int
+	replicatorRetryInterval         time.Duration
+	replicatorSourceCA              string
+	replicatorSourceCert            string
+	replicatorSourceKey             string
+	replicatorCA                    string
+	replicatorCert                  string
+	replicatorKey                   string
+	replicatorInsecureSkipTLSVerify bool
+)
+
+func init() {
+	rootCmd.AddCommand(newReplicatorCommand())
+}
+
+// newReplicatorCommand returns the cobra command for "replicator".
+func newReplicatorCommand() *cobra.Command {
+	lpc := &cobra.Command{
+		Use:   "replicator <subcommand>",
+		Short: "replicator related command",
+	}
+	lpc.AddCommand(newReplicatorStartCommand())
+
+	return lpc
+}
+
+func newReplicatorStartCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "start",
+		Short: "start replicating a primary cluster to a standby cluster",
+		Run:   startReplicator,
+	}
+
+	cmd.Flags().StringSliceVar(&replicatorSourceEndpoints, "source-endpoints", []string{"127.0.0.1:2379"}, "comma separated endpoints of the primary cluster")
+	cmd.Flags().StringSliceVar(&replicatorEndpoints, "endpoints", nil, "comma separated endpoints of the standby cluster")
+	cmd.Flags().StringVar(&replicatorMetricsAddr, "metrics-addr", "", "listen for /metrics requests on this address")
+	cmd.Flags().StringVar(&replicatorName, "name", "", "name of the replication in its checkpoint key (defaults to the primary cluster ID)")
+	cmd.Flags().StringVar(&replicatorPrefix, "prefix", "", "key prefix to replicate (defaults to all keys)")
+	cmd.Flags().StringVar(&replicatorDestPrefix, "dest-prefix", "", "prefix replacing --prefix in the keys written to the standby (defaults to --prefix)")
+	cmd.Flags().StringVar(&replicatorMetaPrefix, "meta-prefix", replicator.DefaultMetaPrefix, "prefix of the checkpoint keys, relative to the replicated prefix")
+	cmd.Flags().IntVar(&replicatorMaxTxnOps, "max-txn-ops", 128, "maximum number of operations per transaction on the standby")
+	cmd.Flags().DurationVar(&replicatorRetryInterval, "retry-interval", time.Second, "wait before retrying after a failure")
+
+	// client TLS for connecting to the primary
+	cmd.Flags().StringVar(&replicatorSourceCert, "source-cert", "", "identify secure connections with the primary using this TLS certificate file")
+	cmd.Flags().StringVar(&replicatorSourceKey, "source-key", "", "identify secure connections with the primary using this TLS key file")
+	cmd.Flags().StringVar(&replicatorSourceCA, "source-cacert", "", "verify certificates of the TLS-enabled primary using this CA bundle")
+
+	// client TLS for connecting to the standby
+	cmd.Flags().StringVar(&replicatorCert, "cert", "", "identify secure connections with the standby using this TLS certificate file")
+	cmd.Flags().StringVar(&replicatorKey, "key", "", "identify secure connections with the standby using this TLS key file")
+	cmd.Flags().StringVar(&replicatorCA, "cacert", "", "verify certificates of the TLS-enabled standby using this CA bundle")
+	cmd.Flags().BoolVar(&replicatorInsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "skip authentication of etcd server TLS certificates")
+	return &cmd
+}
+
+func startReplicator(cmd *cobra.Command, args []string) {
+	if len(replicatorEndpoints) == 0 {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("no standby endpoints given; set --endpoints"))
+		os.Exit(1)
+	}
+
+	src := mustNewReplicatorClient(replicatorSourceEndpoints, newTLS(replicatorSourceCA, replicatorSourceCert, replicatorSourceKey))
+	dst := mustNewReplicatorClient(replicatorEndpoints, newTLS(replicatorCA, replicatorCert, replicatorKey))
+
+	if len(replicatorMetricsAddr) > 0 {
+		murl, err := url.Parse(replicatorMetricsAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot parse %q", replicatorMetricsAddr)
+			os.Exit(1)
+		}
+		ml, err := transport.NewListener(murl.Host, murl.Scheme, nil)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		plog.Info("replicator: listening for metrics on ", murl.String())
+		go func() {
+			mux := http.NewServeMux()
+			etcdhttp.HandlePrometheus(mux)
+			plog.Fatal(http.Serve(ml, mux))
+		}()
+	}
+
+	r := replicator.New(src, dst, replicator.Config{
+		Name:          replicatorName,
+		Prefix:        replicatorPrefix,
+		DestPrefix:    replicatorDestPrefix,
+		MetaPrefix:    replicatorMetaPrefix,
+		MaxTxnOps:     replicatorMaxTxnOps,
+		RetryInterval: replicatorRetryInterval,
+	})
+
+	// the replicator is initialized, ready to replicate
+	notifySystemd()
+
+	for {
+		err := r.Run(context.Background())
+		if err == changefeed.ErrCompacted {
+			plog.Fatalf("replicator: %v; the standby must be rebuilt from a fresh copy of the primary", err)
+		}
+		plog.Warningf("replicator: %v; retrying in %v", err, replicatorRetryInterval)
+		time.Sleep(replicatorRetryInterval)
+	}
+}
+
+func mustNewReplicatorClient(eps []string, tls *transport.TLSInfo) *clientv3.Client {
+	cfg := clientv3.Config{
+		Endpoints:   eps,
+		DialTimeout: 5 * time.Second,
+	}
+	if tls == nil && replicatorInsecureSkipTLSVerify {
+		tls = &transport.TLSInfo{}
+	}
+	if tls != nil {
+		clientTLS, err := tls.ClientConfig()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		clientTLS.InsecureSkipVerify = replicatorInsecureSkipTLSVerify
+		cfg.TLS = clientTLS
+		plog.Infof("ClientTLS: %s", tls)
+	}
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return client
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// Hook admits the writes of client requests. A hook may rewrite the request
+// it is given in place; an error rejects the request.
+type Hook interface {
+	// Name identifies the hook to --experimental-admission-hooks and in
+	// the errors of the requests it rejects.
+	Name() string
+	AdmitPut(ctx context.Context, r *pb.PutRequest) error
+	AdmitDeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) error
+}
+
+// RejectError is the error of a request rejected by a hook.
+type RejectError struct {
+	Hook string
+	Err  error
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("admission hook %q rejected request: %v", e.Hook, e.Err)
+}
+
+var (
+	hooksMu sync.Mutex
+	hooks   = make(map[string]Hook)
+)
+
+// Register makes a hook available to Lookup by its name. It panics if a
+// hook of the same name is already registered.
+func Register(h Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	if _, ok := hooks[h.Name()]; ok {
+		panic(fmt.Sprintf("admission: hook %q registered twice", h.Name()))
+	}
+	hooks[h.Name()] = h
+}
+
+// Registered returns the names of the registered hooks, sorted.
+func Registered() []string {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the registered hooks of a ',' separated list of names.
+func Lookup(s string) ([]Hook, error) {
+	var hs []Hook
+	if strings.TrimSpace(s) == "" {
+		return hs, nil
+	}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	for _, name := range strings.Split(s, ",") {
+		h, ok := hooks[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown admission hook %q", name)
+		}
+		hs = append(hs, h)
+	}
+	return hs, nil
+}
+
+// Chain runs hooks in order, stopping at the first to reject a request.
+type Chain []Hook
+
+// AdmitPut runs the hooks on a put, returning a *RejectError if one
+// rejects it.
+func (c Chain) AdmitPut(ctx context.Context, r *pb.PutRequest) error {
+	for _, h := range c {
+		if err := h.AdmitPut(ctx, r); err != nil {
+			return &RejectError{Hook: h.Name(), Err: err}
+		}
+	}
+	return nil
+}
+
+// AdmitDeleteRange runs the hooks on a delete range, returning a
+// *RejectError if one rejects it.
+func (c Chain) AdmitDeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) error {
+	for _, h := range c {
+		if err := h.AdmitDeleteRange(ctx, r); err != nil {
+			return &RejectError{Hook: h.Name(), Err: err}
+		}
+	}
+	return nil
+}
+
+// AdmitTxn runs the hooks on the puts and delete ranges of both branches
+// of a txn, including those of nested txns. The txn is rejected if any of
+// them is, whichever branch it is in.
+func (c Chain) AdmitTxn(ctx context.Context, r *pb.TxnRequest) error {
+	for _, reqs := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, u := range reqs {
+			var err error
+			switch tv := u.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				if tv.RequestPut != nil {
+					err = c.AdmitPut(ctx, tv.RequestPut)
+				}
+			case *pb.RequestOp_RequestDeleteRange:
+				if tv.RequestDeleteRange != nil {
+					err = c.AdmitDeleteRange(ctx, tv.RequestDeleteRange)
+				}
+			case *pb.RequestOp_RequestTxn:
+				if tv.RequestTxn != nil {
+					err = c.AdmitTxn(ctx, tv.RequestTxn)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
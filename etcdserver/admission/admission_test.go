@@ -0,0 +1,103 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// prefixHook rejects keys without a prefix and lowercases the keys it admits.
+type prefixHook struct {
+	name   string
+	prefix string
+}
+
+func (h *prefixHook) Name() string { return h.name }
+
+func (h *prefixHook) AdmitPut(ctx context.Context, r *pb.PutRequest) error {
+	return h.admit(&r.Key)
+}
+
+func (h *prefixHook) AdmitDeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) error {
+	return h.admit(&r.Key)
+}
+
+func (h *prefixHook) admit(key *[]byte) error {
+	if !strings.HasPrefix(string(*key), h.prefix) {
+		return errors.New("missing prefix " + h.prefix)
+	}
+	*key = []byte(strings.ToLower(string(*key)))
+	return nil
+}
+
+func TestLookup(t *testing.T) {
+	Register(&prefixHook{name: "test-a"})
+	Register(&prefixHook{name: "test-b"})
+
+	tests := []struct {
+		s string
+
+		wnames []string
+		werr   bool
+	}{
+		{"", nil, false},
+		{"test-b,test-a", []string{"test-b", "test-a"}, false},
+		{"test-a,test-c", nil, true},
+	}
+	for i, tt := range tests {
+		hs, err := Lookup(tt.s)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		var names []string
+		for _, h := range hs {
+			names = append(names, h.Name())
+		}
+		if strings.Join(names, ",") != strings.Join(tt.wnames, ",") {
+			t.Errorf("#%d: hooks = %v, want %v", i, names, tt.wnames)
+		}
+	}
+}
+
+func TestChainAdmitTxn(t *testing.T) {
+	c := Chain{&prefixHook{name: "a", prefix: "/A"}, &prefixHook{name: "b", prefix: "/a/"}}
+
+	put := &pb.PutRequest{Key: []byte("/A/X")}
+	txn := &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: put}}},
+	}
+	if err := c.AdmitTxn(context.TODO(), txn); err != nil {
+		t.Fatal(err)
+	}
+	// the second hook sees the key rewritten by the first
+	if string(put.Key) != "/a/x" {
+		t.Fatalf("key = %q, want %q", put.Key, "/a/x")
+	}
+
+	del := &pb.DeleteRangeRequest{Key: []byte("/B")}
+	txn.Failure = []*pb.RequestOp{{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: del}}},
+	}}}}
+	err := c.AdmitTxn(context.TODO(), txn)
+	rerr, ok := err.(*RejectError)
+	if !ok || rerr.Hook != "a" {
+		t.Fatalf("err = %v, want rejection by hook a", err)
+	}
+}
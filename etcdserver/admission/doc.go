@@ -0,0 +1,31 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission lets compiled-in hooks admit, reject or rewrite the
+// writes of client requests before the server proposes them.
+//
+// A hook is compiled into the etcd binary by importing the package that
+// implements it, which registers it from its init function:
+//
+//	func init() { admission.Register(&namingHook{}) }
+//
+// The hook only runs once enabled by name with
+// --experimental-admission-hooks. Embedding applications may instead pass
+// hooks directly in embed.Config.AdmissionHooks.
+//
+// Hooks run on the member that receives the request, in the order they are
+// enabled, before the request is proposed. A hook may rewrite the request it
+// is given; the cluster applies the rewritten request, so permissions are
+// checked against the rewritten keys.
+package admission
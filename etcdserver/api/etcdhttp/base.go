@@ -46,6 +46,12 @@ const (
 func HandleBasic(mux *http.ServeMux, server etcdserver.ServerPeer) {
 	mux.HandleFunc(varsPath, serveVars)
 	mux.HandleFunc(configPath+"/local/log", logHandleFunc)
+	if p, ok := server.(autoCompactionPauser); ok {
+		mux.HandleFunc(configPath+"/local/auto-compaction", autoCompactionHandleFunc(p))
+	}
+	if ro, ok := server.(readOnlySetter); ok {
+		mux.HandleFunc(configPath+"/local/read-only", readOnlyHandleFunc(ro))
+	}
 	HandleMetricsHealth(mux, server)
 	mux.HandleFunc(versionPath, versionHandler(server.Cluster(), serveVersion))
 }
@@ -102,6 +108,73 @@ func logHandleFunc(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// autoCompactionPauser is a server whose auto compaction can be paused.
+type autoCompactionPauser interface {
+	PauseAutoCompaction(paused bool) error
+	AutoCompactionPaused() (bool, error)
+}
+
+func autoCompactionHandleFunc(p autoCompactionPauser) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			out struct{ Paused bool }
+			err error
+		)
+		switch r.Method {
+		case "GET":
+			out.Paused, err = p.AutoCompactionPaused()
+		case "PUT":
+			if derr := json.NewDecoder(r.Body).Decode(&out); derr != nil {
+				WriteError(w, r, httptypes.NewHTTPError(http.StatusBadRequest, "Invalid json body"))
+				return
+			}
+			err = p.PauseAutoCompaction(out.Paused)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err != nil {
+			WriteError(w, r, httptypes.NewHTTPError(http.StatusConflict, err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&out); err != nil {
+			plog.Warningf("failed to encode auto compaction state (%v)", err)
+		}
+	}
+}
+
+// readOnlySetter is a server that can refuse client writes.
+type readOnlySetter interface {
+	SetReadOnly(ro bool)
+	ReadOnly() bool
+}
+
+func readOnlyHandleFunc(ro readOnlySetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var out struct{ ReadOnly bool }
+		switch r.Method {
+		case "GET":
+		case "PUT":
+			if err := json.NewDecoder(r.Body).Decode(&out); err != nil {
+				WriteError(w, r, httptypes.NewHTTPError(http.StatusBadRequest, "Invalid json body"))
+				return
+			}
+			ro.SetReadOnly(out.ReadOnly)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		out.ReadOnly = ro.ReadOnly()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&out); err != nil {
+			plog.Warningf("failed to encode read-only mode (%v)", err)
+		}
+	}
+}
+
 func serveVars(w http.ResponseWriter, r *http.Request) {
 	if !allowMethod(w, r, "GET") {
 		return
@@ -0,0 +1,113 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver"
+)
+
+type fakeAutoCompactionPauser struct {
+	paused bool
+	err    error
+}
+
+func (p *fakeAutoCompactionPauser) PauseAutoCompaction(paused bool) error {
+	if p.err == nil {
+		p.paused = paused
+	}
+	return p.err
+}
+
+func (p *fakeAutoCompactionPauser) AutoCompactionPaused() (bool, error) { return p.paused, p.err }
+
+func TestAutoCompactionHandleFunc(t *testing.T) {
+	tests := []struct {
+		method, body string
+		err          error
+
+		wcode   int
+		wbody   string
+		wpaused bool
+	}{
+		{"GET", "", nil, http.StatusOK, "{\"Paused\":false}\n", false},
+		{"PUT", `{"Paused":true}`, nil, http.StatusOK, "{\"Paused\":true}\n", true},
+		{"PUT", `{"Paused"`, nil, http.StatusBadRequest, "", false},
+		{"PUT", `{"Paused":true}`, etcdserver.ErrNoAutoCompaction, http.StatusConflict, "", false},
+		{"POST", "", nil, http.StatusMethodNotAllowed, "", false},
+	}
+	for i, tt := range tests {
+		p := &fakeAutoCompactionPauser{err: tt.err}
+		req, err := http.NewRequest(tt.method, "", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rw := httptest.NewRecorder()
+		autoCompactionHandleFunc(p)(rw, req)
+		if rw.Code != tt.wcode {
+			t.Errorf("#%d: code = %d, want %d", i, rw.Code, tt.wcode)
+		}
+		if tt.wbody != "" && rw.Body.String() != tt.wbody {
+			t.Errorf("#%d: body = %q, want %q", i, rw.Body.String(), tt.wbody)
+		}
+		if p.paused != tt.wpaused {
+			t.Errorf("#%d: paused = %v, want %v", i, p.paused, tt.wpaused)
+		}
+	}
+}
+
+type fakeReadOnlySetter struct{ ro bool }
+
+func (s *fakeReadOnlySetter) SetReadOnly(ro bool) { s.ro = ro }
+func (s *fakeReadOnlySetter) ReadOnly() bool      { return s.ro }
+
+func TestReadOnlyHandleFunc(t *testing.T) {
+	tests := []struct {
+		method, body string
+		ro           bool
+
+		wcode int
+		wbody string
+		wro   bool
+	}{
+		{"GET", "", true, http.StatusOK, "{\"ReadOnly\":true}\n", true},
+		{"PUT", `{"ReadOnly":true}`, false, http.StatusOK, "{\"ReadOnly\":true}\n", true},
+		{"PUT", `{"ReadOnly":false}`, true, http.StatusOK, "{\"ReadOnly\":false}\n", false},
+		{"PUT", `{"ReadOnly"`, false, http.StatusBadRequest, "", false},
+		{"POST", "", false, http.StatusMethodNotAllowed, "", false},
+	}
+	for i, tt := range tests {
+		s := &fakeReadOnlySetter{ro: tt.ro}
+		req, err := http.NewRequest(tt.method, "", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rw := httptest.NewRecorder()
+		readOnlyHandleFunc(s)(rw, req)
+		if rw.Code != tt.wcode {
+			t.Errorf("#%d: code = %d, want %d", i, rw.Code, tt.wcode)
+		}
+		if tt.wbody != "" && rw.Body.String() != tt.wbody {
+			t.Errorf("#%d: body = %q, want %q", i, rw.Body.String(), tt.wbody)
+		}
+		if s.ro != tt.wro {
+			t.Errorf("#%d: read-only = %v, want %v", i, s.ro, tt.wro)
+		}
+	}
+}
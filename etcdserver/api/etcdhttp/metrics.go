@@ -17,11 +17,15 @@ package etcdhttp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/raft"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -30,12 +34,21 @@ import (
 const (
 	pathMetrics = "/metrics"
 	PathHealth  = "/health"
+	PathReady   = "/readyz"
+
+	// checkBackend names the backend commit check in the exclude parameter.
+	checkBackend = "BACKEND"
+)
+
+var (
+	errBackendCommitTimeout = errors.New("etcdserver: backend commit timed out")
+	errDraining             = errors.New("etcdserver: server is draining")
 )
 
 // HandleMetricsHealth registers metrics and health handlers.
 func HandleMetricsHealth(mux *http.ServeMux, srv etcdserver.ServerV2) {
 	mux.Handle(pathMetrics, prometheus.Handler())
-	mux.Handle(PathHealth, NewHealthHandler(func() Health { return checkHealth(srv) }))
+	HandleHealth(mux, srv)
 }
 
 // HandlePrometheus registers prometheus handler on '/metrics'.
@@ -43,9 +56,39 @@ func HandlePrometheus(mux *http.ServeMux) {
 	mux.Handle(pathMetrics, prometheus.Handler())
 }
 
-// HandleHealth registers health handler on '/health'.
+// HandleHealth registers health handlers on '/health' and '/readyz'.
 func HandleHealth(mux *http.ServeMux, srv etcdserver.ServerV2) {
-	mux.Handle(PathHealth, NewHealthHandler(func() Health { return checkHealth(srv) }))
+	p := &commitProbe{}
+	mux.Handle(PathHealth, newServerHealthHandler(srv, p, false))
+	mux.Handle(PathReady, newServerHealthHandler(srv, p, true))
+}
+
+// newServerHealthHandler handles '/health' requests for an etcd server, or
+// '/readyz' requests if ready is set, which also fail while the server drains
+// before stopping. Checks can be tuned by query parameters:
+//
+//	exclude=NOSPACE,CORRUPT  ignores the given alarms
+//	exclude=BACKEND          skips the backend commit check
+//	serializable=true        checks with a local read instead of a quorum read
+func newServerHealthHandler(srv etcdserver.ServerV2, p *commitProbe, ready bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		excluded := make(map[string]struct{})
+		for _, v := range r.URL.Query()["exclude"] {
+			for _, a := range strings.Split(v, ",") {
+				excluded[strings.ToUpper(strings.TrimSpace(a))] = struct{}{}
+			}
+		}
+		serializable := r.URL.Query().Get("serializable") == "true"
+		hfunc := func() Health {
+			if ready {
+				if d, ok := srv.(drainNotifier); ok && isClosed(d.DrainNotify()) {
+					return Health{Errors: []string{errDraining.Error()}}
+				}
+			}
+			return checkHealth(srv, p, excluded, serializable)
+		}
+		NewHealthHandler(hfunc)(w, r)
+	}
 }
 
 // NewHealthHandler handles '/health' requests.
@@ -74,24 +117,58 @@ type Health struct {
 	Errors []string `json:"errors,omitempty"`
 }
 
-func checkHealth(srv etcdserver.ServerV2) Health {
+// backendGetter and drainNotifier are implemented by *etcdserver.EtcdServer;
+// the checks that need them are skipped for servers without them.
+type backendGetter interface {
+	Backend() backend.Backend
+}
+
+type drainNotifier interface {
+	DrainNotify() <-chan struct{}
+}
+
+func isClosed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func checkHealth(srv etcdserver.ServerV2, p *commitProbe, excluded map[string]struct{}, serializable bool) Health {
 	h := Health{Health: false}
 
-	as := srv.Alarms()
-	if len(as) > 0 {
-		for _, v := range as {
-			h.Errors = append(h.Errors, v.Alarm.String())
+	for _, v := range srv.Alarms() {
+		if _, ok := excluded[v.Alarm.String()]; ok {
+			continue
 		}
+		h.Errors = append(h.Errors, v.Alarm.String())
+	}
+	if len(h.Errors) > 0 {
 		return h
 	}
 
+	if bg, ok := srv.(backendGetter); ok {
+		if _, ok := excluded[checkBackend]; !ok {
+			if err := p.check(bg.Backend(), time.Second); err != nil {
+				h.Errors = append(h.Errors, err.Error())
+				return h
+			}
+		}
+	}
+
 	if uint64(srv.Leader()) == raft.None {
 		h.Errors = append(h.Errors, etcdserver.ErrNoLeader.Error())
 		return h
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	_, err := srv.Do(ctx, etcdserverpb.Request{Method: "QGET"})
+	method := "QGET"
+	if serializable {
+		method = "GET"
+	}
+	_, err := srv.Do(ctx, etcdserverpb.Request{Method: method})
 	cancel()
 	if err != nil {
 		h.Errors = append(h.Errors, err.Error())
@@ -100,3 +177,40 @@ func checkHealth(srv etcdserver.ServerV2) Health {
 	h.Health = err == nil
 	return h
 }
+
+// commitProbe forces backend commits for the health checks. A commit is only
+// started once the previous one returned, so probes of a stalled disk wait on
+// the same commit instead of piling up.
+type commitProbe struct {
+	mu    sync.Mutex
+	donec chan struct{}
+}
+
+// check returns an error if the backend failed to commit, or if a commit does
+// not return within timeout, as when the disk stalls.
+func (p *commitProbe) check(be backend.Backend, timeout time.Duration) error {
+	if err := be.Err(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	if p.donec == nil {
+		donec := make(chan struct{})
+		p.donec = donec
+		go func() {
+			be.ForceCommit()
+			p.mu.Lock()
+			p.donec = nil
+			p.mu.Unlock()
+			close(donec)
+		}()
+	}
+	donec := p.donec
+	p.mu.Unlock()
+
+	select {
+	case <-donec:
+		return be.Err()
+	case <-time.After(timeout):
+		return errBackendCommitTimeout
+	}
+}
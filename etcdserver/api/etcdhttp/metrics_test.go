@@ -0,0 +1,183 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/pkg/types"
+)
+
+type fakeHealthServer struct {
+	etcdserver.ServerV2
+	alarms []*pb.AlarmMember
+	method string
+	be     *fakeBackend
+	drainc chan struct{}
+}
+
+func (s *fakeHealthServer) Backend() backend.Backend     { return s.be }
+func (s *fakeHealthServer) DrainNotify() <-chan struct{} { return s.drainc }
+
+// fakeBackend fails its commits with err, and blocks them until unblockc is
+// closed if it is set.
+type fakeBackend struct {
+	backend.Backend
+	err       error
+	unblockc  chan struct{}
+	committed int
+}
+
+func (b *fakeBackend) Err() error { return b.err }
+func (b *fakeBackend) ForceCommit() {
+	if b.unblockc != nil {
+		<-b.unblockc
+	}
+	b.committed++
+}
+
+func (s *fakeHealthServer) Leader() types.ID          { return 1 }
+func (s *fakeHealthServer) Alarms() []*pb.AlarmMember { return s.alarms }
+func (s *fakeHealthServer) Do(ctx context.Context, r pb.Request) (etcdserver.Response, error) {
+	s.method = r.Method
+	return etcdserver.Response{}, nil
+}
+
+func TestHealthHandler(t *testing.T) {
+	nospace := []*pb.AlarmMember{{MemberID: 1, Alarm: pb.AlarmType_NOSPACE}}
+	tests := []struct {
+		alarms []*pb.AlarmMember
+		query  string
+
+		wcode   int
+		whealth bool
+		wmethod string
+	}{
+		{nil, "", http.StatusOK, true, "QGET"},
+		{nil, "?serializable=true", http.StatusOK, true, "GET"},
+		{nospace, "", http.StatusServiceUnavailable, false, ""},
+		{nospace, "?exclude=NOSPACE", http.StatusOK, true, "QGET"},
+		{nospace, "?exclude=corrupt,nospace", http.StatusOK, true, "QGET"},
+		{nospace, "?exclude=CORRUPT", http.StatusServiceUnavailable, false, ""},
+	}
+
+	for i, tt := range tests {
+		srv := &fakeHealthServer{alarms: tt.alarms, be: &fakeBackend{}, drainc: make(chan struct{})}
+		mux := http.NewServeMux()
+		HandleHealth(mux, srv)
+		ts := httptest.NewServer(mux)
+
+		resp, err := http.Get(ts.URL + PathHealth + tt.query)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var h Health
+		derr := json.NewDecoder(resp.Body).Decode(&h)
+		resp.Body.Close()
+		ts.Close()
+		if derr != nil {
+			t.Fatalf("#%d: %v", i, derr)
+		}
+
+		if resp.StatusCode != tt.wcode {
+			t.Errorf("#%d: status code = %d, want %d", i, resp.StatusCode, tt.wcode)
+		}
+		if h.Health != tt.whealth {
+			t.Errorf("#%d: health = %v, want %v", i, h.Health, tt.whealth)
+		}
+		if srv.method != tt.wmethod {
+			t.Errorf("#%d: method = %q, want %q", i, srv.method, tt.wmethod)
+		}
+	}
+}
+
+func TestHealthHandlerBackend(t *testing.T) {
+	tests := []struct {
+		be    *fakeBackend
+		query string
+
+		wcode   int
+		werr    string
+		wcommit bool
+	}{
+		{&fakeBackend{}, "", http.StatusOK, "", true},
+		{&fakeBackend{err: errors.New("disk failed")}, "", http.StatusServiceUnavailable, "disk failed", false},
+		{&fakeBackend{unblockc: make(chan struct{})}, "", http.StatusServiceUnavailable, errBackendCommitTimeout.Error(), false},
+		{&fakeBackend{err: errors.New("disk failed")}, "?exclude=backend", http.StatusOK, "", false},
+	}
+
+	for i, tt := range tests {
+		srv := &fakeHealthServer{be: tt.be, drainc: make(chan struct{})}
+		h, code := getHealth(t, srv, PathHealth+tt.query)
+		if tt.be.unblockc != nil {
+			close(tt.be.unblockc)
+		}
+		if code != tt.wcode {
+			t.Errorf("#%d: status code = %d, want %d", i, code, tt.wcode)
+		}
+		if tt.werr != "" && (len(h.Errors) != 1 || h.Errors[0] != tt.werr) {
+			t.Errorf("#%d: errors = %v, want [%s]", i, h.Errors, tt.werr)
+		}
+		if committed := tt.be.unblockc == nil && tt.be.committed > 0; committed != tt.wcommit {
+			t.Errorf("#%d: committed = %v, want %v", i, committed, tt.wcommit)
+		}
+	}
+}
+
+func TestReadyHandlerDraining(t *testing.T) {
+	srv := &fakeHealthServer{be: &fakeBackend{}, drainc: make(chan struct{})}
+	if _, code := getHealth(t, srv, PathReady); code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", code, http.StatusOK)
+	}
+
+	close(srv.drainc)
+	h, code := getHealth(t, srv, PathReady)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("status code = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if len(h.Errors) != 1 || h.Errors[0] != errDraining.Error() {
+		t.Fatalf("errors = %v, want [%v]", h.Errors, errDraining)
+	}
+	// a draining member is still alive
+	if _, code = getHealth(t, srv, PathHealth); code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func getHealth(t *testing.T, srv *fakeHealthServer, path string) (Health, int) {
+	mux := http.NewServeMux()
+	HandleHealth(mux, srv)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var h Health
+	if err = json.NewDecoder(resp.Body).Decode(&h); err != nil {
+		t.Fatal(err)
+	}
+	return h, resp.StatusCode
+}
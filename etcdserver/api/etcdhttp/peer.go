@@ -46,6 +46,7 @@ func newPeerHandler(cluster api.Cluster, raftHandler http.Handler, leaseHandler
 	if leaseHandler != nil {
 		mux.Handle(leasehttp.LeasePrefix, leaseHandler)
 		mux.Handle(leasehttp.LeaseInternalPrefix, leaseHandler)
+		mux.Handle(leasehttp.LeaseBatchPrefix, leaseHandler)
 	}
 	mux.HandleFunc(versionPath, versionHandler(cluster, serveVersion))
 	return mux
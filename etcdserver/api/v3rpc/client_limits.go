@@ -0,0 +1,43 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/coreos/etcd/etcdserver"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// clientIdentity returns the identity the limits of the client issuing a
+// request with ctx apply to: its auth user, or else the identity of its
+// connection.
+func clientIdentity(ag AuthGetter, ctx context.Context) string {
+	if ai, err := ag.AuthInfoFromCtx(ctx); err == nil && ai != nil && ai.Username != "" {
+		return ai.Username
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	var cs *tls.ConnectionState
+	if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		cs = &ti.State
+	}
+	return etcdserver.ClientConnIdentity(cs, p.Addr)
+}
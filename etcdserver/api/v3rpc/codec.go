@@ -14,19 +14,40 @@
 
 package v3rpc
 
-import "github.com/gogo/protobuf/proto"
+import (
+	"github.com/gogo/protobuf/proto"
+	golangproto "github.com/golang/protobuf/proto"
+)
 
+// codec encodes the etcd messages, generated by gogoproto with their own
+// marshalers, with gogoproto. Messages without them, such as those of the
+// gRPC reflection service, are generated by golang/protobuf, whose oneof
+// fields gogoproto cannot encode, so they are encoded with golang/protobuf.
+// Messages marshaled ahead of SendMsg are sent as is.
 type codec struct{}
 
 func (c *codec) Marshal(v interface{}) ([]byte, error) {
-	b, err := proto.Marshal(v.(proto.Message))
+	if m, ok := v.(marshaledMessage); ok {
+		sentBytes.Add(float64(len(m)))
+		return m, nil
+	}
+	var b []byte
+	var err error
+	if _, ok := v.(proto.Marshaler); ok {
+		b, err = proto.Marshal(v.(proto.Message))
+	} else {
+		b, err = golangproto.Marshal(v.(golangproto.Message))
+	}
 	sentBytes.Add(float64(len(b)))
 	return b, err
 }
 
 func (c *codec) Unmarshal(data []byte, v interface{}) error {
 	receivedBytes.Add(float64(len(data)))
-	return proto.Unmarshal(data, v.(proto.Message))
+	if _, ok := v.(proto.Unmarshaler); ok {
+		return proto.Unmarshal(data, v.(proto.Message))
+	}
+	return golangproto.Unmarshal(data, v.(golangproto.Message))
 }
 
 func (c *codec) String() string {
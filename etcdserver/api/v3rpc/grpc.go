@@ -18,6 +18,7 @@ import (
 	"crypto/tls"
 	"math"
 	"os"
+	"runtime"
 
 	"github.com/coreos/etcd/etcdserver"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
@@ -27,11 +28,11 @@ import (
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 const (
 	grpcOverheadBytes = 512 * 1024
-	maxStreams        = math.MaxUint32
 	maxSendBytes      = math.MaxInt32
 )
 
@@ -47,13 +48,26 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config, gopts ...grpc.ServerOptio
 	}
 	opts = append(opts, grpc.UnaryInterceptor(newUnaryInterceptor(s)))
 	opts = append(opts, grpc.StreamInterceptor(newStreamInterceptor(s)))
-	opts = append(opts, grpc.MaxRecvMsgSize(int(s.Cfg.MaxRequestBytes+grpcOverheadBytes)))
-	opts = append(opts, grpc.MaxSendMsgSize(maxSendBytes))
-	opts = append(opts, grpc.MaxConcurrentStreams(maxStreams))
+	recvBytes := int(s.Cfg.MaxRequestBytes + grpcOverheadBytes)
+	if s.Cfg.MaxRecvMsgSize != 0 {
+		recvBytes = int(s.Cfg.MaxRecvMsgSize)
+	}
+	sendBytes := maxSendBytes
+	if s.Cfg.MaxSendMsgSize != 0 {
+		sendBytes = int(s.Cfg.MaxSendMsgSize)
+	}
+	opts = append(opts, grpc.MaxRecvMsgSize(recvBytes))
+	opts = append(opts, grpc.MaxSendMsgSize(sendBytes))
+	opts = append(opts, grpc.MaxConcurrentStreams(s.Cfg.MaxConcurrentStreams))
 	grpcServer := grpc.NewServer(append(opts, gopts...)...)
 
 	pb.RegisterKVServer(grpcServer, NewQuotaKVServer(s))
-	pb.RegisterWatchServer(grpcServer, NewWatchServer(s))
+	var mp *marshalPool
+	if n := runtime.GOMAXPROCS(0); n > 1 {
+		// on one CPU, marshaling ahead of the sends only adds a hand-off
+		mp = newMarshalPool(n)
+	}
+	pb.RegisterWatchServer(grpcServer, newWatchServer(s, mp))
 	pb.RegisterLeaseServer(grpcServer, NewQuotaLeaseServer(s))
 	pb.RegisterClusterServer(grpcServer, NewClusterServer(s))
 	pb.RegisterAuthServer(grpcServer, NewAuthServer(s))
@@ -66,5 +80,8 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config, gopts ...grpc.ServerOptio
 	hsrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(grpcServer, hsrv)
 
+	// let generic gRPC tooling list and describe the services
+	reflection.Register(grpcServer)
+
 	return grpcServer
 }
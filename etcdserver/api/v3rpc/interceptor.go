@@ -34,12 +34,20 @@ const (
 	maxNoLeaderCnt = 3
 )
 
+// noLeaderMD flags responses of a member without a leader as possibly stale.
+var noLeaderMD = metadata.Pairs(rpctypes.MetadataNoLeaderKey, rpctypes.MetadataNoLeader)
+
 type streamsMap struct {
 	mu      sync.Mutex
 	streams map[grpc.ServerStream]struct{}
 }
 
 func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
+	var ol *opLogger
+	if s.Cfg.OpLog != nil {
+		ol = newOpLogger(s.Cfg.OpLog, s.ID().String())
+	}
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if !api.IsCapabilityEnabled(api.V3rpcCapability) {
 			return nil, rpctypes.ErrGRPCNotCapable
@@ -53,7 +61,16 @@ func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 				}
 			}
 		}
+		if s.Cfg.FeatureGate.Enabled(etcdserver.NoLeaderHeader) && s.Leader() == types.ID(raft.None) {
+			grpc.SetHeader(ctx, noLeaderMD)
+		}
 
+		if ol != nil {
+			next := handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return ol.logUnary(ctx, req, info, next)
+			}
+		}
 		return prometheus.UnaryServerInterceptor(ctx, req, info, handler)
 	}
 }
@@ -89,6 +106,17 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 
 			}
 		}
+		if s.Cfg.FeatureGate.Enabled(etcdserver.NoLeaderHeader) && s.Leader() == types.ID(raft.None) {
+			ss.SetHeader(noLeaderMD)
+		}
+
+		if cl := s.ClientLimiter(); cl.Limited(etcdserver.ClientStream) {
+			id := clientIdentity(s, ss.Context())
+			if err := cl.Acquire(etcdserver.ClientStream, id); err != nil {
+				return togRPCError(err)
+			}
+			defer cl.Release(etcdserver.ClientStream, id)
+		}
 
 		return prometheus.StreamServerInterceptor(srv, ss, info, handler)
 	}
@@ -24,13 +24,19 @@ import (
 	"github.com/coreos/etcd/lease"
 )
 
+// keepAliveBatchLimit is the maximum number of keepalive requests of a
+// stream renewed together.
+const keepAliveBatchLimit = 1000
+
 type LeaseServer struct {
 	hdr header
 	le  etcdserver.Lessor
+	// drainc is closed when the server drains streams before stopping.
+	drainc <-chan struct{}
 }
 
 func NewLeaseServer(s *etcdserver.EtcdServer) pb.LeaseServer {
-	return &LeaseServer{le: s, hdr: newHeader(s)}
+	return &LeaseServer{le: s, hdr: newHeader(s), drainc: s.DrainNotify()}
 }
 
 func (ls *LeaseServer) LeaseGrant(ctx context.Context, cr *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
@@ -96,18 +102,54 @@ func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err
 		if err == context.Canceled {
 			err = rpctypes.ErrGRPCNoLeader
 		}
+	case <-ls.drainc:
+		err = rpctypes.ErrGRPCStopped
 	}
 	return err
 }
 
 func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	// receive requests while renewing, so the requests that queue up
+	// during a renewal are renewed together by the next one
+	reqc := make(chan *pb.LeaseKeepAliveRequest, keepAliveBatchLimit)
+	donec := make(chan struct{})
+	defer close(donec)
+	var rerr error
+	go func() {
+		defer close(reqc)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					rerr = err
+				}
+				return
+			}
+			select {
+			case reqc <- req:
+			case <-donec:
+				return
+			}
+		}
+	}()
+
 	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
+		req, ok := <-reqc
+		if !ok {
+			return rerr
 		}
-		if err != nil {
-			return err
+		reqs := []*pb.LeaseKeepAliveRequest{req}
+	batch:
+		for len(reqs) < keepAliveBatchLimit {
+			select {
+			case req, ok := <-reqc:
+				if !ok {
+					break batch
+				}
+				reqs = append(reqs, req)
+			default:
+				break batch
+			}
 		}
 
 		// Create header before we sent out the renew request.
@@ -116,23 +158,29 @@ func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) erro
 		// or remote leader.
 		// Without this, a lease might be revoked at rev 3 but client can see the keepalive succeeded
 		// at rev 4.
-		resp := &pb.LeaseKeepAliveResponse{ID: req.ID, Header: &pb.ResponseHeader{}}
-		ls.hdr.fill(resp.Header)
-
-		ttl, err := ls.le.LeaseRenew(stream.Context(), lease.LeaseID(req.ID))
-		if err == lease.ErrLeaseNotFound {
-			err = nil
-			ttl = 0
+		hdr := pb.ResponseHeader{}
+		ls.hdr.fill(&hdr)
+
+		// a client may renew a lease more than once per batch
+		var ids []lease.LeaseID
+		idx := make(map[int64]int, len(reqs))
+		for _, r := range reqs {
+			if _, ok := idx[r.ID]; !ok {
+				idx[r.ID] = len(ids)
+				ids = append(ids, lease.LeaseID(r.ID))
+			}
 		}
-
+		ttls, err := ls.le.LeaseRenewBatch(stream.Context(), ids)
 		if err != nil {
 			return togRPCError(err)
 		}
 
-		resp.TTL = ttl
-		err = stream.Send(resp)
-		if err != nil {
-			return err
+		for _, r := range reqs {
+			h := hdr
+			resp := &pb.LeaseKeepAliveResponse{ID: r.ID, TTL: ttls[idx[r.ID]], Header: &h}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -18,15 +18,19 @@ import (
 	"context"
 	"crypto/sha256"
 	"io"
+	"time"
 
 	"github.com/coreos/etcd/auth"
 	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/version"
+
+	"github.com/coreos/go-semver/semver"
 )
 
 type KVGetter interface {
@@ -45,12 +49,30 @@ type LeaderTransferrer interface {
 	MoveLeader(ctx context.Context, lead, target uint64) error
 }
 
+type Downgrader interface {
+	Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error)
+}
+
 type RaftStatusGetter interface {
 	etcdserver.RaftTimer
 	ID() types.ID
 	Leader() types.ID
 }
 
+type MemoryGetter interface {
+	// MemoryBytes returns the approximate memory held by the key index,
+	// watch buffers and raft log.
+	MemoryBytes() int64
+	// OverMemoryBudget returns true if MemoryBytes exceeds the budget.
+	OverMemoryBudget() bool
+}
+
+type StatusGetter interface {
+	ClusterVersion() *semver.Version
+	Lessor() lease.Lessor
+	StartTime() time.Time
+}
+
 type AuthGetter interface {
 	AuthInfoFromCtx(ctx context.Context) (*auth.AuthInfo, error)
 	AuthStore() auth.AuthStore
@@ -62,11 +84,14 @@ type maintenanceServer struct {
 	bg  BackendGetter
 	a   Alarmer
 	lt  LeaderTransferrer
+	d   Downgrader
+	mg  MemoryGetter
+	sg  StatusGetter
 	hdr header
 }
 
 func NewMaintenanceServer(s *etcdserver.EtcdServer) pb.MaintenanceServer {
-	srv := &maintenanceServer{rg: s, kg: s, bg: s, a: s, lt: s, hdr: newHeader(s)}
+	srv := &maintenanceServer{rg: s, kg: s, bg: s, a: s, lt: s, d: s, mg: s, sg: s, hdr: newHeader(s)}
 	return &authMaintenanceServer{srv, s}
 }
 
@@ -88,11 +113,11 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 	defer pr.Close()
 
 	go func() {
-		snap.WriteTo(pw)
-		if err := snap.Close(); err != nil {
-			plog.Errorf("error closing snapshot (%v)", err)
+		_, err := snap.WriteTo(pw)
+		if cerr := snap.Close(); cerr != nil {
+			plog.Errorf("error closing snapshot (%v)", cerr)
 		}
-		pw.Close()
+		pw.CloseWithError(err)
 	}()
 
 	// send file data
@@ -105,6 +130,10 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return togRPCError(err)
 		}
+		if n == 0 {
+			// never hash a payload shorter than the snapshot
+			return togRPCError(io.ErrUnexpectedEOF)
+		}
 		br += int64(n)
 		resp := &pb.SnapshotResponse{
 			RemainingBytes: uint64(sz - br),
@@ -116,7 +145,7 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 		h.Write(buf[:n])
 	}
 
-	// send sha
+	// send sha, letting the receiver verify the payload with snap.VerifyDBHash
 	sha := h.Sum(nil)
 	hresp := &pb.SnapshotResponse{RemainingBytes: 0, Blob: sha}
 	if err := srv.Send(hresp); err != nil {
@@ -159,6 +188,16 @@ func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (
 		Leader:    uint64(ms.rg.Leader()),
 		RaftIndex: ms.rg.Index(),
 		RaftTerm:  ms.rg.Term(),
+
+		MemoryBytes: ms.mg.MemoryBytes(),
+
+		DbSizeInUse:    ms.bg.Backend().SizeInUse(),
+		ActiveWatchers: int64(ms.kg.KV().Watchers()),
+		ActiveLeases:   int64(len(ms.sg.Lessor().Leases())),
+		UptimeSeconds:  int64(time.Since(ms.sg.StartTime()).Seconds()),
+	}
+	if cv := ms.sg.ClusterVersion(); cv != nil {
+		resp.StorageVersion = version.Cluster(cv.String())
 	}
 	ms.hdr.fill(resp.Header)
 	return resp, nil
@@ -175,6 +214,15 @@ func (ms *maintenanceServer) MoveLeader(ctx context.Context, tr *pb.MoveLeaderRe
 	return &pb.MoveLeaderResponse{}, nil
 }
 
+func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	resp, err := ms.d.Downgrade(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 type authMaintenanceServer struct {
 	*maintenanceServer
 	ag AuthGetter
@@ -227,3 +275,10 @@ func (ams *authMaintenanceServer) Status(ctx context.Context, ar *pb.StatusReque
 func (ams *authMaintenanceServer) MoveLeader(ctx context.Context, tr *pb.MoveLeaderRequest) (*pb.MoveLeaderResponse, error) {
 	return ams.maintenanceServer.MoveLeader(ctx, tr)
 }
+
+func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+	return ams.maintenanceServer.Downgrade(ctx, r)
+}
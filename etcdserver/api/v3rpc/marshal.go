@@ -0,0 +1,128 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// marshalQueueLen is the number of responses of a stream that may be
+// marshaled ahead of its sends.
+const marshalQueueLen = 16
+
+// marshaledMessage is a message marshaled ahead of SendMsg; the codec sends
+// it as is.
+type marshaledMessage []byte
+
+// marshalPool marshals messages concurrently on at most a fixed number of
+// goroutines, so the streams of a server share the CPUs for marshaling
+// instead of each marshaling on its own sending goroutine.
+type marshalPool struct {
+	sem chan struct{}
+}
+
+func newMarshalPool(workers int) *marshalPool {
+	return &marshalPool{sem: make(chan struct{}, workers)}
+}
+
+// marshalReq is a message being marshaled by the pool; done is closed once
+// b and err are set.
+type marshalReq struct {
+	b    []byte
+	err  error
+	done chan struct{}
+}
+
+// marshal starts marshaling m once a worker is free.
+func (p *marshalPool) marshal(m proto.Marshaler) *marshalReq {
+	req := &marshalReq{done: make(chan struct{})}
+	p.sem <- struct{}{}
+	go func() {
+		req.b, req.err = m.Marshal()
+		<-p.sem
+		close(req.done)
+	}()
+	return req
+}
+
+// orderedSender sends messages on a stream in the order they are queued,
+// while the pool marshals the messages queued behind the one being sent.
+type orderedSender struct {
+	stream grpc.ServerStream
+	pool   *marshalPool
+	reqc   chan *marshalReq
+
+	stopc chan struct{}
+	donec chan struct{}
+	// err is the error the stream failed with; set before donec is closed.
+	err error
+}
+
+func newOrderedSender(stream grpc.ServerStream, pool *marshalPool) *orderedSender {
+	s := &orderedSender{
+		stream: stream,
+		pool:   pool,
+		reqc:   make(chan *marshalReq, marshalQueueLen),
+		stopc:  make(chan struct{}),
+		donec:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// send queues m to be sent. It returns the error of an earlier send if the
+// stream has failed.
+func (s *orderedSender) send(m proto.Marshaler) error {
+	select {
+	case <-s.donec:
+		return s.err
+	default:
+	}
+	req := s.pool.marshal(m)
+	select {
+	case s.reqc <- req:
+		return nil
+	case <-s.donec:
+		return s.err
+	}
+}
+
+func (s *orderedSender) run() {
+	defer close(s.donec)
+	for {
+		select {
+		case req := <-s.reqc:
+			<-req.done
+			err := req.err
+			if err == nil {
+				err = s.stream.SendMsg(marshaledMessage(req.b))
+			}
+			if err != nil {
+				s.err = err
+				return
+			}
+		case <-s.stopc:
+			return
+		}
+	}
+}
+
+// stop stops sending, dropping the queued messages, and waits until the
+// stream is no longer in use.
+func (s *orderedSender) stop() {
+	close(s.stopc)
+	<-s.donec
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"google.golang.org/grpc"
+)
+
+// codecStream encodes the messages sent on it with the codec, as a gRPC
+// stream does, and records the watch IDs of the responses.
+type codecStream struct {
+	grpc.ServerStream
+	c codec
+
+	mu  sync.Mutex
+	ids []int64
+	wg  sync.WaitGroup
+}
+
+func (s *codecStream) SendMsg(m interface{}) error {
+	defer s.wg.Done()
+	b, err := s.c.Marshal(m)
+	if err != nil {
+		return err
+	}
+	var wr pb.WatchResponse
+	if err = wr.Unmarshal(b); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.ids = append(s.ids, wr.WatchId)
+	s.mu.Unlock()
+	return nil
+}
+
+func TestOrderedSenderOrder(t *testing.T) {
+	n := 100
+	s := &codecStream{}
+	s.wg.Add(n)
+	os := newOrderedSender(s, newMarshalPool(4))
+	for i := 0; i < n; i++ {
+		// vary the size so marshaling finishes out of order
+		if err := os.send(newTestWatchResponse(int64(i), (n-i)%7)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.wg.Wait()
+	os.stop()
+	for i, id := range s.ids {
+		if id != int64(i) {
+			t.Fatalf("#%d: watch id = %d, want %d", i, id, i)
+		}
+	}
+}
+
+func newTestWatchResponse(id int64, nevs int) *pb.WatchResponse {
+	wr := &pb.WatchResponse{Header: &pb.ResponseHeader{Revision: 1}, WatchId: id}
+	for i := 0; i < nevs; i++ {
+		wr.Events = append(wr.Events, &mvccpb.Event{Kv: &mvccpb.KeyValue{
+			Key:         []byte(fmt.Sprintf("key-%d", i)),
+			Value:       make([]byte, 1024),
+			ModRevision: int64(i + 1),
+		}})
+	}
+	return wr
+}
+
+// discardStream encodes the messages sent on it with the codec and drops
+// them.
+type discardStream struct {
+	grpc.ServerStream
+	c  codec
+	wg sync.WaitGroup
+}
+
+func (s *discardStream) SendMsg(m interface{}) error {
+	defer s.wg.Done()
+	_, err := s.c.Marshal(m)
+	return err
+}
+
+// BenchmarkWatchSend sends watch responses of 100 events on streams that
+// marshal each response as it is sent.
+func BenchmarkWatchSend(b *testing.B) { benchmarkWatchSend(b, nil) }
+
+// BenchmarkWatchSendPooled sends the same responses, marshaled ahead of
+// their sends by a marshal pool with a worker per CPU.
+func BenchmarkWatchSendPooled(b *testing.B) {
+	benchmarkWatchSend(b, newMarshalPool(runtime.GOMAXPROCS(0)))
+}
+
+func benchmarkWatchSend(b *testing.B, mp *marshalPool) {
+	wr := newTestWatchResponse(1, 100)
+	for _, streams := range []int{1, 64} {
+		b.Run(fmt.Sprintf("streams=%d", streams), func(b *testing.B) {
+			b.SetBytes(int64(wr.Size()))
+			b.ReportAllocs()
+			var wg sync.WaitGroup
+			wg.Add(streams)
+			b.ResetTimer()
+			for i := 0; i < streams; i++ {
+				n := b.N / streams
+				if i < b.N%streams {
+					n++
+				}
+				go func(n int) {
+					defer wg.Done()
+					s := &discardStream{}
+					s.wg.Add(n)
+					if mp == nil {
+						for j := 0; j < n; j++ {
+							s.SendMsg(wr)
+						}
+						return
+					}
+					os := newOrderedSender(s, mp)
+					for j := 0; j < n; j++ {
+						os.send(wr)
+					}
+					s.wg.Wait()
+					os.stop()
+				}(n)
+			}
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// opRecord is a line of the operation log. Each request is recorded once
+// when the server receives it and once when the server replies.
+type opRecord struct {
+	// Type is "invoke" or "return".
+	Type   string `json:"type"`
+	OpID   string `json:"op-id,omitempty"`
+	Member string `json:"member"`
+	Method string `json:"method"`
+	// Time is the unix time in nanoseconds.
+	Time     int64       `json:"time"`
+	Request  interface{} `json:"request,omitempty"`
+	Response interface{} `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// opLogger writes the invocation and the return of unary requests as JSON
+// lines so external checkers (e.g. porcupine, Jepsen) can verify the
+// linearizability of a test run.
+type opLogger struct {
+	member string
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newOpLogger(w io.Writer, member string) *opLogger {
+	return &opLogger{member: member, enc: json.NewEncoder(w)}
+}
+
+func (l *opLogger) write(r *opRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(r); err != nil {
+		plog.Warningf("failed to write operation log (%v)", err)
+	}
+}
+
+func (l *opLogger) logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var opID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md[rpctypes.MetadataOpIDKey]; len(ids) > 0 {
+			opID = ids[0]
+		}
+	}
+	// auth requests and responses carry passwords and tokens
+	withBody := !strings.HasPrefix(info.FullMethod, "/etcdserverpb.Auth/")
+
+	r := &opRecord{
+		Type:   "invoke",
+		OpID:   opID,
+		Member: l.member,
+		Method: info.FullMethod,
+		Time:   time.Now().UnixNano(),
+	}
+	if withBody {
+		r.Request = req
+	}
+	l.write(r)
+
+	resp, err := handler(ctx, req)
+
+	r = &opRecord{
+		Type:   "return",
+		OpID:   opID,
+		Member: l.member,
+		Method: info.FullMethod,
+		Time:   time.Now().UnixNano(),
+	}
+	if err != nil {
+		r.Error = err.Error()
+	} else if withBody {
+		r.Response = resp
+	}
+	l.write(r)
+	return resp, err
+}
@@ -57,6 +57,10 @@ func NewQuotaKVServer(s *etcdserver.EtcdServer) pb.KVServer {
 }
 
 func (s *quotaKVServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error) {
+	// a dry run reports the lack of space without raising the alarm
+	if r.DryRun {
+		return s.KVServer.Put(ctx, r)
+	}
 	if err := s.qa.check(ctx, r); err != nil {
 		return nil, err
 	}
@@ -64,6 +68,10 @@ func (s *quotaKVServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutRespo
 }
 
 func (s *quotaKVServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error) {
+	// a dry run reports the lack of space without raising the alarm
+	if r.DryRun {
+		return s.KVServer.Txn(ctx, r)
+	}
 	if err := s.qa.check(ctx, r); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path"
+
+	"github.com/coreos/etcd/auth/authpb"
+	"github.com/coreos/etcd/etcdserver/api/v3election/v3electionpb"
+	"github.com/coreos/etcd/etcdserver/api/v3lock/v3lockpb"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// descriptorMessage is a generated message with its file descriptor.
+type descriptorMessage interface {
+	Descriptor() ([]byte, []int)
+}
+
+// reflectedProtos has a message of each proto file of the etcd services.
+var reflectedProtos = []descriptorMessage{
+	&pb.RangeRequest{},
+	&mvccpb.KeyValue{},
+	&authpb.User{},
+	&v3electionpb.CampaignRequest{},
+	&v3lockpb.LockRequest{},
+}
+
+// The gRPC reflection service looks up proto files in the golang/protobuf
+// registry, but the etcd protos are generated with gogoproto and only carry
+// their descriptors. Register them there under their own names, imported by
+// those names instead of their source paths, and without the imports of gogo
+// and http options that only matter to code generation.
+func init() {
+	for _, m := range reflectedProtos {
+		gz, _ := m.Descriptor()
+		fd, err := decodeFileDescriptor(gz)
+		if err != nil {
+			plog.Panicf("cannot decode descriptor of %T (%v)", m, err)
+		}
+		deps := fd.Dependency[:0]
+		for _, dep := range fd.Dependency {
+			if dep == "gogoproto/gogo.proto" || dep == "google/api/annotations.proto" {
+				continue
+			}
+			deps = append(deps, path.Base(dep))
+		}
+		fd.Dependency = deps
+		if gz, err = encodeFileDescriptor(fd); err != nil {
+			plog.Panicf("cannot encode descriptor of %s (%v)", fd.GetName(), err)
+		}
+		proto.RegisterFile(fd.GetName(), gz)
+	}
+}
+
+func decodeFileDescriptor(gz []byte) (*dpb.FileDescriptorProto, error) {
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &dpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+func encodeFileDescriptor(fd *dpb.FileDescriptorProto) ([]byte, error) {
+	b, err := proto.Marshal(fd)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(b); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -15,24 +15,32 @@
 package rpctypes
 
 import (
+	"strings"
+	"time"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // server-side error
 var (
-	ErrGRPCEmptyKey      = status.New(codes.InvalidArgument, "etcdserver: key is not provided").Err()
-	ErrGRPCKeyNotFound   = status.New(codes.InvalidArgument, "etcdserver: key not found").Err()
-	ErrGRPCValueProvided = status.New(codes.InvalidArgument, "etcdserver: value is provided").Err()
-	ErrGRPCLeaseProvided = status.New(codes.InvalidArgument, "etcdserver: lease is provided").Err()
-	ErrGRPCTooManyOps    = status.New(codes.InvalidArgument, "etcdserver: too many operations in txn request").Err()
-	ErrGRPCDuplicateKey  = status.New(codes.InvalidArgument, "etcdserver: duplicate key given in txn request").Err()
-	ErrGRPCCompacted     = status.New(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted").Err()
-	ErrGRPCFutureRev     = status.New(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision").Err()
-	ErrGRPCNoSpace       = status.New(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded").Err()
-
-	ErrGRPCLeaseNotFound = status.New(codes.NotFound, "etcdserver: requested lease not found").Err()
-	ErrGRPCLeaseExist    = status.New(codes.FailedPrecondition, "etcdserver: lease already exists").Err()
+	ErrGRPCEmptyKey           = status.New(codes.InvalidArgument, "etcdserver: key is not provided").Err()
+	ErrGRPCKeyNotFound        = status.New(codes.InvalidArgument, "etcdserver: key not found").Err()
+	ErrGRPCValueProvided      = status.New(codes.InvalidArgument, "etcdserver: value is provided").Err()
+	ErrGRPCLeaseProvided      = status.New(codes.InvalidArgument, "etcdserver: lease is provided").Err()
+	ErrGRPCTooManyOps         = status.New(codes.InvalidArgument, "etcdserver: too many operations in txn request").Err()
+	ErrGRPCDuplicateKey       = status.New(codes.InvalidArgument, "etcdserver: duplicate key given in txn request").Err()
+	ErrGRPCCompacted          = status.New(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted").Err()
+	ErrGRPCFutureRev          = status.New(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision").Err()
+	ErrGRPCNoSpace            = status.New(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded").Err()
+	ErrGRPCTooManyWatchers    = status.New(codes.ResourceExhausted, "etcdserver: mvcc: too many watchers").Err()
+	ErrGRPCWatchBacklog       = status.New(codes.Unavailable, "etcdserver: mvcc: too many unsynced watchers").Err()
+	ErrGRPCValueIndexNotFound = status.New(codes.InvalidArgument, "etcdserver: mvcc: value index not found").Err()
+	ErrGRPCValueIndexRev      = status.New(codes.InvalidArgument, "etcdserver: mvcc: value index only ranges the current revision").Err()
+
+	ErrGRPCLeaseNotFound         = status.New(codes.NotFound, "etcdserver: requested lease not found").Err()
+	ErrGRPCLeaseExist            = status.New(codes.FailedPrecondition, "etcdserver: lease already exists").Err()
+	ErrGRPCLeaseMetadataTooLarge = status.New(codes.InvalidArgument, "etcdserver: lease metadata is too large").Err()
 
 	ErrGRPCMemberExist            = status.New(codes.FailedPrecondition, "etcdserver: member ID already exist").Err()
 	ErrGRPCPeerURLExist           = status.New(codes.FailedPrecondition, "etcdserver: Peer URLs already exists").Err()
@@ -41,6 +49,7 @@ var (
 	ErrGRPCMemberNotFound         = status.New(codes.NotFound, "etcdserver: member not found").Err()
 
 	ErrGRPCRequestTooLarge        = status.New(codes.InvalidArgument, "etcdserver: request is too large").Err()
+	ErrGRPCValueTooLarge          = status.New(codes.InvalidArgument, "etcdserver: value is too large").Err()
 	ErrGRPCRequestTooManyRequests = status.New(codes.ResourceExhausted, "etcdserver: too many requests").Err()
 
 	ErrGRPCRootUserNotExist     = status.New(codes.FailedPrecondition, "etcdserver: root user does not exist").Err()
@@ -67,6 +76,18 @@ var (
 	ErrGRPCTimeoutDueToConnectionLost = status.New(codes.Unavailable, "etcdserver: request timed out, possibly due to connection lost").Err()
 	ErrGRPCUnhealthy                  = status.New(codes.Unavailable, "etcdserver: unhealthy cluster").Err()
 	ErrGRPCCorrupt                    = status.New(codes.DataLoss, "etcdserver: corrupt cluster").Err()
+	ErrGRPCReadOnly                   = status.New(codes.Unavailable, "etcdserver: member is read-only").Err()
+	ErrGRPCPrefixQuotaExceeded        = status.New(codes.ResourceExhausted, "etcdserver: prefix quota exceeded").Err()
+	ErrGRPCDegraded                   = status.New(codes.Unavailable, "etcdserver: member is degraded by a backend write failure").Err()
+	ErrGRPCMemoryBudgetExceeded       = status.New(codes.ResourceExhausted, "etcdserver: memory budget exceeded").Err()
+	ErrGRPCTooManyClientStreams       = status.New(codes.ResourceExhausted, "etcdserver: too many streams for client").Err()
+	ErrGRPCTooManyClientWatchers      = status.New(codes.ResourceExhausted, "etcdserver: too many watchers for client").Err()
+	ErrGRPCWitness                    = status.New(codes.Unavailable, "etcdserver: member is a witness").Err()
+	ErrGRPCClusterVersionTooLow       = status.New(codes.FailedPrecondition, "etcdserver: request is not supported by the cluster version").Err()
+
+	ErrGRPCInvalidDowngradeTargetVersion = status.New(codes.InvalidArgument, "etcdserver: invalid downgrade target version").Err()
+	ErrGRPCDowngradeInProcess            = status.New(codes.FailedPrecondition, "etcdserver: cluster has a downgrade job in progress").Err()
+	ErrGRPCNoInflightDowngrade           = status.New(codes.FailedPrecondition, "etcdserver: no inflight downgrade job").Err()
 
 	errStringToError = map[string]error{
 		ErrorDesc(ErrGRPCEmptyKey):      ErrGRPCEmptyKey,
@@ -74,14 +95,18 @@ var (
 		ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
 		ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
 
-		ErrorDesc(ErrGRPCTooManyOps):   ErrGRPCTooManyOps,
-		ErrorDesc(ErrGRPCDuplicateKey): ErrGRPCDuplicateKey,
-		ErrorDesc(ErrGRPCCompacted):    ErrGRPCCompacted,
-		ErrorDesc(ErrGRPCFutureRev):    ErrGRPCFutureRev,
-		ErrorDesc(ErrGRPCNoSpace):      ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCTooManyOps):         ErrGRPCTooManyOps,
+		ErrorDesc(ErrGRPCDuplicateKey):       ErrGRPCDuplicateKey,
+		ErrorDesc(ErrGRPCCompacted):          ErrGRPCCompacted,
+		ErrorDesc(ErrGRPCFutureRev):          ErrGRPCFutureRev,
+		ErrorDesc(ErrGRPCNoSpace):            ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCTooManyWatchers):    ErrGRPCTooManyWatchers,
+		ErrorDesc(ErrGRPCValueIndexNotFound): ErrGRPCValueIndexNotFound,
+		ErrorDesc(ErrGRPCValueIndexRev):      ErrGRPCValueIndexRev,
 
-		ErrorDesc(ErrGRPCLeaseNotFound): ErrGRPCLeaseNotFound,
-		ErrorDesc(ErrGRPCLeaseExist):    ErrGRPCLeaseExist,
+		ErrorDesc(ErrGRPCLeaseNotFound):         ErrGRPCLeaseNotFound,
+		ErrorDesc(ErrGRPCLeaseExist):            ErrGRPCLeaseExist,
+		ErrorDesc(ErrGRPCLeaseMetadataTooLarge): ErrGRPCLeaseMetadataTooLarge,
 
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
@@ -90,6 +115,7 @@ var (
 		ErrorDesc(ErrGRPCMemberNotFound):         ErrGRPCMemberNotFound,
 
 		ErrorDesc(ErrGRPCRequestTooLarge):        ErrGRPCRequestTooLarge,
+		ErrorDesc(ErrGRPCValueTooLarge):          ErrGRPCValueTooLarge,
 		ErrorDesc(ErrGRPCRequestTooManyRequests): ErrGRPCRequestTooManyRequests,
 
 		ErrorDesc(ErrGRPCRootUserNotExist):     ErrGRPCRootUserNotExist,
@@ -116,23 +142,39 @@ var (
 		ErrorDesc(ErrGRPCTimeoutDueToConnectionLost): ErrGRPCTimeoutDueToConnectionLost,
 		ErrorDesc(ErrGRPCUnhealthy):                  ErrGRPCUnhealthy,
 		ErrorDesc(ErrGRPCCorrupt):                    ErrGRPCCorrupt,
+		ErrorDesc(ErrGRPCReadOnly):                   ErrGRPCReadOnly,
+		ErrorDesc(ErrGRPCPrefixQuotaExceeded):        ErrGRPCPrefixQuotaExceeded,
+		ErrorDesc(ErrGRPCDegraded):                   ErrGRPCDegraded,
+		ErrorDesc(ErrGRPCMemoryBudgetExceeded):       ErrGRPCMemoryBudgetExceeded,
+		ErrorDesc(ErrGRPCTooManyClientStreams):       ErrGRPCTooManyClientStreams,
+		ErrorDesc(ErrGRPCTooManyClientWatchers):      ErrGRPCTooManyClientWatchers,
+		ErrorDesc(ErrGRPCWitness):                    ErrGRPCWitness,
+		ErrorDesc(ErrGRPCClusterVersionTooLow):       ErrGRPCClusterVersionTooLow,
+
+		ErrorDesc(ErrGRPCInvalidDowngradeTargetVersion): ErrGRPCInvalidDowngradeTargetVersion,
+		ErrorDesc(ErrGRPCDowngradeInProcess):            ErrGRPCDowngradeInProcess,
+		ErrorDesc(ErrGRPCNoInflightDowngrade):           ErrGRPCNoInflightDowngrade,
 	}
 )
 
 // client-side error
 var (
-	ErrEmptyKey      = Error(ErrGRPCEmptyKey)
-	ErrKeyNotFound   = Error(ErrGRPCKeyNotFound)
-	ErrValueProvided = Error(ErrGRPCValueProvided)
-	ErrLeaseProvided = Error(ErrGRPCLeaseProvided)
-	ErrTooManyOps    = Error(ErrGRPCTooManyOps)
-	ErrDuplicateKey  = Error(ErrGRPCDuplicateKey)
-	ErrCompacted     = Error(ErrGRPCCompacted)
-	ErrFutureRev     = Error(ErrGRPCFutureRev)
-	ErrNoSpace       = Error(ErrGRPCNoSpace)
-
-	ErrLeaseNotFound = Error(ErrGRPCLeaseNotFound)
-	ErrLeaseExist    = Error(ErrGRPCLeaseExist)
+	ErrEmptyKey           = Error(ErrGRPCEmptyKey)
+	ErrKeyNotFound        = Error(ErrGRPCKeyNotFound)
+	ErrValueProvided      = Error(ErrGRPCValueProvided)
+	ErrLeaseProvided      = Error(ErrGRPCLeaseProvided)
+	ErrTooManyOps         = Error(ErrGRPCTooManyOps)
+	ErrDuplicateKey       = Error(ErrGRPCDuplicateKey)
+	ErrCompacted          = Error(ErrGRPCCompacted)
+	ErrFutureRev          = Error(ErrGRPCFutureRev)
+	ErrNoSpace            = Error(ErrGRPCNoSpace)
+	ErrTooManyWatchers    = Error(ErrGRPCTooManyWatchers)
+	ErrValueIndexNotFound = Error(ErrGRPCValueIndexNotFound)
+	ErrValueIndexRev      = Error(ErrGRPCValueIndexRev)
+
+	ErrLeaseNotFound         = Error(ErrGRPCLeaseNotFound)
+	ErrLeaseExist            = Error(ErrGRPCLeaseExist)
+	ErrLeaseMetadataTooLarge = Error(ErrGRPCLeaseMetadataTooLarge)
 
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
@@ -141,6 +183,7 @@ var (
 	ErrMemberNotFound         = Error(ErrGRPCMemberNotFound)
 
 	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
+	ErrValueTooLarge   = Error(ErrGRPCValueTooLarge)
 	ErrTooManyRequests = Error(ErrGRPCRequestTooManyRequests)
 
 	ErrRootUserNotExist     = Error(ErrGRPCRootUserNotExist)
@@ -167,6 +210,18 @@ var (
 	ErrTimeoutDueToConnectionLost = Error(ErrGRPCTimeoutDueToConnectionLost)
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
 	ErrCorrupt                    = Error(ErrGRPCCorrupt)
+	ErrReadOnly                   = Error(ErrGRPCReadOnly)
+	ErrPrefixQuotaExceeded        = Error(ErrGRPCPrefixQuotaExceeded)
+	ErrDegraded                   = Error(ErrGRPCDegraded)
+	ErrMemoryBudgetExceeded       = Error(ErrGRPCMemoryBudgetExceeded)
+	ErrTooManyClientStreams       = Error(ErrGRPCTooManyClientStreams)
+	ErrTooManyClientWatchers      = Error(ErrGRPCTooManyClientWatchers)
+	ErrWitness                    = Error(ErrGRPCWitness)
+	ErrClusterVersionTooLow       = Error(ErrGRPCClusterVersionTooLow)
+
+	ErrInvalidDowngradeTargetVersion = Error(ErrGRPCInvalidDowngradeTargetVersion)
+	ErrDowngradeInProcess            = Error(ErrGRPCDowngradeInProcess)
+	ErrNoInflightDowngrade           = Error(ErrGRPCNoInflightDowngrade)
 )
 
 // EtcdError defines gRPC server errors.
@@ -210,3 +265,27 @@ func ErrorDesc(err error) string {
 	}
 	return err.Error()
 }
+
+// WatchBacklogReason is the cancel reason of a watch refused with
+// ErrGRPCWatchBacklog, hinting the client to retry after d.
+func WatchBacklogReason(d time.Duration) string {
+	return ErrorDesc(ErrGRPCWatchBacklog) + ", retry after " + d.String()
+}
+
+// WatchRetryAfter returns the retry hint of a watch error caused by
+// ErrGRPCWatchBacklog. It returns false for any other error.
+func WatchRetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	prefix := ErrorDesc(ErrGRPCWatchBacklog) + ", retry after "
+	desc := ErrorDesc(err)
+	if !strings.HasPrefix(desc, prefix) {
+		return 0, false
+	}
+	d, perr := time.ParseDuration(desc[len(prefix):])
+	if perr != nil {
+		return 0, false
+	}
+	return d, true
+}
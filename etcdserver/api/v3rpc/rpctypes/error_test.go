@@ -15,7 +15,9 @@
 package rpctypes
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -42,3 +44,16 @@ func TestConvert(t *testing.T) {
 		t.Fatalf("expected them to be equal, got %v / %v", ev2.Code(), e3.(EtcdError).Code())
 	}
 }
+
+func TestWatchRetryAfter(t *testing.T) {
+	reason := WatchBacklogReason(1500 * time.Millisecond)
+	d, ok := WatchRetryAfter(status.New(codes.FailedPrecondition, reason).Err())
+	if !ok || d != 1500*time.Millisecond {
+		t.Fatalf("got %v, %v, want 1.5s, true", d, ok)
+	}
+	for _, err := range []error{nil, ErrTooManyWatchers, errors.New(ErrorDesc(ErrGRPCWatchBacklog))} {
+		if _, ok = WatchRetryAfter(err); ok {
+			t.Fatalf("WatchRetryAfter(%v) = true, want false", err)
+		}
+	}
+}
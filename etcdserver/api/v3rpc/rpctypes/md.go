@@ -17,4 +17,20 @@ package rpctypes
 var (
 	MetadataRequireLeaderKey = "hasleader"
 	MetadataHasLeader        = "true"
+
+	// MetadataNoLeaderKey is set in the response header of requests served
+	// while the member has no leader. Such responses, like serializable
+	// reads and watch events, may be stale.
+	MetadataNoLeaderKey = "noleader"
+	MetadataNoLeader    = "true"
+
+	// MetadataOpIDKey carries a client supplied operation ID, which is
+	// recorded with the request in the server's operation log.
+	MetadataOpIDKey = "op-id"
+
+	// MetadataWatchAckKey marks a watch stream whose client acknowledges
+	// the responses it has processed, letting the server bound how many
+	// responses it queues ahead of the client.
+	MetadataWatchAckKey = "watchack"
+	MetadataWatchAck    = "true"
 )
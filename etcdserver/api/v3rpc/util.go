@@ -15,14 +15,17 @@
 package v3rpc
 
 import (
+	"context"
+
 	"github.com/coreos/etcd/auth"
 	"github.com/coreos/etcd/etcdserver"
+	"github.com/coreos/etcd/etcdserver/admission"
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var toGRPCErrorMap = map[error]error{
@@ -34,7 +37,11 @@ var toGRPCErrorMap = map[error]error{
 
 	mvcc.ErrCompacted:             rpctypes.ErrGRPCCompacted,
 	mvcc.ErrFutureRev:             rpctypes.ErrGRPCFutureRev,
+	mvcc.ErrTooManyWatchers:       rpctypes.ErrGRPCTooManyWatchers,
+	mvcc.ErrValueIndexNotFound:    rpctypes.ErrGRPCValueIndexNotFound,
+	mvcc.ErrValueIndexRev:         rpctypes.ErrGRPCValueIndexRev,
 	etcdserver.ErrRequestTooLarge: rpctypes.ErrGRPCRequestTooLarge,
+	etcdserver.ErrValueTooLarge:   rpctypes.ErrGRPCValueTooLarge,
 	etcdserver.ErrNoSpace:         rpctypes.ErrGRPCNoSpace,
 	etcdserver.ErrTooManyRequests: rpctypes.ErrTooManyRequests,
 
@@ -47,9 +54,25 @@ var toGRPCErrorMap = map[error]error{
 	etcdserver.ErrUnhealthy:                  rpctypes.ErrGRPCUnhealthy,
 	etcdserver.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 	etcdserver.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
+	etcdserver.ErrReadOnly:                   rpctypes.ErrGRPCReadOnly,
+	etcdserver.ErrPrefixQuotaExceeded:        rpctypes.ErrGRPCPrefixQuotaExceeded,
+	etcdserver.ErrDegraded:                   rpctypes.ErrGRPCDegraded,
+	etcdserver.ErrMemoryBudgetExceeded:       rpctypes.ErrGRPCMemoryBudgetExceeded,
+	etcdserver.ErrTooManyClientStreams:       rpctypes.ErrGRPCTooManyClientStreams,
+	etcdserver.ErrTooManyClientWatchers:      rpctypes.ErrGRPCTooManyClientWatchers,
+	etcdserver.ErrWitness:                    rpctypes.ErrGRPCWitness,
+	etcdserver.ErrClusterVersionTooLow:       rpctypes.ErrGRPCClusterVersionTooLow,
+
+	etcdserver.ErrInvalidDowngradeTargetVersion: rpctypes.ErrGRPCInvalidDowngradeTargetVersion,
+	etcdserver.ErrDowngradeInProcess:            rpctypes.ErrGRPCDowngradeInProcess,
+	etcdserver.ErrNoInflightDowngrade:           rpctypes.ErrGRPCNoInflightDowngrade,
 
-	lease.ErrLeaseNotFound: rpctypes.ErrGRPCLeaseNotFound,
-	lease.ErrLeaseExists:   rpctypes.ErrGRPCLeaseExist,
+	context.Canceled:         status.New(codes.Canceled, context.Canceled.Error()).Err(),
+	context.DeadlineExceeded: status.New(codes.DeadlineExceeded, context.DeadlineExceeded.Error()).Err(),
+
+	lease.ErrLeaseNotFound:         rpctypes.ErrGRPCLeaseNotFound,
+	lease.ErrLeaseExists:           rpctypes.ErrGRPCLeaseExist,
+	lease.ErrLeaseMetadataTooLarge: rpctypes.ErrGRPCLeaseMetadataTooLarge,
 
 	auth.ErrRootUserNotExist:     rpctypes.ErrGRPCRootUserNotExist,
 	auth.ErrRootRoleNotExist:     rpctypes.ErrGRPCRootRoleNotExist,
@@ -68,9 +91,12 @@ var toGRPCErrorMap = map[error]error{
 }
 
 func togRPCError(err error) error {
+	if rerr, ok := err.(*admission.RejectError); ok {
+		return status.Errorf(codes.FailedPrecondition, "etcdserver: %s", rerr.Error())
+	}
 	grpcErr, ok := toGRPCErrorMap[err]
 	if !ok {
-		return grpc.Errorf(codes.Unknown, err.Error())
+		return status.New(codes.Unknown, err.Error()).Err()
 	}
 	return grpcErr
 }
@@ -26,6 +26,8 @@ import (
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/mvcc"
 	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"google.golang.org/grpc/metadata"
 )
 
 type watchServer struct {
@@ -33,17 +35,40 @@ type watchServer struct {
 	memberID  int64
 	raftTimer etcdserver.RaftTimer
 	watchable mvcc.WatchableKV
+	// drainc is closed when the server drains streams before stopping.
+	drainc <-chan struct{}
+	// ackWindow is the number of unacknowledged responses sent to each
+	// watcher of streams whose client acknowledges responses; 0 disables
+	// flow control.
+	ackWindow int
+	// witness is set if the member is a witness, which has no keys to watch.
+	witness bool
+	// mp marshals the responses of streams ahead of their sends; nil if the
+	// streams do not encode with the codec, as for the in-process client.
+	mp *marshalPool
 
 	ag AuthGetter
+	mg MemoryGetter
+	cl *etcdserver.ClientLimiter
 }
 
 func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
+	return newWatchServer(s, nil)
+}
+
+func newWatchServer(s *etcdserver.EtcdServer, mp *marshalPool) pb.WatchServer {
 	return &watchServer{
 		clusterID: int64(s.Cluster().ID()),
 		memberID:  int64(s.ID()),
 		raftTimer: s,
 		watchable: s.Watchable(),
+		drainc:    s.DrainNotify(),
+		ackWindow: int(s.Cfg.WatchAckWindow),
+		witness:   s.IsWitness(),
+		mp:        mp,
 		ag:        s,
+		mg:        s,
+		cl:        s.ClientLimiter(),
 	}
 }
 
@@ -90,14 +115,25 @@ type serverWatchStream struct {
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
-
-	// mu protects progress, prevKV
+	// ackc forwards the client's acknowledgements to the send loop.
+	ackc chan *pb.WatchAckRequest
+	// ackWindow is the number of responses the send loop sends to a watcher
+	// ahead of the client's acknowledgements; 0 if the stream is not flow
+	// controlled.
+	ackWindow int
+	// sender sends the responses marshaled ahead by the marshal pool, if
+	// any.
+	sender *orderedSender
+
+	// mu protects progress, prevKV, limited
 	mu sync.Mutex
 	// progress tracks the watchID that stream might need to send
 	// progress to.
 	// TODO: combine progress and prevKV into a single struct?
 	progress map[mvcc.WatchID]bool
 	prevKV   map[mvcc.WatchID]bool
+	// limited holds the watchers counted against the client's limit.
+	limited map[mvcc.WatchID]struct{}
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -106,9 +142,16 @@ type serverWatchStream struct {
 	wg sync.WaitGroup
 
 	ag AuthGetter
+	mg MemoryGetter
+	cl *etcdserver.ClientLimiter
+	// clientID is the identity of the client while its watchers are limited.
+	clientID string
 }
 
 func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
+	if ws.witness {
+		return rpctypes.ErrGRPCWitness
+	}
 	sws := serverWatchStream{
 		clusterID: ws.clusterID,
 		memberID:  ws.memberID,
@@ -120,11 +163,24 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		watchStream: ws.watchable.NewWatchStream(),
 		// chan for sending control response like watcher created and canceled.
 		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
+		ackc:       make(chan *pb.WatchAckRequest, ctrlStreamBufLen),
 		progress:   make(map[mvcc.WatchID]bool),
 		prevKV:     make(map[mvcc.WatchID]bool),
+		limited:    make(map[mvcc.WatchID]struct{}),
 		closec:     make(chan struct{}),
 
 		ag: ws.ag,
+		mg: ws.mg,
+		cl: ws.cl,
+	}
+	if ws.cl.Limited(etcdserver.ClientWatcher) {
+		sws.clientID = clientIdentity(ws.ag, stream.Context())
+	}
+	if ws.ackWindow > 0 && watchAcksRequested(stream.Context()) {
+		sws.ackWindow = ws.ackWindow
+	}
+	if ws.mp != nil {
+		sws.sender = newOrderedSender(stream, ws.mp)
 	}
 
 	sws.wg.Add(1)
@@ -152,11 +208,24 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		if err == context.Canceled {
 			err = rpctypes.ErrGRPCNoLeader
 		}
+	case <-ws.drainc:
+		err = rpctypes.ErrGRPCStopped
 	}
 	sws.close()
 	return err
 }
 
+// watchAcksRequested reports whether the client of a stream acknowledges
+// the watch responses it processed.
+func watchAcksRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	ks := md[rpctypes.MetadataWatchAckKey]
+	return len(ks) > 0 && ks[0] == rpctypes.MetadataWatchAck
+}
+
 func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) bool {
 	authInfo, err := sws.ag.AuthInfoFromCtx(sws.gRPCStream.Context())
 	if err != nil {
@@ -224,8 +293,11 @@ func (sws *serverWatchStream) recvLoop() error {
 			if rev == 0 {
 				rev = wsrev + 1
 			}
-			id := sws.watchStream.Watch(creq.Key, creq.RangeEnd, rev, filters...)
-			if id != -1 {
+			id, err := mvcc.WatchID(-1), etcdserver.ErrMemoryBudgetExceeded
+			if !sws.mg.OverMemoryBudget() {
+				id, err = sws.watch(creq.Key, creq.RangeEnd, rev, filters...)
+			}
+			if err == nil {
 				sws.mu.Lock()
 				if creq.ProgressNotify {
 					sws.progress[id] = true
@@ -239,7 +311,16 @@ func (sws *serverWatchStream) recvLoop() error {
 				Header:   sws.newResponseHeader(wsrev),
 				WatchId:  int64(id),
 				Created:  true,
-				Canceled: id == -1,
+				Canceled: err != nil,
+			}
+			if be, ok := err.(*mvcc.BacklogError); ok {
+				wr.CancelReason = rpctypes.WatchBacklogReason(be.RetryAfter)
+			} else if err == mvcc.ErrTooManyWatchers {
+				wr.CancelReason = rpctypes.ErrorDesc(rpctypes.ErrGRPCTooManyWatchers)
+			} else if err == etcdserver.ErrMemoryBudgetExceeded {
+				wr.CancelReason = rpctypes.ErrorDesc(rpctypes.ErrGRPCMemoryBudgetExceeded)
+			} else if err == etcdserver.ErrTooManyClientWatchers {
+				wr.CancelReason = rpctypes.ErrorDesc(rpctypes.ErrGRPCTooManyClientWatchers)
 			}
 			select {
 			case sws.ctrlStream <- wr:
@@ -260,8 +341,18 @@ func (sws *serverWatchStream) recvLoop() error {
 					delete(sws.progress, mvcc.WatchID(id))
 					delete(sws.prevKV, mvcc.WatchID(id))
 					sws.mu.Unlock()
+					sws.releaseWatcher(mvcc.WatchID(id))
 				}
 			}
+		case *pb.WatchRequest_AckRequest:
+			if uv.AckRequest == nil || sws.ackWindow == 0 {
+				break
+			}
+			select {
+			case sws.ackc <- uv.AckRequest:
+			case <-sws.closec:
+				return nil
+			}
 		default:
 			// we probably should not shutdown the entire stream when
 			// receive an valid command.
@@ -276,11 +367,66 @@ func (sws *serverWatchStream) sendLoop() {
 	ids := make(map[mvcc.WatchID]struct{})
 	// watch responses pending on a watch id creation message
 	pending := make(map[mvcc.WatchID][]*pb.WatchResponse)
+	// revisions of the responses sent to each watch id, in order, that the
+	// client has not acknowledged yet; only tracked under flow control
+	unacked := make(map[mvcc.WatchID][]int64)
+	// responses held back from watch ids whose ack window is full, and the
+	// watch ids paused in mvcc until the client catches up with them
+	held := make(map[mvcc.WatchID][]*pb.WatchResponse)
+	paused := make(map[mvcc.WatchID]bool)
+	forget := func(wid mvcc.WatchID) {
+		for _, wr := range held[wid] {
+			mvcc.ReportEventReceived(len(wr.Events))
+		}
+		delete(unacked, wid)
+		delete(held, wid)
+		delete(paused, wid)
+	}
+	sent := func(wr *pb.WatchResponse) {
+		if sws.ackWindow == 0 {
+			return
+		}
+		wid := mvcc.WatchID(wr.WatchId)
+		if wr.Canceled {
+			// the client stops acknowledging a compacted watcher
+			forget(wid)
+			return
+		}
+		unacked[wid] = append(unacked[wid], wr.Header.Revision)
+		if len(unacked[wid]) >= sws.ackWindow && !paused[wid] {
+			// stop the watcher's events in mvcc, so a watcher whose
+			// client falls behind holds back none of the stream's others
+			sws.watchStream.Pause(wid)
+			paused[wid] = true
+		}
+	}
+	// send sends a response unless its watch id's window is full, in which
+	// case it is held until the client acknowledges earlier responses.
+	send := func(wr *pb.WatchResponse) error {
+		wid := mvcc.WatchID(wr.WatchId)
+		if sws.ackWindow > 0 && (len(held[wid]) != 0 || len(unacked[wid]) >= sws.ackWindow) {
+			held[wid] = append(held[wid], wr)
+			return nil
+		}
+		mvcc.ReportEventReceived(len(wr.Events))
+		if err := sws.send(wr); err != nil {
+			return err
+		}
+		sent(wr)
+		if wr.Canceled {
+			sws.releaseWatcher(wid)
+		}
+		return nil
+	}
 
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
 	defer func() {
+		if sws.sender != nil {
+			// the stream must not be sent on once the handler returns
+			sws.sender.stop()
+		}
 		progressTicker.Stop()
 		// drain the chan to clean up pending events
 		for ws := range sws.watchStream.Chan() {
@@ -291,6 +437,11 @@ func (sws *serverWatchStream) sendLoop() {
 				mvcc.ReportEventReceived(len(ws.Events))
 			}
 		}
+		for _, wrs := range held {
+			for _, ws := range wrs {
+				mvcc.ReportEventReceived(len(ws.Events))
+			}
+		}
 	}()
 
 	for {
@@ -313,7 +464,7 @@ func (sws *serverWatchStream) sendLoop() {
 
 				if needPrevKV {
 					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1}
-					r, err := sws.watchable.Range(evs[i].Kv.Key, nil, opt)
+					r, err := sws.watchable.Range(context.TODO(), evs[i].Kv.Key, nil, opt)
 					if err == nil && len(r.KVs) != 0 {
 						events[i].PrevKv = &(r.KVs[0])
 					}
@@ -336,8 +487,7 @@ func (sws *serverWatchStream) sendLoop() {
 				continue
 			}
 
-			mvcc.ReportEventReceived(len(evs))
-			if err := sws.gRPCStream.Send(wr); err != nil {
+			if err := send(wr); err != nil {
 				return
 			}
 
@@ -353,7 +503,7 @@ func (sws *serverWatchStream) sendLoop() {
 				return
 			}
 
-			if err := sws.gRPCStream.Send(c); err != nil {
+			if err := sws.send(c); err != nil {
 				return
 			}
 
@@ -361,19 +511,48 @@ func (sws *serverWatchStream) sendLoop() {
 			wid := mvcc.WatchID(c.WatchId)
 			if c.Canceled {
 				delete(ids, wid)
+				forget(wid)
 				continue
 			}
 			if c.Created {
 				// flush buffered events
 				ids[wid] = struct{}{}
 				for _, v := range pending[wid] {
-					mvcc.ReportEventReceived(len(v.Events))
-					if err := sws.gRPCStream.Send(v); err != nil {
+					if err := send(v); err != nil {
 						return
 					}
 				}
 				delete(pending, wid)
 			}
+		case a := <-sws.ackc:
+			wid := mvcc.WatchID(a.WatchId)
+			revs := unacked[wid]
+			n := 0
+			for n < len(revs) && revs[n] <= a.Revision {
+				n++
+			}
+			if n == len(revs) {
+				delete(unacked, wid)
+			} else {
+				unacked[wid] = revs[n:]
+			}
+			// send the held responses the window now has room for, then
+			// let mvcc send the events the watcher missed while paused
+			wrs := held[wid]
+			delete(held, wid)
+			for i, wr := range wrs {
+				if err := send(wr); err != nil {
+					return
+				}
+				if len(held[wid]) != 0 {
+					held[wid] = append(held[wid], wrs[i+1:]...)
+					break
+				}
+			}
+			if paused[wid] && len(held[wid]) == 0 && len(unacked[wid]) < sws.ackWindow {
+				sws.watchStream.Resume(wid)
+				delete(paused, wid)
+			}
 		case <-progressTicker.C:
 			sws.mu.Lock()
 			for id, ok := range sws.progress {
@@ -389,10 +568,60 @@ func (sws *serverWatchStream) sendLoop() {
 	}
 }
 
+// send sends a response on the stream, through the sender if the responses
+// are marshaled ahead of their sends.
+func (sws *serverWatchStream) send(wr *pb.WatchResponse) error {
+	if sws.sender != nil {
+		return sws.sender.send(wr)
+	}
+	return sws.gRPCStream.Send(wr)
+}
+
 func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
 	close(sws.closec)
 	sws.wg.Wait()
+	sws.mu.Lock()
+	for range sws.limited {
+		sws.cl.Release(etcdserver.ClientWatcher, sws.clientID)
+	}
+	sws.limited = nil
+	sws.mu.Unlock()
+}
+
+// watch creates a watcher on the watch stream, counting it against the
+// watcher limit of the client.
+func (sws *serverWatchStream) watch(key, end []byte, rev int64, fcs ...mvcc.FilterFunc) (mvcc.WatchID, error) {
+	if err := sws.cl.Acquire(etcdserver.ClientWatcher, sws.clientID); err != nil {
+		return -1, err
+	}
+	id, err := sws.watchStream.Watch(key, end, rev, fcs...)
+	if err != nil {
+		sws.cl.Release(etcdserver.ClientWatcher, sws.clientID)
+		return -1, err
+	}
+	sws.mu.Lock()
+	closed := sws.limited == nil
+	if !closed {
+		sws.limited[id] = struct{}{}
+	}
+	sws.mu.Unlock()
+	if closed {
+		// the stream closed while creating the watcher
+		sws.cl.Release(etcdserver.ClientWatcher, sws.clientID)
+	}
+	return id, nil
+}
+
+// releaseWatcher stops counting a canceled watcher against the client's limit.
+func (sws *serverWatchStream) releaseWatcher(id mvcc.WatchID) {
+	sws.mu.Lock()
+	_, ok := sws.limited[id]
+	delete(sws.limited, id)
+	sws.mu.Unlock()
+	if ok {
+		sws.cl.Release(etcdserver.ClientWatcher, sws.clientID)
+	}
 }
 
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
@@ -412,6 +641,10 @@ func filterNoPut(e mvccpb.Event) bool {
 	return e.Type == mvccpb.PUT
 }
 
+func filterNoLeaseDelete(e mvccpb.Event) bool {
+	return e.Type == mvccpb.DELETE && e.Reason == mvccpb.LEASE_REVOKED
+}
+
 func FiltersFromRequest(creq *pb.WatchCreateRequest) []mvcc.FilterFunc {
 	filters := make([]mvcc.FilterFunc, 0, len(creq.Filters))
 	for _, ft := range creq.Filters {
@@ -420,6 +653,8 @@ func FiltersFromRequest(creq *pb.WatchCreateRequest) []mvcc.FilterFunc {
 			filters = append(filters, filterNoPut)
 		case pb.WatchCreateRequest_NODELETE:
 			filters = append(filters, filterNoDelete)
+		case pb.WatchCreateRequest_NOLEASEDELETE:
+			filters = append(filters, filterNoLeaseDelete)
 		default:
 		}
 	}
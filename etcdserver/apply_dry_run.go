@@ -0,0 +1,106 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/auth"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+)
+
+// dryRunApplierV3 evaluates puts, deletes and txns against a read txn that
+// discards their writes, so a dry run gets the response the write would
+// have been applied with, without proposing it.
+type dryRunApplierV3 struct {
+	*applierV3backend
+}
+
+// newDryRunApplierV3 wraps a dry run applier with the auth and quota checks
+// the raft loop applies writes with, on behalf of the given user.
+func (s *EtcdServer) newDryRunApplierV3(ai *auth.AuthInfo) applierV3 {
+	base := &dryRunApplierV3{s.newApplierV3Backend().(*applierV3backend)}
+	aa := newAuthApplierV3(s.AuthStore(), newQuotaApplierV3(s, base), s.lessor)
+	aa.authInfo = *ai
+	return aa
+}
+
+func (a *dryRunApplierV3) Put(_ mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutResponse, error) {
+	txn := mvcc.NewDryRunTxnWrite(a.s.KV().Read())
+	defer txn.End()
+
+	if err := a.s.checkPrefixQuotas(txn, []*pb.PutRequest{p}); err != nil {
+		return nil, err
+	}
+	if leaseID := lease.LeaseID(p.Lease); leaseID != lease.NoLease {
+		if l := a.s.lessor.Lookup(leaseID); l == nil {
+			return nil, lease.ErrLeaseNotFound
+		}
+	}
+	return a.applierV3backend.Put(txn, p)
+}
+
+func (a *dryRunApplierV3) DeleteRange(_ mvcc.TxnWrite, dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	txn := mvcc.NewDryRunTxnWrite(a.s.KV().Read())
+	defer txn.End()
+	return a.applierV3backend.DeleteRange(txn, dr)
+}
+
+func (a *dryRunApplierV3) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+	txn := mvcc.NewDryRunTxnWrite(a.s.KV().Read())
+	defer txn.End()
+
+	// the txn paths of the apply plan belong to the raft loop
+	txnPath := compareToPath(txn, rt)
+	puts, _ := txnPuts(rt, txnPath)
+	if err := a.s.checkPrefixQuotas(txn, puts); err != nil {
+		return nil, err
+	}
+	if _, err := checkRequests(txn, rt, txnPath, a.checkPut); err != nil {
+		return nil, err
+	}
+	if _, err := checkRequests(txn, rt, txnPath, a.checkRange); err != nil {
+		return nil, err
+	}
+
+	txnResp, _ := newTxnResp(rt, txnPath)
+	if _, err := a.applyTxn(ctx, txn, rt, txnPath, txnResp); err != nil {
+		return nil, err
+	}
+	rev := txn.Rev()
+	if len(txn.Changes()) != 0 {
+		rev++
+	}
+	txnResp.Header.Revision = rev
+	return txnResp, nil
+}
+
+// dryRun evaluates a write with the dry run applier once the member has
+// caught up with the cluster, so the outcome is that of a write proposed now.
+func (s *EtcdServer) dryRun(ctx context.Context, f func(applierV3) error) error {
+	if err := s.linearizableReadNotify(ctx); err != nil {
+		return err
+	}
+	ai, err := s.AuthInfoFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if ai == nil {
+		ai = &auth.AuthInfo{}
+	}
+	return f(s.newDryRunApplierV3(ai))
+}
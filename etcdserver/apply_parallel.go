@@ -0,0 +1,248 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/pkg/adt"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// applyPlan prepares a batch of committed entries on several cores before
+// they are applied. The writes of the entries still go to the backend one
+// by one in log order, so revisions, watch events and the consistent index
+// are the same as when applied serially; what runs in parallel is decoding
+// the entries and evaluating the compares of their txns.
+//
+// The batch is split into waves, runs of entries in which no txn compares a
+// key written by an earlier entry of the same wave. The compares of a wave
+// are evaluated together against the store as it is before the wave, which
+// is what each would read if the wave were applied one entry at a time.
+// Requests other than puts, delete ranges, txns and ranges are applied in a
+// wave of their own.
+type applyPlan struct {
+	s       *EtcdServer
+	workers int
+
+	// reqs holds the decoded entries, nil for those not decoded.
+	reqs []*pb.InternalRaftRequest
+	// ends holds the end of each wave.
+	ends []int
+	// wave is the next wave to prepare; prepared is the end of the last
+	// prepared wave.
+	wave, prepared int
+}
+
+// planApply returns the plan of applying es, or nil to apply them serially.
+func (s *EtcdServer) planApply(es []raftpb.Entry) *applyPlan {
+	workers := s.Cfg.ApplyWorkers
+	if workers < 2 || len(es) < 2 {
+		return nil
+	}
+	p := &applyPlan{s: s, workers: workers, reqs: make([]*pb.InternalRaftRequest, len(es))}
+	ci := s.consistIndex.ConsistentIndex()
+	parallelDo(len(es), workers, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			e := &es[i]
+			if e.Type != raftpb.EntryNormal || len(e.Data) == 0 || e.Index <= ci {
+				continue
+			}
+			var r pb.InternalRaftRequest
+			if pbutil.MaybeUnmarshal(&r, e.Data) {
+				p.reqs[i] = &r
+			}
+		}
+	})
+	p.ends = splitWaves(p.reqs)
+	return p
+}
+
+// prepare prepares the wave of the i-th entry if it starts one, and returns
+// the entry decoded, or nil if it was not.
+func (p *applyPlan) prepare(i int) *pb.InternalRaftRequest {
+	if p == nil {
+		return nil
+	}
+	if i >= p.prepared {
+		end := p.ends[p.wave]
+		p.wave++
+		p.prepareTxns(i, end)
+		p.prepared = end
+	}
+	return p.reqs[i]
+}
+
+// prepareTxns evaluates the compares of the txns of entries [start, end).
+func (p *applyPlan) prepareTxns(start, end int) {
+	p.s.txnPaths = nil
+	var txns []*pb.TxnRequest
+	for _, r := range p.reqs[start:end] {
+		if r != nil && r.Txn != nil {
+			txns = append(txns, r.Txn)
+		}
+	}
+	if len(txns) < 2 {
+		return
+	}
+	paths := make([][]bool, len(txns))
+	parallelDo(len(txns), p.workers, func(lo, hi int) {
+		rv := p.s.KV().Read()
+		defer rv.End()
+		for i := lo; i < hi; i++ {
+			paths[i] = compareToPath(rv, txns[i])
+		}
+	})
+	p.s.txnPaths = make(map[*pb.TxnRequest][]bool, len(txns))
+	for i, rt := range txns {
+		p.s.txnPaths[rt] = paths[i]
+	}
+}
+
+// finish drops the prepared txn paths left unused.
+func (p *applyPlan) finish() {
+	if p != nil {
+		p.s.txnPaths = nil
+	}
+}
+
+// txnPath returns the path of the txn rt, prepared by the apply plan if it
+// was, or else evaluated against rv.
+func (s *EtcdServer) txnPath(rv mvcc.ReadView, rt *pb.TxnRequest) []bool {
+	if path, ok := s.txnPaths[rt]; ok {
+		return path
+	}
+	return compareToPath(rv, rt)
+}
+
+// splitWaves returns the end of each wave of reqs.
+func splitWaves(reqs []*pb.InternalRaftRequest) (ends []int) {
+	var written adt.IntervalTree
+	start := 0
+	cut := func(i int) {
+		if i > start {
+			ends = append(ends, i)
+		}
+		start, written = i, adt.IntervalTree{}
+	}
+	for i, r := range reqs {
+		if !isKVRequest(r) {
+			cut(i)
+			cut(i + 1)
+			continue
+		}
+		if r.Txn != nil && comparesWritten(r.Txn, &written) {
+			cut(i)
+		}
+		addWrites(r, &written)
+	}
+	cut(len(reqs))
+	return ends
+}
+
+func isKVRequest(r *pb.InternalRaftRequest) bool {
+	return r != nil && (r.Put != nil || r.DeleteRange != nil || r.Txn != nil || r.Range != nil)
+}
+
+// comparesWritten returns true if any compare of rt, or of the txns nested
+// in it, reads a key in written.
+func comparesWritten(rt *pb.TxnRequest, written *adt.IntervalTree) bool {
+	for _, c := range rt.Compare {
+		if ivl, ok := keyInterval(c.Key, c.RangeEnd); ok && written.Intersects(ivl) {
+			return true
+		}
+	}
+	for _, ops := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, op := range ops {
+			if t := op.GetRequestTxn(); t != nil && comparesWritten(t, written) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addWrites adds the keys r may write to written. Both branches of a txn
+// are added since which one runs is only known when it is applied.
+func addWrites(r *pb.InternalRaftRequest, written *adt.IntervalTree) {
+	switch {
+	case r.Put != nil:
+		addWrite(written, r.Put.Key, nil)
+	case r.DeleteRange != nil:
+		addWrite(written, r.DeleteRange.Key, r.DeleteRange.RangeEnd)
+	case r.Txn != nil:
+		addTxnWrites(r.Txn, written)
+	}
+}
+
+func addTxnWrites(rt *pb.TxnRequest, written *adt.IntervalTree) {
+	for _, ops := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, op := range ops {
+			switch tv := op.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				addWrite(written, tv.RequestPut.Key, nil)
+			case *pb.RequestOp_RequestDeleteRange:
+				addWrite(written, tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd)
+			case *pb.RequestOp_RequestTxn:
+				addTxnWrites(tv.RequestTxn, written)
+			}
+		}
+	}
+}
+
+func addWrite(written *adt.IntervalTree, key, end []byte) {
+	if ivl, ok := keyInterval(key, end); ok {
+		written.Insert(ivl, struct{}{})
+	}
+}
+
+// keyInterval returns the interval of keys of the range [key, end). It
+// returns false if the range holds no keys.
+func keyInterval(key, end []byte) (adt.Interval, bool) {
+	if len(key) == 0 {
+		// keys are never empty; "" is +inf to affine intervals
+		key = []byte{0}
+	}
+	switch {
+	case len(end) == 0:
+		return adt.NewStringAffinePoint(string(key)), true
+	case len(end) == 1 && end[0] == 0:
+		// all keys from key on
+		return adt.NewStringAffineInterval(string(key), ""), true
+	case string(end) <= string(key):
+		return adt.Interval{}, false
+	}
+	return adt.NewStringAffineInterval(string(key), string(end)), true
+}
+
+// parallelDo splits [0, n) into up to workers ranges and calls f for each
+// range [lo, hi) on a goroutine of its own.
+func parallelDo(n, workers int, f func(lo, hi int)) {
+	if workers > n {
+		workers = n
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(lo, hi int) {
+			defer wg.Done()
+			f(lo, hi)
+		}(n*w/workers, n*(w+1)/workers)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/pkg/wait"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+func casTxn(key string, version int64, val string) *pb.TxnRequest {
+	return &pb.TxnRequest{
+		Compare: []*pb.Compare{{
+			Key:         []byte(key),
+			Target:      pb.Compare_VERSION,
+			Result:      pb.Compare_EQUAL,
+			TargetUnion: &pb.Compare_Version{Version: version},
+		}},
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{
+			RequestPut: &pb.PutRequest{Key: []byte(key), Value: []byte(val)}}}},
+		Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{
+			RequestRange: &pb.RangeRequest{Key: []byte(key)}}}},
+	}
+}
+
+func TestSplitWaves(t *testing.T) {
+	put := func(k string) *pb.InternalRaftRequest {
+		return &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(k)}}
+	}
+	del := func(k, end string) *pb.InternalRaftRequest {
+		return &pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{Key: []byte(k), RangeEnd: []byte(end)}}
+	}
+	txn := func(k string) *pb.InternalRaftRequest {
+		return &pb.InternalRaftRequest{Txn: casTxn(k, 0, "")}
+	}
+	lg := &pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{ID: 1}}
+
+	tests := []struct {
+		reqs []*pb.InternalRaftRequest
+
+		wends []int
+	}{
+		{nil, nil},
+		{[]*pb.InternalRaftRequest{put("a"), put("a"), put("b")}, []int{3}},
+		{[]*pb.InternalRaftRequest{txn("a"), txn("b"), txn("c")}, []int{3}},
+		// compares a key written earlier in the wave
+		{[]*pb.InternalRaftRequest{put("a"), txn("b"), txn("a")}, []int{2, 3}},
+		{[]*pb.InternalRaftRequest{txn("a"), txn("a")}, []int{1, 2}},
+		{[]*pb.InternalRaftRequest{del("a", "c"), txn("b"), txn("c")}, []int{1, 3}},
+		{[]*pb.InternalRaftRequest{del("a", "\x00"), txn("z")}, []int{1, 2}},
+		{[]*pb.InternalRaftRequest{del("", "\x00"), txn("a")}, []int{1, 2}},
+		// not decoded or not a key-value request
+		{[]*pb.InternalRaftRequest{txn("a"), nil, txn("b")}, []int{1, 2, 3}},
+		{[]*pb.InternalRaftRequest{txn("a"), txn("b"), lg, txn("c")}, []int{2, 3, 4}},
+	}
+	for i, tt := range tests {
+		if ends := splitWaves(tt.reqs); !reflect.DeepEqual(ends, tt.wends) {
+			t.Errorf("#%d: ends = %v, want %v", i, ends, tt.wends)
+		}
+	}
+}
+
+func newApplyTestServer(workers int) (*EtcdServer, func()) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	s := &EtcdServer{
+		Cfg:    ServerConfig{ApplyWorkers: workers},
+		be:     be,
+		w:      wait.New(),
+		lessor: &lease.FakeLessor{},
+	}
+	s.kv = mvcc.New(be, &lease.FakeLessor{}, &s.consistIndex)
+	s.applyV3Base = s.newApplierV3Backend()
+	s.applyV3 = s.applyV3Base
+	return s, func() {
+		s.kv.Close()
+		be.Close()
+		os.Remove(tmpPath)
+	}
+}
+
+// applyTestEntries returns n entries of puts, deletes and compare-and-swap
+// txns over prefixes keys under each of prefixes.
+func applyTestEntries(n, prefixes, keys int) []raftpb.Entry {
+	rnd := rand.New(rand.NewSource(1))
+	es := make([]raftpb.Entry, n)
+	for i := range es {
+		key := fmt.Sprintf("/p%d/k%d", rnd.Intn(prefixes), rnd.Intn(keys))
+		r := &pb.InternalRaftRequest{Header: &pb.RequestHeader{ID: uint64(i + 1)}}
+		switch x := rnd.Intn(20); {
+		case x == 0:
+			r.DeleteRange = &pb.DeleteRangeRequest{Key: []byte(key)}
+		case x == 1:
+			// an entry applied alone
+			es[i] = raftpb.Entry{Index: uint64(i + 1), Term: 1}
+			continue
+		case x < 6:
+			r.Put = &pb.PutRequest{Key: []byte(key), Value: []byte("v")}
+		default:
+			r.Txn = casTxn(key, int64(rnd.Intn(3)), fmt.Sprint(i))
+		}
+		es[i] = raftpb.Entry{Index: uint64(i + 1), Term: 1, Data: pbutil.MustMarshal(r)}
+	}
+	return es
+}
+
+// TestApplyPlanMatchesSerial ensures entries applied with workers give the
+// responses and the store they give applied serially.
+func TestApplyPlanMatchesSerial(t *testing.T) {
+	es := applyTestEntries(1000, 8, 4)
+
+	var (
+		resps [2][]interface{}
+		hashs [2]uint32
+	)
+	for i, workers := range []int{0, 4} {
+		s, cleanup := newApplyTestServer(workers)
+		chs := make([]<-chan interface{}, len(es))
+		for j := range es {
+			if len(es[j].Data) != 0 {
+				chs[j] = s.w.Register(uint64(j + 1))
+			}
+		}
+		var cs raftpb.ConfState
+		for j := 0; j < len(es); j += 100 {
+			s.apply(es[j:j+100], &cs)
+		}
+		for _, ch := range chs {
+			if ch == nil {
+				continue
+			}
+			ar := (<-ch).(*applyResult)
+			resps[i] = append(resps[i], ar.resp, ar.err)
+		}
+		h, _, err := s.KV().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashs[i] = h
+		cleanup()
+	}
+	if !reflect.DeepEqual(resps[0], resps[1]) {
+		t.Errorf("responses applied with workers differ from applied serially")
+	}
+	if hashs[0] != hashs[1] {
+		t.Errorf("hash = %d, want %d as applied serially", hashs[1], hashs[0])
+	}
+}
+
+func BenchmarkApplyTxns(b *testing.B) {
+	for _, workers := range []int{0, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			benchmarkApplyTxns(b, workers)
+		})
+	}
+}
+
+// benchmarkApplyTxns applies batches of compare-and-swap txns spread over
+// many prefixes, as written by independent clients.
+func benchmarkApplyTxns(b *testing.B, workers int) {
+	const batch = 100
+	s, cleanup := newApplyTestServer(workers)
+	defer cleanup()
+
+	es := make([]raftpb.Entry, b.N)
+	for i := range es {
+		r := &pb.InternalRaftRequest{
+			Header: &pb.RequestHeader{ID: uint64(i + 1)},
+			Txn:    casTxn(fmt.Sprintf("/p%d/k", i%batch), int64(i/batch), "v"),
+		}
+		es[i] = raftpb.Entry{Index: uint64(i + 1), Term: 1, Data: pbutil.MustMarshal(r)}
+	}
+
+	b.ResetTimer()
+	var cs raftpb.ConfState
+	for i := 0; i < len(es); i += batch {
+		end := i + batch
+		if end > len(es) {
+			end = len(es)
+		}
+		s.apply(es[i:end], &cs)
+	}
+}
@@ -91,6 +91,17 @@ func (a *applierV2store) Put(r *RequestV2) Response {
 			// return an empty response since there is no consumer.
 			return Response{}
 		}
+		if r.Path == membership.StoreDowngradeKey() {
+			var d membership.DowngradeInfo
+			if err := json.Unmarshal([]byte(r.Val), &d); err != nil {
+				plog.Panicf("unmarshal %s should never fail: %v", r.Val, err)
+			}
+			if a.cluster != nil {
+				a.cluster.SetDowngradeInfo(&d)
+			}
+			// return an empty response since there is no consumer.
+			return Response{}
+		}
 		return toResponse(a.store.Set(r.Path, r.Dir, r.Val, ttlOptions))
 	}
 }
@@ -0,0 +1,60 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coreos/etcd/pkg/wait"
+)
+
+// errProposalDropped triggers the waits of proposals taken to be dropped by
+// a leader change.
+var errProposalDropped = errors.New("etcdserver: proposal dropped by leader change")
+
+// failDroppedProposals fails the proposals registered before t, when the
+// member applied the first entry of a new term, that are still not applied
+// an election timeout later. Entries are applied in order, so a proposal
+// made before t is either committed ahead of the new leader's first entry,
+// and already applied, or was forwarded to the new leader, and is applied
+// shortly after, or was dropped with the old leader's uncommitted log.
+func (s *EtcdServer) failDroppedProposals(t time.Time) {
+	electionTimeout := time.Duration(s.Cfg.ElectionTicks) * time.Duration(s.Cfg.TickMs) * time.Millisecond
+	s.goAttach(func() {
+		select {
+		case <-time.After(electionTimeout):
+		case <-s.stopping:
+			return
+		}
+		if n := s.w.TriggerRegisteredBefore(t, errProposalDropped); n > 0 {
+			plog.Warningf("failed %d proposal(s) dropped by leader change", n)
+		}
+	})
+}
+
+// parseWaitErr returns the error for a proposal whose wait was triggered
+// with err instead of its result.
+func (s *EtcdServer) parseWaitErr(err error, start time.Time) error {
+	switch err {
+	case wait.ErrDeadlineExceeded:
+		return s.parseProposeCtxErr(context.DeadlineExceeded, start)
+	case errProposalDropped:
+		return ErrTimeoutDueToLeaderFail
+	default:
+		return err
+	}
+}
@@ -29,6 +29,14 @@ import (
 func newBackend(cfg ServerConfig) backend.Backend {
 	bcfg := backend.DefaultBackendConfig()
 	bcfg.Path = cfg.backendPath()
+	if cfg.BackendBatchInterval > 0 {
+		bcfg.BatchInterval = cfg.BackendBatchInterval
+	}
+	if cfg.BackendBatchLimit > 0 {
+		bcfg.BatchLimit = cfg.BackendBatchLimit
+	}
+	bcfg.PreallocSize = cfg.BackendPreallocSize
+	bcfg.DSync = cfg.BackendDSync
 	if cfg.QuotaBackendBytes > 0 && cfg.QuotaBackendBytes != DefaultQuotaBytes {
 		// permit 10% excess over quota for disarm
 		bcfg.MmapSize = uint64(cfg.QuotaBackendBytes + cfg.QuotaBackendBytes/10)
@@ -0,0 +1,53 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync/atomic"
+
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// The proposal backlog is held in memory in two stages: the proposals the
+// member waits on until they are applied, and the committed entries queued
+// for the apply loop. Either grows without bound once the disk falls behind.
+
+// addPendingBytes adds n to the bytes of proposals the member waits on.
+func (s *EtcdServer) addPendingBytes(n int64) {
+	atomic.AddInt64(&s.pendingBytes, n)
+	proposalBacklogBytes.WithLabelValues("pending").Add(float64(n))
+}
+
+// addUnappliedBytes adds n to the bytes of committed entries not yet applied.
+func (s *EtcdServer) addUnappliedBytes(n int64) {
+	atomic.AddInt64(&s.unappliedBytes, n)
+	proposalBacklogBytes.WithLabelValues("unapplied").Add(float64(n))
+}
+
+// backlogExceeds returns true if either stage of the proposal backlog holds
+// more than max bytes. A max of 0 is unlimited.
+func (s *EtcdServer) backlogExceeds(max int64) bool {
+	if max <= 0 {
+		return false
+	}
+	return atomic.LoadInt64(&s.pendingBytes) > max || atomic.LoadInt64(&s.unappliedBytes) > max
+}
+
+func entriesSize(ents []raftpb.Entry) (n int64) {
+	for i := range ents {
+		n += int64(ents[i].Size())
+	}
+	return n
+}
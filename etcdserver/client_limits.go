@@ -0,0 +1,135 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+)
+
+// ClientResource is a resource a client identity holds on the member.
+type ClientResource int
+
+const (
+	ClientConnection ClientResource = iota
+	ClientStream
+	ClientWatcher
+
+	numClientResources
+)
+
+func (r ClientResource) String() string {
+	switch r {
+	case ClientConnection:
+		return "connection"
+	case ClientStream:
+		return "stream"
+	case ClientWatcher:
+		return "watcher"
+	}
+	return "unknown"
+}
+
+var clientResourceErrors = [numClientResources]error{
+	ErrTooManyClientConnections,
+	ErrTooManyClientStreams,
+	ErrTooManyClientWatchers,
+}
+
+// ClientLimiter bounds the connections, streams and watchers each client
+// identity holds, so a single misbehaving client cannot exhaust the file
+// descriptors and memory of the member.
+type ClientLimiter struct {
+	limits [numClientResources]int
+
+	mu   sync.Mutex
+	held map[string]*[numClientResources]int
+}
+
+// NewClientLimiter returns a limiter allowing each client identity the given
+// number of connections, streams and watchers. A limit of 0 is unlimited.
+func NewClientLimiter(conns, streams, watchers int) *ClientLimiter {
+	return &ClientLimiter{
+		limits: [numClientResources]int{conns, streams, watchers},
+		held:   make(map[string]*[numClientResources]int),
+	}
+}
+
+// Limited returns true if the resource r is limited.
+func (l *ClientLimiter) Limited(r ClientResource) bool {
+	return l != nil && l.limits[r] > 0
+}
+
+// Acquire takes one r for the client identity id. It returns an error if the
+// client already holds as many as the limit allows.
+func (l *ClientLimiter) Acquire(r ClientResource, id string) error {
+	if !l.Limited(r) {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	held := l.held[id]
+	if held == nil {
+		held = new([numClientResources]int)
+		l.held[id] = held
+	}
+	if held[r] >= l.limits[r] {
+		clientLimitRejected.WithLabelValues(r.String()).Inc()
+		return clientResourceErrors[r]
+	}
+	held[r]++
+	return nil
+}
+
+// Release returns one r taken by Acquire for the client identity id.
+func (l *ClientLimiter) Release(r ClientResource, id string) {
+	if !l.Limited(r) {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	held := l.held[id]
+	if held == nil || held[r] == 0 {
+		plog.Panicf("releasing %s not acquired by client %q", r, id)
+	}
+	held[r]--
+	if *held == [numClientResources]int{} {
+		delete(l.held, id)
+	}
+}
+
+// ClientConnIdentity returns the identity of a client connection from its
+// TLS state, if any, and remote address: the common name of the verified
+// client certificate, or else the remote IP.
+func ClientConnIdentity(cs *tls.ConnectionState, addr net.Addr) string {
+	if cs != nil && len(cs.VerifiedChains) > 0 && len(cs.VerifiedChains[0]) > 0 {
+		if cn := cs.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+			return cn
+		}
+	}
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// ClientLimiter returns the limiter of the connections, streams and
+// watchers of each client.
+func (s *EtcdServer) ClientLimiter() *ClientLimiter { return s.clientLimiter }
@@ -0,0 +1,96 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+)
+
+func TestClientLimiter(t *testing.T) {
+	l := NewClientLimiter(0, 2, 1)
+	if l.Limited(ClientConnection) || !l.Limited(ClientStream) || !l.Limited(ClientWatcher) {
+		t.Fatalf("unexpected limited resources")
+	}
+
+	// unlimited resources are never rejected
+	for i := 0; i < 10; i++ {
+		if err := l.Acquire(ClientConnection, "a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := l.Acquire(ClientStream, "a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Acquire(ClientStream, "a"); err != ErrTooManyClientStreams {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyClientStreams)
+	}
+	// limits are per client and per resource
+	if err := l.Acquire(ClientStream, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Acquire(ClientWatcher, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Acquire(ClientWatcher, "a"); err != ErrTooManyClientWatchers {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyClientWatchers)
+	}
+
+	l.Release(ClientStream, "a")
+	if err := l.Acquire(ClientStream, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Release(ClientStream, "b")
+	if _, ok := l.held["b"]; ok {
+		t.Fatalf("expected client without resources to be forgotten")
+	}
+
+	// a nil limiter limits nothing
+	var nl *ClientLimiter
+	if err := nl.Acquire(ClientStream, "a"); err != nil {
+		t.Fatal(err)
+	}
+	nl.Release(ClientStream, "a")
+}
+
+func TestClientConnIdentity(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 2379}
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "root"}}
+	tests := []struct {
+		cs   *tls.ConnectionState
+		addr net.Addr
+
+		id string
+	}{
+		{nil, addr, "10.0.0.1"},
+		{&tls.ConnectionState{}, addr, "10.0.0.1"},
+		{&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}, addr, "root"},
+		{&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}, addr, "10.0.0.1"},
+		{nil, &net.UnixAddr{Name: "/tmp/etcd.sock", Net: "unix"}, "/tmp/etcd.sock"},
+		{nil, nil, ""},
+	}
+	for i, tt := range tests {
+		if id := ClientConnIdentity(tt.cs, tt.addr); id != tt.id {
+			t.Errorf("#%d: id = %q, want %q", i, id, tt.id)
+		}
+	}
+}
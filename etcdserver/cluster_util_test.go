@@ -129,3 +129,63 @@ func TestIsCompatibleWithVers(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidDowngrade(t *testing.T) {
+	tests := []struct {
+		cv, tv string
+		wok    bool
+	}{
+		{"3.2.0", "3.1.0", true},
+		{"3.2.0", "3.2.0", false},
+		{"3.2.0", "3.3.0", false},
+		// lower than the minimal cluster version
+		{"3.1.0", "3.0.0", false},
+		{"3.3.0", "3.1.0", false},
+		{"4.0.0", "3.9.0", false},
+	}
+	for i, tt := range tests {
+		cv := semver.Must(semver.NewVersion(tt.cv))
+		tv := semver.Must(semver.NewVersion(tt.tv))
+		if ok := isValidDowngrade(cv, tv); ok != tt.wok {
+			t.Errorf("#%d: isValidDowngrade(%s, %s) = %t, want %t", i, tt.cv, tt.tv, ok, tt.wok)
+		}
+	}
+	if isValidDowngrade(nil, semver.Must(semver.NewVersion("3.1.0"))) {
+		t.Errorf("isValidDowngrade with an undecided cluster version = true, want false")
+	}
+}
+
+func TestIsMatchedVersions(t *testing.T) {
+	tv := semver.Must(semver.NewVersion("3.1.0"))
+	tests := []struct {
+		vers map[string]*version.Versions
+		wok  bool
+	}{
+		{
+			map[string]*version.Versions{
+				"a": {Server: "3.1.0"},
+				"b": {Server: "3.1.5"},
+			},
+			true,
+		},
+		{
+			map[string]*version.Versions{
+				"a": {Server: "3.1.0"},
+				"b": {Server: "3.2.0"},
+			},
+			false,
+		},
+		{
+			map[string]*version.Versions{
+				"a": {Server: "3.1.0"},
+				"b": nil,
+			},
+			false,
+		},
+	}
+	for i, tt := range tests {
+		if ok := isMatchedVersions(tv, tt.vers); ok != tt.wok {
+			t.Errorf("#%d: isMatchedVersions = %t, want %t", i, ok, tt.wok)
+		}
+	}
+}
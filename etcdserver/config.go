@@ -17,12 +17,17 @@ package etcdserver
 import (
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/coreos/etcd/etcdserver/admission"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/pkg/featuregate"
 	"github.com/coreos/etcd/pkg/netutil"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
 )
@@ -47,26 +52,153 @@ type ServerConfig struct {
 	ForceNewCluster     bool
 	PeerTLSInfo         transport.TLSInfo
 
+	// SnapshotCatchUpEntries is the number of entries kept in the raft log
+	// behind the index it is compacted to, for slow followers to catch up
+	// from without a snapshot. The raft log is compacted up to the index the
+	// backend persisted as entries are applied, and on every snapshot.
+	SnapshotCatchUpEntries uint64
+
 	TickMs           uint
 	ElectionTicks    int
 	BootstrapTimeout time.Duration
 
 	AutoCompactionRetention time.Duration
 	AutoCompactionMode      string
-	QuotaBackendBytes       int64
-	MaxTxnOps               uint
+	// AutoCompactionWindows are the maintenance windows auto compaction
+	// runs in, as parsed by compactor.ParseWindows. Any time if empty.
+	AutoCompactionWindows string
+	QuotaBackendBytes     int64
+	MaxTxnOps             uint
+
+	// BackendBatchInterval is the maximum time before committing the
+	// backend transaction. The backend default is used if zero.
+	BackendBatchInterval time.Duration
+	// BackendBatchLimit is the maximum operations before committing the
+	// backend transaction. The backend default is used if zero.
+	BackendBatchLimit int
+	// BackendPreallocSize is the size of the chunks the backend file's disk
+	// space is preallocated in. Preallocation is disabled if zero.
+	BackendPreallocSize int64
 
 	// MaxRequestBytes is the maximum request size to send over raft.
 	MaxRequestBytes uint
 
+	// MaxValueBytes is the maximum size of a value a put may store.
+	// 0 means no limit.
+	MaxValueBytes uint
+
+	// MaxProposalBacklogBytes is the size of the proposals the server waits
+	// on, or of the committed entries it has yet to apply, past which new
+	// client proposals are rejected. 0 means no limit.
+	MaxProposalBacklogBytes uint
+
+	// MaxWatchers and MaxWatchersPerStream are the maximum number of
+	// watchers of the server and of each watch stream. 0 means no limit.
+	MaxWatchers          uint
+	MaxWatchersPerStream uint
+
+	// MaxConnectionsPerClient, MaxStreamsPerClient and MaxWatchersPerClient
+	// bound what each client identity, its auth user, certificate common
+	// name or else remote IP, holds on the member. 0 means no limit.
+	MaxConnectionsPerClient uint
+	MaxStreamsPerClient     uint
+	MaxWatchersPerClient    uint
+
+	// MaxConcurrentStreams is the maximum number of concurrent streams
+	// per client connection.
+	MaxConcurrentStreams uint32
+	// MaxRecvMsgSize and MaxSendMsgSize are the maximum sizes of the
+	// messages the gRPC server receives and sends. 0 uses the defaults.
+	MaxRecvMsgSize uint
+	MaxSendMsgSize uint
+
 	StrictReconfigCheck bool
 
+	// ReadOnly starts the member in read-only mode, refusing client writes.
+	ReadOnly bool
+
+	// Witness starts the member as a witness, which votes but stores no
+	// key-value data and never becomes the leader.
+	Witness bool
+
+	// PrefixQuotas is a ',' separated list of 'prefix=bytes:keys' quotas
+	// limiting the keys stored under each prefix; 0 is unlimited.
+	PrefixQuotas string
+
+	// MemoryBudget is the approximate memory in bytes the key index, watch
+	// buffers and raft log may hold before new watchers and large ranges
+	// are rejected; 0 is unlimited.
+	MemoryBudget int64
+
 	// ClientCertAuthEnabled is true when cert has been signed by the client CA.
 	ClientCertAuthEnabled bool
 
 	AuthToken string
 
 	CorruptCheckTime time.Duration
+
+	// KeyIndexSaveInterval is the time between saves of the key index
+	// to the backend, which shortens restarts. Disabled if zero.
+	KeyIndexSaveInterval time.Duration
+
+	// MaxUnsyncedWatchers is the number of unsynced watchers past which
+	// watches on past revisions are refused. 0 means no limit.
+	MaxUnsyncedWatchers uint
+
+	// WatchEventHistorySize is the number of events of the latest revisions
+	// kept in memory for syncing lagging watchers, none older than
+	// WatchEventHistoryMaxAge if it is positive. 0 disables the history.
+	WatchEventHistorySize   uint
+	WatchEventHistoryMaxAge time.Duration
+
+	// WatchAckWindow is the number of watch responses the server sends to
+	// a watcher ahead of acknowledgements on streams whose client
+	// acknowledges responses. 0 disables flow control.
+	WatchAckWindow uint
+
+	// WALDSync opens the WAL files appended to with O_DSYNC where supported.
+	WALDSync bool
+	// BackendDSync opens the backend file with O_DSYNC where supported.
+	BackendDSync bool
+
+	// ApplyWorkers is the number of goroutines preparing committed entries
+	// before they are applied in order. Entries are prepared by the apply
+	// loop alone if less than 2.
+	ApplyWorkers int
+
+	// TraceExporter receives traces of client requests through the
+	// server stages. Tracing is disabled if nil.
+	TraceExporter traceutil.Exporter
+
+	// FeatureGate toggles experimental features. NewServer sets it to the
+	// defaults if nil.
+	FeatureGate *featuregate.FeatureGate
+
+	// WarningApplyDuration is the time after which a client request is
+	// logged as slow, with its size and the time spent in each stage.
+	// Slow requests are not logged if zero.
+	WarningApplyDuration time.Duration
+
+	// OpLog receives a JSON record of the invocation and the return of
+	// every unary client request, for external linearizability checkers.
+	// Requests are not recorded if nil.
+	OpLog io.Writer
+
+	// ValueIndexes is a ',' separated list of 'name=prefix:pointer' value
+	// indexes of the JSON values of the keys under each prefix.
+	ValueIndexes string
+	// CustomValueIndexes are value indexes with their own extract
+	// functions, maintained along with ValueIndexes.
+	CustomValueIndexes []mvcc.ValueIndex
+
+	// AdmissionHooks is a ',' separated list of the names of registered
+	// admission hooks run on client writes before they are proposed.
+	AdmissionHooks string
+	// CustomAdmissionHooks are admission hooks run after AdmissionHooks.
+	CustomAdmissionHooks []admission.Hook
+	// ValueSchemas is a ',' separated list of 'prefix=file' schemas the
+	// values of the keys under each prefix must conform to.
+	ValueSchemas string
 }
 
 // VerifyBootstrap sanity-checks the initial config for bootstrap case
@@ -84,6 +216,9 @@ func (c *ServerConfig) VerifyBootstrap() error {
 	if c.InitialPeerURLsMap.String() == "" && c.DiscoveryURL == "" {
 		return fmt.Errorf("initial cluster unset and no discovery URL found")
 	}
+	if c.Witness && c.DiscoveryURL == "" && len(c.InitialPeerURLsMap) < 2 {
+		return fmt.Errorf("a witness cannot bootstrap a single member cluster it could never lead")
+	}
 	return nil
 }
 
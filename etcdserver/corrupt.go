@@ -16,11 +16,12 @@ package etcdserver
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
-	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/pkg/logutil"
 	"github.com/coreos/etcd/pkg/types"
 )
 
@@ -52,7 +53,8 @@ func (s *EtcdServer) checkHashKV() error {
 	}
 	resps := []*clientv3.HashKVResponse{}
 	for _, m := range s.cluster.Members() {
-		if m.ID == s.ID() {
+		// witnesses store no key-value data to hash
+		if m.ID == s.ID() || m.Witness {
 			continue
 		}
 
@@ -79,7 +81,7 @@ func (s *EtcdServer) checkHashKV() error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.Cfg.ReqTimeout())
+	ctx, cancel := context.WithTimeout(withPriority(context.Background(), prioritySystem), s.Cfg.ReqTimeout())
 	err = s.linearizableReadNotify(ctx)
 	cancel()
 	if err != nil {
@@ -92,12 +94,12 @@ func (s *EtcdServer) checkHashKV() error {
 		return err
 	}
 
-	alarmed := false
+	alarmed := make(map[uint64]bool)
 	mismatch := func(id uint64) {
-		if alarmed {
+		if alarmed[id] {
 			return
 		}
-		alarmed = true
+		alarmed[id] = true
 		a := &pb.AlarmRequest{
 			MemberID: uint64(id),
 			Action:   pb.AlarmRequest_ACTIVATE,
@@ -109,10 +111,14 @@ func (s *EtcdServer) checkHashKV() error {
 	}
 
 	if h2 != h && rev2 == rev && crev == crev2 {
-		plog.Warningf("mismatched hashes %d and %d for revision %d", h, h2, rev)
+		plog.Warning(fmt.Sprintf("mismatched hashes %d and %d", h, h2), logutil.Revision(rev), logutil.MemberID(uint64(s.ID())))
 		mismatch(uint64(s.ID()))
 	}
 
+	// members hashing the same revision at the same compacted revision
+	// vote on the hash; a member disagreeing with the majority diverged
+	hashes := map[uint32][]uint64{h: {uint64(s.ID())}}
+	voters := 1
 	for _, resp := range resps {
 		id := resp.Header.MemberId
 		if resp.Header.Revision > rev2 {
@@ -132,13 +138,32 @@ func (s *EtcdServer) checkHashKV() error {
 			)
 			mismatch(id)
 		}
-		if resp.CompactRevision == crev && resp.Hash != h {
+		if resp.CompactRevision == crev {
+			hashes[resp.Hash] = append(hashes[resp.Hash], id)
+			voters++
+		}
+	}
+
+	majority, ok := h, false
+	for hash, ids := range hashes {
+		if 2*len(ids) > voters {
+			majority, ok = hash, true
+		}
+	}
+	if !ok && len(hashes) > 1 {
+		plog.Warningf("no majority of %d members agrees on the hash at revision %d; trusting the leader", voters, rev)
+	}
+	for hash, ids := range hashes {
+		if hash == majority {
+			continue
+		}
+		for _, id := range ids {
 			plog.Warningf(
 				"hash %d at revision %d from member %v, expected hash %d",
-				resp.Hash,
+				hash,
 				rev,
 				types.ID(id),
-				h,
+				majority,
 			)
 			mismatch(id)
 		}
@@ -146,36 +171,12 @@ func (s *EtcdServer) checkHashKV() error {
 	return nil
 }
 
-type applierV3Corrupt struct {
-	applierV3
-}
-
-func newApplierV3Corrupt(a applierV3) *applierV3Corrupt { return &applierV3Corrupt{a} }
-
-func (a *applierV3Corrupt) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutResponse, error) {
-	return nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) Range(txn mvcc.TxnRead, p *pb.RangeRequest) (*pb.RangeResponse, error) {
-	return nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) DeleteRange(txn mvcc.TxnWrite, p *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
-	return nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
-	return nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, error) {
-	return nil, nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
-	return nil, ErrCorrupt
-}
-
-func (a *applierV3Corrupt) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
-	return nil, ErrCorrupt
+// isCorrupt returns true if a CORRUPT alarm is raised for this member.
+func (s *EtcdServer) isCorrupt() bool {
+	for _, m := range s.alarmStore.Get(pb.AlarmType_CORRUPT) {
+		if types.ID(m.MemberID) == s.ID() {
+			return true
+		}
+	}
+	return false
 }
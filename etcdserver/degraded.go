@@ -0,0 +1,69 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// monitorBackendInterval is how often the backend is checked for a failed
+// commit.
+var monitorBackendInterval = 500 * time.Millisecond
+
+// monitorBackend watches for the backend failing to commit, e.g. on a full
+// disk or an IO error. The backend then keeps its last committed state on
+// disk, and the member is degraded: it stops applying entries, refuses
+// writes and linearizable reads, and keeps serving serializable reads at
+// its last applied index until restarted. The member raises an IOERROR alarm
+// and hands off leadership so the rest of the cluster keeps going.
+func (s *EtcdServer) monitorBackend() {
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-time.After(monitorBackendInterval):
+		}
+		if !s.isDegraded() {
+			continue
+		}
+
+		plog.Errorf("%s is degraded by a backend write failure (%v); restart it once the disk is fixed", s.ID(), s.Backend().Err())
+		a := &pb.AlarmRequest{
+			MemberID: uint64(s.ID()),
+			Action:   pb.AlarmRequest_ACTIVATE,
+			Alarm:    pb.AlarmType_IOERROR,
+		}
+		// a degraded member does not apply the alarm, so only wait for the
+		// request to time out
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		s.raftRequest(ctx, pb.InternalRaftRequest{Alarm: a})
+		cancel()
+		if s.isLeader() {
+			if err := s.TransferLeadership(); err != nil {
+				plog.Warningf("%s failed to transfer leadership (%v)", s.ID(), err)
+			}
+		}
+		return
+	}
+}
+
+// isDegraded returns true if the backend of the member failed to commit.
+func (s *EtcdServer) isDegraded() bool {
+	be := s.Backend()
+	return be != nil && be.Err() != nil
+}
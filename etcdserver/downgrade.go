@@ -0,0 +1,157 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"encoding/json"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/etcdserver/membership"
+	"github.com/coreos/etcd/version"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Downgrade queries, validates, enables or cancels the downgrade of the
+// cluster version by one minor version.
+func (s *EtcdServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	if err := s.linearizableReadNotify(ctx); err != nil {
+		return nil, err
+	}
+	switch r.Action {
+	case pb.DowngradeRequest_STATUS:
+	case pb.DowngradeRequest_VALIDATE:
+		if _, err := s.validateDowngrade(r.Version); err != nil {
+			return nil, err
+		}
+	case pb.DowngradeRequest_ENABLE:
+		tv, err := s.validateDowngrade(r.Version)
+		if err != nil {
+			return nil, err
+		}
+		d := &membership.DowngradeInfo{Enabled: true, TargetVersion: tv.String()}
+		if err = s.proposeDowngrade(ctx, d); err != nil {
+			return nil, err
+		}
+	case pb.DowngradeRequest_CANCEL:
+		if !s.cluster.DowngradeInfo().Enabled {
+			return nil, ErrNoInflightDowngrade
+		}
+		if err := s.proposeDowngrade(ctx, &membership.DowngradeInfo{}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnknownMethod
+	}
+
+	resp := &pb.DowngradeResponse{Header: &pb.ResponseHeader{}}
+	if cv := s.ClusterVersion(); cv != nil {
+		resp.Version = cv.String()
+	}
+	d := s.cluster.DowngradeInfo()
+	resp.Enabled = d.Enabled
+	resp.TargetVersion = d.TargetVersion
+	return resp, nil
+}
+
+// validateDowngrade returns the major.minor target version if the cluster
+// version may be downgraded to ver.
+func (s *EtcdServer) validateDowngrade(ver string) (*semver.Version, error) {
+	if s.cluster.DowngradeInfo().Enabled {
+		return nil, ErrDowngradeInProcess
+	}
+	tv, err := semver.NewVersion(ver)
+	if err != nil {
+		// accept major.minor, as the cluster version is reported
+		if tv, err = semver.NewVersion(ver + ".0"); err != nil {
+			return nil, ErrInvalidDowngradeTargetVersion
+		}
+	}
+	tv = &semver.Version{Major: tv.Major, Minor: tv.Minor}
+	if !isValidDowngrade(s.ClusterVersion(), tv) {
+		return nil, ErrInvalidDowngradeTargetVersion
+	}
+	return tv, nil
+}
+
+// isValidDowngrade returns true if the cluster version cv may be downgraded
+// to tv, which must be one minor version below cv and no lower than the
+// minimal cluster version this binary runs with.
+func isValidDowngrade(cv, tv *semver.Version) bool {
+	minV := semver.Must(semver.NewVersion(version.MinClusterVersion))
+	return cv != nil && tv.Major == cv.Major && tv.Minor+1 == cv.Minor && !tv.LessThan(*minV)
+}
+
+func (s *EtcdServer) proposeDowngrade(ctx context.Context, d *membership.DowngradeInfo) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		plog.Panicf("marshal downgrade should never fail: %v", err)
+	}
+	req := pb.Request{
+		Method: "PUT",
+		Path:   membership.StoreDowngradeKey(),
+		Val:    string(b),
+	}
+	if _, err = s.Do(ctx, req); err != nil {
+		return err
+	}
+	// let the leader act on the downgrade without waiting for the next check
+	select {
+	case s.forceVersionC <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// monitorDowngrade moves the cluster version down to the target of an
+// enabled downgrade and ends the downgrade once every member runs the
+// target version. It returns false if no downgrade is enabled.
+func (s *EtcdServer) monitorDowngrade(vers map[string]*version.Versions) bool {
+	tv := s.cluster.DowngradeInfo().GetTargetVersion()
+	if tv == nil {
+		return false
+	}
+	if cv := s.cluster.Version(); cv != nil && tv.LessThan(*cv) {
+		s.goAttach(func() { s.updateClusterVersion(tv.String()) })
+		return true
+	}
+	if isMatchedVersions(tv, vers) {
+		plog.Infof("all members run version %s; the downgrade is finished", version.Cluster(tv.String()))
+		s.goAttach(func() {
+			ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+			if err := s.proposeDowngrade(ctx, &membership.DowngradeInfo{}); err != nil && err != ErrStopped {
+				plog.Errorf("error finishing the downgrade (%v)", err)
+			}
+			cancel()
+		})
+	}
+	return true
+}
+
+// isMatchedVersions returns true if every member in vers runs the major.minor
+// version tv.
+func isMatchedVersions(tv *semver.Version, vers map[string]*version.Versions) bool {
+	for _, ver := range vers {
+		if ver == nil {
+			return false
+		}
+		v, err := semver.NewVersion(ver.Server)
+		if err != nil || v.Major != tv.Major || v.Minor != tv.Minor {
+			return false
+		}
+	}
+	return true
+}
@@ -31,11 +31,26 @@ var (
 	ErrNoLeader                   = errors.New("etcdserver: no leader")
 	ErrNotLeader                  = errors.New("etcdserver: not leader")
 	ErrRequestTooLarge            = errors.New("etcdserver: request is too large")
+	ErrValueTooLarge              = errors.New("etcdserver: value is too large")
 	ErrNoSpace                    = errors.New("etcdserver: no space")
 	ErrTooManyRequests            = errors.New("etcdserver: too many requests")
 	ErrUnhealthy                  = errors.New("etcdserver: unhealthy cluster")
 	ErrKeyNotFound                = errors.New("etcdserver: key not found")
 	ErrCorrupt                    = errors.New("etcdserver: corrupt cluster")
+	ErrReadOnly                   = errors.New("etcdserver: member is read-only")
+	ErrPrefixQuotaExceeded        = errors.New("etcdserver: prefix quota exceeded")
+	ErrNoAutoCompaction           = errors.New("etcdserver: auto compaction is disabled")
+	ErrDegraded                   = errors.New("etcdserver: member is degraded by a backend write failure")
+	ErrMemoryBudgetExceeded       = errors.New("etcdserver: memory budget exceeded")
+	ErrTooManyClientConnections   = errors.New("etcdserver: too many connections for client")
+	ErrTooManyClientStreams       = errors.New("etcdserver: too many streams for client")
+	ErrTooManyClientWatchers      = errors.New("etcdserver: too many watchers for client")
+	ErrWitness                    = errors.New("etcdserver: member is a witness")
+	ErrClusterVersionTooLow       = errors.New("etcdserver: request is not supported by the cluster version")
+
+	ErrInvalidDowngradeTargetVersion = errors.New("etcdserver: invalid downgrade target version")
+	ErrDowngradeInProcess            = errors.New("etcdserver: cluster has a downgrade job in progress")
+	ErrNoInflightDowngrade           = errors.New("etcdserver: no inflight downgrade job")
 )
 
 type DiscoveryError struct {
@@ -40,6 +40,7 @@
 		WatchRequest
 		WatchCreateRequest
 		WatchCancelRequest
+		WatchAckRequest
 		WatchResponse
 		LeaseGrantRequest
 		LeaseGrantResponse
@@ -65,6 +66,8 @@
 		DefragmentResponse
 		MoveLeaderRequest
 		MoveLeaderResponse
+		DowngradeRequest
+		DowngradeResponse
 		AlarmRequest
 		AlarmMember
 		AlarmResponse
@@ -35,17 +35,19 @@ func (*RequestHeader) Descriptor() ([]byte, []int) { return fileDescriptorRaftIn
 // An InternalRaftRequest is the union of all requests which can be
 // sent via raft.
 type InternalRaftRequest struct {
-	Header                   *RequestHeader                   `protobuf:"bytes,100,opt,name=header" json:"header,omitempty"`
-	ID                       uint64                           `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	V2                       *Request                         `protobuf:"bytes,2,opt,name=v2" json:"v2,omitempty"`
-	Range                    *RangeRequest                    `protobuf:"bytes,3,opt,name=range" json:"range,omitempty"`
-	Put                      *PutRequest                      `protobuf:"bytes,4,opt,name=put" json:"put,omitempty"`
-	DeleteRange              *DeleteRangeRequest              `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange" json:"delete_range,omitempty"`
-	Txn                      *TxnRequest                      `protobuf:"bytes,6,opt,name=txn" json:"txn,omitempty"`
-	Compaction               *CompactionRequest               `protobuf:"bytes,7,opt,name=compaction" json:"compaction,omitempty"`
-	LeaseGrant               *LeaseGrantRequest               `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant" json:"lease_grant,omitempty"`
-	LeaseRevoke              *LeaseRevokeRequest              `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke" json:"lease_revoke,omitempty"`
-	Alarm                    *AlarmRequest                    `protobuf:"bytes,10,opt,name=alarm" json:"alarm,omitempty"`
+	Header      *RequestHeader      `protobuf:"bytes,100,opt,name=header" json:"header,omitempty"`
+	ID          uint64              `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	V2          *Request            `protobuf:"bytes,2,opt,name=v2" json:"v2,omitempty"`
+	Range       *RangeRequest       `protobuf:"bytes,3,opt,name=range" json:"range,omitempty"`
+	Put         *PutRequest         `protobuf:"bytes,4,opt,name=put" json:"put,omitempty"`
+	DeleteRange *DeleteRangeRequest `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange" json:"delete_range,omitempty"`
+	Txn         *TxnRequest         `protobuf:"bytes,6,opt,name=txn" json:"txn,omitempty"`
+	Compaction  *CompactionRequest  `protobuf:"bytes,7,opt,name=compaction" json:"compaction,omitempty"`
+	LeaseGrant  *LeaseGrantRequest  `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant" json:"lease_grant,omitempty"`
+	LeaseRevoke *LeaseRevokeRequest `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke" json:"lease_revoke,omitempty"`
+	Alarm       *AlarmRequest       `protobuf:"bytes,10,opt,name=alarm" json:"alarm,omitempty"`
+	// expire is a txn deleting expired keys, issued by the leader.
+	Expire                   *TxnRequest                      `protobuf:"bytes,11,opt,name=expire" json:"expire,omitempty"`
 	AuthEnable               *AuthEnableRequest               `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable" json:"auth_enable,omitempty"`
 	AuthDisable              *AuthDisableRequest              `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable" json:"auth_disable,omitempty"`
 	Authenticate             *InternalAuthenticateRequest     `protobuf:"bytes,1012,opt,name=authenticate" json:"authenticate,omitempty"`
@@ -244,17 +246,27 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n9
 	}
+	if m.Expire != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Expire.Size()))
+		n10, err := m.Expire.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
 	if m.Header != nil {
 		dAtA[i] = 0xa2
 		i++
 		dAtA[i] = 0x6
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Header.Size()))
-		n10, err := m.Header.MarshalTo(dAtA[i:])
+		n11, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n11
 	}
 	if m.AuthEnable != nil {
 		dAtA[i] = 0xc2
@@ -262,11 +274,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3e
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthEnable.Size()))
-		n11, err := m.AuthEnable.MarshalTo(dAtA[i:])
+		n12, err := m.AuthEnable.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
 	if m.AuthDisable != nil {
 		dAtA[i] = 0x9a
@@ -274,11 +286,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3f
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthDisable.Size()))
-		n12, err := m.AuthDisable.MarshalTo(dAtA[i:])
+		n13, err := m.AuthDisable.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n13
 	}
 	if m.Authenticate != nil {
 		dAtA[i] = 0xa2
@@ -286,11 +298,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3f
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Authenticate.Size()))
-		n13, err := m.Authenticate.MarshalTo(dAtA[i:])
+		n14, err := m.Authenticate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
 	}
 	if m.AuthUserAdd != nil {
 		dAtA[i] = 0xe2
@@ -298,11 +310,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserAdd.Size()))
-		n14, err := m.AuthUserAdd.MarshalTo(dAtA[i:])
+		n15, err := m.AuthUserAdd.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n15
 	}
 	if m.AuthUserDelete != nil {
 		dAtA[i] = 0xea
@@ -310,11 +322,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserDelete.Size()))
-		n15, err := m.AuthUserDelete.MarshalTo(dAtA[i:])
+		n16, err := m.AuthUserDelete.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n16
 	}
 	if m.AuthUserGet != nil {
 		dAtA[i] = 0xf2
@@ -322,11 +334,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserGet.Size()))
-		n16, err := m.AuthUserGet.MarshalTo(dAtA[i:])
+		n17, err := m.AuthUserGet.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n17
 	}
 	if m.AuthUserChangePassword != nil {
 		dAtA[i] = 0xfa
@@ -334,11 +346,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserChangePassword.Size()))
-		n17, err := m.AuthUserChangePassword.MarshalTo(dAtA[i:])
+		n18, err := m.AuthUserChangePassword.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n18
 	}
 	if m.AuthUserGrantRole != nil {
 		dAtA[i] = 0x82
@@ -346,11 +358,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserGrantRole.Size()))
-		n18, err := m.AuthUserGrantRole.MarshalTo(dAtA[i:])
+		n19, err := m.AuthUserGrantRole.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n19
 	}
 	if m.AuthUserRevokeRole != nil {
 		dAtA[i] = 0x8a
@@ -358,11 +370,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserRevokeRole.Size()))
-		n19, err := m.AuthUserRevokeRole.MarshalTo(dAtA[i:])
+		n20, err := m.AuthUserRevokeRole.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n20
 	}
 	if m.AuthUserList != nil {
 		dAtA[i] = 0x92
@@ -370,11 +382,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserList.Size()))
-		n20, err := m.AuthUserList.MarshalTo(dAtA[i:])
+		n21, err := m.AuthUserList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n21
 	}
 	if m.AuthRoleList != nil {
 		dAtA[i] = 0x9a
@@ -382,11 +394,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleList.Size()))
-		n21, err := m.AuthRoleList.MarshalTo(dAtA[i:])
+		n22, err := m.AuthRoleList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n22
 	}
 	if m.AuthRoleAdd != nil {
 		dAtA[i] = 0x82
@@ -394,11 +406,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleAdd.Size()))
-		n22, err := m.AuthRoleAdd.MarshalTo(dAtA[i:])
+		n23, err := m.AuthRoleAdd.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n23
 	}
 	if m.AuthRoleDelete != nil {
 		dAtA[i] = 0x8a
@@ -406,11 +418,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleDelete.Size()))
-		n23, err := m.AuthRoleDelete.MarshalTo(dAtA[i:])
+		n24, err := m.AuthRoleDelete.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n23
+		i += n24
 	}
 	if m.AuthRoleGet != nil {
 		dAtA[i] = 0x92
@@ -418,11 +430,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleGet.Size()))
-		n24, err := m.AuthRoleGet.MarshalTo(dAtA[i:])
+		n25, err := m.AuthRoleGet.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n25
 	}
 	if m.AuthRoleGrantPermission != nil {
 		dAtA[i] = 0x9a
@@ -430,11 +442,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleGrantPermission.Size()))
-		n25, err := m.AuthRoleGrantPermission.MarshalTo(dAtA[i:])
+		n26, err := m.AuthRoleGrantPermission.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n26
 	}
 	if m.AuthRoleRevokePermission != nil {
 		dAtA[i] = 0xa2
@@ -442,11 +454,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleRevokePermission.Size()))
-		n26, err := m.AuthRoleRevokePermission.MarshalTo(dAtA[i:])
+		n27, err := m.AuthRoleRevokePermission.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n27
 	}
 	return i, nil
 }
@@ -590,6 +602,10 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.Alarm.Size()
 		n += 1 + l + sovRaftInternal(uint64(l))
 	}
+	if m.Expire != nil {
+		l = m.Expire.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -1160,6 +1176,39 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expire", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expire == nil {
+				m.Expire = &TxnRequest{}
+			}
+			if err := m.Expire.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
@@ -2037,58 +2086,59 @@ var (
 func init() { proto.RegisterFile("raft_internal.proto", fileDescriptorRaftInternal) }
 
 var fileDescriptorRaftInternal = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x96, 0xdd, 0x52, 0xd3, 0x40,
-	0x14, 0xc7, 0x69, 0xf9, 0xec, 0xb6, 0x54, 0x5c, 0x40, 0xd7, 0x32, 0x53, 0xa1, 0x8c, 0x8a, 0x5f,
-	0xe8, 0x94, 0x07, 0xd0, 0x4a, 0x19, 0x60, 0x86, 0x61, 0x98, 0x0c, 0xce, 0x38, 0xe3, 0x45, 0x5c,
-	0x9a, 0x43, 0x1b, 0x49, 0x93, 0xb8, 0xd9, 0x56, 0x7c, 0x13, 0x1f, 0xc3, 0xaf, 0x87, 0xe0, 0xc2,
-	0x0f, 0xd4, 0x17, 0x50, 0xbc, 0xf1, 0xca, 0x1b, 0x7d, 0x00, 0x67, 0x3f, 0x92, 0x34, 0x6d, 0xca,
-	0x5d, 0x72, 0xce, 0xff, 0xfc, 0xce, 0xd9, 0xec, 0x7f, 0xbb, 0x45, 0xb3, 0x8c, 0x1e, 0x72, 0xd3,
-	0x76, 0x39, 0x30, 0x97, 0x3a, 0xab, 0x3e, 0xf3, 0xb8, 0x87, 0x0b, 0xc0, 0x1b, 0x56, 0x00, 0xac,
-	0x0b, 0xcc, 0x3f, 0x28, 0xcd, 0x35, 0xbd, 0xa6, 0x27, 0x13, 0xf7, 0xc4, 0x93, 0xd2, 0x94, 0x66,
-	0x62, 0x8d, 0x8e, 0xe4, 0x98, 0xdf, 0x50, 0x8f, 0x95, 0x67, 0x68, 0xda, 0x80, 0x17, 0x1d, 0x08,
-	0xf8, 0x16, 0x50, 0x0b, 0x18, 0x2e, 0xa2, 0xec, 0x76, 0x9d, 0x64, 0x16, 0x33, 0x2b, 0x63, 0x46,
-	0x76, 0xbb, 0x8e, 0x4b, 0x68, 0xaa, 0x13, 0x88, 0x96, 0x6d, 0x20, 0xd9, 0xc5, 0xcc, 0x4a, 0xce,
-	0x88, 0xde, 0xf1, 0x32, 0x9a, 0xa6, 0x1d, 0xde, 0x32, 0x19, 0x74, 0xed, 0xc0, 0xf6, 0x5c, 0x32,
-	0x2a, 0xcb, 0x0a, 0x22, 0x68, 0xe8, 0x58, 0xe5, 0x4f, 0x11, 0xcd, 0x6e, 0xeb, 0xa9, 0x0d, 0x7a,
-	0xc8, 0x75, 0xbb, 0x81, 0x46, 0xd7, 0x50, 0xb6, 0x5b, 0x95, 0x2d, 0xf2, 0xd5, 0xf9, 0xd5, 0xde,
-	0x75, 0xad, 0xea, 0x12, 0x23, 0xdb, 0xad, 0xe2, 0xfb, 0x68, 0x9c, 0x51, 0xb7, 0x09, 0xb2, 0x57,
-	0xbe, 0x5a, 0xea, 0x53, 0x8a, 0x54, 0x28, 0x57, 0x42, 0x7c, 0x0b, 0x8d, 0xfa, 0x1d, 0x4e, 0xc6,
-	0xa4, 0x9e, 0x24, 0xf5, 0x7b, 0x9d, 0x70, 0x1e, 0x43, 0x88, 0xf0, 0x3a, 0x2a, 0x58, 0xe0, 0x00,
-	0x07, 0x53, 0x35, 0x19, 0x97, 0x45, 0x8b, 0xc9, 0xa2, 0xba, 0x54, 0x24, 0x5a, 0xe5, 0xad, 0x38,
-	0x26, 0x1a, 0xf2, 0x63, 0x97, 0x4c, 0xa4, 0x35, 0xdc, 0x3f, 0x76, 0xa3, 0x86, 0xfc, 0xd8, 0xc5,
-	0x0f, 0x10, 0x6a, 0x78, 0x6d, 0x9f, 0x36, 0xb8, 0xf8, 0x7e, 0x93, 0xb2, 0xe4, 0x6a, 0xb2, 0x64,
-	0x3d, 0xca, 0x87, 0x95, 0x3d, 0x25, 0xf8, 0x21, 0xca, 0x3b, 0x40, 0x03, 0x30, 0x9b, 0x8c, 0xba,
-	0x9c, 0x4c, 0xa5, 0x11, 0x76, 0x84, 0x60, 0x53, 0xe4, 0x23, 0x82, 0x13, 0x85, 0xc4, 0x9a, 0x15,
-	0x81, 0x41, 0xd7, 0x3b, 0x02, 0x92, 0x4b, 0x5b, 0xb3, 0x44, 0x18, 0x52, 0x10, 0xad, 0xd9, 0x89,
-	0x63, 0x62, 0x5b, 0xa8, 0x43, 0x59, 0x9b, 0xa0, 0xb4, 0x6d, 0xa9, 0x89, 0x54, 0xb4, 0x2d, 0x52,
-	0x88, 0xd7, 0xd0, 0x44, 0x4b, 0x5a, 0x8e, 0x58, 0xb2, 0x64, 0x21, 0x75, 0xcf, 0x95, 0x2b, 0x0d,
-	0x2d, 0xc5, 0x35, 0x94, 0x97, 0x8e, 0x03, 0x97, 0x1e, 0x38, 0x40, 0x7e, 0xa7, 0x7e, 0xb0, 0x5a,
-	0x87, 0xb7, 0x36, 0xa4, 0x20, 0x5a, 0x2e, 0x8d, 0x42, 0xb8, 0x8e, 0xa4, 0x3f, 0x4d, 0xcb, 0x0e,
-	0x24, 0xe3, 0xef, 0x64, 0xda, 0x7a, 0x05, 0xa3, 0xae, 0x14, 0xd1, 0x7a, 0x69, 0x1c, 0xc3, 0xbb,
-	0x8a, 0x02, 0x2e, 0xb7, 0x1b, 0x94, 0x03, 0xf9, 0xa7, 0x28, 0x37, 0x93, 0x94, 0xd0, 0xf7, 0xb5,
-	0x1e, 0x69, 0x88, 0x4b, 0xd4, 0xe3, 0x0d, 0x7d, 0x94, 0xc4, 0xd9, 0x32, 0xa9, 0x65, 0x91, 0x8f,
-	0x53, 0xc3, 0xc6, 0x7a, 0x1c, 0x00, 0xab, 0x59, 0x56, 0x62, 0x2c, 0x1d, 0xc3, 0xbb, 0x68, 0x26,
-	0xc6, 0x28, 0x4f, 0x92, 0x4f, 0x8a, 0xb4, 0x9c, 0x4e, 0xd2, 0x66, 0xd6, 0xb0, 0x22, 0x4d, 0x84,
-	0x93, 0x63, 0x35, 0x81, 0x93, 0xcf, 0xe7, 0x8e, 0xb5, 0x09, 0x7c, 0x60, 0xac, 0x4d, 0xe0, 0xb8,
-	0x89, 0xae, 0xc4, 0x98, 0x46, 0x4b, 0x9c, 0x12, 0xd3, 0xa7, 0x41, 0xf0, 0xd2, 0x63, 0x16, 0xf9,
-	0xa2, 0x90, 0xb7, 0xd3, 0x91, 0xeb, 0x52, 0xbd, 0xa7, 0xc5, 0x21, 0xfd, 0x12, 0x4d, 0x4d, 0xe3,
-	0x27, 0x68, 0xae, 0x67, 0x5e, 0x61, 0x6f, 0x93, 0x79, 0x0e, 0x90, 0x53, 0xd5, 0xe3, 0xfa, 0x90,
-	0xb1, 0xe5, 0xd1, 0xf0, 0xe2, 0xad, 0xbe, 0x48, 0xfb, 0x33, 0xf8, 0x29, 0x9a, 0x8f, 0xc9, 0xea,
-	0xa4, 0x28, 0xf4, 0x57, 0x85, 0xbe, 0x91, 0x8e, 0xd6, 0x47, 0xa6, 0x87, 0x8d, 0xe9, 0x40, 0x0a,
-	0x6f, 0xa1, 0x62, 0x0c, 0x77, 0xec, 0x80, 0x93, 0x6f, 0x8a, 0xba, 0x94, 0x4e, 0xdd, 0xb1, 0x03,
-	0x9e, 0xf0, 0x51, 0x18, 0x8c, 0x48, 0x62, 0x34, 0x45, 0xfa, 0x3e, 0x94, 0x24, 0x5a, 0x0f, 0x90,
-	0xc2, 0x60, 0xb4, 0xf5, 0x92, 0x24, 0x1c, 0xf9, 0x26, 0x37, 0x6c, 0xeb, 0x45, 0x4d, 0xbf, 0x23,
-	0x75, 0x2c, 0x72, 0xa4, 0xc4, 0x68, 0x47, 0xbe, 0xcd, 0x0d, 0x73, 0xa4, 0xa8, 0x4a, 0x71, 0x64,
-	0x1c, 0x4e, 0x8e, 0x25, 0x1c, 0xf9, 0xee, 0xdc, 0xb1, 0xfa, 0x1d, 0xa9, 0x63, 0xf8, 0x39, 0x2a,
-	0xf5, 0x60, 0xa4, 0x51, 0x7c, 0x60, 0x6d, 0x3b, 0x90, 0xf7, 0xd8, 0x7b, 0xc5, 0xbc, 0x33, 0x84,
-	0x29, 0xe4, 0x7b, 0x91, 0x3a, 0xe4, 0x5f, 0xa6, 0xe9, 0x79, 0xdc, 0x46, 0x0b, 0x71, 0x2f, 0x6d,
-	0x9d, 0x9e, 0x66, 0x1f, 0x54, 0xb3, 0xbb, 0xe9, 0xcd, 0x94, 0x4b, 0x06, 0xbb, 0x11, 0x3a, 0x44,
-	0x50, 0xb9, 0x80, 0xa6, 0x37, 0xda, 0x3e, 0x7f, 0x65, 0x40, 0xe0, 0x7b, 0x6e, 0x00, 0x15, 0x1f,
-	0x2d, 0x9c, 0xf3, 0x43, 0x84, 0x31, 0x1a, 0x93, 0xb7, 0x7b, 0x46, 0xde, 0xee, 0xf2, 0x59, 0xdc,
-	0xfa, 0xd1, 0xf9, 0xd4, 0xb7, 0x7e, 0xf8, 0x8e, 0x97, 0x50, 0x21, 0xb0, 0xdb, 0xbe, 0x03, 0x26,
-	0xf7, 0x8e, 0x40, 0x5d, 0xfa, 0x39, 0x23, 0xaf, 0x62, 0xfb, 0x22, 0xf4, 0x68, 0xee, 0xe4, 0x67,
-	0x79, 0xe4, 0xe4, 0xac, 0x9c, 0x39, 0x3d, 0x2b, 0x67, 0x7e, 0x9c, 0x95, 0x33, 0xaf, 0x7f, 0x95,
-	0x47, 0x0e, 0x26, 0xe4, 0x5f, 0x8e, 0xb5, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xff, 0xc9, 0xfc,
-	0x0e, 0xca, 0x08, 0x00, 0x00,
+	// 857 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x96, 0xdd, 0x4e, 0xdb, 0x48,
+	0x14, 0xc7, 0x49, 0x80, 0x40, 0x26, 0xe1, 0x63, 0x07, 0xd8, 0x9d, 0x0d, 0x52, 0x16, 0x82, 0x76,
+	0x97, 0xdd, 0xb6, 0x14, 0x85, 0x07, 0x68, 0x53, 0x82, 0x00, 0x09, 0x21, 0x64, 0x51, 0xa9, 0x52,
+	0x2f, 0xdc, 0x21, 0x3e, 0x24, 0x2e, 0x8e, 0xed, 0x8e, 0x27, 0x69, 0xfa, 0x26, 0xbd, 0xec, 0x23,
+	0xf4, 0xeb, 0x21, 0xb8, 0xe8, 0x07, 0x6d, 0x5f, 0xa0, 0xa5, 0x37, 0xbd, 0x6f, 0x1f, 0xa0, 0x9a,
+	0x0f, 0xdb, 0x71, 0xe2, 0xd0, 0x3b, 0xe7, 0xcc, 0xff, 0xfc, 0xce, 0x99, 0x99, 0xff, 0x81, 0x41,
+	0x0b, 0x8c, 0x9e, 0x72, 0xd3, 0x76, 0x39, 0x30, 0x97, 0x3a, 0x1b, 0x3e, 0xf3, 0xb8, 0x87, 0x8b,
+	0xc0, 0x1b, 0x56, 0x00, 0xac, 0x0b, 0xcc, 0x3f, 0x29, 0x2d, 0x36, 0xbd, 0xa6, 0x27, 0x17, 0x6e,
+	0x8a, 0x2f, 0xa5, 0x29, 0xcd, 0xc7, 0x1a, 0x1d, 0xc9, 0x33, 0xbf, 0xa1, 0x3e, 0x2b, 0x0f, 0xd0,
+	0x8c, 0x01, 0x8f, 0x3a, 0x10, 0xf0, 0x3d, 0xa0, 0x16, 0x30, 0x3c, 0x8b, 0xb2, 0xfb, 0x75, 0x92,
+	0x59, 0xc9, 0xac, 0x4f, 0x18, 0xd9, 0xfd, 0x3a, 0x2e, 0xa1, 0xe9, 0x4e, 0x20, 0x4a, 0xb6, 0x81,
+	0x64, 0x57, 0x32, 0xeb, 0x79, 0x23, 0xfa, 0x8d, 0xd7, 0xd0, 0x0c, 0xed, 0xf0, 0x96, 0xc9, 0xa0,
+	0x6b, 0x07, 0xb6, 0xe7, 0x92, 0x71, 0x99, 0x56, 0x14, 0x41, 0x43, 0xc7, 0x2a, 0xcf, 0xe6, 0xd0,
+	0xc2, 0xbe, 0xee, 0xda, 0xa0, 0xa7, 0x5c, 0x97, 0x1b, 0x2a, 0xf4, 0x37, 0xca, 0x76, 0xab, 0xb2,
+	0x44, 0xa1, 0xba, 0xb4, 0xd1, 0xbf, 0xaf, 0x0d, 0x9d, 0x62, 0x64, 0xbb, 0x55, 0xbc, 0x89, 0x26,
+	0x19, 0x75, 0x9b, 0x20, 0x6b, 0x15, 0xaa, 0xa5, 0x01, 0xa5, 0x58, 0x0a, 0xe5, 0x4a, 0x88, 0xff,
+	0x47, 0xe3, 0x7e, 0x87, 0x93, 0x09, 0xa9, 0x27, 0x49, 0xfd, 0x51, 0x27, 0xec, 0xc7, 0x10, 0x22,
+	0xbc, 0x8d, 0x8a, 0x16, 0x38, 0xc0, 0xc1, 0x54, 0x45, 0x26, 0x65, 0xd2, 0x4a, 0x32, 0xa9, 0x2e,
+	0x15, 0x89, 0x52, 0x05, 0x2b, 0x8e, 0x89, 0x82, 0xbc, 0xe7, 0x92, 0x5c, 0x5a, 0xc1, 0xe3, 0x9e,
+	0x1b, 0x15, 0xe4, 0x3d, 0x17, 0xdf, 0x42, 0xa8, 0xe1, 0xb5, 0x7d, 0xda, 0xe0, 0xe2, 0xfc, 0xa6,
+	0x64, 0xca, 0x5f, 0xc9, 0x94, 0xed, 0x68, 0x3d, 0xcc, 0xec, 0x4b, 0xc1, 0xb7, 0x51, 0xc1, 0x01,
+	0x1a, 0x80, 0xd9, 0x64, 0xd4, 0xe5, 0x64, 0x3a, 0x8d, 0x70, 0x20, 0x04, 0xbb, 0x62, 0x3d, 0x22,
+	0x38, 0x51, 0x48, 0xec, 0x59, 0x11, 0x18, 0x74, 0xbd, 0x33, 0x20, 0xf9, 0xb4, 0x3d, 0x4b, 0x84,
+	0x21, 0x05, 0xd1, 0x9e, 0x9d, 0x38, 0x26, 0xae, 0x85, 0x3a, 0x94, 0xb5, 0x09, 0x4a, 0xbb, 0x96,
+	0x9a, 0x58, 0x8a, 0xae, 0x45, 0x0a, 0xf1, 0x26, 0xca, 0x41, 0xcf, 0xb7, 0x19, 0x90, 0xc2, 0x2f,
+	0x0e, 0x4a, 0xeb, 0xf0, 0x16, 0xca, 0xb5, 0xa4, 0x49, 0x89, 0x25, 0x33, 0x96, 0x53, 0x5d, 0xa2,
+	0x7c, 0x6c, 0x68, 0x29, 0xae, 0xa1, 0x82, 0xf4, 0x28, 0xb8, 0xf4, 0xc4, 0x01, 0xf2, 0x2d, 0xf5,
+	0x88, 0x6b, 0x1d, 0xde, 0xda, 0x91, 0x82, 0xe8, 0x80, 0x68, 0x14, 0xc2, 0x75, 0x24, 0x1d, 0x6d,
+	0x5a, 0x76, 0x20, 0x19, 0xdf, 0xa7, 0xd2, 0x4e, 0x48, 0x30, 0xea, 0x4a, 0x11, 0x9d, 0x10, 0x8d,
+	0x63, 0xf8, 0x50, 0x51, 0xc0, 0xe5, 0x76, 0x83, 0x72, 0x20, 0x3f, 0x14, 0xe5, 0xbf, 0x24, 0x25,
+	0x9c, 0x94, 0x5a, 0x9f, 0x34, 0xc4, 0x25, 0xf2, 0xf1, 0x8e, 0x1e, 0x3e, 0x31, 0x8d, 0x26, 0xb5,
+	0x2c, 0xf2, 0x66, 0x7a, 0x54, 0x5b, 0x77, 0x03, 0x60, 0x35, 0xcb, 0x4a, 0xb4, 0xa5, 0x63, 0xf8,
+	0x10, 0xcd, 0xc7, 0x18, 0xe5, 0x62, 0xf2, 0x56, 0x91, 0xd6, 0xd2, 0x49, 0xda, 0xfe, 0x1a, 0x36,
+	0x4b, 0x13, 0xe1, 0x64, 0x5b, 0x4d, 0xe0, 0xe4, 0xdd, 0x95, 0x6d, 0xed, 0x02, 0x1f, 0x6a, 0x6b,
+	0x17, 0x38, 0x6e, 0xa2, 0x3f, 0x63, 0x4c, 0xa3, 0x25, 0xe6, 0xca, 0xf4, 0x69, 0x10, 0x3c, 0xf6,
+	0x98, 0x45, 0xde, 0x2b, 0xe4, 0xb5, 0x74, 0xe4, 0xb6, 0x54, 0x1f, 0x69, 0x71, 0x48, 0xff, 0x9d,
+	0xa6, 0x2e, 0xe3, 0x7b, 0x68, 0xb1, 0xaf, 0x5f, 0x31, 0x10, 0x26, 0xf3, 0x1c, 0x20, 0x17, 0xaa,
+	0xc6, 0x3f, 0x23, 0xda, 0x96, 0xc3, 0xe4, 0xc5, 0x57, 0xfd, 0x1b, 0x1d, 0x5c, 0xc1, 0xf7, 0xd1,
+	0x52, 0x4c, 0x56, 0xb3, 0xa5, 0xd0, 0x1f, 0x14, 0xfa, 0xdf, 0x74, 0xb4, 0x1e, 0xb2, 0x3e, 0x36,
+	0xa6, 0x43, 0x4b, 0x78, 0x0f, 0xcd, 0xc6, 0x70, 0xc7, 0x0e, 0x38, 0xf9, 0xa8, 0xa8, 0xab, 0xe9,
+	0xd4, 0x03, 0x3b, 0xe0, 0x09, 0x1f, 0x85, 0xc1, 0x88, 0x24, 0x5a, 0x53, 0xa4, 0x4f, 0x23, 0x49,
+	0xa2, 0xf4, 0x10, 0x29, 0x0c, 0x46, 0x57, 0x2f, 0x49, 0xc2, 0x91, 0xcf, 0xf3, 0xa3, 0xae, 0x5e,
+	0xe4, 0x0c, 0x3a, 0x52, 0xc7, 0x22, 0x47, 0x4a, 0x8c, 0x76, 0xe4, 0x8b, 0xfc, 0x28, 0x47, 0x8a,
+	0xac, 0x14, 0x47, 0xc6, 0xe1, 0x64, 0x5b, 0xc2, 0x91, 0x2f, 0xaf, 0x6c, 0x6b, 0xd0, 0x91, 0x3a,
+	0x86, 0x1f, 0xa2, 0x52, 0x1f, 0x46, 0x1a, 0xc5, 0x07, 0xd6, 0xb6, 0x03, 0xf9, 0x9f, 0xef, 0x95,
+	0x62, 0x5e, 0x1f, 0xc1, 0x14, 0xf2, 0xa3, 0x48, 0x1d, 0xf2, 0xff, 0xa0, 0xe9, 0xeb, 0xb8, 0x8d,
+	0x96, 0xe3, 0x5a, 0xda, 0x3a, 0x7d, 0xc5, 0x5e, 0xab, 0x62, 0x37, 0xd2, 0x8b, 0x29, 0x97, 0x0c,
+	0x57, 0x23, 0x74, 0x84, 0xa0, 0x32, 0x87, 0x66, 0x76, 0xda, 0x3e, 0x7f, 0x62, 0x40, 0xe0, 0x7b,
+	0x6e, 0x00, 0x15, 0x1f, 0x2d, 0x5f, 0xf1, 0x87, 0x08, 0x63, 0x34, 0x21, 0xdf, 0x03, 0x19, 0xf9,
+	0x1e, 0x90, 0xdf, 0xe2, 0x9d, 0x10, 0xcd, 0xa7, 0x7e, 0x27, 0x84, 0xbf, 0xf1, 0x2a, 0x2a, 0x06,
+	0x76, 0xdb, 0x77, 0xc0, 0xe4, 0xde, 0x19, 0xa8, 0x67, 0x42, 0xde, 0x28, 0xa8, 0xd8, 0xb1, 0x08,
+	0xdd, 0x59, 0x3c, 0xff, 0x52, 0x1e, 0x3b, 0xbf, 0x2c, 0x67, 0x2e, 0x2e, 0xcb, 0x99, 0xcf, 0x97,
+	0xe5, 0xcc, 0xd3, 0xaf, 0xe5, 0xb1, 0x93, 0x9c, 0x7c, 0xa4, 0x6c, 0xfd, 0x0c, 0x00, 0x00, 0xff,
+	0xff, 0x4a, 0x27, 0xdb, 0x1e, 0xfc, 0x08, 0x00, 0x00,
 }
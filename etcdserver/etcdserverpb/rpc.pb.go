@@ -33,17 +33,20 @@ const (
 	AlarmType_NONE    AlarmType = 0
 	AlarmType_NOSPACE AlarmType = 1
 	AlarmType_CORRUPT AlarmType = 2
+	AlarmType_IOERROR AlarmType = 3
 )
 
 var AlarmType_name = map[int32]string{
 	0: "NONE",
 	1: "NOSPACE",
 	2: "CORRUPT",
+	3: "IOERROR",
 }
 var AlarmType_value = map[string]int32{
 	"NONE":    0,
 	"NOSPACE": 1,
 	"CORRUPT": 2,
+	"IOERROR": 3,
 }
 
 func (x AlarmType) String() string {
@@ -169,15 +172,19 @@ const (
 	WatchCreateRequest_NOPUT WatchCreateRequest_FilterType = 0
 	// filter out delete event.
 	WatchCreateRequest_NODELETE WatchCreateRequest_FilterType = 1
+	// filter out delete event of keys deleted by revoking their lease.
+	WatchCreateRequest_NOLEASEDELETE WatchCreateRequest_FilterType = 2
 )
 
 var WatchCreateRequest_FilterType_name = map[int32]string{
 	0: "NOPUT",
 	1: "NODELETE",
+	2: "NOLEASEDELETE",
 }
 var WatchCreateRequest_FilterType_value = map[string]int32{
-	"NOPUT":    0,
-	"NODELETE": 1,
+	"NOPUT":         0,
+	"NODELETE":      1,
+	"NOLEASEDELETE": 2,
 }
 
 func (x WatchCreateRequest_FilterType) String() string {
@@ -187,6 +194,35 @@ func (WatchCreateRequest_FilterType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptorRpc, []int{21, 0}
 }
 
+type DowngradeRequest_DowngradeAction int32
+
+const (
+	DowngradeRequest_STATUS   DowngradeRequest_DowngradeAction = 0
+	DowngradeRequest_VALIDATE DowngradeRequest_DowngradeAction = 1
+	DowngradeRequest_ENABLE   DowngradeRequest_DowngradeAction = 2
+	DowngradeRequest_CANCEL   DowngradeRequest_DowngradeAction = 3
+)
+
+var DowngradeRequest_DowngradeAction_name = map[int32]string{
+	0: "STATUS",
+	1: "VALIDATE",
+	2: "ENABLE",
+	3: "CANCEL",
+}
+var DowngradeRequest_DowngradeAction_value = map[string]int32{
+	"STATUS":   0,
+	"VALIDATE": 1,
+	"ENABLE":   2,
+	"CANCEL":   3,
+}
+
+func (x DowngradeRequest_DowngradeAction) String() string {
+	return proto.EnumName(DowngradeRequest_DowngradeAction_name, int32(x))
+}
+func (DowngradeRequest_DowngradeAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorRpc, []int{49, 0}
+}
+
 type AlarmRequest_AlarmAction int32
 
 const (
@@ -210,7 +246,7 @@ func (x AlarmRequest_AlarmAction) String() string {
 	return proto.EnumName(AlarmRequest_AlarmAction_name, int32(x))
 }
 func (AlarmRequest_AlarmAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{48, 0}
+	return fileDescriptorRpc, []int{51, 0}
 }
 
 type ResponseHeader struct {
@@ -300,6 +336,11 @@ type RangeRequest struct {
 	// max_create_revision is the upper bound for returned key create revisions; all keys with
 	// greater create revisions will be filtered away.
 	MaxCreateRevision int64 `protobuf:"varint,13,opt,name=max_create_revision,json=maxCreateRevision,proto3" json:"max_create_revision,omitempty"`
+	// index is the name of an experimental value index to range by; only the
+	// keys in [key, range_end) whose indexed field equals index_value are returned.
+	Index string `protobuf:"bytes,14,opt,name=index,proto3" json:"index,omitempty"`
+	// index_value is the value of the indexed field to match.
+	IndexValue []byte `protobuf:"bytes,15,opt,name=index_value,json=indexValue,proto3" json:"index_value,omitempty"`
 }
 
 func (m *RangeRequest) Reset()                    { *m = RangeRequest{} }
@@ -398,6 +439,20 @@ func (m *RangeRequest) GetMaxCreateRevision() int64 {
 	return 0
 }
 
+func (m *RangeRequest) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *RangeRequest) GetIndexValue() []byte {
+	if m != nil {
+		return m.IndexValue
+	}
+	return nil
+}
+
 type RangeResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// kvs is the list of key-value pairs matched by the range request.
@@ -459,6 +514,22 @@ type PutRequest struct {
 	// If ignore_lease is set, etcd updates the key using its current lease.
 	// Returns an error if the key does not exist.
 	IgnoreLease bool `protobuf:"varint,6,opt,name=ignore_lease,json=ignoreLease,proto3" json:"ignore_lease,omitempty"`
+	// expire_at is the unix time, in seconds, at which the key expires and is deleted.
+	// An expire_at of 0 indicates the key does not expire.
+	ExpireAt int64 `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	// If expected_version is set, the put is only applied if the key has the given version;
+	// an expected_version of -1 expects the key to not exist. On mismatch, conflict is set
+	// in the response and the key is left unchanged.
+	ExpectedVersion int64 `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// If expected_mod_revision is set, the put is only applied if the key was last modified
+	// at the given revision; an expected_mod_revision of -1 expects the key to not exist.
+	// On mismatch, conflict is set in the response and the key is left unchanged.
+	ExpectedModRevision int64 `protobuf:"varint,9,opt,name=expected_mod_revision,json=expectedModRevision,proto3" json:"expected_mod_revision,omitempty"`
+	// If dry_run is set, etcd evaluates the put, including permission, quota and
+	// admission checks, and returns the response it would have returned without
+	// applying it. The header revision is the revision the put would have created.
+	// dry_run is ignored on puts within a txn.
+	DryRun bool `protobuf:"varint,10,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (m *PutRequest) Reset()                    { *m = PutRequest{} }
@@ -508,10 +579,42 @@ func (m *PutRequest) GetIgnoreLease() bool {
 	return false
 }
 
+func (m *PutRequest) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
+func (m *PutRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *PutRequest) GetExpectedModRevision() int64 {
+	if m != nil {
+		return m.ExpectedModRevision
+	}
+	return 0
+}
+
+func (m *PutRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type PutResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// if prev_kv is set in the request, the previous key-value pair will be returned.
 	PrevKv *mvccpb.KeyValue `protobuf:"bytes,2,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+	// conflict is set if the key did not match the expected version or mod revision of the
+	// request, so the put was not applied. The current key-value pair, if the key exists,
+	// is returned in prev_kv.
+	Conflict bool `protobuf:"varint,3,opt,name=conflict,proto3" json:"conflict,omitempty"`
 }
 
 func (m *PutResponse) Reset()                    { *m = PutResponse{} }
@@ -533,6 +636,13 @@ func (m *PutResponse) GetPrevKv() *mvccpb.KeyValue {
 	return nil
 }
 
+func (m *PutResponse) GetConflict() bool {
+	if m != nil {
+		return m.Conflict
+	}
+	return false
+}
+
 type DeleteRangeRequest struct {
 	// key is the first key to delete in the range.
 	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -545,6 +655,11 @@ type DeleteRangeRequest struct {
 	// If prev_kv is set, etcd gets the previous key-value pairs before deleting it.
 	// The previous key-value pairs will be returned in the delete response.
 	PrevKv bool `protobuf:"varint,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// If dry_run is set, etcd evaluates the delete, including permission and admission
+	// checks, and returns the response it would have returned without applying it. The
+	// header revision is the revision the delete would have created.
+	// dry_run is ignored on deletes within a txn.
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (m *DeleteRangeRequest) Reset()                    { *m = DeleteRangeRequest{} }
@@ -573,6 +688,13 @@ func (m *DeleteRangeRequest) GetPrevKv() bool {
 	return false
 }
 
+func (m *DeleteRangeRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type DeleteRangeResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// deleted is the number of keys deleted by the delete range request.
@@ -1236,6 +1358,11 @@ type TxnRequest struct {
 	Success []*RequestOp `protobuf:"bytes,2,rep,name=success" json:"success,omitempty"`
 	// failure is a list of requests which will be applied when compare evaluates to false.
 	Failure []*RequestOp `protobuf:"bytes,3,rep,name=failure" json:"failure,omitempty"`
+	// If dry_run is set, etcd evaluates the compares and the requests of the chosen branch,
+	// including permission, quota and admission checks, and returns the response it would
+	// have returned without applying it. The header revision is the revision the txn would
+	// have created. Ranges within the txn see the keys as they were before the txn.
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (m *TxnRequest) Reset()                    { *m = TxnRequest{} }
@@ -1264,6 +1391,13 @@ func (m *TxnRequest) GetFailure() []*RequestOp {
 	return nil
 }
 
+func (m *TxnRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type TxnResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// succeeded is set to true if the compare evaluated to true or false otherwise.
@@ -1474,11 +1608,13 @@ func (m *SnapshotResponse) GetBlob() []byte {
 }
 
 type WatchRequest struct {
-	// request_union is a request to either create a new watcher or cancel an existing watcher.
+	// request_union is a request to either create a new watcher, cancel an existing watcher,
+	// or acknowledge the responses of a watcher.
 	//
 	// Types that are valid to be assigned to RequestUnion:
 	//	*WatchRequest_CreateRequest
 	//	*WatchRequest_CancelRequest
+	//	*WatchRequest_AckRequest
 	RequestUnion isWatchRequest_RequestUnion `protobuf_oneof:"request_union"`
 }
 
@@ -1499,9 +1635,13 @@ type WatchRequest_CreateRequest struct {
 type WatchRequest_CancelRequest struct {
 	CancelRequest *WatchCancelRequest `protobuf:"bytes,2,opt,name=cancel_request,json=cancelRequest,oneof"`
 }
+type WatchRequest_AckRequest struct {
+	AckRequest *WatchAckRequest `protobuf:"bytes,3,opt,name=ack_request,json=ackRequest,oneof"`
+}
 
 func (*WatchRequest_CreateRequest) isWatchRequest_RequestUnion() {}
 func (*WatchRequest_CancelRequest) isWatchRequest_RequestUnion() {}
+func (*WatchRequest_AckRequest) isWatchRequest_RequestUnion()    {}
 
 func (m *WatchRequest) GetRequestUnion() isWatchRequest_RequestUnion {
 	if m != nil {
@@ -1524,11 +1664,19 @@ func (m *WatchRequest) GetCancelRequest() *WatchCancelRequest {
 	return nil
 }
 
+func (m *WatchRequest) GetAckRequest() *WatchAckRequest {
+	if x, ok := m.GetRequestUnion().(*WatchRequest_AckRequest); ok {
+		return x.AckRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*WatchRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _WatchRequest_OneofMarshaler, _WatchRequest_OneofUnmarshaler, _WatchRequest_OneofSizer, []interface{}{
 		(*WatchRequest_CreateRequest)(nil),
 		(*WatchRequest_CancelRequest)(nil),
+		(*WatchRequest_AckRequest)(nil),
 	}
 }
 
@@ -1546,6 +1694,11 @@ func _WatchRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.CancelRequest); err != nil {
 			return err
 		}
+	case *WatchRequest_AckRequest:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AckRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("WatchRequest.RequestUnion has unexpected type %T", x)
@@ -1572,6 +1725,14 @@ func _WatchRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.B
 		err := b.DecodeMessage(msg)
 		m.RequestUnion = &WatchRequest_CancelRequest{msg}
 		return true, err
+	case 3: // request_union.ack_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(WatchAckRequest)
+		err := b.DecodeMessage(msg)
+		m.RequestUnion = &WatchRequest_AckRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1591,6 +1752,11 @@ func _WatchRequest_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *WatchRequest_AckRequest:
+		s := proto.Size(x.AckRequest)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1685,6 +1851,32 @@ func (m *WatchCancelRequest) GetWatchId() int64 {
 	return 0
 }
 
+type WatchAckRequest struct {
+	// watch_id is the watcher whose responses are acknowledged.
+	WatchId int64 `protobuf:"varint,1,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	// revision acknowledges all responses of the watcher up to and including this revision.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *WatchAckRequest) Reset()                    { *m = WatchAckRequest{} }
+func (m *WatchAckRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchAckRequest) ProtoMessage()               {}
+func (*WatchAckRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{23} }
+
+func (m *WatchAckRequest) GetWatchId() int64 {
+	if m != nil {
+		return m.WatchId
+	}
+	return 0
+}
+
+func (m *WatchAckRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type WatchResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// watch_id is the ID of the watcher that corresponds to the response.
@@ -1714,7 +1906,7 @@ type WatchResponse struct {
 func (m *WatchResponse) Reset()                    { *m = WatchResponse{} }
 func (m *WatchResponse) String() string            { return proto.CompactTextString(m) }
 func (*WatchResponse) ProtoMessage()               {}
-func (*WatchResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{23} }
+func (*WatchResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{24} }
 
 func (m *WatchResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1770,12 +1962,16 @@ type LeaseGrantRequest struct {
 	TTL int64 `protobuf:"varint,1,opt,name=TTL,proto3" json:"TTL,omitempty"`
 	// ID is the requested ID for the lease. If ID is set to 0, the lessor chooses an ID.
 	ID int64 `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	// metadata is opaque client data kept with the lease, such as the name of its owner.
+	Metadata []byte `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// keep_keys is true to detach the keys of the lease instead of deleting them when the lease expires or is revoked.
+	KeepKeys bool `protobuf:"varint,4,opt,name=keep_keys,json=keepKeys,proto3" json:"keep_keys,omitempty"`
 }
 
 func (m *LeaseGrantRequest) Reset()                    { *m = LeaseGrantRequest{} }
 func (m *LeaseGrantRequest) String() string            { return proto.CompactTextString(m) }
 func (*LeaseGrantRequest) ProtoMessage()               {}
-func (*LeaseGrantRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{24} }
+func (*LeaseGrantRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{25} }
 
 func (m *LeaseGrantRequest) GetTTL() int64 {
 	if m != nil {
@@ -1791,6 +1987,20 @@ func (m *LeaseGrantRequest) GetID() int64 {
 	return 0
 }
 
+func (m *LeaseGrantRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *LeaseGrantRequest) GetKeepKeys() bool {
+	if m != nil {
+		return m.KeepKeys
+	}
+	return false
+}
+
 type LeaseGrantResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// ID is the lease ID for the granted lease.
@@ -1803,7 +2013,7 @@ type LeaseGrantResponse struct {
 func (m *LeaseGrantResponse) Reset()                    { *m = LeaseGrantResponse{} }
 func (m *LeaseGrantResponse) String() string            { return proto.CompactTextString(m) }
 func (*LeaseGrantResponse) ProtoMessage()               {}
-func (*LeaseGrantResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{25} }
+func (*LeaseGrantResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{26} }
 
 func (m *LeaseGrantResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1841,7 +2051,7 @@ type LeaseRevokeRequest struct {
 func (m *LeaseRevokeRequest) Reset()                    { *m = LeaseRevokeRequest{} }
 func (m *LeaseRevokeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LeaseRevokeRequest) ProtoMessage()               {}
-func (*LeaseRevokeRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{26} }
+func (*LeaseRevokeRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{27} }
 
 func (m *LeaseRevokeRequest) GetID() int64 {
 	if m != nil {
@@ -1857,7 +2067,7 @@ type LeaseRevokeResponse struct {
 func (m *LeaseRevokeResponse) Reset()                    { *m = LeaseRevokeResponse{} }
 func (m *LeaseRevokeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LeaseRevokeResponse) ProtoMessage()               {}
-func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{27} }
+func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{28} }
 
 func (m *LeaseRevokeResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1874,7 +2084,7 @@ type LeaseKeepAliveRequest struct {
 func (m *LeaseKeepAliveRequest) Reset()                    { *m = LeaseKeepAliveRequest{} }
 func (m *LeaseKeepAliveRequest) String() string            { return proto.CompactTextString(m) }
 func (*LeaseKeepAliveRequest) ProtoMessage()               {}
-func (*LeaseKeepAliveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{28} }
+func (*LeaseKeepAliveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{29} }
 
 func (m *LeaseKeepAliveRequest) GetID() int64 {
 	if m != nil {
@@ -1894,7 +2104,7 @@ type LeaseKeepAliveResponse struct {
 func (m *LeaseKeepAliveResponse) Reset()                    { *m = LeaseKeepAliveResponse{} }
 func (m *LeaseKeepAliveResponse) String() string            { return proto.CompactTextString(m) }
 func (*LeaseKeepAliveResponse) ProtoMessage()               {}
-func (*LeaseKeepAliveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{29} }
+func (*LeaseKeepAliveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{30} }
 
 func (m *LeaseKeepAliveResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1927,7 +2137,7 @@ type LeaseTimeToLiveRequest struct {
 func (m *LeaseTimeToLiveRequest) Reset()                    { *m = LeaseTimeToLiveRequest{} }
 func (m *LeaseTimeToLiveRequest) String() string            { return proto.CompactTextString(m) }
 func (*LeaseTimeToLiveRequest) ProtoMessage()               {}
-func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{30} }
+func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{31} }
 
 func (m *LeaseTimeToLiveRequest) GetID() int64 {
 	if m != nil {
@@ -1953,12 +2163,14 @@ type LeaseTimeToLiveResponse struct {
 	GrantedTTL int64 `protobuf:"varint,4,opt,name=grantedTTL,proto3" json:"grantedTTL,omitempty"`
 	// Keys is the list of keys attached to this lease.
 	Keys [][]byte `protobuf:"bytes,5,rep,name=keys" json:"keys,omitempty"`
+	// metadata is the opaque client data given when the lease was granted.
+	Metadata []byte `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *LeaseTimeToLiveResponse) Reset()                    { *m = LeaseTimeToLiveResponse{} }
 func (m *LeaseTimeToLiveResponse) String() string            { return proto.CompactTextString(m) }
 func (*LeaseTimeToLiveResponse) ProtoMessage()               {}
-func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{31} }
+func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{32} }
 
 func (m *LeaseTimeToLiveResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1995,22 +2207,31 @@ func (m *LeaseTimeToLiveResponse) GetKeys() [][]byte {
 	return nil
 }
 
+func (m *LeaseTimeToLiveResponse) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type LeaseLeasesRequest struct {
 }
 
 func (m *LeaseLeasesRequest) Reset()                    { *m = LeaseLeasesRequest{} }
 func (m *LeaseLeasesRequest) String() string            { return proto.CompactTextString(m) }
 func (*LeaseLeasesRequest) ProtoMessage()               {}
-func (*LeaseLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{32} }
+func (*LeaseLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{33} }
 
 type LeaseStatus struct {
 	ID int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// metadata is the opaque client data given when the lease was granted.
+	Metadata []byte `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *LeaseStatus) Reset()                    { *m = LeaseStatus{} }
 func (m *LeaseStatus) String() string            { return proto.CompactTextString(m) }
 func (*LeaseStatus) ProtoMessage()               {}
-func (*LeaseStatus) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{33} }
+func (*LeaseStatus) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{34} }
 
 func (m *LeaseStatus) GetID() int64 {
 	if m != nil {
@@ -2019,6 +2240,13 @@ func (m *LeaseStatus) GetID() int64 {
 	return 0
 }
 
+func (m *LeaseStatus) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type LeaseLeasesResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Leases []*LeaseStatus  `protobuf:"bytes,2,rep,name=leases" json:"leases,omitempty"`
@@ -2027,7 +2255,7 @@ type LeaseLeasesResponse struct {
 func (m *LeaseLeasesResponse) Reset()                    { *m = LeaseLeasesResponse{} }
 func (m *LeaseLeasesResponse) String() string            { return proto.CompactTextString(m) }
 func (*LeaseLeasesResponse) ProtoMessage()               {}
-func (*LeaseLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{34} }
+func (*LeaseLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{35} }
 
 func (m *LeaseLeasesResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2057,7 +2285,7 @@ type Member struct {
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{35} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{36} }
 
 func (m *Member) GetID() uint64 {
 	if m != nil {
@@ -2095,7 +2323,7 @@ type MemberAddRequest struct {
 func (m *MemberAddRequest) Reset()                    { *m = MemberAddRequest{} }
 func (m *MemberAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberAddRequest) ProtoMessage()               {}
-func (*MemberAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{36} }
+func (*MemberAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{37} }
 
 func (m *MemberAddRequest) GetPeerURLs() []string {
 	if m != nil {
@@ -2115,7 +2343,7 @@ type MemberAddResponse struct {
 func (m *MemberAddResponse) Reset()                    { *m = MemberAddResponse{} }
 func (m *MemberAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberAddResponse) ProtoMessage()               {}
-func (*MemberAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{37} }
+func (*MemberAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{38} }
 
 func (m *MemberAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2146,7 +2374,7 @@ type MemberRemoveRequest struct {
 func (m *MemberRemoveRequest) Reset()                    { *m = MemberRemoveRequest{} }
 func (m *MemberRemoveRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberRemoveRequest) ProtoMessage()               {}
-func (*MemberRemoveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{38} }
+func (*MemberRemoveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{39} }
 
 func (m *MemberRemoveRequest) GetID() uint64 {
 	if m != nil {
@@ -2164,7 +2392,7 @@ type MemberRemoveResponse struct {
 func (m *MemberRemoveResponse) Reset()                    { *m = MemberRemoveResponse{} }
 func (m *MemberRemoveResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberRemoveResponse) ProtoMessage()               {}
-func (*MemberRemoveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{39} }
+func (*MemberRemoveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
 
 func (m *MemberRemoveResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2190,7 +2418,7 @@ type MemberUpdateRequest struct {
 func (m *MemberUpdateRequest) Reset()                    { *m = MemberUpdateRequest{} }
 func (m *MemberUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberUpdateRequest) ProtoMessage()               {}
-func (*MemberUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
+func (*MemberUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
 
 func (m *MemberUpdateRequest) GetID() uint64 {
 	if m != nil {
@@ -2215,7 +2443,7 @@ type MemberUpdateResponse struct {
 func (m *MemberUpdateResponse) Reset()                    { *m = MemberUpdateResponse{} }
 func (m *MemberUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberUpdateResponse) ProtoMessage()               {}
-func (*MemberUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
+func (*MemberUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
 
 func (m *MemberUpdateResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2237,7 +2465,7 @@ type MemberListRequest struct {
 func (m *MemberListRequest) Reset()                    { *m = MemberListRequest{} }
 func (m *MemberListRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberListRequest) ProtoMessage()               {}
-func (*MemberListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
+func (*MemberListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{43} }
 
 type MemberListResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -2248,7 +2476,7 @@ type MemberListResponse struct {
 func (m *MemberListResponse) Reset()                    { *m = MemberListResponse{} }
 func (m *MemberListResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberListResponse) ProtoMessage()               {}
-func (*MemberListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{43} }
+func (*MemberListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{44} }
 
 func (m *MemberListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2270,7 +2498,7 @@ type DefragmentRequest struct {
 func (m *DefragmentRequest) Reset()                    { *m = DefragmentRequest{} }
 func (m *DefragmentRequest) String() string            { return proto.CompactTextString(m) }
 func (*DefragmentRequest) ProtoMessage()               {}
-func (*DefragmentRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{44} }
+func (*DefragmentRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{45} }
 
 type DefragmentResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -2279,7 +2507,7 @@ type DefragmentResponse struct {
 func (m *DefragmentResponse) Reset()                    { *m = DefragmentResponse{} }
 func (m *DefragmentResponse) String() string            { return proto.CompactTextString(m) }
 func (*DefragmentResponse) ProtoMessage()               {}
-func (*DefragmentResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{45} }
+func (*DefragmentResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{46} }
 
 func (m *DefragmentResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2296,7 +2524,7 @@ type MoveLeaderRequest struct {
 func (m *MoveLeaderRequest) Reset()                    { *m = MoveLeaderRequest{} }
 func (m *MoveLeaderRequest) String() string            { return proto.CompactTextString(m) }
 func (*MoveLeaderRequest) ProtoMessage()               {}
-func (*MoveLeaderRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{46} }
+func (*MoveLeaderRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{47} }
 
 func (m *MoveLeaderRequest) GetTargetID() uint64 {
 	if m != nil {
@@ -2312,7 +2540,7 @@ type MoveLeaderResponse struct {
 func (m *MoveLeaderResponse) Reset()                    { *m = MoveLeaderResponse{} }
 func (m *MoveLeaderResponse) String() string            { return proto.CompactTextString(m) }
 func (*MoveLeaderResponse) ProtoMessage()               {}
-func (*MoveLeaderResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{47} }
+func (*MoveLeaderResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{48} }
 
 func (m *MoveLeaderResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2321,6 +2549,78 @@ func (m *MoveLeaderResponse) GetHeader() *ResponseHeader {
 	return nil
 }
 
+type DowngradeRequest struct {
+	// action is the kind of downgrade request to issue. The action may
+	// report the STATUS of the cluster version, VALIDATE or ENABLE a
+	// downgrade to the given version, or CANCEL the downgrade in progress.
+	Action DowngradeRequest_DowngradeAction `protobuf:"varint,1,opt,name=action,proto3,enum=etcdserverpb.DowngradeRequest_DowngradeAction" json:"action,omitempty"`
+	// version is the major.minor version to downgrade the cluster to. It must
+	// be one minor version below the cluster version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *DowngradeRequest) Reset()                    { *m = DowngradeRequest{} }
+func (m *DowngradeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DowngradeRequest) ProtoMessage()               {}
+func (*DowngradeRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{49} }
+
+func (m *DowngradeRequest) GetAction() DowngradeRequest_DowngradeAction {
+	if m != nil {
+		return m.Action
+	}
+	return DowngradeRequest_STATUS
+}
+
+func (m *DowngradeRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+type DowngradeResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// version is the cluster version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// enabled is set while the cluster is being downgraded.
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// target_version is the version the cluster is being downgraded to.
+	TargetVersion string `protobuf:"bytes,4,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+}
+
+func (m *DowngradeResponse) Reset()                    { *m = DowngradeResponse{} }
+func (m *DowngradeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DowngradeResponse) ProtoMessage()               {}
+func (*DowngradeResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{50} }
+
+func (m *DowngradeResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *DowngradeResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DowngradeResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *DowngradeResponse) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
 type AlarmRequest struct {
 	// action is the kind of alarm request to issue. The action
 	// may GET alarm statuses, ACTIVATE an alarm, or DEACTIVATE a
@@ -2336,7 +2636,7 @@ type AlarmRequest struct {
 func (m *AlarmRequest) Reset()                    { *m = AlarmRequest{} }
 func (m *AlarmRequest) String() string            { return proto.CompactTextString(m) }
 func (*AlarmRequest) ProtoMessage()               {}
-func (*AlarmRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{48} }
+func (*AlarmRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{51} }
 
 func (m *AlarmRequest) GetAction() AlarmRequest_AlarmAction {
 	if m != nil {
@@ -2369,7 +2669,7 @@ type AlarmMember struct {
 func (m *AlarmMember) Reset()                    { *m = AlarmMember{} }
 func (m *AlarmMember) String() string            { return proto.CompactTextString(m) }
 func (*AlarmMember) ProtoMessage()               {}
-func (*AlarmMember) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{49} }
+func (*AlarmMember) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{52} }
 
 func (m *AlarmMember) GetMemberID() uint64 {
 	if m != nil {
@@ -2394,7 +2694,7 @@ type AlarmResponse struct {
 func (m *AlarmResponse) Reset()                    { *m = AlarmResponse{} }
 func (m *AlarmResponse) String() string            { return proto.CompactTextString(m) }
 func (*AlarmResponse) ProtoMessage()               {}
-func (*AlarmResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{50} }
+func (*AlarmResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{53} }
 
 func (m *AlarmResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2416,7 +2716,7 @@ type StatusRequest struct {
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{51} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{54} }
 
 type StatusResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -2430,12 +2730,24 @@ type StatusResponse struct {
 	RaftIndex uint64 `protobuf:"varint,5,opt,name=raftIndex,proto3" json:"raftIndex,omitempty"`
 	// raftTerm is the current raft term of the responding member.
 	RaftTerm uint64 `protobuf:"varint,6,opt,name=raftTerm,proto3" json:"raftTerm,omitempty"`
+	// memoryBytes is the approximate memory, in bytes, held by the key index, watch buffers and raft log of the responding member.
+	MemoryBytes int64 `protobuf:"varint,7,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	// dbSizeInUse is the size of the backend database in use, in bytes, of the responding member.
+	DbSizeInUse int64 `protobuf:"varint,8,opt,name=dbSizeInUse,proto3" json:"dbSizeInUse,omitempty"`
+	// storageVersion is the cluster version, major.minor, the responding member writes its data at.
+	StorageVersion string `protobuf:"bytes,9,opt,name=storageVersion,proto3" json:"storageVersion,omitempty"`
+	// activeWatchers is the number of watchers on the responding member.
+	ActiveWatchers int64 `protobuf:"varint,10,opt,name=activeWatchers,proto3" json:"activeWatchers,omitempty"`
+	// activeLeases is the number of leases held by the responding member.
+	ActiveLeases int64 `protobuf:"varint,11,opt,name=activeLeases,proto3" json:"activeLeases,omitempty"`
+	// uptimeSeconds is the time, in seconds, since the responding member started.
+	UptimeSeconds int64 `protobuf:"varint,12,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`
 }
 
 func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
 func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatusResponse) ProtoMessage()               {}
-func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{52} }
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{55} }
 
 func (m *StatusResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2479,13 +2791,55 @@ func (m *StatusResponse) GetRaftTerm() uint64 {
 	return 0
 }
 
+func (m *StatusResponse) GetMemoryBytes() int64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetDbSizeInUse() int64 {
+	if m != nil {
+		return m.DbSizeInUse
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetStorageVersion() string {
+	if m != nil {
+		return m.StorageVersion
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetActiveWatchers() int64 {
+	if m != nil {
+		return m.ActiveWatchers
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetActiveLeases() int64 {
+	if m != nil {
+		return m.ActiveLeases
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
 type AuthEnableRequest struct {
 }
 
 func (m *AuthEnableRequest) Reset()                    { *m = AuthEnableRequest{} }
 func (m *AuthEnableRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthEnableRequest) ProtoMessage()               {}
-func (*AuthEnableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{53} }
+func (*AuthEnableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{56} }
 
 type AuthDisableRequest struct {
 }
@@ -2493,7 +2847,7 @@ type AuthDisableRequest struct {
 func (m *AuthDisableRequest) Reset()                    { *m = AuthDisableRequest{} }
 func (m *AuthDisableRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthDisableRequest) ProtoMessage()               {}
-func (*AuthDisableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{54} }
+func (*AuthDisableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{57} }
 
 type AuthenticateRequest struct {
 	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -2503,7 +2857,7 @@ type AuthenticateRequest struct {
 func (m *AuthenticateRequest) Reset()                    { *m = AuthenticateRequest{} }
 func (m *AuthenticateRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthenticateRequest) ProtoMessage()               {}
-func (*AuthenticateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{55} }
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{58} }
 
 func (m *AuthenticateRequest) GetName() string {
 	if m != nil {
@@ -2527,7 +2881,7 @@ type AuthUserAddRequest struct {
 func (m *AuthUserAddRequest) Reset()                    { *m = AuthUserAddRequest{} }
 func (m *AuthUserAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserAddRequest) ProtoMessage()               {}
-func (*AuthUserAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{56} }
+func (*AuthUserAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{59} }
 
 func (m *AuthUserAddRequest) GetName() string {
 	if m != nil {
@@ -2550,7 +2904,7 @@ type AuthUserGetRequest struct {
 func (m *AuthUserGetRequest) Reset()                    { *m = AuthUserGetRequest{} }
 func (m *AuthUserGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGetRequest) ProtoMessage()               {}
-func (*AuthUserGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{57} }
+func (*AuthUserGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{60} }
 
 func (m *AuthUserGetRequest) GetName() string {
 	if m != nil {
@@ -2567,7 +2921,7 @@ type AuthUserDeleteRequest struct {
 func (m *AuthUserDeleteRequest) Reset()                    { *m = AuthUserDeleteRequest{} }
 func (m *AuthUserDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserDeleteRequest) ProtoMessage()               {}
-func (*AuthUserDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{58} }
+func (*AuthUserDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{61} }
 
 func (m *AuthUserDeleteRequest) GetName() string {
 	if m != nil {
@@ -2587,7 +2941,7 @@ func (m *AuthUserChangePasswordRequest) Reset()         { *m = AuthUserChangePas
 func (m *AuthUserChangePasswordRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthUserChangePasswordRequest) ProtoMessage()    {}
 func (*AuthUserChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{59}
+	return fileDescriptorRpc, []int{62}
 }
 
 func (m *AuthUserChangePasswordRequest) GetName() string {
@@ -2614,7 +2968,7 @@ type AuthUserGrantRoleRequest struct {
 func (m *AuthUserGrantRoleRequest) Reset()                    { *m = AuthUserGrantRoleRequest{} }
 func (m *AuthUserGrantRoleRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGrantRoleRequest) ProtoMessage()               {}
-func (*AuthUserGrantRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{60} }
+func (*AuthUserGrantRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{63} }
 
 func (m *AuthUserGrantRoleRequest) GetUser() string {
 	if m != nil {
@@ -2638,7 +2992,7 @@ type AuthUserRevokeRoleRequest struct {
 func (m *AuthUserRevokeRoleRequest) Reset()                    { *m = AuthUserRevokeRoleRequest{} }
 func (m *AuthUserRevokeRoleRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserRevokeRoleRequest) ProtoMessage()               {}
-func (*AuthUserRevokeRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{61} }
+func (*AuthUserRevokeRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{64} }
 
 func (m *AuthUserRevokeRoleRequest) GetName() string {
 	if m != nil {
@@ -2662,7 +3016,7 @@ type AuthRoleAddRequest struct {
 func (m *AuthRoleAddRequest) Reset()                    { *m = AuthRoleAddRequest{} }
 func (m *AuthRoleAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleAddRequest) ProtoMessage()               {}
-func (*AuthRoleAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{62} }
+func (*AuthRoleAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{65} }
 
 func (m *AuthRoleAddRequest) GetName() string {
 	if m != nil {
@@ -2678,7 +3032,7 @@ type AuthRoleGetRequest struct {
 func (m *AuthRoleGetRequest) Reset()                    { *m = AuthRoleGetRequest{} }
 func (m *AuthRoleGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleGetRequest) ProtoMessage()               {}
-func (*AuthRoleGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{63} }
+func (*AuthRoleGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{66} }
 
 func (m *AuthRoleGetRequest) GetRole() string {
 	if m != nil {
@@ -2693,7 +3047,7 @@ type AuthUserListRequest struct {
 func (m *AuthUserListRequest) Reset()                    { *m = AuthUserListRequest{} }
 func (m *AuthUserListRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserListRequest) ProtoMessage()               {}
-func (*AuthUserListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{64} }
+func (*AuthUserListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{67} }
 
 type AuthRoleListRequest struct {
 }
@@ -2701,7 +3055,7 @@ type AuthRoleListRequest struct {
 func (m *AuthRoleListRequest) Reset()                    { *m = AuthRoleListRequest{} }
 func (m *AuthRoleListRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleListRequest) ProtoMessage()               {}
-func (*AuthRoleListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{65} }
+func (*AuthRoleListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{68} }
 
 type AuthRoleDeleteRequest struct {
 	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -2710,7 +3064,7 @@ type AuthRoleDeleteRequest struct {
 func (m *AuthRoleDeleteRequest) Reset()                    { *m = AuthRoleDeleteRequest{} }
 func (m *AuthRoleDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleDeleteRequest) ProtoMessage()               {}
-func (*AuthRoleDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{66} }
+func (*AuthRoleDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{69} }
 
 func (m *AuthRoleDeleteRequest) GetRole() string {
 	if m != nil {
@@ -2730,7 +3084,7 @@ func (m *AuthRoleGrantPermissionRequest) Reset()         { *m = AuthRoleGrantPer
 func (m *AuthRoleGrantPermissionRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleGrantPermissionRequest) ProtoMessage()    {}
 func (*AuthRoleGrantPermissionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{67}
+	return fileDescriptorRpc, []int{70}
 }
 
 func (m *AuthRoleGrantPermissionRequest) GetName() string {
@@ -2757,7 +3111,7 @@ func (m *AuthRoleRevokePermissionRequest) Reset()         { *m = AuthRoleRevokeP
 func (m *AuthRoleRevokePermissionRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleRevokePermissionRequest) ProtoMessage()    {}
 func (*AuthRoleRevokePermissionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{68}
+	return fileDescriptorRpc, []int{71}
 }
 
 func (m *AuthRoleRevokePermissionRequest) GetRole() string {
@@ -2788,7 +3142,7 @@ type AuthEnableResponse struct {
 func (m *AuthEnableResponse) Reset()                    { *m = AuthEnableResponse{} }
 func (m *AuthEnableResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthEnableResponse) ProtoMessage()               {}
-func (*AuthEnableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{69} }
+func (*AuthEnableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{72} }
 
 func (m *AuthEnableResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2804,7 +3158,7 @@ type AuthDisableResponse struct {
 func (m *AuthDisableResponse) Reset()                    { *m = AuthDisableResponse{} }
 func (m *AuthDisableResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthDisableResponse) ProtoMessage()               {}
-func (*AuthDisableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{70} }
+func (*AuthDisableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{73} }
 
 func (m *AuthDisableResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2822,7 +3176,7 @@ type AuthenticateResponse struct {
 func (m *AuthenticateResponse) Reset()                    { *m = AuthenticateResponse{} }
 func (m *AuthenticateResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthenticateResponse) ProtoMessage()               {}
-func (*AuthenticateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{71} }
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{74} }
 
 func (m *AuthenticateResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2845,7 +3199,7 @@ type AuthUserAddResponse struct {
 func (m *AuthUserAddResponse) Reset()                    { *m = AuthUserAddResponse{} }
 func (m *AuthUserAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserAddResponse) ProtoMessage()               {}
-func (*AuthUserAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{72} }
+func (*AuthUserAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{75} }
 
 func (m *AuthUserAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2862,7 +3216,7 @@ type AuthUserGetResponse struct {
 func (m *AuthUserGetResponse) Reset()                    { *m = AuthUserGetResponse{} }
 func (m *AuthUserGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGetResponse) ProtoMessage()               {}
-func (*AuthUserGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{73} }
+func (*AuthUserGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{76} }
 
 func (m *AuthUserGetResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2885,7 +3239,7 @@ type AuthUserDeleteResponse struct {
 func (m *AuthUserDeleteResponse) Reset()                    { *m = AuthUserDeleteResponse{} }
 func (m *AuthUserDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserDeleteResponse) ProtoMessage()               {}
-func (*AuthUserDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{74} }
+func (*AuthUserDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{77} }
 
 func (m *AuthUserDeleteResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2902,7 +3256,7 @@ func (m *AuthUserChangePasswordResponse) Reset()         { *m = AuthUserChangePa
 func (m *AuthUserChangePasswordResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthUserChangePasswordResponse) ProtoMessage()    {}
 func (*AuthUserChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{75}
+	return fileDescriptorRpc, []int{78}
 }
 
 func (m *AuthUserChangePasswordResponse) GetHeader() *ResponseHeader {
@@ -2919,7 +3273,7 @@ type AuthUserGrantRoleResponse struct {
 func (m *AuthUserGrantRoleResponse) Reset()                    { *m = AuthUserGrantRoleResponse{} }
 func (m *AuthUserGrantRoleResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGrantRoleResponse) ProtoMessage()               {}
-func (*AuthUserGrantRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{76} }
+func (*AuthUserGrantRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{79} }
 
 func (m *AuthUserGrantRoleResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2935,7 +3289,7 @@ type AuthUserRevokeRoleResponse struct {
 func (m *AuthUserRevokeRoleResponse) Reset()                    { *m = AuthUserRevokeRoleResponse{} }
 func (m *AuthUserRevokeRoleResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserRevokeRoleResponse) ProtoMessage()               {}
-func (*AuthUserRevokeRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{77} }
+func (*AuthUserRevokeRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{80} }
 
 func (m *AuthUserRevokeRoleResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2951,7 +3305,7 @@ type AuthRoleAddResponse struct {
 func (m *AuthRoleAddResponse) Reset()                    { *m = AuthRoleAddResponse{} }
 func (m *AuthRoleAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleAddResponse) ProtoMessage()               {}
-func (*AuthRoleAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{78} }
+func (*AuthRoleAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{81} }
 
 func (m *AuthRoleAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2968,7 +3322,7 @@ type AuthRoleGetResponse struct {
 func (m *AuthRoleGetResponse) Reset()                    { *m = AuthRoleGetResponse{} }
 func (m *AuthRoleGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleGetResponse) ProtoMessage()               {}
-func (*AuthRoleGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{79} }
+func (*AuthRoleGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{82} }
 
 func (m *AuthRoleGetResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -2992,7 +3346,7 @@ type AuthRoleListResponse struct {
 func (m *AuthRoleListResponse) Reset()                    { *m = AuthRoleListResponse{} }
 func (m *AuthRoleListResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleListResponse) ProtoMessage()               {}
-func (*AuthRoleListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{80} }
+func (*AuthRoleListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{83} }
 
 func (m *AuthRoleListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -3016,7 +3370,7 @@ type AuthUserListResponse struct {
 func (m *AuthUserListResponse) Reset()                    { *m = AuthUserListResponse{} }
 func (m *AuthUserListResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserListResponse) ProtoMessage()               {}
-func (*AuthUserListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{81} }
+func (*AuthUserListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{84} }
 
 func (m *AuthUserListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -3039,7 +3393,7 @@ type AuthRoleDeleteResponse struct {
 func (m *AuthRoleDeleteResponse) Reset()                    { *m = AuthRoleDeleteResponse{} }
 func (m *AuthRoleDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleDeleteResponse) ProtoMessage()               {}
-func (*AuthRoleDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{82} }
+func (*AuthRoleDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{85} }
 
 func (m *AuthRoleDeleteResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -3056,7 +3410,7 @@ func (m *AuthRoleGrantPermissionResponse) Reset()         { *m = AuthRoleGrantPe
 func (m *AuthRoleGrantPermissionResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleGrantPermissionResponse) ProtoMessage()    {}
 func (*AuthRoleGrantPermissionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{83}
+	return fileDescriptorRpc, []int{86}
 }
 
 func (m *AuthRoleGrantPermissionResponse) GetHeader() *ResponseHeader {
@@ -3074,7 +3428,7 @@ func (m *AuthRoleRevokePermissionResponse) Reset()         { *m = AuthRoleRevoke
 func (m *AuthRoleRevokePermissionResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleRevokePermissionResponse) ProtoMessage()    {}
 func (*AuthRoleRevokePermissionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{84}
+	return fileDescriptorRpc, []int{87}
 }
 
 func (m *AuthRoleRevokePermissionResponse) GetHeader() *ResponseHeader {
@@ -3108,6 +3462,7 @@ func init() {
 	proto.RegisterType((*WatchRequest)(nil), "etcdserverpb.WatchRequest")
 	proto.RegisterType((*WatchCreateRequest)(nil), "etcdserverpb.WatchCreateRequest")
 	proto.RegisterType((*WatchCancelRequest)(nil), "etcdserverpb.WatchCancelRequest")
+	proto.RegisterType((*WatchAckRequest)(nil), "etcdserverpb.WatchAckRequest")
 	proto.RegisterType((*WatchResponse)(nil), "etcdserverpb.WatchResponse")
 	proto.RegisterType((*LeaseGrantRequest)(nil), "etcdserverpb.LeaseGrantRequest")
 	proto.RegisterType((*LeaseGrantResponse)(nil), "etcdserverpb.LeaseGrantResponse")
@@ -3133,6 +3488,8 @@ func init() {
 	proto.RegisterType((*DefragmentResponse)(nil), "etcdserverpb.DefragmentResponse")
 	proto.RegisterType((*MoveLeaderRequest)(nil), "etcdserverpb.MoveLeaderRequest")
 	proto.RegisterType((*MoveLeaderResponse)(nil), "etcdserverpb.MoveLeaderResponse")
+	proto.RegisterType((*DowngradeRequest)(nil), "etcdserverpb.DowngradeRequest")
+	proto.RegisterType((*DowngradeResponse)(nil), "etcdserverpb.DowngradeResponse")
 	proto.RegisterType((*AlarmRequest)(nil), "etcdserverpb.AlarmRequest")
 	proto.RegisterType((*AlarmMember)(nil), "etcdserverpb.AlarmMember")
 	proto.RegisterType((*AlarmResponse)(nil), "etcdserverpb.AlarmResponse")
@@ -3176,6 +3533,7 @@ func init() {
 	proto.RegisterEnum("etcdserverpb.Compare_CompareResult", Compare_CompareResult_name, Compare_CompareResult_value)
 	proto.RegisterEnum("etcdserverpb.Compare_CompareTarget", Compare_CompareTarget_name, Compare_CompareTarget_value)
 	proto.RegisterEnum("etcdserverpb.WatchCreateRequest_FilterType", WatchCreateRequest_FilterType_name, WatchCreateRequest_FilterType_value)
+	proto.RegisterEnum("etcdserverpb.DowngradeRequest_DowngradeAction", DowngradeRequest_DowngradeAction_name, DowngradeRequest_DowngradeAction_value)
 	proto.RegisterEnum("etcdserverpb.AlarmRequest_AlarmAction", AlarmRequest_AlarmAction_name, AlarmRequest_AlarmAction_value)
 }
 
@@ -3952,6 +4310,8 @@ type MaintenanceClient interface {
 	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Maintenance_SnapshotClient, error)
 	// MoveLeader requests current leader node to transfer its leadership to transferee.
 	MoveLeader(ctx context.Context, in *MoveLeaderRequest, opts ...grpc.CallOption) (*MoveLeaderResponse, error)
+	// Downgrade reports, validates, enables or cancels a downgrade of the cluster version.
+	Downgrade(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (*DowngradeResponse, error)
 }
 
 type maintenanceClient struct {
@@ -4048,6 +4408,15 @@ func (c *maintenanceClient) MoveLeader(ctx context.Context, in *MoveLeaderReques
 	return out, nil
 }
 
+func (c *maintenanceClient) Downgrade(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (*DowngradeResponse, error) {
+	out := new(DowngradeResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Maintenance/Downgrade", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Maintenance service
 
 type MaintenanceServer interface {
@@ -4067,6 +4436,8 @@ type MaintenanceServer interface {
 	Snapshot(*SnapshotRequest, Maintenance_SnapshotServer) error
 	// MoveLeader requests current leader node to transfer its leadership to transferee.
 	MoveLeader(context.Context, *MoveLeaderRequest) (*MoveLeaderResponse, error)
+	// Downgrade reports, validates, enables or cancels a downgrade of the cluster version.
+	Downgrade(context.Context, *DowngradeRequest) (*DowngradeResponse, error)
 }
 
 func RegisterMaintenanceServer(s *grpc.Server, srv MaintenanceServer) {
@@ -4202,6 +4573,24 @@ func _Maintenance_MoveLeader_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Maintenance_Downgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DowngradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).Downgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Maintenance/Downgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).Downgrade(ctx, req.(*DowngradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Maintenance_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "etcdserverpb.Maintenance",
 	HandlerType: (*MaintenanceServer)(nil),
@@ -4230,6 +4619,10 @@ var _Maintenance_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MoveLeader",
 			Handler:    _Maintenance_MoveLeader_Handler,
 		},
+		{
+			MethodName: "Downgrade",
+			Handler:    _Maintenance_Downgrade_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4967,6 +5360,18 @@ func (m *RangeRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.MaxCreateRevision))
 	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.IndexValue) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.IndexValue)))
+		i += copy(dAtA[i:], m.IndexValue)
+	}
 	return i, nil
 }
 
@@ -5087,6 +5492,31 @@ func (m *PutRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.ExpireAt))
+	}
+	if m.ExpectedVersion != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.ExpectedVersion))
+	}
+	if m.ExpectedModRevision != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.ExpectedModRevision))
+	}
+	if m.DryRun {
+		dAtA[i] = 0x50
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -5125,6 +5555,16 @@ func (m *PutResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n3
 	}
+	if m.Conflict {
+		dAtA[i] = 0x18
+		i++
+		if m.Conflict {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -5165,6 +5605,16 @@ func (m *DeleteRangeRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.DryRun {
+		dAtA[i] = 0x20
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -5513,6 +5963,16 @@ func (m *TxnRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.DryRun {
+		dAtA[i] = 0x20
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -5849,6 +6309,20 @@ func (m *WatchRequest_CancelRequest) MarshalTo(dAtA []byte) (int, error) {
 	}
 	return i, nil
 }
+func (m *WatchRequest_AckRequest) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.AckRequest != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.AckRequest.Size()))
+		n24, err := m.AckRequest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	return i, nil
+}
 func (m *WatchCreateRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5892,21 +6366,21 @@ func (m *WatchCreateRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 	}
 	if len(m.Filters) > 0 {
-		dAtA25 := make([]byte, len(m.Filters)*10)
-		var j24 int
+		dAtA26 := make([]byte, len(m.Filters)*10)
+		var j25 int
 		for _, num := range m.Filters {
 			for num >= 1<<7 {
-				dAtA25[j24] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j24++
+				j25++
 			}
-			dAtA25[j24] = uint8(num)
-			j24++
+			dAtA26[j25] = uint8(num)
+			j25++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintRpc(dAtA, i, uint64(j24))
-		i += copy(dAtA[i:], dAtA25[:j24])
+		i = encodeVarintRpc(dAtA, i, uint64(j25))
+		i += copy(dAtA[i:], dAtA26[:j25])
 	}
 	if m.PrevKv {
 		dAtA[i] = 0x30
@@ -5944,7 +6418,7 @@ func (m *WatchCancelRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *WatchResponse) Marshal() (dAtA []byte, err error) {
+func (m *WatchAckRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5954,7 +6428,35 @@ func (m *WatchResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WatchResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *WatchAckRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.WatchId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.WatchId))
+	}
+	if m.Revision != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.Revision))
+	}
+	return i, nil
+}
+
+func (m *WatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5963,11 +6465,11 @@ func (m *WatchResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n26, err := m.Header.MarshalTo(dAtA[i:])
+		n27, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n27
 	}
 	if m.WatchId != 0 {
 		dAtA[i] = 0x10
@@ -6045,6 +6547,22 @@ func (m *LeaseGrantRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.ID))
 	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
+	if m.KeepKeys {
+		dAtA[i] = 0x20
+		i++
+		if m.KeepKeys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -6067,11 +6585,11 @@ func (m *LeaseGrantResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n27, err := m.Header.MarshalTo(dAtA[i:])
+		n28, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n28
 	}
 	if m.ID != 0 {
 		dAtA[i] = 0x10
@@ -6134,11 +6652,11 @@ func (m *LeaseRevokeResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n28, err := m.Header.MarshalTo(dAtA[i:])
+		n29, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n29
 	}
 	return i, nil
 }
@@ -6185,11 +6703,11 @@ func (m *LeaseKeepAliveResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n29, err := m.Header.MarshalTo(dAtA[i:])
+		n30, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n30
 	}
 	if m.ID != 0 {
 		dAtA[i] = 0x10
@@ -6256,11 +6774,11 @@ func (m *LeaseTimeToLiveResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n30, err := m.Header.MarshalTo(dAtA[i:])
+		n31, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n31
 	}
 	if m.ID != 0 {
 		dAtA[i] = 0x10
@@ -6285,6 +6803,12 @@ func (m *LeaseTimeToLiveResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], b)
 		}
 	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
 	return i, nil
 }
 
@@ -6326,6 +6850,12 @@ func (m *LeaseStatus) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.ID))
 	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
 	return i, nil
 }
 
@@ -6348,11 +6878,11 @@ func (m *LeaseLeasesResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n31, err := m.Header.MarshalTo(dAtA[i:])
+		n32, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n32
 	}
 	if len(m.Leases) > 0 {
 		for _, msg := range m.Leases {
@@ -6480,21 +7010,21 @@ func (m *MemberAddResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n32, err := m.Header.MarshalTo(dAtA[i:])
+		n33, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n32
+		i += n33
 	}
 	if m.Member != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Member.Size()))
-		n33, err := m.Member.MarshalTo(dAtA[i:])
+		n34, err := m.Member.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
+		i += n34
 	}
 	if len(m.Members) > 0 {
 		for _, msg := range m.Members {
@@ -6553,11 +7083,11 @@ func (m *MemberRemoveResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n34, err := m.Header.MarshalTo(dAtA[i:])
+		n35, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n34
+		i += n35
 	}
 	if len(m.Members) > 0 {
 		for _, msg := range m.Members {
@@ -6631,11 +7161,11 @@ func (m *MemberUpdateResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n35, err := m.Header.MarshalTo(dAtA[i:])
+		n36, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n35
+		i += n36
 	}
 	if len(m.Members) > 0 {
 		for _, msg := range m.Members {
@@ -6689,11 +7219,11 @@ func (m *MemberListResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n36, err := m.Header.MarshalTo(dAtA[i:])
+		n37, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n36
+		i += n37
 	}
 	if len(m.Members) > 0 {
 		for _, msg := range m.Members {
@@ -6747,11 +7277,11 @@ func (m *DefragmentResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n37, err := m.Header.MarshalTo(dAtA[i:])
+		n38, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n37
+		i += n38
 	}
 	return i, nil
 }
@@ -6798,11 +7328,90 @@ func (m *MoveLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n38, err := m.Header.MarshalTo(dAtA[i:])
+		n39, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n38
+		i += n39
+	}
+	return i, nil
+}
+
+func (m *DowngradeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DowngradeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Action != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.Action))
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	return i, nil
+}
+
+func (m *DowngradeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DowngradeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
+		n40, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if m.Enabled {
+		dAtA[i] = 0x18
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.TargetVersion) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TargetVersion)))
+		i += copy(dAtA[i:], m.TargetVersion)
 	}
 	return i, nil
 }
@@ -6887,11 +7496,11 @@ func (m *AlarmResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n39, err := m.Header.MarshalTo(dAtA[i:])
+		n41, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n39
+		i += n41
 	}
 	if len(m.Alarms) > 0 {
 		for _, msg := range m.Alarms {
@@ -6945,11 +7554,11 @@ func (m *StatusResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n40, err := m.Header.MarshalTo(dAtA[i:])
+		n42, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
+		i += n42
 	}
 	if len(m.Version) > 0 {
 		dAtA[i] = 0x12
@@ -6977,6 +7586,37 @@ func (m *StatusResponse) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.RaftTerm))
 	}
+	if m.MemoryBytes != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.MemoryBytes))
+	}
+	if m.DbSizeInUse != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.DbSizeInUse))
+	}
+	if len(m.StorageVersion) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.StorageVersion)))
+		i += copy(dAtA[i:], m.StorageVersion)
+	}
+	if m.ActiveWatchers != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.ActiveWatchers))
+	}
+	if m.ActiveLeases != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.ActiveLeases))
+	}
+	if m.UptimeSeconds != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintRpc(dAtA, i, uint64(m.UptimeSeconds))
+	}
 	return i, nil
 }
 
@@ -7347,11 +7987,11 @@ func (m *AuthRoleGrantPermissionRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Perm.Size()))
-		n41, err := m.Perm.MarshalTo(dAtA[i:])
+		n43, err := m.Perm.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n43
 	}
 	return i, nil
 }
@@ -7411,11 +8051,11 @@ func (m *AuthEnableResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n42, err := m.Header.MarshalTo(dAtA[i:])
+		n44, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n42
+		i += n44
 	}
 	return i, nil
 }
@@ -7439,11 +8079,11 @@ func (m *AuthDisableResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n43, err := m.Header.MarshalTo(dAtA[i:])
+		n45, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n45
 	}
 	return i, nil
 }
@@ -7467,11 +8107,11 @@ func (m *AuthenticateResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n44, err := m.Header.MarshalTo(dAtA[i:])
+		n46, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n44
+		i += n46
 	}
 	if len(m.Token) > 0 {
 		dAtA[i] = 0x12
@@ -7501,11 +8141,11 @@ func (m *AuthUserAddResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n45, err := m.Header.MarshalTo(dAtA[i:])
+		n47, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n47
 	}
 	return i, nil
 }
@@ -7529,11 +8169,11 @@ func (m *AuthUserGetResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n46, err := m.Header.MarshalTo(dAtA[i:])
+		n48, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n48
 	}
 	if len(m.Roles) > 0 {
 		for _, s := range m.Roles {
@@ -7572,11 +8212,11 @@ func (m *AuthUserDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n47, err := m.Header.MarshalTo(dAtA[i:])
+		n49, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n49
 	}
 	return i, nil
 }
@@ -7600,11 +8240,11 @@ func (m *AuthUserChangePasswordResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n48, err := m.Header.MarshalTo(dAtA[i:])
+		n50, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n50
 	}
 	return i, nil
 }
@@ -7628,11 +8268,11 @@ func (m *AuthUserGrantRoleResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n49, err := m.Header.MarshalTo(dAtA[i:])
+		n51, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += n51
 	}
 	return i, nil
 }
@@ -7656,11 +8296,11 @@ func (m *AuthUserRevokeRoleResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n50, err := m.Header.MarshalTo(dAtA[i:])
+		n52, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n52
 	}
 	return i, nil
 }
@@ -7684,11 +8324,11 @@ func (m *AuthRoleAddResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n51, err := m.Header.MarshalTo(dAtA[i:])
+		n53, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n51
+		i += n53
 	}
 	return i, nil
 }
@@ -7712,11 +8352,11 @@ func (m *AuthRoleGetResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n52, err := m.Header.MarshalTo(dAtA[i:])
+		n54, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n52
+		i += n54
 	}
 	if len(m.Perm) > 0 {
 		for _, msg := range m.Perm {
@@ -7752,11 +8392,11 @@ func (m *AuthRoleListResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n53, err := m.Header.MarshalTo(dAtA[i:])
+		n55, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n55
 	}
 	if len(m.Roles) > 0 {
 		for _, s := range m.Roles {
@@ -7795,11 +8435,11 @@ func (m *AuthUserListResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n54, err := m.Header.MarshalTo(dAtA[i:])
+		n56, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n56
 	}
 	if len(m.Users) > 0 {
 		for _, s := range m.Users {
@@ -7838,11 +8478,11 @@ func (m *AuthRoleDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n55, err := m.Header.MarshalTo(dAtA[i:])
+		n57, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n55
+		i += n57
 	}
 	return i, nil
 }
@@ -7866,11 +8506,11 @@ func (m *AuthRoleGrantPermissionResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n56, err := m.Header.MarshalTo(dAtA[i:])
+		n58, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n58
 	}
 	return i, nil
 }
@@ -7894,11 +8534,11 @@ func (m *AuthRoleRevokePermissionResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpc(dAtA, i, uint64(m.Header.Size()))
-		n57, err := m.Header.MarshalTo(dAtA[i:])
+		n59, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n57
+		i += n59
 	}
 	return i, nil
 }
@@ -7992,6 +8632,14 @@ func (m *RangeRequest) Size() (n int) {
 	if m.MaxCreateRevision != 0 {
 		n += 1 + sovRpc(uint64(m.MaxCreateRevision))
 	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.IndexValue)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -8040,10 +8688,22 @@ func (m *PutRequest) Size() (n int) {
 	if m.IgnoreLease {
 		n += 2
 	}
-	return n
-}
-
-func (m *PutResponse) Size() (n int) {
+	if m.ExpireAt != 0 {
+		n += 1 + sovRpc(uint64(m.ExpireAt))
+	}
+	if m.ExpectedVersion != 0 {
+		n += 1 + sovRpc(uint64(m.ExpectedVersion))
+	}
+	if m.ExpectedModRevision != 0 {
+		n += 1 + sovRpc(uint64(m.ExpectedModRevision))
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PutResponse) Size() (n int) {
 	var l int
 	_ = l
 	if m.Header != nil {
@@ -8054,6 +8714,9 @@ func (m *PutResponse) Size() (n int) {
 		l = m.PrevKv.Size()
 		n += 1 + l + sovRpc(uint64(l))
 	}
+	if m.Conflict {
+		n += 2
+	}
 	return n
 }
 
@@ -8071,6 +8734,9 @@ func (m *DeleteRangeRequest) Size() (n int) {
 	if m.PrevKv {
 		n += 2
 	}
+	if m.DryRun {
+		n += 2
+	}
 	return n
 }
 
@@ -8260,6 +8926,9 @@ func (m *TxnRequest) Size() (n int) {
 			n += 1 + l + sovRpc(uint64(l))
 		}
 	}
+	if m.DryRun {
+		n += 2
+	}
 	return n
 }
 
@@ -8398,6 +9067,15 @@ func (m *WatchRequest_CancelRequest) Size() (n int) {
 	}
 	return n
 }
+func (m *WatchRequest_AckRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.AckRequest != nil {
+		l = m.AckRequest.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
 func (m *WatchCreateRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -8437,6 +9115,18 @@ func (m *WatchCancelRequest) Size() (n int) {
 	return n
 }
 
+func (m *WatchAckRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.WatchId != 0 {
+		n += 1 + sovRpc(uint64(m.WatchId))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovRpc(uint64(m.Revision))
+	}
+	return n
+}
+
 func (m *WatchResponse) Size() (n int) {
 	var l int
 	_ = l
@@ -8478,6 +9168,13 @@ func (m *LeaseGrantRequest) Size() (n int) {
 	if m.ID != 0 {
 		n += 1 + sovRpc(uint64(m.ID))
 	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.KeepKeys {
+		n += 2
+	}
 	return n
 }
 
@@ -8579,6 +9276,10 @@ func (m *LeaseTimeToLiveResponse) Size() (n int) {
 			n += 1 + l + sovRpc(uint64(l))
 		}
 	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -8594,6 +9295,10 @@ func (m *LeaseStatus) Size() (n int) {
 	if m.ID != 0 {
 		n += 1 + sovRpc(uint64(m.ID))
 	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -8783,6 +9488,40 @@ func (m *MoveLeaderResponse) Size() (n int) {
 	return n
 }
 
+func (m *DowngradeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Action != 0 {
+		n += 1 + sovRpc(uint64(m.Action))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *DowngradeResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.Enabled {
+		n += 2
+	}
+	l = len(m.TargetVersion)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
 func (m *AlarmRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -8855,6 +9594,25 @@ func (m *StatusResponse) Size() (n int) {
 	if m.RaftTerm != 0 {
 		n += 1 + sovRpc(uint64(m.RaftTerm))
 	}
+	if m.MemoryBytes != 0 {
+		n += 1 + sovRpc(uint64(m.MemoryBytes))
+	}
+	if m.DbSizeInUse != 0 {
+		n += 1 + sovRpc(uint64(m.DbSizeInUse))
+	}
+	l = len(m.StorageVersion)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.ActiveWatchers != 0 {
+		n += 1 + sovRpc(uint64(m.ActiveWatchers))
+	}
+	if m.ActiveLeases != 0 {
+		n += 1 + sovRpc(uint64(m.ActiveLeases))
+	}
+	if m.UptimeSeconds != 0 {
+		n += 1 + sovRpc(uint64(m.UptimeSeconds))
+	}
 	return n
 }
 
@@ -9664,6 +10422,66 @@ func (m *RangeRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IndexValue = append(m.IndexValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.IndexValue == nil {
+				m.IndexValue = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -10008,6 +10826,83 @@ func (m *PutRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IgnoreLease = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
+			}
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
+			}
+			m.ExpectedVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedVersion |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedModRevision", wireType)
+			}
+			m.ExpectedModRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedModRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -10124,6 +11019,26 @@ func (m *PutResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Conflict", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Conflict = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -10256,6 +11171,26 @@ func (m *DeleteRangeRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.PrevKv = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -11148,6 +12083,26 @@ func (m *TxnRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -12093,9 +13048,41 @@ func (m *WatchRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.RequestUnion = &WatchRequest_CancelRequest{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpc(dAtA[iNdEx:])
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &WatchAckRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.RequestUnion = &WatchRequest_AckRequest{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
@@ -12416,6 +13403,94 @@ func (m *WatchCancelRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WatchAckRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchAckRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchAckRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatchId", wireType)
+			}
+			m.WatchId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WatchId |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *WatchResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -12704,6 +13779,57 @@ func (m *LeaseGrantRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepKeys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeepKeys = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -13454,6 +14580,37 @@ func (m *LeaseTimeToLiveResponse) Unmarshal(dAtA []byte) error {
 			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
 			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -13573,6 +14730,37 @@ func (m *LeaseStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -14934,7 +16122,7 @@ func (m *MoveLeaderResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
+func (m *DowngradeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14957,10 +16145,10 @@ func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AlarmRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DowngradeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AlarmRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DowngradeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -14977,16 +16165,16 @@ func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Action |= (AlarmRequest_AlarmAction(b) & 0x7F) << shift
+				m.Action |= (DowngradeRequest_DowngradeAction(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.MemberID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -14996,30 +16184,21 @@ func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MemberID |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Alarm", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
 			}
-			m.Alarm = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Alarm |= (AlarmType(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -15041,7 +16220,7 @@ func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AlarmMember) Unmarshal(dAtA []byte) error {
+func (m *DowngradeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15064,10 +16243,278 @@ func (m *AlarmMember) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AlarmMember: wiretype end group for non-group")
+			return fmt.Errorf("proto: DowngradeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AlarmMember: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DowngradeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AlarmRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AlarmRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AlarmRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= (AlarmRequest_AlarmAction(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Alarm", wireType)
+			}
+			m.Alarm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Alarm |= (AlarmType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AlarmMember) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AlarmMember: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AlarmMember: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -15460,6 +16907,130 @@ func (m *StatusResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryBytes", wireType)
+			}
+			m.MemoryBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DbSizeInUse", wireType)
+			}
+			m.DbSizeInUse = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DbSizeInUse |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveWatchers", wireType)
+			}
+			m.ActiveWatchers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveWatchers |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveLeases", wireType)
+			}
+			m.ActiveLeases = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveLeases |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UptimeSeconds", wireType)
+			}
+			m.UptimeSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UptimeSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -18448,235 +20019,262 @@ var (
 func init() { proto.RegisterFile("rpc.proto", fileDescriptorRpc) }
 
 var fileDescriptorRpc = []byte{
-	// 3674 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5b, 0x5b, 0x6f, 0x1b, 0xc7,
-	0x77, 0xd7, 0x92, 0x22, 0x29, 0x1e, 0x5e, 0x44, 0x8d, 0x64, 0x9b, 0xa2, 0x6d, 0x59, 0x1e, 0xdf,
-	0x64, 0x3b, 0x16, 0xff, 0x7f, 0x25, 0xed, 0x83, 0x5b, 0x04, 0x91, 0x25, 0xc6, 0x52, 0x24, 0x4b,
-	0xca, 0x8a, 0x56, 0x52, 0x20, 0x28, 0xb1, 0x22, 0xc7, 0xd2, 0x42, 0xe4, 0x2e, 0xb3, 0xbb, 0xa4,
-	0xa5, 0x34, 0x2d, 0x8a, 0x20, 0x41, 0xd1, 0x02, 0x7d, 0x69, 0x1e, 0x7a, 0x7b, 0x2c, 0x8a, 0x22,
-	0x2f, 0x7d, 0x2b, 0xfa, 0x15, 0x8a, 0xbe, 0xb4, 0x40, 0xbf, 0x40, 0x91, 0xf6, 0xa5, 0xdf, 0xa1,
-	0x45, 0xff, 0x98, 0xdb, 0xee, 0xec, 0x72, 0x97, 0x52, 0xc2, 0x24, 0x2f, 0xf2, 0xce, 0x99, 0x33,
-	0xe7, 0x9c, 0x39, 0x33, 0xe7, 0x9c, 0x99, 0xdf, 0xd0, 0x90, 0x77, 0xfa, 0xed, 0xd5, 0xbe, 0x63,
-	0x7b, 0x36, 0x2a, 0x12, 0xaf, 0xdd, 0x71, 0x89, 0x33, 0x24, 0x4e, 0xff, 0xb8, 0xb6, 0x70, 0x62,
-	0x9f, 0xd8, 0xac, 0xa3, 0x4e, 0xbf, 0x38, 0x4f, 0x6d, 0x91, 0xf2, 0xd4, 0x7b, 0xc3, 0x76, 0x9b,
-	0xfd, 0xe9, 0x1f, 0xd7, 0xcf, 0x86, 0xa2, 0xeb, 0x26, 0xeb, 0x32, 0x06, 0xde, 0x29, 0xfb, 0xd3,
-	0x3f, 0x66, 0xff, 0x88, 0xce, 0x5b, 0x27, 0xb6, 0x7d, 0xd2, 0x25, 0x75, 0xa3, 0x6f, 0xd6, 0x0d,
-	0xcb, 0xb2, 0x3d, 0xc3, 0x33, 0x6d, 0xcb, 0xe5, 0xbd, 0xf8, 0x1b, 0x0d, 0xca, 0x3a, 0x71, 0xfb,
-	0xb6, 0xe5, 0x92, 0x2d, 0x62, 0x74, 0x88, 0x83, 0x6e, 0x03, 0xb4, 0xbb, 0x03, 0xd7, 0x23, 0x4e,
-	0xcb, 0xec, 0x54, 0xb5, 0x65, 0x6d, 0x65, 0x5a, 0xcf, 0x0b, 0xca, 0x76, 0x07, 0xdd, 0x84, 0x7c,
-	0x8f, 0xf4, 0x8e, 0x79, 0x6f, 0x8a, 0xf5, 0xce, 0x70, 0xc2, 0x76, 0x07, 0xd5, 0x60, 0xc6, 0x21,
-	0x43, 0xd3, 0x35, 0x6d, 0xab, 0x9a, 0x5e, 0xd6, 0x56, 0xd2, 0xba, 0xdf, 0xa6, 0x03, 0x1d, 0xe3,
-	0x8d, 0xd7, 0xf2, 0x88, 0xd3, 0xab, 0x4e, 0xf3, 0x81, 0x94, 0xd0, 0x24, 0x4e, 0x0f, 0x7f, 0x9d,
-	0x81, 0xa2, 0x6e, 0x58, 0x27, 0x44, 0x27, 0x9f, 0x0f, 0x88, 0xeb, 0xa1, 0x0a, 0xa4, 0xcf, 0xc8,
-	0x05, 0x53, 0x5f, 0xd4, 0xe9, 0x27, 0x1f, 0x6f, 0x9d, 0x90, 0x16, 0xb1, 0xb8, 0xe2, 0x22, 0x1d,
-	0x6f, 0x9d, 0x90, 0x86, 0xd5, 0x41, 0x0b, 0x90, 0xe9, 0x9a, 0x3d, 0xd3, 0x13, 0x5a, 0x79, 0x23,
-	0x64, 0xce, 0x74, 0xc4, 0x9c, 0x0d, 0x00, 0xd7, 0x76, 0xbc, 0x96, 0xed, 0x74, 0x88, 0x53, 0xcd,
-	0x2c, 0x6b, 0x2b, 0xe5, 0xb5, 0xfb, 0xab, 0xea, 0x42, 0xac, 0xaa, 0x06, 0xad, 0x1e, 0xda, 0x8e,
-	0xb7, 0x4f, 0x79, 0xf5, 0xbc, 0x2b, 0x3f, 0xd1, 0x87, 0x50, 0x60, 0x42, 0x3c, 0xc3, 0x39, 0x21,
-	0x5e, 0x35, 0xcb, 0xa4, 0x3c, 0xb8, 0x44, 0x4a, 0x93, 0x31, 0xeb, 0x4c, 0x3d, 0xff, 0x46, 0x18,
-	0x8a, 0x2e, 0x71, 0x4c, 0xa3, 0x6b, 0x7e, 0x61, 0x1c, 0x77, 0x49, 0x35, 0xb7, 0xac, 0xad, 0xcc,
-	0xe8, 0x21, 0x1a, 0x9d, 0xff, 0x19, 0xb9, 0x70, 0x5b, 0xb6, 0xd5, 0xbd, 0xa8, 0xce, 0x30, 0x86,
-	0x19, 0x4a, 0xd8, 0xb7, 0xba, 0x17, 0x6c, 0xd1, 0xec, 0x81, 0xe5, 0xf1, 0xde, 0x3c, 0xeb, 0xcd,
-	0x33, 0x0a, 0xeb, 0x5e, 0x81, 0x4a, 0xcf, 0xb4, 0x5a, 0x3d, 0xbb, 0xd3, 0xf2, 0x1d, 0x02, 0xcc,
-	0x21, 0xe5, 0x9e, 0x69, 0xbd, 0xb2, 0x3b, 0xba, 0x74, 0x0b, 0xe5, 0x34, 0xce, 0xc3, 0x9c, 0x05,
-	0xc1, 0x69, 0x9c, 0xab, 0x9c, 0xab, 0x30, 0x4f, 0x65, 0xb6, 0x1d, 0x62, 0x78, 0x24, 0x60, 0x2e,
-	0x32, 0xe6, 0xb9, 0x9e, 0x69, 0x6d, 0xb0, 0x9e, 0x10, 0xbf, 0x71, 0x3e, 0xc2, 0x5f, 0x12, 0xfc,
-	0xc6, 0x79, 0x98, 0x1f, 0xaf, 0x42, 0xde, 0xf7, 0x39, 0x9a, 0x81, 0xe9, 0xbd, 0xfd, 0xbd, 0x46,
-	0x65, 0x0a, 0x01, 0x64, 0xd7, 0x0f, 0x37, 0x1a, 0x7b, 0x9b, 0x15, 0x0d, 0x15, 0x20, 0xb7, 0xd9,
-	0xe0, 0x8d, 0x14, 0x7e, 0x01, 0x10, 0x78, 0x17, 0xe5, 0x20, 0xbd, 0xd3, 0xf8, 0xbd, 0xca, 0x14,
-	0xe5, 0x39, 0x6a, 0xe8, 0x87, 0xdb, 0xfb, 0x7b, 0x15, 0x8d, 0x0e, 0xde, 0xd0, 0x1b, 0xeb, 0xcd,
-	0x46, 0x25, 0x45, 0x39, 0x5e, 0xed, 0x6f, 0x56, 0xd2, 0x28, 0x0f, 0x99, 0xa3, 0xf5, 0xdd, 0xd7,
-	0x8d, 0xca, 0x34, 0xfe, 0x56, 0x83, 0x92, 0x58, 0x2f, 0x1e, 0x13, 0xe8, 0x3d, 0xc8, 0x9e, 0xb2,
-	0xb8, 0x60, 0x5b, 0xb1, 0xb0, 0x76, 0x2b, 0xb2, 0xb8, 0xa1, 0xd8, 0xd1, 0x05, 0x2f, 0xc2, 0x90,
-	0x3e, 0x1b, 0xba, 0xd5, 0xd4, 0x72, 0x7a, 0xa5, 0xb0, 0x56, 0x59, 0xe5, 0x01, 0xbb, 0xba, 0x43,
-	0x2e, 0x8e, 0x8c, 0xee, 0x80, 0xe8, 0xb4, 0x13, 0x21, 0x98, 0xee, 0xd9, 0x0e, 0x61, 0x3b, 0x76,
-	0x46, 0x67, 0xdf, 0x74, 0x1b, 0xb3, 0x45, 0x13, 0xbb, 0x95, 0x37, 0xf0, 0x77, 0x1a, 0xc0, 0xc1,
-	0xc0, 0x4b, 0x0e, 0x8d, 0x05, 0xc8, 0x0c, 0xa9, 0x60, 0x11, 0x16, 0xbc, 0xc1, 0x62, 0x82, 0x18,
-	0x2e, 0xf1, 0x63, 0x82, 0x36, 0xd0, 0x0d, 0xc8, 0xf5, 0x1d, 0x32, 0x6c, 0x9d, 0x0d, 0x99, 0x92,
-	0x19, 0x3d, 0x4b, 0x9b, 0x3b, 0x43, 0x74, 0x17, 0x8a, 0xe6, 0x89, 0x65, 0x3b, 0xa4, 0xc5, 0x65,
-	0x65, 0x58, 0x6f, 0x81, 0xd3, 0x98, 0xdd, 0x0a, 0x0b, 0x17, 0x9c, 0x55, 0x59, 0x76, 0x29, 0x09,
-	0x5b, 0x50, 0x60, 0xa6, 0x4e, 0xe4, 0xbe, 0xc7, 0x81, 0x8d, 0x29, 0x36, 0x6c, 0xd4, 0x85, 0xc2,
-	0x6a, 0xfc, 0x19, 0xa0, 0x4d, 0xd2, 0x25, 0x1e, 0x99, 0x24, 0x7b, 0x28, 0x3e, 0x49, 0xab, 0x3e,
-	0xc1, 0x7f, 0xa1, 0xc1, 0x7c, 0x48, 0xfc, 0x44, 0xd3, 0xaa, 0x42, 0xae, 0xc3, 0x84, 0x71, 0x0b,
-	0xd2, 0xba, 0x6c, 0xa2, 0xa7, 0x30, 0x23, 0x0c, 0x70, 0xab, 0xe9, 0x84, 0x4d, 0x93, 0xe3, 0x36,
-	0xb9, 0xf8, 0xbb, 0x14, 0xe4, 0xc5, 0x44, 0xf7, 0xfb, 0x68, 0x1d, 0x4a, 0x0e, 0x6f, 0xb4, 0xd8,
-	0x7c, 0x84, 0x45, 0xb5, 0xe4, 0x24, 0xb4, 0x35, 0xa5, 0x17, 0xc5, 0x10, 0x46, 0x46, 0xbf, 0x03,
-	0x05, 0x29, 0xa2, 0x3f, 0xf0, 0x84, 0xcb, 0xab, 0x61, 0x01, 0xc1, 0xfe, 0xdb, 0x9a, 0xd2, 0x41,
-	0xb0, 0x1f, 0x0c, 0x3c, 0xd4, 0x84, 0x05, 0x39, 0x98, 0xcf, 0x46, 0x98, 0x91, 0x66, 0x52, 0x96,
-	0xc3, 0x52, 0x46, 0x97, 0x6a, 0x6b, 0x4a, 0x47, 0x62, 0xbc, 0xd2, 0xa9, 0x9a, 0xe4, 0x9d, 0xf3,
-	0xe4, 0x3d, 0x62, 0x52, 0xf3, 0xdc, 0x1a, 0x35, 0xa9, 0x79, 0x6e, 0xbd, 0xc8, 0x43, 0x4e, 0xb4,
-	0xf0, 0x3f, 0xa7, 0x00, 0xe4, 0x6a, 0xec, 0xf7, 0xd1, 0x26, 0x94, 0x1d, 0xd1, 0x0a, 0x79, 0xeb,
-	0x66, 0xac, 0xb7, 0xc4, 0x22, 0x4e, 0xe9, 0x25, 0x39, 0x88, 0x1b, 0xf7, 0x3e, 0x14, 0x7d, 0x29,
-	0x81, 0xc3, 0x16, 0x63, 0x1c, 0xe6, 0x4b, 0x28, 0xc8, 0x01, 0xd4, 0x65, 0x9f, 0xc0, 0x35, 0x7f,
-	0x7c, 0x8c, 0xcf, 0xee, 0x8e, 0xf1, 0x99, 0x2f, 0x70, 0x5e, 0x4a, 0x50, 0xbd, 0xa6, 0x1a, 0x16,
-	0xb8, 0x6d, 0x31, 0xc6, 0x6d, 0xa3, 0x86, 0x51, 0xc7, 0x01, 0xad, 0x97, 0xbc, 0x89, 0xff, 0x27,
-	0x0d, 0xb9, 0x0d, 0xbb, 0xd7, 0x37, 0x1c, 0xba, 0x1a, 0x59, 0x87, 0xb8, 0x83, 0xae, 0xc7, 0xdc,
-	0x55, 0x5e, 0xbb, 0x17, 0x96, 0x28, 0xd8, 0xe4, 0xbf, 0x3a, 0x63, 0xd5, 0xc5, 0x10, 0x3a, 0x58,
-	0x94, 0xc7, 0xd4, 0x15, 0x06, 0x8b, 0xe2, 0x28, 0x86, 0xc8, 0x40, 0x4e, 0x07, 0x81, 0x5c, 0x83,
-	0xdc, 0x90, 0x38, 0x41, 0x49, 0xdf, 0x9a, 0xd2, 0x25, 0x01, 0x3d, 0x86, 0xd9, 0x68, 0x79, 0xc9,
-	0x08, 0x9e, 0x72, 0x3b, 0x5c, 0x8d, 0xee, 0x41, 0x31, 0x54, 0xe3, 0xb2, 0x82, 0xaf, 0xd0, 0x53,
-	0x4a, 0xdc, 0x75, 0x99, 0x57, 0x69, 0x3d, 0x2e, 0x6e, 0x4d, 0xc9, 0xcc, 0x7a, 0x5d, 0x66, 0xd6,
-	0x19, 0x31, 0x4a, 0xe4, 0xd6, 0x50, 0x92, 0xf9, 0x20, 0x9c, 0x64, 0xf0, 0x07, 0x50, 0x0a, 0x39,
-	0x88, 0xd6, 0x9d, 0xc6, 0xc7, 0xaf, 0xd7, 0x77, 0x79, 0x91, 0x7a, 0xc9, 0xea, 0x92, 0x5e, 0xd1,
-	0x68, 0xad, 0xdb, 0x6d, 0x1c, 0x1e, 0x56, 0x52, 0xa8, 0x04, 0xf9, 0xbd, 0xfd, 0x66, 0x8b, 0x73,
-	0xa5, 0xf1, 0x4b, 0x5f, 0x82, 0x28, 0x72, 0x4a, 0x6d, 0x9b, 0x52, 0x6a, 0x9b, 0x26, 0x6b, 0x5b,
-	0x2a, 0xa8, 0x6d, 0xac, 0xcc, 0xed, 0x36, 0xd6, 0x0f, 0x1b, 0x95, 0xe9, 0x17, 0x65, 0x28, 0x72,
-	0xff, 0xb6, 0x06, 0x16, 0x2d, 0xb5, 0x7f, 0xa7, 0x01, 0x04, 0xd1, 0x84, 0xea, 0x90, 0x6b, 0x73,
-	0x3d, 0x55, 0x8d, 0x25, 0xa3, 0x6b, 0xb1, 0x4b, 0xa6, 0x4b, 0x2e, 0xf4, 0x6b, 0xc8, 0xb9, 0x83,
-	0x76, 0x9b, 0xb8, 0xb2, 0xe4, 0xdd, 0x88, 0xe6, 0x43, 0x91, 0xad, 0x74, 0xc9, 0x47, 0x87, 0xbc,
-	0x31, 0xcc, 0xee, 0x80, 0x15, 0xc0, 0xf1, 0x43, 0x04, 0x1f, 0xfe, 0x6b, 0x0d, 0x0a, 0xca, 0xe6,
-	0xfd, 0x91, 0x49, 0xf8, 0x16, 0xe4, 0x99, 0x0d, 0xa4, 0x23, 0xd2, 0xf0, 0x8c, 0x1e, 0x10, 0xd0,
-	0x6f, 0x43, 0x5e, 0x46, 0x80, 0xcc, 0xc4, 0xd5, 0x78, 0xb1, 0xfb, 0x7d, 0x3d, 0x60, 0xc5, 0x3b,
-	0x30, 0xc7, 0xbc, 0xd2, 0xa6, 0x87, 0x6b, 0xe9, 0x47, 0xf5, 0xf8, 0xa9, 0x45, 0x8e, 0x9f, 0x35,
-	0x98, 0xe9, 0x9f, 0x5e, 0xb8, 0x66, 0xdb, 0xe8, 0x0a, 0x2b, 0xfc, 0x36, 0xfe, 0x08, 0x90, 0x2a,
-	0x6c, 0x92, 0xe9, 0xe2, 0x12, 0x14, 0xb6, 0x0c, 0xf7, 0x54, 0x98, 0x84, 0x9f, 0x42, 0x89, 0x36,
-	0x77, 0x8e, 0xae, 0x60, 0x23, 0xbb, 0x1c, 0x48, 0xee, 0x89, 0x7c, 0x8e, 0x60, 0xfa, 0xd4, 0x70,
-	0x4f, 0xd9, 0x44, 0x4b, 0x3a, 0xfb, 0x46, 0x8f, 0xa1, 0xd2, 0xe6, 0x93, 0x6c, 0x45, 0xae, 0x0c,
-	0xb3, 0x82, 0xee, 0x9f, 0x04, 0x3f, 0x85, 0x22, 0x9f, 0xc3, 0x4f, 0x6d, 0x04, 0x9e, 0x83, 0xd9,
-	0x43, 0xcb, 0xe8, 0xbb, 0xa7, 0xb6, 0xac, 0x6e, 0x74, 0xd2, 0x95, 0x80, 0x36, 0x91, 0xc6, 0x47,
-	0x30, 0xeb, 0x90, 0x9e, 0x61, 0x5a, 0xa6, 0x75, 0xd2, 0x3a, 0xbe, 0xf0, 0x88, 0x2b, 0x2e, 0x4c,
-	0x65, 0x9f, 0xfc, 0x82, 0x52, 0xa9, 0x69, 0xc7, 0x5d, 0xfb, 0x58, 0xa4, 0x39, 0xf6, 0x8d, 0xff,
-	0x49, 0x83, 0xe2, 0x27, 0x86, 0xd7, 0x96, 0x4b, 0x87, 0xb6, 0xa1, 0xec, 0x27, 0x37, 0x46, 0x11,
-	0xb6, 0x44, 0x4a, 0x2c, 0x1b, 0x23, 0x8f, 0xd2, 0xb2, 0x3a, 0x96, 0xda, 0x2a, 0x81, 0x89, 0x32,
-	0xac, 0x36, 0xe9, 0xfa, 0xa2, 0x52, 0xc9, 0xa2, 0x18, 0xa3, 0x2a, 0x4a, 0x25, 0xbc, 0x98, 0x0d,
-	0x8e, 0x1f, 0x3c, 0x97, 0xfc, 0x4d, 0x0a, 0xd0, 0xa8, 0x0d, 0x3f, 0xf4, 0x44, 0xf6, 0x00, 0xca,
-	0xae, 0x67, 0x38, 0x23, 0x7b, 0xa3, 0xc4, 0xa8, 0x7e, 0x82, 0x7e, 0x04, 0xb3, 0x7d, 0xc7, 0x3e,
-	0x71, 0x88, 0xeb, 0xb6, 0x2c, 0xdb, 0x33, 0xdf, 0x5c, 0x88, 0x43, 0x6d, 0x59, 0x92, 0xf7, 0x18,
-	0x15, 0x35, 0x20, 0xf7, 0xc6, 0xec, 0x7a, 0xc4, 0x71, 0xab, 0x99, 0xe5, 0xf4, 0x4a, 0x79, 0xed,
-	0xe9, 0x65, 0x5e, 0x5b, 0xfd, 0x90, 0xf1, 0x37, 0x2f, 0xfa, 0x44, 0x97, 0x63, 0xd5, 0x83, 0x62,
-	0x36, 0x74, 0x50, 0x7c, 0x00, 0x10, 0xf0, 0xd3, 0x54, 0xbb, 0xb7, 0x7f, 0xf0, 0xba, 0x59, 0x99,
-	0x42, 0x45, 0x98, 0xd9, 0xdb, 0xdf, 0x6c, 0xec, 0x36, 0x68, 0x5e, 0xc6, 0x75, 0xe9, 0x1b, 0xd5,
-	0x87, 0x68, 0x11, 0x66, 0xde, 0x52, 0xaa, 0xbc, 0x6f, 0xa7, 0xf5, 0x1c, 0x6b, 0x6f, 0x77, 0xf0,
-	0x9f, 0xa7, 0xa0, 0x24, 0x76, 0xc1, 0x44, 0x5b, 0x51, 0x55, 0x91, 0x0a, 0xa9, 0xa0, 0xa7, 0x52,
-	0xbe, 0x3b, 0x3a, 0xe2, 0xf0, 0x2b, 0x9b, 0x34, 0x37, 0xf0, 0xc5, 0x26, 0x1d, 0xe1, 0x56, 0xbf,
-	0x1d, 0x1b, 0xbe, 0x99, 0xd8, 0xf0, 0x45, 0xf7, 0xa0, 0xe4, 0xef, 0x36, 0xc3, 0x15, 0xb5, 0x36,
-	0xaf, 0x17, 0xe5, 0x46, 0xa2, 0x34, 0xf4, 0x00, 0xb2, 0x64, 0x48, 0x2c, 0xcf, 0xad, 0x16, 0x58,
-	0xd6, 0x2d, 0xc9, 0xf3, 0x6f, 0x83, 0x52, 0x75, 0xd1, 0x89, 0x7f, 0x0b, 0xe6, 0xd8, 0x3d, 0xe3,
-	0xa5, 0x63, 0x58, 0xea, 0x85, 0xa8, 0xd9, 0xdc, 0x15, 0xae, 0xa3, 0x9f, 0xa8, 0x0c, 0xa9, 0xed,
-	0x4d, 0x31, 0xd1, 0xd4, 0xf6, 0x26, 0xfe, 0x4a, 0x03, 0xa4, 0x8e, 0x9b, 0xc8, 0x97, 0x11, 0xe1,
-	0x52, 0x7d, 0x3a, 0x50, 0xbf, 0x00, 0x19, 0xe2, 0x38, 0xb6, 0xc3, 0xbc, 0x96, 0xd7, 0x79, 0x03,
-	0xdf, 0x17, 0x36, 0xe8, 0x64, 0x68, 0x9f, 0xf9, 0x81, 0xc1, 0xa5, 0x69, 0xbe, 0xa9, 0x3b, 0x30,
-	0x1f, 0xe2, 0x9a, 0x28, 0xfb, 0x3f, 0x82, 0x6b, 0x4c, 0xd8, 0x0e, 0x21, 0xfd, 0xf5, 0xae, 0x39,
-	0x4c, 0xd4, 0xda, 0x87, 0xeb, 0x51, 0xc6, 0x9f, 0xd7, 0x47, 0xf8, 0x77, 0x85, 0xc6, 0xa6, 0xd9,
-	0x23, 0x4d, 0x7b, 0x37, 0xd9, 0x36, 0x9a, 0x1d, 0xcf, 0xc8, 0x85, 0x2b, 0xca, 0x24, 0xfb, 0xc6,
-	0x7f, 0xaf, 0xc1, 0x8d, 0x91, 0xe1, 0x3f, 0xf3, 0xaa, 0x2e, 0x01, 0x9c, 0xd0, 0xed, 0x43, 0x3a,
-	0xb4, 0x83, 0xdf, 0xd0, 0x15, 0x8a, 0x6f, 0x27, 0x4d, 0x30, 0x45, 0x61, 0xe7, 0x82, 0x58, 0x73,
-	0xf6, 0xc7, 0x95, 0x35, 0xe6, 0x36, 0x14, 0x18, 0xe1, 0xd0, 0x33, 0xbc, 0x81, 0x3b, 0xb2, 0x18,
-	0x7f, 0x24, 0xb6, 0x80, 0x1c, 0x34, 0xd1, 0xbc, 0x7e, 0x0d, 0x59, 0x76, 0x38, 0x95, 0x47, 0xb3,
-	0xc8, 0x6d, 0x40, 0xb1, 0x43, 0x17, 0x8c, 0xf8, 0x14, 0xb2, 0xaf, 0x18, 0xa2, 0xa7, 0x58, 0x36,
-	0x2d, 0x97, 0xc2, 0x32, 0x7a, 0x1c, 0x67, 0xc8, 0xeb, 0xec, 0x9b, 0x9d, 0x64, 0x08, 0x71, 0x5e,
-	0xeb, 0xbb, 0xfc, 0xc4, 0x94, 0xd7, 0xfd, 0x36, 0x75, 0x59, 0xbb, 0x6b, 0x12, 0xcb, 0x63, 0xbd,
-	0xd3, 0xac, 0x57, 0xa1, 0xe0, 0x55, 0xa8, 0x70, 0x4d, 0xeb, 0x9d, 0x8e, 0x72, 0x22, 0xf1, 0xe5,
-	0x69, 0x61, 0x79, 0xf8, 0x1f, 0x34, 0x98, 0x53, 0x06, 0x4c, 0xe4, 0x98, 0x77, 0x20, 0xcb, 0x71,
-	0x4b, 0x51, 0xfc, 0x16, 0xc2, 0xa3, 0xb8, 0x1a, 0x5d, 0xf0, 0xa0, 0x55, 0xc8, 0xf1, 0x2f, 0x79,
-	0x2c, 0x8c, 0x67, 0x97, 0x4c, 0xf8, 0x01, 0xcc, 0x0b, 0x12, 0xe9, 0xd9, 0x71, 0x7b, 0x9b, 0x39,
-	0x14, 0x7f, 0x09, 0x0b, 0x61, 0xb6, 0x89, 0xa6, 0xa4, 0x18, 0x99, 0xba, 0x8a, 0x91, 0xeb, 0xd2,
-	0xc8, 0xd7, 0xfd, 0x8e, 0x52, 0xab, 0xa3, 0xab, 0xae, 0xae, 0x48, 0x2a, 0xb2, 0x22, 0xfe, 0x04,
-	0xa4, 0x88, 0x5f, 0x74, 0x02, 0xf3, 0x72, 0x3b, 0xec, 0x9a, 0xae, 0x7f, 0x82, 0xfb, 0x02, 0x90,
-	0x4a, 0xfc, 0xa5, 0x0d, 0xda, 0x24, 0x6f, 0x1c, 0xe3, 0xa4, 0x47, 0xfc, 0xfa, 0x44, 0xcf, 0xf3,
-	0x2a, 0x71, 0xa2, 0x8c, 0x5e, 0x87, 0xb9, 0x57, 0xf6, 0x90, 0xa6, 0x06, 0x4a, 0x0d, 0x42, 0x86,
-	0xdf, 0xe7, 0xfc, 0x65, 0xf3, 0xdb, 0x54, 0xb9, 0x3a, 0x60, 0x22, 0xe5, 0xff, 0xa6, 0x41, 0x71,
-	0xbd, 0x6b, 0x38, 0x3d, 0xa9, 0xf8, 0x7d, 0xc8, 0xf2, 0x5b, 0x8a, 0x00, 0x06, 0x1e, 0x86, 0xc5,
-	0xa8, 0xbc, 0xbc, 0xb1, 0xce, 0xef, 0x34, 0x62, 0x14, 0x35, 0x5c, 0xbc, 0x1d, 0x6c, 0x46, 0xde,
-	0x12, 0x36, 0xd1, 0x33, 0xc8, 0x18, 0x74, 0x08, 0x4b, 0xc1, 0xe5, 0xe8, 0xfd, 0x90, 0x49, 0x63,
-	0x87, 0x33, 0xce, 0x85, 0xdf, 0x83, 0x82, 0xa2, 0x81, 0xde, 0x80, 0x5f, 0x36, 0xc4, 0x01, 0x6c,
-	0x7d, 0xa3, 0xb9, 0x7d, 0xc4, 0x2f, 0xc6, 0x65, 0x80, 0xcd, 0x86, 0xdf, 0x4e, 0xe1, 0x4f, 0xc5,
-	0x28, 0x91, 0xef, 0x54, 0x7b, 0xb4, 0x24, 0x7b, 0x52, 0x57, 0xb2, 0xe7, 0x1c, 0x4a, 0x62, 0xfa,
-	0x93, 0xa6, 0x6f, 0x26, 0x2f, 0x21, 0x7d, 0x2b, 0xc6, 0xeb, 0x82, 0x11, 0xcf, 0x42, 0x49, 0x24,
-	0x74, 0xb1, 0xff, 0xfe, 0x55, 0x83, 0xb2, 0xa4, 0x4c, 0x0a, 0x60, 0x4a, 0xec, 0x85, 0x57, 0x00,
-	0x1f, 0x79, 0xb9, 0x0e, 0xd9, 0xce, 0xf1, 0xa1, 0xf9, 0x85, 0x04, 0x9b, 0x45, 0x8b, 0xd2, 0xbb,
-	0x5c, 0x0f, 0x7f, 0xf1, 0x11, 0x2d, 0x7a, 0x0b, 0x77, 0x8c, 0x37, 0xde, 0xb6, 0xd5, 0x21, 0xe7,
-	0xec, 0xdc, 0x38, 0xad, 0x07, 0x04, 0x76, 0x29, 0x15, 0x2f, 0x43, 0xec, 0xb0, 0xa8, 0xbe, 0x14,
-	0xcd, 0xc3, 0xdc, 0xfa, 0xc0, 0x3b, 0x6d, 0x58, 0xc6, 0x71, 0x57, 0x66, 0x2c, 0x5a, 0x66, 0x29,
-	0x71, 0xd3, 0x74, 0x55, 0x6a, 0x03, 0xe6, 0x29, 0x95, 0x58, 0x9e, 0xd9, 0x56, 0xd2, 0x9b, 0x2c,
-	0x62, 0x5a, 0xa4, 0x88, 0x19, 0xae, 0xfb, 0xd6, 0x76, 0x3a, 0x62, 0x6a, 0x7e, 0x1b, 0x6f, 0x72,
-	0xe1, 0xaf, 0xdd, 0x50, 0x99, 0xfa, 0xa1, 0x52, 0x56, 0x02, 0x29, 0x2f, 0x89, 0x37, 0x46, 0x0a,
-	0x7e, 0x0a, 0xd7, 0x24, 0xa7, 0x00, 0xf7, 0xc6, 0x30, 0xef, 0xc3, 0x6d, 0xc9, 0xbc, 0x71, 0x4a,
-	0x6f, 0x4f, 0x07, 0x42, 0xe1, 0x8f, 0xb5, 0xf3, 0x05, 0x54, 0x7d, 0x3b, 0xd9, 0x61, 0xd9, 0xee,
-	0xaa, 0x06, 0x0c, 0x5c, 0xb1, 0x67, 0xf2, 0x3a, 0xfb, 0xa6, 0x34, 0xc7, 0xee, 0xfa, 0x47, 0x02,
-	0xfa, 0x8d, 0x37, 0x60, 0x51, 0xca, 0x10, 0xc7, 0xd8, 0xb0, 0x90, 0x11, 0x83, 0xe2, 0x84, 0x08,
-	0x87, 0xd1, 0xa1, 0xe3, 0xdd, 0xae, 0x72, 0x86, 0x5d, 0xcb, 0x64, 0x6a, 0x8a, 0xcc, 0x6b, 0x7c,
-	0x47, 0x50, 0xc3, 0xd4, 0x8a, 0x21, 0xc8, 0x54, 0x80, 0x4a, 0x16, 0x0b, 0x41, 0xc9, 0x23, 0x0b,
-	0x31, 0x22, 0xfa, 0x33, 0x58, 0xf2, 0x8d, 0xa0, 0x7e, 0x3b, 0x20, 0x4e, 0xcf, 0x74, 0x5d, 0x05,
-	0x0e, 0x8a, 0x9b, 0xf8, 0x43, 0x98, 0xee, 0x13, 0x91, 0x53, 0x0a, 0x6b, 0x68, 0x95, 0xbf, 0xdf,
-	0xae, 0x2a, 0x83, 0x59, 0x3f, 0xee, 0xc0, 0x1d, 0x29, 0x9d, 0x7b, 0x34, 0x56, 0x7c, 0xd4, 0x28,
-	0x79, 0xeb, 0xe6, 0x6e, 0x1d, 0xbd, 0x75, 0xa7, 0xf9, 0xda, 0xfb, 0x10, 0xe5, 0x47, 0xdc, 0x91,
-	0x32, 0xb6, 0x26, 0xaa, 0x15, 0x3b, 0xdc, 0xa7, 0x7e, 0x48, 0x4e, 0x24, 0xec, 0x18, 0x16, 0xc2,
-	0x91, 0x3c, 0x51, 0x1a, 0x5b, 0x80, 0x8c, 0x67, 0x9f, 0x11, 0x99, 0xc4, 0x78, 0x43, 0x1a, 0xec,
-	0x87, 0xf9, 0x44, 0x06, 0x1b, 0x81, 0x30, 0xb6, 0x25, 0x27, 0xb5, 0x97, 0xae, 0xa6, 0x3c, 0x7c,
-	0xf1, 0x06, 0xde, 0x83, 0xeb, 0xd1, 0x34, 0x31, 0x91, 0xc9, 0x47, 0x7c, 0x03, 0xc7, 0x65, 0x92,
-	0x89, 0xe4, 0x7e, 0x1c, 0x24, 0x03, 0x25, 0xa1, 0x4c, 0x24, 0x52, 0x87, 0x5a, 0x5c, 0x7e, 0xf9,
-	0x29, 0xf6, 0xab, 0x9f, 0x6e, 0x26, 0x12, 0xe6, 0x06, 0xc2, 0x26, 0x5f, 0xfe, 0x20, 0x47, 0xa4,
-	0xc7, 0xe6, 0x08, 0x11, 0x24, 0x41, 0x16, 0xfb, 0x19, 0x36, 0x9d, 0xd0, 0x11, 0x24, 0xd0, 0x49,
-	0x75, 0xd0, 0x1a, 0xe2, 0xeb, 0x60, 0x0d, 0xb9, 0xb1, 0xd5, 0xb4, 0x3b, 0xd1, 0x62, 0x7c, 0x12,
-	0xe4, 0xce, 0x91, 0xcc, 0x3c, 0x91, 0xe0, 0x4f, 0x61, 0x39, 0x39, 0x29, 0x4f, 0x22, 0xf9, 0x49,
-	0x1d, 0xf2, 0xfe, 0x81, 0x52, 0xf9, 0xed, 0x43, 0x01, 0x72, 0x7b, 0xfb, 0x87, 0x07, 0xeb, 0x1b,
-	0x0d, 0xfe, 0xe3, 0x87, 0x8d, 0x7d, 0x5d, 0x7f, 0x7d, 0xd0, 0xac, 0xa4, 0xd6, 0xfe, 0x2f, 0x0d,
-	0xa9, 0x9d, 0x23, 0xf4, 0xfb, 0x90, 0xe1, 0x2f, 0x81, 0x63, 0x9e, 0x7f, 0x6b, 0xe3, 0x1e, 0x3b,
-	0xf1, 0xad, 0xaf, 0xfe, 0xe3, 0xbf, 0xbf, 0x4d, 0x5d, 0xc7, 0x73, 0xf5, 0xe1, 0xbb, 0x46, 0xb7,
-	0x7f, 0x6a, 0xd4, 0xcf, 0x86, 0x75, 0x56, 0x20, 0x9e, 0x6b, 0x4f, 0xd0, 0x11, 0xa4, 0x0f, 0x06,
-	0x1e, 0x4a, 0x7c, 0x1b, 0xae, 0x25, 0x3f, 0x82, 0xe2, 0x1a, 0x93, 0xbc, 0x80, 0x67, 0x55, 0xc9,
-	0xfd, 0x81, 0x47, 0xe5, 0x0e, 0xa1, 0xa0, 0xbe, 0x63, 0x5e, 0xfa, 0x6a, 0x5c, 0xbb, 0xfc, 0x8d,
-	0x14, 0x63, 0xa6, 0xef, 0x16, 0xbe, 0xa1, 0xea, 0xe3, 0xcf, 0xad, 0xea, 0x7c, 0x9a, 0xe7, 0x16,
-	0x4a, 0x7c, 0x58, 0xae, 0x25, 0xbf, 0x9d, 0xc6, 0xcf, 0xc7, 0x3b, 0xb7, 0xa8, 0x5c, 0x5b, 0xbc,
-	0x9d, 0xb6, 0x3d, 0x74, 0x27, 0xe6, 0xed, 0x4c, 0x7d, 0x25, 0xaa, 0x2d, 0x27, 0x33, 0x08, 0x4d,
-	0x77, 0x99, 0xa6, 0x9b, 0xf8, 0xba, 0xaa, 0xa9, 0xed, 0xf3, 0x3d, 0xd7, 0x9e, 0xac, 0x9d, 0x42,
-	0x86, 0xc1, 0xc4, 0xa8, 0x25, 0x3f, 0x6a, 0x31, 0x00, 0x77, 0xc2, 0x0e, 0x08, 0x01, 0xcc, 0x78,
-	0x91, 0x69, 0x9b, 0xc7, 0x65, 0x5f, 0x1b, 0x43, 0x8a, 0x9f, 0x6b, 0x4f, 0x56, 0xb4, 0x5f, 0x69,
-	0x6b, 0xff, 0x3b, 0x0d, 0x19, 0x06, 0x1a, 0xa1, 0x3e, 0x40, 0x80, 0xa9, 0x46, 0xe7, 0x39, 0x82,
-	0xd2, 0x46, 0xe7, 0x39, 0x0a, 0xc7, 0xe2, 0x3b, 0x4c, 0xf3, 0x22, 0x5e, 0xf0, 0x35, 0x33, 0x40,
-	0xaa, 0xce, 0x30, 0x36, 0xea, 0xd6, 0xb7, 0x02, 0x37, 0xe3, 0xd1, 0x86, 0xe2, 0x24, 0x86, 0xc0,
-	0xd5, 0xe8, 0x36, 0x89, 0x01, 0x56, 0xf1, 0x3d, 0xa6, 0xf4, 0x36, 0xae, 0xaa, 0xce, 0xe5, 0x7a,
-	0x1d, 0xc6, 0x49, 0x15, 0x7f, 0xad, 0x41, 0x39, 0x8c, 0x8f, 0xa2, 0x7b, 0x31, 0xa2, 0xa3, 0x30,
-	0x6b, 0xed, 0xfe, 0x78, 0xa6, 0x44, 0x13, 0xb8, 0xfe, 0x33, 0x42, 0xfa, 0x06, 0xe5, 0x14, 0xbe,
-	0x47, 0x7f, 0xa2, 0xc1, 0x6c, 0x04, 0xf5, 0x44, 0x71, 0x2a, 0x46, 0x30, 0xd5, 0xda, 0x83, 0x4b,
-	0xb8, 0x84, 0x25, 0x8f, 0x98, 0x25, 0x77, 0xf1, 0xad, 0x51, 0x67, 0x78, 0x66, 0x8f, 0x78, 0xb6,
-	0xb0, 0xc6, 0x5f, 0x09, 0x0e, 0x51, 0xc6, 0xae, 0x44, 0x08, 0xf2, 0x8c, 0x5d, 0x89, 0x30, 0xbe,
-	0x39, 0x6e, 0x25, 0x38, 0x30, 0x49, 0x37, 0xfa, 0xff, 0xa7, 0x21, 0xb7, 0xc1, 0x7f, 0x8c, 0x88,
-	0x3c, 0xc8, 0xfb, 0x60, 0x20, 0x5a, 0x8a, 0x03, 0x66, 0x82, 0x8b, 0x43, 0xed, 0x4e, 0x62, 0xbf,
-	0x50, 0xff, 0x90, 0xa9, 0x5f, 0xc6, 0x37, 0x7d, 0xf5, 0xe2, 0x47, 0x8f, 0x75, 0x0e, 0x01, 0xd4,
-	0x8d, 0x4e, 0x87, 0x4e, 0xfd, 0x8f, 0x35, 0x28, 0xaa, 0x98, 0x1d, 0xba, 0x1b, 0x0b, 0x09, 0xa9,
-	0xb0, 0x5f, 0x0d, 0x8f, 0x63, 0x11, 0xfa, 0x1f, 0x33, 0xfd, 0xf7, 0xf0, 0x52, 0x92, 0x7e, 0x87,
-	0xf1, 0x87, 0x4d, 0xe0, 0xa8, 0x5b, 0xbc, 0x09, 0x21, 0x50, 0x2f, 0xde, 0x84, 0x30, 0x68, 0x77,
-	0xb9, 0x09, 0x03, 0xc6, 0x4f, 0x4d, 0x38, 0x07, 0x08, 0x40, 0x36, 0x14, 0xeb, 0x5c, 0xe5, 0x2a,
-	0x15, 0x0d, 0xfe, 0x51, 0x7c, 0x2e, 0x66, 0xeb, 0x45, 0x74, 0x77, 0x4d, 0x97, 0x26, 0x81, 0xb5,
-	0x7f, 0xcc, 0x42, 0xe1, 0x95, 0x61, 0x5a, 0x1e, 0xb1, 0x0c, 0xab, 0x4d, 0xd0, 0x09, 0x64, 0x58,
-	0xad, 0x8c, 0x66, 0x3c, 0x15, 0x7c, 0x8a, 0x66, 0xbc, 0x10, 0x32, 0x83, 0x1f, 0x30, 0xd5, 0x77,
-	0x70, 0xcd, 0x57, 0xdd, 0x0b, 0xe4, 0xd7, 0x19, 0xaa, 0x42, 0xa7, 0x7c, 0x06, 0x59, 0x01, 0xd8,
-	0x47, 0xa4, 0x85, 0xd0, 0x96, 0xda, 0xad, 0xf8, 0xce, 0xc4, 0x5d, 0xa6, 0xea, 0x72, 0x19, 0x33,
-	0x55, 0xf6, 0x07, 0x00, 0x01, 0x66, 0x18, 0xf5, 0xef, 0x08, 0xc4, 0x58, 0x5b, 0x4e, 0x66, 0x10,
-	0x8a, 0x9f, 0x30, 0xc5, 0xf7, 0xf1, 0x9d, 0x58, 0xc5, 0x1d, 0x7f, 0x00, 0x55, 0xde, 0x86, 0xe9,
-	0x2d, 0xc3, 0x3d, 0x45, 0x91, 0xea, 0xa7, 0xfc, 0x90, 0xa0, 0x56, 0x8b, 0xeb, 0x12, 0xaa, 0xee,
-	0x33, 0x55, 0x4b, 0x78, 0x31, 0x56, 0xd5, 0xa9, 0xe1, 0xd2, 0x62, 0x82, 0x4c, 0xc8, 0xf2, 0x1f,
-	0x17, 0x44, 0xdd, 0x19, 0xfa, 0x81, 0x42, 0xd4, 0x9d, 0xe1, 0xdf, 0x23, 0x5c, 0x51, 0xd5, 0x00,
-	0x66, 0xe4, 0x93, 0x3e, 0xba, 0x1d, 0x59, 0x9e, 0xf0, 0xf3, 0x7f, 0x6d, 0x29, 0xa9, 0x5b, 0x28,
-	0x5c, 0x61, 0x0a, 0x31, 0xbe, 0x1d, 0xbf, 0x7e, 0x82, 0xfd, 0xb9, 0xf6, 0xe4, 0x57, 0x1a, 0xad,
-	0x1a, 0x10, 0x60, 0xaf, 0x23, 0x41, 0x12, 0x85, 0x71, 0x47, 0x82, 0x64, 0x04, 0xb6, 0xc5, 0xef,
-	0x32, 0xed, 0xcf, 0xf0, 0x4a, 0xac, 0x76, 0xcf, 0x31, 0x2c, 0xf7, 0x0d, 0x71, 0x9e, 0x71, 0x90,
-	0xcd, 0x3d, 0x35, 0xfb, 0x34, 0x60, 0xfe, 0xac, 0x02, 0xd3, 0xf4, 0x9c, 0x4a, 0x0b, 0x76, 0x70,
-	0xbd, 0x8f, 0x9a, 0x33, 0x02, 0xaa, 0x45, 0xcd, 0x19, 0x45, 0x06, 0x62, 0x0a, 0x36, 0xfb, 0x11,
-	0x3a, 0x61, 0x5c, 0xd4, 0xf1, 0x1e, 0x14, 0x14, 0x10, 0x00, 0xc5, 0x48, 0x0c, 0x43, 0x76, 0xd1,
-	0x32, 0x11, 0x83, 0x20, 0xe0, 0x65, 0xa6, 0xb4, 0x86, 0xaf, 0x85, 0x95, 0x76, 0x38, 0x1b, 0xd5,
-	0xfa, 0x25, 0x14, 0x55, 0xb4, 0x00, 0xc5, 0x08, 0x8d, 0x60, 0x82, 0xd1, 0xec, 0x18, 0x07, 0x36,
-	0xc4, 0xa4, 0x09, 0xff, 0x27, 0xf7, 0x92, 0x97, 0x6a, 0xff, 0x1c, 0x72, 0x02, 0x43, 0x88, 0x9b,
-	0x6f, 0x18, 0x45, 0x8c, 0x9b, 0x6f, 0x04, 0x80, 0x88, 0x39, 0xfd, 0x31, 0xb5, 0xf4, 0xae, 0x24,
-	0x4b, 0x92, 0x50, 0xf9, 0x92, 0x78, 0x49, 0x2a, 0x03, 0x5c, 0x2c, 0x49, 0xa5, 0x72, 0x4f, 0x1d,
-	0xab, 0xf2, 0x84, 0x78, 0x22, 0xa4, 0xe4, 0x25, 0x10, 0x25, 0x48, 0x54, 0xf3, 0x3f, 0x1e, 0xc7,
-	0x92, 0x78, 0x60, 0x0f, 0xb4, 0x8a, 0xe4, 0x8f, 0xfe, 0x10, 0x20, 0x00, 0x3c, 0xa2, 0x67, 0xb0,
-	0x58, 0xd4, 0x34, 0x7a, 0x06, 0x8b, 0xc7, 0x4c, 0x62, 0x12, 0x49, 0xa0, 0x9c, 0x5f, 0x1a, 0xa8,
-	0xfa, 0xbf, 0xd4, 0x00, 0x8d, 0x02, 0x24, 0xe8, 0x69, 0xbc, 0x8a, 0x58, 0x40, 0xb6, 0xf6, 0xce,
-	0xd5, 0x98, 0x13, 0xeb, 0x45, 0x60, 0x57, 0x9b, 0x0d, 0xe9, 0xbf, 0xa5, 0x96, 0x7d, 0xa3, 0x41,
-	0x29, 0x04, 0xb1, 0xa0, 0x87, 0x09, 0xeb, 0x1c, 0x01, 0x75, 0x6b, 0x8f, 0x2e, 0xe5, 0x4b, 0x3c,
-	0x9f, 0x29, 0xbb, 0x42, 0x1e, 0xd1, 0xff, 0x54, 0x83, 0x72, 0x18, 0x97, 0x41, 0x09, 0x0a, 0x46,
-	0x90, 0xe1, 0xda, 0xca, 0xe5, 0x8c, 0x57, 0x58, 0xad, 0xe0, 0xd4, 0xfe, 0x39, 0xe4, 0x04, 0x9c,
-	0x13, 0x17, 0x16, 0x61, 0x60, 0x39, 0x2e, 0x2c, 0x22, 0x58, 0x50, 0x52, 0x58, 0x38, 0x76, 0x97,
-	0x28, 0x91, 0x28, 0x40, 0x9f, 0x24, 0x95, 0xe3, 0x23, 0x31, 0x82, 0x18, 0x8d, 0x55, 0x19, 0x44,
-	0xa2, 0x84, 0x7c, 0x50, 0x82, 0xc4, 0x4b, 0x22, 0x31, 0x8a, 0x18, 0x25, 0x45, 0x22, 0xd3, 0xaa,
-	0x44, 0x62, 0x80, 0xd0, 0xc4, 0x45, 0xe2, 0x08, 0x6c, 0x1e, 0x17, 0x89, 0xa3, 0x20, 0x4f, 0xd2,
-	0xda, 0x32, 0xe5, 0xa1, 0x48, 0x9c, 0x8f, 0x41, 0x74, 0xd0, 0x3b, 0x09, 0x3e, 0x8d, 0x85, 0xe4,
-	0x6b, 0xcf, 0xae, 0xc8, 0x3d, 0x3e, 0x02, 0xf8, 0x6a, 0xc8, 0x08, 0xf8, 0x5b, 0x0d, 0x16, 0xe2,
-	0x20, 0x21, 0x94, 0xa0, 0x2c, 0x01, 0xcf, 0xaf, 0xad, 0x5e, 0x95, 0xfd, 0x0a, 0x7e, 0xf3, 0x63,
-	0xe2, 0x45, 0xe5, 0x5f, 0xbe, 0x5f, 0xd2, 0xfe, 0xfd, 0xfb, 0x25, 0xed, 0x3f, 0xbf, 0x5f, 0xd2,
-	0xfe, 0xea, 0xbf, 0x96, 0xa6, 0x8e, 0xb3, 0xec, 0x7f, 0x82, 0xbd, 0xfb, 0x9b, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xdd, 0x84, 0xb6, 0xd7, 0x90, 0x36, 0x00, 0x00,
+	// 4110 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7b, 0xdd, 0x6f, 0x1b, 0x49,
+	0x72, 0xb8, 0x86, 0x14, 0xbf, 0x8a, 0x1f, 0xa2, 0x5a, 0xb2, 0x4d, 0xd3, 0xb6, 0x2c, 0xb7, 0xbf,
+	0xb4, 0xf6, 0xae, 0x74, 0xa7, 0x3d, 0xfc, 0xf0, 0xcb, 0xe6, 0x70, 0x58, 0x5a, 0xe2, 0x5a, 0x3a,
+	0xc9, 0xa2, 0x6f, 0x44, 0x69, 0x37, 0x40, 0x10, 0x62, 0x44, 0xb6, 0xa9, 0x81, 0xc8, 0x19, 0xee,
+	0xcc, 0x90, 0x96, 0x36, 0x97, 0x20, 0x38, 0xe4, 0x10, 0xe4, 0x80, 0xbc, 0xe4, 0x1e, 0x92, 0x20,
+	0xaf, 0x01, 0x2e, 0x79, 0xc8, 0x73, 0x10, 0x24, 0x08, 0x90, 0xb7, 0xbc, 0x25, 0x40, 0xfe, 0x81,
+	0x60, 0x93, 0x97, 0xfc, 0x05, 0x79, 0x49, 0x90, 0xa0, 0xbf, 0x66, 0x7a, 0x86, 0x33, 0x94, 0xee,
+	0x78, 0x7b, 0x2f, 0xd2, 0x74, 0x75, 0x75, 0x55, 0x75, 0x55, 0x57, 0x75, 0x75, 0x75, 0x13, 0x0a,
+	0xce, 0xa8, 0xbb, 0x39, 0x72, 0x6c, 0xcf, 0x46, 0x25, 0xe2, 0x75, 0x7b, 0x2e, 0x71, 0x26, 0xc4,
+	0x19, 0x9d, 0xd5, 0x57, 0xfb, 0x76, 0xdf, 0x66, 0x1d, 0x5b, 0xf4, 0x8b, 0xe3, 0xd4, 0xef, 0x52,
+	0x9c, 0xad, 0xe1, 0xa4, 0xdb, 0x65, 0x7f, 0x46, 0x67, 0x5b, 0x17, 0x13, 0xd1, 0x75, 0x8f, 0x75,
+	0x19, 0x63, 0xef, 0x9c, 0xfd, 0x19, 0x9d, 0xb1, 0x7f, 0xa2, 0xf3, 0x7e, 0xdf, 0xb6, 0xfb, 0x03,
+	0xb2, 0x65, 0x8c, 0xcc, 0x2d, 0xc3, 0xb2, 0x6c, 0xcf, 0xf0, 0x4c, 0xdb, 0x72, 0x79, 0x2f, 0xfe,
+	0xb1, 0x06, 0x15, 0x9d, 0xb8, 0x23, 0xdb, 0x72, 0xc9, 0x1e, 0x31, 0x7a, 0xc4, 0x41, 0x0f, 0x00,
+	0xba, 0x83, 0xb1, 0xeb, 0x11, 0xa7, 0x63, 0xf6, 0x6a, 0xda, 0xba, 0xb6, 0xb1, 0xa8, 0x17, 0x04,
+	0x64, 0xbf, 0x87, 0xee, 0x41, 0x61, 0x48, 0x86, 0x67, 0xbc, 0x37, 0xc5, 0x7a, 0xf3, 0x1c, 0xb0,
+	0xdf, 0x43, 0x75, 0xc8, 0x3b, 0x64, 0x62, 0xba, 0xa6, 0x6d, 0xd5, 0xd2, 0xeb, 0xda, 0x46, 0x5a,
+	0xf7, 0xdb, 0x74, 0xa0, 0x63, 0xbc, 0xf3, 0x3a, 0x1e, 0x71, 0x86, 0xb5, 0x45, 0x3e, 0x90, 0x02,
+	0xda, 0xc4, 0x19, 0xe2, 0xbf, 0xcb, 0x40, 0x49, 0x37, 0xac, 0x3e, 0xd1, 0xc9, 0x97, 0x63, 0xe2,
+	0x7a, 0xa8, 0x0a, 0xe9, 0x0b, 0x72, 0xc5, 0xd8, 0x97, 0x74, 0xfa, 0xc9, 0xc7, 0x5b, 0x7d, 0xd2,
+	0x21, 0x16, 0x67, 0x5c, 0xa2, 0xe3, 0xad, 0x3e, 0x69, 0x5a, 0x3d, 0xb4, 0x0a, 0x99, 0x81, 0x39,
+	0x34, 0x3d, 0xc1, 0x95, 0x37, 0x42, 0xe2, 0x2c, 0x46, 0xc4, 0xd9, 0x01, 0x70, 0x6d, 0xc7, 0xeb,
+	0xd8, 0x4e, 0x8f, 0x38, 0xb5, 0xcc, 0xba, 0xb6, 0x51, 0xd9, 0x7e, 0xb2, 0xa9, 0x1a, 0x62, 0x53,
+	0x15, 0x68, 0xf3, 0xd8, 0x76, 0xbc, 0x16, 0xc5, 0xd5, 0x0b, 0xae, 0xfc, 0x44, 0x9f, 0x41, 0x91,
+	0x11, 0xf1, 0x0c, 0xa7, 0x4f, 0xbc, 0x5a, 0x96, 0x51, 0x79, 0x7a, 0x0d, 0x95, 0x36, 0x43, 0xd6,
+	0x19, 0x7b, 0xfe, 0x8d, 0x30, 0x94, 0x5c, 0xe2, 0x98, 0xc6, 0xc0, 0xfc, 0xca, 0x38, 0x1b, 0x90,
+	0x5a, 0x6e, 0x5d, 0xdb, 0xc8, 0xeb, 0x21, 0x18, 0x9d, 0xff, 0x05, 0xb9, 0x72, 0x3b, 0xb6, 0x35,
+	0xb8, 0xaa, 0xe5, 0x19, 0x42, 0x9e, 0x02, 0x5a, 0xd6, 0xe0, 0x8a, 0x19, 0xcd, 0x1e, 0x5b, 0x1e,
+	0xef, 0x2d, 0xb0, 0xde, 0x02, 0x83, 0xb0, 0xee, 0x0d, 0xa8, 0x0e, 0x4d, 0xab, 0x33, 0xb4, 0x7b,
+	0x1d, 0x5f, 0x21, 0xc0, 0x14, 0x52, 0x19, 0x9a, 0xd6, 0x1b, 0xbb, 0xa7, 0x4b, 0xb5, 0x50, 0x4c,
+	0xe3, 0x32, 0x8c, 0x59, 0x14, 0x98, 0xc6, 0xa5, 0x8a, 0xb9, 0x09, 0x2b, 0x94, 0x66, 0xd7, 0x21,
+	0x86, 0x47, 0x02, 0xe4, 0x12, 0x43, 0x5e, 0x1e, 0x9a, 0xd6, 0x0e, 0xeb, 0x09, 0xe1, 0x1b, 0x97,
+	0x53, 0xf8, 0x65, 0x81, 0x6f, 0x5c, 0x46, 0xf0, 0x57, 0x21, 0x63, 0x5a, 0x3d, 0x72, 0x59, 0xab,
+	0xac, 0x6b, 0x1b, 0x05, 0x9d, 0x37, 0xd0, 0x43, 0x28, 0xb2, 0x8f, 0xce, 0xc4, 0x18, 0x8c, 0x49,
+	0x6d, 0x89, 0xad, 0x03, 0x60, 0xa0, 0x53, 0x0a, 0xc1, 0x9b, 0x50, 0xf0, 0x4d, 0x85, 0xf2, 0xb0,
+	0x78, 0xd4, 0x3a, 0x6a, 0x56, 0x17, 0x10, 0x40, 0xb6, 0x71, 0xbc, 0xd3, 0x3c, 0xda, 0xad, 0x6a,
+	0xa8, 0x08, 0xb9, 0xdd, 0x26, 0x6f, 0xa4, 0xf0, 0x2b, 0x80, 0xc0, 0x28, 0x28, 0x07, 0xe9, 0x83,
+	0xe6, 0x6f, 0x54, 0x17, 0x28, 0xce, 0x69, 0x53, 0x3f, 0xde, 0x6f, 0x1d, 0x55, 0x35, 0x3a, 0x78,
+	0x47, 0x6f, 0x36, 0xda, 0xcd, 0x6a, 0x8a, 0x62, 0xbc, 0x69, 0xed, 0x56, 0xd3, 0xa8, 0x00, 0x99,
+	0xd3, 0xc6, 0xe1, 0x49, 0xb3, 0xba, 0x88, 0x7f, 0xaa, 0x41, 0x59, 0x98, 0x99, 0xbb, 0x12, 0xfa,
+	0x0e, 0x64, 0xcf, 0x99, 0x3b, 0xb1, 0x15, 0x5c, 0xdc, 0xbe, 0x1f, 0x59, 0x13, 0x21, 0x97, 0xd3,
+	0x05, 0x2e, 0xc2, 0x90, 0xbe, 0x98, 0xb8, 0xb5, 0xd4, 0x7a, 0x7a, 0xa3, 0xb8, 0x5d, 0xdd, 0xe4,
+	0x7e, 0xbe, 0x79, 0x40, 0xae, 0xd8, 0xd4, 0x74, 0xda, 0x89, 0x10, 0x2c, 0x0e, 0x6d, 0x87, 0xb0,
+	0x85, 0x9e, 0xd7, 0xd9, 0x37, 0x55, 0x15, 0xb3, 0xb5, 0x58, 0xe4, 0xbc, 0x81, 0xff, 0x21, 0x05,
+	0xf0, 0x76, 0xec, 0x25, 0x7b, 0xd4, 0x2a, 0x64, 0xb8, 0x16, 0xb9, 0x37, 0xf1, 0x06, 0x73, 0x25,
+	0x62, 0xb8, 0xc4, 0x77, 0x25, 0xda, 0x40, 0x77, 0x20, 0x37, 0x72, 0xc8, 0xa4, 0x73, 0x31, 0x61,
+	0x4c, 0xf2, 0x7a, 0x96, 0x36, 0x0f, 0x26, 0xe8, 0x11, 0x94, 0xcc, 0xbe, 0x65, 0x3b, 0x44, 0x58,
+	0x24, 0xc3, 0x7a, 0x8b, 0x1c, 0xc6, 0xe4, 0x56, 0x50, 0x38, 0xe1, 0xac, 0x8a, 0x72, 0xc8, 0xc8,
+	0xdf, 0x83, 0x02, 0xb9, 0x1c, 0x99, 0x0e, 0xe9, 0x18, 0x1e, 0x5b, 0xfd, 0x69, 0x3d, 0xcf, 0x01,
+	0x0d, 0x0f, 0x7d, 0x00, 0x55, 0x72, 0x39, 0x22, 0x5d, 0x8f, 0xf4, 0x3a, 0x13, 0xe2, 0xb0, 0x65,
+	0x93, 0x67, 0x38, 0x4b, 0x12, 0x7e, 0xca, 0xc1, 0x68, 0x1b, 0x6e, 0xf9, 0xa8, 0xa1, 0x35, 0x5c,
+	0x60, 0xf8, 0x2b, 0xb2, 0x53, 0x5d, 0xc8, 0x77, 0x20, 0xd7, 0x73, 0xae, 0x3a, 0xce, 0x98, 0xfb,
+	0x44, 0x5e, 0xcf, 0xf6, 0x9c, 0x2b, 0x7d, 0x6c, 0xe1, 0x9f, 0x68, 0x50, 0x64, 0x0a, 0x9c, 0xcb,
+	0xa8, 0x1f, 0x04, 0x9a, 0x4b, 0xb1, 0x61, 0xd3, 0x86, 0x95, 0xba, 0xac, 0x43, 0xbe, 0x6b, 0x5b,
+	0xef, 0x06, 0x66, 0xd7, 0x13, 0xf6, 0xf5, 0xdb, 0x78, 0x0c, 0x68, 0x97, 0x0c, 0x88, 0x47, 0xe6,
+	0x09, 0x93, 0x8a, 0x15, 0xd3, 0x21, 0x2b, 0x2a, 0x3a, 0x58, 0x0c, 0xe9, 0xe0, 0x8f, 0x35, 0x58,
+	0x09, 0xf1, 0x9d, 0x4b, 0x17, 0x35, 0xc8, 0xf5, 0x18, 0x31, 0x2e, 0x5a, 0x5a, 0x97, 0x4d, 0xf4,
+	0x12, 0xf2, 0x42, 0x32, 0xb7, 0x96, 0x4e, 0x58, 0xff, 0x39, 0x2e, 0xac, 0x8b, 0xff, 0x2a, 0x05,
+	0x05, 0xa1, 0x81, 0xd6, 0x08, 0x35, 0xa0, 0xec, 0xf0, 0x46, 0x87, 0x4d, 0x54, 0x48, 0x54, 0x4f,
+	0x0e, 0xc3, 0x7b, 0x0b, 0x7a, 0x49, 0x0c, 0x61, 0x60, 0xf4, 0xeb, 0x50, 0x94, 0x24, 0x46, 0x63,
+	0x4f, 0xd8, 0xa9, 0x16, 0x26, 0x10, 0xb8, 0xd2, 0xde, 0x82, 0x0e, 0x02, 0xfd, 0xed, 0xd8, 0x43,
+	0x6d, 0x58, 0x95, 0x83, 0xf9, 0x6c, 0x84, 0x18, 0x69, 0x46, 0x65, 0x3d, 0x4c, 0x65, 0xda, 0x86,
+	0x7b, 0x0b, 0x3a, 0x12, 0xe3, 0x95, 0x4e, 0x55, 0x24, 0xef, 0x92, 0x5b, 0x65, 0x4a, 0xa4, 0xf6,
+	0xa5, 0x35, 0x2d, 0x52, 0xfb, 0xd2, 0x7a, 0x55, 0x80, 0x9c, 0x68, 0xe1, 0xbf, 0x49, 0x01, 0x48,
+	0x6b, 0xb4, 0x46, 0x68, 0x17, 0x2a, 0x8e, 0x68, 0x85, 0xb4, 0x75, 0x2f, 0x56, 0x5b, 0xc2, 0x88,
+	0x0b, 0x7a, 0x59, 0x0e, 0xe2, 0xc2, 0x7d, 0x0f, 0x4a, 0x3e, 0x95, 0x40, 0x61, 0x77, 0x63, 0x14,
+	0xe6, 0x53, 0x28, 0xca, 0x01, 0x54, 0x65, 0x9f, 0xc3, 0x2d, 0x7f, 0x7c, 0x8c, 0xce, 0x1e, 0xcd,
+	0xd0, 0x99, 0x4f, 0x70, 0x45, 0x52, 0x50, 0xb5, 0xa6, 0x0a, 0x16, 0xa8, 0xed, 0x6e, 0x8c, 0xda,
+	0xa6, 0x05, 0xa3, 0x8a, 0x03, 0x9a, 0x31, 0xf0, 0x26, 0xfe, 0xcf, 0x34, 0xe4, 0x76, 0xec, 0xe1,
+	0xc8, 0x70, 0xa8, 0x35, 0xb2, 0x0e, 0x71, 0xc7, 0x03, 0x8f, 0xa9, 0xab, 0xb2, 0xfd, 0x38, 0x4c,
+	0x51, 0xa0, 0xc9, 0xff, 0x3a, 0x43, 0xd5, 0xc5, 0x10, 0x3a, 0x58, 0x24, 0x08, 0xa9, 0x1b, 0x0c,
+	0x16, 0xe9, 0x81, 0x18, 0x22, 0x3d, 0x3c, 0x1d, 0x78, 0x78, 0x1d, 0x72, 0x32, 0x0a, 0xb2, 0x78,
+	0xbf, 0xb7, 0xa0, 0x4b, 0x00, 0xfa, 0x00, 0x96, 0xa2, 0x1b, 0x6c, 0x46, 0xe0, 0x54, 0xba, 0xe1,
+	0xfd, 0xf5, 0x31, 0x94, 0x42, 0x11, 0x32, 0x2b, 0xf0, 0x8a, 0x43, 0x25, 0x36, 0xde, 0x96, 0x5b,
+	0x04, 0x8d, 0xc9, 0xa5, 0xbd, 0x05, 0xb9, 0x49, 0xdc, 0x96, 0x9b, 0x44, 0x5e, 0x8c, 0x12, 0xdb,
+	0x44, 0x28, 0xfa, 0x7c, 0x1a, 0x8e, 0x3e, 0xf8, 0x53, 0x28, 0x87, 0x14, 0x44, 0xb7, 0xd0, 0xe6,
+	0x0f, 0x4e, 0x1a, 0x87, 0x7c, 0xbf, 0x7d, 0xcd, 0xb6, 0x58, 0xbd, 0xaa, 0xd1, 0x6d, 0xfb, 0xb0,
+	0x79, 0x7c, 0x5c, 0x4d, 0xa1, 0x32, 0x14, 0x8e, 0x5a, 0xed, 0x0e, 0xc7, 0x4a, 0xe3, 0xd7, 0x3e,
+	0x05, 0xb1, 0x5f, 0x2b, 0xdb, 0xf4, 0x82, 0xb2, 0x4d, 0x6b, 0x72, 0x9b, 0x4e, 0x05, 0xdb, 0x34,
+	0xdb, 0xb1, 0x0f, 0x9b, 0x8d, 0xe3, 0x66, 0x75, 0xf1, 0x55, 0x05, 0x4a, 0x5c, 0xbf, 0x9d, 0xb1,
+	0x65, 0xda, 0x16, 0xfe, 0x7b, 0x0d, 0x20, 0xf0, 0x26, 0xb4, 0x05, 0xb9, 0x2e, 0xe7, 0x53, 0xd3,
+	0x58, 0x30, 0xba, 0x15, 0x6b, 0x32, 0x5d, 0x62, 0xa1, 0x6f, 0x43, 0xce, 0x1d, 0x77, 0xbb, 0xc4,
+	0x95, 0xbb, 0xf7, 0x9d, 0x68, 0x3c, 0x14, 0xd1, 0x4a, 0x97, 0x78, 0x74, 0xc8, 0x3b, 0xc3, 0x1c,
+	0x8c, 0xd9, 0x5e, 0x3e, 0x7b, 0x88, 0xc0, 0x4b, 0x8e, 0xd2, 0x7f, 0xa6, 0x41, 0x51, 0x59, 0xd5,
+	0xbf, 0x60, 0x74, 0xbe, 0x0f, 0x05, 0x26, 0x1c, 0xe9, 0x89, 0xf8, 0x9c, 0xd7, 0x03, 0x00, 0xfa,
+	0x7f, 0x50, 0x90, 0xae, 0x21, 0x43, 0x74, 0x2d, 0x9e, 0x6c, 0x6b, 0xa4, 0x07, 0xa8, 0xf8, 0x00,
+	0x96, 0x99, 0xba, 0xba, 0xf4, 0xdc, 0x21, 0x15, 0xac, 0x66, 0xe6, 0x5a, 0x24, 0x33, 0xaf, 0x43,
+	0x7e, 0x74, 0x7e, 0xe5, 0x9a, 0x5d, 0x63, 0x20, 0xa4, 0xf0, 0xdb, 0xf8, 0xfb, 0x80, 0x54, 0x62,
+	0xf3, 0x4c, 0x17, 0x97, 0xa1, 0xb8, 0x67, 0xb8, 0xe7, 0x42, 0x24, 0xfc, 0x12, 0xca, 0xb4, 0x79,
+	0x70, 0x7a, 0x03, 0x19, 0xd9, 0xb9, 0x49, 0x62, 0xcf, 0xa5, 0x73, 0x04, 0x8b, 0xe7, 0x86, 0x7b,
+	0xce, 0x26, 0x5a, 0xd6, 0xd9, 0x37, 0xcd, 0x77, 0xba, 0x7c, 0x92, 0x9d, 0xc8, 0x69, 0x6a, 0x49,
+	0xc0, 0xa5, 0x7f, 0xe2, 0x2f, 0xa0, 0xc4, 0xe7, 0xf0, 0xcb, 0x16, 0x02, 0x2f, 0xc3, 0xd2, 0xb1,
+	0x65, 0x8c, 0xdc, 0x73, 0x5b, 0x6e, 0x7b, 0x74, 0xd2, 0xd5, 0x00, 0x36, 0x17, 0xc7, 0xe7, 0xb0,
+	0xe4, 0x90, 0xa1, 0x61, 0x5a, 0xa6, 0xd5, 0xef, 0x9c, 0x5d, 0x79, 0xc4, 0x15, 0x67, 0xc9, 0x8a,
+	0x0f, 0x7e, 0x45, 0xa1, 0x54, 0xb4, 0xb3, 0x81, 0x7d, 0x26, 0xe2, 0x1f, 0xfb, 0xc6, 0xff, 0xa5,
+	0x41, 0xe9, 0x73, 0xc3, 0xeb, 0x4a, 0xd3, 0xa1, 0x7d, 0xa8, 0xf8, 0x51, 0x8f, 0x41, 0x84, 0x2c,
+	0x91, 0xbd, 0x97, 0x8d, 0x91, 0xa7, 0x0c, 0xb9, 0x6d, 0x96, 0xbb, 0x2a, 0x80, 0x91, 0x32, 0xac,
+	0x2e, 0x19, 0xf8, 0xa4, 0x52, 0xc9, 0xa4, 0x18, 0xa2, 0x4a, 0x4a, 0x05, 0xa0, 0x4f, 0xa1, 0x68,
+	0x74, 0x2f, 0x7c, 0x3a, 0x7c, 0x6b, 0x7b, 0x10, 0x43, 0xa7, 0xd1, 0xbd, 0x50, 0xb6, 0x71, 0xc3,
+	0x6f, 0xbd, 0x5a, 0x0a, 0x32, 0x1b, 0x1e, 0xa6, 0xfe, 0x3a, 0x05, 0x68, 0x7a, 0x16, 0x3f, 0x6f,
+	0x16, 0xf8, 0x14, 0x2a, 0xae, 0x67, 0x38, 0x53, 0xab, 0xab, 0xcc, 0xa0, 0x7e, 0xec, 0x7f, 0x0e,
+	0x4b, 0x23, 0xc7, 0xee, 0x3b, 0xc4, 0x75, 0x3b, 0x96, 0xed, 0x99, 0xef, 0xae, 0x44, 0xd4, 0xa9,
+	0x48, 0xf0, 0x11, 0x83, 0xa2, 0x26, 0xe4, 0xde, 0x99, 0x03, 0x8f, 0x38, 0x6e, 0x2d, 0xb3, 0x9e,
+	0xde, 0xa8, 0x6c, 0xbf, 0xbc, 0x4e, 0xef, 0x9b, 0x9f, 0x31, 0xfc, 0xf6, 0xd5, 0x88, 0xe8, 0x72,
+	0xac, 0x9a, 0x9c, 0x66, 0xd5, 0xe4, 0x14, 0xff, 0x7f, 0x80, 0x00, 0x9f, 0x46, 0xf1, 0xa3, 0xd6,
+	0xdb, 0x93, 0x76, 0x75, 0x01, 0x95, 0x20, 0x7f, 0xd4, 0xda, 0x6d, 0x1e, 0x36, 0x59, 0xc8, 0x5f,
+	0x86, 0xf2, 0x51, 0x8b, 0x05, 0x78, 0x01, 0x4a, 0xe1, 0x2d, 0xa9, 0xae, 0x90, 0x61, 0xee, 0x42,
+	0xfe, 0x3d, 0x85, 0xca, 0xfa, 0x46, 0x5a, 0xcf, 0xb1, 0xf6, 0x7e, 0x0f, 0xef, 0xc1, 0x52, 0xc4,
+	0x24, 0x33, 0xb0, 0x43, 0x11, 0x22, 0x15, 0x89, 0x10, 0x7f, 0x94, 0x82, 0xb2, 0x58, 0xa4, 0x73,
+	0x79, 0x8a, 0xca, 0x3e, 0x15, 0x66, 0x5f, 0x83, 0x1c, 0x5f, 0xbc, 0x3d, 0x91, 0xcd, 0xcb, 0x26,
+	0x3b, 0x48, 0xb0, 0x29, 0x93, 0x9e, 0xb0, 0x99, 0xdf, 0x8e, 0x8d, 0x2e, 0x99, 0xd8, 0xe8, 0x82,
+	0x1e, 0x43, 0xd9, 0x77, 0x06, 0xc3, 0x15, 0x39, 0x42, 0x41, 0x2f, 0xc9, 0x75, 0x4e, 0x61, 0xe8,
+	0x29, 0x64, 0xc9, 0x84, 0x58, 0x9e, 0x5b, 0x2b, 0xb2, 0x4d, 0xa1, 0x2c, 0xf3, 0xf6, 0x26, 0x85,
+	0xea, 0xa2, 0x13, 0x5b, 0xb0, 0xcc, 0x8e, 0x7a, 0xaf, 0x1d, 0xc3, 0x52, 0xcf, 0xa4, 0xed, 0xf6,
+	0xa1, 0x50, 0x2b, 0xfd, 0x44, 0x15, 0x48, 0xed, 0xef, 0x8a, 0x89, 0xa6, 0xf6, 0x77, 0xe9, 0x4c,
+	0x86, 0xc4, 0x33, 0x7a, 0x86, 0x67, 0x88, 0x18, 0xe0, 0xb7, 0x79, 0x45, 0x84, 0x8c, 0x3a, 0x17,
+	0xe4, 0xca, 0x95, 0xd3, 0xa4, 0x80, 0x03, 0x72, 0xe5, 0xe2, 0x1f, 0x69, 0x80, 0x54, 0x86, 0x73,
+	0x19, 0x21, 0x2a, 0x95, 0x90, 0x3b, 0x1d, 0xc8, 0xbd, 0x0a, 0x19, 0xe2, 0x38, 0xb6, 0xc3, 0xe4,
+	0x28, 0xe8, 0xbc, 0x81, 0x9f, 0x08, 0x19, 0x74, 0x32, 0xb1, 0x2f, 0x7c, 0x77, 0xe5, 0xd4, 0x34,
+	0x49, 0x0d, 0x1f, 0xc0, 0x4a, 0x08, 0x6b, 0xae, 0x5d, 0xed, 0x39, 0xdc, 0x62, 0xc4, 0x0e, 0x08,
+	0x19, 0x35, 0x06, 0xe6, 0x24, 0x91, 0xeb, 0x08, 0x6e, 0x47, 0x11, 0xbf, 0x59, 0x1d, 0xe1, 0xef,
+	0x0a, 0x8e, 0x6d, 0x73, 0x48, 0xda, 0xf6, 0x61, 0xb2, 0x6c, 0x34, 0xea, 0x33, 0xa3, 0xf2, 0xed,
+	0x9f, 0x7d, 0xe3, 0x7f, 0xd4, 0xe0, 0xce, 0xd4, 0xf0, 0x6f, 0xd8, 0xaa, 0x6b, 0x00, 0x7d, 0xba,
+	0x7c, 0x48, 0x8f, 0x76, 0xf0, 0xea, 0x8a, 0x02, 0xf1, 0xe5, 0xa4, 0x61, 0xaf, 0xc4, 0xe5, 0x0c,
+	0xad, 0xd8, 0x6c, 0x78, 0xc5, 0xe2, 0x55, 0xb1, 0x1e, 0xd8, 0x1f, 0x57, 0xee, 0xab, 0xbf, 0x06,
+	0x45, 0x06, 0x38, 0xf6, 0x0c, 0x6f, 0xec, 0x4e, 0x29, 0x63, 0x86, 0x0b, 0xe0, 0xdf, 0x15, 0x4b,
+	0x47, 0x12, 0x9c, 0x4b, 0x1f, 0xdf, 0x86, 0x2c, 0xcb, 0xe2, 0x65, 0x0e, 0x1b, 0x39, 0x36, 0x29,
+	0x32, 0xea, 0x02, 0x11, 0x9f, 0x43, 0xf6, 0x0d, 0x2b, 0xfe, 0x2a, 0x52, 0x2f, 0x4a, 0x13, 0x5a,
+	0xc6, 0x90, 0xd7, 0x96, 0x0a, 0x3a, 0xfb, 0x66, 0x99, 0x1d, 0x21, 0xce, 0x89, 0x7e, 0xc8, 0x33,
+	0xc8, 0x82, 0xee, 0xb7, 0xa9, 0xaa, 0xbb, 0x03, 0x93, 0x58, 0x1e, 0xeb, 0x5d, 0x64, 0xbd, 0x0a,
+	0x04, 0x6f, 0x42, 0x95, 0x73, 0x6a, 0xf4, 0x7a, 0x4a, 0x86, 0xe6, 0xd3, 0xd3, 0xc2, 0xf4, 0xf0,
+	0xcf, 0x34, 0x58, 0x56, 0x06, 0xcc, 0xa5, 0x98, 0x0f, 0x21, 0xcb, 0x4b, 0xdc, 0x22, 0x19, 0x58,
+	0x0d, 0x8f, 0xe2, 0x6c, 0x74, 0x81, 0x83, 0x36, 0x21, 0xc7, 0xbf, 0x64, 0x9a, 0x1c, 0x8f, 0x2e,
+	0x91, 0xf0, 0x53, 0x58, 0x11, 0x20, 0x32, 0xb4, 0xe3, 0x7c, 0x82, 0x29, 0x14, 0xff, 0x10, 0x56,
+	0xc3, 0x68, 0x73, 0x4d, 0x49, 0x11, 0x32, 0x75, 0x13, 0x21, 0x1b, 0x52, 0xc8, 0x93, 0x51, 0x4f,
+	0xc9, 0x3c, 0xa2, 0x56, 0x57, 0x2d, 0x92, 0x8a, 0x58, 0xc4, 0x9f, 0x80, 0x24, 0xf1, 0x2b, 0x9d,
+	0xc0, 0x8a, 0x5c, 0x0e, 0x87, 0xa6, 0xeb, 0x67, 0xb4, 0x5f, 0x01, 0x52, 0x81, 0xbf, 0x6a, 0x81,
+	0x76, 0xc9, 0x3b, 0xc7, 0xe8, 0x0f, 0x89, 0xbf, 0x21, 0xd2, 0xf3, 0x8d, 0x0a, 0x9c, 0x6b, 0x27,
+	0xd8, 0x82, 0xe5, 0x37, 0xf6, 0x84, 0x86, 0x06, 0x0a, 0x0d, 0x5c, 0x86, 0x1f, 0x7c, 0x7d, 0xb3,
+	0xf9, 0x6d, 0xca, 0x5c, 0x1d, 0x30, 0x17, 0xf3, 0xbf, 0xd5, 0xa0, 0xba, 0x6b, 0xbf, 0xb7, 0xfa,
+	0x8e, 0xd1, 0xf3, 0x57, 0xcb, 0x67, 0x90, 0xe5, 0x27, 0x37, 0x51, 0x45, 0xd9, 0x8c, 0xd4, 0x79,
+	0x22, 0xf8, 0x01, 0xa0, 0xc1, 0xcf, 0x7b, 0x62, 0x34, 0x4d, 0x7c, 0x64, 0x05, 0x84, 0x87, 0x17,
+	0xd9, 0xc4, 0x3b, 0xb0, 0x14, 0x19, 0x84, 0x00, 0xb2, 0xc7, 0xed, 0x46, 0xfb, 0xe4, 0x98, 0x27,
+	0x8c, 0xa7, 0x8d, 0xc3, 0xfd, 0x5d, 0x5e, 0x23, 0x00, 0xc8, 0x36, 0x8f, 0x1a, 0xaf, 0x0e, 0x9b,
+	0xd5, 0x14, 0xab, 0x1d, 0x34, 0x8e, 0x76, 0x9a, 0x87, 0xd5, 0x34, 0xfe, 0x0b, 0x0d, 0x96, 0x15,
+	0x59, 0xe6, 0xad, 0x78, 0xc6, 0x8b, 0x4a, 0x7b, 0x88, 0x65, 0x9c, 0x0d, 0x82, 0xec, 0x4d, 0x34,
+	0x69, 0x7e, 0x2e, 0x8a, 0x13, 0x6a, 0x9d, 0xa7, 0xa0, 0x97, 0x39, 0x54, 0xd4, 0xba, 0xf1, 0x3f,
+	0x6b, 0x50, 0x6a, 0x0c, 0x0c, 0x67, 0x28, 0xd5, 0xfb, 0xbd, 0x88, 0x7a, 0x9f, 0x85, 0x25, 0x54,
+	0x71, 0x79, 0x23, 0xa2, 0x56, 0xb6, 0xd1, 0xb0, 0x9b, 0xbc, 0xdd, 0xc8, 0xcd, 0xde, 0x2e, 0xfa,
+	0x08, 0x32, 0x06, 0x1d, 0xc2, 0x64, 0xad, 0x44, 0x6b, 0x15, 0x8c, 0x1a, 0xcb, 0xe6, 0x39, 0x16,
+	0xfe, 0x0e, 0x14, 0x15, 0x0e, 0x28, 0x07, 0xe9, 0xd7, 0x4d, 0x91, 0xb1, 0x37, 0x76, 0xda, 0xfb,
+	0xa7, 0xdc, 0x00, 0x15, 0x80, 0xdd, 0xa6, 0xdf, 0x4e, 0xe1, 0x2f, 0xc4, 0x28, 0xb1, 0xa5, 0xa8,
+	0xf2, 0x68, 0x49, 0xf2, 0xa4, 0x6e, 0x24, 0xcf, 0x25, 0x94, 0xc5, 0xf4, 0xe7, 0xdd, 0x21, 0x19,
+	0xbd, 0x84, 0x1d, 0x52, 0x11, 0x5e, 0x17, 0x88, 0x78, 0x09, 0xca, 0x62, 0xcf, 0x14, 0x2e, 0xfe,
+	0x97, 0x69, 0xa8, 0x48, 0xc8, 0x37, 0xb4, 0xb4, 0x6e, 0x43, 0xb6, 0x77, 0x76, 0x6c, 0x7e, 0x25,
+	0xef, 0x70, 0x44, 0x8b, 0xc2, 0x07, 0x9c, 0x0f, 0xbf, 0x7f, 0x15, 0x2d, 0x74, 0x9f, 0x5f, 0xcd,
+	0xee, 0xb3, 0xeb, 0xb6, 0x0c, 0xbf, 0xf1, 0xf5, 0x01, 0xec, 0x94, 0x23, 0xee, 0x69, 0x59, 0x42,
+	0xa3, 0xdc, 0xdb, 0xa2, 0x75, 0x28, 0x0e, 0xc9, 0xd0, 0x76, 0xae, 0xd8, 0x69, 0x5d, 0xdc, 0xdc,
+	0xa8, 0x20, 0x8a, 0xc1, 0xb9, 0xef, 0x5b, 0x27, 0xb2, 0x5e, 0xa8, 0xab, 0x20, 0xf4, 0x8c, 0x1e,
+	0x47, 0x6d, 0xc7, 0xe8, 0x13, 0xb1, 0xb2, 0xd9, 0x65, 0x4d, 0x41, 0x8f, 0x40, 0x29, 0x1e, 0x5d,
+	0xa8, 0x13, 0xc2, 0x8e, 0x55, 0x34, 0xce, 0x8a, 0x2b, 0xcc, 0x30, 0x14, 0x61, 0x28, 0x71, 0x08,
+	0x4f, 0x8a, 0xc4, 0xf5, 0x65, 0x08, 0x86, 0x9e, 0x40, 0x79, 0x3c, 0xf2, 0xcc, 0x21, 0x39, 0x26,
+	0x5d, 0xdb, 0xea, 0xb9, 0xe2, 0xda, 0x32, 0x0c, 0xa4, 0x21, 0xba, 0x31, 0xf6, 0xce, 0x9b, 0xcc,
+	0x2f, 0xa5, 0xfd, 0x56, 0x01, 0x51, 0xe0, 0xae, 0xe9, 0xaa, 0xd0, 0x26, 0xac, 0x50, 0x28, 0xb1,
+	0x3c, 0xb3, 0xab, 0xec, 0x8f, 0x32, 0x0b, 0xd2, 0x22, 0x59, 0x90, 0xe1, 0xba, 0xef, 0x6d, 0xa7,
+	0x27, 0x0c, 0xe7, 0xb7, 0xf1, 0x2e, 0x27, 0x7e, 0xe2, 0x86, 0xf2, 0x9c, 0x9f, 0x97, 0xca, 0x46,
+	0x40, 0xe5, 0x35, 0xf1, 0x66, 0x50, 0xc1, 0x2f, 0xe1, 0x96, 0xc4, 0x14, 0x65, 0xf4, 0x19, 0xc8,
+	0x2d, 0x78, 0x20, 0x91, 0x77, 0xce, 0x0d, 0xab, 0x4f, 0xde, 0x0a, 0x86, 0xbf, 0xa8, 0x9c, 0xaf,
+	0xa0, 0xe6, 0xcb, 0xc9, 0x4e, 0x69, 0xf6, 0x40, 0x15, 0x60, 0xec, 0x0a, 0x8f, 0x28, 0xe8, 0xec,
+	0x9b, 0xc2, 0x1c, 0x7b, 0xe0, 0xe7, 0x94, 0xf4, 0x1b, 0xef, 0xc0, 0x5d, 0x49, 0x43, 0x9c, 0x9f,
+	0xc2, 0x44, 0xa6, 0x04, 0x8a, 0x23, 0x22, 0x14, 0x46, 0x87, 0xce, 0x56, 0xbb, 0x8a, 0x19, 0x56,
+	0x2d, 0xa3, 0xa9, 0x29, 0x34, 0x6f, 0xf1, 0x15, 0x41, 0x05, 0x53, 0x53, 0x0e, 0x01, 0xa6, 0x04,
+	0x54, 0xb0, 0x30, 0x04, 0x05, 0x4f, 0x19, 0x62, 0x8a, 0xf4, 0x6f, 0xc2, 0x9a, 0x2f, 0x04, 0xd5,
+	0xdb, 0x5b, 0xe2, 0x0c, 0x4d, 0xd7, 0x55, 0xea, 0xab, 0x71, 0x13, 0x7f, 0x06, 0x8b, 0x23, 0x22,
+	0x22, 0x66, 0x71, 0x1b, 0x6d, 0xf2, 0xb7, 0x22, 0x9b, 0xca, 0x60, 0xd6, 0x8f, 0x7b, 0xf0, 0x50,
+	0x52, 0xe7, 0x1a, 0x8d, 0x25, 0x1f, 0x15, 0x4a, 0x16, 0xa1, 0xb8, 0x5a, 0xa7, 0x8b, 0x50, 0x69,
+	0x6e, 0x7b, 0xff, 0x32, 0xe0, 0xfb, 0x5c, 0x91, 0xd2, 0xb7, 0xe6, 0x4a, 0x36, 0x0e, 0xb8, 0x4e,
+	0x7d, 0x97, 0x9c, 0x8b, 0xd8, 0x19, 0xac, 0x86, 0x3d, 0x79, 0xae, 0x20, 0xbd, 0x0a, 0x19, 0xcf,
+	0xbe, 0x20, 0x32, 0x44, 0xf3, 0x86, 0x14, 0xd8, 0x77, 0xf3, 0xb9, 0x04, 0x36, 0x02, 0x62, 0x6c,
+	0x49, 0xce, 0x2b, 0x2f, 0xb5, 0xa6, 0xcc, 0xde, 0x79, 0x03, 0x1f, 0xc1, 0xed, 0x68, 0x98, 0x98,
+	0x4b, 0xe4, 0x53, 0xbe, 0x80, 0xe3, 0x22, 0xc9, 0x5c, 0x74, 0x7f, 0x10, 0x04, 0x03, 0x25, 0xa0,
+	0xcc, 0x45, 0x52, 0x87, 0x7a, 0x5c, 0x7c, 0xf9, 0x65, 0xac, 0x57, 0x3f, 0xdc, 0xcc, 0x45, 0xcc,
+	0x0d, 0x88, 0xcd, 0x6f, 0xfe, 0x20, 0x46, 0xa4, 0x67, 0xc6, 0x08, 0xe1, 0x24, 0x41, 0x14, 0xfb,
+	0x06, 0x16, 0x9d, 0xe0, 0x11, 0x04, 0xd0, 0x79, 0x79, 0xd0, 0x3d, 0xc4, 0xe7, 0xc1, 0x1a, 0x72,
+	0x61, 0xab, 0x61, 0x77, 0x2e, 0x63, 0x7c, 0x1e, 0xc4, 0xce, 0xa9, 0xc8, 0x3c, 0x17, 0xe1, 0x2f,
+	0x60, 0x3d, 0x39, 0x28, 0xcf, 0x43, 0xf9, 0xc5, 0x77, 0xa1, 0xe0, 0xa7, 0xcb, 0xca, 0x83, 0xa9,
+	0x22, 0xe4, 0x8e, 0x5a, 0xc7, 0x6f, 0x1b, 0x3b, 0x4d, 0xfe, 0x62, 0x6a, 0xa7, 0xa5, 0xeb, 0x27,
+	0x6f, 0xdb, 0xd5, 0x14, 0x6d, 0xec, 0xb7, 0x9a, 0xba, 0xde, 0xd2, 0xab, 0xe9, 0xed, 0xff, 0x49,
+	0x43, 0xea, 0xe0, 0x14, 0xfd, 0x16, 0x64, 0xf8, 0x05, 0xfc, 0x8c, 0x57, 0x17, 0xf5, 0x59, 0x6f,
+	0x0c, 0xf0, 0xfd, 0x1f, 0xfd, 0xeb, 0x7f, 0xfc, 0x34, 0x75, 0x1b, 0x2f, 0x6f, 0x4d, 0x3e, 0x36,
+	0x06, 0xa3, 0x73, 0x63, 0xeb, 0x62, 0xb2, 0xc5, 0x76, 0x8b, 0x4f, 0xb4, 0x17, 0xe8, 0x14, 0xd2,
+	0x6f, 0xc7, 0x1e, 0x4a, 0x7c, 0x92, 0x51, 0x4f, 0x7e, 0x7b, 0x80, 0xeb, 0x8c, 0xf2, 0x2a, 0x5e,
+	0x52, 0x29, 0x8f, 0xc6, 0x1e, 0xa5, 0x3b, 0x81, 0xa2, 0xfa, 0x7c, 0xe0, 0xda, 0xc7, 0x1a, 0xf5,
+	0xeb, 0x9f, 0x26, 0x60, 0xcc, 0xf8, 0xdd, 0xc7, 0x77, 0x54, 0x7e, 0xfc, 0x95, 0x83, 0x3a, 0x9f,
+	0xf6, 0xa5, 0x85, 0x12, 0xdf, 0x73, 0xd4, 0x93, 0x9f, 0x2c, 0xc4, 0xcf, 0xc7, 0xbb, 0xb4, 0x28,
+	0x5d, 0x5b, 0x3c, 0x59, 0xe8, 0x7a, 0xe8, 0x61, 0xcc, 0x95, 0xb5, 0x7a, 0x07, 0x5b, 0x5f, 0x4f,
+	0x46, 0x10, 0x9c, 0x1e, 0x31, 0x4e, 0xf7, 0xf0, 0x6d, 0x95, 0x53, 0xd7, 0xc7, 0xfb, 0x44, 0x7b,
+	0xb1, 0x7d, 0x0e, 0x19, 0x96, 0x78, 0xa3, 0x8e, 0xfc, 0xa8, 0xc7, 0x5c, 0xfe, 0x24, 0xac, 0x80,
+	0xd0, 0xfd, 0x08, 0xbe, 0xcb, 0xb8, 0xad, 0xe0, 0x8a, 0xcf, 0x8d, 0x5d, 0x74, 0x7c, 0xa2, 0xbd,
+	0xd8, 0xd0, 0xbe, 0xa5, 0x6d, 0xff, 0xf7, 0x22, 0x64, 0xf8, 0x63, 0xb1, 0x11, 0x40, 0x50, 0xd9,
+	0x8f, 0xce, 0x73, 0xea, 0x92, 0x21, 0x3a, 0xcf, 0xe9, 0x4b, 0x01, 0xfc, 0x90, 0x71, 0xbe, 0x8b,
+	0x57, 0x7d, 0xce, 0xac, 0xbc, 0xb9, 0xc5, 0x2a, 0xbd, 0x54, 0xad, 0xef, 0x45, 0x85, 0x96, 0xbb,
+	0x1e, 0x8a, 0xa3, 0x18, 0x2a, 0xf1, 0x47, 0x97, 0x49, 0x4c, 0x79, 0x1f, 0x3f, 0x66, 0x4c, 0x1f,
+	0xe0, 0x9a, 0xaa, 0x5c, 0xce, 0xd7, 0x61, 0x98, 0x94, 0xf1, 0xef, 0x6b, 0x50, 0x09, 0x57, 0xe9,
+	0xd1, 0xe3, 0x18, 0xd2, 0xd1, 0x62, 0x7f, 0xfd, 0xc9, 0x6c, 0xa4, 0x44, 0x11, 0x38, 0xff, 0x0b,
+	0x42, 0x46, 0x06, 0xc5, 0x14, 0xba, 0x47, 0x7f, 0xa0, 0xc1, 0x52, 0xa4, 0xf6, 0x8e, 0xe2, 0x58,
+	0x4c, 0x55, 0xf6, 0xeb, 0x4f, 0xaf, 0xc1, 0x12, 0x92, 0x3c, 0x67, 0x92, 0x3c, 0xc2, 0xf7, 0xa7,
+	0x95, 0x41, 0x0f, 0x64, 0x9e, 0x2d, 0xa4, 0xf1, 0x2d, 0x21, 0xce, 0x71, 0x71, 0x96, 0x08, 0x15,
+	0xd7, 0x63, 0x2d, 0x11, 0xae, 0x96, 0xcf, 0xb2, 0x04, 0x2f, 0x73, 0xd3, 0x85, 0xfe, 0xbf, 0x69,
+	0xc8, 0xed, 0xf0, 0x57, 0xd0, 0xc8, 0x83, 0x82, 0x5f, 0x5a, 0x46, 0x6b, 0x71, 0x65, 0xbe, 0xe0,
+	0x14, 0x51, 0x7f, 0x98, 0xd8, 0x2f, 0xd8, 0x3f, 0x63, 0xec, 0xd7, 0xf1, 0x3d, 0x9f, 0xbd, 0x78,
+	0x6d, 0xbd, 0xc5, 0xab, 0x1d, 0x5b, 0x46, 0xaf, 0x47, 0xa7, 0xfe, 0x7b, 0x1a, 0x94, 0xd4, 0x0a,
+	0x30, 0x7a, 0x14, 0x5b, 0x60, 0x54, 0x8b, 0xc8, 0x75, 0x3c, 0x0b, 0x45, 0xf0, 0xff, 0x80, 0xf1,
+	0x7f, 0x8c, 0xd7, 0x92, 0xf8, 0x3b, 0x0c, 0x3f, 0x2c, 0x02, 0xaf, 0xe1, 0xc6, 0x8b, 0x10, 0x2a,
+	0x11, 0xc7, 0x8b, 0x10, 0x2e, 0x01, 0x5f, 0x2f, 0xc2, 0x98, 0xe1, 0x53, 0x11, 0x2e, 0x01, 0x82,
+	0x92, 0x2d, 0x8a, 0x55, 0xae, 0x72, 0xae, 0x8a, 0x3a, 0xff, 0x74, 0xb5, 0x37, 0x66, 0xe9, 0x45,
+	0x78, 0x0f, 0x4c, 0x97, 0x06, 0x81, 0xed, 0x9f, 0xe5, 0xa0, 0xf8, 0xc6, 0x30, 0x2d, 0x8f, 0x58,
+	0x86, 0xd5, 0x25, 0xa8, 0x0f, 0x19, 0xb6, 0x71, 0x46, 0x23, 0x9e, 0x5a, 0x67, 0x8b, 0x46, 0xbc,
+	0x50, 0x11, 0x0a, 0x3f, 0x65, 0xac, 0x1f, 0xe2, 0xba, 0xcf, 0x7a, 0x18, 0xd0, 0xdf, 0x62, 0x05,
+	0x24, 0x3a, 0xe5, 0x0b, 0xc8, 0x8a, 0xab, 0xa1, 0x08, 0xb5, 0x50, 0x61, 0xa9, 0x7e, 0x3f, 0xbe,
+	0x33, 0x71, 0x95, 0xa9, 0xbc, 0x5c, 0x86, 0x4c, 0x99, 0xfd, 0x36, 0x40, 0x50, 0x81, 0x8e, 0xea,
+	0x77, 0xaa, 0x60, 0x5d, 0x5f, 0x4f, 0x46, 0x10, 0x8c, 0x5f, 0x30, 0xc6, 0x4f, 0xf0, 0xc3, 0x58,
+	0xc6, 0x3d, 0x7f, 0x00, 0x65, 0xde, 0x85, 0xc5, 0x3d, 0xc3, 0x3d, 0x47, 0x91, 0xdd, 0x4f, 0x79,
+	0xa6, 0x53, 0xaf, 0xc7, 0x75, 0x09, 0x56, 0x4f, 0x18, 0xab, 0x35, 0x7c, 0x37, 0x96, 0xd5, 0xb9,
+	0xe1, 0xd2, 0xcd, 0x04, 0x99, 0x90, 0xe5, 0x4f, 0x77, 0xa2, 0xea, 0x0c, 0x3d, 0xff, 0x89, 0xaa,
+	0x33, 0xfc, 0xda, 0xe7, 0x86, 0xac, 0xc6, 0x90, 0x97, 0x0f, 0x66, 0x50, 0xe4, 0xe5, 0x47, 0xe4,
+	0x71, 0x4d, 0x7d, 0x2d, 0xa9, 0x5b, 0x30, 0xdc, 0x60, 0x0c, 0x31, 0x7e, 0x10, 0x6f, 0x3f, 0x81,
+	0xfe, 0x89, 0xf6, 0xe2, 0x5b, 0x1a, 0xdd, 0x35, 0x20, 0xa8, 0xe4, 0x4f, 0x39, 0x49, 0xf4, 0x52,
+	0x60, 0xca, 0x49, 0xa6, 0x2e, 0x01, 0xf0, 0xc7, 0x8c, 0xfb, 0x47, 0x78, 0x23, 0x96, 0xbb, 0xe7,
+	0x18, 0x96, 0xfb, 0x8e, 0x38, 0x1f, 0xf1, 0x7a, 0xa2, 0x7b, 0x6e, 0x8e, 0x78, 0xac, 0x2e, 0xf8,
+	0x65, 0xf4, 0x68, 0x98, 0x8c, 0xd6, 0xfa, 0xa3, 0x61, 0x72, 0xaa, 0xfe, 0x1e, 0x13, 0x23, 0x42,
+	0xeb, 0x48, 0xe2, 0x53, 0x4f, 0xfd, 0x49, 0x15, 0x16, 0x69, 0xb6, 0x4c, 0x33, 0x85, 0xa0, 0xc8,
+	0x10, 0xd5, 0xc3, 0x54, 0x69, 0x2f, 0xaa, 0x87, 0xe9, 0xfa, 0x44, 0x4c, 0xa6, 0xc0, 0x7e, 0x76,
+	0xc3, 0x2b, 0xf7, 0x74, 0xce, 0x1e, 0x14, 0x95, 0x52, 0x04, 0x8a, 0xa1, 0x18, 0x2e, 0x1c, 0x46,
+	0xf7, 0xa7, 0x98, 0x3a, 0x06, 0x5e, 0x67, 0x4c, 0xeb, 0xf8, 0x56, 0x98, 0x69, 0x8f, 0xa3, 0x51,
+	0xae, 0x3f, 0x84, 0x92, 0x5a, 0xb3, 0x40, 0x31, 0x44, 0x23, 0x95, 0xc9, 0x68, 0x58, 0x8e, 0x2b,
+	0x79, 0xc4, 0xc4, 0x27, 0xff, 0x47, 0x46, 0x12, 0x97, 0x72, 0xff, 0x12, 0x72, 0xa2, 0x92, 0x11,
+	0x37, 0xdf, 0x70, 0x2d, 0x33, 0x6e, 0xbe, 0x91, 0x32, 0x48, 0x4c, 0xda, 0xc9, 0xd8, 0xd2, 0x13,
+	0x9b, 0xdc, 0x0b, 0x05, 0xcb, 0xd7, 0xc4, 0x4b, 0x62, 0x19, 0x54, 0xe7, 0x92, 0x58, 0x2a, 0xa7,
+	0xe5, 0x99, 0x2c, 0xfb, 0xc4, 0x13, 0xbe, 0x2c, 0x8f, 0xa2, 0x28, 0x81, 0xa2, 0xba, 0xf1, 0xe0,
+	0x59, 0x28, 0x89, 0x27, 0x85, 0x80, 0xab, 0xd8, 0x75, 0xd0, 0xef, 0x00, 0x04, 0x65, 0x97, 0x68,
+	0xf2, 0x17, 0x5b, 0xbb, 0x8d, 0x26, 0x7f, 0xf1, 0x95, 0x9b, 0x98, 0x08, 0x16, 0x30, 0xe7, 0xa7,
+	0x15, 0xca, 0xfe, 0x4f, 0x34, 0x40, 0xd3, 0x65, 0x1a, 0xf4, 0x32, 0x9e, 0x45, 0x6c, 0x59, 0xb8,
+	0xfe, 0xe1, 0xcd, 0x90, 0x13, 0x37, 0xaa, 0x40, 0xae, 0x2e, 0x1b, 0x32, 0x7a, 0x4f, 0x25, 0xfb,
+	0xb1, 0x06, 0xe5, 0x50, 0xa1, 0x07, 0x3d, 0x4b, 0xb0, 0x73, 0xa4, 0xb4, 0x5c, 0x7f, 0x7e, 0x2d,
+	0x5e, 0x62, 0x62, 0xa8, 0xac, 0x0a, 0x79, 0x36, 0xf8, 0x43, 0x0d, 0x2a, 0xe1, 0xea, 0x10, 0x4a,
+	0x60, 0x30, 0x55, 0x9f, 0xae, 0x6f, 0x5c, 0x8f, 0x78, 0x03, 0x6b, 0x05, 0xc7, 0x85, 0x2f, 0x21,
+	0x27, 0x8a, 0x4a, 0x71, 0x6e, 0x11, 0x2e, 0x6f, 0xc7, 0xb9, 0x45, 0xa4, 0x22, 0x95, 0xe4, 0x16,
+	0x8e, 0x3d, 0x20, 0x8a, 0x27, 0x8a, 0xd2, 0x53, 0x12, 0xcb, 0xd9, 0x9e, 0x18, 0xa9, 0x5b, 0xcd,
+	0x64, 0x19, 0x78, 0xa2, 0x2c, 0x3c, 0xa1, 0x04, 0x8a, 0xd7, 0x78, 0x62, 0xb4, 0x6e, 0x95, 0xe4,
+	0x89, 0x8c, 0xab, 0xe2, 0x89, 0x41, 0x9d, 0x28, 0xce, 0x13, 0xa7, 0x8a, 0xf7, 0x71, 0x9e, 0x38,
+	0x5d, 0x6a, 0x4a, 0xb2, 0x2d, 0x63, 0x1e, 0xf2, 0xc4, 0x95, 0x98, 0xba, 0x12, 0xfa, 0x30, 0x41,
+	0xa7, 0xb1, 0x17, 0x03, 0xf5, 0x8f, 0x6e, 0x88, 0x3d, 0xdb, 0x03, 0xb8, 0x35, 0xa4, 0x07, 0xfc,
+	0xb9, 0x06, 0xab, 0x71, 0x85, 0x29, 0x94, 0xc0, 0x2c, 0xe1, 0x56, 0xa1, 0xbe, 0x79, 0x53, 0xf4,
+	0x1b, 0xe8, 0xcd, 0xf7, 0x89, 0x57, 0xd5, 0x7f, 0xfa, 0x7a, 0x4d, 0xfb, 0x97, 0xaf, 0xd7, 0xb4,
+	0x7f, 0xfb, 0x7a, 0x4d, 0xfb, 0xd3, 0x7f, 0x5f, 0x5b, 0x38, 0xcb, 0xb2, 0xdf, 0xbe, 0x7e, 0xfc,
+	0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xab, 0xc0, 0x72, 0xf2, 0x82, 0x3b, 0x00, 0x00,
 }
@@ -0,0 +1,86 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+const (
+	// expirySweepInterval is how often the leader looks for expired keys.
+	expirySweepInterval = 500 * time.Millisecond
+	// maxExpiredKeysPerSweep bounds the keys deleted by one proposal.
+	maxExpiredKeysPerSweep = 128
+)
+
+// sweepExpiredKeys deletes the keys past their expire_at while the member is
+// the leader. Each key is deleted only if it has not been modified since it
+// was found expired, so a key rewritten meanwhile keeps its new expiry.
+func (s *EtcdServer) sweepExpiredKeys() {
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-time.After(expirySweepInterval):
+		}
+		// members below 3.2 cannot apply the expire request
+		if !s.isLeader() || !s.clusterVersionAtLeast(newRequestsVersion) {
+			continue
+		}
+		for {
+			kvs := s.kv.Expired(time.Now().Unix(), maxExpiredKeysPerSweep)
+			if len(kvs) == 0 {
+				break
+			}
+			ctx, cancel := context.WithTimeout(withPriority(s.ctx, prioritySystem), s.Cfg.ReqTimeout())
+			_, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{Expire: expireTxn(kvs)})
+			cancel()
+			if err != nil {
+				plog.Warningf("failed to delete %d expired keys (%v)", len(kvs), err)
+				break
+			}
+			if len(kvs) < maxExpiredKeysPerSweep {
+				break
+			}
+		}
+	}
+}
+
+// expireTxn builds a txn deleting each of the given keys if it is still at
+// its given mod revision.
+func expireTxn(kvs []mvccpb.KeyValue) *pb.TxnRequest {
+	txn := &pb.TxnRequest{Success: make([]*pb.RequestOp, 0, len(kvs))}
+	for _, kv := range kvs {
+		del := &pb.TxnRequest{
+			Compare: []*pb.Compare{{
+				Key:         kv.Key,
+				Target:      pb.Compare_MOD,
+				Result:      pb.Compare_EQUAL,
+				TargetUnion: &pb.Compare_ModRevision{ModRevision: kv.ModRevision},
+			}},
+			Success: []*pb.RequestOp{{
+				Request: &pb.RequestOp_RequestDeleteRange{
+					RequestDeleteRange: &pb.DeleteRangeRequest{Key: kv.Key},
+				},
+			}},
+		}
+		txn.Success = append(txn.Success, &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: del}})
+	}
+	return txn
+}
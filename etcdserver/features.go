@@ -0,0 +1,34 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import "github.com/coreos/etcd/pkg/featuregate"
+
+const (
+	// NoLeaderHeader flags responses served while the member has no leader
+	// with the "noleader" response header metadata.
+	NoLeaderHeader featuregate.Feature = "NoLeaderHeader"
+)
+
+// DefaultFeatures lists every experimental server feature and whether it is
+// enabled by default. New risky features should be added disabled.
+var DefaultFeatures = map[featuregate.Feature]bool{
+	NoLeaderHeader: true,
+}
+
+// NewFeatureGate returns a feature gate with the server features at their defaults.
+func NewFeatureGate() *featuregate.FeatureGate {
+	return featuregate.New(DefaultFeatures)
+}
@@ -48,7 +48,9 @@ type RaftCluster struct {
 
 	sync.Mutex // guards the fields below
 	version    *semver.Version
-	members    map[types.ID]*Member
+	// downgradeInfo is nil until a downgrade is first enabled.
+	downgradeInfo *DowngradeInfo
+	members       map[types.ID]*Member
 	// removed contains the ids of removed members in the cluster.
 	// removed id cannot be reused.
 	removed map[types.ID]bool
@@ -209,7 +211,15 @@ func (c *RaftCluster) Recover(onSet func(*semver.Version)) {
 
 	c.members, c.removed = membersFromStore(c.store)
 	c.version = clusterVersionFromStore(c.store)
-	mustDetectDowngrade(c.version)
+	if c.version == nil && c.be != nil {
+		c.version = clusterVersionFromBackend(c.be)
+	}
+	c.downgradeInfo = downgradeFromStore(c.store)
+	if c.downgradeInfo == nil && c.be != nil {
+		c.downgradeInfo = downgradeFromBackend(c.be)
+	}
+	mustDetectDowngrade(c.version, c.downgradeInfo)
+	mustDetectUpgradeSkip(c.version)
 	onSet(c.version)
 
 	for _, m := range c.members {
@@ -218,6 +228,9 @@ func (c *RaftCluster) Recover(onSet func(*semver.Version)) {
 	if c.version != nil {
 		plog.Infof("set the cluster version to %v from store", version.Cluster(c.version.String()))
 	}
+	if c.downgradeInfo != nil && c.downgradeInfo.Enabled {
+		plog.Infof("the cluster is being downgraded to %v", version.Cluster(c.downgradeInfo.TargetVersion))
+	}
 }
 
 // ValidateConfigurationChange takes a proposed ConfChange and
@@ -369,7 +382,7 @@ func (c *RaftCluster) SetVersion(ver *semver.Version, onSet func(*semver.Version
 		plog.Noticef("set the initial cluster version to %v", version.Cluster(ver.String()))
 	}
 	c.version = ver
-	mustDetectDowngrade(c.version)
+	mustDetectDowngrade(c.version, c.downgradeInfo)
 	if c.store != nil {
 		mustSaveClusterVersionToStore(c.store, ver)
 	}
@@ -474,6 +487,49 @@ func clusterVersionFromStore(st store.Store) *semver.Version {
 	return semver.Must(semver.NewVersion(*e.Node.Value))
 }
 
+// clusterVersionFromBackend returns the cluster version mirrored to the
+// backend, or nil if it has not been saved yet.
+func clusterVersionFromBackend(be backend.Backend) *semver.Version {
+	tx := be.ReadTx()
+	tx.Lock()
+	defer tx.Unlock()
+	_, vs := tx.UnsafeRange(clusterBucketName, backendClusterVersionKey(), nil, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	return semver.Must(semver.NewVersion(string(vs[0])))
+}
+
+func downgradeFromStore(st store.Store) *DowngradeInfo {
+	e, err := st.Get(StoreDowngradeKey(), false, false)
+	if err != nil {
+		if isKeyNotFound(err) {
+			return nil
+		}
+		plog.Panicf("unexpected error (%v) when getting downgrade from store", err)
+	}
+	d := &DowngradeInfo{}
+	if err := json.Unmarshal([]byte(*e.Node.Value), d); err != nil {
+		plog.Panicf("unmarshal downgrade should never fail: %v", err)
+	}
+	return d
+}
+
+func downgradeFromBackend(be backend.Backend) *DowngradeInfo {
+	tx := be.ReadTx()
+	tx.Lock()
+	defer tx.Unlock()
+	_, vs := tx.UnsafeRange(clusterBucketName, backendDowngradeKey(), nil, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	d := &DowngradeInfo{}
+	if err := json.Unmarshal(vs[0], d); err != nil {
+		plog.Panicf("unmarshal downgrade should never fail: %v", err)
+	}
+	return d
+}
+
 // ValidateClusterAndAssignIDs validates the local cluster by matching the PeerURLs
 // with the existing cluster. If the validation succeeds, it assigns the IDs
 // from the existing cluster to the local cluster.
@@ -502,10 +558,32 @@ func ValidateClusterAndAssignIDs(local *RaftCluster, existing *RaftCluster) erro
 	return nil
 }
 
-func mustDetectDowngrade(cv *semver.Version) {
+// mustDetectUpgradeSkip exits if the recovered cluster version is lower than
+// the minimal cluster version this binary supports, i.e. the member is being
+// upgraded by more than one minor version at once.
+func mustDetectUpgradeSkip(cv *semver.Version) {
+	if err := checkUpgradeSkip(cv, semver.Must(semver.NewVersion(version.MinClusterVersion))); err != nil {
+		plog.Fatal(err)
+	}
+}
+
+func checkUpgradeSkip(cv, minV *semver.Version) error {
+	if cv == nil || !cv.LessThan(*minV) {
+		return nil
+	}
+	return fmt.Errorf("cluster cannot be upgraded from %s to %s at once (upgrade the cluster to %s first)",
+		version.Cluster(cv.String()), version.Cluster(version.Version), version.Cluster(minV.String()))
+}
+
+// mustDetectDowngrade exits if the local version is lower than the cluster
+// version, unless the cluster is being downgraded to the local version.
+func mustDetectDowngrade(cv *semver.Version, d *DowngradeInfo) {
 	lv := semver.Must(semver.NewVersion(version.Version))
 	// only keep major.minor version for comparison against cluster version
 	lv = &semver.Version{Major: lv.Major, Minor: lv.Minor}
+	if tv := d.GetTargetVersion(); tv != nil && lv.Equal(*tv) {
+		return
+	}
 	if cv != nil && lv.LessThan(*cv) {
 		plog.Fatalf("cluster cannot be downgraded (current version: %s is lower than determined cluster version: %s).", version.Version, version.Cluster(cv.String()))
 	}
@@ -17,15 +17,19 @@ package membership
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path"
 	"reflect"
 	"testing"
 
+	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/pkg/mock/mockstore"
 	"github.com/coreos/etcd/pkg/testutil"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/store"
+
+	"github.com/coreos/go-semver/semver"
 )
 
 func TestClusterMember(t *testing.T) {
@@ -732,3 +736,48 @@ func TestIsReadyToRemoveMember(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckUpgradeSkip(t *testing.T) {
+	minV := semver.Must(semver.NewVersion("3.1.0"))
+	tests := []struct {
+		cv   *semver.Version
+		werr bool
+	}{
+		{nil, false},
+		{semver.Must(semver.NewVersion("3.2.0")), false},
+		{semver.Must(semver.NewVersion("3.1.0")), false},
+		{semver.Must(semver.NewVersion("3.0.0")), true},
+		{semver.Must(semver.NewVersion("2.3.0")), true},
+	}
+	for i, tt := range tests {
+		if err := checkUpgradeSkip(tt.cv, minV); (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+	}
+}
+
+// TestClusterRecoverFromBackend ensures the cluster version and the downgrade
+// state are recovered from the backend when the store does not have them.
+func TestClusterRecoverFromBackend(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	defer be.Close()
+
+	c := NewCluster("")
+	c.SetStore(store.New())
+	c.SetBackend(be)
+	c.SetVersion(semver.Must(semver.NewVersion("3.1.0")), func(*semver.Version) {})
+	wd := &DowngradeInfo{Enabled: true, TargetVersion: "3.1.0"}
+	c.SetDowngradeInfo(wd)
+
+	rc := NewCluster("")
+	rc.SetStore(store.New())
+	rc.SetBackend(be)
+	rc.Recover(func(*semver.Version) {})
+	if v := rc.Version(); v == nil || v.String() != "3.1.0" {
+		t.Errorf("version = %v, want 3.1.0", v)
+	}
+	if d := rc.DowngradeInfo(); !reflect.DeepEqual(d, wd) {
+		t.Errorf("downgrade = %+v, want %+v", d, wd)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membership
+
+import (
+	"github.com/coreos/etcd/version"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// DowngradeInfo is the state of a downgrade of the cluster version.
+type DowngradeInfo struct {
+	// Enabled is set while the cluster is being downgraded.
+	Enabled bool `json:"enabled"`
+	// TargetVersion is the major.minor version the cluster is being
+	// downgraded to.
+	TargetVersion string `json:"target-version"`
+}
+
+// GetTargetVersion returns the target version, or nil if no downgrade is
+// enabled.
+func (d *DowngradeInfo) GetTargetVersion() *semver.Version {
+	if d == nil || !d.Enabled {
+		return nil
+	}
+	return semver.Must(semver.NewVersion(d.TargetVersion))
+}
+
+// DowngradeInfo returns the downgrade state of the cluster.
+func (c *RaftCluster) DowngradeInfo() *DowngradeInfo {
+	c.Lock()
+	defer c.Unlock()
+	if c.downgradeInfo == nil {
+		return &DowngradeInfo{}
+	}
+	d := *c.downgradeInfo
+	return &d
+}
+
+// SetDowngradeInfo sets the downgrade state of the cluster.
+func (c *RaftCluster) SetDowngradeInfo(d *DowngradeInfo) {
+	c.Lock()
+	defer c.Unlock()
+	if d.Enabled {
+		plog.Noticef("enabled the downgrade of the cluster version to %v", version.Cluster(d.TargetVersion))
+	} else {
+		plog.Noticef("disabled the downgrade of the cluster version")
+	}
+	c.downgradeInfo = d
+	if c.store != nil {
+		mustSaveDowngradeToStore(c.store, d)
+	}
+	if c.be != nil {
+		mustSaveDowngradeToBackend(c.be, d)
+	}
+}
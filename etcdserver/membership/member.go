@@ -41,6 +41,9 @@ type RaftAttributes struct {
 type Attributes struct {
 	Name       string   `json:"name,omitempty"`
 	ClientURLs []string `json:"clientURLs,omitempty"`
+	// Witness is true if the member votes but stores no key-value data and
+	// never becomes the leader.
+	Witness bool `json:"witness,omitempty"`
 }
 
 type Member struct {
@@ -89,7 +92,8 @@ func (m *Member) Clone() *Member {
 	mm := &Member{
 		ID: m.ID,
 		Attributes: Attributes{
-			Name: m.Name,
+			Name:    m.Name,
+			Witness: m.Witness,
 		},
 	}
 	if m.PeerURLs != nil {
@@ -94,6 +94,7 @@ func TestMemberClone(t *testing.T) {
 		newTestMember(1, []string{"http://a"}, "abc", nil),
 		newTestMember(1, nil, "abc", []string{"http://b"}),
 		newTestMember(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		{ID: 2, Attributes: Attributes{Name: "abc", Witness: true}},
 	}
 	for i, tt := range tests {
 		nm := tt.Clone()
@@ -75,6 +75,19 @@ func mustSaveClusterVersionToBackend(be backend.Backend, ver *semver.Version) {
 	tx.UnsafePut(clusterBucketName, ckey, []byte(ver.String()))
 }
 
+func mustSaveDowngradeToBackend(be backend.Backend, d *DowngradeInfo) {
+	dkey := backendDowngradeKey()
+	dvalue, err := json.Marshal(d)
+	if err != nil {
+		plog.Panicf("marshal downgrade should never fail: %v", err)
+	}
+
+	tx := be.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	tx.UnsafePut(clusterBucketName, dkey, dvalue)
+}
+
 func mustSaveMemberToStore(s store.Store, m *Member) {
 	b, err := json.Marshal(m.RaftAttributes)
 	if err != nil {
@@ -123,6 +136,16 @@ func mustSaveClusterVersionToStore(s store.Store, ver *semver.Version) {
 	}
 }
 
+func mustSaveDowngradeToStore(s store.Store, d *DowngradeInfo) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		plog.Panicf("marshal downgrade should never fail: %v", err)
+	}
+	if _, err := s.Set(StoreDowngradeKey(), false, string(b), store.TTLOptionSet{ExpireTime: store.Permanent}); err != nil {
+		plog.Panicf("save downgrade should never fail: %v", err)
+	}
+}
+
 // nodeToMember builds member from a key value node.
 // the child nodes of the given node MUST be sorted by key.
 func nodeToMember(n *store.NodeExtern) (*Member, error) {
@@ -159,6 +182,10 @@ func backendClusterVersionKey() []byte {
 	return []byte("clusterVersion")
 }
 
+func backendDowngradeKey() []byte {
+	return []byte("downgrade")
+}
+
 func mustCreateBackendBuckets(be backend.Backend) {
 	tx := be.BatchTx()
 	tx.Lock()
@@ -176,6 +203,10 @@ func StoreClusterVersionKey() string {
 	return path.Join(storePrefix, "version")
 }
 
+func StoreDowngradeKey() string {
+	return path.Join(storePrefix, "downgrade")
+}
+
 func MemberAttributesStorePath(id types.ID) string {
 	return path.Join(MemberStoreKey(id), attributesSuffix)
 }
@@ -0,0 +1,104 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+var (
+	// monitorMemoryInterval is how often the memory usage is accounted.
+	monitorMemoryInterval = time.Second
+
+	// maxRangeLimitOverBudget is the largest limit of a range over a key
+	// range served while over the memory budget.
+	maxRangeLimitOverBudget int64 = 1000
+)
+
+// monitorMemory accounts the memory held by the key index, watch buffers
+// and raft log every monitorMemoryInterval.
+func (s *EtcdServer) monitorMemory() {
+	for {
+		s.updateMemoryUsage()
+		select {
+		case <-s.stopping:
+			return
+		case <-time.After(monitorMemoryInterval):
+		}
+	}
+}
+
+func (s *EtcdServer) updateMemoryUsage() {
+	kv := s.KV()
+	index, watch := kv.IndexBytes(), kv.WatchBufferBytes()
+	raft := s.raftLogBytes()
+	memoryBytes.WithLabelValues("index").Set(float64(index))
+	memoryBytes.WithLabelValues("watch").Set(float64(watch))
+	memoryBytes.WithLabelValues("raft").Set(float64(raft))
+
+	total := index + watch + raft
+	old := atomic.SwapInt64(&s.memoryBytes, total)
+	if budget := s.Cfg.MemoryBudget; budget > 0 {
+		switch {
+		case total > budget && old <= budget:
+			plog.Warningf("memory usage %d bytes exceeds the memory budget of %d bytes; rejecting new watchers and large ranges", total, budget)
+		case total <= budget && old > budget:
+			plog.Infof("memory usage %d bytes is back within the memory budget of %d bytes", total, budget)
+		}
+	}
+}
+
+// raftLogBytes returns the size of the entries held in the raft storage.
+func (s *EtcdServer) raftLogBytes() (n int64) {
+	first, err := s.r.raftStorage.FirstIndex()
+	if err != nil {
+		return 0
+	}
+	last, err := s.r.raftStorage.LastIndex()
+	if err != nil || last < first {
+		return 0
+	}
+	ents, err := s.r.raftStorage.Entries(first, last+1, math.MaxUint64)
+	if err != nil {
+		return 0
+	}
+	for i := range ents {
+		n += int64(ents[i].Size())
+	}
+	return n
+}
+
+// MemoryBytes returns the approximate memory held by the key index, watch
+// buffers and raft log as last accounted.
+func (s *EtcdServer) MemoryBytes() int64 { return atomic.LoadInt64(&s.memoryBytes) }
+
+// OverMemoryBudget returns true if the accounted memory exceeds the
+// memory budget.
+func (s *EtcdServer) OverMemoryBudget() bool {
+	return s.Cfg.MemoryBudget > 0 && s.MemoryBytes() > s.Cfg.MemoryBudget
+}
+
+// isLargeRange returns true if r may return more keys than served over
+// the memory budget.
+func isLargeRange(r *pb.RangeRequest) bool {
+	if len(r.RangeEnd) == 0 || r.CountOnly {
+		return false
+	}
+	return r.Limit == 0 || r.Limit > maxRangeLimitOverBudget
+}
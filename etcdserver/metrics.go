@@ -58,6 +58,42 @@ var (
 		Name:      "proposals_failed_total",
 		Help:      "The total number of failed proposals seen.",
 	})
+	requestsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "requests_rejected_total",
+		Help:      "The total number of requests rejected by priority for a too long apply backlog.",
+	}, []string{"priority"})
+	prefixUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "prefix_quota_used_bytes",
+		Help:      "The size in bytes of the keys and values stored under a prefix with a prefix quota.",
+	}, []string{"prefix"})
+	prefixUsedKeys = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "prefix_quota_used_keys",
+		Help:      "The number of keys stored under a prefix with a prefix quota.",
+	}, []string{"prefix"})
+	memoryBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "memory_bytes",
+		Help:      "The approximate memory in bytes held by the key index, watch buffers and raft log.",
+	}, []string{"type"})
+	proposalBacklogBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "proposal_backlog_bytes",
+		Help:      "The size in bytes of the proposals waiting to be applied, by stage: pending for proposals of the member, unapplied for committed entries.",
+	}, []string{"stage"})
+	clientLimitRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "client_limit_rejected_total",
+		Help:      "The total number of connections, streams and watchers rejected for exceeding the limit of their client.",
+	}, []string{"resource"})
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
@@ -73,6 +109,12 @@ func init() {
 	prometheus.MustRegister(proposalsApplied)
 	prometheus.MustRegister(proposalsPending)
 	prometheus.MustRegister(proposalsFailed)
+	prometheus.MustRegister(requestsRejected)
+	prometheus.MustRegister(prefixUsedBytes)
+	prometheus.MustRegister(prefixUsedKeys)
+	prometheus.MustRegister(memoryBytes)
+	prometheus.MustRegister(proposalBacklogBytes)
+	prometheus.MustRegister(clientLimitRejected)
 	prometheus.MustRegister(leaseExpired)
 }
 
@@ -0,0 +1,252 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc"
+)
+
+// PrefixQuota limits the keys stored under a prefix. A zero limit is
+// unlimited, so the usage of the prefix is only accounted.
+type PrefixQuota struct {
+	Prefix   string
+	MaxBytes int64
+	MaxKeys  int64
+}
+
+// ParsePrefixQuotas parses a ',' separated list of prefix quotas of the form
+// 'prefix=bytes:keys', e.g. '/tenant-a/=1048576:1000,/tenant-b/=0:500'.
+// Prefixes may not contain one another.
+func ParsePrefixQuotas(s string) ([]PrefixQuota, error) {
+	var qs []PrefixQuota
+	if strings.TrimSpace(s) == "" {
+		return qs, nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		i := strings.LastIndex(f, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("prefix quota %q is not of the form prefix=bytes:keys", f)
+		}
+		limits := strings.Split(f[i+1:], ":")
+		if len(limits) != 2 {
+			return nil, fmt.Errorf("prefix quota %q is not of the form prefix=bytes:keys", f)
+		}
+		q := PrefixQuota{Prefix: f[:i]}
+		var err error
+		if q.MaxBytes, err = strconv.ParseInt(limits[0], 10, 64); err != nil || q.MaxBytes < 0 {
+			return nil, fmt.Errorf("invalid byte limit %q in prefix quota %q", limits[0], f)
+		}
+		if q.MaxKeys, err = strconv.ParseInt(limits[1], 10, 64); err != nil || q.MaxKeys < 0 {
+			return nil, fmt.Errorf("invalid key limit %q in prefix quota %q", limits[1], f)
+		}
+		qs = append(qs, q)
+	}
+	sort.Slice(qs, func(i, j int) bool { return qs[i].Prefix < qs[j].Prefix })
+	for i := 1; i < len(qs); i++ {
+		if strings.HasPrefix(qs[i].Prefix, qs[i-1].Prefix) {
+			return nil, fmt.Errorf("prefix quota %q overlaps prefix quota %q", qs[i].Prefix, qs[i-1].Prefix)
+		}
+	}
+	return qs, nil
+}
+
+// prefixUsage is the number and size of the keys stored under a prefix.
+type prefixUsage struct {
+	PrefixQuota
+	bytes int64
+	keys  int64
+}
+
+// prefixQuotas accounts the keys stored under the prefixes of the configured
+// prefix quotas. It is the size tracker of the server's KV, so its usage
+// follows every write to the KV, including the deletes of revoked leases.
+type prefixQuotas struct {
+	mu sync.Mutex
+	// usages is sorted by prefix.
+	usages []*prefixUsage
+}
+
+func newPrefixQuotas(qs []PrefixQuota) *prefixQuotas {
+	pq := &prefixQuotas{}
+	for _, q := range qs {
+		pq.usages = append(pq.usages, &prefixUsage{PrefixQuota: q})
+	}
+	return pq
+}
+
+// lookup returns the usage of the prefix of a key, or nil if the key is not
+// under any prefix.
+func (pq *prefixQuotas) lookup(key []byte) *prefixUsage {
+	i := sort.Search(len(pq.usages), func(i int) bool { return pq.usages[i].Prefix > string(key) })
+	if i == 0 || !bytes.HasPrefix(key, []byte(pq.usages[i-1].Prefix)) {
+		return nil
+	}
+	return pq.usages[i-1]
+}
+
+func (pq *prefixQuotas) Tracks(key []byte) bool { return pq.lookup(key) != nil }
+
+func (pq *prefixQuotas) Add(key []byte, bytes, keys int64) {
+	u := pq.lookup(key)
+	if u == nil {
+		return
+	}
+	pq.mu.Lock()
+	u.bytes += bytes
+	u.keys += keys
+	pq.mu.Unlock()
+	prefixUsedBytes.WithLabelValues(u.Prefix).Add(float64(bytes))
+	prefixUsedKeys.WithLabelValues(u.Prefix).Add(float64(keys))
+}
+
+// recount sets the usage of every prefix to the keys the KV holds under it.
+func (pq *prefixQuotas) recount(kv mvcc.KV) error {
+	for _, u := range pq.usages {
+		rr, err := kv.Range(context.TODO(), []byte(u.Prefix), prefixEnd([]byte(u.Prefix)), mvcc.RangeOptions{})
+		if err != nil {
+			return err
+		}
+		var n int64
+		for _, p := range rr.KVs {
+			n += int64(len(p.Key) + len(p.Value))
+		}
+		pq.mu.Lock()
+		u.bytes, u.keys = n, int64(len(rr.KVs))
+		pq.mu.Unlock()
+		prefixUsedBytes.WithLabelValues(u.Prefix).Set(float64(n))
+		prefixUsedKeys.WithLabelValues(u.Prefix).Set(float64(len(rr.KVs)))
+	}
+	return nil
+}
+
+// prefixEnd returns the end of the range of the keys with the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// the prefix is all 0xff, so range to the end of the keyspace
+	return []byte{0}
+}
+
+// usage returns the bytes and keys used by a prefix.
+func (pq *prefixQuotas) usage(prefix string) (bytes, keys int64) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for _, u := range pq.usages {
+		if u.Prefix == prefix {
+			return u.bytes, u.keys
+		}
+	}
+	return 0, 0
+}
+
+// limited returns true if any prefix quota limits its prefix.
+func (pq *prefixQuotas) limited() bool {
+	for _, u := range pq.usages {
+		if u.MaxBytes > 0 || u.MaxKeys > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns ErrPrefixQuotaExceeded if applying the given puts to the
+// view would take any prefix over its quota. Puts that shrink a prefix
+// or overwrite its keys in place are always accepted.
+func (pq *prefixQuotas) check(rv mvcc.ReadView, puts []*pb.PutRequest) error {
+	type delta struct{ bytes, keys int64 }
+	deltas := make(map[*prefixUsage]*delta)
+	written := make(map[string]bool)
+	for _, p := range puts {
+		u := pq.lookup(p.Key)
+		if u == nil || (u.MaxBytes == 0 && u.MaxKeys == 0) {
+			continue
+		}
+		d := deltas[u]
+		if d == nil {
+			d = &delta{}
+			deltas[u] = d
+		}
+		rr, err := rv.Range(context.TODO(), p.Key, nil, mvcc.RangeOptions{})
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(rr.KVs) == 0 && !written[string(p.Key)]:
+			d.bytes += int64(len(p.Key) + len(p.Value))
+			d.keys++
+		case len(rr.KVs) != 0 && !p.IgnoreValue:
+			d.bytes += int64(len(p.Value) - len(rr.KVs[0].Value))
+		}
+		written[string(p.Key)] = true
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for u, d := range deltas {
+		if u.MaxBytes > 0 && d.bytes > 0 && u.bytes+d.bytes > u.MaxBytes {
+			return ErrPrefixQuotaExceeded
+		}
+		if u.MaxKeys > 0 && d.keys > 0 && u.keys+d.keys > u.MaxKeys {
+			return ErrPrefixQuotaExceeded
+		}
+	}
+	return nil
+}
+
+// checkPrefixQuotas checks the puts of a request against the prefix quotas
+// before it is proposed, as the admission hooks do. The quotas are member
+// configuration, so enforcing them as requests are applied would let members
+// with different quotas apply different writes.
+func (s *EtcdServer) checkPrefixQuotas(rv mvcc.ReadView, puts []*pb.PutRequest) error {
+	if pq := s.prefixQuotas; pq != nil && pq.limited() {
+		return pq.check(rv, puts)
+	}
+	return nil
+}
+
+// txnPuts returns the puts a txn applies given its path.
+func txnPuts(rt *pb.TxnRequest, txnPath []bool) (puts []*pb.PutRequest, txnCount int) {
+	reqs := rt.Success
+	if !txnPath[0] {
+		reqs = rt.Failure
+	}
+	for _, req := range reqs {
+		switch tv := req.Request.(type) {
+		case *pb.RequestOp_RequestPut:
+			puts = append(puts, tv.RequestPut)
+		case *pb.RequestOp_RequestTxn:
+			ps, txns := txnPuts(tv.RequestTxn, txnPath[1:])
+			puts = append(puts, ps...)
+			txnPath = txnPath[1+txns:]
+			txnCount += txns + 1
+		}
+	}
+	return puts, txnCount
+}
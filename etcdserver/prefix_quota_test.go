@@ -0,0 +1,111 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+)
+
+func TestParsePrefixQuotas(t *testing.T) {
+	tests := []struct {
+		s string
+
+		wqs  []PrefixQuota
+		werr bool
+	}{
+		{"", nil, false},
+		{"/a/=10:2", []PrefixQuota{{"/a/", 10, 2}}, false},
+		{"/b/=0:5,/a/=10:0", []PrefixQuota{{"/a/", 10, 0}, {"/b/", 0, 5}}, false},
+		{"/a=b/=1:1", []PrefixQuota{{"/a=b/", 1, 1}}, false},
+		{"/a/", nil, true},
+		{"=1:1", nil, true},
+		{"/a/=1", nil, true},
+		{"/a/=x:1", nil, true},
+		{"/a/=1:-1", nil, true},
+		{"/a/=1:1,/a/b/=1:1", nil, true},
+	}
+	for i, tt := range tests {
+		qs, err := ParsePrefixQuotas(tt.s)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+			continue
+		}
+		if !reflect.DeepEqual(qs, tt.wqs) {
+			t.Errorf("#%d: quotas = %+v, want %+v", i, qs, tt.wqs)
+		}
+	}
+}
+
+func TestPrefixQuotasCheck(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	kv := mvcc.New(be, &lease.FakeLessor{}, nil)
+	defer kv.Close()
+
+	kv.Put([]byte("/a/1"), []byte("xx"), lease.NoLease)
+	kv.Put([]byte("/b/1"), []byte("xx"), lease.NoLease)
+
+	pq := newPrefixQuotas([]PrefixQuota{{"/a/", 16, 2}, {"/b/", 0, 0}})
+	if err := pq.recount(kv); err != nil {
+		t.Fatal(err)
+	}
+	kv.SetSizeTracker(pq)
+	if b, k := pq.usage("/a/"); b != 6 || k != 1 {
+		t.Fatalf("usage = %d bytes %d keys, want 6 bytes 1 keys", b, k)
+	}
+
+	put := func(k, v string) *pb.PutRequest { return &pb.PutRequest{Key: []byte(k), Value: []byte(v)} }
+	tests := []struct {
+		puts []*pb.PutRequest
+
+		werr error
+	}{
+		// fits the byte limit exactly
+		{[]*pb.PutRequest{put("/a/2", "123456")}, nil},
+		// exceeds the byte limit
+		{[]*pb.PutRequest{put("/a/2", "1234567")}, ErrPrefixQuotaExceeded},
+		// overwrites in place
+		{[]*pb.PutRequest{put("/a/1", "123456789")}, nil},
+		{[]*pb.PutRequest{put("/a/1", "1234567890123")}, ErrPrefixQuotaExceeded},
+		// exceeds the key limit
+		{[]*pb.PutRequest{put("/a/2", ""), put("/a/3", "")}, ErrPrefixQuotaExceeded},
+		// other prefixes are not limited
+		{[]*pb.PutRequest{put("/b/2", "1234567890123456789"), put("/c", "1234567890123456789")}, nil},
+	}
+	for i, tt := range tests {
+		if err := pq.check(kv, tt.puts); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+	}
+
+	kv.Put([]byte("/a/2"), []byte("123456"), lease.NoLease)
+	if err := pq.check(kv, []*pb.PutRequest{put("/a/3", "")}); err != ErrPrefixQuotaExceeded {
+		t.Errorf("err = %v, want %v", err, ErrPrefixQuotaExceeded)
+	}
+	kv.DeleteRange([]byte("/a/"), []byte("/a0"))
+	if b, k := pq.usage("/a/"); b != 0 || k != 0 {
+		t.Errorf("usage = %d bytes %d keys, want 0 bytes 0 keys", b, k)
+	}
+	if err := pq.check(kv, []*pb.PutRequest{put("/a/3", "")}); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// requestPriority classifies requests so operator-critical ones are still
+// admitted while client load saturates the member.
+type requestPriority int
+
+const (
+	// priorityUser is the priority of client reads and writes.
+	priorityUser requestPriority = iota
+	// prioritySystem is the priority of lease revocation, compaction,
+	// alarms, key expiry and corruption checks. Membership changes are
+	// proposed as conf changes and never held back.
+	prioritySystem
+)
+
+// maxGapBetweenApplyAndCommitIndexSystem bounds the apply backlog up to which
+// system requests are admitted once user requests are rejected. Likewise,
+// system requests are admitted up to twice MaxProposalBacklogBytes.
+const maxGapBetweenApplyAndCommitIndexSystem = 2 * maxGapBetweenApplyAndCommitIndex
+
+func (p requestPriority) String() string {
+	if p == prioritySystem {
+		return "system"
+	}
+	return "user"
+}
+
+type priorityKey struct{}
+
+// withPriority tags ctx with the priority of the requests issued with it.
+func withPriority(ctx context.Context, p requestPriority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityFromCtx returns the priority ctx is tagged with, or priorityUser.
+func priorityFromCtx(ctx context.Context) requestPriority {
+	if p, ok := ctx.Value(priorityKey{}).(requestPriority); ok {
+		return p
+	}
+	return priorityUser
+}
+
+// raftRequestPriority returns the priority of proposing r with ctx. Lease
+// revocation, compaction and alarms free resources or report trouble, so
+// they are system requests whoever issues them.
+func raftRequestPriority(ctx context.Context, r *pb.InternalRaftRequest) requestPriority {
+	if r.LeaseRevoke != nil || r.Compaction != nil || r.Alarm != nil {
+		return prioritySystem
+	}
+	return priorityFromCtx(ctx)
+}
+
+// admit returns ErrTooManyRequests if the apply backlog is too long, or the
+// proposal backlog too large, to take a request of priority p.
+func (s *EtcdServer) admit(p requestPriority) error {
+	max := uint64(maxGapBetweenApplyAndCommitIndex)
+	maxBytes := int64(s.Cfg.MaxProposalBacklogBytes)
+	if p == prioritySystem {
+		max = maxGapBetweenApplyAndCommitIndexSystem
+		maxBytes *= 2
+	}
+	if s.getCommittedIndex() > s.getAppliedIndex()+max || s.backlogExceeds(maxBytes) {
+		requestsRejected.WithLabelValues(p.String()).Inc()
+		return ErrTooManyRequests
+	}
+	return nil
+}
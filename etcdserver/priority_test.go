@@ -0,0 +1,106 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestRaftRequestPriority(t *testing.T) {
+	sysCtx := withPriority(context.TODO(), prioritySystem)
+	tests := []struct {
+		ctx context.Context
+		r   pb.InternalRaftRequest
+
+		wp requestPriority
+	}{
+		{context.TODO(), pb.InternalRaftRequest{Put: &pb.PutRequest{}}, priorityUser},
+		{context.TODO(), pb.InternalRaftRequest{Txn: &pb.TxnRequest{}}, priorityUser},
+		{context.TODO(), pb.InternalRaftRequest{LeaseRevoke: &pb.LeaseRevokeRequest{}}, prioritySystem},
+		{context.TODO(), pb.InternalRaftRequest{Compaction: &pb.CompactionRequest{}}, prioritySystem},
+		{context.TODO(), pb.InternalRaftRequest{Alarm: &pb.AlarmRequest{}}, prioritySystem},
+		{sysCtx, pb.InternalRaftRequest{Txn: &pb.TxnRequest{}}, prioritySystem},
+	}
+	for i, tt := range tests {
+		if p := raftRequestPriority(tt.ctx, &tt.r); p != tt.wp {
+			t.Errorf("#%d: priority = %v, want %v", i, p, tt.wp)
+		}
+	}
+}
+
+func TestAdmitByPriority(t *testing.T) {
+	tests := []struct {
+		gap uint64
+
+		wuser, wsystem error
+	}{
+		{0, nil, nil},
+		{maxGapBetweenApplyAndCommitIndex, nil, nil},
+		{maxGapBetweenApplyAndCommitIndex + 1, ErrTooManyRequests, nil},
+		{maxGapBetweenApplyAndCommitIndexSystem, ErrTooManyRequests, nil},
+		{maxGapBetweenApplyAndCommitIndexSystem + 1, ErrTooManyRequests, ErrTooManyRequests},
+	}
+	for i, tt := range tests {
+		srv := &EtcdServer{}
+		srv.setAppliedIndex(10)
+		srv.setCommittedIndex(10 + tt.gap)
+		if err := srv.admit(priorityUser); err != tt.wuser {
+			t.Errorf("#%d: user err = %v, want %v", i, err, tt.wuser)
+		}
+		if err := srv.admit(prioritySystem); err != tt.wsystem {
+			t.Errorf("#%d: system err = %v, want %v", i, err, tt.wsystem)
+		}
+	}
+}
+
+func TestAdmitByBacklogBytes(t *testing.T) {
+	const max = 100
+	tests := []struct {
+		pending, unapplied int64
+
+		wuser, wsystem error
+	}{
+		{0, 0, nil, nil},
+		{max, max, nil, nil},
+		{max + 1, 0, ErrTooManyRequests, nil},
+		{0, max + 1, ErrTooManyRequests, nil},
+		{2*max + 1, 0, ErrTooManyRequests, ErrTooManyRequests},
+		{0, 2*max + 1, ErrTooManyRequests, ErrTooManyRequests},
+	}
+	for i, tt := range tests {
+		srv := &EtcdServer{Cfg: ServerConfig{MaxProposalBacklogBytes: max}}
+		srv.addPendingBytes(tt.pending)
+		srv.addUnappliedBytes(tt.unapplied)
+		if err := srv.admit(priorityUser); err != tt.wuser {
+			t.Errorf("#%d: user err = %v, want %v", i, err, tt.wuser)
+		}
+		if err := srv.admit(prioritySystem); err != tt.wsystem {
+			t.Errorf("#%d: system err = %v, want %v", i, err, tt.wsystem)
+		}
+		srv.addPendingBytes(-tt.pending)
+		srv.addUnappliedBytes(-tt.unapplied)
+	}
+
+	// no limit
+	srv := &EtcdServer{}
+	srv.addUnappliedBytes(1 << 40)
+	defer srv.addUnappliedBytes(-1 << 40)
+	if err := srv.admit(priorityUser); err != nil {
+		t.Errorf("err = %v, want nil without a limit", err)
+	}
+}
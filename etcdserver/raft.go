@@ -36,13 +36,6 @@ import (
 )
 
 const (
-	// Number of entries for slow follower to catch-up after compacting
-	// the raft storage entries.
-	// We expect the follower has a millisecond level latency with the leader.
-	// The max throughput is around 10K. Keep a 5K entries is enough for helping
-	// follower to catch up.
-	numberOfCatchUpEntries = 5000
-
 	// The max throughput of etcd will not exceed 100MB/s (100K * 1KB value).
 	// Assuming the RTT is around 10ms, 1MB max size is large enough.
 	maxSizePerMsg = 1 * 1024 * 1024
@@ -127,6 +120,9 @@ type raftNodeConfig struct {
 	// clients should timeout and reissue their messages.
 	// If transport is nil, server will panic.
 	transport rafthttp.Transporter
+	// witness drops the data of the entries the member does not apply
+	// before persisting them.
+	witness bool
 }
 
 func newRaftNode(cfg raftNodeConfig) *raftNode {
@@ -214,8 +210,13 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 					r.transport.Send(r.processMessages(rd.Messages))
 				}
 
+				ents := rd.Entries
+				if r.witness {
+					ents = witnessEntries(ents)
+				}
+
 				// gofail: var raftBeforeSave struct{}
-				if err := r.storage.Save(rd.HardState, rd.Entries); err != nil {
+				if err := r.storage.Save(rd.HardState, ents); err != nil {
 					plog.Fatalf("raft save state and entries error: %v", err)
 				}
 				if !raft.IsEmptyHardState(rd.HardState) {
@@ -237,7 +238,7 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 					// gofail: var raftAfterApplySnap struct{}
 				}
 
-				r.raftStorage.Append(rd.Entries)
+				r.raftStorage.Append(ents)
 
 				if !islead {
 					// finish processing incoming messages before we signal raftdone chan
@@ -387,7 +388,7 @@ func startNode(cfg ServerConfig, cl *membership.RaftCluster, ids []types.ID) (id
 			ClusterID: uint64(cl.ID()),
 		},
 	)
-	if w, err = wal.Create(cfg.WALDir(), metadata); err != nil {
+	if w, err = wal.CreateWithOptions(cfg.WALDir(), metadata, wal.Options{DSync: cfg.WALDSync}); err != nil {
 		plog.Fatalf("create wal error: %v", err)
 	}
 	peers := make([]raft.Peer, len(ids))
@@ -409,6 +410,7 @@ func startNode(cfg ServerConfig, cl *membership.RaftCluster, ids []types.ID) (id
 		MaxSizePerMsg:   maxSizePerMsg,
 		MaxInflightMsgs: maxInflightMsgs,
 		CheckQuorum:     true,
+		Witness:         cfg.Witness,
 	}
 
 	n = raft.StartNode(c, peers)
@@ -424,7 +426,7 @@ func restartNode(cfg ServerConfig, snapshot *raftpb.Snapshot) (types.ID, *member
 	if snapshot != nil {
 		walsnap.Index, walsnap.Term = snapshot.Metadata.Index, snapshot.Metadata.Term
 	}
-	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap)
+	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap, wal.Options{DSync: cfg.WALDSync})
 
 	plog.Infof("restarting member %s in cluster %s at commit index %d", id, cid, st.Commit)
 	cl := membership.NewCluster("")
@@ -443,6 +445,7 @@ func restartNode(cfg ServerConfig, snapshot *raftpb.Snapshot) (types.ID, *member
 		MaxSizePerMsg:   maxSizePerMsg,
 		MaxInflightMsgs: maxInflightMsgs,
 		CheckQuorum:     true,
+		Witness:         cfg.Witness,
 	}
 
 	n := raft.RestartNode(c)
@@ -458,7 +461,7 @@ func restartAsStandaloneNode(cfg ServerConfig, snapshot *raftpb.Snapshot) (types
 	if snapshot != nil {
 		walsnap.Index, walsnap.Term = snapshot.Metadata.Index, snapshot.Metadata.Term
 	}
-	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap)
+	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap, wal.Options{DSync: cfg.WALDSync})
 
 	// discard the previously uncommitted entries
 	for i, ent := range ents {
@@ -33,20 +33,24 @@ import (
 	"github.com/coreos/etcd/auth"
 	"github.com/coreos/etcd/compactor"
 	"github.com/coreos/etcd/discovery"
+	"github.com/coreos/etcd/etcdserver/admission"
 	"github.com/coreos/etcd/etcdserver/api"
 	"github.com/coreos/etcd/etcdserver/api/v2http/httptypes"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/etcdserver/stats"
+	"github.com/coreos/etcd/etcdserver/valueschema"
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/lease/leasehttp"
 	"github.com/coreos/etcd/mvcc"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/pkg/fileutil"
 	"github.com/coreos/etcd/pkg/idutil"
+	"github.com/coreos/etcd/pkg/logutil"
 	"github.com/coreos/etcd/pkg/pbutil"
 	"github.com/coreos/etcd/pkg/runtime"
 	"github.com/coreos/etcd/pkg/schedule"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/pkg/wait"
 	"github.com/coreos/etcd/raft"
@@ -64,6 +68,13 @@ import (
 const (
 	DefaultSnapCount = 100000
 
+	// DefaultSnapshotCatchUpEntries is the number of entries kept in the
+	// raft log behind the compaction index for slow followers to catch up
+	// from. We expect the follower has a millisecond level latency with
+	// the leader. The max throughput is around 10K. Keep a 5K entries is
+	// enough for helping follower to catch up.
+	DefaultSnapshotCatchUpEntries = 5000
+
 	StoreClusterPrefix = "/0"
 	StoreKeysPrefix    = "/1"
 
@@ -174,6 +185,9 @@ type EtcdServer struct {
 	inflightSnapshots int64  // must use atomic operations to access; keep 64-bit aligned.
 	appliedIndex      uint64 // must use atomic operations to access; keep 64-bit aligned.
 	committedIndex    uint64 // must use atomic operations to access; keep 64-bit aligned.
+	memoryBytes       int64  // must use atomic operations to access; keep 64-bit aligned.
+	pendingBytes      int64  // must use atomic operations to access; keep 64-bit aligned.
+	unappliedBytes    int64  // must use atomic operations to access; keep 64-bit aligned.
 	// consistIndex used to hold the offset of current executing entry
 	// It is initialized to 0 before executing any entry.
 	consistIndex consistentIndex // must use atomic operations to access; keep 64-bit aligned.
@@ -198,6 +212,9 @@ type EtcdServer struct {
 	stopping chan struct{}
 	// done is closed when all goroutines from start() complete.
 	done chan struct{}
+	// drainc is closed by Drain to end long running client streams.
+	drainc    chan struct{}
+	drainOnce sync.Once
 
 	errorc     chan error
 	id         types.ID
@@ -216,6 +233,10 @@ type EtcdServer struct {
 	applyV3Base applierV3
 	applyWait   wait.WaitTime
 
+	// txnPaths holds the txn paths the apply plan evaluated ahead of
+	// applying the txns. Accessed only by the apply loop.
+	txnPaths map[*pb.TxnRequest][]bool
+
 	kv         mvcc.ConsistentWatchableKV
 	lessor     lease.Lessor
 	bemu       sync.Mutex
@@ -223,17 +244,31 @@ type EtcdServer struct {
 	authStore  auth.AuthStore
 	alarmStore *alarm.AlarmStore
 
+	// readOnly is non-zero while the member refuses client writes.
+	readOnly int32 // must use atomic operations to access
+
+	// prefixQuotas accounts and limits the keys under the configured prefixes.
+	prefixQuotas *prefixQuotas
+
+	// admission runs the admission hooks on client writes.
+	admission admission.Chain
+
+	clientLimiter *ClientLimiter
+
 	stats  *stats.ServerStats
 	lstats *stats.LeaderStats
 
 	SyncTicker *time.Ticker
 	// compactor is used to auto-compact the KV.
-	compactor compactor.Compactor
+	compactor *compactor.Scheduled
 
 	// peerRt used to send requests (version, lease) to peers.
 	peerRt   http.RoundTripper
 	reqIDGen *idutil.Generator
 
+	// traceExporter receives request traces; tracing is disabled if nil.
+	traceExporter traceutil.Exporter
+
 	// forceVersionC is used to force the version monitor loop
 	// to detect the cluster version immediately.
 	forceVersionC chan struct{}
@@ -258,6 +293,10 @@ type EtcdServer struct {
 func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 	st := store.New(StoreClusterPrefix, StoreKeysPrefix)
 
+	if cfg.FeatureGate == nil {
+		cfg.FeatureGate = NewFeatureGate()
+	}
+
 	var (
 		w  *wal.WAL
 		n  raft.Node
@@ -389,6 +428,9 @@ func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 		if !cfg.ForceNewCluster {
 			id, cl, n, s, w = restartNode(cfg, snapshot)
 		} else {
+			if cfg.Witness {
+				return nil, fmt.Errorf("a witness cannot force a new cluster it could never lead")
+			}
 			id, cl, n, s, w = restartAsStandaloneNode(cfg, snapshot)
 		}
 		cl.SetStore(st)
@@ -423,10 +465,11 @@ func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 				heartbeat:   heartbeat,
 				raftStorage: s,
 				storage:     NewStorage(w, ss),
+				witness:     cfg.Witness,
 			},
 		),
 		id:            id,
-		attributes:    membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice()},
+		attributes:    membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice(), Witness: cfg.Witness},
 		cluster:       cl,
 		stats:         sstats,
 		lstats:        lstats,
@@ -434,9 +477,16 @@ func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 		peerRt:        prt,
 		reqIDGen:      idutil.NewGenerator(uint16(id), time.Now()),
 		forceVersionC: make(chan struct{}),
+		clientLimiter: NewClientLimiter(int(cfg.MaxConnectionsPerClient), int(cfg.MaxStreamsPerClient), int(cfg.MaxWatchersPerClient)),
 	}
 
 	srv.applyV2 = &applierV2store{store: srv.store, cluster: srv.cluster}
+	srv.SetReadOnly(cfg.ReadOnly)
+
+	srv.traceExporter = cfg.TraceExporter
+	if cfg.WarningApplyDuration > 0 {
+		srv.traceExporter = &slowRequestExporter{threshold: cfg.WarningApplyDuration, next: cfg.TraceExporter}
+	}
 
 	srv.be = be
 	minTTL := time.Duration((3*cfg.ElectionTicks)/2) * heartbeat
@@ -445,6 +495,44 @@ func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
 	srv.lessor = lease.NewLessor(srv.be, int64(math.Ceil(minTTL.Seconds())))
 	srv.kv = mvcc.New(srv.be, srv.lessor, &srv.consistIndex)
+	srv.kv.SetWatcherLimits(int(cfg.MaxWatchers), int(cfg.MaxWatchersPerStream))
+	srv.kv.SetUnsyncedWatcherLimit(int(cfg.MaxUnsyncedWatchers))
+	srv.kv.SetEventHistory(int(cfg.WatchEventHistorySize), cfg.WatchEventHistoryMaxAge)
+	// members below 3.2 write the tombstones of revoked keys without the
+	// lease, so tagging them earlier would make the members' hashes differ
+	srv.kv.SetRevokeTombstones(func() bool { return srv.clusterVersionAtLeast(newRequestsVersion) })
+	pqs, err := ParsePrefixQuotas(cfg.PrefixQuotas)
+	if err != nil {
+		return nil, err
+	}
+	if len(pqs) != 0 {
+		srv.prefixQuotas = newPrefixQuotas(pqs)
+		if err = srv.prefixQuotas.recount(srv.kv); err != nil {
+			return nil, err
+		}
+		srv.kv.SetSizeTracker(srv.prefixQuotas)
+	}
+	hooks, err := admission.Lookup(cfg.AdmissionHooks)
+	if err != nil {
+		return nil, err
+	}
+	srv.admission = append(hooks, cfg.CustomAdmissionHooks...)
+	schemas, err := valueschema.Parse(cfg.ValueSchemas)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemas) != 0 {
+		// validate the values as rewritten by the other hooks
+		srv.admission = append(srv.admission, valueschema.NewHook(schemas))
+	}
+	vis, err := ParseValueIndexes(cfg.ValueIndexes)
+	if err != nil {
+		return nil, err
+	}
+	// set even without indexes, to drop the indexes saved by past configs
+	if err = srv.kv.SetValueIndexes(append(vis, cfg.CustomValueIndexes...)); err != nil {
+		return nil, err
+	}
 	if beExist {
 		kvindex := srv.kv.ConsistentIndex()
 		// TODO: remove kvindex != 0 checking when we do not expect users to upgrade
@@ -477,10 +565,15 @@ func NewServer(cfg ServerConfig) (srv *EtcdServer, err error) {
 	}
 	srv.authStore = auth.NewAuthStore(srv.be, tp)
 	if num := cfg.AutoCompactionRetention; num != 0 {
-		srv.compactor, err = compactor.New(cfg.AutoCompactionMode, num, srv.kv, srv)
+		c, err := compactor.New(cfg.AutoCompactionMode, num, srv.kv, srv)
 		if err != nil {
 			return nil, err
 		}
+		windows, err := compactor.ParseWindows(cfg.AutoCompactionWindows)
+		if err != nil {
+			return nil, err
+		}
+		srv.compactor = compactor.NewScheduled(c, windows)
 		srv.compactor.Run()
 	}
 
@@ -533,6 +626,10 @@ func (s *EtcdServer) Start() {
 	s.goAttach(s.monitorVersions)
 	s.goAttach(s.linearizableReadLoop)
 	s.goAttach(s.monitorKVHash)
+	s.goAttach(s.monitorBackend)
+	s.goAttach(s.monitorMemory)
+	s.goAttach(s.sweepExpiredKeys)
+	s.goAttach(s.saveKeyIndex)
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -543,18 +640,22 @@ func (s *EtcdServer) start() {
 		plog.Infof("set snapshot count to default %d", DefaultSnapCount)
 		s.Cfg.SnapCount = DefaultSnapCount
 	}
+	if s.Cfg.SnapshotCatchUpEntries == 0 {
+		s.Cfg.SnapshotCatchUpEntries = DefaultSnapshotCatchUpEntries
+	}
 	s.w = wait.New()
 	s.applyWait = wait.NewTimeList()
 	s.done = make(chan struct{})
 	s.stop = make(chan struct{})
 	s.stopping = make(chan struct{})
+	s.drainc = make(chan struct{})
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.readwaitc = make(chan struct{}, 1)
 	s.readNotifier = newNotifier()
 	if s.ClusterVersion() != nil {
-		plog.Infof("starting server... [version: %v, cluster version: %v]", version.Version, version.Cluster(s.ClusterVersion().String()))
+		plog.Info(fmt.Sprintf("starting server... [version: %v, cluster version: %v]", version.Version, version.Cluster(s.ClusterVersion().String())), logutil.MemberID(uint64(s.ID())))
 	} else {
-		plog.Infof("starting server... [version: %v, cluster version: to_be_decided]", version.Version)
+		plog.Info(fmt.Sprintf("starting server... [version: %v, cluster version: to_be_decided]", version.Version), logutil.MemberID(uint64(s.ID())))
 	}
 	// TODO: if this is an empty log, writes all peer infos
 	// into the first entry
@@ -582,6 +683,63 @@ func (s *EtcdServer) purgeFile() {
 	}
 }
 
+// saveKeyIndex periodically saves the mvcc key index to the backend so a
+// restart only replays the revisions written since the last save.
+func (s *EtcdServer) saveKeyIndex() {
+	t := s.Cfg.KeyIndexSaveInterval
+	if t == 0 {
+		return
+	}
+	plog.Infof("enabled saving the key index with %s interval", t)
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-time.After(t):
+		}
+		s.kv.SaveKeyIndex()
+	}
+}
+
+// PauseAutoCompaction pauses the member's auto compaction until it is
+// resumed, or resumes it. The pause is not persisted.
+func (s *EtcdServer) PauseAutoCompaction(paused bool) error {
+	if s.compactor == nil {
+		return ErrNoAutoCompaction
+	}
+	if paused {
+		s.compactor.Hold()
+	} else {
+		s.compactor.Release()
+	}
+	return nil
+}
+
+// AutoCompactionPaused returns true if auto compaction is paused by
+// PauseAutoCompaction.
+func (s *EtcdServer) AutoCompactionPaused() (bool, error) {
+	if s.compactor == nil {
+		return false, ErrNoAutoCompaction
+	}
+	return s.compactor.Held(), nil
+}
+
+// SetReadOnly puts the member into read-only mode, where it refuses client
+// writes but keeps replicating the cluster, or takes it out of it. The mode
+// is not persisted.
+func (s *EtcdServer) SetReadOnly(ro bool) {
+	var v int32
+	if ro {
+		v = 1
+	}
+	if atomic.SwapInt32(&s.readOnly, v) != v {
+		plog.Noticef("%s read-only mode (read-only=%v)", types.ID(s.id), ro)
+	}
+}
+
+// ReadOnly returns true if the member is in read-only mode.
+func (s *EtcdServer) ReadOnly() bool { return atomic.LoadInt32(&s.readOnly) != 0 }
+
 func (s *EtcdServer) ID() types.ID { return s.id }
 
 func (s *EtcdServer) Cluster() api.Cluster { return s.cluster }
@@ -631,6 +789,16 @@ type etcdProgress struct {
 	snapi     uint64
 	appliedt  uint64
 	appliedi  uint64
+
+	// compacti is the index the raft log was last compacted to by
+	// compactRaftLog. persisti is the applied index the backend is known to
+	// have persisted; any backend commit after commits, counted when
+	// sampledi was applied, persists sampledi.
+	compacti uint64
+	persisti uint64
+	sampledi uint64
+	commits  int64
+	sampled  bool
 }
 
 // raftReadyHandler contains a set of EtcdServer operations to be called by raftNode,
@@ -755,7 +923,12 @@ func (s *EtcdServer) run() {
 	for {
 		select {
 		case ap := <-s.r.apply():
-			f := func(context.Context) { s.applyAll(&ep, &ap) }
+			n := entriesSize(ap.entries)
+			s.addUnappliedBytes(n)
+			f := func(context.Context) {
+				s.applyAll(&ep, &ap)
+				s.addUnappliedBytes(-n)
+			}
 			sched.Schedule(f)
 		case leases := <-expiredLeaseC:
 			s.goAttach(func() {
@@ -808,9 +981,15 @@ func (s *EtcdServer) applyAll(ep *etcdProgress, apply *apply) {
 	<-apply.notifyc
 
 	s.triggerSnapshot(ep)
+	s.compactRaftLog(ep)
 	select {
 	// snapshot requested via send()
 	case m := <-s.r.msgSnapC:
+		if s.isDegraded() {
+			// the backend on disk is behind the applied index
+			s.r.ReportSnapshot(m.To, raft.SnapshotFailure)
+			break
+		}
 		merged := s.createMergedSnapshotMessage(m, ep.appliedt, ep.appliedi, ep.confState)
 		s.sendMergedSnap(merged)
 	default:
@@ -837,12 +1016,18 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 	if err != nil {
 		plog.Panic(err)
 	}
+	if s.Cfg.Witness {
+		plog.Info("purging key-value data of the snapshot from the witness backend...")
+		if err := purgeWitnessBackend(newbe); err != nil {
+			plog.Panicf("purge backend error: %v", err)
+		}
+	}
 
 	// always recover lessor before kv. When we recover the mvcc.KV it will reattach keys to its leases.
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
 	if s.lessor != nil {
 		plog.Info("recovering lessor...")
-		s.lessor.Recover(newbe, func() lease.TxnDelete { return s.kv.Write() })
+		s.lessor.Recover(newbe, func() lease.TxnDelete { return mvcc.NewLeaseTxnWrite(s.kv.Write()) })
 		plog.Info("finished recovering lessor")
 	}
 
@@ -852,6 +1037,11 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 		plog.Panicf("restore KV error: %v", err)
 	}
 	s.consistIndex.setConsistentIndex(s.kv.ConsistentIndex())
+	if s.prefixQuotas != nil {
+		if err := s.prefixQuotas.recount(s.kv); err != nil {
+			plog.Panicf("recount prefix quotas error: %v", err)
+		}
+	}
 
 	plog.Info("finished restoring mvcc store")
 
@@ -888,6 +1078,9 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 	if err := s.store.Recovery(apply.snapshot.Data); err != nil {
 		plog.Panicf("recovery store error: %v", err)
 	}
+	if s.Cfg.Witness {
+		s.purgeWitnessStore()
+	}
 	plog.Info("finished recovering store v2")
 
 	s.cluster.SetBackend(s.be)
@@ -913,10 +1106,12 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 	ep.appliedi = apply.snapshot.Metadata.Index
 	ep.snapi = ep.appliedi
 	ep.confState = apply.snapshot.Metadata.ConfState
+	// the commits of the new backend count from zero
+	ep.sampled = false
 }
 
 func (s *EtcdServer) applyEntries(ep *etcdProgress, apply *apply) {
-	if len(apply.entries) == 0 {
+	if len(apply.entries) == 0 || s.isDegraded() {
 		return
 	}
 	firsti := apply.entries[0].Index
@@ -930,8 +1125,17 @@ func (s *EtcdServer) applyEntries(ep *etcdProgress, apply *apply) {
 	if len(ents) == 0 {
 		return
 	}
-	var shouldstop bool
-	if ep.appliedt, ep.appliedi, shouldstop = s.apply(ents, &ep.confState); shouldstop {
+	start := time.Now()
+	appliedt, appliedi, shouldstop := s.apply(ents, &ep.confState)
+	if appliedi == 0 {
+		// degraded before applying any entry
+		return
+	}
+	if appliedt > ep.appliedt {
+		s.failDroppedProposals(start)
+	}
+	ep.appliedt, ep.appliedi = appliedt, appliedi
+	if shouldstop {
 		go s.stopWithDelay(10*100*time.Millisecond, fmt.Errorf("the member has been permanently removed from the cluster"))
 	}
 }
@@ -946,6 +1150,59 @@ func (s *EtcdServer) triggerSnapshot(ep *etcdProgress) {
 	ep.snapi = ep.appliedi
 }
 
+// compactRaftLog compacts the in-memory raft log up to the index the backend
+// has persisted, or the index of the raft snapshot if lower, less the
+// SnapshotCatchUpEntries kept for slow followers. Followers further behind
+// are sent a snapshot of the backend, which holds every entry up to that
+// index; the WAL still holds the entries since the last snapshot to recover
+// from on restart.
+func (s *EtcdServer) compactRaftLog(ep *etcdProgress) {
+	be := s.Backend()
+	if be == nil || s.isDegraded() {
+		return
+	}
+	// A commit counted after sampledi was applied started after the writes
+	// of the entries up to sampledi ended, since commits and writes hold
+	// the batch tx lock, so it persisted them.
+	if commits := be.Commits(); !ep.sampled || commits > ep.commits {
+		if ep.sampled {
+			ep.persisti = ep.sampledi
+		}
+		ep.sampledi, ep.commits, ep.sampled = ep.appliedi, commits, true
+	}
+
+	catchUp := s.Cfg.SnapshotCatchUpEntries
+	if ep.persisti <= catchUp {
+		return
+	}
+	compacti := ep.persisti - catchUp
+	// raft sends its snapshot to followers behind the compacted log, which
+	// then catch up from the entries after it; keep them, along with the
+	// catch up entries, until the next snapshot
+	snap, err := s.r.raftStorage.Snapshot()
+	if err != nil || raft.IsEmptySnap(snap) || snap.Metadata.Index <= catchUp {
+		return
+	}
+	if snapi := snap.Metadata.Index - catchUp; compacti > snapi {
+		compacti = snapi
+	}
+	// compacting copies the entries kept; wait until as many can be
+	// dropped to amortize the copy
+	if compacti < ep.compacti+catchUp {
+		return
+	}
+	// see snapshot() on pausing compaction for inflight snapshots
+	if atomic.LoadInt64(&s.inflightSnapshots) != 0 {
+		return
+	}
+	err = s.r.raftStorage.Compact(compacti)
+	if err != nil && err != raft.ErrCompacted {
+		plog.Panicf("unexpected compaction error %v", err)
+	}
+	ep.compacti = compacti
+	plog.Debugf("compacted raft log at %d", compacti)
+}
+
 func (s *EtcdServer) isMultiNode() bool {
 	return s.cluster != nil && len(s.cluster.MemberIDs()) > 1
 }
@@ -956,6 +1213,9 @@ func (s *EtcdServer) isLeader() bool {
 
 // MoveLeader transfers the leader to the given transferee.
 func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) error {
+	if m := s.cluster.Member(types.ID(transferee)); m != nil && m.Witness {
+		return ErrWitness
+	}
 	now := time.Now()
 	interval := time.Duration(s.Cfg.TickMs) * time.Millisecond
 
@@ -987,7 +1247,7 @@ func (s *EtcdServer) TransferLeadership() error {
 		return nil
 	}
 
-	transferee, ok := longestConnected(s.r.transport, s.cluster.MemberIDs())
+	transferee, ok := longestConnected(s.r.transport, s.dataMemberIDs())
 	if !ok {
 		return ErrUnhealthy
 	}
@@ -999,6 +1259,25 @@ func (s *EtcdServer) TransferLeadership() error {
 	return err
 }
 
+// Drain prepares the server to stop. It transfers leadership away if this
+// member is the leader and closes DrainNotify so long running client streams
+// (watches, lease keepalives) end with ErrStopped instead of being cut when
+// the gRPC server shuts down. Other requests are served until Stop.
+func (s *EtcdServer) Drain() {
+	s.drainOnce.Do(func() {
+		if err := s.TransferLeadership(); err != nil {
+			plog.Warningf("%s failed to transfer leadership (%v)", s.ID(), err)
+		}
+		// drainc is nil if the server was never started
+		if s.drainc != nil {
+			close(s.drainc)
+		}
+	})
+}
+
+// DrainNotify returns a channel that is closed when the server starts draining.
+func (s *EtcdServer) DrainNotify() <-chan struct{} { return s.drainc }
+
 // HardStop stops the server without coordination with other members in the cluster.
 func (s *EtcdServer) HardStop() {
 	select {
@@ -1016,9 +1295,7 @@ func (s *EtcdServer) HardStop() {
 // Stop terminates the Server and performs any necessary finalization.
 // Do and Process cannot be called after Stop has been invoked.
 func (s *EtcdServer) Stop() {
-	if err := s.TransferLeadership(); err != nil {
-		plog.Warningf("%s failed to transfer leadership (%v)", s.ID(), err)
-	}
+	s.Drain()
 	s.HardStop()
 }
 
@@ -1198,6 +1475,9 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*me
 		if x == nil {
 			plog.Panicf("configure trigger value should never be nil")
 		}
+		if err, ok := x.(error); ok {
+			return nil, s.parseWaitErr(err, start)
+		}
 		resp := x.(*confChangeResponse)
 		return resp.membs, resp.err
 	case <-ctx.Done():
@@ -1290,11 +1570,17 @@ func (s *EtcdServer) sendMergedSnap(merged snap.Message) {
 // applies them to the current state of the EtcdServer.
 // The given entries should not be empty.
 func (s *EtcdServer) apply(es []raftpb.Entry, confState *raftpb.ConfState) (appliedt uint64, appliedi uint64, shouldStop bool) {
+	plan := s.planApply(es)
+	defer plan.finish()
 	for i := range es {
 		e := es[i]
+		if s.isDegraded() {
+			// the entry would never reach the disk; stay at the applied index
+			break
+		}
 		switch e.Type {
 		case raftpb.EntryNormal:
-			s.applyEntryNormal(&e)
+			s.applyEntryNormal(&e, plan.prepare(i))
 		case raftpb.EntryConfChange:
 			// set the consistent index of current executing entry
 			if e.Index > s.consistIndex.ConsistentIndex() {
@@ -1317,8 +1603,9 @@ func (s *EtcdServer) apply(es []raftpb.Entry, confState *raftpb.ConfState) (appl
 	return appliedt, appliedi, shouldStop
 }
 
-// applyEntryNormal apples an EntryNormal type raftpb request to the EtcdServer
-func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
+// applyEntryNormal apples an EntryNormal type raftpb request to the EtcdServer.
+// decoded is the request of the entry if it was already decoded, or nil.
+func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, decoded *pb.InternalRaftRequest) {
 	shouldApplyV3 := false
 	if e.Index > s.consistIndex.ConsistentIndex() {
 		// set the consistent index of current executing entry
@@ -1341,9 +1628,14 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 		}
 		return
 	}
+	if s.Cfg.Witness && !witnessApplies(e.Data) {
+		return
+	}
 
 	var raftReq pb.InternalRaftRequest
-	if !pbutil.MaybeUnmarshal(&raftReq, e.Data) { // backward compatible
+	if decoded != nil {
+		raftReq = *decoded
+	} else if !pbutil.MaybeUnmarshal(&raftReq, e.Data) { // backward compatible
 		var r pb.Request
 		rp := &r
 		pbutil.MustUnmarshal(rp, e.Data)
@@ -1372,7 +1664,11 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 		if !needResult && raftReq.Txn != nil {
 			removeNeedlessRangeReqs(raftReq.Txn)
 		}
+		start := time.Now()
 		ar = s.applyV3.Apply(&raftReq)
+		if ar != nil {
+			ar.applyStart = start
+		}
 	}
 
 	if ar == nil {
@@ -1451,6 +1747,11 @@ func (s *EtcdServer) snapshot(snapi uint64, confState raftpb.ConfState) {
 	// So KV().Commit() cannot run in parallel with apply. It has to be called outside
 	// the go routine created below.
 	s.KV().Commit()
+	if s.isDegraded() {
+		// releasing the wal up to snapi would lose entries the backend missed
+		plog.Warningf("skipped snapshot at index %d on degraded member", snapi)
+		return
+	}
 
 	s.goAttach(func() {
 		d, err := clone.SaveNoCopy()
@@ -1468,6 +1769,7 @@ func (s *EtcdServer) snapshot(snapi uint64, confState raftpb.ConfState) {
 			}
 			plog.Panicf("unexpected create snapshot error %v", err)
 		}
+		// gofail: var beforeSaveLocalSnap struct{}
 		// SaveSnap saves the snapshot and releases the locked wal files
 		// to the snapshot index.
 		if err = s.r.storage.SaveSnap(snap); err != nil {
@@ -1487,8 +1789,8 @@ func (s *EtcdServer) snapshot(snapi uint64, confState raftpb.ConfState) {
 
 		// keep some in memory log entries for slow followers.
 		compacti := uint64(1)
-		if snapi > numberOfCatchUpEntries {
-			compacti = snapi - numberOfCatchUpEntries
+		if snapi > s.Cfg.SnapshotCatchUpEntries {
+			compacti = snapi - s.Cfg.SnapshotCatchUpEntries
 		}
 		err = s.r.raftStorage.Compact(compacti)
 		if err != nil {
@@ -1530,8 +1832,17 @@ func (s *EtcdServer) ClusterVersion() *semver.Version {
 	return s.cluster.Version()
 }
 
+// clusterVersionAtLeast returns true if the cluster version is known and no
+// lower than v, so every member applies the requests introduced in v. It
+// turns false again while the cluster is downgraded below v.
+func (s *EtcdServer) clusterVersionAtLeast(v semver.Version) bool {
+	cv := s.ClusterVersion()
+	return cv != nil && !cv.LessThan(v)
+}
+
 // monitorVersions checks the member's version every monitorVersionInterval.
-// It updates the cluster version if all members agrees on a higher one.
+// It updates the cluster version if all members agrees on a higher one, or
+// lowers it while the cluster is being downgraded.
 // It prints out log if there is a member with a higher version than the
 // local version.
 func (s *EtcdServer) monitorVersions() {
@@ -1547,7 +1858,13 @@ func (s *EtcdServer) monitorVersions() {
 			continue
 		}
 
-		v := decideClusterVersion(getVersions(s.cluster, s.id, s.peerRt))
+		vers := getVersions(s.cluster, s.id, s.peerRt)
+		if s.monitorDowngrade(vers) {
+			// the cluster version must not be raised while downgrading
+			continue
+		}
+
+		v := decideClusterVersion(vers)
 		if v != nil {
 			// only keep major.minor version for comparison
 			v = &semver.Version{
@@ -1559,6 +1876,8 @@ func (s *EtcdServer) monitorVersions() {
 		// if the current version is nil:
 		// 1. use the decided version if possible
 		// 2. or use the min cluster version
+		// the version is only nil for a new cluster, whose members all run
+		// at least the min cluster version; a 3.0 member cannot join it
 		if s.cluster.Version() == nil {
 			verStr := version.MinClusterVersion
 			if v != nil {
@@ -1643,6 +1962,11 @@ func (s *EtcdServer) Backend() backend.Backend {
 
 func (s *EtcdServer) AuthStore() auth.AuthStore { return s.authStore }
 
+func (s *EtcdServer) Lessor() lease.Lessor { return s.lessor }
+
+// StartTime returns when the server was created.
+func (s *EtcdServer) StartTime() time.Time { return s.stats.StartTime }
+
 func (s *EtcdServer) restoreAlarms() error {
 	s.applyV3 = s.newApplierV3()
 	as, err := alarm.NewAlarmStore(s)
@@ -1653,9 +1977,6 @@ func (s *EtcdServer) restoreAlarms() error {
 	if len(as.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.applyV3 = newApplierV3Capped(s.applyV3)
 	}
-	if len(as.Get(pb.AlarmType_CORRUPT)) > 0 {
-		s.applyV3 = newApplierV3Corrupt(s.applyV3)
-	}
 	return nil
 }
 
@@ -45,6 +45,7 @@ import (
 	"github.com/coreos/etcd/rafthttp"
 	"github.com/coreos/etcd/snap"
 	"github.com/coreos/etcd/store"
+	"github.com/coreos/go-semver/semver"
 )
 
 // TestDoLocalAction tests requests which do not need to go through raft to be applied,
@@ -766,6 +767,41 @@ func TestDoProposalStopped(t *testing.T) {
 	}
 }
 
+// TestDoProposalDroppedByLeaderChange ensures a proposal not applied an
+// election timeout after a new term starts fails rather than waiting out its
+// context.
+func TestDoProposalDroppedByLeaderChange(t *testing.T) {
+	srv := &EtcdServer{
+		Cfg:      ServerConfig{TickMs: 1, ElectionTicks: 10},
+		r:        *newRaftNode(raftNodeConfig{Node: newNodeNop()}),
+		w:        wait.New(),
+		reqIDGen: idutil.NewGenerator(0, time.Time{}),
+		stopping: make(chan struct{}),
+	}
+	srv.applyV2 = &applierV2store{store: srv.store, cluster: srv.cluster}
+	defer func() {
+		close(srv.stopping)
+		srv.wg.Wait()
+	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := srv.Do(context.Background(), pb.Request{Method: "PUT"})
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	srv.failDroppedProposals(time.Now())
+
+	select {
+	case err := <-errc:
+		if err != ErrTimeoutDueToLeaderFail {
+			t.Fatalf("err = %v, want %v", err, ErrTimeoutDueToLeaderFail)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("proposal dropped by leader change did not fail")
+	}
+}
+
 // TestSync tests sync 1. is nonblocking 2. proposes SYNC request.
 func TestSync(t *testing.T) {
 	n := newNodeRecorder()
@@ -1081,6 +1117,200 @@ func TestTriggerSnap(t *testing.T) {
 	srv.Stop()
 }
 
+// TestCompactRaftLog ensures the raft log is compacted up to the index the
+// backend persisted, less the catch up entries, once a commit follows the
+// entries applied.
+func TestCompactRaftLog(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	rs := raft.NewMemoryStorage()
+	s := &EtcdServer{
+		Cfg: ServerConfig{SnapshotCatchUpEntries: 10},
+		r:   raftNode{raftNodeConfig: raftNodeConfig{raftStorage: rs}},
+		be:  be,
+	}
+	ep := &etcdProgress{}
+	apply := func(last uint64) {
+		var ents []raftpb.Entry
+		for i := ep.appliedi + 1; i <= last; i++ {
+			ents = append(ents, raftpb.Entry{Index: i, Term: 1})
+		}
+		rs.Append(ents)
+		ep.appliedi = last
+		s.compactRaftLog(ep)
+	}
+	commit := func() {
+		// the backend skips commits with no writes pending
+		tx := be.BatchTx()
+		tx.Lock()
+		tx.UnsafeCreateBucket([]byte("test"))
+		tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+		tx.Unlock()
+		be.ForceCommit()
+	}
+	firstIndex := func() uint64 {
+		fi, err := rs.FirstIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi
+	}
+
+	apply(100)
+	if fi := firstIndex(); fi != 1 {
+		t.Fatalf("first index = %d, want 1 before any commit", fi)
+	}
+	commit()
+	apply(105)
+	if fi := firstIndex(); fi != 1 {
+		t.Fatalf("first index = %d, want 1 before any raft snapshot", fi)
+	}
+	if _, err := rs.CreateSnapshot(100, &raftpb.ConfState{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	apply(106)
+	// the commit persisted the entries up to 100
+	if fi := firstIndex(); fi != 91 {
+		t.Fatalf("first index = %d, want 91", fi)
+	}
+	apply(120)
+	if fi := firstIndex(); fi != 91 {
+		t.Fatalf("first index = %d, want 91 without a commit", fi)
+	}
+	commit()
+	apply(121)
+	// the commit persisted the entries up to 106, too few to compact
+	if fi := firstIndex(); fi != 91 {
+		t.Fatalf("first index = %d, want 91", fi)
+	}
+	commit()
+	apply(122)
+	// the commit persisted the entries up to 121, past the raft snapshot
+	if fi := firstIndex(); fi != 91 {
+		t.Fatalf("first index = %d, want 91 behind the raft snapshot", fi)
+	}
+	if _, err := rs.CreateSnapshot(122, &raftpb.ConfState{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	apply(123)
+	if fi := firstIndex(); fi != 112 {
+		t.Fatalf("first index = %d, want 112", fi)
+	}
+}
+
+// TestCompactRaftLogLaggingFollower ensures a follower behind the compacted
+// raft log is sent the raft snapshot and catches up from the entries after
+// it.
+func TestCompactRaftLogLaggingFollower(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	rs := raft.NewMemoryStorage()
+	cs := raftpb.ConfState{Nodes: []uint64{1, 2, 3}}
+	rs.ApplySnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 1, Term: 1, ConfState: cs}})
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:              1,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         rs,
+		MaxSizePerMsg:   1024 * 1024,
+		MaxInflightMsgs: 256,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &EtcdServer{
+		Cfg: ServerConfig{SnapshotCatchUpEntries: 10},
+		r:   raftNode{raftNodeConfig: raftNodeConfig{raftStorage: rs}},
+		be:  be,
+	}
+	ep := &etcdProgress{}
+
+	// process runs the leader until it has nothing ready, with member 2
+	// acknowledging every append and member 3 lagging; it applies and
+	// commits the committed entries, compacting the raft log, and returns
+	// the messages to member 3.
+	process := func() (msgs []raftpb.Message) {
+		for rn.HasReady() {
+			rd := rn.Ready()
+			if !raft.IsEmptyHardState(rd.HardState) {
+				rs.SetHardState(rd.HardState)
+			}
+			rs.Append(rd.Entries)
+			if n := len(rd.CommittedEntries); n != 0 {
+				ep.appliedi = rd.CommittedEntries[n-1].Index
+				tx := be.BatchTx()
+				tx.Lock()
+				tx.UnsafeCreateBucket([]byte("test"))
+				tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+				tx.Unlock()
+				be.ForceCommit()
+				s.compactRaftLog(ep)
+			}
+			rn.Advance(rd)
+			for _, m := range rd.Messages {
+				switch {
+				case m.To == 3:
+					msgs = append(msgs, m)
+				case m.Type == raftpb.MsgApp:
+					rn.Step(raftpb.Message{Type: raftpb.MsgAppResp, From: 2, To: 1, Term: m.Term, Index: m.Index + uint64(len(m.Entries))})
+				}
+			}
+		}
+		return msgs
+	}
+
+	if err = rn.Campaign(); err != nil {
+		t.Fatal(err)
+	}
+	process()
+	rn.Step(raftpb.Message{Type: raftpb.MsgVoteResp, From: 2, To: 1, Term: rn.Status().Term})
+	msgs := process()
+	if len(msgs) != 1 || msgs[0].Type != raftpb.MsgApp {
+		t.Fatalf("messages to member 3 = %+v, want one MsgApp", msgs)
+	}
+	probe := msgs[0]
+	for i := 0; i < 100; i++ {
+		if err = rn.Propose([]byte("foo")); err != nil {
+			t.Fatal(err)
+		}
+		process()
+		if ep.appliedi == 50 {
+			if _, err = rs.CreateSnapshot(ep.appliedi, &cs, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	fi, err := rs.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi <= probe.Index {
+		t.Fatalf("first index = %d, want the log compacted past %d", fi, probe.Index)
+	}
+
+	// member 3 rejects the append it lags behind, and is sent the snapshot
+	rn.Step(raftpb.Message{Type: raftpb.MsgAppResp, From: 3, To: 1, Term: probe.Term, Index: probe.Index, Reject: true, RejectHint: 1})
+	msgs = process()
+	if len(msgs) != 1 || msgs[0].Type != raftpb.MsgSnap {
+		t.Fatalf("messages to member 3 = %+v, want one MsgSnap", msgs)
+	}
+	snapi := msgs[0].Snapshot.Metadata.Index
+	rn.ReportSnapshot(3, raft.SnapshotFinish)
+	rn.Step(raftpb.Message{Type: raftpb.MsgAppResp, From: 3, To: 1, Term: probe.Term, Index: snapi})
+
+	// it then catches up from the entries after the snapshot
+	msgs = process()
+	if len(msgs) != 1 || msgs[0].Type != raftpb.MsgApp || msgs[0].Index != snapi {
+		t.Fatalf("messages to member 3 = %+v, want one MsgApp after index %d", msgs, snapi)
+	}
+}
+
 // TestConcurrentApplyAndSnapshotV3 will send out snapshots concurrently with
 // proposals.
 func TestConcurrentApplyAndSnapshotV3(t *testing.T) {
@@ -1457,6 +1687,61 @@ func TestUpdateVersion(t *testing.T) {
 	}
 }
 
+// TestClusterVersionGate ensures requests using fields added in 3.2 are
+// refused until the cluster version reaches 3.2.
+func TestClusterVersionGate(t *testing.T) {
+	cl := membership.NewCluster("")
+	srv := &EtcdServer{cluster: cl}
+	for _, v := range []*semver.Version{nil, semver.New("3.1.0")} {
+		if v != nil {
+			cl.SetVersion(v, func(*semver.Version) {})
+		}
+		if srv.clusterVersionAtLeast(newRequestsVersion) {
+			t.Fatalf("cluster version %v: expected the gate to be closed", v)
+		}
+
+		tests := []func() error{
+			func() error {
+				_, err := srv.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 5, KeepKeys: true})
+				return err
+			},
+			func() error {
+				_, err := srv.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 5, Metadata: []byte("owner")})
+				return err
+			},
+			func() error {
+				_, err := srv.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), ExpireAt: 1})
+				return err
+			},
+			func() error {
+				put := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), ExpireAt: 1}}}
+				nested := &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Failure: []*pb.RequestOp{put}}}}
+				_, err := srv.Txn(context.TODO(), &pb.TxnRequest{Success: []*pb.RequestOp{nested}})
+				return err
+			},
+			func() error {
+				_, err := srv.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), ExpectedVersion: -1})
+				return err
+			},
+			func() error {
+				put := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), ExpectedModRevision: 5}}}
+				_, err := srv.Txn(context.TODO(), &pb.TxnRequest{Success: []*pb.RequestOp{put}})
+				return err
+			},
+		}
+		for i, tt := range tests {
+			if err := tt(); err != ErrClusterVersionTooLow {
+				t.Errorf("cluster version %v, #%d: err = %v, want %v", v, i, err, ErrClusterVersionTooLow)
+			}
+		}
+	}
+
+	cl.SetVersion(semver.New("3.2.0"), func(*semver.Version) {})
+	if !srv.clusterVersionAtLeast(newRequestsVersion) {
+		t.Fatal("cluster version 3.2.0: expected the gate to be open")
+	}
+}
+
 func TestStopNotify(t *testing.T) {
 	s := &EtcdServer{
 		stop: make(chan struct{}),
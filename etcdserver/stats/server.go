@@ -16,7 +16,6 @@ package stats
 
 import (
 	"encoding/json"
-	"log"
 	"sync"
 	"time"
 
@@ -81,7 +80,7 @@ func (ss *ServerStats) JSON() []byte {
 	b, err := json.Marshal(stats)
 	// TODO(jonboulle): appropriate error handling?
 	if err != nil {
-		log.Printf("stats: error marshalling server stats: %v", err)
+		plog.Errorf("error marshalling server stats: %v", err)
 	}
 	return b
 }
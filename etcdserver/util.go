@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/etcdserver/membership"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/rafthttp"
 )
@@ -95,3 +96,19 @@ func (nc *notifier) notify(err error) {
 	nc.err = err
 	close(nc.c)
 }
+
+// slowRequestExporter warns about traced requests that took longer than
+// threshold, then hands the trace on to the next exporter if there is one.
+type slowRequestExporter struct {
+	threshold time.Duration
+	next      traceutil.Exporter
+}
+
+func (e *slowRequestExporter) Export(t *traceutil.Trace) {
+	if d := time.Since(t.StartTime()); d > e.threshold {
+		plog.Warningf("request took too long (%v, expected %v): %v", d, e.threshold, t)
+	}
+	if e.next != nil {
+		e.next.Export(t)
+	}
+}
@@ -15,15 +15,20 @@
 package etcdserver
 
 import (
+	"bytes"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/coreos/etcd/etcdserver/membership"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/rafthttp"
 	"github.com/coreos/etcd/snap"
+	"github.com/coreos/pkg/capnslog"
 )
 
 func TestLongestConnected(t *testing.T) {
@@ -87,3 +92,39 @@ func (s *nopTransporterWithActiveTime) Stop()                               {}
 func (s *nopTransporterWithActiveTime) Pause()                              {}
 func (s *nopTransporterWithActiveTime) Resume()                             {}
 func (s *nopTransporterWithActiveTime) reset(am map[types.ID]time.Time)     { s.activeMap = am }
+
+type traceRecorder struct{ traces []*traceutil.Trace }
+
+func (r *traceRecorder) Export(t *traceutil.Trace) { r.traces = append(r.traces, t) }
+
+// TestSlowRequestExporter ensures only requests slower than the threshold
+// are logged and that all traces are passed on to the next exporter.
+func TestSlowRequestExporter(t *testing.T) {
+	var buf bytes.Buffer
+	capnslog.SetFormatter(capnslog.NewStringFormatter(&buf))
+	defer capnslog.SetFormatter(capnslog.NewDefaultFormatter(os.Stderr))
+
+	rec := &traceRecorder{}
+	e := &slowRequestExporter{threshold: time.Hour, next: rec}
+	tr := traceutil.New("range", e, traceutil.Field{Key: "range_begin", Value: "foo"})
+	tr.Step("range keys from storage")
+	tr.End()
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected warning for fast request: %q", buf.String())
+	}
+
+	e.threshold = time.Nanosecond
+	tr = traceutil.New("range", e, traceutil.Field{Key: "range_begin", Value: "foo"})
+	time.Sleep(time.Millisecond)
+	tr.Step("range keys from storage")
+	tr.AddField(traceutil.Field{Key: "response_count", Value: 1})
+	tr.End()
+	for _, w := range []string{"took too long", "range_begin:foo;", "response_count:1;", "range keys from storage"} {
+		if !strings.Contains(buf.String(), w) {
+			t.Errorf("expected %q in warning %q", w, buf.String())
+		}
+	}
+	if len(rec.traces) != 2 {
+		t.Fatalf("expected 2 exported traces, got %d", len(rec.traces))
+	}
+}
@@ -107,6 +107,10 @@ func (a *reqV2HandlerEtcdServer) processRaftRequest(ctx context.Context, r *Requ
 
 	select {
 	case x := <-ch:
+		if err, ok := x.(error); ok {
+			proposalsFailed.Inc()
+			return Response{}, a.s.parseWaitErr(err, start)
+		}
 		resp := x.(Response)
 		return resp, resp.Err
 	case <-ctx.Done():
@@ -119,6 +123,10 @@ func (a *reqV2HandlerEtcdServer) processRaftRequest(ctx context.Context, r *Requ
 }
 
 func (s *EtcdServer) Do(ctx context.Context, r pb.Request) (Response, error) {
+	// a witness keeps only the cluster metadata of the v2 store
+	if s.IsWitness() && !isClusterPath(r.Path) {
+		return Response{}, ErrWitness
+	}
 	r.ID = s.reqIDGen.Next()
 	h := &reqV2HandlerEtcdServer{
 		reqV2HandlerStore: reqV2HandlerStore{
@@ -26,8 +26,10 @@ import (
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/lease/leasehttp"
 	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/raft"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -39,6 +41,12 @@ const (
 	maxGapBetweenApplyAndCommitIndex = 5000
 )
 
+// newRequestsVersion is the cluster version from which members apply the
+// request fields added in 3.2. Requests using them are refused with
+// ErrClusterVersionTooLow below it, since older members would ignore the
+// fields and diverge from the members applying them.
+var newRequestsVersion = semver.Version{Major: 3, Minor: 2}
+
 type RaftKV interface {
 	Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error)
 	Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error)
@@ -57,6 +65,10 @@ type Lessor interface {
 	// is returned.
 	LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error)
 
+	// LeaseRenewBatch renews the leases with given IDs together. The renewed
+	// TTL of each lease is returned, or 0 for leases that do not exist.
+	LeaseRenewBatch(ctx context.Context, ids []lease.LeaseID) ([]int64, error)
+
 	// LeaseTimeToLive retrieves lease information.
 	LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error)
 
@@ -84,64 +96,189 @@ type Authenticator interface {
 }
 
 func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+	ctx, trace := s.newTrace(ctx, "range",
+		traceutil.Field{Key: "range_begin", Value: string(r.Key)},
+		traceutil.Field{Key: "range_end", Value: string(r.RangeEnd)},
+	)
+	defer trace.End()
+
+	if s.IsWitness() {
+		return nil, ErrWitness
+	}
+	// a corrupt member must not serve its keys either
+	if s.isCorrupt() {
+		return nil, ErrCorrupt
+	}
+	if isLargeRange(r) && s.OverMemoryBudget() {
+		return nil, ErrMemoryBudgetExceeded
+	}
 	if !r.Serializable {
 		err := s.linearizableReadNotify(ctx)
 		if err != nil {
 			return nil, err
 		}
+		trace.Step("agreement among raft nodes before linearized reading")
 	}
 	var resp *pb.RangeResponse
 	var err error
 	chk := func(ai *auth.AuthInfo) error {
 		return s.authStore.IsRangePermitted(ai, r.Key, r.RangeEnd)
 	}
-	get := func() { resp, err = s.applyV3Base.Range(nil, r) }
+	get := func() { resp, err = s.applyV3Base.Range(ctx, nil, r) }
 	if serr := s.doSerialize(ctx, chk, get); serr != nil {
 		return nil, serr
 	}
+	trace.Step("range keys from storage")
+	if resp != nil && trace.IsEnabled() {
+		trace.AddField(
+			traceutil.Field{Key: "response_count", Value: len(resp.Kvs)},
+			traceutil.Field{Key: "response_size", Value: resp.Size()},
+		)
+	}
 	return resp, err
 }
 
 func (s *EtcdServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error) {
+	ctx, trace := s.newTrace(ctx, "put", traceutil.Field{Key: "key", Value: string(r.Key)})
+	defer trace.End()
+
+	if s.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if !s.putSupported(r) {
+		return nil, ErrClusterVersionTooLow
+	}
+	if err := s.admission.AdmitPut(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := s.checkPrefixQuotas(s.KV(), []*pb.PutRequest{r}); err != nil {
+		return nil, err
+	}
+	if !s.valueFits(r) {
+		return nil, ErrValueTooLarge
+	}
+	if r.DryRun {
+		var resp *pb.PutResponse
+		err := s.dryRun(ctx, func(a applierV3) (err error) {
+			resp, err = a.Put(nil, r)
+			return err
+		})
+		return resp, err
+	}
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{Put: r})
 	if err != nil {
 		return nil, err
 	}
+	if trace.IsEnabled() {
+		trace.AddField(traceutil.Field{Key: "request_size", Value: r.Size()})
+	}
 	return resp.(*pb.PutResponse), nil
 }
 
 func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	ctx, trace := s.newTrace(ctx, "delete_range",
+		traceutil.Field{Key: "range_begin", Value: string(r.Key)},
+		traceutil.Field{Key: "range_end", Value: string(r.RangeEnd)},
+	)
+	defer trace.End()
+
+	if s.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if err := s.admission.AdmitDeleteRange(ctx, r); err != nil {
+		return nil, err
+	}
+	if r.DryRun {
+		var resp *pb.DeleteRangeResponse
+		err := s.dryRun(ctx, func(a applierV3) (err error) {
+			resp, err = a.DeleteRange(nil, r)
+			return err
+		})
+		return resp, err
+	}
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{DeleteRange: r})
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*pb.DeleteRangeResponse), nil
+	dresp := resp.(*pb.DeleteRangeResponse)
+	trace.AddField(traceutil.Field{Key: "deleted", Value: dresp.Deleted})
+	return dresp, nil
 }
 
 func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error) {
+	ctx, trace := s.newTrace(ctx, "transaction",
+		traceutil.Field{Key: "compares", Value: len(r.Compare)},
+		traceutil.Field{Key: "success_ops", Value: len(r.Success)},
+		traceutil.Field{Key: "failure_ops", Value: len(r.Failure)},
+	)
+	defer trace.End()
+
+	if s.IsWitness() {
+		return nil, ErrWitness
+	}
 	if isTxnReadonly(r) {
+		if s.isCorrupt() {
+			return nil, ErrCorrupt
+		}
 		if !isTxnSerializable(r) {
 			err := s.linearizableReadNotify(ctx)
 			if err != nil {
 				return nil, err
 			}
+			trace.Step("agreement among raft nodes before linearized reading")
 		}
 		var resp *pb.TxnResponse
 		var err error
 		chk := func(ai *auth.AuthInfo) error {
 			return checkTxnAuth(s.authStore, ai, r)
 		}
-		get := func() { resp, err = s.applyV3Base.Txn(r) }
+		get := func() { resp, err = s.applyV3Base.Txn(ctx, r) }
 		if serr := s.doSerialize(ctx, chk, get); serr != nil {
 			return nil, serr
 		}
+		trace.Step("read-only transaction from storage")
+		if resp != nil && trace.IsEnabled() {
+			trace.AddField(traceutil.Field{Key: "response_size", Value: resp.Size()})
+		}
+		return resp, err
+	}
+	if s.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if !s.txnPutsSupported(r) {
+		return nil, ErrClusterVersionTooLow
+	}
+	if err := s.admission.AdmitTxn(ctx, r); err != nil {
+		return nil, err
+	}
+	if puts, _ := txnPuts(r, compareToPath(s.KV(), r)); len(puts) != 0 {
+		if err := s.checkPrefixQuotas(s.KV(), puts); err != nil {
+			return nil, err
+		}
+	}
+	if !s.txnValuesFit(r) {
+		return nil, ErrValueTooLarge
+	}
+	if r.DryRun {
+		var resp *pb.TxnResponse
+		err := s.dryRun(ctx, func(a applierV3) (err error) {
+			resp, err = a.Txn(ctx, r)
+			return err
+		})
 		return resp, err
 	}
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{Txn: r})
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*pb.TxnResponse), nil
+	tresp := resp.(*pb.TxnResponse)
+	if trace.IsEnabled() {
+		trace.AddField(
+			traceutil.Field{Key: "request_size", Value: r.Size()},
+			traceutil.Field{Key: "response_size", Value: tresp.Size()},
+		)
+	}
+	return tresp, nil
 }
 
 func isTxnSerializable(r *pb.TxnRequest) bool {
@@ -158,6 +295,65 @@ func isTxnSerializable(r *pb.TxnRequest) bool {
 	return true
 }
 
+// valueFits reports whether the put's value is within MaxValueBytes. Values
+// are checked before proposal rather than at apply, so that members with
+// different limits never disagree on the outcome of a committed put.
+func (s *EtcdServer) valueFits(r *pb.PutRequest) bool {
+	return s.Cfg.MaxValueBytes == 0 || uint(len(r.Value)) <= s.Cfg.MaxValueBytes
+}
+
+// txnValuesFit reports whether every put of the txn, including the puts
+// of nested txns, has a value within MaxValueBytes.
+func (s *EtcdServer) txnValuesFit(r *pb.TxnRequest) bool {
+	if s.Cfg.MaxValueBytes == 0 {
+		return true
+	}
+	for _, reqs := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, u := range reqs {
+			switch tv := u.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				if tv.RequestPut != nil && !s.valueFits(tv.RequestPut) {
+					return false
+				}
+			case *pb.RequestOp_RequestTxn:
+				if tv.RequestTxn != nil && !s.txnValuesFit(tv.RequestTxn) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// putSupported reports whether the cluster version supports the fields set
+// on the put.
+func (s *EtcdServer) putSupported(r *pb.PutRequest) bool {
+	if r.ExpireAt == 0 && !isPutConditional(r) {
+		return true
+	}
+	return s.clusterVersionAtLeast(newRequestsVersion)
+}
+
+// txnPutsSupported reports whether the cluster version supports every put
+// of the txn, including the puts of nested txns.
+func (s *EtcdServer) txnPutsSupported(r *pb.TxnRequest) bool {
+	for _, reqs := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, u := range reqs {
+			switch tv := u.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				if tv.RequestPut != nil && !s.putSupported(tv.RequestPut) {
+					return false
+				}
+			case *pb.RequestOp_RequestTxn:
+				if tv.RequestTxn != nil && !s.txnPutsSupported(tv.RequestTxn) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
 func isTxnReadonly(r *pb.TxnRequest) bool {
 	for _, u := range r.Success {
 		if r := u.GetRequestRange(); r == nil {
@@ -201,6 +397,15 @@ func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.
 }
 
 func (s *EtcdServer) LeaseGrant(ctx context.Context, r *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	if s.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if len(r.Metadata) > lease.MaxMetadataSize {
+		return nil, lease.ErrLeaseMetadataTooLarge
+	}
+	if (len(r.Metadata) != 0 || r.KeepKeys) && !s.clusterVersionAtLeast(newRequestsVersion) {
+		return nil, ErrClusterVersionTooLow
+	}
 	// no id given? choose one
 	for r.ID == int64(lease.NoLease) {
 		// only use positive int64 id's
@@ -222,6 +427,9 @@ func (s *EtcdServer) LeaseRevoke(ctx context.Context, r *pb.LeaseRevokeRequest)
 }
 
 func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error) {
+	if s.IsWitness() {
+		return -1, ErrWitness
+	}
 	ttl, err := s.lessor.Renew(id)
 	if err == nil { // already requested to primary lessor(leader)
 		return ttl, nil
@@ -250,7 +458,71 @@ func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, e
 	return -1, ErrTimeout
 }
 
+func (s *EtcdServer) LeaseRenewBatch(ctx context.Context, ids []lease.LeaseID) ([]int64, error) {
+	if s.IsWitness() {
+		return nil, ErrWitness
+	}
+	ttls := make([]int64, len(ids))
+	for i, id := range ids {
+		ttl, err := s.lessor.Renew(id)
+		if err == lease.ErrNotPrimary {
+			return s.forwardRenewBatch(ctx, ids)
+		}
+		if err == lease.ErrLeaseNotFound {
+			ttl, err = 0, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ttls[i] = ttl
+	}
+	return ttls, nil
+}
+
+// forwardRenewBatch renews leases through the leader in a single request,
+// falling back to one request per lease if the leader can't batch them.
+func (s *EtcdServer) forwardRenewBatch(ctx context.Context, ids []lease.LeaseID) ([]int64, error) {
+	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+
+	for cctx.Err() == nil {
+		leader, lerr := s.waitLeader(cctx)
+		if lerr != nil {
+			return nil, lerr
+		}
+		for _, url := range leader.PeerURLs {
+			lurl := url + leasehttp.LeaseBatchPrefix
+			ttls, err := leasehttp.RenewBatchHTTP(cctx, ids, lurl, s.peerRt)
+			if err == nil {
+				return ttls, nil
+			}
+			if err == leasehttp.ErrLeaseHTTPBatchUnsupported {
+				return s.renewEach(cctx, ids)
+			}
+		}
+	}
+	return nil, ErrTimeout
+}
+
+func (s *EtcdServer) renewEach(ctx context.Context, ids []lease.LeaseID) ([]int64, error) {
+	ttls := make([]int64, len(ids))
+	for i, id := range ids {
+		ttl, err := s.LeaseRenew(ctx, id)
+		if err == lease.ErrLeaseNotFound {
+			ttl, err = 0, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ttls[i] = ttl
+	}
+	return ttls, nil
+}
+
 func (s *EtcdServer) LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	if s.IsWitness() {
+		return nil, ErrWitness
+	}
 	if s.Leader() == s.ID() {
 		// primary; timetolive directly from leader
 		le := s.lessor.Lookup(lease.LeaseID(r.ID))
@@ -258,7 +530,7 @@ func (s *EtcdServer) LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveR
 			return nil, lease.ErrLeaseNotFound
 		}
 		// TODO: fill out ResponseHeader
-		resp := &pb.LeaseTimeToLiveResponse{Header: &pb.ResponseHeader{}, ID: r.ID, TTL: int64(le.Remaining().Seconds()), GrantedTTL: le.TTL()}
+		resp := &pb.LeaseTimeToLiveResponse{Header: &pb.ResponseHeader{}, ID: r.ID, TTL: int64(le.Remaining().Seconds()), GrantedTTL: le.TTL(), Metadata: le.Metadata()}
 		if r.Keys {
 			ks := le.Keys()
 			kbs := make([][]byte, len(ks))
@@ -294,10 +566,13 @@ func (s *EtcdServer) LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveR
 }
 
 func (s *EtcdServer) LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	if s.IsWitness() {
+		return nil, ErrWitness
+	}
 	ls := s.lessor.Leases()
 	lss := make([]*pb.LeaseStatus, len(ls))
 	for i := range ls {
-		lss[i] = &pb.LeaseStatus{ID: int64(ls[i].ID)}
+		lss[i] = &pb.LeaseStatus{ID: int64(ls[i].ID), Metadata: ls[i].Metadata()}
 	}
 	return &pb.LeaseLeasesResponse{Header: newHeader(s), Leases: lss}, nil
 }
@@ -509,6 +784,16 @@ func (s *EtcdServer) raftRequest(ctx context.Context, r pb.InternalRaftRequest)
 	}
 }
 
+// newTrace starts a trace of a client request and stores it in the returned
+// context. Tracing is disabled unless a trace exporter is configured.
+func (s *EtcdServer) newTrace(ctx context.Context, op string, fields ...traceutil.Field) (context.Context, *traceutil.Trace) {
+	if s.traceExporter == nil {
+		return ctx, traceutil.TODO()
+	}
+	trace := traceutil.New(op, s.traceExporter, fields...)
+	return context.WithValue(ctx, traceutil.TraceKey{}, trace), trace
+}
+
 // doSerialize handles the auth logic, with permissions checked by "chk", for a serialized request "get". Returns a non-nil error on authentication failure.
 func (s *EtcdServer) doSerialize(ctx context.Context, chk func(*auth.AuthInfo) error, get func()) error {
 	for {
@@ -537,10 +822,21 @@ func (s *EtcdServer) doSerialize(ctx context.Context, chk func(*auth.AuthInfo) e
 }
 
 func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.InternalRaftRequest) (*applyResult, error) {
-	ai := s.getAppliedIndex()
-	ci := s.getCommittedIndex()
-	if ci > ai+maxGapBetweenApplyAndCommitIndex {
-		return nil, ErrTooManyRequests
+	if err := s.admit(raftRequestPriority(ctx, &r)); err != nil {
+		return nil, err
+	}
+
+	// a corrupt member must not write to the cluster, but may disarm
+	if r.Alarm == nil && s.isCorrupt() {
+		return nil, ErrCorrupt
+	}
+	// nor may a degraded one, though it may raise its alarm
+	if r.Alarm == nil && s.isDegraded() {
+		return nil, ErrDegraded
+	}
+	// a witness applies no v3 requests, so it could never see its own
+	if s.IsWitness() {
+		return nil, ErrWitness
 	}
 
 	r.Header = &pb.RequestHeader{
@@ -569,19 +865,35 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	if id == 0 {
 		id = r.Header.ID
 	}
-	ch := s.w.Register(id)
+	start := time.Now()
+	ch := s.w.RegisterWithDeadline(id, start.Add(s.Cfg.ReqTimeout()))
 
 	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
 	defer cancel()
 
-	start := time.Now()
 	s.r.Propose(cctx, data)
 	proposalsPending.Inc()
 	defer proposalsPending.Dec()
+	s.addPendingBytes(int64(len(data)))
+	defer s.addPendingBytes(-int64(len(data)))
+
+	trace := traceutil.Get(ctx)
+	trace.Step("propose request to raft")
 
 	select {
 	case x := <-ch:
-		return x.(*applyResult), nil
+		if err, ok := x.(error); ok {
+			proposalsFailed.Inc()
+			return nil, s.parseWaitErr(err, start)
+		}
+		ar := x.(*applyResult)
+		if ar != nil && !ar.applyStart.IsZero() {
+			// time until apply start covers raft replication, fsync and
+			// waiting for earlier entries to be applied
+			trace.StepAt(ar.applyStart, "request committed and queued for apply")
+		}
+		trace.Step("request applied")
+		return ar, nil
 	case <-cctx.Done():
 		proposalsFailed.Inc()
 		s.w.Trigger(id, nil) // GC wait
@@ -664,6 +976,15 @@ func (s *EtcdServer) linearizableReadLoop() {
 }
 
 func (s *EtcdServer) linearizableReadNotify(ctx context.Context) error {
+	// a degraded member never catches up to the read index
+	if s.isDegraded() {
+		return ErrDegraded
+	}
+	// user reads would wait out the apply backlog; leave the wait to system
+	// reads
+	if err := s.admit(priorityFromCtx(ctx)); err != nil {
+		return err
+	}
 	s.readMu.RLock()
 	nc := s.readNotifier
 	s.readMu.RUnlock()
@@ -0,0 +1,46 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/etcd/mvcc"
+)
+
+// ParseValueIndexes parses a ',' separated list of value indexes of the form
+// 'name=prefix:pointer', e.g. 'by-owner=/jobs/:/owner', indexing the JSON
+// values of the keys under prefix by the field at the JSON pointer. Prefixes
+// may not contain ':'.
+func ParseValueIndexes(s string) ([]mvcc.ValueIndex, error) {
+	var vis []mvcc.ValueIndex
+	if strings.TrimSpace(s) == "" {
+		return vis, nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		i := strings.Index(f, "=")
+		j := strings.Index(f, ":")
+		if i <= 0 || j < i {
+			return nil, fmt.Errorf("value index %q is not of the form name=prefix:pointer", f)
+		}
+		vi, err := mvcc.NewJSONPointerIndex(f[:i], []byte(f[i+1:j]), f[j+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value index %q (%v)", f, err)
+		}
+		vis = append(vis, vi)
+	}
+	return vis, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import "testing"
+
+func TestParseValueIndexes(t *testing.T) {
+	tests := []struct {
+		s string
+
+		names    []string
+		prefixes []string
+		werr     bool
+	}{
+		{"", nil, nil, false},
+		{"a=/x/:/owner", []string{"a"}, []string{"/x/"}, false},
+		{"a=/x/:/owner,b=:/kind", []string{"a", "b"}, []string{"/x/", ""}, false},
+		{"a", nil, nil, true},
+		{"=/x/:/owner", nil, nil, true},
+		{"a:/owner=b", nil, nil, true},
+		{"a=/x/:owner", nil, nil, true},
+	}
+	for i, tt := range tests {
+		vis, err := ParseValueIndexes(tt.s)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+			continue
+		}
+		if len(vis) != len(tt.names) {
+			t.Errorf("#%d: got %d indexes, want %d", i, len(vis), len(tt.names))
+			continue
+		}
+		for j, vi := range vis {
+			if vi.Name != tt.names[j] || string(vi.Prefix) != tt.prefixes[j] {
+				t.Errorf("#%d.%d: index = %q on %q, want %q on %q", i, j, vi.Name, vi.Prefix, tt.names[j], tt.prefixes[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valueschema validates the values written under key prefixes
+// against a schema registered for each prefix.
+//
+// A schema is either a JSON Schema document, for JSON values, or a protobuf
+// message type in a file descriptor set, for values that are serialized
+// messages of the type. Of JSON Schema, the keywords about types, objects,
+// arrays, strings, numbers and enums are supported, along with allOf, anyOf,
+// oneOf and not; schemas using references are refused. Protobuf values must
+// decode as the message type without unknown fields, with the wire types of
+// the declared fields and with valid UTF-8 strings.
+//
+// The schemas are enforced by an admission hook, so each put is validated
+// on the member that receives it, before it is proposed.
+package valueschema
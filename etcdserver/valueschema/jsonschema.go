@@ -0,0 +1,374 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema is a compiled JSON Schema. Unset limits are negative.
+type jsonSchema struct {
+	types []string
+	enum  []interface{}
+
+	properties   map[string]*jsonSchema
+	required     []string
+	additional   *jsonSchema
+	noAdditional bool
+
+	items              *jsonSchema
+	minItems, maxItems int
+
+	minLength, maxLength int
+	pattern              *regexp.Regexp
+
+	minimum, maximum *float64
+
+	allOf, anyOf, oneOf []*jsonSchema
+	not                 *jsonSchema
+}
+
+// NewJSONSchema compiles a JSON Schema document into a Validator of JSON
+// values.
+func NewJSONSchema(doc []byte) (Validator, error) {
+	var v interface{}
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, err
+	}
+	return compileJSONSchema(v, "")
+}
+
+func compileJSONSchema(v interface{}, path string) (*jsonSchema, error) {
+	s := &jsonSchema{minItems: -1, maxItems: -1, minLength: -1, maxLength: -1}
+	if b, ok := v.(bool); ok {
+		// true accepts any value, false none
+		if !b {
+			s.not = &jsonSchema{minItems: -1, maxItems: -1, minLength: -1, maxLength: -1}
+		}
+		return s, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema is not an object", schemaPath(path))
+	}
+	var err error
+	for k, kv := range m {
+		kpath := path + "/" + k
+		switch k {
+		case "$ref":
+			return nil, fmt.Errorf("%s: references are not supported", kpath)
+		case "type":
+			switch t := kv.(type) {
+			case string:
+				s.types = []string{t}
+			case []interface{}:
+				for _, e := range t {
+					es, ok := e.(string)
+					if !ok {
+						return nil, fmt.Errorf("%s: type is not a string", kpath)
+					}
+					s.types = append(s.types, es)
+				}
+			default:
+				return nil, fmt.Errorf("%s: type is not a string or an array", kpath)
+			}
+			for _, t := range s.types {
+				switch t {
+				case "null", "boolean", "object", "array", "number", "integer", "string":
+				default:
+					return nil, fmt.Errorf("%s: unknown type %q", kpath, t)
+				}
+			}
+		case "enum":
+			if s.enum, ok = kv.([]interface{}); !ok {
+				return nil, fmt.Errorf("%s: enum is not an array", kpath)
+			}
+		case "properties":
+			pm, ok := kv.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: properties is not an object", kpath)
+			}
+			s.properties = make(map[string]*jsonSchema, len(pm))
+			for name, pv := range pm {
+				if s.properties[name], err = compileJSONSchema(pv, kpath+"/"+name); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			rs, ok := kv.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: required is not an array", kpath)
+			}
+			for _, r := range rs {
+				name, ok := r.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: required property is not a string", kpath)
+				}
+				s.required = append(s.required, name)
+			}
+		case "additionalProperties":
+			if b, ok := kv.(bool); ok && !b {
+				s.noAdditional = true
+			} else if s.additional, err = compileJSONSchema(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "items":
+			if s.items, err = compileJSONSchema(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "minItems":
+			if s.minItems, err = schemaCount(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "maxItems":
+			if s.maxItems, err = schemaCount(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "minLength":
+			if s.minLength, err = schemaCount(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "maxLength":
+			if s.maxLength, err = schemaCount(kv, kpath); err != nil {
+				return nil, err
+			}
+		case "pattern":
+			p, ok := kv.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: pattern is not a string", kpath)
+			}
+			if s.pattern, err = regexp.Compile(p); err != nil {
+				return nil, fmt.Errorf("%s: %v", kpath, err)
+			}
+		case "minimum", "maximum":
+			f, ok := kv.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s is not a number", kpath, k)
+			}
+			if k == "minimum" {
+				s.minimum = &f
+			} else {
+				s.maximum = &f
+			}
+		case "allOf", "anyOf", "oneOf":
+			es, ok := kv.([]interface{})
+			if !ok || len(es) == 0 {
+				return nil, fmt.Errorf("%s: %s is not a non-empty array", kpath, k)
+			}
+			var ss []*jsonSchema
+			for i, e := range es {
+				cs, cerr := compileJSONSchema(e, fmt.Sprintf("%s/%d", kpath, i))
+				if cerr != nil {
+					return nil, cerr
+				}
+				ss = append(ss, cs)
+			}
+			switch k {
+			case "allOf":
+				s.allOf = ss
+			case "anyOf":
+				s.anyOf = ss
+			default:
+				s.oneOf = ss
+			}
+		case "not":
+			if s.not, err = compileJSONSchema(kv, kpath); err != nil {
+				return nil, err
+			}
+		}
+		// other keywords, such as titles and descriptions, do not constrain values
+	}
+	return s, nil
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func schemaCount(v interface{}, path string) (int, error) {
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%s: not a non-negative integer", path)
+	}
+	return int(f), nil
+}
+
+func (s *jsonSchema) Validate(value []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return fmt.Errorf("invalid JSON (%v)", err)
+	}
+	return s.validate(v, "")
+}
+
+// validate returns an error naming the JSON pointer of the first part of
+// v that does not conform to the schema.
+func (s *jsonSchema) validate(v interface{}, path string) error {
+	if len(s.types) != 0 && !hasJSONType(s.types, v) {
+		return fmt.Errorf("%s: got %s, want %s", schemaPath(path), jsonType(v), strings.Join(s.types, " or "))
+	}
+	if s.enum != nil {
+		found := false
+		for _, e := range s.enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: value is not one of the enum values", schemaPath(path))
+		}
+	}
+
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := tv[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", schemaPath(path), name)
+			}
+		}
+		// validate in name order, so errors are stable
+		names := make([]string, 0, len(tv))
+		for name := range tv {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ps, ok := s.properties[name]
+			switch {
+			case ok:
+			case s.noAdditional:
+				return fmt.Errorf("%s: property %q is not allowed", schemaPath(path), name)
+			case s.additional != nil:
+				ps = s.additional
+			default:
+				continue
+			}
+			if err := ps.validate(tv[name], path+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.minItems >= 0 && len(tv) < s.minItems {
+			return fmt.Errorf("%s: got %d items, want at least %d", schemaPath(path), len(tv), s.minItems)
+		}
+		if s.maxItems >= 0 && len(tv) > s.maxItems {
+			return fmt.Errorf("%s: got %d items, want at most %d", schemaPath(path), len(tv), s.maxItems)
+		}
+		if s.items != nil {
+			for i, e := range tv {
+				if err := s.items.validate(e, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		n := utf8.RuneCountInString(tv)
+		if s.minLength >= 0 && n < s.minLength {
+			return fmt.Errorf("%s: got %d characters, want at least %d", schemaPath(path), n, s.minLength)
+		}
+		if s.maxLength >= 0 && n > s.maxLength {
+			return fmt.Errorf("%s: got %d characters, want at most %d", schemaPath(path), n, s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(tv) {
+			return fmt.Errorf("%s: %q does not match pattern %q", schemaPath(path), tv, s.pattern.String())
+		}
+	case float64:
+		if s.minimum != nil && tv < *s.minimum {
+			return fmt.Errorf("%s: %v is less than the minimum %v", schemaPath(path), tv, *s.minimum)
+		}
+		if s.maximum != nil && tv > *s.maximum {
+			return fmt.Errorf("%s: %v is greater than the maximum %v", schemaPath(path), tv, *s.maximum)
+		}
+	}
+
+	for _, as := range s.allOf {
+		if err := as.validate(v, path); err != nil {
+			return err
+		}
+	}
+	if s.anyOf != nil {
+		matched := false
+		for _, as := range s.anyOf {
+			if as.validate(v, path) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value matches none of the anyOf schemas", schemaPath(path))
+		}
+	}
+	if s.oneOf != nil {
+		n := 0
+		for _, os := range s.oneOf {
+			if os.validate(v, path) == nil {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf("%s: value matches %d of the oneOf schemas, want 1", schemaPath(path), n)
+		}
+	}
+	if s.not != nil && s.not.validate(v, path) == nil {
+		return fmt.Errorf("%s: value matches a schema it must not match", schemaPath(path))
+	}
+	return nil
+}
+
+func jsonType(v interface{}) string {
+	switch tv := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case float64:
+		if tv == math.Trunc(tv) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func hasJSONType(types []string, v interface{}) bool {
+	vt := jsonType(v)
+	for _, t := range types {
+		if t == vt || (t == "number" && vt == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePointer escapes a property name as a JSON pointer token.
+func escapePointer(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testJSONSchema = `{
+	"type": "object",
+	"required": ["name", "replicas"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 8},
+		"replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+		"mode": {"enum": ["active", "standby"]},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+		"port": {"oneOf": [{"type": "integer"}, {"type": "string", "pattern": "^[0-9]+$"}]}
+	}
+}`
+
+func TestJSONSchema(t *testing.T) {
+	v, err := NewJSONSchema([]byte(testJSONSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		value string
+
+		werr string
+	}{
+		{`{"name":"web","replicas":3}`, ""},
+		{`{"name":"web","replicas":3,"mode":"standby","tags":["a","b"],"port":"80"}`, ""},
+		{`{"name":"web","replicas":3,"port":80}`, ""},
+		{`{"name":"web"`, "invalid JSON"},
+		{`[]`, "/: got array, want object"},
+		{`{"name":"web"}`, `/: missing required property "replicas"`},
+		{`{"name":"web","replicas":3,"x":1}`, `/: property "x" is not allowed`},
+		{`{"name":"Web","replicas":3}`, `/name: "Web" does not match pattern`},
+		{`{"name":"webserverx","replicas":3}`, "/name: got 10 characters, want at most 8"},
+		{`{"name":"web","replicas":2.5}`, "/replicas: got number, want integer"},
+		{`{"name":"web","replicas":9}`, "/replicas: 9 is greater than the maximum 5"},
+		{`{"name":"web","replicas":3,"mode":"off"}`, "/mode: value is not one of the enum values"},
+		{`{"name":"web","replicas":3,"tags":["a",1]}`, "/tags/1: got integer, want string"},
+		{`{"name":"web","replicas":3,"tags":["a","b","c"]}`, "/tags: got 3 items, want at most 2"},
+		{`{"name":"web","replicas":3,"port":"http"}`, "/port: value matches 0 of the oneOf schemas, want 1"},
+	}
+	for i, tt := range tests {
+		err := v.Validate([]byte(tt.value))
+		if tt.werr == "" {
+			if err != nil {
+				t.Errorf("#%d: err = %v, want nil", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.werr) {
+			t.Errorf("#%d: err = %v, want %q", i, err, tt.werr)
+		}
+	}
+}
+
+func TestJSONSchemaInvalid(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`{"type": "text"}`,
+		`{"properties": {"a": {"$ref": "#/definitions/a"}}}`,
+		`{"pattern": "("}`,
+		`{"minItems": -1}`,
+		`{"anyOf": []}`,
+	}
+	for i, tt := range tests {
+		if _, err := NewJSONSchema([]byte(tt)); err == nil {
+			t.Errorf("#%d: expected error for schema %s", i, tt)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// protoMessage validates the encoding of a protobuf message type.
+type protoMessage struct {
+	name   string
+	fields map[int32]*dpb.FieldDescriptorProto
+	// types has every message type of the descriptor set by full name,
+	// for the fields of message types.
+	types map[string]*dpb.DescriptorProto
+}
+
+// NewProtoSchema returns a Validator of values that are serialized messages
+// of the message type with the given full name, e.g. "app.v1.Config", in a
+// serialized FileDescriptorSet, as written by protoc --descriptor_set_out.
+func NewProtoSchema(descriptorSet []byte, message string) (Validator, error) {
+	fds := &dpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptorSet, fds); err != nil {
+		return nil, err
+	}
+	types := make(map[string]*dpb.DescriptorProto)
+	for _, fd := range fds.File {
+		prefix := ""
+		if fd.GetPackage() != "" {
+			prefix = fd.GetPackage() + "."
+		}
+		addProtoTypes(types, prefix, fd.MessageType)
+	}
+	if _, ok := types[message]; !ok {
+		return nil, fmt.Errorf("message type %q not found in descriptor set", message)
+	}
+	return newProtoMessage(types, message), nil
+}
+
+func addProtoTypes(types map[string]*dpb.DescriptorProto, prefix string, mds []*dpb.DescriptorProto) {
+	for _, md := range mds {
+		name := prefix + md.GetName()
+		types[name] = md
+		addProtoTypes(types, name+".", md.NestedType)
+	}
+}
+
+func newProtoMessage(types map[string]*dpb.DescriptorProto, name string) *protoMessage {
+	m := &protoMessage{name: name, fields: make(map[int32]*dpb.FieldDescriptorProto), types: types}
+	for _, f := range types[name].Field {
+		m.fields[f.GetNumber()] = f
+	}
+	return m
+}
+
+func (m *protoMessage) Validate(value []byte) error {
+	return m.validate(value, m.name)
+}
+
+// validate returns an error naming the path of fields from the validated
+// message type to the first part of b that is not an encoding of the type.
+func (m *protoMessage) validate(b []byte, path string) error {
+	seen := make(map[int32]bool)
+	for len(b) > 0 {
+		tag, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return fmt.Errorf("%s: truncated field tag", path)
+		}
+		b = b[n:]
+		num, wt := int32(tag>>3), int(tag&7)
+		f, ok := m.fields[num]
+		if !ok {
+			return fmt.Errorf("%s: unknown field number %d", path, num)
+		}
+		fpath := path + "." + f.GetName()
+		want := protoWireType(f.GetType())
+		packed := wt == proto.WireBytes && f.GetLabel() == dpb.FieldDescriptorProto_LABEL_REPEATED && want != proto.WireBytes
+		if wt != want && !packed {
+			return fmt.Errorf("%s: got wire type %d, want %d", fpath, wt, want)
+		}
+		if wt == proto.WireStartGroup {
+			return fmt.Errorf("%s: groups are not supported", fpath)
+		}
+		seen[num] = true
+
+		var data []byte
+		if n, data = protoSkip(b, wt); n < 0 {
+			return fmt.Errorf("%s: truncated value", fpath)
+		}
+		b = b[n:]
+		switch {
+		case packed:
+			for len(data) > 0 {
+				if n, _ = protoSkip(data, want); n < 0 {
+					return fmt.Errorf("%s: truncated packed value", fpath)
+				}
+				data = data[n:]
+			}
+		case f.GetType() == dpb.FieldDescriptorProto_TYPE_STRING:
+			if !utf8.Valid(data) {
+				return fmt.Errorf("%s: string is not valid UTF-8", fpath)
+			}
+		case f.GetType() == dpb.FieldDescriptorProto_TYPE_MESSAGE:
+			name := strings.TrimPrefix(f.GetTypeName(), ".")
+			if _, ok := m.types[name]; !ok {
+				// a type of a file missing from the descriptor set
+				continue
+			}
+			if err := newProtoMessage(m.types, name).validate(data, fpath); err != nil {
+				return err
+			}
+		}
+	}
+	for num, f := range m.fields {
+		if f.GetLabel() == dpb.FieldDescriptorProto_LABEL_REQUIRED && !seen[num] {
+			return fmt.Errorf("%s: missing required field %q", path, f.GetName())
+		}
+	}
+	return nil
+}
+
+func protoWireType(t dpb.FieldDescriptorProto_Type) int {
+	switch t {
+	case dpb.FieldDescriptorProto_TYPE_DOUBLE, dpb.FieldDescriptorProto_TYPE_FIXED64, dpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return proto.WireFixed64
+	case dpb.FieldDescriptorProto_TYPE_FLOAT, dpb.FieldDescriptorProto_TYPE_FIXED32, dpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return proto.WireFixed32
+	case dpb.FieldDescriptorProto_TYPE_STRING, dpb.FieldDescriptorProto_TYPE_BYTES, dpb.FieldDescriptorProto_TYPE_MESSAGE:
+		return proto.WireBytes
+	case dpb.FieldDescriptorProto_TYPE_GROUP:
+		return proto.WireStartGroup
+	default:
+		return proto.WireVarint
+	}
+}
+
+// protoSkip returns the length of the value of wire type wt at the start
+// of b, and the contents of a length-delimited value, or a negative length
+// if b is too short or wt is a group.
+func protoSkip(b []byte, wt int) (int, []byte) {
+	switch wt {
+	case proto.WireVarint:
+		if _, n := proto.DecodeVarint(b); n > 0 {
+			return n, nil
+		}
+	case proto.WireFixed64:
+		if len(b) >= 8 {
+			return 8, nil
+		}
+	case proto.WireFixed32:
+		if len(b) >= 4 {
+			return 4, nil
+		}
+	case proto.WireBytes:
+		l, n := binary.Uvarint(b)
+		if n > 0 && l <= uint64(len(b)-n) {
+			return n + int(l), b[n : n+int(l)]
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// kvDescriptorSet returns a descriptor set of the mvccpb messages.
+func kvDescriptorSet(t *testing.T) []byte {
+	gz, _ := (&mvccpb.KeyValue{}).Descriptor()
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := &dpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(b, fd); err != nil {
+		t.Fatal(err)
+	}
+	set, err := proto.Marshal(&dpb.FileDescriptorSet{File: []*dpb.FileDescriptorProto{fd}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+func TestProtoSchema(t *testing.T) {
+	set := kvDescriptorSet(t)
+	if _, err := NewProtoSchema(set, "mvccpb.Missing"); err == nil {
+		t.Fatal("expected error for unknown message type")
+	}
+	v, err := NewProtoSchema(set, "mvccpb.Event")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev, err := (&mvccpb.Event{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 5}}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// field 2 (kv) holding field 9, which KeyValue does not have
+	unknown := []byte{0x12, 0x02, 0x48, 0x01}
+	tests := []struct {
+		value []byte
+
+		werr string
+	}{
+		{ev, ""},
+		{nil, ""},
+		{[]byte("{}"), "mvccpb.Event: unknown field number 15"},
+		{unknown, "mvccpb.Event.kv: unknown field number 9"},
+		{ev[:len(ev)-1], "mvccpb.Event.kv: truncated value"},
+		{[]byte{0x08, 0x80}, "mvccpb.Event.type: truncated value"},
+		{[]byte{0x0a, 0x00}, "mvccpb.Event.type: got wire type 2, want 0"},
+	}
+	for i, tt := range tests {
+		err := v.Validate(tt.value)
+		if tt.werr == "" {
+			if err != nil {
+				t.Errorf("#%d: err = %v, want nil", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.werr) {
+			t.Errorf("#%d: err = %v, want %q", i, err, tt.werr)
+		}
+	}
+}
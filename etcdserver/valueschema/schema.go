@@ -0,0 +1,120 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/coreos/etcd/etcdserver/admission"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// Validator checks values against a schema.
+type Validator interface {
+	// Validate returns an error describing where value does not conform
+	// to the schema, or nil if it does.
+	Validate(value []byte) error
+}
+
+// Schema is the schema of the values of the keys under a prefix.
+type Schema struct {
+	Prefix string
+	Validator
+}
+
+// Parse parses a ',' separated list of schemas of the form 'prefix=file',
+// reading each schema from its file. A file named 'file#message' is a
+// serialized protobuf FileDescriptorSet holding the message type with the
+// full name message; any other file is a JSON Schema document, e.g.
+// '/config/=/etc/etcd/config.schema.json,/jobs/=/etc/etcd/jobs.pb#jobs.Job'.
+// Prefixes may not contain '='.
+func Parse(s string) ([]Schema, error) {
+	var schemas []Schema
+	if strings.TrimSpace(s) == "" {
+		return schemas, nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		i := strings.Index(f, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("value schema %q is not of the form prefix=file", f)
+		}
+		sc := Schema{Prefix: f[:i]}
+		path, message := f[i+1:], ""
+		if j := strings.LastIndex(path, "#"); j >= 0 {
+			path, message = path[:j], path[j+1:]
+		}
+		doc, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read value schema %q (%v)", f, err)
+		}
+		if message != "" {
+			sc.Validator, err = NewProtoSchema(doc, message)
+		} else {
+			sc.Validator, err = NewJSONSchema(doc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value schema %q (%v)", f, err)
+		}
+		schemas = append(schemas, sc)
+	}
+	return schemas, nil
+}
+
+type hook struct {
+	// schemas is sorted by prefix, longest prefixes last.
+	schemas []Schema
+}
+
+// NewHook returns an admission hook that rejects the puts whose values do
+// not conform to the schema of the longest prefix of their key.
+func NewHook(schemas []Schema) admission.Hook {
+	h := &hook{schemas: make([]Schema, len(schemas))}
+	copy(h.schemas, schemas)
+	sort.Slice(h.schemas, func(i, j int) bool { return h.schemas[i].Prefix < h.schemas[j].Prefix })
+	return h
+}
+
+func (h *hook) Name() string { return "value-schema" }
+
+// lookup returns the schema of the longest prefix of key, or nil.
+func (h *hook) lookup(key []byte) *Schema {
+	for i := len(h.schemas) - 1; i >= 0; i-- {
+		if strings.HasPrefix(string(key), h.schemas[i].Prefix) {
+			return &h.schemas[i]
+		}
+	}
+	return nil
+}
+
+func (h *hook) AdmitPut(ctx context.Context, r *pb.PutRequest) error {
+	if r.IgnoreValue {
+		// the key keeps its current value
+		return nil
+	}
+	sc := h.lookup(r.Key)
+	if sc == nil {
+		return nil
+	}
+	if err := sc.Validate(r.Value); err != nil {
+		return fmt.Errorf("value of key %q does not conform to the schema of prefix %q: %v", r.Key, sc.Prefix, err)
+	}
+	return nil
+}
+
+func (h *hook) AdmitDeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) error { return nil }
@@ -0,0 +1,103 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueschema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestParse(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "valueschema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	js, pbs := filepath.Join(dir, "a.json"), filepath.Join(dir, "kv.pb")
+	if err = ioutil.WriteFile(js, []byte(`{"type":"object"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(pbs, kvDescriptorSet(t), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		s string
+
+		wprefixes []string
+		werr      bool
+	}{
+		{"", nil, false},
+		{"/a/=" + js + ",/kv/=" + pbs + "#mvccpb.KeyValue", []string{"/a/", "/kv/"}, false},
+		{"/a/", nil, true},
+		{"/a/=" + filepath.Join(dir, "missing.json"), nil, true},
+		{"/a/=" + pbs, nil, true},
+		{"/a/=" + pbs + "#mvccpb.Missing", nil, true},
+	}
+	for i, tt := range tests {
+		schemas, err := Parse(tt.s)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if len(schemas) != len(tt.wprefixes) {
+			t.Errorf("#%d: got %d schemas, want %d", i, len(schemas), len(tt.wprefixes))
+			continue
+		}
+		for j, sc := range schemas {
+			if sc.Prefix != tt.wprefixes[j] {
+				t.Errorf("#%d.%d: prefix = %q, want %q", i, j, sc.Prefix, tt.wprefixes[j])
+			}
+		}
+	}
+}
+
+func TestHookLongestPrefix(t *testing.T) {
+	obj, err := NewJSONSchema([]byte(`{"type":"object"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	str, err := NewJSONSchema([]byte(`{"type":"string"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHook([]Schema{{"/a/b/", str}, {"/a/", obj}})
+
+	tests := []struct {
+		key, value string
+
+		werr bool
+	}{
+		{"/a/x", `{}`, false},
+		{"/a/x", `"s"`, true},
+		{"/a/b/x", `"s"`, false},
+		{"/a/b/x", `{}`, true},
+		{"/c", `not json`, false},
+	}
+	for i, tt := range tests {
+		err := h.AdmitPut(context.TODO(), &pb.PutRequest{Key: []byte(tt.key), Value: []byte(tt.value)})
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+	}
+	// puts keeping the current value are not checked
+	if err = h.AdmitPut(context.TODO(), &pb.PutRequest{Key: []byte("/a/x"), IgnoreValue: true}); err != nil {
+		t.Fatal(err)
+	}
+}
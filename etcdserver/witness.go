@@ -0,0 +1,140 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"path"
+	"strings"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/store"
+)
+
+// A witness is a member that votes in elections and on log entries but
+// stores no key-value data and never becomes the leader; it is a cheap
+// tiebreaker, for example in a third location of a two datacenter
+// deployment. A witness applies the configuration changes and the cluster
+// metadata kept in the v2 store under StoreClusterPrefix. Of every other
+// entry it persists only the index and term, which is all raft needs of a
+// voter that never sends entries to others.
+//
+// Since a witness acknowledges entries it keeps no data of, an entry may be
+// committed by the leader and a witness alone. If the leader is then lost,
+// the witness refuses to vote for data members missing the entry, and the
+// cluster has no leader until the lost member restarts with its data; if its
+// data is gone too, the entry is lost, and a remaining data member has to
+// force a new cluster.
+
+// witnessDataBuckets are the backend buckets holding key-value data, which a
+// witness drops from the snapshots it receives.
+var witnessDataBuckets = [][]byte{
+	[]byte("key"),
+	[]byte("keyIndex"),
+	[]byte("expiry"),
+	[]byte("valueIndex"),
+	[]byte("lease"),
+}
+
+// IsWitness returns true if the member is a witness.
+func (s *EtcdServer) IsWitness() bool { return s.Cfg.Witness }
+
+// witnessApplies returns true if a witness applies the normal entry data,
+// a v2 request on the cluster metadata.
+func witnessApplies(data []byte) bool {
+	var raftReq pb.InternalRaftRequest
+	if !pbutil.MaybeUnmarshal(&raftReq, data) { // backward compatible
+		var r pb.Request
+		if !pbutil.MaybeUnmarshal(&r, data) {
+			return false
+		}
+		return isClusterPath(r.Path)
+	}
+	return raftReq.V2 != nil && isClusterPath(raftReq.V2.Path)
+}
+
+func isClusterPath(p string) bool {
+	p = path.Clean(path.Join("/", p))
+	return p == StoreClusterPrefix || strings.HasPrefix(p, StoreClusterPrefix+"/")
+}
+
+// witnessEntries returns ents with the data dropped from the normal entries
+// a witness does not apply. ents is left untouched since raft still holds it.
+func witnessEntries(ents []raftpb.Entry) []raftpb.Entry {
+	var wents []raftpb.Entry
+	for i := range ents {
+		e := ents[i]
+		if e.Type == raftpb.EntryNormal && len(e.Data) != 0 && !witnessApplies(e.Data) {
+			if wents == nil {
+				wents = append(make([]raftpb.Entry, 0, len(ents)), ents[:i]...)
+			}
+			e.Data = nil
+		}
+		if wents != nil {
+			wents = append(wents, e)
+		}
+	}
+	if wents == nil {
+		return ents
+	}
+	return wents
+}
+
+// purgeWitnessBackend deletes the key-value data of a backend received in a
+// snapshot and defragments it to drop the data from the file too.
+func purgeWitnessBackend(be backend.Backend) error {
+	tx := be.BatchTx()
+	tx.Lock()
+	for _, bucket := range witnessDataBuckets {
+		var keys [][]byte
+		tx.UnsafeForEach(bucket, func(k, v []byte) error {
+			keys = append(keys, k)
+			return nil
+		})
+		for _, k := range keys {
+			tx.UnsafeDelete(bucket, k)
+		}
+	}
+	tx.Unlock()
+	be.ForceCommit()
+	return be.Defrag()
+}
+
+// purgeWitnessStore deletes the v2 keys of a v2 store received in a snapshot.
+func (s *EtcdServer) purgeWitnessStore() {
+	if _, err := s.store.Get(StoreKeysPrefix, false, false); err != nil {
+		return
+	}
+	if _, err := s.store.Delete(StoreKeysPrefix, true, true); err != nil {
+		plog.Panicf("purge store error: %v", err)
+	}
+	if _, err := s.store.Create(StoreKeysPrefix, true, "", false, store.TTLOptionSet{ExpireTime: store.Permanent}); err != nil {
+		plog.Panicf("purge store error: %v", err)
+	}
+}
+
+// dataMemberIDs returns the IDs of the members that are not witnesses.
+func (s *EtcdServer) dataMemberIDs() []types.ID {
+	var ids []types.ID
+	for _, m := range s.cluster.Members() {
+		if !m.Witness {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+func TestWitnessApplies(t *testing.T) {
+	tests := []struct {
+		data []byte
+
+		w bool
+	}{
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/0/members/1/attributes"}), true},
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/0/version"}), true},
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/0"}), true},
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/1/foo"}), false},
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/0/../1/foo"}), false},
+		{pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/00"}), false},
+		{pbutil.MustMarshal(&pb.InternalRaftRequest{V2: &pb.Request{Method: "PUT", Path: "/0/version"}}), true},
+		{pbutil.MustMarshal(&pb.InternalRaftRequest{V2: &pb.Request{Method: "PUT", Path: "/1/foo"}}), false},
+		{pbutil.MustMarshal(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/0/version")}}), false},
+		{pbutil.MustMarshal(&pb.InternalRaftRequest{Alarm: &pb.AlarmRequest{}}), false},
+	}
+	for i, tt := range tests {
+		if w := witnessApplies(tt.data); w != tt.w {
+			t.Errorf("#%d: applies = %v, want %v", i, w, tt.w)
+		}
+	}
+}
+
+func TestWitnessEntries(t *testing.T) {
+	v2 := pbutil.MustMarshal(&pb.Request{Method: "PUT", Path: "/0/version"})
+	put := pbutil.MustMarshal(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("foo")}})
+	cc := pbutil.MustMarshal(&raftpb.ConfChange{NodeID: 1})
+
+	ents := []raftpb.Entry{
+		{Index: 1, Term: 1},
+		{Index: 2, Term: 1, Data: v2},
+		{Index: 3, Term: 2, Data: put},
+		{Index: 4, Term: 2, Type: raftpb.EntryConfChange, Data: cc},
+	}
+	wents := []raftpb.Entry{
+		{Index: 1, Term: 1},
+		{Index: 2, Term: 1, Data: v2},
+		{Index: 3, Term: 2},
+		{Index: 4, Term: 2, Type: raftpb.EntryConfChange, Data: cc},
+	}
+	if got := witnessEntries(ents); !reflect.DeepEqual(got, wents) {
+		t.Errorf("entries = %+v, want %+v", got, wents)
+	}
+	if len(ents[2].Data) == 0 {
+		t.Errorf("the entries raft holds were modified")
+	}
+	kept := ents[:2]
+	if got := witnessEntries(kept); &got[0] != &kept[0] {
+		t.Errorf("entries with nothing to drop were copied")
+	}
+}
+
+// TestPurgeWitnessBackend ensures a witness drops the keys and leases of a
+// snapshot backend but keeps its consistent index.
+func TestPurgeWitnessBackend(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.Remove(tmpPath)
+	}()
+
+	var ci consistentIndex
+	ci.setConsistentIndex(10)
+	le := lease.NewLessor(be, 0)
+	s := mvcc.NewStore(be, le, &ci)
+	l, err := le.Grant(1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Put([]byte("foo"), []byte("bar"), l.ID)
+	s.Commit()
+	s.Close()
+	le.Stop()
+
+	if err = purgeWitnessBackend(be); err != nil {
+		t.Fatal(err)
+	}
+	le = lease.NewLessor(be, 0)
+	defer le.Stop()
+	s = mvcc.NewStore(be, le, &ci)
+	defer s.Close()
+	r, err := s.Range(context.TODO(), []byte("foo"), nil, mvcc.RangeOptions{})
+	if err != nil || r.Count != 0 {
+		t.Fatalf("range = %+v, %v; want no keys", r, err)
+	}
+	if ls := le.Leases(); len(ls) != 0 {
+		t.Fatalf("leases = %v, want none", ls)
+	}
+	if idx := s.ConsistentIndex(); idx != 10 {
+		t.Fatalf("consistent index = %d, want 10", idx)
+	}
+}
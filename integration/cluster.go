@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net"
@@ -36,6 +37,7 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/embed"
 	"github.com/coreos/etcd/etcdserver"
+	"github.com/coreos/etcd/etcdserver/admission"
 	"github.com/coreos/etcd/etcdserver/api/etcdhttp"
 	"github.com/coreos/etcd/etcdserver/api/v2http"
 	"github.com/coreos/etcd/etcdserver/api/v3client"
@@ -46,6 +48,7 @@ import (
 	"github.com/coreos/etcd/etcdserver/api/v3rpc"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/pkg/testutil"
+	"github.com/coreos/etcd/pkg/traceutil"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/rafthttp"
@@ -98,13 +101,28 @@ type ClusterConfig struct {
 	DiscoveryURL          string
 	UseGRPC               bool
 	QuotaBackendBytes     int64
+	PrefixQuotas          string
+	ValueIndexes          string
+	AdmissionHooks        []admission.Hook
+	ValueSchemas          string
+	MemoryBudget          int64
 	MaxTxnOps             uint
 	MaxRequestBytes       uint
+	MaxValueBytes         uint
+	MaxSendMsgSize        uint
 	GRPCKeepAliveMinTime  time.Duration
 	GRPCKeepAliveInterval time.Duration
 	GRPCKeepAliveTimeout  time.Duration
+	MaxWatchersPerStream  uint
+	MaxStreamsPerClient   uint
+	MaxWatchersPerClient  uint
+	WatchAckWindow        uint
 	// SkipCreatingClient to skip creating clients for each member.
 	SkipCreatingClient bool
+	// TraceExporter receives request traces from every member.
+	TraceExporter traceutil.Exporter
+	// OpLog receives the operation log of every member.
+	OpLog io.Writer
 }
 
 type cluster struct {
@@ -236,11 +254,24 @@ func (c *cluster) mustNewMember(t *testing.T) *member {
 			peerTLS:               c.cfg.PeerTLS,
 			clientTLS:             c.cfg.ClientTLS,
 			quotaBackendBytes:     c.cfg.QuotaBackendBytes,
+			prefixQuotas:          c.cfg.PrefixQuotas,
+			valueIndexes:          c.cfg.ValueIndexes,
+			admissionHooks:        c.cfg.AdmissionHooks,
+			valueSchemas:          c.cfg.ValueSchemas,
+			memoryBudget:          c.cfg.MemoryBudget,
 			maxTxnOps:             c.cfg.MaxTxnOps,
 			maxRequestBytes:       c.cfg.MaxRequestBytes,
+			maxValueBytes:         c.cfg.MaxValueBytes,
+			maxSendMsgSize:        c.cfg.MaxSendMsgSize,
 			grpcKeepAliveMinTime:  c.cfg.GRPCKeepAliveMinTime,
 			grpcKeepAliveInterval: c.cfg.GRPCKeepAliveInterval,
 			grpcKeepAliveTimeout:  c.cfg.GRPCKeepAliveTimeout,
+			maxWatchersPerStream:  c.cfg.MaxWatchersPerStream,
+			maxStreamsPerClient:   c.cfg.MaxStreamsPerClient,
+			maxWatchersPerClient:  c.cfg.MaxWatchersPerClient,
+			watchAckWindow:        c.cfg.WatchAckWindow,
+			traceExporter:         c.cfg.TraceExporter,
+			opLog:                 c.cfg.OpLog,
 		})
 	m.DiscoveryURL = c.cfg.DiscoveryURL
 	if c.cfg.UseGRPC {
@@ -511,11 +542,24 @@ type memberConfig struct {
 	peerTLS               *transport.TLSInfo
 	clientTLS             *transport.TLSInfo
 	quotaBackendBytes     int64
+	prefixQuotas          string
+	valueIndexes          string
+	admissionHooks        []admission.Hook
+	valueSchemas          string
+	memoryBudget          int64
 	maxTxnOps             uint
 	maxRequestBytes       uint
+	maxValueBytes         uint
+	maxSendMsgSize        uint
 	grpcKeepAliveMinTime  time.Duration
 	grpcKeepAliveInterval time.Duration
 	grpcKeepAliveTimeout  time.Duration
+	maxWatchersPerStream  uint
+	maxStreamsPerClient   uint
+	maxWatchersPerClient  uint
+	watchAckWindow        uint
+	traceExporter         traceutil.Exporter
+	opLog                 io.Writer
 }
 
 // mustNewMember return an inited member with the given name. If peerTLS is
@@ -563,6 +607,11 @@ func mustNewMember(t *testing.T, mcfg memberConfig) *member {
 	m.ElectionTicks = electionTicks
 	m.TickMs = uint(tickDuration / time.Millisecond)
 	m.QuotaBackendBytes = mcfg.quotaBackendBytes
+	m.PrefixQuotas = mcfg.prefixQuotas
+	m.ValueIndexes = mcfg.valueIndexes
+	m.CustomAdmissionHooks = mcfg.admissionHooks
+	m.ValueSchemas = mcfg.valueSchemas
+	m.MemoryBudget = mcfg.memoryBudget
 	m.MaxTxnOps = mcfg.maxTxnOps
 	if m.MaxTxnOps == 0 {
 		m.MaxTxnOps = embed.DefaultMaxTxnOps
@@ -571,7 +620,15 @@ func mustNewMember(t *testing.T, mcfg memberConfig) *member {
 	if m.MaxRequestBytes == 0 {
 		m.MaxRequestBytes = embed.DefaultMaxRequestBytes
 	}
+	m.MaxValueBytes = mcfg.maxValueBytes
+	m.MaxSendMsgSize = mcfg.maxSendMsgSize
 	m.AuthToken = "simple" // for the purpose of integration testing, simple token is enough
+	m.MaxWatchersPerStream = mcfg.maxWatchersPerStream
+	m.MaxStreamsPerClient = mcfg.maxStreamsPerClient
+	m.MaxWatchersPerClient = mcfg.maxWatchersPerClient
+	m.WatchAckWindow = mcfg.watchAckWindow
+	m.TraceExporter = mcfg.traceExporter
+	m.OpLog = mcfg.opLog
 
 	m.grpcServerOpts = []grpc.ServerOption{}
 	if mcfg.grpcKeepAliveMinTime > time.Duration(0) {
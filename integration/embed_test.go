@@ -17,6 +17,8 @@ package integration
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -146,6 +148,58 @@ func TestEmbedEtcdGracefulStop(t *testing.T) {
 	}
 }
 
+// TestEmbedEtcdStaleUnixSocket ensures a socket file left behind by a
+// previous process does not prevent listening on a unix client url.
+func TestEmbedEtcdStaleUnixSocket(t *testing.T) {
+	cfg := embed.NewConfig()
+
+	urls := newEmbedURLs(2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+
+	cfg.Dir = filepath.Join(os.TempDir(), fmt.Sprintf("embed-etcd"))
+	os.RemoveAll(cfg.Dir)
+	defer os.RemoveAll(cfg.Dir)
+
+	if err := ioutil.WriteFile(urls[0].Host, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(urls[0].Host)
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-e.Server.ReadyNotify() // wait for e.Server to join the cluster
+	e.Close()
+}
+
+// TestEmbedEtcdMetricsListenerError ensures a metrics url that fails to
+// listen makes StartEtcd return an error and release the client listeners.
+func TestEmbedEtcdMetricsListenerError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.ListenMetricsUrls = []url.URL{{Scheme: "http", Host: ln.Addr().String()}}
+
+	cfg.Dir = filepath.Join(os.TempDir(), fmt.Sprintf("embed-etcd"))
+	os.RemoveAll(cfg.Dir)
+	defer os.RemoveAll(cfg.Dir)
+
+	for i := 0; i < 2; i++ {
+		e, err := embed.StartEtcd(cfg)
+		if err == nil {
+			e.Close()
+			t.Fatalf("#%d: expected error on occupied metrics url", i)
+		}
+	}
+}
+
 func newEmbedURLs(n int) (urls []url.URL) {
 	for i := 0; i < n; i++ {
 		u, _ := url.Parse(fmt.Sprintf("unix://localhost:%d%06d", os.Getpid(), i))
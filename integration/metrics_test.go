@@ -95,3 +95,51 @@ func TestMetricDbSizeDefrag(t *testing.T) {
 		t.Fatalf("expected less than %d, got %d after defrag", bv, av)
 	}
 }
+
+// TestMetricLeaseExpired checks the lease metrics are updated when a lease expires.
+func TestMetricLeaseExpired(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	metric := func(name string) float64 {
+		v, err := clus.Members[0].Metric(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return f
+	}
+	expired := metric("etcd_debugging_lease_expired_total")
+	durations := metric("etcd_debugging_lease_expired_revoke_duration_seconds_count")
+
+	lresp, err := toGRPC(clus.Client(0)).Lease.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := metric("etcd_debugging_lease_total"); v != 1 {
+		t.Fatalf("expected 1 lease, got %v", v)
+	}
+	putreq := &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: lresp.ID}
+	if _, err = toGRPC(clus.Client(0)).KV.Put(context.TODO(), putreq); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the lease to expire and be revoked
+	for i := 0; metric("etcd_debugging_lease_total") != 0; i++ {
+		if i == 50 {
+			t.Fatal("lease expiration too slow")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	if v := metric("etcd_debugging_lease_expired_total"); v != expired+1 {
+		t.Fatalf("expected %v expired leases, got %v", expired+1, v)
+	}
+	if v := metric("etcd_debugging_lease_expired_revoke_duration_seconds_count"); v != durations+1 {
+		t.Fatalf("expected %v observed revoke durations, got %v", durations+1, v)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !cluster_proxy
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/admission"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// namingHook rejects writes outside of /apps/ and lowercases the keys of
+// the puts it admits.
+type namingHook struct{}
+
+func (namingHook) Name() string { return "naming" }
+
+func (namingHook) AdmitPut(ctx context.Context, r *pb.PutRequest) error {
+	if !bytes.HasPrefix(r.Key, []byte("/apps/")) {
+		return errors.New("keys must be under /apps/")
+	}
+	r.Key = bytes.ToLower(r.Key)
+	return nil
+}
+
+func (namingHook) AdmitDeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) error {
+	if !bytes.HasPrefix(r.Key, []byte("/apps/")) {
+		return errors.New("keys must be under /apps/")
+	}
+	return nil
+}
+
+// TestV3AdmissionHooks ensures admission hooks reject and rewrite writes
+// before they are proposed. It is not run through the proxy, which
+// namespaces the keys out of the admitted prefix.
+func TestV3AdmissionHooks(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, AdmissionHooks: []admission.Hook{namingHook{}}})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/Apps/A"), Value: []byte("v")}); err == nil {
+		t.Fatal("expected put outside of /apps/ to be rejected")
+	} else if grpc.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "keys must be under /apps/") {
+		t.Fatalf("err = %v, want the rejection reason of the hook", err)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/apps/b"), Value: []byte("v")}}},
+		{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("/c")}}},
+	}}
+	if _, err := kvc.Txn(context.TODO(), txn); grpc.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/apps/A"), Value: []byte("v")}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("/"), RangeEnd: []byte{0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Key) != "/apps/a" {
+		t.Fatalf("kvs = %+v, want the rewritten key /apps/a", resp.Kvs)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// TestV3IOErrorAlarm ensures a member failing to write its backend refuses
+// writes, keeps serving serializable reads, and raises an IOERROR alarm.
+func TestV3IOErrorAlarm(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	if _, err := clus.Client(0).Put(context.TODO(), "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+
+	// fail the writes of member 0 to its backend by swapping the descriptor
+	// of the database file for a read-only one
+	fp := filepath.Join(clus.Members[0].DataDir, "member", "snap", "db")
+	fd := fileFd(t, fp)
+	ro, err := os.Open(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if err = syscall.Dup3(int(ro.Fd()), fd, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clus.Client(1).Put(context.TODO(), "abc", "def"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; ; i++ {
+		_, err = clus.Client(0).Put(context.TODO(), "abc", "ghi")
+		if err == rpctypes.ErrDegraded {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("expected %v, got %v", rpctypes.ErrDegraded, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	resp, err := clus.Client(0).Get(context.TODO(), "foo", clientv3.WithSerializable())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+		t.Fatalf("got %+v, want foo=bar", resp.Kvs)
+	}
+
+	id := uint64(clus.Members[0].s.ID())
+	for i := 0; ; i++ {
+		aresp, aerr := clus.Client(1).AlarmList(context.TODO())
+		if aerr != nil {
+			t.Fatal(aerr)
+		}
+		if len(aresp.Alarms) == 1 && aresp.Alarms[0].Alarm == pb.AlarmType_IOERROR && aresp.Alarms[0].MemberID == id {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("expected IOERROR alarm for %x, got %+v", id, aresp.Alarms)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// the rest of the cluster keeps taking writes
+	if _, err = clus.Client(1).Put(context.TODO(), "foo", "baz"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fileFd returns a descriptor the process has open for the file at path.
+func fileFd(t *testing.T, path string) int {
+	fds, err := ioutil.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, fd := range fds {
+		dst, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name()))
+		if err != nil || dst != path {
+			continue
+		}
+		var n int
+		if _, err := fmt.Sscanf(fd.Name(), "%d", &n); err == nil {
+			return n
+		}
+	}
+	t.Skipf("no descriptor open for %s", path)
+	return -1
+}
@@ -207,11 +207,64 @@ func TestV3CorruptAlarm(t *testing.T) {
 		if perr != nil {
 			if !eqErrGRPC(perr, rpctypes.ErrCorrupt) {
 				t.Fatalf("expected %v, got %+v (%v)", rpctypes.ErrCorrupt, presp, perr)
-			} else {
-				return
 			}
+			// only the corrupt member refuses writes
+			if _, err := clus.Client(1).Put(context.TODO(), "abc", "bbb"); err != nil {
+				t.Fatal(err)
+			}
+			return
 		}
 		time.Sleep(time.Second)
 	}
 	t.Fatalf("expected error %v after %s", rpctypes.ErrCorrupt, 5*time.Second)
 }
+
+// TestV3CorruptAlarmRefusesReads ensures a corrupt member refuses reads while
+// the other members keep serving them.
+func TestV3CorruptAlarmRefusesReads(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	kvc0 := toGRPC(clus.Client(0)).KV
+	kvc1 := toGRPC(clus.Client(1)).KV
+	mt := toGRPC(clus.Client(0)).Maintenance
+
+	if _, err := kvc0.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// applied by member 0 once the request returns
+	alarmReq := &pb.AlarmRequest{
+		MemberID: uint64(clus.Members[0].s.ID()),
+		Action:   pb.AlarmRequest_ACTIVATE,
+		Alarm:    pb.AlarmType_CORRUPT,
+	}
+	if _, err := mt.Alarm(context.TODO(), alarmReq); err != nil {
+		t.Fatal(err)
+	}
+
+	rreqs := []*pb.RangeRequest{
+		{Key: []byte("foo")},
+		{Key: []byte("foo"), Serializable: true},
+	}
+	for i, rreq := range rreqs {
+		if _, err := kvc0.Range(context.TODO(), rreq); !eqErrGRPC(err, rpctypes.ErrGRPCCorrupt) {
+			t.Fatalf("#%d: range got %v, expected %v", i, err, rpctypes.ErrGRPCCorrupt)
+		}
+		txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: rreq}}}}
+		if _, err := kvc0.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCCorrupt) {
+			t.Fatalf("#%d: txn got %v, expected %v", i, err, rpctypes.ErrGRPCCorrupt)
+		}
+		if _, err := kvc1.Range(context.TODO(), rreq); err != nil {
+			t.Fatalf("#%d: range on healthy member got %v", i, err)
+		}
+	}
+
+	alarmReq.Action = pb.AlarmRequest_DEACTIVATE
+	if _, err := mt.Alarm(context.TODO(), alarmReq); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvc0.Range(context.TODO(), rreqs[0]); err != nil {
+		t.Fatal(err)
+	}
+}
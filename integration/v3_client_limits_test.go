@@ -0,0 +1,148 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !cluster_proxy
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// The tests below are not run through the proxy, which holds its own
+// streams and watchers to the member on behalf of its clients.
+
+func watchCreateRequest(key string) *pb.WatchRequest {
+	return &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte(key)}}}
+}
+
+// TestV3MaxStreamsPerClient ensures a client cannot open more streams than
+// its limit until it closes one.
+func TestV3MaxStreamsPerClient(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxStreamsPerClient: 1})
+	defer clus.Terminate(t)
+
+	wAPI := toGRPC(clus.RandClient()).Watch
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	wStream1, err := wAPI.Watch(ctx1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = wStream1.Send(watchCreateRequest("a")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = wStream1.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	wStream2, err := wAPI.Watch(ctx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = wStream2.Recv(); !eqErrGRPC(err, rpctypes.ErrGRPCTooManyClientStreams) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCTooManyClientStreams)
+	}
+
+	// closing the first stream frees the limit
+	cancel1()
+	for i := 0; ; i++ {
+		wStream3, serr := wAPI.Watch(ctx2)
+		if serr != nil {
+			t.Fatal(serr)
+		}
+		if serr = wStream3.Send(watchCreateRequest("a")); serr != nil {
+			t.Fatal(serr)
+		}
+		if _, serr = wStream3.Recv(); serr == nil {
+			break
+		} else if !eqErrGRPC(serr, rpctypes.ErrGRPCTooManyClientStreams) || i == 10 {
+			t.Fatalf("err = %v, want stream after closing the first", serr)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestV3MaxWatchersPerClient ensures the watchers of a client are limited
+// across its watch streams, and canceling a watcher frees the limit.
+func TestV3MaxWatchersPerClient(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxWatchersPerClient: 1})
+	defer clus.Terminate(t)
+
+	wAPI := toGRPC(clus.RandClient()).Watch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wStream1, err := wAPI.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wStream2, err := wAPI.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = wStream1.Send(watchCreateRequest("a")); err != nil {
+		t.Fatal(err)
+	}
+	wresp, err := wStream1.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Created || wresp.Canceled {
+		t.Fatalf("unexpected response %+v", wresp)
+	}
+	id := wresp.WatchId
+
+	if err = wStream2.Send(watchCreateRequest("b")); err != nil {
+		t.Fatal(err)
+	}
+	if wresp, err = wStream2.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Canceled || wresp.CancelReason != rpctypes.ErrorDesc(rpctypes.ErrGRPCTooManyClientWatchers) {
+		t.Fatalf("unexpected response %+v", wresp)
+	}
+
+	cancelReq := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CancelRequest{
+		CancelRequest: &pb.WatchCancelRequest{WatchId: id}}}
+	if err = wStream1.Send(cancelReq); err != nil {
+		t.Fatal(err)
+	}
+	if wresp, err = wStream1.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Canceled {
+		t.Fatalf("unexpected response %+v", wresp)
+	}
+
+	if err = wStream2.Send(watchCreateRequest("b")); err != nil {
+		t.Fatal(err)
+	}
+	if wresp, err = wStream2.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Created || wresp.Canceled {
+		t.Fatalf("expected watcher after canceling the first, got %+v", wresp)
+	}
+}
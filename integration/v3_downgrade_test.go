@@ -0,0 +1,92 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+	"github.com/coreos/etcd/version"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// TestV3Downgrade ensures a downgrade lowers the cluster version to its
+// target, and that canceling it lets the cluster version go back up since
+// no member runs the target version.
+func TestV3Downgrade(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	mt := toGRPC(clus.RandClient()).Maintenance
+
+	lv := semver.Must(semver.NewVersion(version.Version))
+	cv := semver.Version{Major: lv.Major, Minor: lv.Minor}
+	tv := semver.Version{Major: lv.Major, Minor: lv.Minor - 1}
+	waitDowngradeStatus(t, mt, cv.String(), false)
+
+	for _, ver := range []string{cv.String(), "2.3.0", "foo"} {
+		req := &pb.DowngradeRequest{Action: pb.DowngradeRequest_VALIDATE, Version: ver}
+		if _, err := mt.Downgrade(context.TODO(), req); !eqErrGRPC(err, rpctypes.ErrGRPCInvalidDowngradeTargetVersion) {
+			t.Fatalf("validate %q got %v, expected %v", ver, err, rpctypes.ErrGRPCInvalidDowngradeTargetVersion)
+		}
+	}
+	if _, err := mt.Downgrade(context.TODO(), &pb.DowngradeRequest{Action: pb.DowngradeRequest_CANCEL}); !eqErrGRPC(err, rpctypes.ErrGRPCNoInflightDowngrade) {
+		t.Fatalf("cancel got %v, expected %v", err, rpctypes.ErrGRPCNoInflightDowngrade)
+	}
+
+	// major.minor is accepted as the target version
+	req := &pb.DowngradeRequest{Action: pb.DowngradeRequest_ENABLE, Version: fmt.Sprintf("%d.%d", tv.Major, tv.Minor)}
+	resp, err := mt.Downgrade(context.TODO(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Enabled || resp.TargetVersion != tv.String() {
+		t.Fatalf("enable got %+v, expected downgrade to %s", resp, tv.String())
+	}
+	if _, err = mt.Downgrade(context.TODO(), req); !eqErrGRPC(err, rpctypes.ErrGRPCDowngradeInProcess) {
+		t.Fatalf("enable again got %v, expected %v", err, rpctypes.ErrGRPCDowngradeInProcess)
+	}
+	waitDowngradeStatus(t, mt, tv.String(), true)
+
+	if resp, err = mt.Downgrade(context.TODO(), &pb.DowngradeRequest{Action: pb.DowngradeRequest_CANCEL}); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Enabled {
+		t.Fatalf("cancel got %+v, expected no downgrade", resp)
+	}
+	waitDowngradeStatus(t, mt, cv.String(), false)
+}
+
+func waitDowngradeStatus(t *testing.T, mt pb.MaintenanceClient, ver string, enabled bool) {
+	var resp *pb.DowngradeResponse
+	for i := 0; i < 30; i++ {
+		var err error
+		resp, err = mt.Downgrade(context.TODO(), &pb.DowngradeRequest{Action: pb.DowngradeRequest_STATUS})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Version == ver && resp.Enabled == enabled {
+			return
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	t.Fatalf("status got %+v, expected version %s with downgrade enabled %t", resp, ver, enabled)
+}
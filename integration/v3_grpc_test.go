@@ -17,11 +17,14 @@ package integration
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,6 +35,7 @@ import (
 	"github.com/coreos/etcd/pkg/transport"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -931,6 +935,173 @@ func TestV3PutIgnoreLease(t *testing.T) {
 	}
 }
 
+// TestV3PutExpected ensures puts expecting a version or mod revision are only
+// applied if the key matches, and report the key on conflict.
+func TestV3PutExpected(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	key := []byte("foo")
+	put := func(val string, ver, modRev int64) *pb.PutResponse {
+		req := &pb.PutRequest{Key: key, Value: []byte(val), ExpectedVersion: ver, ExpectedModRevision: modRev}
+		resp, err := kvc.Put(context.TODO(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := put("bar", -1, 0); resp.Conflict {
+		t.Fatalf("put of missing key conflicted with %+v", resp.PrevKv)
+	}
+	rr, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur := rr.Kvs[0]
+
+	tests := []struct {
+		ver, modRev int64
+	}{
+		{-1, 0},
+		{2, 0},
+		{0, -1},
+		{0, cur.ModRevision + 1},
+		{1, cur.ModRevision + 1},
+	}
+	for i, tt := range tests {
+		resp := put("baz", tt.ver, tt.modRev)
+		if !resp.Conflict {
+			t.Fatalf("#%d: expected conflict", i)
+		}
+		if !reflect.DeepEqual(resp.PrevKv, cur) {
+			t.Errorf("#%d: conflicting kv = %+v, want %+v", i, resp.PrevKv, cur)
+		}
+		if resp.Header.Revision != cur.ModRevision {
+			t.Errorf("#%d: revision = %d, want %d", i, resp.Header.Revision, cur.ModRevision)
+		}
+	}
+
+	if resp := put("baz", 1, cur.ModRevision); resp.Conflict {
+		t.Fatalf("put conflicted with %+v", resp.PrevKv)
+	}
+	rr, err = kvc.Range(context.TODO(), &pb.RangeRequest{Key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rr.Kvs[0].Value) != "baz" || rr.Kvs[0].Version != 2 {
+		t.Errorf("kv = %+v, want value baz at version 2", rr.Kvs[0])
+	}
+
+	// a conflicting put in a txn leaves the key as is
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{
+		RequestPut: &pb.PutRequest{Key: key, Value: []byte("qux"), ExpectedVersion: 1}}}}}
+	tresp, err := kvc.Txn(context.TODO(), txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tresp.Responses[0].GetResponsePut().Conflict {
+		t.Errorf("expected conflict in txn")
+	}
+	if tresp.Header.Revision != rr.Header.Revision {
+		t.Errorf("revision = %d, want %d", tresp.Header.Revision, rr.Header.Revision)
+	}
+}
+
+// TestV3DryRun ensures dry run puts, deletes and txns report their outcome
+// without changing the store.
+func TestV3DryRun(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"foo", "foo1"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("bar")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rr, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev := rr.Header.Revision
+
+	presp, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz"), PrevKv: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presp.Header.Revision != rev+1 {
+		t.Errorf("put revision = %d, want %d", presp.Header.Revision, rev+1)
+	}
+	if !reflect.DeepEqual(presp.PrevKv, rr.Kvs[0]) {
+		t.Errorf("put prev kv = %+v, want %+v", presp.PrevKv, rr.Kvs[0])
+	}
+
+	dresp, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 2 || dresp.Header.Revision != rev+1 {
+		t.Errorf("deleted %d at revision %d, want 2 at %d", dresp.Deleted, dresp.Header.Revision, rev+1)
+	}
+	dresp, err = kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("zoo"), DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 0 || dresp.Header.Revision != rev {
+		t.Errorf("deleted %d at revision %d, want 0 at %d", dresp.Deleted, dresp.Header.Revision, rev)
+	}
+
+	txn := &pb.TxnRequest{
+		Compare: []*pb.Compare{{
+			Key:         []byte("foo"),
+			Target:      pb.Compare_VALUE,
+			Result:      pb.Compare_EQUAL,
+			TargetUnion: &pb.Compare_Value{Value: []byte("bar")},
+		}},
+		Success: []*pb.RequestOp{
+			{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz")}}},
+			{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("foo")}}},
+		},
+		DryRun: true,
+	}
+	tresp, err := kvc.Txn(context.TODO(), txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tresp.Succeeded || tresp.Header.Revision != rev+1 {
+		t.Errorf("txn succeeded %v at revision %d, want true at %d", tresp.Succeeded, tresp.Header.Revision, rev+1)
+	}
+	if kvs := tresp.Responses[1].GetResponseRange().Kvs; len(kvs) != 1 || string(kvs[0].Value) != "bar" {
+		t.Errorf("txn range = %+v, want the key before the txn", kvs)
+	}
+
+	// a dry run fails as the write would
+	_, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz"), Lease: 123456, DryRun: true})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCLeaseNotFound) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCLeaseNotFound)
+	}
+	_, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), IgnoreValue: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("zoo"), IgnoreValue: true, DryRun: true})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCKeyNotFound) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCKeyNotFound)
+	}
+
+	rr2, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr2.Header.Revision != rev || !reflect.DeepEqual(rr2.Kvs, rr.Kvs) {
+		t.Errorf("store changed to %+v at revision %d, want %+v at %d", rr2.Kvs, rr2.Header.Revision, rr.Kvs, rev)
+	}
+}
+
 // TestV3PutMissingLease ensures that a Put on a key with a bogus lease fails.
 func TestV3PutMissingLease(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -1783,6 +1954,136 @@ func TestGRPCRequireLeader(t *testing.T) {
 	}
 }
 
+// TestGRPCNoLeaderHeader ensures serializable reads are served by a member
+// without a leader and are flagged as possibly stale.
+func TestGRPCNoLeaderHeader(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	cfg := ClusterConfig{Size: 3}
+	clus := newClusterV3NoClients(t, &cfg)
+	defer clus.Terminate(t)
+
+	client, err := NewClientV3(clus.Members[0])
+	if err != nil {
+		t.Fatalf("cannot create client: %v", err)
+	}
+	defer client.Close()
+
+	kvc := toGRPC(client).KV
+	if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.RangeRequest{Key: []byte("foo"), Serializable: true}
+	var hdr metadata.MD
+	if _, err = kvc.Range(context.TODO(), req, grpc.Header(&hdr)); err != nil {
+		t.Fatal(err)
+	}
+	if v := hdr[rpctypes.MetadataNoLeaderKey]; len(v) != 0 {
+		t.Fatalf("unexpected no leader header %v with a leader", v)
+	}
+
+	clus.Members[1].Stop(t)
+	clus.Members[2].Stop(t)
+
+	// wait for election timeout, then member[0] will not have a leader.
+	time.Sleep(time.Duration(3*electionTicks) * tickDuration)
+
+	hdr = nil
+	resp, err := kvc.Range(context.TODO(), req, grpc.Header(&hdr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+		t.Fatalf("unexpected range response %+v", resp)
+	}
+	if v := hdr[rpctypes.MetadataNoLeaderKey]; len(v) != 1 || v[0] != rpctypes.MetadataNoLeader {
+		t.Fatalf("expected no leader header, got %v", hdr)
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestGRPCOpLog ensures the operation log records the invocation and the
+// return of client requests with their client supplied operation IDs.
+func TestGRPCOpLog(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	var oplog lockedBuffer
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, OpLog: &oplog})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	if _, err := cli.Put(clientv3.WithOpID(context.TODO(), "op-1"), "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Get(clientv3.WithOpID(context.TODO(), "op-2"), "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	type record struct {
+		Type     string          `json:"type"`
+		OpID     string          `json:"op-id"`
+		Member   string          `json:"member"`
+		Method   string          `json:"method"`
+		Time     int64           `json:"time"`
+		Request  json.RawMessage `json:"request"`
+		Response json.RawMessage `json:"response"`
+		Error    string          `json:"error"`
+	}
+	var recs []record
+	for _, l := range strings.Split(strings.TrimSpace(oplog.String()), "\n") {
+		var r record
+		if err := json.Unmarshal([]byte(l), &r); err != nil {
+			t.Fatalf("cannot decode %q (%v)", l, err)
+		}
+		if r.OpID != "" {
+			recs = append(recs, r)
+		}
+	}
+
+	want := []struct{ typ, opID, method string }{
+		{"invoke", "op-1", "/etcdserverpb.KV/Put"},
+		{"return", "op-1", "/etcdserverpb.KV/Put"},
+		{"invoke", "op-2", "/etcdserverpb.KV/Range"},
+		{"return", "op-2", "/etcdserverpb.KV/Range"},
+	}
+	if len(recs) != len(want) {
+		t.Fatalf("got %d records with op IDs, want %d (%s)", len(recs), len(want), oplog.String())
+	}
+	member := clus.Members[0].s.ID().String()
+	for i, w := range want {
+		r := recs[i]
+		if r.Type != w.typ || r.OpID != w.opID || r.Method != w.method || r.Member != member {
+			t.Fatalf("#%d: unexpected record %+v", i, r)
+		}
+		if i > 0 && r.Time < recs[i-1].Time {
+			t.Fatalf("#%d: time %d before previous record's %d", i, r.Time, recs[i-1].Time)
+		}
+		if w.typ == "invoke" && len(r.Request) == 0 {
+			t.Fatalf("#%d: expected request", i)
+		}
+		if w.typ == "return" && (len(r.Response) == 0 || r.Error != "") {
+			t.Fatalf("#%d: expected response, got error %q", i, r.Error)
+		}
+	}
+}
+
 func TestGRPCStreamRequireLeader(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -1874,6 +2175,59 @@ func TestV3PutLargeRequests(t *testing.T) {
 	}
 }
 
+// TestV3PutLargeValues ensures that configurable MaxValueBytes applies to
+// puts and to the puts of nested txns.
+func TestV3PutLargeValues(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxValueBytes: 1024})
+	defer clus.Terminate(t)
+	kvcli := toGRPC(clus.Client(0)).KV
+
+	tests := []struct {
+		valueSize   int
+		expectError error
+	}{
+		{1024, nil},
+		{1025, rpctypes.ErrGRPCValueTooLarge},
+	}
+	for i, test := range tests {
+		reqput := &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, test.valueSize)}
+		if _, err := kvcli.Put(context.TODO(), reqput); fmt.Sprint(err) != fmt.Sprint(test.expectError) {
+			t.Errorf("#%d: expected error %v, got %v", i, test.expectError, err)
+		}
+
+		op := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: reqput}}
+		nested := &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Success: []*pb.RequestOp{op}}}}
+		txn := &pb.TxnRequest{Failure: []*pb.RequestOp{nested}}
+		if _, err := kvcli.Txn(context.TODO(), txn); fmt.Sprint(err) != fmt.Sprint(test.expectError) {
+			t.Errorf("#%d: expected txn error %v, got %v", i, test.expectError, err)
+		}
+	}
+}
+
+// TestV3RangeLargeResponse ensures that configurable MaxSendMsgSize bounds
+// the responses the server sends.
+func TestV3RangeLargeResponse(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxSendMsgSize: 64 * 1024})
+	defer clus.Terminate(t)
+	kvcli := toGRPC(clus.Client(0)).KV
+
+	for _, k := range []string{"a", "b"} {
+		reqput := &pb.PutRequest{Key: []byte(k), Value: make([]byte, 40*1024)}
+		if _, err := kvcli.Put(context.TODO(), reqput); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kvcli.Range(context.TODO(), &pb.RangeRequest{Key: []byte("a")}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := kvcli.Range(context.TODO(), &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("c")})
+	if grpc.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected %v, got %v", codes.ResourceExhausted, err)
+	}
+}
+
 func eqErrGRPC(err1 error, err2 error) bool {
 	return !(err1 == nil && err2 != nil) || err1.Error() == err2.Error()
 }
@@ -1888,3 +2242,180 @@ func waitForRestart(t *testing.T, kvc pb.KVClient) {
 		t.Fatal(err)
 	}
 }
+
+// TestV3ReadOnlyMember ensures a read-only member refuses writes but keeps
+// replicating and serving reads.
+func TestV3ReadOnlyMember(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	clus.Members[0].s.SetReadOnly(true)
+
+	kvc0, kvc1 := toGRPC(clus.Client(0)).KV, toGRPC(clus.Client(1)).KV
+	preq := &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}
+	if _, err := kvc0.Put(context.TODO(), preq); !eqErrGRPC(err, rpctypes.ErrGRPCReadOnly) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrGRPCReadOnly, err)
+	}
+	dreq := &pb.DeleteRangeRequest{Key: []byte("foo")}
+	if _, err := kvc0.DeleteRange(context.TODO(), dreq); !eqErrGRPC(err, rpctypes.ErrGRPCReadOnly) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrGRPCReadOnly, err)
+	}
+	if _, err := kvc1.Put(context.TODO(), preq); err != nil {
+		t.Fatal(err)
+	}
+
+	// reads, including read-only txns, are still served
+	resp, err := kvc0.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+		t.Fatalf("unexpected range response %+v", resp)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("foo")}}}}}
+	if _, err = kvc0.Txn(context.TODO(), txn); err != nil {
+		t.Fatal(err)
+	}
+
+	clus.Members[0].s.SetReadOnly(false)
+	if _, err = kvc0.Put(context.TODO(), preq); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestV3PrefixQuota ensures writes over a prefix quota are rejected for only
+// that prefix and accepted again once the prefix is shrunk.
+func TestV3PrefixQuota(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, PrefixQuotas: "/a/=0:2"})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"/a/1", "/a/2", "/b/1", "/b/2", "/b/3"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	preq := &pb.PutRequest{Key: []byte("/a/3"), Value: []byte("v")}
+	if _, err := kvc.Put(context.TODO(), preq); !eqErrGRPC(err, rpctypes.ErrGRPCPrefixQuotaExceeded) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrGRPCPrefixQuotaExceeded, err)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: preq}}}}
+	if _, err := kvc.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCPrefixQuotaExceeded) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrGRPCPrefixQuotaExceeded, err)
+	}
+	// overwriting a key of the prefix keeps its key count
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/a/1"), Value: []byte("vv")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("/a/1")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvc.Put(context.TODO(), preq); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestV3MemoryBudget ensures a member over its memory budget rejects new
+// watchers and large ranges while serving small reads.
+func TestV3MemoryBudget(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MemoryBudget: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+
+	mc := toGRPC(clus.RandClient()).Maintenance
+	for i := 0; ; i++ {
+		sresp, err := mc.Status(context.TODO(), &pb.StatusRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sresp.MemoryBytes > 1 {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("memory usage not accounted, got %d bytes", sresp.MemoryBytes)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	okReqs := []*pb.RangeRequest{
+		{Key: []byte("foo")},
+		{Key: []byte("a"), RangeEnd: []byte("z"), Limit: 10},
+		{Key: []byte("a"), RangeEnd: []byte("z"), CountOnly: true},
+	}
+	for i, req := range okReqs {
+		if _, err := kvc.Range(context.TODO(), req); err != nil {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+	_, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("z")})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCMemoryBudgetExceeded) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrGRPCMemoryBudgetExceeded, err)
+	}
+
+	wStream, err := toGRPC(clus.RandClient()).Watch.Watch(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")}}}
+	if err = wStream.Send(req); err != nil {
+		t.Fatal(err)
+	}
+	wresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Created || !wresp.Canceled {
+		t.Fatalf("expected canceled watch creation, got %+v", wresp)
+	}
+	if wresp.CancelReason != rpctypes.ErrorDesc(rpctypes.ErrGRPCMemoryBudgetExceeded) {
+		t.Fatalf("expected cancel reason %q, got %q", rpctypes.ErrorDesc(rpctypes.ErrGRPCMemoryBudgetExceeded), wresp.CancelReason)
+	}
+}
+
+// TestV3StatusCounts ensures the status of a member reports its watchers,
+// leases, storage version and uptime.
+func TestV3StatusCounts(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	if _, err := cli.Grant(context.TODO(), 60); err != nil {
+		t.Fatal(err)
+	}
+	wctx, wcancel := context.WithCancel(context.TODO())
+	defer wcancel()
+	wch := cli.Watch(wctx, "foo", clientv3.WithCreatedNotify())
+	if wresp := <-wch; !wresp.Created {
+		t.Fatalf("expected watch created, got %+v", wresp)
+	}
+
+	sresp, err := toGRPC(cli).Maintenance.Status(context.TODO(), &pb.StatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sresp.ActiveWatchers != 1 {
+		t.Errorf("activeWatchers = %d, want 1", sresp.ActiveWatchers)
+	}
+	if sresp.ActiveLeases != 1 {
+		t.Errorf("activeLeases = %d, want 1", sresp.ActiveLeases)
+	}
+	if sresp.DbSizeInUse <= 0 || sresp.DbSizeInUse > sresp.DbSize {
+		t.Errorf("dbSizeInUse = %d, want in (0, %d]", sresp.DbSizeInUse, sresp.DbSize)
+	}
+	if sresp.StorageVersion == "" {
+		t.Errorf("expected storage version")
+	}
+	if sresp.UptimeSeconds < 0 {
+		t.Errorf("uptimeSeconds = %d, want >= 0", sresp.UptimeSeconds)
+	}
+}
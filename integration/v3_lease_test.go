@@ -189,6 +189,83 @@ func TestV3LeaseExpire(t *testing.T) {
 	})
 }
 
+// TestV3LeaseExpireKeepKeys ensures an expired lease granted with keep_keys
+// detaches its keys instead of deleting them, notifying watchers with a put
+// that clears the lease.
+func TestV3LeaseExpireKeepKeys(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lresp, err := toGRPC(clus.RandClient()).Lease.LeaseGrant(
+		context.TODO(),
+		&pb.LeaseGrantRequest{TTL: 1, KeepKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	preq := &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: lresp.ID}
+	presp, err := toGRPC(clus.RandClient()).KV.Put(context.TODO(), preq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wStream, err := toGRPC(clus.RandClient()).Watch.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wreq := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{
+			Key: []byte("foo"), StartRevision: presp.Header.Revision + 1, PrevKv: true}}}
+	if err = wStream.Send(wreq); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = wStream.Recv(); err != nil {
+		// the 'created' message
+		t.Fatal(err)
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		resp, err := wStream.Recv()
+		switch {
+		case err != nil:
+			errc <- err
+		case len(resp.Events) != 1:
+			fallthrough
+		case resp.Events[0].Type != mvccpb.PUT:
+			errc <- fmt.Errorf("expected key put, got %v", resp)
+		case resp.Events[0].Kv.Lease != 0 || string(resp.Events[0].Kv.Value) != "bar":
+			errc <- fmt.Errorf("expected detached key, got %v", resp.Events[0].Kv)
+		case resp.Events[0].PrevKv == nil || resp.Events[0].PrevKv.Lease != lresp.ID:
+			errc <- fmt.Errorf("expected previous key with lease %x, got %v", lresp.ID, resp.Events[0].PrevKv)
+		default:
+			errc <- nil
+		}
+	}()
+
+	select {
+	case <-time.After(15 * time.Second):
+		t.Fatalf("lease expiration too slow")
+	case err = <-errc:
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rresp, err := toGRPC(clus.RandClient()).KV.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rresp.Kvs) != 1 || rresp.Kvs[0].Lease != 0 {
+		t.Fatalf("expected key without lease, got %v", rresp.Kvs)
+	}
+	if leaseExist(t, clus, lresp.ID) {
+		t.Fatalf("expired lease %x still exists", lresp.ID)
+	}
+}
+
 // TestV3LeaseKeepAlive ensures keepalive keeps the lease alive.
 func TestV3LeaseKeepAlive(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -222,6 +299,56 @@ func TestV3LeaseKeepAlive(t *testing.T) {
 	})
 }
 
+// TestV3LeaseKeepAliveMultiplexed renews many leases over one keepalive
+// stream on a follower, which forwards the renewals to the leader.
+func TestV3LeaseKeepAliveMultiplexed(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	follower := clus.Client((clus.WaitLeader(t) + 1) % 3)
+	lc := toGRPC(follower).Lease
+
+	ttls := make(map[int64]int64)
+	for i := int64(0); i < 20; i++ {
+		lresp, err := lc.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 10 + i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ttls[lresp.ID] = lresp.TTL
+	}
+	// leases that don't exist renew to a zero TTL
+	ttls[123456] = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lac, err := lc.LeaseKeepAlive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lac.CloseSend()
+
+	for id := range ttls {
+		if err = lac.Send(&pb.LeaseKeepAliveRequest{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range ttls {
+		lresp, rerr := lac.Recv()
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		ttl, ok := ttls[lresp.ID]
+		if !ok {
+			t.Fatalf("unexpected response for lease %x", lresp.ID)
+		}
+		if lresp.TTL != ttl {
+			t.Errorf("lease %x: TTL expected %d, got %d", lresp.ID, ttl, lresp.TTL)
+		}
+		delete(ttls, lresp.ID)
+	}
+}
+
 // TestV3LeaseExists creates a lease on a random client and confirms it exists in the cluster.
 func TestV3LeaseExists(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -103,6 +103,49 @@ func TestMutexSessionRelock(t *testing.T) {
 	}
 }
 
+// TestMutexTryLock ensures TryLock fails fast with ErrLocked while another
+// session holds the lock and succeeds once it is released.
+func TestMutexTryLock(t *testing.T) {
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	s1, err := concurrency.NewSession(clus.RandClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s1.Close()
+	s2, err := concurrency.NewSession(clus.RandClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s2.Close()
+
+	m1 := concurrency.NewMutex(s1, "test-mutex")
+	if err = m1.Lock(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	m2 := concurrency.NewMutex(s2, "test-mutex")
+	if err = m2.TryLock(context.TODO()); err != concurrency.ErrLocked {
+		t.Fatalf("expected %v, got %v", concurrency.ErrLocked, err)
+	}
+	// a failed TryLock must not leave a waiter key behind
+	resp, err := clus.RandClient().Get(context.TODO(), "test-mutex", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 {
+		t.Fatalf("expected 1 lock key, got %d", len(resp.Kvs))
+	}
+
+	if err = m1.Unlock(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if err = m2.TryLock(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestMutexWaitsOnCurrentHolder ensures a mutex is only acquired once all
 // waiters older than the new owner are gone by testing the case where
 // the waiter prior to the acquirer expires before the current holder.
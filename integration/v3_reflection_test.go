@@ -0,0 +1,98 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/etcd/pkg/testutil"
+
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// TestV3Reflection ensures the gRPC reflection service lists the etcd
+// services and serves their proto files with all of their imports.
+func TestV3Reflection(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	rc := rpb.NewServerReflectionClient(clus.RandClient().ActiveConnection())
+	stream, err := rc.ServerReflectionInfo(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.CloseSend()
+	do := func(req *rpb.ServerReflectionRequest) *rpb.ServerReflectionResponse {
+		if err = stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		resp, rerr := stream.Recv()
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if e := resp.GetErrorResponse(); e != nil {
+			t.Fatalf("reflection error for %v: %s", req.MessageRequest, e.ErrorMessage)
+		}
+		return resp
+	}
+
+	lresp := do(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{ListServices: "*"}})
+	services := make(map[string]bool)
+	for _, s := range lresp.GetListServicesResponse().Service {
+		services[s.Name] = true
+	}
+	for _, s := range []string{"etcdserverpb.KV", "etcdserverpb.Watch", "etcdserverpb.Maintenance", "v3lockpb.Lock", "v3electionpb.Election"} {
+		if !services[s] {
+			t.Errorf("service %s not listed in %v", s, services)
+		}
+	}
+
+	// resolve the files of each service down to all of their imports
+	files := make(map[string]bool)
+	var pending []*dpb.FileDescriptorProto
+	decode := func(resp *rpb.ServerReflectionResponse) {
+		for _, b := range resp.GetFileDescriptorResponse().FileDescriptorProto {
+			fd := &dpb.FileDescriptorProto{}
+			if err = proto.Unmarshal(b, fd); err != nil {
+				t.Fatal(err)
+			}
+			if !files[fd.GetName()] {
+				files[fd.GetName()] = true
+				pending = append(pending, fd)
+			}
+		}
+	}
+	for _, s := range []string{"etcdserverpb.KV", "v3lockpb.Lock", "v3electionpb.Election"} {
+		decode(do(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: s}}))
+	}
+	for len(pending) > 0 {
+		fd := pending[0]
+		pending = pending[1:]
+		for _, dep := range fd.Dependency {
+			if !files[dep] {
+				decode(do(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep}}))
+			}
+		}
+	}
+	for _, f := range []string{"rpc.proto", "kv.proto", "auth.proto", "v3lock.proto", "v3election.proto"} {
+		if !files[f] {
+			t.Errorf("file %s not resolved from %v", f, files)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+	"github.com/coreos/etcd/pkg/traceutil"
+)
+
+type traceRecorder struct {
+	mu     sync.Mutex
+	traces []*traceutil.Trace
+}
+
+func (r *traceRecorder) Export(t *traceutil.Trace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.traces = append(r.traces, t)
+}
+
+func (r *traceRecorder) steps(op string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.traces {
+		if t.Operation() != op {
+			continue
+		}
+		var msgs []string
+		for _, s := range t.Steps() {
+			msgs = append(msgs, s.Msg)
+		}
+		return msgs
+	}
+	return nil
+}
+
+// TestV3TraceExporter ensures client requests are traced through the
+// server stages when a trace exporter is configured.
+func TestV3TraceExporter(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	rec := &traceRecorder{}
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, TraceExporter: rec})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		op     string
+		wsteps []string
+	}{
+		{"put", []string{"propose request to raft", "request committed and queued for apply", "request applied"}},
+		{"range", []string{"agreement among raft nodes before linearized reading", "range keys from storage"}},
+	}
+	for i, tt := range tests {
+		steps := rec.steps(tt.op)
+		if len(steps) != len(tt.wsteps) {
+			t.Fatalf("#%d: %s steps = %v, want %v", i, tt.op, steps, tt.wsteps)
+		}
+		for j := range steps {
+			if steps[j] != tt.wsteps[j] {
+				t.Errorf("#%d: %s steps = %v, want %v", i, tt.op, steps, tt.wsteps)
+			}
+		}
+	}
+}
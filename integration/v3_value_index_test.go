@@ -0,0 +1,69 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !cluster_proxy
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// TestV3RangeValueIndex ensures ranges by a value index return the keys with
+// the requested field on every member. It is not run through the proxy,
+// which namespaces the keys out of the indexed prefix.
+func TestV3RangeValueIndex(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, ValueIndexes: "by-owner=/jobs/:/owner"})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.Client(0)).KV
+	puts := []struct{ key, value string }{
+		{"/jobs/1", `{"owner":"alice"}`},
+		{"/jobs/2", `{"owner":"bob"}`},
+		{"/jobs/3", `{"owner":"alice"}`},
+		{"/jobs/1", `{"owner":"carol"}`},
+		{"/other/1", `{"owner":"alice"}`},
+	}
+	for _, p := range puts {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(p.key), Value: []byte(p.value)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := &pb.RangeRequest{Key: []byte("/"), RangeEnd: []byte{0}, Index: "by-owner", IndexValue: []byte("alice")}
+	for i := range clus.Members {
+		resp, err := toGRPC(clus.Client(i)).KV.Range(context.TODO(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Kvs) != 1 || string(resp.Kvs[0].Key) != "/jobs/3" {
+			t.Errorf("#%d: kvs = %+v, want /jobs/3", i, resp.Kvs)
+		}
+	}
+
+	bad := &pb.RangeRequest{Key: []byte("/"), RangeEnd: []byte{0}, Index: "by-name"}
+	if _, err := kvc.Range(context.TODO(), bad); !eqErrGRPC(err, rpctypes.ErrGRPCValueIndexNotFound) {
+		t.Errorf("expected %v, got %v", rpctypes.ErrGRPCValueIndexNotFound, err)
+	}
+	rev := &pb.RangeRequest{Key: []byte("/"), RangeEnd: []byte{0}, Index: "by-owner", Revision: 2}
+	if _, err := kvc.Range(context.TODO(), rev); !eqErrGRPC(err, rpctypes.ErrGRPCValueIndexRev) {
+		t.Errorf("expected %v, got %v", rpctypes.ErrGRPCValueIndexRev, err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !cluster_proxy
+
+package integration
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// TestV3ValueSchema ensures puts of values not conforming to the schema of
+// their prefix are rejected with the non-conforming part of the value. It is
+// not run through the proxy, which namespaces the keys out of the prefix.
+func TestV3ValueSchema(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "valueschema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.schema.json")
+	schema := `{"type":"object","properties":{"replicas":{"type":"integer","minimum":1}}}`
+	if err = ioutil.WriteFile(path, []byte(schema), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, ValueSchemas: "/config/=" + path})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/config/web"), Value: []byte(`{"replicas":3}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/other"), Value: []byte(`garbage`)}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/config/web"), Value: []byte(`{"replicas":0}`)})
+	if grpc.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "/replicas: 0 is less than the minimum 1") {
+		t.Fatalf("err = %v, want rejection naming /replicas", err)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/config/db"), Value: []byte(`[]`)}}},
+	}}
+	if _, err = kvc.Txn(context.TODO(), txn); grpc.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	resp, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("/config/web")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != `{"replicas":3}` {
+		t.Fatalf("kvs = %+v, want the conforming value", resp.Kvs)
+	}
+}
@@ -25,9 +25,12 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/etcdserver/api/v3rpc"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/coreos/etcd/pkg/testutil"
+
+	"google.golang.org/grpc/metadata"
 )
 
 // TestV3WatchFromCurrentRevision tests Watch APIs from current revision.
@@ -291,6 +294,143 @@ func TestV3WatchFromCurrentRevision(t *testing.T) {
 	}
 }
 
+// TestV3WatchDrain ensures draining a member before shutdown transfers its
+// leadership and ends watch streams with a server stopped error.
+func TestV3WatchDrain(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	m := clus.Members[lead]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	wStream, err := toGRPC(clus.Client(lead)).Watch.Watch(ctx)
+	if err != nil {
+		t.Fatalf("wAPI.Watch error: %v", err)
+	}
+	req := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")}}}
+	if err = wStream.Send(req); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	if _, err = wStream.Recv(); err != nil {
+		t.Fatalf("wStream.Recv error: %v", err)
+	}
+
+	oldLead := uint64(m.s.ID())
+	m.s.Drain()
+
+	if _, err = wStream.Recv(); rpctypes.ErrorDesc(err) != rpctypes.ErrorDesc(rpctypes.ErrGRPCStopped) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCStopped)
+	}
+	if m.s.Lead() == oldLead {
+		t.Fatalf("expected leadership to be transferred from %x", oldLead)
+	}
+}
+
+// TestV3WatchAckWindow ensures the server sends no more unacknowledged
+// responses to a watcher than the ack window on streams whose client
+// acknowledges them, while the other watchers of the stream keep receiving.
+func TestV3WatchAckWindow(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, WatchAckWindow: 2})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	md := metadata.Pairs(rpctypes.MetadataWatchAckKey, rpctypes.MetadataWatchAck)
+	wStream, err := toGRPC(clus.RandClient()).Watch.Watch(metadata.NewOutgoingContext(ctx, md))
+	if err != nil {
+		t.Fatalf("wAPI.Watch error: %v", err)
+	}
+	req := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")}}}
+	if err = wStream.Send(req); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	cresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatalf("wStream.Recv error: %v", err)
+	}
+	req = &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("bar")}}}
+	if err = wStream.Send(req); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	bresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatalf("wStream.Recv error: %v", err)
+	}
+
+	respc := make(chan *pb.WatchResponse, 3)
+	go func() {
+		for {
+			resp, rerr := wStream.Recv()
+			if rerr != nil {
+				close(respc)
+				return
+			}
+			respc <- resp
+		}
+	}()
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for i := 0; i < 3; i++ {
+		if _, err = kvc.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+			t.Fatalf("couldn't put key (%v)", err)
+		}
+	}
+
+	var revs []int64
+	for i := 0; i < 2; i++ {
+		select {
+		case resp := <-respc:
+			revs = append(revs, resp.Header.Revision)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("took too long to receive response %d", i)
+		}
+	}
+	select {
+	case resp := <-respc:
+		t.Fatalf("unexpected response past the ack window %+v", resp)
+	case <-time.After(time.Second):
+	}
+
+	// the full window of foo holds back none of the events of bar
+	if _, err = kvc.Put(ctx, &pb.PutRequest{Key: []byte("bar"), Value: []byte("baz")}); err != nil {
+		t.Fatalf("couldn't put key (%v)", err)
+	}
+	select {
+	case resp := <-respc:
+		if resp.WatchId != bresp.WatchId {
+			t.Fatalf("watch id = %d, want %d", resp.WatchId, bresp.WatchId)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to receive response of another watcher")
+	}
+
+	ack := &pb.WatchRequest{RequestUnion: &pb.WatchRequest_AckRequest{
+		AckRequest: &pb.WatchAckRequest{WatchId: cresp.WatchId, Revision: revs[0]}}}
+	if err = wStream.Send(ack); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	select {
+	case resp := <-respc:
+		if resp.WatchId != cresp.WatchId || len(resp.Events) != 1 {
+			t.Fatalf("got %+v, want one event of watch id %d", resp, cresp.WatchId)
+		}
+		if rev := resp.Events[0].Kv.ModRevision; rev != revs[1]+1 {
+			t.Errorf("revision = %d, want %d", rev, revs[1]+1)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to receive response after ack")
+	}
+}
+
 // TestV3WatchFutureRevision tests Watch APIs from a future revision.
 func TestV3WatchFutureRevision(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -0,0 +1,186 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !cluster_proxy
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/pkg/testutil"
+)
+
+// TestV3WitnessMember ensures a witness keeps the quorum of a cluster that
+// lost a data member, while it stores no keys and refuses client requests.
+func TestV3WitnessMember(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterByConfig(t, &ClusterConfig{Size: 3, UseGRPC: true})
+	w := clus.Members[2]
+	w.Witness = true
+	clus.Launch(t)
+	defer clus.Terminate(t)
+
+	wcli, err := NewClientV3(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wcli.Close()
+	wkv := toGRPC(wcli).KV
+	if _, err = wkv.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")}); !isWitnessErr(err) {
+		t.Fatalf("range on witness error = %v, want %v", err, rpctypes.ErrGRPCWitness)
+	}
+	if _, err = wkv.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); !isWitnessErr(err) {
+		t.Fatalf("put on witness error = %v, want %v", err, rpctypes.ErrGRPCWitness)
+	}
+
+	// the remaining data member leads with the witness's vote
+	lead := clus.WaitLeader(t)
+	if lead == 2 {
+		t.Fatalf("witness became the leader")
+	}
+	clus.Members[lead].Stop(t)
+	m := clus.Members[1-lead]
+	cli, err := NewClientV3(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	mustPutWithQuorum(t, cli, "witness-value")
+	if m.s.Lead() != uint64(m.s.ID()) {
+		t.Fatalf("leader = %x, want %x", m.s.Lead(), m.s.ID())
+	}
+	if wm := m.s.Cluster().Member(w.s.ID()); wm == nil || !wm.Witness {
+		t.Fatalf("member %s not known as a witness", w.s.ID())
+	}
+
+	// the witness recovers from a log of indexes and terms
+	w.Stop(t)
+	if err = w.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	mustPutWithQuorum(t, cli, "witness-value")
+
+	if r, err := w.s.KV().Range(context.TODO(), []byte("foo"), nil, mvcc.RangeOptions{}); err != nil || r.Count != 0 {
+		t.Fatalf("witness range = %+v, %v; want no keys", r, err)
+	}
+	wals, err := filepath.Glob(filepath.Join(w.WALDir(), "*.wal"))
+	if err != nil || len(wals) == 0 {
+		t.Fatalf("witness wal files = %v, %v", wals, err)
+	}
+	for _, wal := range wals {
+		b, err := ioutil.ReadFile(wal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(b, []byte("witness-value")) {
+			t.Fatalf("witness wal %s holds a value", wal)
+		}
+	}
+
+	_, err = toGRPC(cli).Maintenance.MoveLeader(context.TODO(), &pb.MoveLeaderRequest{TargetID: uint64(w.s.ID())})
+	if !isWitnessErr(err) {
+		t.Fatalf("move leader to witness error = %v, want %v", err, rpctypes.ErrGRPCWitness)
+	}
+}
+
+// TestV3WitnessLeaderLoss ensures a data member missing entries that only the
+// lost leader and the witness acknowledged cannot lead, and that restarting
+// the lost leader recovers the cluster.
+func TestV3WitnessLeaderLoss(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterByConfig(t, &ClusterConfig{Size: 3, UseGRPC: true})
+	w := clus.Members[2]
+	w.Witness = true
+	clus.Launch(t)
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	if lead == 2 {
+		t.Fatalf("witness became the leader")
+	}
+	l, f := clus.Members[lead], clus.Members[1-lead]
+
+	// the leader commits a put with the witness's acknowledgement only
+	f.Stop(t)
+	lcli, err := NewClientV3(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustPutWithQuorum(t, lcli, "lost-value")
+	lcli.Close()
+	l.Stop(t)
+
+	// the witness refuses to vote for the data member missing the put
+	if err = f.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	fcli, err := NewClientV3(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fcli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	_, err = toGRPC(fcli).KV.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	cancel()
+	if err == nil {
+		t.Fatal("put succeeded without the lost leader")
+	}
+	if f.s.Lead() != 0 {
+		t.Fatalf("leader = %x, want none", f.s.Lead())
+	}
+
+	// restarting the lost leader recovers the cluster and its put
+	if err = l.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	mustPutWithQuorum(t, fcli, "recovered-value")
+	r, err := toGRPC(fcli).KV.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), Revision: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Kvs) != 1 || string(r.Kvs[0].Value) != "lost-value" {
+		t.Fatalf("kvs at revision 2 = %+v, want lost-value", r.Kvs)
+	}
+}
+
+func isWitnessErr(err error) bool {
+	return err != nil && rpctypes.ErrorDesc(err) == rpctypes.ErrorDesc(rpctypes.ErrGRPCWitness)
+}
+
+// mustPutWithQuorum puts foo until the cluster has the quorum to commit it.
+func mustPutWithQuorum(t *testing.T, cli *clientv3.Client, val string) {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := toGRPC(cli).KV.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte(val)})
+		cancel()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("put error = %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -32,8 +32,12 @@ import (
 var (
 	LeasePrefix         = "/leases"
 	LeaseInternalPrefix = "/leases/internal"
+	LeaseBatchPrefix    = "/leases/batch"
 	applyTimeout        = time.Second
 	ErrLeaseHTTPTimeout = errors.New("waiting for node to catch up its applied index has timed out")
+	// ErrLeaseHTTPBatchUnsupported is returned by RenewBatchHTTP if the
+	// peer predates batched renewals.
+	ErrLeaseHTTPBatchUnsupported = errors.New("lease: peer does not support batched renewals")
 )
 
 // NewHandler returns an http Handler for lease renewals
@@ -90,6 +94,37 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+	case LeaseBatchPrefix:
+		lreq := leasepb.LeaseRenewBatchRequest{}
+		if err := lreq.Unmarshal(b); err != nil {
+			http.Error(w, "error unmarshalling request", http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-h.waitch():
+		case <-time.After(applyTimeout):
+			http.Error(w, ErrLeaseHTTPTimeout.Error(), http.StatusRequestTimeout)
+			return
+		}
+		resp := &leasepb.LeaseRenewBatchResponse{Responses: make([]*pb.LeaseKeepAliveResponse, len(lreq.Requests))}
+		for i, r := range lreq.Requests {
+			ttl, err := h.l.Renew(lease.LeaseID(r.ID))
+			if err == lease.ErrLeaseNotFound {
+				// leases not found have no TTL; renew the others
+				ttl, err = 0, nil
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			resp.Responses[i] = &pb.LeaseKeepAliveResponse{ID: r.ID, TTL: ttl}
+		}
+		v, err = resp.Marshal()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 	case LeaseInternalPrefix:
 		lreq := leasepb.LeaseInternalRequest{}
 		if err := lreq.Unmarshal(b); err != nil {
@@ -114,6 +149,7 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				ID:         lreq.LeaseTimeToLiveRequest.ID,
 				TTL:        int64(l.Remaining().Seconds()),
 				GrantedTTL: l.TTL(),
+				Metadata:   l.Metadata(),
 			},
 		}
 		if lreq.LeaseTimeToLiveRequest.Keys {
@@ -188,6 +224,64 @@ func RenewHTTP(ctx context.Context, id lease.LeaseID, url string, rt http.RoundT
 	return lresp.TTL, nil
 }
 
+// RenewBatchHTTP renews the leases of the given IDs with a single request
+// to the primary lessor's peer. It returns the renewed TTL of each lease,
+// or 0 for leases that do not exist.
+func RenewBatchHTTP(ctx context.Context, ids []lease.LeaseID, url string, rt http.RoundTripper) ([]int64, error) {
+	breq := &leasepb.LeaseRenewBatchRequest{Requests: make([]*pb.LeaseKeepAliveRequest, len(ids))}
+	for i, id := range ids {
+		breq.Requests[i] = &pb.LeaseKeepAliveRequest{ID: int64(id)}
+	}
+	lreq, err := breq.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &http.Client{Transport: rt}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(lreq))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/protobuf")
+	req = req.WithContext(ctx)
+
+	resp, err := cc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusRequestTimeout:
+		return nil, ErrLeaseHTTPTimeout
+	case http.StatusNotFound:
+		// the peer's mux has no handler for the batch path
+		return nil, ErrLeaseHTTPBatchUnsupported
+	default:
+		return nil, fmt.Errorf("lease: unknown error(%s)", string(b))
+	}
+
+	lresp := &leasepb.LeaseRenewBatchResponse{}
+	if err := lresp.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf(`lease: %v. data = "%s"`, err, string(b))
+	}
+	if len(lresp.Responses) != len(ids) {
+		return nil, fmt.Errorf("lease: renewed %d leases, want %d", len(lresp.Responses), len(ids))
+	}
+	ttls := make([]int64, len(ids))
+	for i, r := range lresp.Responses {
+		if r.ID != int64(ids[i]) {
+			return nil, fmt.Errorf("lease: renew id mismatch")
+		}
+		ttls[i] = r.TTL
+	}
+	return ttls, nil
+}
+
 // TimeToLiveHTTP retrieves lease information of the given lease ID.
 func TimeToLiveHTTP(ctx context.Context, id lease.LeaseID, keys bool, url string, rt http.RoundTripper) (*leasepb.LeaseInternalResponse, error) {
 	// will post lreq protobuf to leader
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -51,6 +52,44 @@ func TestRenewHTTP(t *testing.T) {
 	}
 }
 
+func TestRenewBatchHTTP(t *testing.T) {
+	be, tmpPath := backend.NewTmpBackend(time.Hour, 10000)
+	defer os.Remove(tmpPath)
+	defer be.Close()
+
+	le := lease.NewLessor(be, int64(5))
+	le.Promote(time.Second)
+	if _, err := le.Grant(1, int64(5)); err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	if _, err := le.Grant(2, int64(10)); err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le, waitReady))
+	defer ts.Close()
+
+	ttls, err := RenewBatchHTTP(context.TODO(), []lease.LeaseID{2, 3, 1}, ts.URL+LeaseBatchPrefix, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{10, 0, 5}; !reflect.DeepEqual(ttls, want) {
+		t.Fatalf("ttls expected %v, got %v", want, ttls)
+	}
+
+	// peers without batched renewals answer not found
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", http.NotFound)
+	mux.Handle(LeasePrefix, NewHandler(le, waitReady))
+	ots := httptest.NewServer(mux)
+	defer ots.Close()
+
+	_, err = RenewBatchHTTP(context.TODO(), []lease.LeaseID{1}, ots.URL+LeaseBatchPrefix, http.DefaultTransport)
+	if err != ErrLeaseHTTPBatchUnsupported {
+		t.Fatalf("err expected %v, got %v", ErrLeaseHTTPBatchUnsupported, err)
+	}
+}
+
 func TestTimeToLiveHTTP(t *testing.T) {
 	be, tmpPath := backend.NewTmpBackend(time.Hour, 10000)
 	defer os.Remove(tmpPath)
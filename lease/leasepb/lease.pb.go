@@ -12,6 +12,8 @@
 		Lease
 		LeaseInternalRequest
 		LeaseInternalResponse
+		LeaseRenewBatchRequest
+		LeaseRenewBatchResponse
 */
 package leasepb
 
@@ -39,8 +41,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Lease struct {
-	ID  int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	TTL int64 `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	ID       int64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TTL      int64  `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Metadata []byte `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	KeepKeys bool   `protobuf:"varint,4,opt,name=keep_keys,json=keepKeys,proto3" json:"keep_keys,omitempty"`
 }
 
 func (m *Lease) Reset()                    { *m = Lease{} }
@@ -66,10 +70,30 @@ func (m *LeaseInternalResponse) String() string            { return proto.Compac
 func (*LeaseInternalResponse) ProtoMessage()               {}
 func (*LeaseInternalResponse) Descriptor() ([]byte, []int) { return fileDescriptorLease, []int{2} }
 
+type LeaseRenewBatchRequest struct {
+	Requests []*etcdserverpb.LeaseKeepAliveRequest `protobuf:"bytes,1,rep,name=Requests" json:"Requests,omitempty"`
+}
+
+func (m *LeaseRenewBatchRequest) Reset()                    { *m = LeaseRenewBatchRequest{} }
+func (m *LeaseRenewBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseRenewBatchRequest) ProtoMessage()               {}
+func (*LeaseRenewBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptorLease, []int{3} }
+
+type LeaseRenewBatchResponse struct {
+	Responses []*etcdserverpb.LeaseKeepAliveResponse `protobuf:"bytes,1,rep,name=Responses" json:"Responses,omitempty"`
+}
+
+func (m *LeaseRenewBatchResponse) Reset()                    { *m = LeaseRenewBatchResponse{} }
+func (m *LeaseRenewBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseRenewBatchResponse) ProtoMessage()               {}
+func (*LeaseRenewBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptorLease, []int{4} }
+
 func init() {
 	proto.RegisterType((*Lease)(nil), "leasepb.Lease")
 	proto.RegisterType((*LeaseInternalRequest)(nil), "leasepb.LeaseInternalRequest")
 	proto.RegisterType((*LeaseInternalResponse)(nil), "leasepb.LeaseInternalResponse")
+	proto.RegisterType((*LeaseRenewBatchRequest)(nil), "leasepb.LeaseRenewBatchRequest")
+	proto.RegisterType((*LeaseRenewBatchResponse)(nil), "leasepb.LeaseRenewBatchResponse")
 }
 func (m *Lease) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -96,6 +120,22 @@ func (m *Lease) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintLease(dAtA, i, uint64(m.TTL))
 	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintLease(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
+	if m.KeepKeys {
+		dAtA[i] = 0x20
+		i++
+		if m.KeepKeys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -155,6 +195,66 @@ func (m *LeaseInternalResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LeaseRenewBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseRenewBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintLease(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *LeaseRenewBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseRenewBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, msg := range m.Responses {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintLease(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func encodeFixed64Lease(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -191,6 +291,13 @@ func (m *Lease) Size() (n int) {
 	if m.TTL != 0 {
 		n += 1 + sovLease(uint64(m.TTL))
 	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovLease(uint64(l))
+	}
+	if m.KeepKeys {
+		n += 2
+	}
 	return n
 }
 
@@ -214,6 +321,30 @@ func (m *LeaseInternalResponse) Size() (n int) {
 	return n
 }
 
+func (m *LeaseRenewBatchRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovLease(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LeaseRenewBatchResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovLease(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovLease(x uint64) (n int) {
 	for {
 		n++
@@ -294,6 +425,57 @@ func (m *Lease) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepKeys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeepKeys = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLease(dAtA[iNdEx:])
@@ -481,6 +663,168 @@ func (m *LeaseInternalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LeaseRenewBatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLease
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseRenewBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseRenewBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &etcdserverpb.LeaseKeepAliveRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLease(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLease
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseRenewBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLease
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseRenewBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseRenewBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &etcdserverpb.LeaseKeepAliveResponse{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLease(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLease
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLease(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -589,20 +933,27 @@ var (
 func init() { proto.RegisterFile("lease.proto", fileDescriptorLease) }
 
 var fileDescriptorLease = []byte{
-	// 233 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xce, 0x49, 0x4d, 0x2c,
-	0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x07, 0x73, 0x0a, 0x92, 0xa4, 0x44, 0xd2,
-	0xf3, 0xd3, 0xf3, 0xc1, 0x62, 0xfa, 0x20, 0x16, 0x44, 0x5a, 0x4a, 0x2d, 0xb5, 0x24, 0x39, 0x45,
-	0x1f, 0x44, 0x14, 0xa7, 0x16, 0x95, 0xa5, 0x16, 0x21, 0x31, 0x0b, 0x92, 0xf4, 0x8b, 0x0a, 0x92,
-	0x21, 0xea, 0x94, 0x34, 0xb9, 0x58, 0x7d, 0x40, 0x06, 0x09, 0xf1, 0x71, 0x31, 0x79, 0xba, 0x48,
-	0x30, 0x2a, 0x30, 0x6a, 0x30, 0x07, 0x31, 0x79, 0xba, 0x08, 0x09, 0x70, 0x31, 0x87, 0x84, 0xf8,
-	0x48, 0x30, 0x81, 0x05, 0x40, 0x4c, 0xa5, 0x12, 0x2e, 0x11, 0xb0, 0x52, 0xcf, 0xbc, 0x92, 0xd4,
-	0xa2, 0xbc, 0xc4, 0x9c, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0xa1, 0x18, 0x2e, 0x31, 0xb0,
-	0x78, 0x48, 0x66, 0x6e, 0x6a, 0x48, 0xbe, 0x4f, 0x66, 0x59, 0x2a, 0x54, 0x06, 0x6c, 0x1a, 0xb7,
-	0x91, 0x8a, 0x1e, 0xb2, 0xdd, 0x7a, 0xd8, 0xd5, 0x06, 0xe1, 0x30, 0x43, 0xa9, 0x82, 0x4b, 0x14,
-	0xcd, 0xd6, 0xe2, 0x82, 0xfc, 0xbc, 0xe2, 0x54, 0xa1, 0x78, 0x2e, 0x71, 0x0c, 0x2d, 0x10, 0x29,
-	0xa8, 0xbd, 0xaa, 0x04, 0xec, 0x85, 0x28, 0x0e, 0xc2, 0x65, 0x8a, 0x93, 0xc4, 0x89, 0x87, 0x72,
-	0x0c, 0x17, 0x1e, 0xca, 0x31, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47,
-	0x72, 0x8c, 0x33, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0xc3, 0xce, 0x18, 0x10, 0x00, 0x00, 0xff,
-	0xff, 0x9f, 0xf2, 0x42, 0xe0, 0x91, 0x01, 0x00, 0x00,
+	// 343 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xdd, 0x4a, 0x02, 0x41,
+	0x14, 0x76, 0xdc, 0x7e, 0xd6, 0x31, 0x22, 0x06, 0xab, 0xc5, 0x60, 0x59, 0x36, 0x8b, 0xbd, 0x52,
+	0xb0, 0x07, 0x88, 0xc4, 0x1b, 0xd1, 0xab, 0x61, 0x6f, 0x82, 0x42, 0x76, 0xf5, 0x60, 0xa2, 0xee,
+	0x4c, 0x3b, 0x93, 0xe5, 0x9b, 0xf4, 0x48, 0x5e, 0xfa, 0x08, 0x69, 0x2f, 0x12, 0x3b, 0x3b, 0xda,
+	0x52, 0x5a, 0x37, 0xc3, 0x77, 0xbe, 0x73, 0xce, 0xf7, 0x9d, 0x0f, 0x06, 0x17, 0xc7, 0x10, 0x08,
+	0xa8, 0xf2, 0x98, 0x49, 0x46, 0x0e, 0x55, 0xc1, 0xc3, 0x72, 0x69, 0xc0, 0x06, 0x4c, 0x71, 0xb5,
+	0x04, 0xa5, 0xed, 0xf2, 0x35, 0xc8, 0x5e, 0xbf, 0x96, 0x3c, 0x02, 0xe2, 0x29, 0xc4, 0x19, 0xc8,
+	0xc3, 0x5a, 0xcc, 0x7b, 0xe9, 0x9c, 0x1b, 0xe2, 0xfd, 0x4e, 0x22, 0x44, 0x8e, 0x71, 0xbe, 0xd5,
+	0xb4, 0x90, 0x83, 0x3c, 0x83, 0xe6, 0x5b, 0x4d, 0x72, 0x82, 0x0d, 0xdf, 0xef, 0x58, 0x79, 0x45,
+	0x24, 0x90, 0x94, 0xb1, 0x39, 0x01, 0x19, 0xf4, 0x03, 0x19, 0x58, 0x86, 0x83, 0xbc, 0x23, 0xba,
+	0xa9, 0xc9, 0x05, 0x2e, 0x8c, 0x00, 0x78, 0x77, 0x04, 0x33, 0x61, 0xed, 0x39, 0xc8, 0x33, 0xa9,
+	0x99, 0x10, 0x6d, 0x98, 0x09, 0x57, 0xe2, 0x92, 0xf2, 0x68, 0x45, 0x12, 0xe2, 0x28, 0x18, 0x53,
+	0x78, 0x7e, 0x01, 0x21, 0xc9, 0x03, 0x3e, 0x53, 0xbc, 0x3f, 0x9c, 0x80, 0xcf, 0x3a, 0xc3, 0x29,
+	0xe8, 0x8e, 0x3a, 0xa3, 0x58, 0xaf, 0x54, 0xb3, 0x47, 0x57, 0xb7, 0xcf, 0xd2, 0x1d, 0x1a, 0xee,
+	0x1b, 0x3e, 0xfd, 0xe1, 0x2a, 0x38, 0x8b, 0x04, 0x90, 0x2e, 0x3e, 0xff, 0xb5, 0x92, 0xb6, 0xb4,
+	0xef, 0xd5, 0x3f, 0xbe, 0xe9, 0x30, 0xdd, 0xa5, 0xe2, 0xde, 0xeb, 0x5c, 0x14, 0x22, 0x78, 0x6d,
+	0x04, 0xb2, 0xf7, 0xb4, 0x4e, 0x7c, 0x8b, 0x4d, 0x0d, 0x85, 0x85, 0x1c, 0xc3, 0x2b, 0xd6, 0x2f,
+	0xb7, 0x78, 0xb5, 0x01, 0xf8, 0xdd, 0x38, 0x13, 0x71, 0xb3, 0xe4, 0x3e, 0xea, 0xdb, 0xb3, 0xd2,
+	0x3a, 0x56, 0x03, 0x17, 0xd6, 0x78, 0x2d, 0x5e, 0xf9, 0x5b, 0x5c, 0xe7, 0xf8, 0x5e, 0x6b, 0x58,
+	0xf3, 0xa5, 0x9d, 0x5b, 0x2c, 0xed, 0xdc, 0x7c, 0x65, 0xa3, 0xc5, 0xca, 0x46, 0x1f, 0x2b, 0x1b,
+	0xbd, 0x7f, 0xda, 0xb9, 0xf0, 0x40, 0x7d, 0x97, 0x9b, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x33,
+	0x7b, 0x20, 0xb5, 0x84, 0x02, 0x00, 0x00,
 }
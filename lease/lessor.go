@@ -29,6 +29,9 @@ import (
 // NoLease is a special LeaseID representing the absence of a lease.
 const NoLease = LeaseID(0)
 
+// MaxMetadataSize is the maximum size in bytes of the metadata of a lease.
+const MaxMetadataSize = 256
+
 var (
 	forever = time.Time{}
 
@@ -40,12 +43,16 @@ var (
 	ErrNotPrimary    = errors.New("not a primary lessor")
 	ErrLeaseNotFound = errors.New("lease not found")
 	ErrLeaseExists   = errors.New("lease already exists")
+
+	ErrLeaseMetadataTooLarge = errors.New("lease metadata is too large")
 )
 
-// TxnDelete is a TxnWrite that only permits deletes. Defined here
-// to avoid circular dependency with mvcc.
+// TxnDelete is a TxnWrite that only permits deletes and detaching keys
+// from their lease. Defined here to avoid circular dependency with mvcc.
 type TxnDelete interface {
 	DeleteRange(key, end []byte) (n, rev int64)
+	// DetachLease rewrites the key with its current value and no lease.
+	DetachLease(key []byte) (rev int64)
 	End()
 }
 
@@ -54,6 +61,15 @@ type RangeDeleter func() TxnDelete
 
 type LeaseID int64
 
+// GrantOptions are the optional attributes of a granted lease.
+type GrantOptions struct {
+	// Metadata is opaque client data kept with the lease.
+	Metadata []byte
+	// KeepKeys detaches the keys of the lease instead of deleting them
+	// when the lease is revoked or expires.
+	KeepKeys bool
+}
+
 // Lessor owns leases. It can grant, revoke, renew and modify leases for lessee.
 type Lessor interface {
 	// SetRangeDeleter lets the lessor create TxnDeletes to the store.
@@ -63,6 +79,9 @@ type Lessor interface {
 
 	// Grant grants a lease that expires at least after TTL seconds.
 	Grant(id LeaseID, ttl int64) (*Lease, error)
+
+	// GrantWithOptions grants a lease like Grant with the given options.
+	GrantWithOptions(id LeaseID, ttl int64, opts GrantOptions) (*Lease, error)
 	// Revoke revokes a lease with given ID. The item attached to the
 	// given lease will be removed. If the ID does not exist, an error
 	// will be returned.
@@ -194,17 +213,26 @@ func (le *lessor) SetRangeDeleter(rd RangeDeleter) {
 }
 
 func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
+	return le.GrantWithOptions(id, ttl, GrantOptions{})
+}
+
+func (le *lessor) GrantWithOptions(id LeaseID, ttl int64, opts GrantOptions) (*Lease, error) {
 	if id == NoLease {
 		return nil, ErrLeaseNotFound
 	}
+	if len(opts.Metadata) > MaxMetadataSize {
+		return nil, ErrLeaseMetadataTooLarge
+	}
 
 	// TODO: when lessor is under high load, it should give out lease
 	// with longer TTL to reduce renew load.
 	l := &Lease{
-		ID:      id,
-		ttl:     ttl,
-		itemSet: make(map[LeaseItem]struct{}),
-		revokec: make(chan struct{}),
+		ID:       id,
+		ttl:      ttl,
+		metadata: opts.Metadata,
+		keepKeys: opts.KeepKeys,
+		itemSet:  make(map[LeaseItem]struct{}),
+		revokec:  make(chan struct{}),
 	}
 
 	le.mu.Lock()
@@ -227,6 +255,10 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	le.leaseMap[id] = l
 	l.persistTo(le.b)
 
+	leaseTotalTTLs.Observe(float64(l.ttl))
+	leaseGranted.Inc()
+	leaseTotal.Set(float64(len(le.leaseMap)))
+
 	return l, nil
 }
 
@@ -253,6 +285,10 @@ func (le *lessor) Revoke(id LeaseID) error {
 	keys := l.Keys()
 	sort.StringSlice(keys).Sort()
 	for _, key := range keys {
+		if l.keepKeys {
+			txn.DetachLease([]byte(key))
+			continue
+		}
 		txn.DeleteRange([]byte(key), nil)
 	}
 
@@ -265,6 +301,15 @@ func (le *lessor) Revoke(id LeaseID) error {
 	le.b.BatchTx().UnsafeDelete(leaseBucketName, int64ToBytes(int64(l.ID)))
 
 	txn.End()
+
+	leaseRevoked.Inc()
+	leaseTotal.Set(float64(len(le.leaseMap)))
+	leaseRevokedKeys.Observe(float64(len(keys)))
+	// only the primary lessor sees leases expire
+	if d := -l.Remaining(); d >= 0 {
+		leaseExpired.Inc()
+		leaseExpiredRevokeDurations.Observe(d.Seconds())
+	}
 	return nil
 }
 
@@ -307,6 +352,7 @@ func (le *lessor) Renew(id LeaseID) (int64, error) {
 	}
 
 	l.refresh(0)
+	leaseRenewed.Inc()
 	return l.ttl, nil
 }
 
@@ -543,8 +589,10 @@ func (le *lessor) initAndRecover() {
 			lpb.TTL = le.minLeaseTTL
 		}
 		le.leaseMap[ID] = &Lease{
-			ID:  ID,
-			ttl: lpb.TTL,
+			ID:       ID,
+			ttl:      lpb.TTL,
+			metadata: lpb.Metadata,
+			keepKeys: lpb.KeepKeys,
 			// itemSet will be filled in when recover key-value pairs
 			// set expiry to forever, refresh when promoted
 			itemSet: make(map[LeaseItem]struct{}),
@@ -554,12 +602,18 @@ func (le *lessor) initAndRecover() {
 	}
 	tx.Unlock()
 
+	leaseTotal.Set(float64(len(le.leaseMap)))
+
 	le.b.ForceCommit()
 }
 
 type Lease struct {
 	ID  LeaseID
 	ttl int64 // time to live in seconds
+	// metadata is opaque client data given at grant time
+	metadata []byte
+	// keepKeys detaches the keys on revoke instead of deleting them
+	keepKeys bool
 	// expiryMu protects concurrent accesses to expiry
 	expiryMu sync.RWMutex
 	// expiry is time when lease should expire. no expiration when expiry.IsZero() is true
@@ -578,7 +632,7 @@ func (l *Lease) expired() bool {
 func (l *Lease) persistTo(b backend.Backend) {
 	key := int64ToBytes(int64(l.ID))
 
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.ttl)}
+	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.ttl), Metadata: l.metadata, KeepKeys: l.keepKeys}
 	val, err := lpb.Marshal()
 	if err != nil {
 		panic("failed to marshal lease proto item")
@@ -594,6 +648,11 @@ func (l *Lease) TTL() int64 {
 	return l.ttl
 }
 
+// Metadata returns the opaque metadata the Lease was granted with.
+func (l *Lease) Metadata() []byte {
+	return l.metadata
+}
+
 // refresh refreshes the expiry of the lease.
 func (l *Lease) refresh(extend time.Duration) {
 	newExpiry := time.Now().Add(extend + time.Duration(l.ttl)*time.Second)
@@ -648,6 +707,10 @@ func (fl *FakeLessor) SetRangeDeleter(dr RangeDeleter) {}
 
 func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) { return nil, nil }
 
+func (fl *FakeLessor) GrantWithOptions(id LeaseID, ttl int64, opts GrantOptions) (*Lease, error) {
+	return nil, nil
+}
+
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
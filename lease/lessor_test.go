@@ -15,6 +15,7 @@
 package lease
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -196,6 +197,47 @@ func TestLessorRevoke(t *testing.T) {
 	be.BatchTx().Unlock()
 }
 
+// TestLessorRevokeKeepKeys ensures revoking a lease granted with KeepKeys
+// detaches its items instead of deleting them.
+func TestLessorRevokeKeepKeys(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be, minLeaseTTL)
+	defer le.Stop()
+	var fd *fakeDeleter
+	le.SetRangeDeleter(func() TxnDelete {
+		fd = newFakeDeleter(be)
+		return fd
+	})
+
+	l, err := le.GrantWithOptions(1, 100, GrantOptions{KeepKeys: true})
+	if err != nil {
+		t.Fatalf("could not grant lease for 100s ttl (%v)", err)
+	}
+	if err = le.Attach(l.ID, []LeaseItem{{"foo"}, {"bar"}}); err != nil {
+		t.Fatalf("failed to attach items to the lease: %v", err)
+	}
+
+	// the option must survive recovery
+	nle := newLessor(be, minLeaseTTL)
+	defer nle.Stop()
+	if nl := nle.Lookup(l.ID); nl == nil || !nl.keepKeys {
+		t.Fatalf("recovered lease = %v, want keepKeys", nl)
+	}
+
+	if err = le.Revoke(l.ID); err != nil {
+		t.Fatal("failed to revoke lease:", err)
+	}
+	if len(fd.deleted) != 0 {
+		t.Errorf("deleted = %v, want none", fd.deleted)
+	}
+	if wdetached := []string{"bar", "foo"}; !reflect.DeepEqual(fd.detached, wdetached) {
+		t.Errorf("detached = %v, want %v", fd.detached, wdetached)
+	}
+}
+
 // TestLessorRenew ensures Lessor can renew an existing lease.
 func TestLessorRenew(t *testing.T) {
 	dir, be := NewTestBackend(t)
@@ -349,6 +391,34 @@ func TestLessorRecover(t *testing.T) {
 	}
 }
 
+// TestLessorGrantWithMetadata ensures the metadata of a lease is kept
+// across recovery and oversized metadata is rejected.
+func TestLessorGrantWithMetadata(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be, minLeaseTTL)
+	defer le.Stop()
+	md := []byte("owner=scheduler")
+	if _, err := le.GrantWithOptions(1, 10, GrantOptions{Metadata: md}); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	if _, err := le.GrantWithOptions(2, 10, GrantOptions{Metadata: make([]byte, MaxMetadataSize+1)}); err != ErrLeaseMetadataTooLarge {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseMetadataTooLarge)
+	}
+	if l := le.Lookup(2); l != nil {
+		t.Fatalf("lease with oversized metadata was granted")
+	}
+
+	nle := newLessor(be, minLeaseTTL)
+	defer nle.Stop()
+	nl := nle.Lookup(1)
+	if nl == nil || !bytes.Equal(nl.Metadata(), md) {
+		t.Errorf("recovered lease = %v, want metadata %q", nl, md)
+	}
+}
+
 func TestLessorExpire(t *testing.T) {
 	dir, be := NewTestBackend(t)
 	defer os.RemoveAll(dir)
@@ -452,12 +522,13 @@ func TestLessorExpireAndDemote(t *testing.T) {
 }
 
 type fakeDeleter struct {
-	deleted []string
-	tx      backend.BatchTx
+	deleted  []string
+	detached []string
+	tx       backend.BatchTx
 }
 
 func newFakeDeleter(be backend.Backend) *fakeDeleter {
-	fd := &fakeDeleter{nil, be.BatchTx()}
+	fd := &fakeDeleter{tx: be.BatchTx()}
 	fd.tx.Lock()
 	return fd
 }
@@ -469,6 +540,11 @@ func (fd *fakeDeleter) DeleteRange(key, end []byte) (int64, int64) {
 	return 0, 0
 }
 
+func (fd *fakeDeleter) DetachLease(key []byte) int64 {
+	fd.detached = append(fd.detached, string(key))
+	return 0
+}
+
 func NewTestBackend(t *testing.T) (string, backend.Backend) {
 	tmpPath, err := ioutil.TempDir("", "lease")
 	if err != nil {
@@ -25,6 +25,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/coreos/etcd/pkg/fileutil"
+
 	bolt "github.com/coreos/bbolt"
 	"github.com/coreos/pkg/capnslog"
 )
@@ -54,8 +56,17 @@ type Backend interface {
 	Hash(ignores map[IgnoreKey]struct{}) (uint32, error)
 	// Size returns the current size of the backend.
 	Size() int64
+	// SizeInUse returns the current size of the backend less its free pages,
+	// which defragmentation would reclaim.
+	SizeInUse() int64
 	Defrag() error
 	ForceCommit()
+	// Commits returns the number of commits since the backend was opened.
+	Commits() int64
+	// Err returns the error of the commit the backend failed on, or nil.
+	// Once a commit fails, the backend keeps serving its last state but
+	// commits nothing more.
+	Err() error
 	Close() error
 }
 
@@ -74,11 +85,16 @@ type backend struct {
 
 	// size is the number of bytes in the backend
 	size int64
+	// sizeInUse is the number of bytes in the backend not on free pages
+	sizeInUse int64
 	// commits counts number of commits since start
 	commits int64
 
 	mu sync.RWMutex
 	db *bolt.DB
+	// bopts are the options the database was opened with; defrag reopens
+	// the database with them.
+	bopts *bolt.Options
 
 	batchInterval time.Duration
 	batchLimit    int
@@ -86,6 +102,23 @@ type backend struct {
 
 	readTx *readTx
 
+	// defragMu serializes defrags.
+	defragMu sync.Mutex
+	// defragging is set while a defrag copies the database; the writes
+	// made meanwhile are recorded to defragTail, to be applied to the copy
+	// before it replaces the database. Both are protected by the batchTx lock.
+	defragging bool
+	defragTail []defragOp
+
+	// preallocSize is the preallocation chunk size; 0 if disabled.
+	preallocSize int64
+	// preallocEnd is the end of the preallocated space of the backend file.
+	// It is protected by the batchTx lock.
+	preallocEnd int64
+
+	// err holds the error of the first failed commit.
+	err atomic.Value
+
 	stopc chan struct{}
 	donec chan struct{}
 }
@@ -99,6 +132,13 @@ type BackendConfig struct {
 	BatchLimit int
 	// MmapSize is the number of bytes to mmap for the backend.
 	MmapSize uint64
+	// PreallocSize is the size of the chunks the disk space of the backend
+	// file is preallocated in as the backend nears its preallocated size.
+	// 0 disables preallocation.
+	PreallocSize int64
+	// DSync opens the backend file with O_DSYNC, so each page bolt writes
+	// reaches stable storage before the write returns.
+	DSync bool
 }
 
 func DefaultBackendConfig() BackendConfig {
@@ -125,6 +165,11 @@ func newBackend(bcfg BackendConfig) *backend {
 		*bopts = *boltOpenOptions
 	}
 	bopts.InitialMmapSize = bcfg.mmapSize()
+	if bcfg.DSync {
+		bopts.OpenFile = func(path string, flag int, mode os.FileMode) (*os.File, error) {
+			return os.OpenFile(path, flag|fileutil.DSyncFlag, mode)
+		}
+	}
 
 	db, err := bolt.Open(bcfg.Path, 0600, bopts)
 	if err != nil {
@@ -134,10 +179,12 @@ func newBackend(bcfg BackendConfig) *backend {
 	// In future, may want to make buffering optional for low-concurrency systems
 	// or dynamically swap between buffered/non-buffered depending on workload.
 	b := &backend{
-		db: db,
+		db:    db,
+		bopts: bopts,
 
 		batchInterval: bcfg.BatchInterval,
 		batchLimit:    bcfg.BatchLimit,
+		preallocSize:  bcfg.PreallocSize,
 
 		readTx: &readTx{
 			buf: txReadBuffer{
@@ -168,6 +215,20 @@ func (b *backend) ForceCommit() {
 	b.batchTx.Commit()
 }
 
+func (b *backend) Err() error {
+	if err, ok := b.err.Load().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// fail records the error a commit failed on. It is called with the
+// batchTx lock held.
+func (b *backend) fail(err error) {
+	plog.Errorf("cannot commit tx (%s); the backend stops committing", err)
+	b.err.Store(err)
+}
+
 func (b *backend) Snapshot() Snapshot {
 	b.batchTx.Commit()
 
@@ -206,6 +267,8 @@ func (b *backend) Snapshot() Snapshot {
 	return &snapshot{tx, stopc, donec}
 }
 
+// IgnoreKey is a key to leave out of Hash. An IgnoreKey with an
+// empty Key leaves out the whole bucket.
 type IgnoreKey struct {
 	Bucket string
 	Key    string
@@ -223,6 +286,9 @@ func (b *backend) Hash(ignores map[IgnoreKey]struct{}) (uint32, error) {
 			if b == nil {
 				return fmt.Errorf("cannot get hash of bucket %s", string(next))
 			}
+			if _, ok := ignores[IgnoreKey{Bucket: string(next)}]; ok {
+				continue
+			}
 			h.Write(next)
 			b.ForEach(func(k, v []byte) error {
 				bk := IgnoreKey{Bucket: string(next), Key: string(k)}
@@ -247,6 +313,10 @@ func (b *backend) Size() int64 {
 	return atomic.LoadInt64(&b.size)
 }
 
+func (b *backend) SizeInUse() int64 {
+	return atomic.LoadInt64(&b.sizeInUse)
+}
+
 func (b *backend) run() {
 	defer close(b.donec)
 	t := time.NewTimer(b.batchInterval)
@@ -275,6 +345,10 @@ func (b *backend) Commits() int64 {
 }
 
 func (b *backend) Defrag() error {
+	// the database file misses the writes of the failed commit
+	if err := b.Err(); err != nil {
+		return err
+	}
 	err := b.defrag()
 	if err != nil {
 		return err
@@ -286,12 +360,83 @@ func (b *backend) Defrag() error {
 	return nil
 }
 
+// defragOp is a write made to the backend while it is being defragmented.
+type defragOp struct {
+	createBucket bool
+	delete       bool
+	bucket       []byte
+	key, value   []byte
+}
+
+// recordDefragOp records a write for the ongoing defrag, if any. It must be
+// called holding the lock on the batchTx.
+func (b *backend) recordDefragOp(op defragOp) {
+	if !b.defragging {
+		return
+	}
+	// the caller may reuse its slices once the write returns
+	op.bucket = append([]byte(nil), op.bucket...)
+	op.key = append([]byte(nil), op.key...)
+	op.value = append([]byte(nil), op.value...)
+	b.defragTail = append(b.defragTail, op)
+}
+
+// takeDefragTail returns the writes recorded since the last call. It must be
+// called holding the lock on the batchTx.
+func (b *backend) takeDefragTail() []defragOp {
+	ops := b.defragTail
+	b.defragTail = nil
+	return ops
+}
+
+// defrag copies the database aside without blocking writes, then applies
+// the writes made during the copy to it and switches to it. Writes are only
+// blocked while applying the writes made during the catch up of the copy,
+// and while switching files.
 func (b *backend) defrag() error {
-	// TODO: make this non-blocking?
+	b.defragMu.Lock()
+	defer b.defragMu.Unlock()
+
+	// begin the read tx to copy from right after a commit, so every write
+	// the copy misses is recorded
+	b.batchTx.Lock()
+	b.batchTx.commit(false)
+	tx := b.begin(false)
+	b.defragging = true
+	b.batchTx.Unlock()
+
+	tmpdb, err := bolt.Open(b.db.Path()+".tmp", 0600, boltOpenOptions)
+	if err == nil {
+		err = defragdb(tx, tmpdb, defragLimit)
+	}
+	if rerr := tx.Rollback(); rerr != nil {
+		plog.Fatalf("cannot rollback tx (%s)", rerr)
+	}
+	if err == nil {
+		// catch up with the writes made during the copy while writes go on
+		b.batchTx.Lock()
+		ops := b.takeDefragTail()
+		b.batchTx.Unlock()
+		err = applyDefragOps(tmpdb, ops)
+	}
+	if err != nil {
+		b.batchTx.Lock()
+		b.defragging = false
+		b.defragTail = nil
+		b.batchTx.Unlock()
+		if tmpdb != nil {
+			tmpdb.Close()
+			os.RemoveAll(tmpdb.Path())
+		}
+		return err
+	}
+
 	// lock batchTx to ensure nobody is using previous tx, and then
 	// close previous ongoing tx.
 	b.batchTx.Lock()
 	defer b.batchTx.Unlock()
+	start := time.Now()
+	defer func() { defragPauseDurations.Observe(time.Since(start).Seconds()) }()
 
 	// lock database after lock tx to avoid deadlock.
 	b.mu.Lock()
@@ -304,17 +449,10 @@ func (b *backend) defrag() error {
 	b.batchTx.unsafeCommit(true)
 	b.batchTx.tx = nil
 
-	tmpdb, err := bolt.Open(b.db.Path()+".tmp", 0600, boltOpenOptions)
-	if err != nil {
-		return err
-	}
-
-	err = defragdb(b.db, tmpdb, defragLimit)
-
-	if err != nil {
-		tmpdb.Close()
-		os.RemoveAll(tmpdb.Path())
-		return err
+	ops := b.takeDefragTail()
+	b.defragging = false
+	if err = applyDefragOps(tmpdb, ops); err != nil {
+		plog.Fatalf("cannot apply writes to defragmented database (%s)", err)
 	}
 
 	dbp := b.db.Path()
@@ -333,7 +471,7 @@ func (b *backend) defrag() error {
 		plog.Fatalf("cannot rename database (%s)", err)
 	}
 
-	b.db, err = bolt.Open(dbp, 0600, boltOpenOptions)
+	b.db, err = bolt.Open(dbp, 0600, b.bopts)
 	if err != nil {
 		plog.Panicf("cannot open database at %s (%v)", dbp, err)
 	}
@@ -344,39 +482,38 @@ func (b *backend) defrag() error {
 
 	b.readTx.reset()
 	b.readTx.tx = b.unsafeBegin(false)
-	atomic.StoreInt64(&b.size, b.readTx.tx.Size())
+	b.storeSize(b.readTx.tx)
+
+	// the defragmented file has no space preallocated
+	b.preallocEnd = 0
+	b.preallocate(b.readTx.tx.Size())
 
 	return nil
 }
 
-func defragdb(odb, tmpdb *bolt.DB, limit int) error {
+func defragdb(tx *bolt.Tx, tmpdb *bolt.DB, limit int) error {
 	// open a tx on tmpdb for writes
 	tmptx, err := tmpdb.Begin(true)
 	if err != nil {
 		return err
 	}
 
-	// open a tx on old db for read
-	tx, err := odb.Begin(false)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
 	c := tx.Cursor()
 
 	count := 0
 	for next, _ := c.First(); next != nil; next, _ = c.Next() {
 		b := tx.Bucket(next)
 		if b == nil {
+			tmptx.Rollback()
 			return fmt.Errorf("backend: cannot defrag bucket %s", string(next))
 		}
 
 		tmpb, berr := tmptx.CreateBucketIfNotExists(next)
-		tmpb.FillPercent = 0.9 // for seq write in for each
 		if berr != nil {
+			tmptx.Rollback()
 			return berr
 		}
+		tmpb.FillPercent = 0.9 // for seq write in for each
 
 		b.ForEach(func(k, v []byte) error {
 			count++
@@ -401,14 +538,78 @@ func defragdb(odb, tmpdb *bolt.DB, limit int) error {
 	return tmptx.Commit()
 }
 
+// applyDefragOps applies the writes recorded during a defrag to its copy.
+func applyDefragOps(tmpdb *bolt.DB, ops []defragOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	return tmpdb.Update(func(tx *bolt.Tx) error {
+		for _, op := range ops {
+			if op.createBucket {
+				if _, err := tx.CreateBucketIfNotExists(op.bucket); err != nil {
+					return err
+				}
+				continue
+			}
+			bucket := tx.Bucket(op.bucket)
+			if bucket == nil {
+				return fmt.Errorf("backend: bucket %s does not exist", string(op.bucket))
+			}
+			var err error
+			if op.delete {
+				err = bucket.Delete(op.key)
+			} else {
+				err = bucket.Put(op.key, op.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// preallocate reserves the disk space of the backend file ahead of its use
+// once the backend size comes within half a chunk of the preallocated end,
+// so the file grows into large contiguous extents instead of many small
+// ones. The file size is unchanged; where the platform cannot reserve space
+// without extending the file, nothing is reserved. It must be called
+// holding the lock on the batchTx.
+func (b *backend) preallocate(size int64) {
+	if b.preallocSize <= 0 || size+b.preallocSize/2 < b.preallocEnd {
+		return
+	}
+	end := ((size+b.preallocSize/2)/b.preallocSize + 1) * b.preallocSize
+	// do not retry on every commit if preallocation fails
+	b.preallocEnd = end
+
+	f, err := os.OpenFile(b.db.Path(), os.O_RDWR, 0600)
+	if err != nil {
+		plog.Warningf("cannot open database to preallocate (%v)", err)
+		return
+	}
+	defer f.Close()
+	if err = fileutil.Preallocate(f, end, false); err != nil {
+		plog.Warningf("cannot preallocate %d bytes for database (%v)", end, err)
+	}
+}
+
 func (b *backend) begin(write bool) *bolt.Tx {
 	b.mu.RLock()
 	tx := b.unsafeBegin(write)
 	b.mu.RUnlock()
-	atomic.StoreInt64(&b.size, tx.Size())
+	b.storeSize(tx)
 	return tx
 }
 
+// storeSize records the size of the database as of tx.
+func (b *backend) storeSize(tx *bolt.Tx) {
+	size := tx.Size()
+	db := tx.DB()
+	atomic.StoreInt64(&b.size, size)
+	atomic.StoreInt64(&b.sizeInUse, size-int64(db.Stats().FreePageN)*int64(db.Info().PageSize))
+}
+
 func (b *backend) unsafeBegin(write bool) *bolt.Tx {
 	tx, err := b.db.Begin(write)
 	if err != nil {
@@ -0,0 +1,112 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestBackendCommitFailure ensures a failed commit leaves the database at
+// its last commit while the writes since stay readable from the backend.
+func TestBackendCommitFailure(t *testing.T) {
+	b, tmpPath := NewTmpBackend(time.Hour, 10000)
+	defer os.Remove(tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("key"))
+	tx.UnsafePut([]byte("key"), []byte("foo"), []byte("bar"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	// fail the writes to the database file by swapping its descriptor for
+	// a read-only one
+	fd := dbFileFd(t, tmpPath)
+	ro, err := os.Open(tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if err = syscall.Dup3(int(ro.Fd()), fd, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.Lock()
+	tx.UnsafePut([]byte("key"), []byte("foo2"), []byte("bar2"))
+	tx.Unlock()
+	b.ForceCommit()
+	if b.Err() == nil {
+		t.Fatal("expected commit error")
+	}
+
+	// commits after the failure commit nothing but keep the writes readable
+	tx.Lock()
+	tx.UnsafePut([]byte("key"), []byte("foo3"), []byte("bar3"))
+	tx.Unlock()
+	b.ForceCommit()
+	rtx := b.ReadTx()
+	rtx.Lock()
+	for _, k := range []string{"foo", "foo2", "foo3"} {
+		if ks, _ := rtx.UnsafeRange([]byte("key"), []byte(k), nil, 0); len(ks) != 1 {
+			t.Errorf("%s not readable after failed commit", k)
+		}
+	}
+	rtx.Unlock()
+
+	if err = b.Defrag(); err == nil {
+		t.Errorf("defrag succeeded on failed backend")
+	}
+	if err = b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the writes before the failure are on disk
+	b = newBackend(BackendConfig{Path: tmpPath, BatchInterval: time.Hour, BatchLimit: 10000})
+	defer b.Close()
+	rtx = b.ReadTx()
+	rtx.Lock()
+	defer rtx.Unlock()
+	for k, want := range map[string]int{"foo": 1, "foo2": 0, "foo3": 0} {
+		if ks, _ := rtx.UnsafeRange([]byte("key"), []byte(k), nil, 0); len(ks) != want {
+			t.Errorf("got %d %s keys on disk, want %d", len(ks), k, want)
+		}
+	}
+}
+
+// dbFileFd returns the descriptor the backend opened the file at path with.
+func dbFileFd(t *testing.T, path string) int {
+	fds, err := ioutil.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, fd := range fds {
+		dst, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name()))
+		if err != nil || dst != path {
+			continue
+		}
+		var n int
+		if _, err := fmt.Sscanf(fd.Name(), "%d", &n); err == nil {
+			return n
+		}
+	}
+	t.Skipf("no descriptor open for %s", path)
+	return -1
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -176,6 +177,123 @@ func TestBackendDefrag(t *testing.T) {
 	b.ForceCommit()
 }
 
+// TestBackendDefragConcurrentWrites ensures writes made while the backend
+// is copied by a defrag are kept by the defragmented backend.
+func TestBackendDefragConcurrentWrites(t *testing.T) {
+	b, tmpPath := NewDefaultTmpBackend()
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	for i := 0; i < 2*defragLimit; i++ {
+		tx.UnsafePut([]byte("test"), []byte(fmt.Sprintf("foo_%d", i)), []byte("bar"))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+
+	donec := make(chan error)
+	go func() { donec <- b.Defrag() }()
+
+	n := 0
+	for done := false; !done; n++ {
+		select {
+		case err := <-donec:
+			if err != nil {
+				t.Fatal(err)
+			}
+			done = true
+		default:
+		}
+		tx.Lock()
+		tx.UnsafePut([]byte("test"), []byte(fmt.Sprintf("new_%d", n)), []byte("bar"))
+		tx.UnsafeDelete([]byte("test"), []byte(fmt.Sprintf("foo_%d", n)))
+		tx.Unlock()
+	}
+	b.ForceCommit()
+
+	tx.Lock()
+	defer tx.Unlock()
+	for i := 0; i < n; i++ {
+		if ks, _ := tx.UnsafeRange([]byte("test"), []byte(fmt.Sprintf("new_%d", i)), nil, 0); len(ks) != 1 {
+			t.Fatalf("key new_%d is missing after defrag", i)
+		}
+		if ks, _ := tx.UnsafeRange([]byte("test"), []byte(fmt.Sprintf("foo_%d", i)), nil, 0); len(ks) != 0 {
+			t.Fatalf("deleted key foo_%d is back after defrag", i)
+		}
+	}
+}
+
+func TestBackendPreallocate(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcd_backend_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bcfg := DefaultBackendConfig()
+	bcfg.Path, bcfg.PreallocSize = filepath.Join(dir, "database"), 1024*1024
+	b := newBackend(bcfg)
+	defer b.Close()
+
+	// preallocated on open
+	tx := b.BatchTx()
+	tx.Lock()
+	if b.preallocEnd != 1024*1024 {
+		t.Fatalf("preallocated end = %d, want %d", b.preallocEnd, 1024*1024)
+	}
+	tx.UnsafeCreateBucket([]byte("test"))
+	for i := 0; i < 1000; i++ {
+		tx.UnsafePut([]byte("test"), []byte(fmt.Sprintf("foo_%d", i)), make([]byte, 1024))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+
+	// extended ahead of the grown backend
+	tx.Lock()
+	defer tx.Unlock()
+	if size := b.Size(); b.preallocEnd < size+bcfg.PreallocSize/2 {
+		t.Fatalf("preallocated end = %d, want at least %d", b.preallocEnd, size+bcfg.PreallocSize/2)
+	}
+}
+
+// TestBackendDSync ensures a backend opened with DSync keeps its data across
+// defrag and reopen.
+func TestBackendDSync(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcd_backend_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bcfg := DefaultBackendConfig()
+	bcfg.Path, bcfg.DSync = filepath.Join(dir, "database"), true
+	b := newBackend(bcfg)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	if err = b.Defrag(); err != nil {
+		t.Fatal(err)
+	}
+	if b.bopts.OpenFile == nil {
+		t.Fatal("defrag dropped the dsync open option")
+	}
+	b.Close()
+
+	b = newBackend(bcfg)
+	defer b.Close()
+	tx = b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	_, vals := tx.UnsafeRange([]byte("test"), []byte("foo"), nil, 0)
+	if len(vals) != 1 || string(vals[0]) != "bar" {
+		t.Fatalf("vals = %q, want [bar]", vals)
+	}
+}
+
 // TestBackendWriteback ensures writes are stored to the read txn on write txn unlock.
 func TestBackendWriteback(t *testing.T) {
 	b, tmpPath := NewDefaultTmpBackend()
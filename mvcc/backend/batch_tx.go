@@ -49,6 +49,7 @@ func (t *batchTx) UnsafeCreateBucket(name []byte) {
 	if err != nil && err != bolt.ErrBucketExists {
 		plog.Fatalf("cannot create bucket %s (%v)", name, err)
 	}
+	t.backend.recordDefragOp(defragOp{createBucket: true, bucket: name})
 	t.pending++
 }
 
@@ -75,6 +76,7 @@ func (t *batchTx) unsafePut(bucketName []byte, key []byte, value []byte, seq boo
 	if err := bucket.Put(key, value); err != nil {
 		plog.Fatalf("cannot put key into bucket (%v)", err)
 	}
+	t.backend.recordDefragOp(defragOp{bucket: bucketName, key: key, value: value})
 	t.pending++
 }
 
@@ -118,6 +120,7 @@ func (t *batchTx) UnsafeDelete(bucketName []byte, key []byte) {
 	if err != nil {
 		plog.Fatalf("cannot delete key from bucket (%v)", err)
 	}
+	t.backend.recordDefragOp(defragOp{delete: true, bucket: bucketName, key: key})
 	t.pending++
 }
 
@@ -155,6 +158,16 @@ func (t *batchTx) Unlock() {
 }
 
 func (t *batchTx) commit(stop bool) {
+	if t.backend.Err() != nil {
+		// a commit failed; the writes since then are kept in the open tx
+		// but never committed, so the database stays at its last commit
+		t.pending = 0
+		if stop {
+			t.tx.Rollback()
+		}
+		return
+	}
+
 	// commit the last tx
 	if t.tx != nil {
 		if t.pending == 0 && !stop {
@@ -178,11 +191,17 @@ func (t *batchTx) commit(stop bool) {
 
 		t.pending = 0
 		if err != nil {
-			plog.Fatalf("cannot commit tx (%s)", err)
+			// bolt has rolled the tx back
+			t.backend.fail(err)
+			if !stop {
+				t.tx = t.backend.begin(true)
+			}
+			return
 		}
 	}
 	if !stop {
 		t.tx = t.backend.begin(true)
+		t.backend.preallocate(t.tx.Size())
 	}
 }
 
@@ -235,15 +254,26 @@ func (t *batchTxBuffered) commit(stop bool) {
 }
 
 func (t *batchTxBuffered) unsafeCommit(stop bool) {
+	if t.backend.Err() != nil && !stop {
+		// nothing is committed; keep reading the last commit and the
+		// buffered writes since
+		t.batchTx.commit(stop)
+		return
+	}
 	if t.backend.readTx.tx != nil {
 		if err := t.backend.readTx.tx.Rollback(); err != nil {
 			plog.Fatalf("cannot rollback tx (%s)", err)
 		}
-		t.backend.readTx.reset()
 	}
 
 	t.batchTx.commit(stop)
 
+	if t.backend.Err() == nil {
+		t.backend.readTx.reset()
+	} else {
+		// the buffer keeps the writes of the failed commit readable
+		t.backend.readTx.buckets = make(map[string]*bolt.Bucket)
+	}
 	if !stop {
 		t.backend.readTx.tx = t.backend.begin(false)
 	}
@@ -33,9 +33,19 @@ var (
 		// 10 ms -> 655 seconds
 		Buckets: prometheus.ExponentialBuckets(.01, 2, 17),
 	})
+
+	defragPauseDurations = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "disk",
+		Name:      "backend_defrag_pause_duration_seconds",
+		Help:      "The latency distribution of the write pauses of backend defrags.",
+		// 1 ms -> 16 seconds
+		Buckets: prometheus.ExponentialBuckets(.001, 2, 15),
+	})
 )
 
 func init() {
 	prometheus.MustRegister(commitDurations)
 	prometheus.MustRegister(snapshotDurations)
+	prometheus.MustRegister(defragPauseDurations)
 }
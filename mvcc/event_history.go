@@ -0,0 +1,90 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"time"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// eventHistory holds the events of the most recent revisions in memory, so
+// that watchers only a few revisions behind sync without reading the backend.
+// It is bounded by the number of events it holds and by their age; the
+// zero value holds nothing.
+type eventHistory struct {
+	maxEvents int
+	maxAge    time.Duration
+
+	// revs holds the events of consecutive revisions, oldest first.
+	revs []historyRev
+	// events is the number of events in revs.
+	events int
+	// first is the oldest revision from which the history holds every
+	// event, or 0 if it holds none yet.
+	first int64
+	// last is the latest revision added.
+	last int64
+}
+
+type historyRev struct {
+	rev int64
+	t   time.Time
+	evs []mvccpb.Event
+}
+
+// add appends the events of revision rev.
+func (h *eventHistory) add(rev int64, evs []mvccpb.Event, now time.Time) {
+	if h.maxEvents <= 0 {
+		return
+	}
+	if h.last != 0 && rev != h.last+1 {
+		// revisions were skipped; history before them can't be trusted
+		h.reset()
+	}
+	if h.first == 0 {
+		h.first = rev
+	}
+	h.revs = append(h.revs, historyRev{rev: rev, t: now, evs: evs})
+	h.events += len(evs)
+	h.last = rev
+
+	for len(h.revs) > 0 && (h.events > h.maxEvents || h.maxAge > 0 && now.Sub(h.revs[0].t) > h.maxAge) {
+		h.events -= len(h.revs[0].evs)
+		h.revs[0] = historyRev{}
+		h.revs = h.revs[1:]
+		h.first++
+	}
+}
+
+// since returns the events of revisions from minRev on, and false if the
+// history does not hold all of them.
+func (h *eventHistory) since(minRev int64) ([]mvccpb.Event, bool) {
+	if h.first == 0 || minRev < h.first {
+		return nil, false
+	}
+	if minRev > h.last {
+		return nil, true
+	}
+	var evs []mvccpb.Event
+	for _, hr := range h.revs[minRev-h.first:] {
+		evs = append(evs, hr.evs...)
+	}
+	return evs, true
+}
+
+func (h *eventHistory) reset() {
+	h.revs, h.events, h.first, h.last = nil, 0, 0, 0
+}
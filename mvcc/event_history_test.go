@@ -0,0 +1,93 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func historyEvents(rev int64, n int) []mvccpb.Event {
+	evs := make([]mvccpb.Event, n)
+	for i := range evs {
+		evs[i] = mvccpb.Event{Kv: &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: rev}}
+	}
+	return evs
+}
+
+func historyRevs(evs []mvccpb.Event) (revs []int64) {
+	for _, ev := range evs {
+		revs = append(revs, ev.Kv.ModRevision)
+	}
+	return revs
+}
+
+func TestEventHistory(t *testing.T) {
+	now := time.Now()
+	h := eventHistory{maxEvents: 4, maxAge: time.Minute}
+	if _, ok := h.since(1); ok {
+		t.Fatalf("empty history holds revision 1")
+	}
+
+	h.add(2, historyEvents(2, 1), now)
+	h.add(3, historyEvents(3, 2), now)
+	h.add(4, historyEvents(4, 1), now)
+	if evs, ok := h.since(3); !ok || len(evs) != 3 {
+		t.Fatalf("since(3) = %v, %v, want revisions [3 3 4]", historyRevs(evs), ok)
+	}
+	if _, ok := h.since(1); ok {
+		t.Fatalf("history holds revision 1 it never saw")
+	}
+
+	// over maxEvents; revision 2 and 3 are dropped
+	h.add(5, historyEvents(5, 2), now)
+	if _, ok := h.since(3); ok {
+		t.Fatalf("history holds dropped revision 3")
+	}
+	if evs, ok := h.since(4); !ok || len(evs) != 3 {
+		t.Fatalf("since(4) = %v, %v, want revisions [4 5 5]", historyRevs(evs), ok)
+	}
+	if evs, ok := h.since(6); !ok || len(evs) != 0 {
+		t.Fatalf("since(6) = %v, %v, want none, true", historyRevs(evs), ok)
+	}
+
+	// over maxAge; only revision 6 stays
+	h.add(6, historyEvents(6, 1), now.Add(2*time.Minute))
+	if evs, ok := h.since(6); !ok || len(evs) != 1 {
+		t.Fatalf("since(6) = %v, %v, want revisions [6]", historyRevs(evs), ok)
+	}
+	if _, ok := h.since(5); ok {
+		t.Fatalf("history holds expired revision 5")
+	}
+
+	// revisions skipped; the history restarts at the next revision
+	h.add(9, historyEvents(9, 1), now)
+	if _, ok := h.since(6); ok {
+		t.Fatalf("history holds revision 6 from before the gap")
+	}
+	if evs, ok := h.since(9); !ok || len(evs) != 1 {
+		t.Fatalf("since(9) = %v, %v, want revisions [9]", historyRevs(evs), ok)
+	}
+}
+
+func TestEventHistoryDisabled(t *testing.T) {
+	var h eventHistory
+	h.add(2, historyEvents(2, 1), time.Now())
+	if _, ok := h.since(2); ok {
+		t.Fatalf("disabled history holds revision 2")
+	}
+}
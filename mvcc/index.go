@@ -18,6 +18,8 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/coreos/etcd/pkg/logutil"
+
 	"github.com/google/btree"
 )
 
@@ -27,18 +29,28 @@ type index interface {
 	Revisions(key, end []byte, atRev int64) []revision
 	Put(key []byte, rev revision)
 	Tombstone(key []byte, rev revision) error
+	TombstoneRange(key, end []byte, rev revision) [][]byte
 	RangeSince(key, end []byte, rev int64) []revision
 	Compact(rev int64) map[revision]struct{}
 	Keep(rev int64) map[revision]struct{}
 	Equal(b index) bool
+	// Bytes returns the approximate memory held by the index.
+	Bytes() int64
 
 	Insert(ki *keyIndex)
 	KeyIndex(ki *keyIndex) *keyIndex
+
+	visit(key, end []byte, f func(ki *keyIndex))
+	// recount recomputes Bytes after keyIndexes in the index are changed
+	// directly, as on restore.
+	recount()
 }
 
 type treeIndex struct {
 	sync.RWMutex
 	tree *btree.BTree
+	// bytes is the sum of the bytes of the keyIndexes in the tree.
+	bytes int64
 }
 
 func newTreeIndex() index {
@@ -56,10 +68,13 @@ func (ti *treeIndex) Put(key []byte, rev revision) {
 	if item == nil {
 		keyi.put(rev.main, rev.sub)
 		ti.tree.ReplaceOrInsert(keyi)
+		ti.bytes += keyi.bytes()
 		return
 	}
 	okeyi := item.(*keyIndex)
+	ti.bytes -= okeyi.bytes()
 	okeyi.put(rev.main, rev.sub)
+	ti.bytes += okeyi.bytes()
 }
 
 func (ti *treeIndex) Get(key []byte, atRev int64) (modified, created revision, ver int64, err error) {
@@ -144,7 +159,51 @@ func (ti *treeIndex) Tombstone(key []byte, rev revision) error {
 	}
 
 	ki := item.(*keyIndex)
-	return ki.tombstone(rev.main, rev.sub)
+	ti.bytes -= ki.bytes()
+	err := ki.tombstone(rev.main, rev.sub)
+	ti.bytes += ki.bytes()
+	return err
+}
+
+// TombstoneRange tombstones every live key from key(including) to
+// end(excluding), or only key if end is nil, in a single pass over the
+// index. The keys are tombstoned in key order at consecutive revisions
+// starting from rev. It returns the tombstoned keys.
+func (ti *treeIndex) TombstoneRange(key, end []byte, rev revision) (keys [][]byte) {
+	keyi := &keyIndex{key: key}
+
+	ti.Lock()
+	defer ti.Unlock()
+
+	tombstone := func(ki *keyIndex) {
+		if ki.generations[len(ki.generations)-1].isEmpty() {
+			return
+		}
+		ti.bytes -= ki.bytes()
+		if err := ki.tombstone(rev.main, rev.sub); err != nil {
+			plog.Panicf("store.index: unexpected tombstone failure (%v)", err)
+		}
+		ti.bytes += ki.bytes()
+		keys = append(keys, ki.key)
+		rev.sub++
+	}
+
+	if end == nil {
+		if item := ti.tree.Get(keyi); item != nil {
+			tombstone(item.(*keyIndex))
+		}
+		return keys
+	}
+
+	endi := &keyIndex{key: end}
+	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
+		if len(endi.key) > 0 && !item.Less(endi) {
+			return false
+		}
+		tombstone(item.(*keyIndex))
+		return true
+	})
+	return keys
 }
 
 // RangeSince returns all revisions from key(including) to end(excluding)
@@ -183,7 +242,7 @@ func (ti *treeIndex) RangeSince(key, end []byte, rev int64) []revision {
 func (ti *treeIndex) Compact(rev int64) map[revision]struct{} {
 	available := make(map[revision]struct{})
 	var emptyki []*keyIndex
-	plog.Printf("store.index: compact %d", rev)
+	plog.Info("store.index: compact", logutil.Revision(rev))
 	// TODO: do not hold the lock for long time?
 	// This is probably OK. Compacting 10M keys takes O(10ms).
 	ti.Lock()
@@ -195,6 +254,7 @@ func (ti *treeIndex) Compact(rev int64) map[revision]struct{} {
 			plog.Panic("store.index: unexpected delete failure during compaction")
 		}
 	}
+	ti.unsafeRecount()
 	return available
 }
 
@@ -247,5 +307,28 @@ func (ti *treeIndex) Equal(bi index) bool {
 func (ti *treeIndex) Insert(ki *keyIndex) {
 	ti.Lock()
 	defer ti.Unlock()
-	ti.tree.ReplaceOrInsert(ki)
+	if item := ti.tree.ReplaceOrInsert(ki); item != nil {
+		ti.bytes -= item.(*keyIndex).bytes()
+	}
+	ti.bytes += ki.bytes()
+}
+
+func (ti *treeIndex) Bytes() int64 {
+	ti.RLock()
+	defer ti.RUnlock()
+	return ti.bytes
+}
+
+func (ti *treeIndex) recount() {
+	ti.Lock()
+	defer ti.Unlock()
+	ti.unsafeRecount()
+}
+
+func (ti *treeIndex) unsafeRecount() {
+	ti.bytes = 0
+	ti.tree.Ascend(func(i btree.Item) bool {
+		ti.bytes += i.(*keyIndex).bytes()
+		return true
+	})
 }
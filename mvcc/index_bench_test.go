@@ -0,0 +1,97 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkIndexRange1(b *testing.B)    { benchmarkIndexRange(b, 1) }
+func BenchmarkIndexRange1000(b *testing.B) { benchmarkIndexRange(b, 1000) }
+
+func benchmarkIndexRange(b *testing.B, n int) {
+	ti := newBenchIndex(100000)
+	key, end := benchIndexKey(50000), benchIndexKey(50000+n)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ti.Range(key, end, 100000)
+	}
+}
+
+func BenchmarkIndexTombstoneRange1(b *testing.B)    { benchmarkIndexTombstoneRange(b, 1) }
+func BenchmarkIndexTombstoneRange1000(b *testing.B) { benchmarkIndexTombstoneRange(b, 1000) }
+
+// benchmarkIndexTombstoneRange deletes and recreates n keys of a large
+// index through TombstoneRange.
+func benchmarkIndexTombstoneRange(b *testing.B, n int) {
+	ti := newBenchIndex(100000)
+	key, end := benchIndexKey(50000), benchIndexKey(50000+n)
+	keys, _ := ti.Range(key, end, 100000)
+	rev := int64(100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rev++
+		ti.TombstoneRange(key, end, revision{main: rev})
+		b.StopTimer()
+		rev++
+		for j, k := range keys {
+			ti.Put(k, revision{main: rev, sub: int64(j)})
+		}
+		b.StartTimer()
+	}
+}
+
+func BenchmarkIndexRangeTombstone1(b *testing.B)    { benchmarkIndexRangeTombstone(b, 1) }
+func BenchmarkIndexRangeTombstone1000(b *testing.B) { benchmarkIndexRangeTombstone(b, 1000) }
+
+// benchmarkIndexRangeTombstone deletes and recreates n keys of a large
+// index by ranging over them and tombstoning each key, for comparison
+// with TombstoneRange.
+func benchmarkIndexRangeTombstone(b *testing.B, n int) {
+	ti := newBenchIndex(100000)
+	key, end := benchIndexKey(50000), benchIndexKey(50000+n)
+	rev := int64(100000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rev++
+		keys, _ := ti.Range(key, end, rev)
+		for j, k := range keys {
+			ti.Tombstone(k, revision{main: rev, sub: int64(j)})
+		}
+		b.StopTimer()
+		rev++
+		for j, k := range keys {
+			ti.Put(k, revision{main: rev, sub: int64(j)})
+		}
+		b.StartTimer()
+	}
+}
+
+func newBenchIndex(n int) index {
+	ti := newTreeIndex()
+	for i := 0; i < n; i++ {
+		ti.Put(benchIndexKey(i), revision{main: int64(i + 1)})
+	}
+	return ti
+}
+
+func benchIndexKey(i int) []byte { return []byte(fmt.Sprintf("/registry/key%08d", i)) }
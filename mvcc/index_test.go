@@ -138,6 +138,49 @@ func TestIndexTombstone(t *testing.T) {
 	}
 }
 
+func TestIndexTombstoneRange(t *testing.T) {
+	allKeys := [][]byte{[]byte("foo"), []byte("foo1"), []byte("foo2"), []byte("foo3")}
+
+	tests := []struct {
+		key, end []byte
+		wkeys    [][]byte
+	}{
+		// single key that not found
+		{[]byte("bar"), nil, nil},
+		// single key that found
+		{[]byte("foo"), nil, allKeys[:1]},
+		// single key that is already deleted
+		{[]byte("foo3"), nil, nil},
+		// range keys, skip the deleted member
+		{[]byte("foo"), []byte("fop"), allKeys[:3]},
+		// range keys, return middle members
+		{[]byte("foo1"), []byte("foo3"), allKeys[1:3]},
+		// range keys, return nothing
+		{[]byte("foo4"), []byte("fop"), nil},
+	}
+	for i, tt := range tests {
+		ti := newTreeIndex()
+		for j := range allKeys {
+			ti.Put(allKeys[j], revision{main: int64(j + 1)})
+		}
+		ti.Tombstone(allKeys[3], revision{main: 5})
+
+		keys := ti.TombstoneRange(tt.key, tt.end, revision{main: 6, sub: 1})
+		if !reflect.DeepEqual(keys, tt.wkeys) {
+			t.Errorf("#%d: keys = %+v, want %+v", i, keys, tt.wkeys)
+		}
+		for j, key := range keys {
+			ki := ti.KeyIndex(&keyIndex{key: key})
+			if wrev := (revision{main: 6, sub: int64(j + 1)}); ki.modified != wrev {
+				t.Errorf("#%d: tombstone rev of %q = %+v, want %+v", i, key, ki.modified, wrev)
+			}
+			if _, _, _, err := ti.Get(key, 6); err != ErrRevisionNotFound {
+				t.Errorf("#%d: get %q error = %v, want %v", i, key, err, ErrRevisionNotFound)
+			}
+		}
+	}
+}
+
 func TestIndexRangeSince(t *testing.T) {
 	allKeys := [][]byte{[]byte("foo"), []byte("foo1"), []byte("foo2"), []byte("foo2"), []byte("foo1"), []byte("foo")}
 	allRevs := []revision{{main: 1}, {main: 2}, {main: 3}, {main: 4}, {main: 5}, {main: 6}}
@@ -276,6 +319,41 @@ func TestIndexCompactAndKeep(t *testing.T) {
 	}
 }
 
+func TestIndexBytes(t *testing.T) {
+	ti := newTreeIndex()
+	if n := ti.Bytes(); n != 0 {
+		t.Fatalf("bytes = %d, want 0", n)
+	}
+
+	ti.Put([]byte("foo"), revision{main: 2})
+	ti.Put([]byte("foo"), revision{main: 3})
+	ti.Put([]byte("foo1"), revision{main: 4})
+	ti.Tombstone([]byte("foo"), revision{main: 5})
+	ti.Put([]byte("foo"), revision{main: 6})
+	if n, w := ti.Bytes(), indexBytes(ti); n != w {
+		t.Errorf("bytes = %d, want %d", n, w)
+	}
+
+	ti.Compact(5)
+	if n, w := ti.Bytes(), indexBytes(ti); n != w {
+		t.Errorf("bytes after compaction = %d, want %d", n, w)
+	}
+
+	ti.recount()
+	if n, w := ti.Bytes(), indexBytes(ti); n != w {
+		t.Errorf("bytes after recount = %d, want %d", n, w)
+	}
+}
+
+func indexBytes(idx index) (n int64) {
+	ti := idx.(*treeIndex)
+	ti.tree.Ascend(func(item btree.Item) bool {
+		n += item.(*keyIndex).bytes()
+		return true
+	})
+	return n
+}
+
 func restore(ti *treeIndex, key []byte, created, modified revision, ver int64) {
 	keyi := &keyIndex{key: key}
 
@@ -72,6 +72,24 @@ type keyIndex struct {
 	generations []generation
 }
 
+// keyIndexOverhead, generationOverhead and revisionBytes approximate the
+// memory held by a keyIndex in the tree, by each of its generations, and
+// by each of their revisions.
+const (
+	keyIndexOverhead   = 96
+	generationOverhead = 48
+	revisionBytes      = 16
+)
+
+// bytes returns the approximate memory held by the keyIndex.
+func (ki *keyIndex) bytes() int64 {
+	n := int64(keyIndexOverhead + len(ki.key))
+	for _, g := range ki.generations {
+		n += generationOverhead + int64(len(g.revs))*revisionBytes
+	}
+	return n
+}
+
 // put puts a revision to the keyIndex.
 func (ki *keyIndex) put(main int64, sub int64) {
 	rev := revision{main: main, sub: sub}
@@ -15,6 +15,9 @@
 package mvcc
 
 import (
+	"context"
+	"time"
+
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/mvcc/mvccpb"
@@ -24,6 +27,10 @@ type RangeOptions struct {
 	Limit int64
 	Rev   int64
 	Count bool
+	// Index, if set, is the value index to range by; only the keys whose
+	// indexed field equals IndexValue are returned.
+	Index      string
+	IndexValue []byte
 }
 
 type RangeResult struct {
@@ -49,7 +56,8 @@ type ReadView interface {
 	// If `end` is not nil and empty, it gets the keys greater than or equal to key.
 	// Limit limits the number of keys returned.
 	// If the required rev is compacted, ErrCompacted will be returned.
-	Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error)
+	// If ctx is done before the keys are read, Range stops and returns its error.
+	Range(ctx context.Context, key, end []byte, ro RangeOptions) (r *RangeResult, err error)
 }
 
 // TxnRead represents a read-only transaction with operations that will not
@@ -76,6 +84,11 @@ type WriteView interface {
 	// A put also increases the rev of the store, and generates one event in the event history.
 	// The returned rev is the current revision of the KV when the operation is executed.
 	Put(key, value []byte, lease lease.LeaseID) (rev int64)
+
+	// PutWithExpiry puts the given key, value into the store like Put, and also
+	// stores the unix time in seconds at which the key expires. An expireAt of
+	// 0 means the key does not expire.
+	PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) (rev int64)
 }
 
 // TxnWrite represents a transaction that can modify the store.
@@ -93,10 +106,57 @@ func (trw *txnReadWrite) DeleteRange(key, end []byte) (n, rev int64) { panic("un
 func (trw *txnReadWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	panic("unexpected Put")
 }
+func (trw *txnReadWrite) PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) (rev int64) {
+	panic("unexpected PutWithExpiry")
+}
 func (trw *txnReadWrite) Changes() []mvccpb.KeyValue { return nil }
 
 func NewReadOnlyTxnWrite(txn TxnRead) TxnWrite { return &txnReadWrite{txn} }
 
+// txnDryRun evaluates writes against a read txn, recording the changes they
+// would make and the revision they would create without applying them.
+type txnDryRun struct {
+	TxnRead
+	changes []mvccpb.KeyValue
+}
+
+func (tdr *txnDryRun) DeleteRange(key, end []byte) (n, rev int64) {
+	rr, err := tdr.Range(context.TODO(), key, end, RangeOptions{})
+	if err == nil {
+		for _, kv := range rr.KVs {
+			tdr.changes = append(tdr.changes, mvccpb.KeyValue{Key: kv.Key})
+		}
+		n = int64(len(rr.KVs))
+	}
+	if len(tdr.changes) == 0 {
+		return 0, tdr.Rev()
+	}
+	return n, tdr.Rev() + 1
+}
+
+func (tdr *txnDryRun) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
+	return tdr.PutWithExpiry(key, value, lease, 0)
+}
+
+func (tdr *txnDryRun) PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) (rev int64) {
+	rev = tdr.Rev() + 1
+	tdr.changes = append(tdr.changes, mvccpb.KeyValue{
+		Key:         key,
+		Value:       value,
+		ModRevision: rev,
+		Lease:       int64(lease),
+		ExpireAt:    expireAt,
+	})
+	return rev
+}
+
+func (tdr *txnDryRun) Changes() []mvccpb.KeyValue { return tdr.changes }
+
+// NewDryRunTxnWrite coerces a read txn to a write that discards its writes.
+// Writes return the revision they would have created, and ranges see the
+// store as of the read txn, without the writes made before them.
+func NewDryRunTxnWrite(txn TxnRead) TxnWrite { return &txnDryRun{TxnRead: txn} }
+
 type KV interface {
 	ReadView
 	WriteView
@@ -113,6 +173,38 @@ type KV interface {
 	// HashByRev computes the hash of all MVCC revisions up to a given revision.
 	HashByRev(rev int64) (hash uint32, revision int64, compactRev int64, err error)
 
+	// Events returns an iterator over the events of revisions [startRev, endRev)
+	// in revision order, as a watcher on the whole keyspace would receive them.
+	// If startRev <= 0, it starts at the oldest revision not compacted; if
+	// endRev <= 0, it ends after the current revision.
+	Events(startRev, endRev int64) (EventIterator, error)
+
+	// SaveKeyIndex saves the in-memory key index to the backend so that
+	// restoring the KV only replays the revisions written after the save.
+	SaveKeyIndex()
+
+	// SetSizeTracker sets the tracker to account the keys written to the KV.
+	SetSizeTracker(t SizeTracker)
+
+	// SetRevokeTombstones sets the function telling if the tombstones written
+	// by lease revocations record the revoked lease. It must return the same
+	// on every member applying a given revision. If unset, they record it.
+	SetRevokeTombstones(enabled func() bool)
+
+	// SetValueIndexes sets the value indexes the KV maintains.
+	SetValueIndexes(vis []ValueIndex) error
+
+	// HasValueIndex reports whether the KV maintains the named value index.
+	HasValueIndex(name string) bool
+
+	// IndexBytes returns the approximate memory held by the key index.
+	IndexBytes() int64
+
+	// Expired returns up to limit keys that expire at or before the given
+	// unix time in seconds, earliest first. Only the Key, ModRevision and
+	// ExpireAt of the returned KeyValues are set.
+	Expired(now int64, limit int) []mvccpb.KeyValue
+
 	// Compact frees all superseded keys with revisions less than rev.
 	Compact(rev int64) (<-chan struct{}, error)
 
@@ -124,10 +216,43 @@ type KV interface {
 	Close() error
 }
 
+// SizeTracker accounts the number and size of the keys held by a KV
+// for the keys it tracks.
+type SizeTracker interface {
+	// Tracks returns true if the given key is accounted.
+	Tracks(key []byte) bool
+	// Add changes the accounted bytes and keys of the given key by the
+	// given amounts.
+	Add(key []byte, bytes, keys int64)
+}
+
 // WatchableKV is a KV that can be watched.
 type WatchableKV interface {
 	KV
 	Watchable
+
+	// SetWatcherLimits limits the number of watchers of the KV and of
+	// each of its watch streams; Watch returns ErrTooManyWatchers past
+	// them. 0 means no limit.
+	SetWatcherLimits(max, maxPerStream int)
+
+	// SetUnsyncedWatcherLimit makes Watch return a *BacklogError for
+	// watches on past revisions while max or more watchers are unsynced.
+	// 0 means no limit.
+	SetUnsyncedWatcherLimit(max int)
+
+	// WatchBufferBytes returns the approximate memory of the events held
+	// for slow watchers and in the event history.
+	WatchBufferBytes() int64
+
+	// Watchers returns the number of watchers of the KV.
+	Watchers() int
+
+	// SetEventHistory keeps up to maxEvents events of the latest revisions,
+	// none older than maxAge if it is positive, in memory so that watchers
+	// a few revisions behind sync without reading the backend. Setting it
+	// clears the history; maxEvents 0 disables it.
+	SetEventHistory(maxEvents int, maxAge time.Duration)
 }
 
 // Watchable is the interface that wraps the NewWatchStream function.
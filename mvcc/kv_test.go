@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -43,12 +44,12 @@ type (
 
 var (
 	normalRangeFunc = func(kv KV, key, end []byte, ro RangeOptions) (*RangeResult, error) {
-		return kv.Range(key, end, ro)
+		return kv.Range(context.TODO(), key, end, ro)
 	}
 	txnRangeFunc = func(kv KV, key, end []byte, ro RangeOptions) (*RangeResult, error) {
 		txn := kv.Read()
 		defer txn.End()
-		return txn.Range(key, end, ro)
+		return txn.Range(context.TODO(), key, end, ro)
 	}
 
 	normalPutFunc = func(kv KV, key, value []byte, lease lease.LeaseID) int64 {
@@ -262,7 +263,7 @@ func testKVPutMultipleTimes(t *testing.T, f putFunc) {
 			t.Errorf("#%d: rev = %d, want %d", i, rev, base+1)
 		}
 
-		r, err := s.Range([]byte("foo"), nil, RangeOptions{})
+		r, err := s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -366,7 +367,7 @@ func TestKVOperationInSequence(t *testing.T) {
 			t.Errorf("#%d: put rev = %d, want %d", i, rev, base+1)
 		}
 
-		r, err := s.Range([]byte("foo"), nil, RangeOptions{Rev: base + 1})
+		r, err := s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: base + 1})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -386,7 +387,7 @@ func TestKVOperationInSequence(t *testing.T) {
 			t.Errorf("#%d: n = %d, rev = %d, want (%d, %d)", i, n, rev, 1, base+2)
 		}
 
-		r, err = s.Range([]byte("foo"), nil, RangeOptions{Rev: base + 2})
+		r, err = s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: base + 2})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -432,6 +433,32 @@ func TestKVTxnBlockWriteOperations(t *testing.T) {
 	cleanup(s, b, tmpPath)
 }
 
+func TestKVRangeCanceled(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err := s.Range(ctx, []byte("foo"), []byte("foo3"), RangeOptions{}); err != context.Canceled {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+	txn := s.Read()
+	_, err := txn.Range(ctx, []byte("foo"), []byte("foo3"), RangeOptions{})
+	txn.End()
+	if err != context.Canceled {
+		t.Errorf("txn err = %v, want %v", err, context.Canceled)
+	}
+	// a count reads no keys and finishes regardless
+	r, err := s.Range(ctx, []byte("foo"), []byte("foo3"), RangeOptions{Count: true})
+	if err != nil || r.Count != 2 {
+		t.Errorf("count = %+v, %v, want 2, <nil>", r, err)
+	}
+}
+
 func TestKVTxnNonBlockRange(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 	s := NewStore(b, &lease.FakeLessor{}, nil)
@@ -443,7 +470,7 @@ func TestKVTxnNonBlockRange(t *testing.T) {
 	donec := make(chan struct{})
 	go func() {
 		defer close(donec)
-		s.Range([]byte("foo"), nil, RangeOptions{})
+		s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{})
 	}()
 	select {
 	case <-donec:
@@ -468,7 +495,7 @@ func TestKVTxnOperationInSequence(t *testing.T) {
 			t.Errorf("#%d: put rev = %d, want %d", i, rev, base+1)
 		}
 
-		r, err := txn.Range([]byte("foo"), nil, RangeOptions{Rev: base + 1})
+		r, err := txn.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: base + 1})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -488,7 +515,7 @@ func TestKVTxnOperationInSequence(t *testing.T) {
 			t.Errorf("#%d: n = %d, rev = %d, want (%d, %d)", i, n, rev, 1, base+1)
 		}
 
-		r, err = txn.Range([]byte("foo"), nil, RangeOptions{Rev: base + 1})
+		r, err = txn.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: base + 1})
 		if err != nil {
 			t.Errorf("#%d: range error (%v)", i, err)
 		}
@@ -503,6 +530,41 @@ func TestKVTxnOperationInSequence(t *testing.T) {
 	}
 }
 
+func TestKVDryRunTxnWrite(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+
+	txn := NewDryRunTxnWrite(s.Read())
+	if n, rev := txn.DeleteRange([]byte("zoo"), nil); n != 0 || rev != 3 {
+		t.Errorf("n = %d, rev = %d, want (0, 3)", n, rev)
+	}
+	if rev := txn.Put([]byte("foo2"), []byte("bar2"), lease.NoLease); rev != 4 {
+		t.Errorf("put rev = %d, want 4", rev)
+	}
+	if n, rev := txn.DeleteRange([]byte("foo"), []byte("fop")); n != 2 || rev != 4 {
+		t.Errorf("n = %d, rev = %d, want (2, 4)", n, rev)
+	}
+	if n := len(txn.Changes()); n != 3 {
+		t.Errorf("len(changes) = %d, want 3", n)
+	}
+	txn.End()
+
+	if rev := s.Rev(); rev != 3 {
+		t.Errorf("rev = %d, want 3", rev)
+	}
+	r, err := s.Range(context.TODO(), []byte("foo"), []byte("fop"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 2 {
+		t.Errorf("len(kvs) = %d, want 2", len(r.KVs))
+	}
+}
+
 func TestKVCompactReserveLastValue(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 	s := NewStore(b, &lease.FakeLessor{}, nil)
@@ -547,7 +609,7 @@ func TestKVCompactReserveLastValue(t *testing.T) {
 		if err != nil {
 			t.Errorf("#%d: unexpect compact error %v", i, err)
 		}
-		r, err := s.Range([]byte("foo"), nil, RangeOptions{Rev: tt.rev + 1})
+		r, err := s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: tt.rev + 1})
 		if err != nil {
 			t.Errorf("#%d: unexpect range error %v", i, err)
 		}
@@ -634,7 +696,7 @@ func TestKVRestore(t *testing.T) {
 		tt(s)
 		var kvss [][]mvccpb.KeyValue
 		for k := int64(0); k < 10; k++ {
-			r, _ := s.Range([]byte("a"), []byte("z"), RangeOptions{Rev: k})
+			r, _ := s.Range(context.TODO(), []byte("a"), []byte("z"), RangeOptions{Rev: k})
 			kvss = append(kvss, r.KVs)
 		}
 
@@ -652,7 +714,7 @@ func TestKVRestore(t *testing.T) {
 		testutil.WaitSchedule()
 		var nkvss [][]mvccpb.KeyValue
 		for k := int64(0); k < 10; k++ {
-			r, _ := ns.Range([]byte("a"), []byte("z"), RangeOptions{Rev: k})
+			r, _ := ns.Range(context.TODO(), []byte("a"), []byte("z"), RangeOptions{Rev: k})
 			nkvss = append(nkvss, r.KVs)
 		}
 		cleanup(ns, b, tmpPath)
@@ -696,7 +758,7 @@ func TestKVSnapshot(t *testing.T) {
 
 	ns := NewStore(b, &lease.FakeLessor{}, nil)
 	defer ns.Close()
-	r, err := ns.Range([]byte("a"), []byte("z"), RangeOptions{})
+	r, err := ns.Range(context.TODO(), []byte("a"), []byte("z"), RangeOptions{})
 	if err != nil {
 		t.Errorf("unexpect range error (%v)", err)
 	}
@@ -716,7 +778,7 @@ func TestWatchableKVWatch(t *testing.T) {
 	w := s.NewWatchStream()
 	defer w.Close()
 
-	wid := w.Watch([]byte("foo"), []byte("fop"), 0)
+	wid, _ := w.Watch([]byte("foo"), []byte("fop"), 0)
 
 	wev := []mvccpb.Event{
 		{Type: mvccpb.PUT,
@@ -783,7 +845,7 @@ func TestWatchableKVWatch(t *testing.T) {
 	}
 
 	w = s.NewWatchStream()
-	wid = w.Watch([]byte("foo1"), []byte("foo2"), 3)
+	wid, _ = w.Watch([]byte("foo1"), []byte("foo2"), 3)
 
 	select {
 	case resp := <-w.Chan():
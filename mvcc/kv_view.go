@@ -15,6 +15,8 @@
 package mvcc
 
 import (
+	"context"
+
 	"github.com/coreos/etcd/lease"
 )
 
@@ -32,10 +34,10 @@ func (rv *readView) Rev() int64 {
 	return tr.Rev()
 }
 
-func (rv *readView) Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
+func (rv *readView) Range(ctx context.Context, key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	tr := rv.kv.Read()
 	defer tr.End()
-	return tr.Range(key, end, ro)
+	return tr.Range(ctx, key, end, ro)
 }
 
 type writeView struct{ kv KV }
@@ -51,3 +53,9 @@ func (wv *writeView) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	defer tw.End()
 	return tw.Put(key, value, lease)
 }
+
+func (wv *writeView) PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) (rev int64) {
+	tw := wv.kv.Write()
+	defer tw.End()
+	return tw.PutWithExpiry(key, value, lease, expireAt)
+}
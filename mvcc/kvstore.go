@@ -27,6 +27,7 @@ import (
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/coreos/etcd/pkg/logutil"
 	"github.com/coreos/etcd/pkg/schedule"
 	"github.com/coreos/pkg/capnslog"
 )
@@ -83,6 +84,28 @@ type store struct {
 
 	le lease.Lessor
 
+	// sizeTracker, if set, accounts the keys written by write txns.
+	sizeTracker SizeTracker
+
+	// revokeTombstones, if set, tells if the tombstones written by lease
+	// revocations record the revoked lease.
+	revokeTombstones func() bool
+
+	// valueIndexes are the value indexes maintained by write txns, and
+	// valueIndexesSet whether they were set. They are set holding both mu
+	// and valueIdxMu.
+	valueIndexes    []ValueIndex
+	valueIndexesSet bool
+	// valueIdxMu protects valueIdx.
+	valueIdxMu sync.RWMutex
+	// valueIdx holds the indexed keys of each value index.
+	valueIdx map[string]*valueIndexState
+
+	// expiryMu protects expiries.
+	expiryMu sync.Mutex
+	// expiries maps the keys with an expiry to the unix time they expire at.
+	expiries map[string]int64
+
 	// revMuLock protects currentRev and compactMainRev.
 	// Locked at end of write txn and released after write txn unlock lock.
 	// Locked before locking read txn and released after locking.
@@ -91,6 +114,9 @@ type store struct {
 	currentRev int64
 	// compactMainRev is the main revision of the last compaction.
 	compactMainRev int64
+	// keyIndexRev is the revision the key index saved in the backend is
+	// current to, or 0 if it has not been saved.
+	keyIndexRev int64
 
 	// bytesBuf8 is a byte slice of length 8
 	// to avoid a repetitive allocation in saveIndex.
@@ -122,13 +148,14 @@ func NewStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter) *sto
 	s.ReadView = &readView{s}
 	s.WriteView = &writeView{s}
 	if s.le != nil {
-		s.le.SetRangeDeleter(func() lease.TxnDelete { return s.Write() })
+		s.le.SetRangeDeleter(func() lease.TxnDelete { return NewLeaseTxnWrite(s.Write()) })
 	}
 
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tx.UnsafeCreateBucket(keyBucketName)
 	tx.UnsafeCreateBucket(metaBucketName)
+	tx.UnsafeCreateBucket(expiryBucketName)
 	tx.Unlock()
 	s.b.ForceCommit()
 
@@ -236,11 +263,21 @@ func (s *store) Compact(rev int64) (<-chan struct{}, error) {
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tx.UnsafePut(metaBucketName, scheduledCompactKeyName, rbytes)
+	if s.keyIndexRev != 0 {
+		// the saved key index must not refer to revisions the compaction
+		// removes from the backend without the compaction being persisted.
+		s.saveKeyIndex(tx, s.currentRev)
+	}
 	tx.Unlock()
 	// ensure that desired compaction is persisted
 	s.b.ForceCommit()
 
 	keep := s.kvindex.Compact(rev)
+	if s.keyIndexRev != 0 {
+		tx.Lock()
+		s.sweepKeyIndex(tx)
+		tx.Unlock()
+	}
 	ch := make(chan struct{})
 	var j = func(ctx context.Context) {
 		if ctx.Err() != nil {
@@ -268,7 +305,36 @@ func init() {
 		// consistent index might be changed due to v2 internal sync, which
 		// is not controllable by the user.
 		{Bucket: string(metaBucketName), Key: string(consistentIndexKeyName)}: {},
+		// the saved key index depends on when each member last saved it.
+		{Bucket: string(metaBucketName), Key: string(keyIndexRevKeyName)}: {},
+		{Bucket: string(keyIndexBucketName)}:                              {},
+		// the value indexes depend on the configuration of each member.
+		{Bucket: string(valueIndexBucketName)}: {},
+	}
+}
+
+func (s *store) SetSizeTracker(t SizeTracker) {
+	s.mu.Lock()
+	s.sizeTracker = t
+	s.mu.Unlock()
+}
+
+func (s *store) SetRevokeTombstones(enabled func() bool) {
+	s.mu.Lock()
+	s.revokeTombstones = enabled
+	s.mu.Unlock()
+}
+
+func (s *store) HasValueIndex(name string) bool {
+	// not s.mu, which the txns calling it may already hold
+	s.valueIdxMu.RLock()
+	defer s.valueIdxMu.RUnlock()
+	for i := range s.valueIndexes {
+		if s.valueIndexes[i].Name == name {
+			return true
+		}
 	}
+	return false
 }
 
 func (s *store) Commit() {
@@ -282,6 +348,12 @@ func (s *store) Commit() {
 	s.b.ForceCommit()
 }
 
+func (s *store) IndexBytes() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.kvindex.Bytes()
+}
+
 func (s *store) Restore(b backend.Backend) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -294,6 +366,7 @@ func (s *store) Restore(b backend.Backend) error {
 	s.kvindex = newTreeIndex()
 	s.currentRev = 1
 	s.compactMainRev = -1
+	s.keyIndexRev = 0
 	s.fifoSched = schedule.NewFIFOScheduler()
 	s.stopc = make(chan struct{})
 
@@ -319,7 +392,7 @@ func (s *store) restore() error {
 	_, finishedCompactBytes := tx.UnsafeRange(metaBucketName, finishedCompactKeyName, nil, 0)
 	if len(finishedCompactBytes) != 0 {
 		s.compactMainRev = bytesToRev(finishedCompactBytes[0]).main
-		plog.Printf("restore compact to %d", s.compactMainRev)
+		plog.Info("restore compact", logutil.Revision(s.compactMainRev))
 	}
 	_, scheduledCompactBytes := tx.UnsafeRange(metaBucketName, scheduledCompactKeyName, nil, 0)
 	scheduledCompact := int64(0)
@@ -329,6 +402,11 @@ func (s *store) restore() error {
 
 	// index keys concurrently as they're loaded in from tx
 	keysGauge.Set(0)
+	// only replay the revisions after the saved key index, if any
+	keyIndexRev := s.loadKeyIndex(tx, keyToLease)
+	if keyIndexRev > 0 {
+		revToBytes(revision{main: keyIndexRev + 1}, min)
+	}
 	rkvc, revc := restoreIntoIndex(s.kvindex)
 	for {
 		keys, vals := tx.UnsafeRange(keyBucketName, min, max, int64(restoreChunkKeys))
@@ -349,6 +427,14 @@ func (s *store) restore() error {
 	}
 	close(rkvc)
 	s.currentRev = <-revc
+	s.kvindex.recount()
+	if s.currentRev < keyIndexRev {
+		s.currentRev = keyIndexRev
+	}
+	if keyIndexRev > 0 && s.compactMainRev > 0 {
+		// the saved key index may predate the last compaction
+		s.kvindex.Compact(s.compactMainRev)
+	}
 
 	// keys in the range [compacted revision -N, compaction] might all be deleted due to compaction.
 	// the correct revision should be set to compaction revision in the case, not the largest revision
@@ -370,6 +456,12 @@ func (s *store) restore() error {
 		}
 	}
 
+	tx.UnsafeCreateBucket(expiryBucketName)
+	s.restoreExpiries(tx)
+	if s.valueIndexesSet {
+		s.restoreValueIndexes(tx)
+	}
+
 	tx.Unlock()
 
 	if scheduledCompact != 0 {
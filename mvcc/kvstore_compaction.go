@@ -16,7 +16,10 @@ package mvcc
 
 import (
 	"encoding/binary"
+	"fmt"
 	"time"
+
+	"github.com/coreos/etcd/pkg/logutil"
 )
 
 func (s *store) scheduleCompaction(compactMainRev int64, keep map[revision]struct{}) bool {
@@ -51,7 +54,7 @@ func (s *store) scheduleCompaction(compactMainRev int64, keep map[revision]struc
 			revToBytes(revision{main: compactMainRev}, rbytes)
 			tx.UnsafePut(metaBucketName, finishedCompactKeyName, rbytes)
 			tx.Unlock()
-			plog.Printf("finished scheduled compaction at %d (took %v)", compactMainRev, time.Since(totalStart))
+			plog.Info(fmt.Sprintf("finished scheduled compaction (took %v)", time.Since(totalStart)), logutil.Revision(compactMainRev))
 			return true
 		}
 
@@ -0,0 +1,118 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import "github.com/coreos/etcd/mvcc/mvccpb"
+
+// eventBatchLimit is the number of revisions an EventIterator reads from
+// the backend at a time.
+var eventBatchLimit = int64(1000)
+
+// EventIterator iterates over the events of a range of revisions.
+type EventIterator interface {
+	// Next advances to the next event. It returns false when there are no
+	// more events or the iteration failed; Err tells the two apart.
+	Next() bool
+
+	// Event returns the event Next advanced to.
+	Event() mvccpb.Event
+
+	// Err returns the error that stopped the iteration, if any. It is
+	// ErrCompacted if the revisions left to read were compacted meanwhile.
+	Err() error
+}
+
+func (s *store) Events(startRev, endRev int64) (EventIterator, error) {
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+
+	if startRev <= 0 {
+		startRev = s.compactMainRev
+		if startRev < 1 {
+			startRev = 1
+		}
+	}
+	if startRev < s.compactMainRev {
+		return nil, ErrCompacted
+	}
+	if endRev <= 0 {
+		endRev = s.currentRev + 1
+	}
+	if endRev > s.currentRev+1 {
+		return nil, ErrFutureRev
+	}
+	return &eventIterator{s: s, next: revision{main: startRev}, end: endRev}, nil
+}
+
+type eventIterator struct {
+	s *store
+	// next is the revision the next batch starts at.
+	next revision
+	// end is the main revision the iteration stops before.
+	end int64
+
+	evs []mvccpb.Event
+	ev  mvccpb.Event
+	err error
+}
+
+func (it *eventIterator) Next() bool {
+	if len(it.evs) == 0 && it.err == nil && it.next.main < it.end {
+		it.evs, it.err = it.read()
+	}
+	if len(it.evs) == 0 {
+		return false
+	}
+	it.ev, it.evs = it.evs[0], it.evs[1:]
+	return true
+}
+
+func (it *eventIterator) Event() mvccpb.Event { return it.ev }
+
+func (it *eventIterator) Err() error { return it.err }
+
+// read reads the events of the next batch of revisions, the same way
+// syncWatchers reads the events unsynced watchers missed.
+func (it *eventIterator) read() ([]mvccpb.Event, error) {
+	minBytes, maxBytes := newRevBytes(), newRevBytes()
+	revToBytes(it.next, minBytes)
+	revToBytes(revision{main: it.end}, maxBytes)
+
+	tx := it.s.b.ReadTx()
+	tx.Lock()
+	revs, vs := tx.UnsafeRange(keyBucketName, minBytes, maxBytes, eventBatchLimit)
+	evs := make([]mvccpb.Event, 0, len(vs))
+	for i, v := range vs {
+		evs = append(evs, kvToEvent(revs[i], v))
+	}
+	tx.Unlock()
+
+	// compaction moves compactMainRev before it deletes any revision, so
+	// the batch is whole unless it started below the compacted revision.
+	it.s.revMu.RLock()
+	compacted := it.next.main < it.s.compactMainRev
+	it.s.revMu.RUnlock()
+	if compacted {
+		return nil, ErrCompacted
+	}
+
+	if int64(len(revs)) < eventBatchLimit {
+		it.next = revision{main: it.end}
+	} else {
+		last := bytesToRev(revs[len(revs)-1])
+		it.next = revision{main: last.main, sub: last.sub + 1}
+	}
+	return evs, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+type testEvent struct {
+	typ mvccpb.Event_EventType
+	key string
+	rev int64
+}
+
+func readEvents(t *testing.T, it EventIterator) []testEvent {
+	var evs []testEvent
+	for it.Next() {
+		ev := it.Event()
+		evs = append(evs, testEvent{ev.Type, string(ev.Kv.Key), ev.Kv.ModRevision})
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return evs
+}
+
+func TestStoreEvents(t *testing.T) {
+	defer func(l int64) { eventBatchLimit = l }(eventBatchLimit)
+	// split the deletes of revision 4 across batches
+	eventBatchLimit = 2
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("a"), []byte("1"), lease.NoLease)
+	s.Put([]byte("b"), []byte("2"), lease.NoLease)
+	s.Put([]byte("c"), []byte("3"), lease.NoLease)
+	s.DeleteRange([]byte("a"), []byte("d"))
+	s.Put([]byte("a"), []byte("4"), lease.NoLease)
+
+	all := []testEvent{
+		{mvccpb.PUT, "a", 2},
+		{mvccpb.PUT, "b", 3},
+		{mvccpb.PUT, "c", 4},
+		{mvccpb.DELETE, "a", 5},
+		{mvccpb.DELETE, "b", 5},
+		{mvccpb.DELETE, "c", 5},
+		{mvccpb.PUT, "a", 6},
+	}
+	tests := []struct {
+		start, end int64
+		wevs       []testEvent
+	}{
+		{0, 0, all},
+		{1, 7, all},
+		{3, 6, all[1:6]},
+		{5, 6, all[3:6]},
+		{6, 0, all[6:]},
+		{7, 0, nil},
+	}
+	for i, tt := range tests {
+		it, err := s.Events(tt.start, tt.end)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		evs := readEvents(t, it)
+		if len(evs) != len(tt.wevs) {
+			t.Fatalf("#%d: events = %v, want %v", i, evs, tt.wevs)
+		}
+		for j := range evs {
+			if evs[j] != tt.wevs[j] {
+				t.Fatalf("#%d: events = %v, want %v", i, evs, tt.wevs)
+			}
+		}
+	}
+
+	if _, err := s.Events(1, 8); err != ErrFutureRev {
+		t.Fatalf("err = %v, want %v", err, ErrFutureRev)
+	}
+}
+
+func TestStoreEventsCompacted(t *testing.T) {
+	defer func(l int64) { eventBatchLimit = l }(eventBatchLimit)
+	eventBatchLimit = 1
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	for i := 0; i < 4; i++ {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+
+	it, err := s.Events(2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() {
+		t.Fatalf("Next() = false, want true (err %v)", it.Err())
+	}
+
+	done, err := s.Compact(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+
+	if it.Next() {
+		t.Fatalf("Next() = true after compaction, want false")
+	}
+	if it.Err() != ErrCompacted {
+		t.Fatalf("err = %v, want %v", it.Err(), ErrCompacted)
+	}
+
+	if _, err = s.Events(3, 0); err != ErrCompacted {
+		t.Fatalf("err = %v, want %v", err, ErrCompacted)
+	}
+	it, err = s.Events(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evs := readEvents(t, it); len(evs) != 2 || evs[0].rev != 4 {
+		t.Fatalf("events = %v, want revisions 4 and 5", evs)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// expiryBucketName maps each key with an expiry to the unix time in
+// seconds it expires at, so the expiring keys can be found on restore
+// without reading every revision.
+var expiryBucketName = []byte("expiry")
+
+// setExpiry records the expiry of a key written in tx; an expireAt of 0
+// clears it. It must be called holding the lock on tx.
+func (s *store) setExpiry(tx backend.BatchTx, key []byte, expireAt int64) {
+	s.expiryMu.Lock()
+	defer s.expiryMu.Unlock()
+	if s.expiries[string(key)] == expireAt {
+		return
+	}
+	if expireAt == 0 {
+		delete(s.expiries, string(key))
+		tx.UnsafeDelete(expiryBucketName, key)
+		return
+	}
+	s.expiries[string(key)] = expireAt
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(expireAt))
+	tx.UnsafePut(expiryBucketName, key, v)
+}
+
+// restoreExpiries loads the expiries of the restored keys, dropping any
+// expiry that no longer matches the current revision of its key. It must
+// be called holding the lock on tx after the key index is restored.
+func (s *store) restoreExpiries(tx backend.BatchTx) {
+	expiries := make(map[string]int64)
+	var stale [][]byte
+	tx.UnsafeForEach(expiryBucketName, func(k, v []byte) error {
+		expireAt := int64(binary.BigEndian.Uint64(v))
+		if s.expiryOf(tx, k) != expireAt {
+			stale = append(stale, append([]byte(nil), k...))
+			return nil
+		}
+		expiries[string(k)] = expireAt
+		return nil
+	})
+	for _, k := range stale {
+		tx.UnsafeDelete(expiryBucketName, k)
+	}
+
+	s.expiryMu.Lock()
+	s.expiries = expiries
+	s.expiryMu.Unlock()
+}
+
+// expiryOf returns the expiry stored with the current revision of a key.
+func (s *store) expiryOf(tx backend.BatchTx, key []byte) int64 {
+	rev, _, _, err := s.kvindex.Get(key, s.currentRev)
+	if err != nil {
+		return 0
+	}
+	revBytes := newRevBytes()
+	revToBytes(rev, revBytes)
+	_, vs := tx.UnsafeRange(keyBucketName, revBytes, nil, 0)
+	if len(vs) != 1 {
+		return 0
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(vs[0]); err != nil {
+		plog.Fatalf("cannot unmarshal event: %v", err)
+	}
+	return kv.ExpireAt
+}
+
+func (s *store) Expired(now int64, limit int) []mvccpb.KeyValue {
+	s.expiryMu.Lock()
+	var kvs []mvccpb.KeyValue
+	for k, expireAt := range s.expiries {
+		if expireAt <= now {
+			kvs = append(kvs, mvccpb.KeyValue{Key: []byte(k), ExpireAt: expireAt})
+		}
+	}
+	s.expiryMu.Unlock()
+
+	sort.Slice(kvs, func(i, j int) bool {
+		if kvs[i].ExpireAt != kvs[j].ExpireAt {
+			return kvs[i].ExpireAt < kvs[j].ExpireAt
+		}
+		return string(kvs[i].Key) < string(kvs[j].Key)
+	})
+	if limit > 0 && len(kvs) > limit {
+		kvs = kvs[:limit]
+	}
+
+	s.revMu.RLock()
+	rev := s.currentRev
+	s.revMu.RUnlock()
+	expired := kvs[:0]
+	for _, kv := range kvs {
+		modified, _, _, err := s.kvindex.Get(kv.Key, rev)
+		if err != nil {
+			// deleted by a write not yet visible at rev
+			continue
+		}
+		kv.ModRevision = modified.main
+		expired = append(expired, kv)
+	}
+	return expired
+}
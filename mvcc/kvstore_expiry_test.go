@@ -0,0 +1,76 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func TestStoreExpired(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer os.Remove(tmpPath)
+
+	s0.PutWithExpiry([]byte("foo"), []byte("bar"), lease.NoLease, 20)
+	s0.PutWithExpiry([]byte("foo1"), []byte("bar"), lease.NoLease, 10)
+	s0.PutWithExpiry([]byte("foo2"), []byte("bar"), lease.NoLease, 10)
+	s0.PutWithExpiry([]byte("foo3"), []byte("bar"), lease.NoLease, 30)
+	// overwriting or deleting a key drops its expiry
+	s0.Put([]byte("foo2"), []byte("bar"), lease.NoLease)
+	s0.DeleteRange([]byte("foo3"), nil)
+
+	tests := []struct {
+		now   int64
+		limit int
+
+		wkvs []mvccpb.KeyValue
+	}{
+		{9, 0, nil},
+		{10, 0, []mvccpb.KeyValue{{Key: []byte("foo1"), ModRevision: 3, ExpireAt: 10}}},
+		{30, 0, []mvccpb.KeyValue{
+			{Key: []byte("foo1"), ModRevision: 3, ExpireAt: 10},
+			{Key: []byte("foo"), ModRevision: 2, ExpireAt: 20},
+		}},
+		{30, 1, []mvccpb.KeyValue{{Key: []byte("foo1"), ModRevision: 3, ExpireAt: 10}}},
+	}
+	for i, tt := range tests {
+		if kvs := s0.Expired(tt.now, tt.limit); !reflect.DeepEqual(kvs, tt.wkvs) {
+			t.Errorf("#%d: expired = %+v, want %+v", i, kvs, tt.wkvs)
+		}
+	}
+
+	r, err := s0.Range(context.TODO(), []byte("foo"), nil, RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.KVs[0].ExpireAt != 20 {
+		t.Errorf("expire at = %d, want 20", r.KVs[0].ExpireAt)
+	}
+	s0.Close()
+
+	// the expiries are restored with the store
+	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s1, b, tmpPath)
+	if !reflect.DeepEqual(s1.expiries, s0.expiries) {
+		t.Errorf("restored expiries = %v, want %v", s1.expiries, s0.expiries)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+var (
+	// keyIndexBucketName holds a snapshot of the in-memory key index,
+	// one entry per key, so a restart only needs to replay the revisions
+	// written after the snapshot instead of every revision in the backend.
+	keyIndexBucketName = []byte("keyIndex")
+	// keyIndexRevKeyName is the revision the saved key index is current to.
+	keyIndexRevKeyName = []byte("keyIndexRev")
+
+	errBadKeyIndex = errors.New("mvcc: bad encoded key index")
+)
+
+const keyIndexFormatV1 = 1
+
+// SaveKeyIndex writes the key index changes since the last save, or the
+// whole key index on the first save, to the backend.
+func (s *store) SaveKeyIndex() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx := s.b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+
+	s.revMu.RLock()
+	rev := s.currentRev
+	s.revMu.RUnlock()
+	s.saveKeyIndex(tx, rev)
+}
+
+// saveKeyIndex must be called holding s.mu and the lock on tx so that
+// neither writes nor compactions change the key index while it is saved.
+func (s *store) saveKeyIndex(tx backend.BatchTx, rev int64) {
+	if s.keyIndexRev >= rev {
+		return
+	}
+	tx.UnsafeCreateBucket(keyIndexBucketName)
+
+	if s.keyIndexRev == 0 {
+		// keys are visited in order; sequential puts keep the bucket dense
+		s.kvindex.visit([]byte{}, nil, func(ki *keyIndex) {
+			tx.UnsafeSeqPut(keyIndexBucketName, ki.key, encodeKeyIndex(ki, s.leaseOf(ki.key)))
+		})
+	} else {
+		for key := range changedKeys(tx, s.keyIndexRev+1, rev) {
+			kb := []byte(key)
+			ki := s.kvindex.KeyIndex(&keyIndex{key: kb})
+			if ki == nil {
+				tx.UnsafeDelete(keyIndexBucketName, kb)
+				continue
+			}
+			tx.UnsafePut(keyIndexBucketName, kb, encodeKeyIndex(ki, s.leaseOf(kb)))
+		}
+	}
+
+	rbytes := newRevBytes()
+	revToBytes(revision{main: rev}, rbytes)
+	tx.UnsafePut(metaBucketName, keyIndexRevKeyName, rbytes)
+	s.keyIndexRev = rev
+}
+
+// sweepKeyIndex deletes the saved entries of keys removed from the key
+// index by a compaction.
+func (s *store) sweepKeyIndex(tx backend.BatchTx) {
+	var removed [][]byte
+	tx.UnsafeForEach(keyIndexBucketName, func(k, v []byte) error {
+		if s.kvindex.KeyIndex(&keyIndex{key: k}) == nil {
+			removed = append(removed, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	for _, k := range removed {
+		tx.UnsafeDelete(keyIndexBucketName, k)
+	}
+}
+
+// loadKeyIndex inserts the saved key index into s.kvindex and returns the
+// revision it is current to, or 0 if there is no usable saved key index.
+func (s *store) loadKeyIndex(tx backend.BatchTx, keyToLease map[string]lease.LeaseID) int64 {
+	_, revBytes := tx.UnsafeRange(metaBucketName, keyIndexRevKeyName, nil, 0)
+	if len(revBytes) == 0 {
+		return 0
+	}
+	rev := bytesToRev(revBytes[0]).main
+
+	var (
+		kis    []*keyIndex
+		leases = make(map[string]lease.LeaseID)
+	)
+	err := tx.UnsafeForEach(keyIndexBucketName, func(k, v []byte) error {
+		ki, lid, err := decodeKeyIndex(k, v)
+		if err != nil {
+			return err
+		}
+		kis = append(kis, ki)
+		if lid != lease.NoLease && !ki.generations[len(ki.generations)-1].isEmpty() {
+			leases[string(ki.key)] = lid
+		}
+		return nil
+	})
+	if err != nil {
+		plog.Warningf("ignored saved key index at revision %d (%v)", rev, err)
+		return 0
+	}
+
+	for _, ki := range kis {
+		s.kvindex.Insert(ki)
+		if !ki.generations[len(ki.generations)-1].isEmpty() {
+			keysGauge.Inc()
+		}
+	}
+	for k, lid := range leases {
+		keyToLease[k] = lid
+	}
+	s.keyIndexRev = rev
+	return rev
+}
+
+func (s *store) leaseOf(key []byte) lease.LeaseID {
+	if s.le == nil {
+		return lease.NoLease
+	}
+	return s.le.GetLease(lease.LeaseItem{Key: string(key)})
+}
+
+// changedKeys returns the keys written in the revisions [min, max].
+func changedKeys(tx backend.BatchTx, min, max int64) map[string]struct{} {
+	minBytes, maxBytes := newRevBytes(), newRevBytes()
+	revToBytes(revision{main: min}, minBytes)
+	revToBytes(revision{main: max + 1}, maxBytes)
+
+	keys := make(map[string]struct{})
+	for {
+		revs, vals := tx.UnsafeRange(keyBucketName, minBytes, maxBytes, int64(restoreChunkKeys))
+		for _, v := range vals {
+			key, ok := unsafeKeyOf(v)
+			if !ok {
+				var kv mvccpb.KeyValue
+				if err := kv.Unmarshal(v); err != nil {
+					plog.Fatalf("cannot unmarshal event: %v", err)
+				}
+				key = kv.Key
+			}
+			keys[string(key)] = struct{}{}
+		}
+		if len(revs) < restoreChunkKeys {
+			return keys
+		}
+		next := bytesToRev(revs[len(revs)-1][:revBytesLen])
+		next.sub++
+		revToBytes(next, minBytes)
+	}
+}
+
+// encodeKeyIndex encodes the revisions of a keyIndex and the lease
+// attached to its key; the key itself is the bucket key.
+func encodeKeyIndex(ki *keyIndex, lid lease.LeaseID) []byte {
+	b := make([]byte, 1, 32)
+	b[0] = keyIndexFormatV1
+	b = appendVarint(b, int64(lid))
+	b = appendRevision(b, ki.modified)
+	b = appendVarint(b, int64(len(ki.generations)))
+	for _, g := range ki.generations {
+		b = appendVarint(b, g.ver)
+		b = appendRevision(b, g.created)
+		b = appendVarint(b, int64(len(g.revs)))
+		for _, r := range g.revs {
+			b = appendRevision(b, r)
+		}
+	}
+	return b
+}
+
+func decodeKeyIndex(key, b []byte) (*keyIndex, lease.LeaseID, error) {
+	if len(b) == 0 || b[0] != keyIndexFormatV1 {
+		return nil, lease.NoLease, errBadKeyIndex
+	}
+	d := keyIndexDecoder{b: b[1:]}
+	ki := &keyIndex{key: append([]byte(nil), key...)}
+	lid := lease.LeaseID(d.varint())
+	ki.modified = d.revision()
+	n := d.varint()
+	if n <= 0 || n > int64(len(d.b)) {
+		return nil, lease.NoLease, errBadKeyIndex
+	}
+	ki.generations = make([]generation, n)
+	for i := range ki.generations {
+		g := &ki.generations[i]
+		g.ver = d.varint()
+		g.created = d.revision()
+		nrevs := d.varint()
+		if nrevs < 0 || nrevs > int64(len(d.b)) {
+			return nil, lease.NoLease, errBadKeyIndex
+		}
+		if nrevs > 0 {
+			g.revs = make([]revision, nrevs)
+			for j := range g.revs {
+				g.revs[j] = d.revision()
+			}
+		}
+	}
+	if d.err || len(d.b) != 0 {
+		return nil, lease.NoLease, errBadKeyIndex
+	}
+	return ki, lid, nil
+}
+
+func appendVarint(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendRevision(b []byte, r revision) []byte {
+	return appendVarint(appendVarint(b, r.main), r.sub)
+}
+
+type keyIndexDecoder struct {
+	b   []byte
+	err bool
+}
+
+func (d *keyIndexDecoder) varint() int64 {
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err, d.b = true, nil
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *keyIndexDecoder) revision() revision {
+	return revision{main: d.varint(), sub: d.varint()}
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+)
+
+func TestStoreSaveKeyIndexRestore(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s0.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	s0.DeleteRange([]byte("foo"), nil)
+	s0.Put([]byte("foo"), []byte("bar2"), lease.NoLease)
+	s0.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+	s0.Put([]byte("foo2"), []byte("bar"), lease.NoLease)
+	s0.SaveKeyIndex()
+	savedRev := s0.currentRev
+
+	// changes after the save are replayed on restore
+	s0.Put([]byte("foo"), []byte("bar3"), lease.NoLease)
+	s0.DeleteRange([]byte("foo1"), nil)
+	s0.Put([]byte("foo3"), []byte("bar"), lease.NoLease)
+	s0.Close()
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s1, b, tmpPath)
+
+	if s1.keyIndexRev != savedRev {
+		t.Errorf("saved key index rev = %d, want %d", s1.keyIndexRev, savedRev)
+	}
+	if s1.currentRev != s0.currentRev {
+		t.Errorf("current rev = %d, want %d", s1.currentRev, s0.currentRev)
+	}
+	if !s0.kvindex.Equal(s1.kvindex) {
+		t.Errorf("restored key index differs from the saved store's key index")
+	}
+	r0, err := s0.Range(context.TODO(), []byte("foo"), []byte("foo9"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := s1.Range(context.TODO(), []byte("foo"), []byte("foo9"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r0.KVs, r1.KVs) {
+		t.Errorf("range = %+v, want %+v", r1.KVs, r0.KVs)
+	}
+}
+
+func TestStoreSaveKeyIndexCompact(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s0.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+	s0.SaveKeyIndex()
+
+	// the compaction saves the deletion and then drops the removed key
+	s0.DeleteRange([]byte("foo"), nil)
+	s0.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+	done, err := s0.Compact(s0.currentRev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+	if s0.keyIndexRev != s0.currentRev {
+		t.Errorf("saved key index rev = %d, want %d", s0.keyIndexRev, s0.currentRev)
+	}
+
+	var saved []string
+	tx := s0.b.BatchTx()
+	tx.Lock()
+	tx.UnsafeForEach(keyIndexBucketName, func(k, v []byte) error {
+		saved = append(saved, string(k))
+		return nil
+	})
+	tx.Unlock()
+	if wsaved := []string{"foo1"}; !reflect.DeepEqual(saved, wsaved) {
+		t.Errorf("saved keys = %v, want %v", saved, wsaved)
+	}
+	s0.Close()
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s1, b, tmpPath)
+
+	if !s0.kvindex.Equal(s1.kvindex) {
+		t.Errorf("restored key index differs from the saved store's key index")
+	}
+}
+
+func TestStoreSaveKeyIndexHash(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	h0, _, err := s.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SaveKeyIndex()
+	h1, _, err := s.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h0 != h1 {
+		t.Errorf("hash = %d, want %d", h1, h0)
+	}
+}
+
+func TestStoreRestoreBadKeyIndex(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s0.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+	s0.SaveKeyIndex()
+	tx := s0.b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(keyIndexBucketName, []byte("foo"), []byte{keyIndexFormatV1, 0xff})
+	tx.Unlock()
+	s0.Close()
+
+	// a bad saved key index falls back to replaying every revision
+	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s1, b, tmpPath)
+
+	if s1.keyIndexRev != 0 {
+		t.Errorf("saved key index rev = %d, want 0", s1.keyIndexRev)
+	}
+	if !s0.kvindex.Equal(s1.kvindex) {
+		t.Errorf("restored key index differs from the saved store's key index")
+	}
+}
+
+func TestKeyIndexEncoding(t *testing.T) {
+	ki := &keyIndex{
+		key:      []byte("foo"),
+		modified: revision{16, 0},
+		generations: []generation{
+			{created: revision{2, 0}, ver: 3, revs: []revision{{2, 0}, {4, 1}, {6, 0}}},
+			{created: revision{8, 0}, ver: 2, revs: []revision{{8, 0}, {16, 0}}},
+			{},
+		},
+	}
+	ki2, lid, err := decodeKeyIndex(ki.key, encodeKeyIndex(ki, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lid != 7 {
+		t.Errorf("lease = %d, want 7", lid)
+	}
+	if !ki.equal(ki2) {
+		t.Errorf("key index = %+v, want %+v", ki2, ki)
+	}
+}
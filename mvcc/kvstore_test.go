@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
@@ -207,7 +208,7 @@ func TestStoreRange(t *testing.T) {
 		b.tx.rangeRespc <- tt.r
 		fi.indexRangeRespc <- tt.idxr
 
-		ret, err := s.Range([]byte("foo"), []byte("goo"), ro)
+		ret, err := s.Range(context.TODO(), []byte("foo"), []byte("goo"), ro)
 		if err != nil {
 			t.Errorf("#%d: err = %v, want nil", i, err)
 		}
@@ -261,7 +262,6 @@ func TestStoreDeleteRange(t *testing.T) {
 
 		wkey    []byte
 		wrev    revision
-		wrrev   int64
 		wdelrev revision
 	}{
 		{
@@ -271,7 +271,6 @@ func TestStoreDeleteRange(t *testing.T) {
 
 			newTestKeyBytes(revision{3, 0}, true),
 			revision{3, 0},
-			2,
 			revision{3, 0},
 		},
 	}
@@ -302,8 +301,7 @@ func TestStoreDeleteRange(t *testing.T) {
 			t.Errorf("#%d: tx action = %+v, want %+v", i, g, wact)
 		}
 		wact = []testutil.Action{
-			{"range", []interface{}{[]byte("foo"), []byte("goo"), tt.wrrev}},
-			{"tombstone", []interface{}{[]byte("foo"), tt.wdelrev}},
+			{"tombstoneRange", []interface{}{[]byte("foo"), []byte("goo"), tt.wdelrev}},
 		}
 		if g := fi.Action(); !reflect.DeepEqual(g, wact) {
 			t.Errorf("#%d: index action = %+v, want %+v", i, g, wact)
@@ -378,6 +376,7 @@ func TestStoreRestore(t *testing.T) {
 	}
 	b.tx.rangeRespc <- rangeResp{[][]byte{finishedCompactKeyName}, [][]byte{newTestRevBytes(revision{3, 0})}}
 	b.tx.rangeRespc <- rangeResp{[][]byte{scheduledCompactKeyName}, [][]byte{newTestRevBytes(revision{3, 0})}}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
 
 	b.tx.rangeRespc <- rangeResp{[][]byte{putkey, delkey}, [][]byte{putkvb, delkvb}}
 	b.tx.rangeRespc <- rangeResp{nil, nil}
@@ -393,6 +392,7 @@ func TestStoreRestore(t *testing.T) {
 	wact := []testutil.Action{
 		{"range", []interface{}{metaBucketName, finishedCompactKeyName, []byte(nil), int64(0)}},
 		{"range", []interface{}{metaBucketName, scheduledCompactKeyName, []byte(nil), int64(0)}},
+		{"range", []interface{}{metaBucketName, keyIndexRevKeyName, []byte(nil), int64(0)}},
 		{"range", []interface{}{keyBucketName, newTestRevBytes(revision{1, 0}), newTestRevBytes(revision{math.MaxInt64, math.MaxInt64}), int64(restoreChunkKeys)}},
 	}
 	if g := b.tx.Action(); !reflect.DeepEqual(g, wact) {
@@ -449,7 +449,7 @@ func TestRestoreDelete(t *testing.T) {
 	defer s.Close()
 	for i := 0; i < 20; i++ {
 		ks := fmt.Sprintf("foo-%d", i)
-		r, err := s.Range([]byte(ks), nil, RangeOptions{})
+		r, err := s.Range(context.TODO(), []byte(ks), nil, RangeOptions{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -487,7 +487,7 @@ func TestRestoreContinueUnfinishedCompaction(t *testing.T) {
 	// wait for scheduled compaction to be finished
 	time.Sleep(100 * time.Millisecond)
 
-	if _, err := s1.Range([]byte("foo"), nil, RangeOptions{Rev: 1}); err != ErrCompacted {
+	if _, err := s1.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: 1}); err != ErrCompacted {
 		t.Errorf("range on compacted rev error = %v, want %v", err, ErrCompacted)
 	}
 	// check the key in backend is deleted
@@ -604,6 +604,88 @@ func TestTxnPut(t *testing.T) {
 	}
 }
 
+// fakeSizeTracker tracks the keys starting with "a".
+type fakeSizeTracker struct{ bytes, keys int64 }
+
+func (t *fakeSizeTracker) Tracks(key []byte) bool { return len(key) > 0 && key[0] == 'a' }
+func (t *fakeSizeTracker) Add(key []byte, bytes, keys int64) {
+	t.bytes += bytes
+	t.keys += keys
+}
+
+func TestStoreSizeTracker(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	st := &fakeSizeTracker{}
+	s.SetSizeTracker(st)
+
+	tests := []struct {
+		f func()
+
+		wbytes, wkeys int64
+	}{
+		{func() { s.Put([]byte("a1"), []byte("1234"), lease.NoLease) }, 6, 1},
+		{func() { s.Put([]byte("b1"), []byte("1234"), lease.NoLease) }, 6, 1},
+		{func() { s.Put([]byte("a1"), []byte("12"), lease.NoLease) }, 4, 1},
+		{func() { s.Put([]byte("a2"), []byte("123"), lease.NoLease) }, 9, 2},
+		{func() { s.DeleteRange([]byte("a"), []byte("c")) }, 0, 0},
+		{func() {
+			txn := s.Write()
+			txn.Put([]byte("a3"), []byte("1"), lease.NoLease)
+			txn.Put([]byte("a3"), []byte("123"), lease.NoLease)
+			txn.DeleteRange([]byte("a3"), nil)
+			txn.Put([]byte("a4"), nil, lease.NoLease)
+			txn.End()
+		}, 2, 1},
+	}
+	for i, tt := range tests {
+		tt.f()
+		if st.bytes != tt.wbytes || st.keys != tt.wkeys {
+			t.Errorf("#%d: usage = %d bytes %d keys, want %d bytes %d keys", i, st.bytes, st.keys, tt.wbytes, tt.wkeys)
+		}
+	}
+}
+
+// TestStoreRevokeTombstones ensures the tombstones of revoked keys only
+// record the lease, and so only change the hash, when enabled.
+func TestStoreRevokeTombstones(t *testing.T) {
+	hashAfter := func(del func(s *store, le lease.Lessor), enabled bool) uint32 {
+		b, tmpPath := backend.NewDefaultTmpBackend()
+		le := lease.NewLessor(b, 0)
+		defer le.Stop()
+		s := NewStore(b, le, nil)
+		defer cleanup(s, b, tmpPath)
+		s.SetRevokeTombstones(func() bool { return enabled })
+
+		if _, err := le.Grant(1, 1000); err != nil {
+			t.Fatal(err)
+		}
+		s.Put([]byte("foo"), []byte("bar"), 1)
+		del(s, le)
+		h, _, _, err := s.HashByRev(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	revoke := func(s *store, le lease.Lessor) {
+		if err := le.Revoke(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleteRange := func(s *store, le lease.Lessor) { s.DeleteRange([]byte("foo"), nil) }
+
+	wh := hashAfter(deleteRange, true)
+	if h := hashAfter(revoke, false); h != wh {
+		t.Errorf("hash with lease tombstones disabled = %d, want %d", h, wh)
+	}
+	if h := hashAfter(revoke, true); h == wh {
+		t.Errorf("hash with lease tombstones enabled = %d, want it to differ", h)
+	}
+}
+
 func TestTxnBlockBackendForceCommit(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 	s := NewStore(b, &lease.FakeLessor{}, nil)
@@ -712,9 +794,12 @@ func (b *fakeBackend) BatchTx() backend.BatchTx
 func (b *fakeBackend) ReadTx() backend.ReadTx                                      { return b.tx }
 func (b *fakeBackend) Hash(ignores map[backend.IgnoreKey]struct{}) (uint32, error) { return 0, nil }
 func (b *fakeBackend) Size() int64                                                 { return 0 }
+func (b *fakeBackend) SizeInUse() int64                                            { return 0 }
 func (b *fakeBackend) Snapshot() backend.Snapshot                                  { return nil }
 func (b *fakeBackend) ForceCommit()                                                {}
+func (b *fakeBackend) Commits() int64                                              { return 0 }
 func (b *fakeBackend) Defrag() error                                               { return nil }
+func (b *fakeBackend) Err() error                                                  { return nil }
 func (b *fakeBackend) Close() error                                                { return nil }
 
 type indexGetResp struct {
@@ -763,6 +848,11 @@ func (i *fakeIndex) Tombstone(key []byte, rev revision) error {
 	i.Recorder.Record(testutil.Action{Name: "tombstone", Params: []interface{}{key, rev}})
 	return nil
 }
+func (i *fakeIndex) TombstoneRange(key, end []byte, rev revision) [][]byte {
+	i.Recorder.Record(testutil.Action{Name: "tombstoneRange", Params: []interface{}{key, end, rev}})
+	r := <-i.indexRangeRespc
+	return r.keys
+}
 func (i *fakeIndex) RangeSince(key, end []byte, rev int64) []revision {
 	i.Recorder.Record(testutil.Action{Name: "rangeEvents", Params: []interface{}{key, end, rev}})
 	r := <-i.indexRangeEventsRespc
@@ -777,6 +867,8 @@ func (i *fakeIndex) Keep(rev int64) map[revision]struct{} {
 	return <-i.indexCompactRespc
 }
 func (i *fakeIndex) Equal(b index) bool { return false }
+func (i *fakeIndex) Bytes() int64       { return 0 }
+func (i *fakeIndex) recount()           {}
 
 func (i *fakeIndex) Insert(ki *keyIndex) {
 	i.Recorder.Record(testutil.Action{Name: "insert", Params: []interface{}{ki}})
@@ -787,6 +879,10 @@ func (i *fakeIndex) KeyIndex(ki *keyIndex) *keyIndex {
 	return nil
 }
 
+func (i *fakeIndex) visit(key, end []byte, f func(ki *keyIndex)) {
+	i.Recorder.Record(testutil.Action{Name: "visit", Params: []interface{}{key, end}})
+}
+
 func createBytesSlice(bytesN, sliceN int) [][]byte {
 	rs := [][]byte{}
 	for len(rs) != sliceN {
@@ -15,9 +15,13 @@
 package mvcc
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/coreos/etcd/pkg/logutil"
 )
 
 type storeTxnRead struct {
@@ -26,6 +30,10 @@ type storeTxnRead struct {
 
 	firstRev int64
 	rev      int64
+
+	// valueIdxChanges are the value index changes of a write txn, applied
+	// to the store at its end.
+	valueIdxChanges []valueIndexChange
 }
 
 func (s *store) Read() TxnRead {
@@ -35,14 +43,14 @@ func (s *store) Read() TxnRead {
 	tx.Lock()
 	firstRev, rev := s.compactMainRev, s.currentRev
 	s.revMu.RUnlock()
-	return newMetricsTxnRead(&storeTxnRead{s, tx, firstRev, rev})
+	return newMetricsTxnRead(&storeTxnRead{s, tx, firstRev, rev, nil})
 }
 
 func (tr *storeTxnRead) FirstRev() int64 { return tr.firstRev }
 func (tr *storeTxnRead) Rev() int64      { return tr.rev }
 
-func (tr *storeTxnRead) Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
-	return tr.rangeKeys(key, end, tr.Rev(), ro)
+func (tr *storeTxnRead) Range(ctx context.Context, key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
+	return tr.rangeKeys(ctx, key, end, tr.Rev(), ro)
 }
 
 func (tr *storeTxnRead) End() {
@@ -56,6 +64,8 @@ type storeTxnWrite struct {
 	// beginRev is the revision where the txn begins; it will write to the next revision.
 	beginRev int64
 	changes  []mvccpb.KeyValue
+	// revoking is set when the txn deletes the keys of revoked leases.
+	revoking bool
 }
 
 func (s *store) Write() TxnWrite {
@@ -63,7 +73,7 @@ func (s *store) Write() TxnWrite {
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tw := &storeTxnWrite{
-		storeTxnRead: storeTxnRead{s, tx, 0, 0},
+		storeTxnRead: storeTxnRead{s, tx, 0, 0, nil},
 		tx:           tx,
 		beginRev:     s.currentRev,
 		changes:      make([]mvccpb.KeyValue, 0, 4),
@@ -73,12 +83,12 @@ func (s *store) Write() TxnWrite {
 
 func (tw *storeTxnWrite) Rev() int64 { return tw.beginRev }
 
-func (tw *storeTxnWrite) Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
+func (tw *storeTxnWrite) Range(ctx context.Context, key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	rev := tw.beginRev
 	if len(tw.changes) > 0 {
 		rev++
 	}
-	return tw.rangeKeys(key, end, rev, ro)
+	return tw.rangeKeys(ctx, key, end, rev, ro)
 }
 
 func (tw *storeTxnWrite) DeleteRange(key, end []byte) (int64, int64) {
@@ -89,13 +99,18 @@ func (tw *storeTxnWrite) DeleteRange(key, end []byte) (int64, int64) {
 }
 
 func (tw *storeTxnWrite) Put(key, value []byte, lease lease.LeaseID) int64 {
-	tw.put(key, value, lease)
+	return tw.PutWithExpiry(key, value, lease, 0)
+}
+
+func (tw *storeTxnWrite) PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) int64 {
+	tw.put(key, value, lease, expireAt)
 	return int64(tw.beginRev + 1)
 }
 
 func (tw *storeTxnWrite) End() {
 	// only update index if the txn modifies the mvcc state.
 	if len(tw.changes) != 0 {
+		// gofail: var beforeSaveIndex struct{}
 		tw.s.saveIndex(tw.tx)
 		// hold revMu lock to prevent new read txns from opening until writeback.
 		tw.s.revMu.Lock()
@@ -103,12 +118,15 @@ func (tw *storeTxnWrite) End() {
 	}
 	tw.tx.Unlock()
 	if len(tw.changes) != 0 {
+		if len(tw.valueIdxChanges) != 0 {
+			tw.s.applyValueIndexChanges(tw.valueIdxChanges)
+		}
 		tw.s.revMu.Unlock()
 	}
 	tw.s.mu.RUnlock()
 }
 
-func (tr *storeTxnRead) rangeKeys(key, end []byte, curRev int64, ro RangeOptions) (*RangeResult, error) {
+func (tr *storeTxnRead) rangeKeys(ctx context.Context, key, end []byte, curRev int64, ro RangeOptions) (*RangeResult, error) {
 	rev := ro.Rev
 	if rev > curRev {
 		return &RangeResult{KVs: nil, Count: -1, Rev: curRev}, ErrFutureRev
@@ -119,6 +137,9 @@ func (tr *storeTxnRead) rangeKeys(key, end []byte, curRev int64, ro RangeOptions
 	if rev < tr.s.compactMainRev {
 		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
 	}
+	if ro.Index != "" {
+		return tr.rangeValueIndex(ctx, key, end, curRev, ro)
+	}
 
 	revpairs := tr.s.kvindex.Revisions(key, end, int64(rev))
 	if len(revpairs) == 0 {
@@ -136,6 +157,11 @@ func (tr *storeTxnRead) rangeKeys(key, end []byte, curRev int64, ro RangeOptions
 	kvs := make([]mvccpb.KeyValue, limit)
 	revBytes := newRevBytes()
 	for i, revpair := range revpairs[:len(kvs)] {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
 		revToBytes(revpair, revBytes)
 		_, vs := tr.tx.UnsafeRange(keyBucketName, revBytes, nil, 0)
 		if len(vs) != 1 {
@@ -148,18 +174,25 @@ func (tr *storeTxnRead) rangeKeys(key, end []byte, curRev int64, ro RangeOptions
 	return &RangeResult{KVs: kvs, Count: len(revpairs), Rev: curRev}, nil
 }
 
-func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
+func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID, expireAt int64) {
 	rev := tw.beginRev + 1
 	c := rev
 	oldLease := lease.NoLease
 
 	// if the key exists before, use its previous created and
 	// get its previous leaseID
-	_, created, ver, err := tw.s.kvindex.Get(key, rev)
+	modified, created, ver, err := tw.s.kvindex.Get(key, rev)
 	if err == nil {
 		c = created.main
 		oldLease = tw.s.le.GetLease(lease.LeaseItem{Key: string(key)})
 	}
+	if t := tw.s.sizeTracker; t != nil && t.Tracks(key) {
+		if err == nil {
+			t.Add(key, int64(len(value))-tw.valueSize(modified), 0)
+		} else {
+			t.Add(key, int64(len(key)+len(value)), 1)
+		}
+	}
 
 	ibytes := newRevBytes()
 	idxRev := revision{main: rev, sub: int64(len(tw.changes))}
@@ -173,6 +206,7 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		ModRevision:    rev,
 		Version:        ver,
 		Lease:          int64(leaseID),
+		ExpireAt:       expireAt,
 	}
 
 	d, err := kv.Marshal()
@@ -183,6 +217,8 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 	tw.tx.UnsafeSeqPut(keyBucketName, ibytes, d)
 	tw.s.kvindex.Put(key, idxRev)
 	tw.changes = append(tw.changes, kv)
+	tw.s.setExpiry(tw.tx, key, expireAt)
+	tw.updateValueIndexes(key, value)
 
 	if oldLease != lease.NoLease {
 		if tw.s.le == nil {
@@ -205,27 +241,39 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 }
 
 func (tw *storeTxnWrite) deleteRange(key, end []byte) int64 {
-	rrev := tw.beginRev
-	if len(tw.changes) > 0 {
-		rrev += 1
-	}
-	keys, revs := tw.s.kvindex.Range(key, end, rrev)
-	if len(keys) == 0 {
-		return 0
+	// the index tombstones the keys at the revisions delete writes them at
+	idxRev := revision{main: tw.beginRev + 1, sub: int64(len(tw.changes))}
+	if t := tw.s.sizeTracker; t != nil {
+		keys, revs := tw.s.kvindex.Range(key, end, idxRev.main)
+		for i := range keys {
+			if t.Tracks(keys[i]) {
+				t.Add(keys[i], -int64(len(keys[i]))-tw.valueSize(revs[i]), -1)
+			}
+		}
 	}
-	for i, key := range keys {
-		tw.delete(key, revs[i])
+	keys := tw.s.kvindex.TombstoneRange(key, end, idxRev)
+	for _, key := range keys {
+		tw.delete(key)
 	}
 	return int64(len(keys))
 }
 
-func (tw *storeTxnWrite) delete(key []byte, rev revision) {
+// delete writes the tombstone of a key already tombstoned in the index.
+func (tw *storeTxnWrite) delete(key []byte) {
 	ibytes := newRevBytes()
 	idxRev := revision{main: tw.beginRev + 1, sub: int64(len(tw.changes))}
 	revToBytes(idxRev, ibytes)
 	ibytes = appendMarkTombstone(ibytes)
 
+	item := lease.LeaseItem{Key: string(key)}
+	leaseID := tw.s.le.GetLease(item)
+
 	kv := mvccpb.KeyValue{Key: key}
+	if tw.revoking && (tw.s.revokeTombstones == nil || tw.s.revokeTombstones()) {
+		// the tombstone keeps the revoked lease so the delete
+		// event can be told apart from a requested delete
+		kv.Lease = int64(leaseID)
+	}
 
 	d, err := kv.Marshal()
 	if err != nil {
@@ -233,14 +281,9 @@ func (tw *storeTxnWrite) delete(key []byte, rev revision) {
 	}
 
 	tw.tx.UnsafeSeqPut(keyBucketName, ibytes, d)
-	err = tw.s.kvindex.Tombstone(key, idxRev)
-	if err != nil {
-		plog.Fatalf("cannot tombstone an existing key (%s): %v", string(key), err)
-	}
 	tw.changes = append(tw.changes, kv)
-
-	item := lease.LeaseItem{Key: string(key)}
-	leaseID := tw.s.le.GetLease(item)
+	tw.s.setExpiry(tw.tx, key, 0)
+	tw.updateValueIndexes(key, nil)
 
 	if leaseID != lease.NoLease {
 		err = tw.s.le.Detach(leaseID, []lease.LeaseItem{item})
@@ -251,3 +294,52 @@ func (tw *storeTxnWrite) delete(key []byte, rev revision) {
 }
 
 func (tw *storeTxnWrite) Changes() []mvccpb.KeyValue { return tw.changes }
+
+// valueSize returns the size of the value a key was written with at a revision.
+func (tw *storeTxnWrite) valueSize(rev revision) int64 {
+	revBytes := newRevBytes()
+	revToBytes(rev, revBytes)
+	_, vs := tw.tx.UnsafeRange(keyBucketName, revBytes, nil, 0)
+	if len(vs) != 1 {
+		plog.Fatalf("range cannot find rev (%d,%d)", rev.main, rev.sub)
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(vs[0]); err != nil {
+		plog.Fatalf("cannot unmarshal event: %v", err)
+	}
+	return int64(len(kv.Value))
+}
+
+// leaseTxnWrite is the TxnWrite a lessor uses to delete the keys of a
+// revoked lease or to detach the keys from it.
+type leaseTxnWrite struct{ TxnWrite }
+
+// NewLeaseTxnWrite wraps a TxnWrite for a lessor to revoke leases with.
+func NewLeaseTxnWrite(tw TxnWrite) lease.TxnDelete {
+	if stw := unwrapTxnWrite(tw); stw != nil {
+		stw.revoking = true
+	}
+	return &leaseTxnWrite{tw}
+}
+
+// unwrapTxnWrite returns the store txn under the given TxnWrite, if any.
+func unwrapTxnWrite(tw TxnWrite) *storeTxnWrite {
+	switch t := tw.(type) {
+	case *storeTxnWrite:
+		return t
+	case *metricsTxnWrite:
+		return unwrapTxnWrite(t.TxnWrite)
+	case *watchableStoreTxnWrite:
+		return unwrapTxnWrite(t.TxnWrite)
+	}
+	return nil
+}
+
+func (tw *leaseTxnWrite) DetachLease(key []byte) int64 {
+	r, err := tw.Range(context.TODO(), key, nil, RangeOptions{})
+	if err != nil || len(r.KVs) == 0 {
+		plog.Error(fmt.Sprintf("cannot find the key to detach from its lease (%v)", err), logutil.KeyPrefixHash(key))
+		return 0
+	}
+	return tw.PutWithExpiry(key, r.KVs[0].Value, lease.NoLease, r.KVs[0].ExpireAt)
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+// valueIndexBucketName maps each indexed key, prefixed by the name of its
+// index and a NUL byte, to its indexed field. A NUL byte followed by the
+// name of an index maps to the spec and prefix the index was built with.
+var valueIndexBucketName = []byte("valueIndex")
+
+// valueIndexChange is a change of the indexed field of a key, written to the
+// backend by a write txn and applied to the store at its end.
+type valueIndexChange struct {
+	name string
+	key  string
+	// field is the indexed field of the key, if ok is set.
+	field string
+	ok    bool
+}
+
+// valueIndexState holds the indexed keys of a value index.
+type valueIndexState struct {
+	// fields maps each indexed key to its field.
+	fields map[string]string
+	// keys maps each field to its keys.
+	keys map[string]map[string]struct{}
+}
+
+func newValueIndexState() *valueIndexState {
+	return &valueIndexState{fields: make(map[string]string), keys: make(map[string]map[string]struct{})}
+}
+
+func (vs *valueIndexState) set(key, field string, ok bool) {
+	if old, had := vs.fields[key]; had {
+		delete(vs.keys[old], key)
+		if len(vs.keys[old]) == 0 {
+			delete(vs.keys, old)
+		}
+		delete(vs.fields, key)
+	}
+	if !ok {
+		return
+	}
+	vs.fields[key] = field
+	if vs.keys[field] == nil {
+		vs.keys[field] = make(map[string]struct{})
+	}
+	vs.keys[field][key] = struct{}{}
+}
+
+func valueIndexEntryKey(name string, key []byte) []byte {
+	k := make([]byte, 0, len(name)+1+len(key))
+	k = append(append(append(k, name...), 0), key...)
+	return k
+}
+
+func valueIndexSpecKey(name string) []byte { return append([]byte{0}, name...) }
+
+func valueIndexSpec(vi *ValueIndex) []byte {
+	return append(append([]byte(vi.Spec), 0), vi.Prefix...)
+}
+
+// SetValueIndexes sets the value indexes the store maintains, loading those
+// saved in the backend with the same spec and prefix, rebuilding the others
+// from the current keys and dropping the saved indexes no longer set. A store
+// maintains no value index until it is called, so it must be called, even
+// with none, before writing to a backend that may hold saved indexes.
+func (s *store) SetValueIndexes(vis []ValueIndex) error {
+	names := make(map[string]struct{})
+	for i := range vis {
+		if err := vis[i].validate(); err != nil {
+			return err
+		}
+		if _, ok := names[vis[i].Name]; ok {
+			return fmt.Errorf("mvcc: duplicate value index %q", vis[i].Name)
+		}
+		names[vis[i].Name] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valueIdxMu.Lock()
+	s.valueIndexes, s.valueIndexesSet = vis, true
+	s.valueIdxMu.Unlock()
+	tx := s.b.BatchTx()
+	tx.Lock()
+	s.restoreValueIndexes(tx)
+	tx.Unlock()
+	s.b.ForceCommit()
+	return nil
+}
+
+// restoreValueIndexes loads the value indexes saved in the backend and
+// brings the backend in line with the set value indexes. It must be called
+// holding the lock on tx after the key index is restored.
+func (s *store) restoreValueIndexes(tx backend.BatchTx) {
+	tx.UnsafeCreateBucket(valueIndexBucketName)
+	want := make(map[string]*ValueIndex)
+	for i := range s.valueIndexes {
+		want[s.valueIndexes[i].Name] = &s.valueIndexes[i]
+	}
+
+	states := make(map[string]*valueIndexState)
+	var stale [][]byte
+	// the spec keys sort before the entries, which start with a name
+	tx.UnsafeForEach(valueIndexBucketName, func(k, v []byte) error {
+		if k[0] == 0 {
+			if vi, ok := want[string(k[1:])]; ok && bytes.Equal(v, valueIndexSpec(vi)) {
+				states[vi.Name] = newValueIndexState()
+			} else {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+			return nil
+		}
+		i := bytes.IndexByte(k, 0)
+		if vs, ok := states[string(k[:i])]; ok {
+			vs.set(string(k[i+1:]), string(v), true)
+		} else {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	for _, k := range stale {
+		tx.UnsafeDelete(valueIndexBucketName, k)
+	}
+
+	for name, vi := range want {
+		if _, ok := states[name]; ok {
+			continue
+		}
+		plog.Infof("building value index %q", name)
+		vs := newValueIndexState()
+		keys, revs := s.kvindex.Range(vi.Prefix, prefixEnd(vi.Prefix), s.currentRev)
+		for i := range keys {
+			kv := readKeyValue(tx, revs[i])
+			if field, ok := vi.Extract(kv.Value); ok {
+				vs.set(string(keys[i]), string(field), true)
+				tx.UnsafePut(valueIndexBucketName, valueIndexEntryKey(name, keys[i]), field)
+			}
+		}
+		tx.UnsafePut(valueIndexBucketName, valueIndexSpecKey(name), valueIndexSpec(vi))
+		states[name] = vs
+	}
+
+	s.valueIdxMu.Lock()
+	s.valueIdx = states
+	s.valueIdxMu.Unlock()
+}
+
+// prefixEnd returns the end of the range of the keys with prefix p.
+func prefixEnd(p []byte) []byte {
+	end := append([]byte(nil), p...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// all keys
+	return []byte{}
+}
+
+// readKeyValue reads the key-value written at rev.
+func readKeyValue(tx backend.BatchTx, rev revision) mvccpb.KeyValue {
+	revBytes := newRevBytes()
+	revToBytes(rev, revBytes)
+	_, vs := tx.UnsafeRange(keyBucketName, revBytes, nil, 0)
+	if len(vs) != 1 {
+		plog.Fatalf("range cannot find rev (%d,%d)", rev.main, rev.sub)
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(vs[0]); err != nil {
+		plog.Fatalf("cannot unmarshal event: %v", err)
+	}
+	return kv
+}
+
+// updateValueIndexes writes the indexed fields of a key written with value,
+// or deleted if value is nil.
+func (tw *storeTxnWrite) updateValueIndexes(key, value []byte) {
+	for i := range tw.s.valueIndexes {
+		vi := &tw.s.valueIndexes[i]
+		if !vi.indexes(key) {
+			continue
+		}
+		c := valueIndexChange{name: vi.Name, key: string(key)}
+		ek := valueIndexEntryKey(vi.Name, key)
+		var field []byte
+		if value != nil {
+			field, c.ok = vi.Extract(value)
+		}
+		if c.ok {
+			c.field = string(field)
+			tw.tx.UnsafePut(valueIndexBucketName, ek, field)
+		} else {
+			tw.tx.UnsafeDelete(valueIndexBucketName, ek)
+		}
+		tw.valueIdxChanges = append(tw.valueIdxChanges, c)
+	}
+}
+
+// applyValueIndexChanges applies the value index changes of a write txn. It
+// is called at the end of the txn while no read txn is open, so read txns
+// see the value indexes as of their revision.
+func (s *store) applyValueIndexChanges(cs []valueIndexChange) {
+	s.valueIdxMu.Lock()
+	defer s.valueIdxMu.Unlock()
+	for _, c := range cs {
+		if vs := s.valueIdx[c.name]; vs != nil {
+			vs.set(c.key, c.field, c.ok)
+		}
+	}
+}
+
+// rangeValueIndex gets the keys in the range whose field of the value index
+// ro.Index equals ro.IndexValue at the current revision.
+func (tr *storeTxnRead) rangeValueIndex(ctx context.Context, key, end []byte, curRev int64, ro RangeOptions) (*RangeResult, error) {
+	if ro.Rev > 0 && ro.Rev != curRev {
+		return &RangeResult{KVs: nil, Count: -1, Rev: curRev}, ErrValueIndexRev
+	}
+	var vi *ValueIndex
+	for i := range tr.s.valueIndexes {
+		if tr.s.valueIndexes[i].Name == ro.Index {
+			vi = &tr.s.valueIndexes[i]
+		}
+	}
+	if vi == nil {
+		return &RangeResult{KVs: nil, Count: -1, Rev: curRev}, ErrValueIndexNotFound
+	}
+
+	field := string(ro.IndexValue)
+	var keys []string
+	tr.s.valueIdxMu.RLock()
+	if vs := tr.s.valueIdx[ro.Index]; vs != nil {
+		for k := range vs.keys[field] {
+			keys = append(keys, k)
+		}
+	}
+	tr.s.valueIdxMu.RUnlock()
+	// keys the enclosing write txn indexed with the field
+	for _, c := range tr.valueIdxChanges {
+		if c.name == ro.Index && c.ok && c.field == field {
+			keys = append(keys, c.key)
+		}
+	}
+	sort.Strings(keys)
+
+	// the keys are checked against their values at the current revision,
+	// which also drops the keys the write txn no longer indexes
+	var kvs []mvccpb.KeyValue
+	for i, k := range keys {
+		if i > 0 && keys[i-1] == k {
+			continue
+		}
+		if !inRange([]byte(k), key, end) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		rev, _, _, err := tr.s.kvindex.Get([]byte(k), curRev)
+		if err != nil {
+			continue
+		}
+		revBytes := newRevBytes()
+		revToBytes(rev, revBytes)
+		_, vs := tr.tx.UnsafeRange(keyBucketName, revBytes, nil, 0)
+		if len(vs) != 1 {
+			plog.Fatalf("range cannot find rev (%d,%d)", rev.main, rev.sub)
+		}
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(vs[0]); err != nil {
+			plog.Fatalf("cannot unmarshal event: %v", err)
+		}
+		if f, ok := vi.Extract(kv.Value); !ok || string(f) != field {
+			continue
+		}
+		kvs = append(kvs, kv)
+	}
+
+	if ro.Count {
+		return &RangeResult{KVs: nil, Count: len(kvs), Rev: curRev}, nil
+	}
+	count := len(kvs)
+	if ro.Limit > 0 && int(ro.Limit) < len(kvs) {
+		kvs = kvs[:ro.Limit]
+	}
+	return &RangeResult{KVs: kvs, Count: count, Rev: curRev}, nil
+}
+
+// inRange reports whether k is in the range of key and end, as a range
+// request gets it.
+func inRange(k, key, end []byte) bool {
+	if end == nil {
+		return bytes.Equal(k, key)
+	}
+	return bytes.Compare(k, key) >= 0 && (len(end) == 0 || bytes.Compare(k, end) < 0)
+}
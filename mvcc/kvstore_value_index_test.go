@@ -0,0 +1,224 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+)
+
+func newTestJSONPointerIndex(t *testing.T, name, prefix, pointer string) ValueIndex {
+	vi, err := NewJSONPointerIndex(name, []byte(prefix), pointer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vi
+}
+
+func rangeIndexKeys(t *testing.T, s KV, name, value string) []string {
+	r, err := s.Range(context.TODO(), []byte("a"), []byte{}, RangeOptions{Index: name, IndexValue: []byte(value)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Count != len(r.KVs) {
+		t.Errorf("count = %d, want %d", r.Count, len(r.KVs))
+	}
+	var keys []string
+	for _, kv := range r.KVs {
+		keys = append(keys, string(kv.Key))
+	}
+	return keys
+}
+
+func TestStoreValueIndexRange(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	if err := s.SetValueIndexes([]ValueIndex{newTestJSONPointerIndex(t, "color", "apple/", "/color")}); err != nil {
+		t.Fatal(err)
+	}
+	s.Put([]byte("apple/1"), []byte(`{"color":"red"}`), lease.NoLease)
+	s.Put([]byte("apple/2"), []byte(`{"color":"green"}`), lease.NoLease)
+	s.Put([]byte("apple/3"), []byte(`{"color":"red"}`), lease.NoLease)
+	s.Put([]byte("apple/4"), []byte(`not json`), lease.NoLease)
+	s.Put([]byte("berry/1"), []byte(`{"color":"red"}`), lease.NoLease)
+
+	if keys, w := rangeIndexKeys(t, s, "color", "red"), []string{"apple/1", "apple/3"}; !reflect.DeepEqual(keys, w) {
+		t.Errorf("keys = %v, want %v", keys, w)
+	}
+
+	s.Put([]byte("apple/1"), []byte(`{"color":"green"}`), lease.NoLease)
+	s.DeleteRange([]byte("apple/3"), nil)
+	if keys := rangeIndexKeys(t, s, "color", "red"); keys != nil {
+		t.Errorf("keys = %v, want none", keys)
+	}
+	if keys, w := rangeIndexKeys(t, s, "color", "green"), []string{"apple/1", "apple/2"}; !reflect.DeepEqual(keys, w) {
+		t.Errorf("keys = %v, want %v", keys, w)
+	}
+
+	r, err := s.Range(context.TODO(), []byte("apple/2"), nil, RangeOptions{Index: "color", IndexValue: []byte("green")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 || string(r.KVs[0].Key) != "apple/2" {
+		t.Errorf("single key range = %+v, want apple/2", r.KVs)
+	}
+	r, err = s.Range(context.TODO(), []byte("a"), []byte{}, RangeOptions{Index: "color", IndexValue: []byte("green"), Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 || r.Count != 2 {
+		t.Errorf("limited range = %d keys of %d, want 1 of 2", len(r.KVs), r.Count)
+	}
+
+	if _, err = s.Range(context.TODO(), []byte("a"), []byte{}, RangeOptions{Index: "size"}); err != ErrValueIndexNotFound {
+		t.Errorf("err = %v, want %v", err, ErrValueIndexNotFound)
+	}
+	if _, err = s.Range(context.TODO(), []byte("a"), []byte{}, RangeOptions{Index: "color", Rev: 2}); err != ErrValueIndexRev {
+		t.Errorf("err = %v, want %v", err, ErrValueIndexRev)
+	}
+}
+
+func TestStoreValueIndexTxn(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	if err := s.SetValueIndexes([]ValueIndex{newTestJSONPointerIndex(t, "color", "apple/", "/color")}); err != nil {
+		t.Fatal(err)
+	}
+	s.Put([]byte("apple/1"), []byte(`{"color":"red"}`), lease.NoLease)
+
+	// a write txn ranges its own writes
+	txn := s.Write()
+	txn.Put([]byte("apple/1"), []byte(`{"color":"green"}`), lease.NoLease)
+	txn.Put([]byte("apple/2"), []byte(`{"color":"red"}`), lease.NoLease)
+	r, err := txn.Range(context.TODO(), []byte("a"), []byte{}, RangeOptions{Index: "color", IndexValue: []byte("red")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 || string(r.KVs[0].Key) != "apple/2" {
+		t.Errorf("txn range = %+v, want apple/2", r.KVs)
+	}
+	txn.End()
+
+	if keys, w := rangeIndexKeys(t, s, "color", "red"), []string{"apple/2"}; !reflect.DeepEqual(keys, w) {
+		t.Errorf("keys = %v, want %v", keys, w)
+	}
+}
+
+func TestStoreValueIndexRestore(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("apple/1"), []byte(`{"color":"red","size":1}`), lease.NoLease)
+	// the index is built from the keys written before it is set
+	vis := []ValueIndex{newTestJSONPointerIndex(t, "color", "apple/", "/color")}
+	if err := s0.SetValueIndexes(vis); err != nil {
+		t.Fatal(err)
+	}
+	s0.Put([]byte("apple/2"), []byte(`{"color":"red","size":2}`), lease.NoLease)
+	s0.Close()
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	if err := s1.SetValueIndexes(vis); err != nil {
+		t.Fatal(err)
+	}
+	if keys, w := rangeIndexKeys(t, s1, "color", "red"), []string{"apple/1", "apple/2"}; !reflect.DeepEqual(keys, w) {
+		t.Errorf("keys = %v, want %v", keys, w)
+	}
+	s1.Close()
+
+	// an index set with another spec is rebuilt, and dropped indexes are removed
+	s2 := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s2, b, tmpPath)
+	if err := s2.SetValueIndexes([]ValueIndex{newTestJSONPointerIndex(t, "color", "apple/", "/size")}); err != nil {
+		t.Fatal(err)
+	}
+	if keys, w := rangeIndexKeys(t, s2, "color", "2"), []string{"apple/2"}; !reflect.DeepEqual(keys, w) {
+		t.Errorf("keys = %v, want %v", keys, w)
+	}
+	if err := s2.SetValueIndexes(nil); err != nil {
+		t.Fatal(err)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	ks, _ := tx.UnsafeRange(valueIndexBucketName, []byte{0}, []byte{0xff}, 0)
+	tx.Unlock()
+	if len(ks) != 0 {
+		t.Errorf("saved value index keys = %q, want none", ks)
+	}
+}
+
+func TestSetValueIndexesInvalid(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	vi := newTestJSONPointerIndex(t, "color", "apple/", "/color")
+	tests := [][]ValueIndex{
+		{vi, vi},
+		{{Name: "", Extract: vi.Extract}},
+		{{Name: "color"}},
+	}
+	for i, tt := range tests {
+		if err := s.SetValueIndexes(tt); err == nil {
+			t.Errorf("#%d: expected error", i)
+		}
+	}
+}
+
+func TestJSONPointerIndexExtract(t *testing.T) {
+	tests := []struct {
+		pointer string
+		value   string
+
+		field string
+		ok    bool
+	}{
+		{"/a", `{"a":"x"}`, "x", true},
+		{"/a", `{"a":12.50}`, "12.50", true},
+		{"/a", `{"a":true}`, "true", true},
+		{"/a/b", `{"a":{"b":"y"}}`, "y", true},
+		{"/a/1", `{"a":["x","y"]}`, "y", true},
+		{"/a~1b", `{"a/b":"z"}`, "z", true},
+		{"/a~0b", `{"a~b":"z"}`, "z", true},
+		{"/a", `{"a":{"b":"y"}}`, "", false},
+		{"/a", `{"a":null}`, "", false},
+		{"/a", `{"b":"x"}`, "", false},
+		{"/a/2", `{"a":["x","y"]}`, "", false},
+		{"/a", `not json`, "", false},
+	}
+	for i, tt := range tests {
+		vi, err := NewJSONPointerIndex("i", nil, tt.pointer)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		field, ok := vi.Extract([]byte(tt.value))
+		if string(field) != tt.field || ok != tt.ok {
+			t.Errorf("#%d: extract = %q, %v, want %q, %v", i, field, ok, tt.field, tt.ok)
+		}
+	}
+	if _, err := NewJSONPointerIndex("i", nil, "a"); err == nil {
+		t.Errorf("expected error for pointer without leading slash")
+	}
+}
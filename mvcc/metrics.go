@@ -53,6 +53,14 @@ var (
 			Help:      "Total number of txns seen by this member.",
 		})
 
+	rangeAbortedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "range_aborted_total",
+			Help:      "Total number of ranges stopped before reading all keys because their request was canceled or timed out.",
+		})
+
 	keysGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "etcd_debugging",
@@ -85,6 +93,23 @@ var (
 			Help:      "Total number of unsynced slow watchers.",
 		})
 
+	watcherSyncCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watcher_sync_total",
+			Help:      "Total number of unsynced watcher syncs, by whether the events came from the in-memory event history or the backend.",
+		},
+		[]string{"source"})
+
+	watchBacklogRejectedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watch_backlog_rejected_total",
+			Help:      "Total number of watches on past revisions refused due to the unsynced watcher backlog.",
+		})
+
 	totalEventsCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "etcd_debugging",
@@ -101,6 +126,16 @@ var (
 			Help:      "Total number of pending events to be sent.",
 		})
 
+	opDurations = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "op_duration_seconds",
+			Help:      "Bucketed histogram of storage operation duration, by operation type and by upper bound of the number of keys and of the payload bytes.",
+			// 10us -> 5.2 seconds
+			Buckets: prometheus.ExponentialBuckets(0.00001, 2, 20),
+		}, []string{"op", "keys", "bytes"})
+
 	indexCompactionPauseDurations = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -161,12 +196,16 @@ func init() {
 	prometheus.MustRegister(putCounter)
 	prometheus.MustRegister(deleteCounter)
 	prometheus.MustRegister(txnCounter)
+	prometheus.MustRegister(rangeAbortedCounter)
 	prometheus.MustRegister(keysGauge)
 	prometheus.MustRegister(watchStreamGauge)
 	prometheus.MustRegister(watcherGauge)
 	prometheus.MustRegister(slowWatcherGauge)
+	prometheus.MustRegister(watchBacklogRejectedCounter)
+	prometheus.MustRegister(watcherSyncCounter)
 	prometheus.MustRegister(totalEventsCounter)
 	prometheus.MustRegister(pendingEventsGauge)
+	prometheus.MustRegister(opDurations)
 	prometheus.MustRegister(indexCompactionPauseDurations)
 	prometheus.MustRegister(dbCompactionPauseDurations)
 	prometheus.MustRegister(dbCompactionTotalDurations)
@@ -15,6 +15,9 @@
 package mvcc
 
 import (
+	"context"
+	"time"
+
 	"github.com/coreos/etcd/lease"
 )
 
@@ -23,37 +26,94 @@ type metricsTxnWrite struct {
 	ranges  uint
 	puts    uint
 	deletes uint
+
+	// keys and bytes are the number of keys and the payload size in
+	// bytes of all the operations of the txn.
+	keys, bytes int
+	start       time.Time
 }
 
 func newMetricsTxnRead(tr TxnRead) TxnRead {
-	return &metricsTxnWrite{&txnReadWrite{tr}, 0, 0, 0}
+	return &metricsTxnWrite{TxnWrite: &txnReadWrite{tr}, start: time.Now()}
 }
 
 func newMetricsTxnWrite(tw TxnWrite) TxnWrite {
-	return &metricsTxnWrite{tw, 0, 0, 0}
+	return &metricsTxnWrite{TxnWrite: tw, start: time.Now()}
 }
 
-func (tw *metricsTxnWrite) Range(key, end []byte, ro RangeOptions) (*RangeResult, error) {
+func (tw *metricsTxnWrite) Range(ctx context.Context, key, end []byte, ro RangeOptions) (*RangeResult, error) {
 	tw.ranges++
-	return tw.TxnWrite.Range(key, end, ro)
+	start := time.Now()
+	r, err := tw.TxnWrite.Range(ctx, key, end, ro)
+	if err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			rangeAbortedCounter.Inc()
+		}
+		return r, err
+	}
+	keys, bytes := len(r.KVs), 0
+	for i := range r.KVs {
+		bytes += len(r.KVs[i].Key) + len(r.KVs[i].Value)
+	}
+	observeOp("range", start, keys, bytes)
+	tw.keys, tw.bytes = tw.keys+keys, tw.bytes+bytes
+	return r, err
 }
 
 func (tw *metricsTxnWrite) DeleteRange(key, end []byte) (n, rev int64) {
 	tw.deletes++
-	return tw.TxnWrite.DeleteRange(key, end)
+	start := time.Now()
+	n, rev = tw.TxnWrite.DeleteRange(key, end)
+	bytes := len(key) + len(end)
+	observeOp("delete_range", start, int(n), bytes)
+	tw.keys, tw.bytes = tw.keys+int(n), tw.bytes+bytes
+	return n, rev
 }
 
 func (tw *metricsTxnWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
+	return tw.PutWithExpiry(key, value, lease, 0)
+}
+
+func (tw *metricsTxnWrite) PutWithExpiry(key, value []byte, lease lease.LeaseID, expireAt int64) (rev int64) {
 	tw.puts++
-	return tw.TxnWrite.Put(key, value, lease)
+	start := time.Now()
+	rev = tw.TxnWrite.PutWithExpiry(key, value, lease, expireAt)
+	bytes := len(key) + len(value)
+	observeOp("put", start, 1, bytes)
+	tw.keys, tw.bytes = tw.keys+1, tw.bytes+bytes
+	return rev
 }
 
 func (tw *metricsTxnWrite) End() {
-	defer tw.TxnWrite.End()
+	tw.TxnWrite.End()
 	if sum := tw.ranges + tw.puts + tw.deletes; sum > 1 {
 		txnCounter.Inc()
+		observeOp("txn", tw.start, tw.keys, tw.bytes)
 	}
 	rangeCounter.Add(float64(tw.ranges))
 	putCounter.Add(float64(tw.puts))
 	deleteCounter.Add(float64(tw.deletes))
 }
+
+var (
+	opKeysBounds  = []int{1, 10, 100, 1000}
+	opKeysLabels  = []string{"1", "10", "100", "1000", "+Inf"}
+	opBytesBounds = []int{1 << 10, 16 << 10, 256 << 10, 4 << 20}
+	opBytesLabels = []string{"1024", "16384", "262144", "4194304", "+Inf"}
+)
+
+// observeOp records the duration of an operation on opDurations, with
+// the upper bounds of its key count and payload size buckets as labels.
+func observeOp(op string, start time.Time, keys, bytes int) {
+	opDurations.WithLabelValues(op, opBucket(opKeysBounds, opKeysLabels, keys), opBucket(opBytesBounds, opBytesLabels, bytes)).
+		Observe(time.Since(start).Seconds())
+}
+
+func opBucket(bounds []int, labels []string, v int) string {
+	for i, b := range bounds {
+		if v <= b {
+			return labels[i]
+		}
+	}
+	return labels[len(bounds)]
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestOpBucket(t *testing.T) {
+	tests := []struct {
+		v int
+		w string
+	}{
+		{0, "1"},
+		{1, "1"},
+		{2, "10"},
+		{1000, "1000"},
+		{1001, "+Inf"},
+	}
+	for i, tt := range tests {
+		if g := opBucket(opKeysBounds, opKeysLabels, tt.v); g != tt.w {
+			t.Errorf("#%d: bucket of %d = %q, want %q", i, tt.v, g, tt.w)
+		}
+	}
+}
+
+func TestMetricsTxnOpDurations(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	tests := []struct {
+		labels []string
+		f      func()
+	}{
+		{
+			[]string{"put", "1", "1024"},
+			func() { s.Put([]byte("foo"), []byte("bar"), lease.NoLease) },
+		},
+		{
+			[]string{"put", "1", "16384"},
+			func() { s.Put([]byte("foo"), make([]byte, 2048), lease.NoLease) },
+		},
+		{
+			[]string{"range", "10", "16384"},
+			func() {
+				s.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+				s.Range(context.TODO(), []byte("foo"), []byte("foo2"), RangeOptions{})
+			},
+		},
+		{
+			[]string{"delete_range", "10", "1024"},
+			func() { s.DeleteRange([]byte("foo"), []byte("foo2")) },
+		},
+		{
+			[]string{"txn", "10", "1024"},
+			func() {
+				txn := s.Write()
+				txn.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+				txn.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+				txn.End()
+			},
+		},
+	}
+	for i, tt := range tests {
+		before := readOpDurationCount(tt.labels)
+		tt.f()
+		if g := readOpDurationCount(tt.labels) - before; g != 1 {
+			t.Errorf("#%d: %v observations = %d, want 1", i, tt.labels, g)
+		}
+	}
+}
+
+func readOpDurationCount(labels []string) uint64 {
+	mm := &dto.Metric{}
+	opDurations.WithLabelValues(labels...).Write(mm)
+	return mm.GetHistogram().GetSampleCount()
+}
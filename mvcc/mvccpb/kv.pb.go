@@ -56,6 +56,29 @@ func (x Event_EventType) String() string {
 }
 func (Event_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptorKv, []int{1, 0} }
 
+type Event_DeleteReason int32
+
+const (
+	// REQUESTED is a key deleted by a client request.
+	REQUESTED Event_DeleteReason = 0
+	// LEASE_REVOKED is a key deleted by the revoke or expiry of its lease.
+	LEASE_REVOKED Event_DeleteReason = 1
+)
+
+var Event_DeleteReason_name = map[int32]string{
+	0: "REQUESTED",
+	1: "LEASE_REVOKED",
+}
+var Event_DeleteReason_value = map[string]int32{
+	"REQUESTED":     0,
+	"LEASE_REVOKED": 1,
+}
+
+func (x Event_DeleteReason) String() string {
+	return proto.EnumName(Event_DeleteReason_name, int32(x))
+}
+func (Event_DeleteReason) EnumDescriptor() ([]byte, []int) { return fileDescriptorKv, []int{1, 1} }
+
 type KeyValue struct {
 	// key is the key in bytes. An empty key is not allowed.
 	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -73,6 +96,9 @@ type KeyValue struct {
 	// When the attached lease expires, the key will be deleted.
 	// If lease is 0, then no lease is attached to the key.
 	Lease int64 `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
+	// expire_at is the unix time, in seconds, at which the key expires.
+	// If expire_at is 0, the key does not expire.
+	ExpireAt int64 `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
 }
 
 func (m *KeyValue) Reset()                    { *m = KeyValue{} }
@@ -93,6 +119,8 @@ type Event struct {
 	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv" json:"kv,omitempty"`
 	// prev_kv holds the key-value pair before the event happens.
 	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv" json:"prev_kv,omitempty"`
+	// reason is why the key of a DELETE event was deleted.
+	Reason Event_DeleteReason `protobuf:"varint,4,opt,name=reason,proto3,enum=mvccpb.Event_DeleteReason" json:"reason,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -104,6 +132,7 @@ func init() {
 	proto.RegisterType((*KeyValue)(nil), "mvccpb.KeyValue")
 	proto.RegisterType((*Event)(nil), "mvccpb.Event")
 	proto.RegisterEnum("mvccpb.Event_EventType", Event_EventType_name, Event_EventType_value)
+	proto.RegisterEnum("mvccpb.Event_DeleteReason", Event_DeleteReason_name, Event_DeleteReason_value)
 }
 func (m *KeyValue) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -152,6 +181,11 @@ func (m *KeyValue) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintKv(dAtA, i, uint64(m.Lease))
 	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.ExpireAt))
+	}
 	return i, nil
 }
 
@@ -195,6 +229,11 @@ func (m *Event) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.Reason))
+	}
 	return i, nil
 }
 
@@ -248,6 +287,9 @@ func (m *KeyValue) Size() (n int) {
 	if m.Lease != 0 {
 		n += 1 + sovKv(uint64(m.Lease))
 	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovKv(uint64(m.ExpireAt))
+	}
 	return n
 }
 
@@ -265,6 +307,9 @@ func (m *Event) Size() (n int) {
 		l = m.PrevKv.Size()
 		n += 1 + l + sovKv(uint64(l))
 	}
+	if m.Reason != 0 {
+		n += 1 + sovKv(uint64(m.Reason))
+	}
 	return n
 }
 
@@ -448,6 +493,25 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
+			}
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -583,6 +647,25 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= (Event_DeleteReason(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -712,24 +795,29 @@ var (
 func init() { proto.RegisterFile("kv.proto", fileDescriptorKv) }
 
 var fileDescriptorKv = []byte{
-	// 303 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x41, 0x4e, 0xc2, 0x40,
-	0x14, 0x86, 0x3b, 0x14, 0x0a, 0x3e, 0x08, 0x36, 0x13, 0x12, 0x27, 0x2e, 0x26, 0x95, 0x8d, 0x18,
-	0x13, 0x4c, 0xf0, 0x06, 0xc6, 0xae, 0x70, 0x61, 0x1a, 0x74, 0x4b, 0x4a, 0x79, 0x21, 0xa4, 0x94,
-	0x69, 0x4a, 0x9d, 0xa4, 0x37, 0x71, 0xef, 0xde, 0x73, 0xb0, 0xe4, 0x08, 0x52, 0x2f, 0x62, 0xfa,
-	0xc6, 0xe2, 0xc6, 0xcd, 0xe4, 0xfd, 0xff, 0xff, 0x65, 0xe6, 0x7f, 0x03, 0x9d, 0x58, 0x8f, 0xd3,
-	0x4c, 0xe5, 0x8a, 0x3b, 0x89, 0x8e, 0xa2, 0x74, 0x71, 0x39, 0x58, 0xa9, 0x95, 0x22, 0xeb, 0xae,
-	0x9a, 0x4c, 0x3a, 0xfc, 0x64, 0xd0, 0x99, 0x62, 0xf1, 0x1a, 0x6e, 0xde, 0x90, 0xbb, 0x60, 0xc7,
-	0x58, 0x08, 0xe6, 0xb1, 0x51, 0x2f, 0xa8, 0x46, 0x7e, 0x0d, 0xe7, 0x51, 0x86, 0x61, 0x8e, 0xf3,
-	0x0c, 0xf5, 0x7a, 0xb7, 0x56, 0x5b, 0xd1, 0xf0, 0xd8, 0xc8, 0x0e, 0xfa, 0xc6, 0x0e, 0x7e, 0x5d,
-	0x7e, 0x05, 0xbd, 0x44, 0x2d, 0xff, 0x28, 0x9b, 0xa8, 0x6e, 0xa2, 0x96, 0x27, 0x44, 0x40, 0x5b,
-	0x63, 0x46, 0x69, 0x93, 0xd2, 0x5a, 0xf2, 0x01, 0xb4, 0x74, 0x55, 0x40, 0xb4, 0xe8, 0x65, 0x23,
-	0x2a, 0x77, 0x83, 0xe1, 0x0e, 0x85, 0x43, 0xb4, 0x11, 0xc3, 0x0f, 0x06, 0x2d, 0x5f, 0xe3, 0x36,
-	0xe7, 0xb7, 0xd0, 0xcc, 0x8b, 0x14, 0xa9, 0x6e, 0x7f, 0x72, 0x31, 0x36, 0x7b, 0x8e, 0x29, 0x34,
-	0xe7, 0xac, 0x48, 0x31, 0x20, 0x88, 0x7b, 0xd0, 0x88, 0x35, 0x75, 0xef, 0x4e, 0xdc, 0x1a, 0xad,
-	0x17, 0x0f, 0x1a, 0xb1, 0xe6, 0x37, 0xd0, 0x4e, 0x33, 0xd4, 0xf3, 0x58, 0x53, 0xf9, 0xff, 0x30,
-	0xa7, 0x02, 0xa6, 0x7a, 0xe8, 0xc1, 0xd9, 0xe9, 0x7e, 0xde, 0x06, 0xfb, 0xf9, 0x65, 0xe6, 0x5a,
-	0x1c, 0xc0, 0x79, 0xf4, 0x9f, 0xfc, 0x99, 0xef, 0xb2, 0x07, 0xb1, 0x3f, 0x4a, 0xeb, 0x70, 0x94,
-	0xd6, 0xbe, 0x94, 0xec, 0x50, 0x4a, 0xf6, 0x55, 0x4a, 0xf6, 0xfe, 0x2d, 0xad, 0x85, 0x43, 0xff,
-	0x7e, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x45, 0x92, 0x5d, 0xa1, 0x01, 0x00, 0x00,
+	// 380 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xdf, 0xaa, 0xda, 0x40,
+	0x10, 0xc6, 0xb3, 0x89, 0x26, 0x3a, 0xfe, 0x69, 0xba, 0x08, 0x0d, 0x16, 0x42, 0x9a, 0x9b, 0x5a,
+	0x0a, 0xb6, 0xa4, 0x4f, 0x60, 0xc9, 0x5e, 0x29, 0xb4, 0x5d, 0xa3, 0xb7, 0x21, 0xea, 0x20, 0x12,
+	0x35, 0x21, 0xa6, 0x4b, 0xf3, 0x08, 0x7d, 0x83, 0x3e, 0x92, 0xd0, 0x1b, 0x1f, 0xa1, 0xda, 0x17,
+	0x39, 0x64, 0xa3, 0x9e, 0x73, 0xe0, 0xdc, 0x84, 0x99, 0xef, 0xfb, 0x85, 0x99, 0x6f, 0x16, 0x1a,
+	0xb1, 0x18, 0xa6, 0x59, 0x92, 0x27, 0x54, 0xdf, 0x89, 0xe5, 0x32, 0x5d, 0xf4, 0x7b, 0xeb, 0x64,
+	0x9d, 0x48, 0xe9, 0x53, 0x59, 0x55, 0xae, 0xfb, 0x97, 0x40, 0x63, 0x8c, 0xc5, 0x3c, 0xda, 0xfe,
+	0x44, 0x6a, 0x82, 0x16, 0x63, 0x61, 0x11, 0x87, 0x0c, 0xda, 0xbc, 0x2c, 0xe9, 0x7b, 0x78, 0xb5,
+	0xcc, 0x30, 0xca, 0x31, 0xcc, 0x50, 0x6c, 0x0e, 0x9b, 0x64, 0x6f, 0xa9, 0x0e, 0x19, 0x68, 0xbc,
+	0x5b, 0xc9, 0xfc, 0xaa, 0xd2, 0x77, 0xd0, 0xde, 0x25, 0xab, 0x47, 0x4a, 0x93, 0x54, 0x6b, 0x97,
+	0xac, 0xee, 0x88, 0x05, 0x86, 0xc0, 0x4c, 0xba, 0x35, 0xe9, 0xde, 0x5a, 0xda, 0x83, 0xba, 0x28,
+	0x17, 0xb0, 0xea, 0x72, 0x72, 0xd5, 0x94, 0xea, 0x16, 0xa3, 0x03, 0x5a, 0xba, 0xa4, 0xab, 0x86,
+	0xbe, 0x85, 0x26, 0xfe, 0x4a, 0x37, 0x19, 0x86, 0x51, 0x6e, 0x19, 0xd2, 0x69, 0x54, 0xc2, 0x28,
+	0x77, 0x7f, 0xab, 0x50, 0x67, 0x02, 0xf7, 0x39, 0xfd, 0x08, 0xb5, 0xbc, 0x48, 0x51, 0x66, 0xe9,
+	0x7a, 0x6f, 0x86, 0xd5, 0x11, 0x86, 0xd2, 0xac, 0xbe, 0x41, 0x91, 0x22, 0x97, 0x10, 0x75, 0x40,
+	0x8d, 0x85, 0x0c, 0xd6, 0xf2, 0xcc, 0x1b, 0x7a, 0xbb, 0x0a, 0x57, 0x63, 0x41, 0x3f, 0x80, 0x91,
+	0x66, 0x28, 0xc2, 0x58, 0xc8, 0x64, 0x2f, 0x61, 0x7a, 0x09, 0x8c, 0x05, 0xf5, 0x40, 0xcf, 0x30,
+	0x3a, 0x5c, 0x53, 0x76, 0xbd, 0xfe, 0xf3, 0xd9, 0x3e, 0x6e, 0xb1, 0xbc, 0x5b, 0x49, 0xf0, 0x2b,
+	0xe9, 0x3a, 0xd0, 0xbc, 0xef, 0x44, 0x0d, 0xd0, 0xbe, 0xcf, 0x02, 0x53, 0xa1, 0x00, 0xba, 0xcf,
+	0x26, 0x2c, 0x60, 0x26, 0x71, 0x3f, 0x43, 0xfb, 0xe9, 0x9f, 0xb4, 0x03, 0x4d, 0xce, 0x7e, 0xcc,
+	0xd8, 0x34, 0x60, 0xbe, 0xa9, 0xd0, 0xd7, 0xd0, 0x99, 0xb0, 0xd1, 0x94, 0x85, 0x9c, 0xcd, 0xbf,
+	0x8d, 0x99, 0x6f, 0x92, 0xaf, 0xd6, 0xf1, 0x6c, 0x2b, 0xa7, 0xb3, 0xad, 0x1c, 0x2f, 0x36, 0x39,
+	0x5d, 0x6c, 0xf2, 0xef, 0x62, 0x93, 0x3f, 0xff, 0x6d, 0x65, 0xa1, 0xcb, 0xa7, 0xff, 0xf2, 0x10,
+	0x00, 0x00, 0xff, 0xff, 0x68, 0x98, 0x0c, 0x9f, 0x24, 0x02, 0x00, 0x00,
 }
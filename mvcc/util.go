@@ -41,6 +41,23 @@ func UpdateConsistentIndex(be backend.Backend, index uint64) {
 	tx.UnsafePut(metaBucketName, consistentIndexKeyName, bs)
 }
 
+// unsafeKeyOf returns the key of a marshaled mvccpb.KeyValue without decoding
+// or copying the rest of it. The key aliases b, so it is only valid as long
+// as b is, e.g. within the backend read transaction b comes from. It returns
+// false if the key is not the first field of b.
+func unsafeKeyOf(b []byte) ([]byte, bool) {
+	// the key is field 1 with wire type 2 (length-delimited)
+	if len(b) == 0 || b[0] != 1<<3|2 {
+		return nil, false
+	}
+	l, n := binary.Uvarint(b[1:])
+	if n <= 0 || l > uint64(len(b)-1-n) {
+		return nil, false
+	}
+	start := 1 + n
+	return b[start : start+int(l)], true
+}
+
 func WriteKV(be backend.Backend, kv mvccpb.KeyValue) {
 	ibytes := newRevBytes()
 	revToBytes(revision{main: kv.ModRevision}, ibytes)
@@ -0,0 +1,53 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func TestUnsafeKeyOf(t *testing.T) {
+	longKey := []byte(strings.Repeat("a", 300))
+	tests := []mvccpb.KeyValue{
+		{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 1, ModRevision: 2, Version: 1},
+		{Key: longKey, Value: bytes.Repeat([]byte("b"), 1<<20), ModRevision: 3},
+		{Key: []byte("foo")},
+	}
+	for i, kv := range tests {
+		b, err := kv.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, ok := unsafeKeyOf(b)
+		if !ok || !bytes.Equal(key, kv.Key) {
+			t.Errorf("#%d: key = %q, %v, want %q, true", i, key, ok, kv.Key)
+		}
+	}
+
+	// no key, truncated and malformed input
+	noKey, err := (&mvccpb.KeyValue{Value: []byte("bar")}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range [][]byte{nil, noKey, {0x0a}, {0x0a, 0x05, 'f', 'o'}, {0x0a, 0xff}} {
+		if key, ok := unsafeKeyOf(b); ok {
+			t.Errorf("#%d: unexpected key %q", i, key)
+		}
+	}
+}
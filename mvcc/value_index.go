@@ -0,0 +1,124 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrValueIndexNotFound = errors.New("mvcc: value index not found")
+	ErrValueIndexRev      = errors.New("mvcc: value index only ranges the current revision")
+)
+
+// ValueIndex indexes the keys under a prefix by a field extracted from
+// their values, so the keys with a given field can be ranged without
+// reading every key under the prefix.
+type ValueIndex struct {
+	// Name identifies the index in range requests.
+	Name string
+	// Prefix is the prefix of the indexed keys.
+	Prefix []byte
+	// Spec describes what Extract extracts. The index saved in the backend
+	// is rebuilt when the spec it was built with changes.
+	Spec string
+	// Extract returns the indexed field of a value, or false if the value
+	// has no such field. It must be deterministic, since each member keeps
+	// its own index.
+	Extract func(value []byte) ([]byte, bool)
+}
+
+func (vi *ValueIndex) indexes(key []byte) bool { return bytes.HasPrefix(key, vi.Prefix) }
+
+func (vi *ValueIndex) validate() error {
+	if vi.Name == "" || strings.IndexByte(vi.Name, 0) != -1 {
+		return fmt.Errorf("mvcc: invalid value index name %q", vi.Name)
+	}
+	if vi.Extract == nil {
+		return fmt.Errorf("mvcc: value index %q has no extract function", vi.Name)
+	}
+	return nil
+}
+
+// NewJSONPointerIndex returns a ValueIndex of the keys under prefix whose
+// values are JSON documents, by the string, number or boolean at the given
+// RFC 6901 JSON pointer. Strings are indexed by their contents; numbers and
+// booleans by their JSON text.
+func NewJSONPointerIndex(name string, prefix []byte, pointer string) (ValueIndex, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return ValueIndex{}, err
+	}
+	return ValueIndex{
+		Name:    name,
+		Prefix:  prefix,
+		Spec:    "json:" + pointer,
+		Extract: func(value []byte) ([]byte, bool) { return extractJSONPointer(value, tokens) },
+	}, nil
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("mvcc: JSON pointer %q does not start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(t, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func extractJSONPointer(value []byte, tokens []string) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	for _, t := range tokens {
+		switch c := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = c[t]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(t)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			v = c[i]
+		default:
+			return nil, false
+		}
+	}
+	switch f := v.(type) {
+	case string:
+		return []byte(f), true
+	case json.Number:
+		return []byte(f), true
+	case bool:
+		return []byte(strconv.FormatBool(f)), true
+	}
+	return nil, false
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build watch_invariants
+
+package mvcc
+
+import "github.com/coreos/etcd/mvcc/mvccpb"
+
+// deliveryCheck panics if a watcher is sent an event of an older revision
+// than one it was already sent, or the same key at the same revision twice.
+// Building with the watch_invariants tag enables it for tests; it costs a
+// key set per watcher.
+type deliveryCheck struct {
+	// rev is the revision of the last event sent to the watcher
+	rev int64
+	// keys are the keys of the events of revision rev sent to the watcher
+	keys map[string]struct{}
+}
+
+func (dc *deliveryCheck) record(id WatchID, evs []mvccpb.Event) {
+	for _, ev := range evs {
+		rev, key := ev.Kv.ModRevision, string(ev.Kv.Key)
+		switch {
+		case rev < dc.rev:
+			plog.Panicf("watcher %d sent revision %d after revision %d", id, rev, dc.rev)
+		case rev > dc.rev:
+			dc.rev, dc.keys = rev, make(map[string]struct{})
+		}
+		if _, ok := dc.keys[key]; ok {
+			plog.Panicf("watcher %d sent key %q at revision %d twice", id, key, rev)
+		}
+		dc.keys[key] = struct{}{}
+	}
+}
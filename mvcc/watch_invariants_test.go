@@ -0,0 +1,54 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build watch_invariants
+
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func TestDeliveryCheck(t *testing.T) {
+	ev := func(key string, rev int64) mvccpb.Event {
+		return mvccpb.Event{Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev}}
+	}
+	tests := []struct {
+		resps [][]mvccpb.Event
+		panic bool
+	}{
+		{[][]mvccpb.Event{{ev("a", 2), ev("b", 2)}, {ev("a", 3)}, {ev("b", 5)}}, false},
+		// revisions may repeat across responses, keys may not
+		{[][]mvccpb.Event{{ev("a", 2)}, {ev("b", 2)}}, false},
+		{[][]mvccpb.Event{{ev("a", 3)}, {ev("a", 2)}}, true},
+		{[][]mvccpb.Event{{ev("a", 2), ev("b", 3), ev("c", 2)}}, true},
+		{[][]mvccpb.Event{{ev("a", 2)}, {ev("a", 2)}}, true},
+		{[][]mvccpb.Event{{ev("a", 2), ev("a", 2)}}, true},
+	}
+	for i, tt := range tests {
+		panicked := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			var dc deliveryCheck
+			for _, evs := range tt.resps {
+				dc.record(0, evs)
+			}
+			return false
+		}()
+		if panicked != tt.panic {
+			t.Errorf("#%d: panicked = %v, want %v", i, panicked, tt.panic)
+		}
+	}
+}
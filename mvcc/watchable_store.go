@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/etcd/lease"
@@ -36,8 +37,11 @@ var (
 )
 
 type watchable interface {
-	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
+	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc, error)
 	progress(w *watcher)
+	pause(w *watcher)
+	resume(w *watcher)
+	stats(w *watcher) WatcherStats
 	rev() int64
 }
 
@@ -59,6 +63,23 @@ type watchableStore struct {
 	// The key of the map is the key that the watcher watches on.
 	synced watcherGroup
 
+	// contains all paused watchers, which are sent no events until resumed.
+	paused watcherGroup
+
+	// watchers is the number of watchers not yet canceled.
+	watchers int
+	// maxWatchers and maxStreamWatchers are the maximum number of
+	// watchers of the store and of each of its streams, or 0 for no limit.
+	maxWatchers       int
+	maxStreamWatchers int
+	// maxUnsynced is the unsynced watcher backlog past which new watchers
+	// on past revisions are refused, or 0 for no limit.
+	maxUnsynced int
+
+	// history holds the events of recent revisions for syncing watchers
+	// that are only slightly behind.
+	history eventHistory
+
 	stopc chan struct{}
 	wg    sync.WaitGroup
 }
@@ -77,13 +98,14 @@ func newWatchableStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGet
 		victimc:  make(chan struct{}, 1),
 		unsynced: newWatcherGroup(),
 		synced:   newWatcherGroup(),
+		paused:   newWatcherGroup(),
 		stopc:    make(chan struct{}),
 	}
 	s.store.ReadView = &readView{s}
 	s.store.WriteView = &writeView{s}
 	if s.le != nil {
 		// use this store as the deleter so revokes trigger watch events
-		s.le.SetRangeDeleter(func() lease.TxnDelete { return s.Write() })
+		s.le.SetRangeDeleter(func() lease.TxnDelete { return NewLeaseTxnWrite(s.Write()) })
 	}
 	s.wg.Add(2)
 	go s.syncWatchersLoop()
@@ -99,15 +121,71 @@ func (s *watchableStore) Close() error {
 
 func (s *watchableStore) NewWatchStream() WatchStream {
 	watchStreamGauge.Inc()
+	s.mu.RLock()
+	maxWatchers := s.maxStreamWatchers
+	s.mu.RUnlock()
 	return &watchStream{
-		watchable: s,
-		ch:        make(chan WatchResponse, chanBufLen),
-		cancels:   make(map[WatchID]cancelFunc),
-		watchers:  make(map[WatchID]*watcher),
+		watchable:   s,
+		ch:          make(chan WatchResponse, chanBufLen),
+		cancels:     make(map[WatchID]cancelFunc),
+		watchers:    make(map[WatchID]*watcher),
+		maxWatchers: maxWatchers,
+	}
+}
+
+func (s *watchableStore) SetWatcherLimits(max, maxPerStream int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxWatchers, s.maxStreamWatchers = max, maxPerStream
+}
+
+func (s *watchableStore) SetEventHistory(maxEvents int, maxAge time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = eventHistory{maxEvents: maxEvents, maxAge: maxAge}
+}
+
+func (s *watchableStore) SetUnsyncedWatcherLimit(max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxUnsynced = max
+}
+
+func (s *watchableStore) Watchers() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.watchers
+}
+
+func (s *watchableStore) WatchBufferBytes() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var n int64
+	for _, wb := range s.victims {
+		for _, eb := range wb {
+			n += eventsBytes(eb.evs)
+		}
+	}
+	for _, hr := range s.history.revs {
+		n += eventsBytes(hr.evs)
+	}
+	return n
+}
+
+func eventsBytes(evs []mvccpb.Event) (n int64) {
+	for i := range evs {
+		n += int64(evs[i].Size())
 	}
+	return n
+}
+
+// unsyncedRetryAfter estimates how long the sync loop takes to catch up
+// n unsynced watchers, syncing maxWatchersPerSync of them every 100ms.
+func unsyncedRetryAfter(n int) time.Duration {
+	return time.Duration(n/maxWatchersPerSync+1) * 100 * time.Millisecond
 }
 
-func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
+func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc, error) {
 	wa := &watcher{
 		key:    key,
 		end:    end,
@@ -118,8 +196,22 @@ func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch c
 	}
 
 	s.mu.Lock()
+	if s.maxWatchers > 0 && s.watchers >= s.maxWatchers {
+		s.mu.Unlock()
+		return nil, nil, ErrTooManyWatchers
+	}
 	s.revMu.RLock()
 	synced := startRev > s.store.currentRev || startRev == 0
+	if !synced && s.maxUnsynced > 0 && s.unsynced.size() >= s.maxUnsynced {
+		// syncing past revisions for more watchers only delays the
+		// ones already waiting; have the client come back later.
+		n := s.unsynced.size()
+		s.revMu.RUnlock()
+		s.mu.Unlock()
+		watchBacklogRejectedCounter.Inc()
+		return nil, nil, &BacklogError{RetryAfter: unsyncedRetryAfter(n)}
+	}
+	s.watchers++
 	if synced {
 		wa.minRev = s.store.currentRev + 1
 		if startRev > wa.minRev {
@@ -137,7 +229,7 @@ func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch c
 
 	watcherGauge.Inc()
 
-	return wa, func() { s.cancelWatcher(wa) }
+	return wa, func() { s.cancelWatcher(wa) }, nil
 }
 
 // cancelWatcher removes references of the watcher from the watchableStore
@@ -149,6 +241,8 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 			break
 		} else if s.synced.delete(wa) {
 			break
+		} else if s.paused.delete(wa) {
+			break
 		} else if wa.compacted {
 			break
 		} else if wa.ch == nil {
@@ -179,6 +273,9 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 	}
 
 	watcherGauge.Dec()
+	if wa.ch != nil {
+		s.watchers--
+	}
 	wa.ch = nil
 	s.mu.Unlock()
 }
@@ -190,8 +287,10 @@ func (s *watchableStore) Restore(b backend.Backend) error {
 	if err != nil {
 		return err
 	}
+	s.history.reset()
 
 	for wa := range s.synced.watchers {
+		atomic.AddInt64(&wa.resyncs, 1)
 		s.unsynced.watchers.add(wa)
 	}
 	s.synced = newWatcherGroup()
@@ -294,6 +393,11 @@ func (s *watchableStore) moveVictims() (moved int) {
 			if eb.moreRev != 0 {
 				w.minRev = eb.moreRev
 			}
+			if w.paused {
+				slowWatcherGauge.Dec()
+				s.paused.add(w)
+				continue
+			}
 			if w.minRev <= curRev {
 				s.unsynced.add(w)
 			} else {
@@ -337,17 +441,26 @@ func (s *watchableStore) syncWatchers() int {
 	compactionRev := s.store.compactMainRev
 
 	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactionRev)
-	minBytes, maxBytes := newRevBytes(), newRevBytes()
-	revToBytes(revision{main: minRev}, minBytes)
-	revToBytes(revision{main: curRev + 1}, maxBytes)
-
-	// UnsafeRange returns keys and values. And in boltdb, keys are revisions.
-	// values are actual key-value pairs in backend.
-	tx := s.store.b.ReadTx()
-	tx.Lock()
-	revs, vs := tx.UnsafeRange(keyBucketName, minBytes, maxBytes, 0)
-	evs := kvsToEvents(wg, revs, vs)
-	tx.Unlock()
+
+	var evs []mvccpb.Event
+	if hevs, ok := s.history.since(minRev); ok {
+		// notify adds to history under s.mu, so it ends at curRev
+		evs = filterEvents(wg, hevs)
+		watcherSyncCounter.WithLabelValues("memory").Inc()
+	} else {
+		minBytes, maxBytes := newRevBytes(), newRevBytes()
+		revToBytes(revision{main: minRev}, minBytes)
+		revToBytes(revision{main: curRev + 1}, maxBytes)
+
+		// UnsafeRange returns keys and values. And in boltdb, keys are revisions.
+		// values are actual key-value pairs in backend.
+		tx := s.store.b.ReadTx()
+		tx.Lock()
+		revs, vs := tx.UnsafeRange(keyBucketName, minBytes, maxBytes, 0)
+		evs = kvsToEvents(wg, revs, vs)
+		tx.Unlock()
+		watcherSyncCounter.WithLabelValues("backend").Inc()
+	}
 
 	var victims watcherBatch
 	wb := newWatcherBatch(wg, evs)
@@ -400,29 +513,62 @@ func (s *watchableStore) syncWatchers() int {
 // kvsToEvents gets all events for the watchers from all key-value pairs
 func kvsToEvents(wg *watcherGroup, revs, vals [][]byte) (evs []mvccpb.Event) {
 	for i, v := range vals {
-		var kv mvccpb.KeyValue
-		if err := kv.Unmarshal(v); err != nil {
-			plog.Panicf("cannot unmarshal event: %v", err)
+		// skip decoding, and copying the values of, keys nobody watches
+		key, ok := unsafeKeyOf(v)
+		if ok && !wg.contains(string(key)) {
+			continue
 		}
 
-		if !wg.contains(string(kv.Key)) {
+		ev := kvToEvent(revs[i], v)
+		if !ok && !wg.contains(string(ev.Kv.Key)) {
 			continue
 		}
+		evs = append(evs, ev)
+	}
+	return evs
+}
 
-		ty := mvccpb.PUT
-		if isTombstone(revs[i]) {
-			ty = mvccpb.DELETE
-			// patch in mod revision so watchers won't skip
-			kv.ModRevision = bytesToRev(revs[i]).main
+// filterEvents returns the events on keys watched by the group.
+func filterEvents(wg *watcherGroup, evs []mvccpb.Event) (wevs []mvccpb.Event) {
+	for _, ev := range evs {
+		if wg.contains(string(ev.Kv.Key)) {
+			wevs = append(wevs, ev)
 		}
-		evs = append(evs, mvccpb.Event{Kv: &kv, Type: ty})
 	}
-	return evs
+	return wevs
+}
+
+// kvToEvent decodes the key-value pair stored at a backend revision key
+// into the event that wrote it.
+func kvToEvent(rev, v []byte) mvccpb.Event {
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(v); err != nil {
+		plog.Panicf("cannot unmarshal event: %v", err)
+	}
+
+	ty := mvccpb.PUT
+	if isTombstone(rev) {
+		ty = mvccpb.DELETE
+		// patch in mod revision so watchers won't skip
+		kv.ModRevision = bytesToRev(rev).main
+		return mvccpb.Event{Kv: &kv, Type: ty, Reason: deleteReason(&kv)}
+	}
+	return mvccpb.Event{Kv: &kv, Type: ty}
+}
+
+// deleteReason tells why the key of a tombstone was deleted. Only
+// tombstones written by revoking a lease keep the lease of the key.
+func deleteReason(kv *mvccpb.KeyValue) mvccpb.Event_DeleteReason {
+	if kv.Lease != 0 {
+		return mvccpb.LEASE_REVOKED
+	}
+	return mvccpb.REQUESTED
 }
 
 // notify notifies the fact that given event at the given rev just happened to
 // watchers that watch on the key of the event.
 func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
+	s.history.add(rev, evs, time.Now())
 	var victim watcherBatch
 	for w, eb := range newWatcherBatch(&s.synced, evs) {
 		if eb.revs != 1 {
@@ -439,6 +585,7 @@ func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
 			w.victim = true
 			victim[w] = eb
 			s.synced.delete(w)
+			atomic.AddInt64(&w.resyncs, 1)
 			slowWatcherGauge.Inc()
 		}
 	}
@@ -458,6 +605,78 @@ func (s *watchableStore) addVictim(victim watcherBatch) {
 
 func (s *watchableStore) rev() int64 { return s.store.Rev() }
 
+func (s *watchableStore) stats(w *watcher) WatcherStats {
+	s.mu.RLock()
+	// the watcher has observed the store up to, but not including, w.minRev
+	rev := w.minRev - 1
+	if w.victim {
+		// minRev is past the events still waiting to be sent
+		for _, wb := range s.victims {
+			if eb := wb[w]; eb != nil && len(eb.evs) != 0 {
+				rev = eb.evs[0].Kv.ModRevision - 1
+				break
+			}
+		}
+	}
+	lag := s.rev() - rev
+	s.mu.RUnlock()
+	if lag < 0 {
+		// watching from a future revision
+		lag = 0
+	}
+	return WatcherStats{
+		Delivered: atomic.LoadInt64(&w.delivered),
+		Dropped:   atomic.LoadInt64(&w.dropped),
+		Resyncs:   atomic.LoadInt64(&w.resyncs),
+		Lag:       lag,
+	}
+}
+
+// pause stops sending events to the watcher until it is resumed. A victim
+// is paused once its pending events are sent.
+func (s *watchableStore) pause(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.paused || w.ch == nil || w.compacted {
+		return
+	}
+	w.paused = true
+	if s.synced.delete(w) {
+		// notify has sent the watcher every event up to the current revision
+		s.store.revMu.RLock()
+		if rev := s.store.currentRev + 1; w.minRev < rev {
+			w.minRev = rev
+		}
+		s.store.revMu.RUnlock()
+		s.paused.add(w)
+	} else if s.unsynced.delete(w) {
+		slowWatcherGauge.Dec()
+		s.paused.add(w)
+	}
+}
+
+// resume syncs a paused watcher from the revision it was paused at.
+func (s *watchableStore) resume(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !w.paused {
+		return
+	}
+	w.paused = false
+	if !s.paused.delete(w) {
+		// still a victim; moveVictims assigns it
+		return
+	}
+	s.store.revMu.RLock()
+	if w.minRev <= s.store.currentRev {
+		slowWatcherGauge.Inc()
+		s.unsynced.add(w)
+	} else {
+		s.synced.add(w)
+	}
+	s.store.revMu.RUnlock()
+}
+
 func (s *watchableStore) progress(w *watcher) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -470,6 +689,13 @@ func (s *watchableStore) progress(w *watcher) {
 }
 
 type watcher struct {
+	// delivered, dropped and resyncs count the events sent, the responses
+	// not sent on a full channel and the times the watcher fell behind.
+	// They are accessed through atomics so must be 64-bit aligned.
+	delivered int64
+	dropped   int64
+	resyncs   int64
+
 	// the watcher key
 	key []byte
 	// end indicates the end of the range to watch.
@@ -482,6 +708,9 @@ type watcher struct {
 	// compacted is set when the watcher is removed because of compaction
 	compacted bool
 
+	// paused is set while the watcher's stream does not take its events
+	paused bool
+
 	// minRev is the minimum revision update the watcher will accept
 	minRev int64
 	id     WatchID
@@ -490,6 +719,9 @@ type watcher struct {
 	// a chan to send out the watch response.
 	// The chan might be shared with other watchers.
 	ch chan<- WatchResponse
+
+	// dc checks the order of the events sent on ch
+	dc deliveryCheck
 }
 
 func (w *watcher) send(wr WatchResponse) bool {
@@ -518,8 +750,11 @@ func (w *watcher) send(wr WatchResponse) bool {
 	}
 	select {
 	case w.ch <- wr:
+		w.dc.record(w.id, wr.Events)
+		atomic.AddInt64(&w.delivered, int64(len(wr.Events)))
 		return true
 	default:
+		atomic.AddInt64(&w.dropped, 1)
 		return false
 	}
 }
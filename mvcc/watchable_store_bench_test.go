@@ -15,12 +15,14 @@
 package mvcc
 
 import (
+	"fmt"
 	"math/rand"
 	"os"
 	"testing"
 
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 )
 
 func BenchmarkWatchableStorePut(b *testing.B) {
@@ -78,7 +80,7 @@ func BenchmarkWatchableStoreWatchSyncPut(b *testing.B) {
 	watchIDs := make([]WatchID, b.N)
 	for i := range watchIDs {
 		// non-0 value to keep watchers in unsynced
-		watchIDs[i] = w.Watch(k, nil, 1)
+		watchIDs[i], _ = w.Watch(k, nil, 1)
 	}
 
 	b.ResetTimer()
@@ -142,7 +144,7 @@ func BenchmarkWatchableStoreUnsyncedCancel(b *testing.B) {
 	watchIDs := make([]WatchID, watcherN)
 	for i := 0; i < watcherN; i++ {
 		// non-0 value to keep watchers in unsynced
-		watchIDs[i] = w.Watch(testKey, nil, 1)
+		watchIDs[i], _ = w.Watch(testKey, nil, 1)
 	}
 
 	// random-cancel N watchers to make it not biased towards
@@ -182,7 +184,7 @@ func BenchmarkWatchableStoreSyncedCancel(b *testing.B) {
 	watchIDs := make([]WatchID, watcherN)
 	for i := 0; i < watcherN; i++ {
 		// 0 for startRev to keep watchers in synced
-		watchIDs[i] = w.Watch(testKey, nil, 0)
+		watchIDs[i], _ = w.Watch(testKey, nil, 0)
 	}
 
 	// randomly cancel watchers to make it not biased towards
@@ -198,3 +200,190 @@ func BenchmarkWatchableStoreSyncedCancel(b *testing.B) {
 		}
 	}
 }
+
+// newBenchWatchableStore creates a watchableStore without the background
+// sync loops so benchmarks control when watchers are notified and synced.
+func newBenchWatchableStore() (*watchableStore, func()) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	ws := &watchableStore{
+		store:    NewStore(be, &lease.FakeLessor{}, nil),
+		victimc:  make(chan struct{}, 1),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+		stopc:    make(chan struct{}),
+	}
+	return ws, func() {
+		ws.store.Close()
+		be.Close()
+		os.Remove(tmpPath)
+	}
+}
+
+// drainWatchResponses consumes responses until ch is closed so watchers
+// sharing ch never become victims.
+func drainWatchResponses(ch chan WatchResponse) {
+	go func() {
+		for range ch {
+		}
+	}()
+}
+
+func benchKey(i int) []byte { return []byte(fmt.Sprintf("foo%07d", i)) }
+
+func BenchmarkWatchableStoreNotifySynced1(b *testing.B) {
+	benchmarkWatchableStoreNotify(b, 1, false)
+}
+func BenchmarkWatchableStoreNotifySynced1K(b *testing.B) {
+	benchmarkWatchableStoreNotify(b, 1000, false)
+}
+func BenchmarkWatchableStoreNotifySynced100K(b *testing.B) {
+	benchmarkWatchableStoreNotify(b, 100000, false)
+}
+func BenchmarkWatchableStoreNotifyPrefix1K(b *testing.B) {
+	benchmarkWatchableStoreNotify(b, 1000, true)
+}
+func BenchmarkWatchableStoreNotifyPrefix100K(b *testing.B) {
+	benchmarkWatchableStoreNotify(b, 100000, true)
+}
+
+// benchmarkWatchableStoreNotify benchmarks notifying a single key update
+// to watcherN synced watchers, each watching its own key, or its own key
+// prefix if prefix is set. Events are for random watched keys.
+func benchmarkWatchableStoreNotify(b *testing.B, watcherN int, prefix bool) {
+	s, cleanup := newBenchWatchableStore()
+	defer cleanup()
+
+	ch := make(chan WatchResponse, chanBufLen)
+	drainWatchResponses(ch)
+	defer close(ch)
+
+	for i := 0; i < watcherN; i++ {
+		key := benchKey(i)
+		var end []byte
+		if prefix {
+			end = append(append([]byte{}, key...), 0xff)
+		}
+		s.watch(key, end, 0, WatchID(i), ch)
+	}
+
+	evs := make([][]mvccpb.Event, b.N)
+	for i := range evs {
+		key := benchKey(rand.Intn(watcherN))
+		if prefix {
+			key = append(key, "/bar"...)
+		}
+		evs[i] = []mvccpb.Event{{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: key, ModRevision: int64(i + 2)}}}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.mu.Lock()
+		s.notify(int64(i+2), evs[i])
+		s.mu.Unlock()
+	}
+}
+
+// BenchmarkWatchableStoreNotifyChanFull benchmarks notifying watchers whose
+// channel is full, which moves every one of them to the victims.
+func BenchmarkWatchableStoreNotifyChanFull(b *testing.B) {
+	s, cleanup := newBenchWatchableStore()
+	defer cleanup()
+
+	const watcherN = 1000
+	key := []byte("foo")
+	// unbuffered and never read, so every send fails
+	ch := make(chan WatchResponse)
+	for i := 0; i < watcherN; i++ {
+		s.watch(key, nil, 0, WatchID(i), ch)
+	}
+	// watchers start at the revision after the store's current revision
+	rev := s.rev() + 1
+	evs := []mvccpb.Event{{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: key, ModRevision: rev}}}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.mu.Lock()
+		s.notify(rev, evs)
+		if s.synced.size() != 0 {
+			b.Fatalf("synced = %d, want 0", s.synced.size())
+		}
+
+		b.StopTimer()
+		// bring the victims back to synced for the next round
+		for _, wb := range s.victims {
+			for w := range wb {
+				w.victim = false
+				w.minRev = rev
+				s.synced.add(w)
+			}
+		}
+		s.victims = nil
+		s.mu.Unlock()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkWatchableStoreSyncWatchers1K(b *testing.B) {
+	benchmarkWatchableStoreSyncWatchers(b, 1000, false)
+}
+func BenchmarkWatchableStoreSyncWatchers10K(b *testing.B) {
+	benchmarkWatchableStoreSyncWatchers(b, 10000, false)
+}
+func BenchmarkWatchableStoreSyncWatchersPrefix10K(b *testing.B) {
+	benchmarkWatchableStoreSyncWatchers(b, 10000, true)
+}
+
+// benchmarkWatchableStoreSyncWatchers benchmarks catching up watcherN
+// unsynced watchers from the first revision of a 10,000 revision backlog.
+func benchmarkWatchableStoreSyncWatchers(b *testing.B, watcherN int, prefix bool) {
+	s, cleanup := newBenchWatchableStore()
+	defer cleanup()
+
+	const keyN, revsPerKey = 1000, 10
+	for r := 0; r < revsPerKey; r++ {
+		for k := 0; k < keyN; k++ {
+			s.store.Put(benchKey(k), []byte("bar"), lease.NoLease)
+		}
+	}
+
+	// large enough to hold every response of a full catch up so no
+	// watcher becomes a victim; drained between iterations
+	ch := make(chan WatchResponse, watcherN*(keyN*revsPerKey/watchBatchMaxRevs+2))
+
+	ws := make([]*watcher, watcherN)
+	for i := range ws {
+		key := benchKey(i % keyN)
+		var end []byte
+		if prefix {
+			// watch ranges of 10 keys
+			key = benchKey(i % keyN / 10 * 10)
+			end = benchKey(i%keyN/10*10 + 10)
+		}
+		ws[i], _, _ = s.watch(key, end, 1, WatchID(i), ch)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for s.syncWatchers() != 0 {
+		}
+
+		b.StopTimer()
+		for len(ch) > 0 {
+			<-ch
+		}
+		s.mu.Lock()
+		if len(s.victims) != 0 {
+			b.Fatalf("unexpected victims")
+		}
+		for _, w := range ws {
+			s.synced.delete(w)
+			w.minRev = 1
+			s.unsynced.add(w)
+		}
+		s.mu.Unlock()
+		b.StartTimer()
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
 	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	dto "github.com/prometheus/client_model/go"
 )
 
 func TestWatch(t *testing.T) {
@@ -63,7 +65,7 @@ func TestNewWatcherCancel(t *testing.T) {
 	s.Put(testKey, testValue, lease.NoLease)
 
 	w := s.NewWatchStream()
-	wt := w.Watch(testKey, nil, 0)
+	wt, _ := w.Watch(testKey, nil, 0)
 
 	if err := w.Cancel(wt); err != nil {
 		t.Error(err)
@@ -114,7 +116,7 @@ func TestCancelUnsynced(t *testing.T) {
 	watchIDs := make([]WatchID, watcherN)
 	for i := 0; i < watcherN; i++ {
 		// use 1 to keep watchers in unsynced
-		watchIDs[i] = w.Watch(testKey, nil, 1)
+		watchIDs[i], _ = w.Watch(testKey, nil, 1)
 	}
 
 	for _, idx := range watchIDs {
@@ -132,6 +134,108 @@ func TestCancelUnsynced(t *testing.T) {
 	}
 }
 
+// TestSyncWatchersFromHistory ensures unsynced watchers covered by the
+// event history sync from memory and receive the same events.
+func TestSyncWatchersFromHistory(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+	}
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	// write through the watchable store so puts reach the history
+	s.store.WriteView = &writeView{s}
+	s.SetEventHistory(10, 0)
+	testKey := []byte("foo")
+	for i := 0; i < 3; i++ {
+		s.Put(testKey, []byte("bar"), lease.NoLease)
+	}
+	s.Put([]byte("other"), []byte("bar"), lease.NoLease)
+
+	w := s.NewWatchStream()
+	w.Watch(testKey, nil, 3)
+
+	var m dto.Metric
+	watcherSyncCounter.WithLabelValues("memory").Write(&m)
+	memorySyncs := m.GetCounter().GetValue()
+
+	s.syncWatchers()
+
+	watcherSyncCounter.WithLabelValues("memory").Write(&m)
+	if m.GetCounter().GetValue() != memorySyncs+1 {
+		t.Fatalf("memory syncs = %v, want %v", m.GetCounter().GetValue(), memorySyncs+1)
+	}
+	if s.unsynced.size() != 0 {
+		t.Fatalf("unsynced size = %d, want 0", s.unsynced.size())
+	}
+
+	select {
+	case resp := <-w.Chan():
+		if len(resp.Events) != 2 || resp.Events[0].Kv.ModRevision != 3 || resp.Events[1].Kv.ModRevision != 4 {
+			t.Fatalf("events = %v, want puts of foo at revisions 3 and 4", resp.Events)
+		}
+	default:
+		t.Fatalf("no events after sync")
+	}
+}
+
+// TestWatchUnsyncedLimit ensures watches on past revisions are refused with a
+// retry hint while the unsynced watcher backlog is at the limit, and that watches
+// from the current revision are still admitted.
+func TestWatchUnsyncedLimit(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+	}
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	testKey := []byte("foo")
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	s.SetUnsyncedWatcherLimit(2)
+
+	w := s.NewWatchStream()
+	for i := 0; i < 2; i++ {
+		if _, err := w.Watch(testKey, nil, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := w.Watch(testKey, nil, 1)
+	be, ok := err.(*BacklogError)
+	if !ok {
+		t.Fatalf("err = %v, want *BacklogError", err)
+	}
+	if be.RetryAfter <= 0 {
+		t.Fatalf("RetryAfter = %v, want > 0", be.RetryAfter)
+	}
+	if n := s.unsynced.size(); n != 2 {
+		t.Fatalf("unsynced size = %d, want 2", n)
+	}
+
+	if _, err = w.Watch(testKey, nil, 0); err != nil {
+		t.Fatalf("err = %v for watch from current revision, want nil", err)
+	}
+
+	s.syncWatchers()
+	if _, err = w.Watch(testKey, nil, 1); err != nil {
+		t.Fatalf("err = %v after sync, want nil", err)
+	}
+}
+
 // TestSyncWatchers populates unsynced watcher map and tests syncWatchers
 // method to see if it correctly sends events to channel of unsynced watchers
 // and moves these watchers to synced.
@@ -242,7 +346,7 @@ func TestWatchCompacted(t *testing.T) {
 	}
 
 	w := s.NewWatchStream()
-	wt := w.Watch(testKey, nil, compactRev-1)
+	wt, _ := w.Watch(testKey, nil, compactRev-1)
 
 	select {
 	case resp := <-w.Chan():
@@ -540,6 +644,120 @@ func TestWatchVictims(t *testing.T) {
 	}
 }
 
+// TestWatchEventOrdering ensures every watcher receives each of its events
+// exactly once and in revision order while watchers sync, become victims
+// and get notified concurrently. Build with the watch_invariants tag to also
+// check each response as it is sent.
+func TestWatchEventOrdering(t *testing.T) {
+	oldChanBufLen, oldMaxWatchersPerSync := chanBufLen, maxWatchersPerSync
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+		chanBufLen, maxWatchersPerSync = oldChanBufLen, oldMaxWatchersPerSync
+	}()
+
+	chanBufLen, maxWatchersPerSync = 1, 2
+
+	keys := [][]byte{[]byte("k0"), []byte("k1"), []byte("k2"), []byte("k3")}
+	for _, k := range keys {
+		s.Put(k, []byte("v"), lease.NoLease)
+	}
+
+	type watchReq struct{ key, end []byte }
+	reqs := []watchReq{
+		{keys[0], []byte("k4")},
+		{keys[1], nil},
+		{keys[1], keys[3]},
+		{keys[3], nil},
+	}
+	numStreams, numPuts := 8, 200
+
+	// a watcher from revision 1 gets every event on its range
+	want := func(r watchReq, k []byte) bool {
+		if r.end == nil {
+			return bytes.Equal(k, r.key)
+		}
+		return bytes.Compare(k, r.key) >= 0 && bytes.Compare(k, r.end) < 0
+	}
+	expected := make([]int, len(reqs))
+	for i, r := range reqs {
+		for _, k := range keys {
+			if want(r, k) {
+				// initial put and one per round
+				expected[i] += 1 + numPuts/len(keys)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	errc := make(chan error, numStreams)
+	wg.Add(numStreams)
+	for i := 0; i < numStreams; i++ {
+		w := s.NewWatchStream()
+		for _, r := range reqs {
+			if _, err := w.Watch(r.key, r.end, 1); err != nil {
+				t.Fatal(err)
+			}
+		}
+		go func() {
+			defer func() {
+				w.Close()
+				wg.Done()
+			}()
+			got := make([]int, len(reqs))
+			lastRev := make([]int64, len(reqs))
+			lastKey := make([][]byte, len(reqs))
+			tc := time.After(10 * time.Second)
+			for !reflect.DeepEqual(got, expected) {
+				select {
+				case <-tc:
+					errc <- fmt.Errorf("timed out with %v events, want %v", got, expected)
+					return
+				case wr := <-w.Chan():
+					id := wr.WatchID
+					for _, ev := range wr.Events {
+						rev, k := ev.Kv.ModRevision, ev.Kv.Key
+						if rev < lastRev[id] || (rev == lastRev[id] && bytes.Equal(k, lastKey[id])) {
+							errc <- fmt.Errorf("watcher %d got %q at %d after %q at %d", id, k, rev, lastKey[id], lastRev[id])
+							return
+						}
+						lastRev[id], lastKey[id] = rev, k
+						got[id]++
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, expected) {
+				errc <- fmt.Errorf("got %v events, want %v", got, expected)
+			}
+		}()
+	}
+
+	// write two keys per revision half of the time
+	for i := 0; i < numPuts; i += 2 {
+		k1, k2 := keys[i%len(keys)], keys[(i+1)%len(keys)]
+		if i%4 == 0 {
+			txn := s.Write()
+			txn.Put(k1, []byte("v"), lease.NoLease)
+			txn.Put(k2, []byte("v"), lease.NoLease)
+			txn.End()
+		} else {
+			s.Put(k1, []byte("v"), lease.NoLease)
+			s.Put(k2, []byte("v"), lease.NoLease)
+		}
+	}
+
+	wg.Wait()
+	select {
+	case err := <-errc:
+		t.Fatal(err)
+	default:
+	}
+}
+
 // TestStressWatchCancelClose tests closing a watch stream while
 // canceling its watches.
 func TestStressWatchCancelClose(t *testing.T) {
@@ -561,7 +779,7 @@ func TestStressWatchCancelClose(t *testing.T) {
 			w := s.NewWatchStream()
 			ids := make([]WatchID, 10)
 			for i := range ids {
-				ids[i] = w.Watch(testKey, nil, 0)
+				ids[i], _ = w.Watch(testKey, nil, 0)
 			}
 			<-readyc
 			wg.Add(1 + len(ids)/2)
@@ -585,3 +803,62 @@ func TestStressWatchCancelClose(t *testing.T) {
 
 	wg.Wait()
 }
+
+// TestWatchDeleteReason ensures delete events tell keys deleted by revoking
+// their lease apart from requested deletes, whether the events are notified
+// or read back from the backend.
+func TestWatchDeleteReason(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	le := lease.NewLessor(b, 0)
+	defer le.Stop()
+	s := newWatchableStore(b, le, nil)
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	if _, err := le.Grant(1, 1000); err != nil {
+		t.Fatal(err)
+	}
+	s.Put([]byte("foo"), []byte("bar"), 1)
+	s.Put([]byte("foo2"), []byte("bar"), lease.NoLease)
+	rev := s.Rev()
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	// synced watcher, notified of the events
+	w.Watch([]byte("foo"), []byte("fop"), rev+1)
+
+	if err := le.Revoke(1); err != nil {
+		t.Fatal(err)
+	}
+	s.DeleteRange([]byte("foo2"), nil)
+
+	// unsynced watcher, reading the events back from the backend
+	w.Watch([]byte("foo"), []byte("fop"), rev+1)
+
+	wreasons := map[string]mvccpb.Event_DeleteReason{
+		"foo":  mvccpb.LEASE_REVOKED,
+		"foo2": mvccpb.REQUESTED,
+	}
+	for i := 0; i < 2; i++ {
+		var evs []mvccpb.Event
+		for len(evs) < 2 {
+			select {
+			case resp := <-w.Chan():
+				evs = append(evs, resp.Events...)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("#%d: timed out waiting for events", i)
+			}
+		}
+		for _, ev := range evs {
+			if ev.Type != mvccpb.DELETE {
+				t.Fatalf("#%d: expected delete event, got %+v", i, ev)
+			}
+			if wr := wreasons[string(ev.Kv.Key)]; ev.Reason != wr {
+				t.Errorf("#%d: reason of %q = %v, want %v", i, ev.Kv.Key, ev.Reason, wr)
+			}
+		}
+	}
+}
@@ -32,6 +32,7 @@ func (tw *watchableStoreTxnWrite) End() {
 		if change.CreateRevision == 0 {
 			evs[i].Type = mvccpb.DELETE
 			evs[i].Kv.ModRevision = rev
+			evs[i].Reason = deleteReason(evs[i].Kv)
 		} else {
 			evs[i].Type = mvccpb.PUT
 		}
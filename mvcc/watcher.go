@@ -17,15 +17,31 @@ package mvcc
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/coreos/etcd/mvcc/mvccpb"
 )
 
 var (
-	ErrWatcherNotExist = errors.New("mvcc: watcher does not exist")
+	ErrWatcherNotExist   = errors.New("mvcc: watcher does not exist")
+	ErrEmptyWatcherRange = errors.New("mvcc: watcher range is empty")
+	ErrWatchStreamClosed = errors.New("mvcc: watch stream is closed")
+	ErrTooManyWatchers   = errors.New("mvcc: too many watchers")
 )
 
+// BacklogError is returned by Watch when a watch on past revisions is
+// refused because too many watchers are still catching up with the store.
+// RetryAfter estimates how long the current backlog takes to sync.
+type BacklogError struct {
+	RetryAfter time.Duration
+}
+
+func (e *BacklogError) Error() string {
+	return fmt.Sprintf("mvcc: too many unsynced watchers, retry after %v", e.RetryAfter)
+}
+
 type WatchID int64
 
 // FilterFunc returns true if the given event should be filtered out.
@@ -40,8 +56,12 @@ type WatchStream interface {
 	//
 	// The returned `id` is the ID of this watcher. It appears as WatchID
 	// in events that are sent to the created watcher through stream channel.
+	// The `id` is -1 if the watcher cannot be created, and ErrTooManyWatchers
+	// is returned if the KV or the stream already has its maximum number of
+	// watchers. A *BacklogError is returned if startRev is in the past while
+	// the KV has more unsynced watchers than it admits.
 	//
-	Watch(key, end []byte, startRev int64, fcs ...FilterFunc) WatchID
+	Watch(key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error)
 
 	// Chan returns a chan. All watch response will be sent to the returned chan.
 	Chan() <-chan WatchResponse
@@ -54,6 +74,15 @@ type WatchStream interface {
 	// of the watchers since the watcher is currently synced.
 	RequestProgress(id WatchID)
 
+	// Pause stops sending the events of the watcher with the given ID to
+	// Chan, though responses already sent may still arrive. A paused watcher
+	// falls behind the store, and once resumed it is synced from the
+	// revision it was paused at.
+	Pause(id WatchID)
+
+	// Resume resumes sending the events of a paused watcher.
+	Resume(id WatchID)
+
 	// Cancel cancels a watcher by giving its ID. If watcher does not exist, an error will be
 	// returned.
 	Cancel(id WatchID) error
@@ -63,6 +92,23 @@ type WatchStream interface {
 
 	// Rev returns the current revision of the KV the stream watches on.
 	Rev() int64
+
+	// Stats returns the statistics of the stream's watchers by their IDs.
+	Stats() map[WatchID]WatcherStats
+}
+
+// WatcherStats holds the statistics of a watcher.
+type WatcherStats struct {
+	// Delivered is the number of events sent to the stream's channel.
+	Delivered int64
+	// Dropped is the number of responses the stream's channel was too full
+	// to take. Their events are sent again once the channel drains.
+	Dropped int64
+	// Resyncs is the number of times the watcher fell behind the store
+	// and had to be synced from past revisions.
+	Resyncs int64
+	// Lag is the number of revisions the watcher is behind the store.
+	Lag int64
 }
 
 type WatchResponse struct {
@@ -95,31 +141,39 @@ type watchStream struct {
 	closed   bool
 	cancels  map[WatchID]cancelFunc
 	watchers map[WatchID]*watcher
+	// maxWatchers is the maximum number of watchers of the stream,
+	// or 0 for no limit.
+	maxWatchers int
 }
 
 // Watch creates a new watcher in the stream and returns its WatchID.
 // TODO: return error if ws is closed?
-func (ws *watchStream) Watch(key, end []byte, startRev int64, fcs ...FilterFunc) WatchID {
+func (ws *watchStream) Watch(key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error) {
 	// prevent wrong range where key >= end lexicographically
 	// watch request with 'WithFromKey' has empty-byte range end
 	if len(end) != 0 && bytes.Compare(key, end) != -1 {
-		return -1
+		return -1, ErrEmptyWatcherRange
 	}
 
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	if ws.closed {
-		return -1
+		return -1, ErrWatchStreamClosed
+	}
+	if ws.maxWatchers > 0 && len(ws.cancels) >= ws.maxWatchers {
+		return -1, ErrTooManyWatchers
+	}
+
+	w, c, err := ws.watchable.watch(key, end, startRev, ws.nextID, ws.ch, fcs...)
+	if err != nil {
+		return -1, err
 	}
 
 	id := ws.nextID
 	ws.nextID++
-
-	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, fcs...)
-
 	ws.cancels[id] = c
 	ws.watchers[id] = w
-	return id
+	return id, nil
 }
 
 func (ws *watchStream) Chan() <-chan WatchResponse {
@@ -169,6 +223,16 @@ func (ws *watchStream) Rev() int64 {
 	return ws.watchable.rev()
 }
 
+func (ws *watchStream) Stats() map[WatchID]WatcherStats {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	stats := make(map[WatchID]WatcherStats, len(ws.watchers))
+	for id, w := range ws.watchers {
+		stats[id] = ws.watchable.stats(w)
+	}
+	return stats
+}
+
 func (ws *watchStream) RequestProgress(id WatchID) {
 	ws.mu.Lock()
 	w, ok := ws.watchers[id]
@@ -178,3 +242,23 @@ func (ws *watchStream) RequestProgress(id WatchID) {
 	}
 	ws.watchable.progress(w)
 }
+
+func (ws *watchStream) Pause(id WatchID) {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	ws.mu.Unlock()
+	if !ok {
+		return
+	}
+	ws.watchable.pause(w)
+}
+
+func (ws *watchStream) Resume(id WatchID) {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	ws.mu.Unlock()
+	if !ok {
+		return
+	}
+	ws.watchable.resume(w)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc/backend"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 )
 
 func BenchmarkKVWatcherMemoryUsage(b *testing.B) {
@@ -36,3 +37,34 @@ func BenchmarkKVWatcherMemoryUsage(b *testing.B) {
 		w.Watch([]byte(fmt.Sprint("foo", i)), nil, 0)
 	}
 }
+
+func BenchmarkNewWatcherBatchKeys(b *testing.B)   { benchmarkNewWatcherBatch(b, false) }
+func BenchmarkNewWatcherBatchRanges(b *testing.B) { benchmarkNewWatcherBatch(b, true) }
+
+// benchmarkNewWatcherBatch benchmarks mapping a batch of 100 events to 1000
+// watchers of distinct keys, or of distinct key ranges if ranges is set.
+func benchmarkNewWatcherBatch(b *testing.B, ranges bool) {
+	const watcherN, eventN = 1000, 100
+
+	wg := newWatcherGroup()
+	for i := 0; i < watcherN; i++ {
+		w := &watcher{key: []byte(fmt.Sprintf("foo%04d", i)), minRev: 1}
+		if ranges {
+			w.end = []byte(fmt.Sprintf("foo%04d\xff", i))
+		}
+		wg.add(w)
+	}
+	evs := make([]mvccpb.Event, eventN)
+	for i := range evs {
+		key := []byte(fmt.Sprintf("foo%04d", i*watcherN/eventN))
+		evs[i] = mvccpb.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: key, ModRevision: 2}}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if wb := newWatcherBatch(&wg, evs); len(wb) != eventN {
+			b.Fatalf("got %d watchers, want %d", len(wb), eventN)
+		}
+	}
+}
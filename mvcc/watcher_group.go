@@ -66,15 +66,6 @@ func (eb *eventBatch) add(ev mvccpb.Event) {
 
 type watcherBatch map[*watcher]*eventBatch
 
-func (wb watcherBatch) add(w *watcher, ev mvccpb.Event) {
-	eb := wb[w]
-	if eb == nil {
-		eb = &eventBatch{}
-		wb[w] = eb
-	}
-	eb.add(ev)
-}
-
 // newWatcherBatch maps watchers to their matched events. It enables quick
 // events look up by watcher.
 func newWatcherBatch(wg *watcherGroup, evs []mvccpb.Event) watcherBatch {
@@ -83,13 +74,25 @@ func newWatcherBatch(wg *watcherGroup, evs []mvccpb.Event) watcherBatch {
 	}
 
 	wb := make(watcherBatch)
+	// allocate event batches in chunks instead of one by one
+	var ebs []eventBatch
 	for _, ev := range evs {
-		for w := range wg.watcherSetByKey(string(ev.Kv.Key)) {
-			if ev.Kv.ModRevision >= w.minRev {
+		wg.visitByKey(ev.Kv.Key, func(w *watcher) {
+			if ev.Kv.ModRevision < w.minRev {
 				// don't double notify
-				wb.add(w, ev)
+				return
 			}
-		}
+			eb := wb[w]
+			if eb == nil {
+				if len(ebs) == cap(ebs) {
+					ebs = make([]eventBatch, 0, len(evs))
+				}
+				ebs = append(ebs, eventBatch{})
+				eb = &ebs[len(ebs)-1]
+				wb[w] = eb
+			}
+			eb.add(ev)
+		})
 	}
 	return wb
 }
@@ -257,6 +260,24 @@ func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
 	return minRev
 }
 
+// visitByKey calls f on every watcher that receives events on the given key.
+// Each watcher watches a single key or range, so it is visited at most once.
+func (wg *watcherGroup) visitByKey(key []byte, f func(*watcher)) {
+	// indexing with the converted key does not allocate
+	for w := range wg.keyWatchers[string(key)] {
+		f(w)
+	}
+	if wg.ranges.Len() == 0 {
+		return
+	}
+	wg.ranges.Visit(adt.NewStringAffinePoint(string(key)), func(iv *adt.IntervalValue) bool {
+		for w := range iv.Val.(watcherSet) {
+			f(w)
+		}
+		return true
+	})
+}
+
 // watcherSetByKey gets the set of watchers that receive events on the given key.
 func (wg *watcherGroup) watcherSetByKey(key string) watcherSet {
 	wkeys := wg.keyWatchers[key]
@@ -40,7 +40,7 @@ func TestWatcherWatchID(t *testing.T) {
 	idm := make(map[WatchID]struct{})
 
 	for i := 0; i < 10; i++ {
-		id := w.Watch([]byte("foo"), nil, 0)
+		id, _ := w.Watch([]byte("foo"), nil, 0)
 		if _, ok := idm[id]; ok {
 			t.Errorf("#%d: id %d exists", i, id)
 		}
@@ -62,7 +62,7 @@ func TestWatcherWatchID(t *testing.T) {
 
 	// unsynced watchers
 	for i := 10; i < 20; i++ {
-		id := w.Watch([]byte("foo2"), nil, 1)
+		id, _ := w.Watch([]byte("foo2"), nil, 1)
 		if _, ok := idm[id]; ok {
 			t.Errorf("#%d: id %d exists", i, id)
 		}
@@ -95,7 +95,7 @@ func TestWatcherWatchPrefix(t *testing.T) {
 	keyWatch, keyEnd, keyPut := []byte("foo"), []byte("fop"), []byte("foobar")
 
 	for i := 0; i < 10; i++ {
-		id := w.Watch(keyWatch, keyEnd, 0)
+		id, _ := w.Watch(keyWatch, keyEnd, 0)
 		if _, ok := idm[id]; ok {
 			t.Errorf("#%d: unexpected duplicated id %x", i, id)
 		}
@@ -127,7 +127,7 @@ func TestWatcherWatchPrefix(t *testing.T) {
 
 	// unsynced watchers
 	for i := 10; i < 15; i++ {
-		id := w.Watch(keyWatch1, keyEnd1, 1)
+		id, _ := w.Watch(keyWatch1, keyEnd1, 1)
 		if _, ok := idm[id]; ok {
 			t.Errorf("#%d: id %d exists", i, id)
 		}
@@ -163,15 +163,96 @@ func TestWatcherWatchWrongRange(t *testing.T) {
 	w := s.NewWatchStream()
 	defer w.Close()
 
-	if id := w.Watch([]byte("foa"), []byte("foa"), 1); id != -1 {
-		t.Fatalf("key == end range given; id expected -1, got %d", id)
+	if id, err := w.Watch([]byte("foa"), []byte("foa"), 1); id != -1 || err != ErrEmptyWatcherRange {
+		t.Fatalf("key == end range given; id expected -1, got %d (%v)", id, err)
 	}
-	if id := w.Watch([]byte("fob"), []byte("foa"), 1); id != -1 {
-		t.Fatalf("key > end range given; id expected -1, got %d", id)
+	if id, err := w.Watch([]byte("fob"), []byte("foa"), 1); id != -1 || err != ErrEmptyWatcherRange {
+		t.Fatalf("key > end range given; id expected -1, got %d (%v)", id, err)
 	}
 	// watch request with 'WithFromKey' has empty-byte range end
-	if id := w.Watch([]byte("foo"), []byte{}, 1); id != 0 {
-		t.Fatalf("\x00 is range given; id expected 0, got %d", id)
+	if id, err := w.Watch([]byte("foo"), []byte{}, 1); id != 0 || err != nil {
+		t.Fatalf("\x00 is range given; id expected 0, got %d (%v)", id, err)
+	}
+}
+
+// TestWatcherLimits ensures that watchers past the store and stream
+// limits are not created, and that canceled watchers free their slots.
+func TestWatcherLimits(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := WatchableKV(newWatchableStore(b, &lease.FakeLessor{}, nil))
+	defer cleanup(s, b, tmpPath)
+	s.SetWatcherLimits(3, 2)
+
+	w1, w2 := s.NewWatchStream(), s.NewWatchStream()
+	defer w1.Close()
+	defer w2.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := w1.Watch([]byte("foo"), nil, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// per stream limit
+	if id, err := w1.Watch([]byte("foo"), nil, 0); id != -1 || err != ErrTooManyWatchers {
+		t.Fatalf("id, err = %d, %v, want -1, %v", id, err, ErrTooManyWatchers)
+	}
+	id, err := w2.Watch([]byte("foo"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// store limit
+	if _, err = w2.Watch([]byte("foo"), nil, 0); err != ErrTooManyWatchers {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyWatchers)
+	}
+
+	if err = w2.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w2.Watch([]byte("foo"), nil, 0); err != nil {
+		t.Fatalf("err = %v after cancel, want nil", err)
+	}
+}
+
+// TestWatchStreamStats ensures the stream reports the delivered and dropped
+// events, the resyncs and the lag of its watchers.
+func TestWatchStreamStats(t *testing.T) {
+	oldChanBufLen := chanBufLen
+	defer func() { chanBufLen = oldChanBufLen }()
+	chanBufLen = 1
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	id, _ := w.Watch([]byte("foo"), nil, 0)
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	// the channel is full, so the watcher becomes a victim
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+
+	// the victim stays pending until the channel is drained
+	wst := w.Stats()[id]
+	want := WatcherStats{Delivered: 1, Dropped: 1, Resyncs: 1, Lag: 1}
+	if wst != want {
+		t.Fatalf("stats = %+v, want %+v", wst, want)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-w.Chan():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("#%d: took too long to receive response", i)
+		}
+	}
+	wst = w.Stats()[id]
+	if wst.Delivered != 2 || wst.Lag != 0 {
+		t.Fatalf("stats = %+v, want 2 delivered and no lag", wst)
+	}
+
+	if _, ok := w.Stats()[id+1]; ok {
+		t.Fatalf("unexpected stats of unknown watcher %d", id+1)
 	}
 }
 
@@ -222,7 +303,7 @@ func TestWatchStreamCancelWatcherByID(t *testing.T) {
 	w := s.NewWatchStream()
 	defer w.Close()
 
-	id := w.Watch([]byte("foo"), nil, 0)
+	id, _ := w.Watch([]byte("foo"), nil, 0)
 
 	tests := []struct {
 		cancelID WatchID
@@ -284,7 +365,7 @@ func TestWatcherRequestProgress(t *testing.T) {
 	default:
 	}
 
-	id := w.Watch(notTestKey, nil, 1)
+	id, _ := w.Watch(notTestKey, nil, 1)
 	w.RequestProgress(id)
 	select {
 	case resp := <-w.Chan():
@@ -306,6 +387,59 @@ func TestWatcherRequestProgress(t *testing.T) {
 	}
 }
 
+// TestWatcherPauseResume ensures a paused watcher is sent no events, and is
+// sent the events it missed once resumed, while other watchers on the stream
+// keep receiving theirs.
+func TestWatcherPauseResume(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := WatchableKV(newWatchableStore(b, &lease.FakeLessor{}, nil))
+	defer cleanup(s, b, tmpPath)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	paused, _ := w.Watch([]byte("foo"), nil, 0)
+	other, _ := w.Watch([]byte("bar"), nil, 0)
+	w.Pause(paused)
+
+	s.Put([]byte("foo"), []byte("1"), lease.NoLease)
+	s.Put([]byte("bar"), []byte("1"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("2"), lease.NoLease)
+
+	select {
+	case resp := <-w.Chan():
+		if resp.WatchID != other || len(resp.Events) != 1 || resp.Events[0].Kv.ModRevision != 3 {
+			t.Fatalf("got %+v, expected the event of %d at revision 3", resp, other)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive the event of the other watcher")
+	}
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected %+v", resp)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	w.Resume(paused)
+	var revs []int64
+	for len(revs) < 2 {
+		select {
+		case resp := <-w.Chan():
+			if resp.WatchID != paused {
+				t.Fatalf("got %+v, expected a response of %d", resp, paused)
+			}
+			for _, ev := range resp.Events {
+				revs = append(revs, ev.Kv.ModRevision)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("got revisions %v after resume, expected [2 4]", revs)
+		}
+	}
+	if !reflect.DeepEqual(revs, []int64{2, 4}) {
+		t.Fatalf("got revisions %v after resume, expected [2 4]", revs)
+	}
+}
+
 func TestWatcherWatchWithFilter(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 	s := WatchableKV(newWatchableStore(b, &lease.FakeLessor{}, nil))
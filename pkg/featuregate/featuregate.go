@@ -0,0 +1,113 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featuregate implements named switches that let experimental
+// features ship disabled and be toggled per deployment.
+package featuregate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a gated feature.
+type Feature string
+
+// FeatureGate tracks whether each known feature is enabled. It implements
+// flag.Value, accepting comma separated Feature=bool pairs; repeated Set
+// calls accumulate.
+type FeatureGate struct {
+	mu       sync.RWMutex
+	defaults map[Feature]bool
+	enabled  map[Feature]bool
+}
+
+// New returns a FeatureGate that knows the given features, each enabled
+// or disabled by default as given.
+func New(defaults map[Feature]bool) *FeatureGate {
+	g := &FeatureGate{defaults: make(map[Feature]bool), enabled: make(map[Feature]bool)}
+	for f, v := range defaults {
+		g.defaults[f] = v
+	}
+	return g
+}
+
+// Set parses a comma separated list of Feature=bool pairs, such as
+// "FeatureA=true,FeatureB=false". Nothing is changed if any pair is
+// malformed or names an unknown feature.
+func (g *FeatureGate) Set(value string) error {
+	m := make(map[Feature]bool)
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if len(s) == 0 {
+			continue
+		}
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("missing bool value for feature %q", s)
+		}
+		f := Feature(strings.TrimSpace(kv[0]))
+		if _, ok := g.defaults[f]; !ok {
+			return fmt.Errorf("unrecognized feature %q", f)
+		}
+		v, err := strconv.ParseBool(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return fmt.Errorf("invalid value of %s=%s (%v)", f, kv[1], err)
+		}
+		m[f] = v
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for f, v := range m {
+		g.enabled[f] = v
+	}
+	return nil
+}
+
+// String returns the explicitly set features as Feature=bool pairs.
+func (g *FeatureGate) String() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	pairs := make([]string, 0, len(g.enabled))
+	for f, v := range g.enabled {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", f, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Enabled returns true if the feature is enabled, either explicitly or
+// by default. Unknown features are disabled.
+func (g *FeatureGate) Enabled(f Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if v, ok := g.enabled[f]; ok {
+		return v
+	}
+	return g.defaults[f]
+}
+
+// KnownFeatures returns the known features with their defaults, sorted by name.
+func (g *FeatureGate) KnownFeatures() []string {
+	known := make([]string, 0, len(g.defaults))
+	for f, v := range g.defaults {
+		known = append(known, fmt.Sprintf("%s=true|false (default=%t)", f, v))
+	}
+	sort.Strings(known)
+	return known
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featuregate
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	featureA Feature = "FeatureA"
+	featureB Feature = "FeatureB"
+)
+
+func TestFeatureGateSet(t *testing.T) {
+	tests := []struct {
+		values []string
+
+		wenabled map[Feature]bool
+		wstr     string
+		werr     bool
+	}{
+		{
+			wenabled: map[Feature]bool{featureA: false, featureB: true},
+		},
+		{
+			values:   []string{"FeatureA=true"},
+			wenabled: map[Feature]bool{featureA: true, featureB: true},
+			wstr:     "FeatureA=true",
+		},
+		{
+			values:   []string{"FeatureA=true, FeatureB=false"},
+			wenabled: map[Feature]bool{featureA: true, featureB: false},
+			wstr:     "FeatureA=true,FeatureB=false",
+		},
+		{
+			// repeated flags accumulate
+			values:   []string{"FeatureA=true", "FeatureB=false", "FeatureA=false"},
+			wenabled: map[Feature]bool{featureA: false, featureB: false},
+			wstr:     "FeatureA=false,FeatureB=false",
+		},
+		{
+			values:   []string{"FeatureA=true,FeatureC=true"},
+			wenabled: map[Feature]bool{featureA: false, featureB: true},
+			werr:     true,
+		},
+		{
+			values:   []string{"FeatureA"},
+			wenabled: map[Feature]bool{featureA: false, featureB: true},
+			werr:     true,
+		},
+		{
+			values:   []string{"FeatureA=yes"},
+			wenabled: map[Feature]bool{featureA: false, featureB: true},
+			werr:     true,
+		},
+	}
+	for i, tt := range tests {
+		g := New(map[Feature]bool{featureA: false, featureB: true})
+		var err error
+		for _, v := range tt.values {
+			if err = g.Set(v); err != nil {
+				break
+			}
+		}
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		for f, w := range tt.wenabled {
+			if g.Enabled(f) != w {
+				t.Errorf("#%d: %s enabled = %v, want %v", i, f, g.Enabled(f), w)
+			}
+		}
+		if !tt.werr && g.String() != tt.wstr {
+			t.Errorf("#%d: string = %q, want %q", i, g.String(), tt.wstr)
+		}
+	}
+}
+
+func TestFeatureGateKnownFeatures(t *testing.T) {
+	g := New(map[Feature]bool{featureB: true, featureA: false})
+	w := []string{"FeatureA=true|false (default=false)", "FeatureB=true|false (default=true)"}
+	if known := g.KnownFeatures(); !reflect.DeepEqual(known, w) {
+		t.Fatalf("known = %v, want %v", known, w)
+	}
+	if g.Enabled("FeatureC") {
+		t.Fatal("unknown feature should be disabled")
+	}
+}
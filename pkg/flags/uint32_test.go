@@ -0,0 +1,54 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flags
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestUint32Value(t *testing.T) {
+	tests := []struct {
+		s string
+
+		expectedVal uint32
+		expectError bool
+	}{
+		{s: "200", expectedVal: 200},
+		{s: "0", expectedVal: 0},
+		{s: "4294967295", expectedVal: 4294967295},
+		{s: "4294967296", expectError: true},
+		{s: "-1", expectError: true},
+		{s: "a", expectError: true},
+	}
+	for i, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(NewUint32Value(0), "max", "")
+		err := fs.Set("max", tt.s)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("#%d: expected error for %q", i, tt.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("#%d: unexpected error (%v)", i, err)
+			continue
+		}
+		if v := Uint32FromFlag(fs, "max"); v != tt.expectedVal {
+			t.Errorf("#%d: expected %d, got %d", i, tt.expectedVal, v)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/pkg/capnslog"
+)
+
+// Field is a structured field of a log entry. Fields are passed along with
+// the message to the unformatted capnslog functions, e.g.
+//
+//	plog.Info("compacted the index", logutil.Revision(rev))
+//
+// The JSON formatter and Loggers receive them as separate keys; text
+// formatters wrapped by NewFieldFormatter write them after the message.
+type Field struct {
+	Key   string
+	Value string
+}
+
+func (f Field) String() string { return f.Key + "=" + f.Value }
+
+// MemberID returns the field of a member ID, in hex as in the other logs.
+func MemberID(id uint64) Field {
+	return Field{Key: "member-id", Value: strconv.FormatUint(id, 16)}
+}
+
+// Revision returns the field of a store revision.
+func Revision(rev int64) Field {
+	return Field{Key: "revision", Value: strconv.FormatInt(rev, 10)}
+}
+
+// KeyPrefixHash returns the field of the hash of the prefix of key, up to
+// its last '/', so entries about the keys of an application can be told
+// apart without writing the keys to the logs.
+func KeyPrefixHash(key []byte) Field {
+	if i := bytes.LastIndexByte(key, '/'); i != -1 {
+		key = key[:i+1]
+	}
+	h := fnv.New32a()
+	h.Write(key)
+	return Field{Key: "key-prefix-hash", Value: fmt.Sprintf("%08x", h.Sum32())}
+}
+
+// splitFields returns the message of entries and their fields.
+func splitFields(entries []interface{}) (string, []Field) {
+	var fields []Field
+	msg := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		if f, ok := e.(Field); ok {
+			fields = append(fields, f)
+			continue
+		}
+		msg = append(msg, e)
+	}
+	return strings.TrimSuffix(fmt.Sprint(msg...), "\n"), fields
+}
+
+// NewFieldFormatter returns a formatter writing the fields of each entry after
+// its message, as space separated key=value pairs, to f.
+func NewFieldFormatter(f capnslog.Formatter) capnslog.Formatter {
+	return &fieldFormatter{f: f}
+}
+
+type fieldFormatter struct {
+	f capnslog.Formatter
+}
+
+func (ff *fieldFormatter) Format(pkg string, l capnslog.LogLevel, depth int, entries ...interface{}) {
+	msg, fields := splitFields(entries)
+	if len(fields) == 0 {
+		ff.f.Format(pkg, l, depth+1, entries...)
+		return
+	}
+	for _, f := range fields {
+		msg += " " + f.String()
+	}
+	ff.f.Format(pkg, l, depth+1, msg)
+}
+
+func (ff *fieldFormatter) Flush() { ff.f.Flush() }
@@ -0,0 +1,84 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/pkg/capnslog"
+)
+
+type recordFormatter struct {
+	entries [][]interface{}
+}
+
+func (f *recordFormatter) Format(pkg string, l capnslog.LogLevel, depth int, entries ...interface{}) {
+	f.entries = append(f.entries, entries)
+}
+
+func (f *recordFormatter) Flush() {}
+
+func TestFieldFormatter(t *testing.T) {
+	rf := &recordFormatter{}
+	f := NewFieldFormatter(rf)
+	f.Format("mvcc", capnslog.INFO, 0, "compacted", Revision(5), MemberID(10))
+	f.Format("mvcc", capnslog.INFO, 0, "no ", "fields")
+
+	wentries := [][]interface{}{
+		{"compacted revision=5 member-id=a"},
+		{"no ", "fields"},
+	}
+	if !reflect.DeepEqual(rf.entries, wentries) {
+		t.Fatalf("entries = %q, want %q", rf.entries, wentries)
+	}
+}
+
+func TestKeyPrefixHash(t *testing.T) {
+	a, b := KeyPrefixHash([]byte("/jobs/1")), KeyPrefixHash([]byte("/jobs/2"))
+	if a != b {
+		t.Errorf("keys under the same prefix hash to %v and %v", a, b)
+	}
+	if c := KeyPrefixHash([]byte("/users/1")); c == a {
+		t.Errorf("keys under different prefixes both hash to %v", c)
+	}
+	if a.Key != "key-prefix-hash" || len(a.Value) != 8 {
+		t.Errorf("unexpected field %v", a)
+	}
+}
+
+type recordLogger struct {
+	entries []Entry
+}
+
+func (l *recordLogger) Log(e Entry) { l.entries = append(l.entries, e) }
+
+func TestLoggerFormatter(t *testing.T) {
+	l := &recordLogger{}
+	f := NewLoggerFormatter(l, Field{Key: "name", Value: "infra0"})
+	f.Format("etcdserver", capnslog.WARNING, 0, "slow apply\n", Revision(7))
+
+	if len(l.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(l.entries))
+	}
+	e := l.entries[0]
+	if e.Pkg != "etcdserver" || e.Level != capnslog.WARNING || e.Msg != "slow apply" || e.Time.IsZero() {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	wfields := []Field{{Key: "name", Value: "infra0"}, Revision(7)}
+	if !reflect.DeepEqual(e.Fields, wfields) {
+		t.Errorf("fields = %v, want %v", e.Fields, wfields)
+	}
+}
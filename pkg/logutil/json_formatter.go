@@ -0,0 +1,83 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+)
+
+// NewJSONFormatter returns a capnslog formatter that writes each log entry
+// as a single line JSON object with "ts", "level", "pkg" and "msg" keys,
+// plus the given fields (e.g., the member name) on every entry and the
+// fields of the entry.
+func NewJSONFormatter(w io.Writer, fields map[string]string) capnslog.Formatter {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fs := make([]Field, len(keys))
+	for i, k := range keys {
+		fs[i] = Field{Key: k, Value: fields[k]}
+	}
+	return NewLoggerFormatter(NewJSONLogger(w), fs...)
+}
+
+// NewJSONLogger returns a Logger that writes each entry to w as a single line
+// JSON object, as NewJSONFormatter does.
+func NewJSONLogger(w io.Writer) Logger {
+	return &jsonLogger{w: bufio.NewWriter(w)}
+}
+
+type jsonLogger struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func (jl *jsonLogger) Log(e Entry) {
+	m := make(map[string]string, len(e.Fields)+4)
+	for _, f := range e.Fields {
+		m[f.Key] = f.Value
+	}
+	m["ts"] = e.Time.UTC().Format(time.RFC3339Nano)
+	m["level"] = e.Level.String()
+	if e.Pkg != "" {
+		m["pkg"] = e.Pkg
+	}
+	m["msg"] = e.Msg
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	jl.mu.Lock()
+	defer jl.mu.Unlock()
+	jl.w.Write(b)
+	jl.w.WriteByte('\n')
+	jl.w.Flush()
+}
+
+func (jl *jsonLogger) Flush() {
+	jl.mu.Lock()
+	defer jl.mu.Unlock()
+	jl.w.Flush()
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/coreos/pkg/capnslog"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewJSONFormatter(&buf, map[string]string{"name": "infra0"})
+	f.Format("etcdserver", capnslog.WARNING, 0, "slow fdatasync\n")
+	f.Format("wal", capnslog.INFO, 0, "saved ", 3, " entries")
+	f.Format("mvcc", capnslog.INFO, 0, "compacted", Revision(5), MemberID(0x8e9e05c52164694d))
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	wentries := []map[string]string{
+		{"name": "infra0", "level": "WARNING", "pkg": "etcdserver", "msg": "slow fdatasync"},
+		{"name": "infra0", "level": "INFO", "pkg": "wal", "msg": "saved 3 entries"},
+		{"name": "infra0", "level": "INFO", "pkg": "mvcc", "msg": "compacted", "revision": "5", "member-id": "8e9e05c52164694d"},
+	}
+	for i, line := range lines {
+		var e map[string]string
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if e["ts"] == "" {
+			t.Errorf("#%d: expected timestamp in %q", i, line)
+		}
+		for k, v := range wentries[i] {
+			if e[k] != v {
+				t.Errorf("#%d: %s = %q, want %q", i, k, e[k], v)
+			}
+		}
+	}
+}
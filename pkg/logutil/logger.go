@@ -0,0 +1,66 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+)
+
+// Logger receives the log entries of etcd, e.g. to pass them to the logging
+// system of an application embedding etcd.
+type Logger interface {
+	Log(e Entry)
+}
+
+// Entry is a log entry.
+type Entry struct {
+	Time  time.Time
+	Level capnslog.LogLevel
+	// Pkg is the package that logged the entry.
+	Pkg string
+	Msg string
+	// Fields are the fields of the entry, after the fields of the formatter.
+	Fields []Field
+}
+
+// NewLoggerFormatter returns a capnslog formatter passing each entry to l,
+// with the given fields (e.g., the member name) added to every entry.
+func NewLoggerFormatter(l Logger, fields ...Field) capnslog.Formatter {
+	return &loggerFormatter{l: l, fields: fields}
+}
+
+type loggerFormatter struct {
+	l      Logger
+	fields []Field
+}
+
+func (f *loggerFormatter) Format(pkg string, l capnslog.LogLevel, depth int, entries ...interface{}) {
+	msg, fields := splitFields(entries)
+	f.l.Log(Entry{
+		Time:   time.Now(),
+		Level:  l,
+		Pkg:    pkg,
+		Msg:    msg,
+		Fields: append(append([]Field(nil), f.fields...), fields...),
+	})
+}
+
+func (f *loggerFormatter) Flush() {
+	if fl, ok := f.l.(interface{ Flush() }); ok {
+		fl.Flush()
+	}
+}
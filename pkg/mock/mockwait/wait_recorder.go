@@ -15,6 +15,8 @@
 package mockwait
 
 import (
+	"time"
+
 	"github.com/coreos/etcd/pkg/testutil"
 	"github.com/coreos/etcd/pkg/wait"
 )
@@ -38,9 +40,17 @@ func (w *waitRecorder) Register(id uint64) <-chan interface{} {
 	w.Record(testutil.Action{Name: "Register"})
 	return nil
 }
+func (w *waitRecorder) RegisterWithDeadline(id uint64, deadline time.Time) <-chan interface{} {
+	w.Record(testutil.Action{Name: "RegisterWithDeadline"})
+	return nil
+}
 func (w *waitRecorder) Trigger(id uint64, x interface{}) {
 	w.Record(testutil.Action{Name: "Trigger"})
 }
+func (w *waitRecorder) TriggerRegisteredBefore(t time.Time, x interface{}) int {
+	w.Record(testutil.Action{Name: "TriggerRegisteredBefore"})
+	return 0
+}
 
 func (w *waitRecorder) IsRegistered(id uint64) bool {
 	panic("waitRecorder.IsRegistered() shouldn't be called")
@@ -0,0 +1,57 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestGetCipherSuite(t *testing.T) {
+	if v, ok := GetCipherSuite("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"); !ok || v != tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 {
+		t.Errorf("GetCipherSuite = %#04x, %v, want %#04x, true", v, ok, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)
+	}
+	if _, ok := GetCipherSuite("TLS_UNKNOWN"); ok {
+		t.Errorf("expected unknown cipher suite")
+	}
+}
+
+func TestGetTLSVersion(t *testing.T) {
+	if v, ok := GetTLSVersion("TLS1.2"); !ok || v != tls.VersionTLS12 {
+		t.Errorf("GetTLSVersion = %#04x, %v, want %#04x, true", v, ok, tls.VersionTLS12)
+	}
+	if _, ok := GetTLSVersion("SSL3.0"); ok {
+		t.Errorf("expected unknown TLS version")
+	}
+}
+
+func TestCheckFIPS(t *testing.T) {
+	tests := []struct {
+		suites     []uint16
+		minVersion uint16
+
+		werr bool
+	}{
+		{nil, 0, false},
+		{[]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_AES_256_GCM_SHA384}, tls.VersionTLS12, false},
+		{[]uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305}, 0, true},
+		{nil, tls.VersionTLS11, true},
+	}
+	for i, tt := range tests {
+		if err := CheckFIPS(tt.suites, tt.minVersion); (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// fipsCipherSuites are the cipher suites approved by FIPS 140-2.
+var fipsCipherSuites = map[uint16]bool{
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256:         true,
+	tls.TLS_RSA_WITH_AES_256_GCM_SHA384:         true,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   true,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: true,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   true,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: true,
+}
+
+// CheckFIPS returns an error if the cipher suites or minimum TLS version
+// are not approved by FIPS 140-2. A minimum version of 0 is the default,
+// TLS 1.2.
+func CheckFIPS(suites []uint16, minVersion uint16) error {
+	if minVersion != 0 && minVersion < tls.VersionTLS12 {
+		return fmt.Errorf("TLS versions before TLS1.2 are not allowed in FIPS mode")
+	}
+	for _, s := range suites {
+		if !fipsCipherSuites[s] {
+			return fmt.Errorf("cipher suite %#04x is not allowed in FIPS mode", s)
+		}
+	}
+	return nil
+}
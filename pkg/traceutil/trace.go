@@ -0,0 +1,133 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traceutil implements tracing of requests through etcd server
+// stages so slow requests can be attributed to the stage they spent time in.
+package traceutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+)
+
+// TraceKey is the context key under which a request's Trace is stored.
+type TraceKey struct{}
+
+// Exporter receives finished traces. Export is called synchronously at the
+// end of the request, so implementations should hand off any slow work.
+type Exporter interface {
+	Export(t *Trace)
+}
+
+// Field is a key/value attribute of a trace.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Step marks the time a stage of the traced request completed.
+type Step struct {
+	Time time.Time
+	Msg  string
+}
+
+// Trace records the stages of a single request.
+type Trace struct {
+	operation string
+	fields    []Field
+	startTime time.Time
+	steps     []Step
+	exporter  Exporter
+}
+
+var todo = &Trace{}
+
+// New creates a trace for the given operation that is handed to exporter
+// once ended. A nil exporter disables tracing.
+func New(op string, exporter Exporter, fields ...Field) *Trace {
+	if exporter == nil {
+		return todo
+	}
+	return &Trace{operation: op, fields: fields, startTime: time.Now(), exporter: exporter}
+}
+
+// TODO returns a disabled trace.
+func TODO() *Trace { return todo }
+
+// Get returns the trace stored in ctx, or a disabled trace if there is none.
+func Get(ctx context.Context) *Trace {
+	if trace, ok := ctx.Value(TraceKey{}).(*Trace); ok && trace != nil {
+		return trace
+	}
+	return todo
+}
+
+// IsEnabled returns true if the trace will be exported.
+func (t *Trace) IsEnabled() bool { return t.exporter != nil }
+
+// Step records that a stage of the request completed now.
+func (t *Trace) Step(msg string) { t.StepAt(time.Now(), msg) }
+
+// StepAt records that a stage of the request completed at the given time.
+func (t *Trace) StepAt(at time.Time, msg string) {
+	if !t.IsEnabled() {
+		return
+	}
+	t.steps = append(t.steps, Step{Time: at, Msg: msg})
+}
+
+// AddField adds attributes to the trace.
+func (t *Trace) AddField(fields ...Field) {
+	if !t.IsEnabled() {
+		return
+	}
+	t.fields = append(t.fields, fields...)
+}
+
+// End finishes the trace and hands it to the exporter.
+func (t *Trace) End() {
+	if !t.IsEnabled() {
+		return
+	}
+	t.exporter.Export(t)
+}
+
+// Operation returns the name of the traced operation.
+func (t *Trace) Operation() string { return t.operation }
+
+// Fields returns the attributes of the trace.
+func (t *Trace) Fields() []Field { return t.fields }
+
+// StartTime returns the time the trace was created.
+func (t *Trace) StartTime() time.Time { return t.startTime }
+
+// Steps returns the recorded stages in order.
+func (t *Trace) Steps() []Step { return t.steps }
+
+// String formats the trace with the duration spent before each step.
+func (t *Trace) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "trace[%s]", t.operation)
+	for _, f := range t.fields {
+		fmt.Fprintf(&buf, " %s:%v;", f.Key, f.Value)
+	}
+	last := t.startTime
+	for _, s := range t.steps {
+		fmt.Fprintf(&buf, " (%v) %s;", s.Time.Sub(last), s.Msg)
+		last = s.Time
+	}
+	return buf.String()
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type recorder struct{ traces []*Trace }
+
+func (r *recorder) Export(t *Trace) { r.traces = append(r.traces, t) }
+
+func TestTrace(t *testing.T) {
+	r := &recorder{}
+	trace := New("range", r, Field{"key", "foo"})
+	ctx := context.WithValue(context.Background(), TraceKey{}, trace)
+
+	Get(ctx).Step("first")
+	Get(ctx).Step("second")
+	Get(ctx).AddField(Field{"count", 1})
+	trace.End()
+
+	if len(r.traces) != 1 || r.traces[0] != trace {
+		t.Fatalf("expected trace to be exported once, got %v", r.traces)
+	}
+	steps := trace.Steps()
+	if len(steps) != 2 || steps[0].Msg != "first" || steps[1].Msg != "second" {
+		t.Fatalf("unexpected steps %+v", steps)
+	}
+	if steps[0].Time.Before(trace.StartTime()) || steps[1].Time.Before(steps[0].Time) {
+		t.Fatalf("expected steps in time order, got %+v", steps)
+	}
+	if len(trace.Fields()) != 2 {
+		t.Fatalf("expected 2 fields, got %+v", trace.Fields())
+	}
+	s := trace.String()
+	for _, w := range []string{"trace[range]", "key:foo;", "count:1;", "first;", "second;"} {
+		if !strings.Contains(s, w) {
+			t.Errorf("expected %q in %q", w, s)
+		}
+	}
+}
+
+func TestTraceDisabled(t *testing.T) {
+	trace := New("range", nil)
+	if trace.IsEnabled() {
+		t.Fatal("expected disabled trace without exporter")
+	}
+	trace.Step("step")
+	trace.AddField(Field{"key", "foo"})
+	trace.End()
+	if len(trace.Steps()) != 0 || len(trace.Fields()) != 0 {
+		t.Fatalf("expected disabled trace to record nothing")
+	}
+	if Get(context.Background()).IsEnabled() {
+		t.Fatal("expected disabled trace from empty context")
+	}
+}
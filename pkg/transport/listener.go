@@ -29,6 +29,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/coreos/etcd/pkg/tlsutil"
@@ -80,6 +81,16 @@ type TLSInfo struct {
 
 	// AllowedCN is a CN which must be provided by a client.
 	AllowedCN string
+
+	// CipherSuites is a list of supported cipher suites.
+	// If empty, Go auto-populates it by default.
+	// Note that cipher suites are prioritized in the given order.
+	CipherSuites []uint16
+
+	// MinVersion and MaxVersion bound the TLS versions; 0 leaves
+	// the default minimum of TLS 1.2 and Go's maximum.
+	MinVersion uint16
+	MaxVersion uint16
 }
 
 func (info TLSInfo) String() string {
@@ -168,7 +179,8 @@ func (info TLSInfo) baseConfig() (*tls.Config, error) {
 		return nil, fmt.Errorf("KeyFile and CertFile must both be present[key: %v, cert: %v]", info.KeyFile, info.CertFile)
 	}
 
-	tlsCert, err := tlsutil.NewCert(info.CertFile, info.KeyFile, info.parseFunc)
+	cc := &certCache{certFile: info.CertFile, keyFile: info.KeyFile, parseFunc: info.parseFunc}
+	tlsCert, err := cc.get()
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +190,13 @@ func (info TLSInfo) baseConfig() (*tls.Config, error) {
 		MinVersion:   tls.VersionTLS12,
 		ServerName:   info.ServerName,
 	}
+	if info.MinVersion != 0 {
+		cfg.MinVersion = info.MinVersion
+	}
+	cfg.MaxVersion = info.MaxVersion
+	if len(info.CipherSuites) > 0 {
+		cfg.CipherSuites = info.CipherSuites
+	}
 
 	if info.AllowedCN != "" {
 		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
@@ -192,17 +211,58 @@ func (info TLSInfo) baseConfig() (*tls.Config, error) {
 		}
 	}
 
-	// this only reloads certs when there's a client request
-	// TODO: support server-side refresh (e.g. inotify, SIGHUP), caching
+	// this only reloads certs when there's a client request and the
+	// cert or key file changed on disk since it was last loaded
 	cfg.GetCertificate = func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
-		return tlsutil.NewCert(info.CertFile, info.KeyFile, info.parseFunc)
+		return cc.get()
 	}
 	cfg.GetClientCertificate = func(unused *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-		return tlsutil.NewCert(info.CertFile, info.KeyFile, info.parseFunc)
+		return cc.get()
 	}
 	return cfg, nil
 }
 
+// certCache holds the key pair loaded from a cert and key file, reloading it
+// whenever either file is modified so certificates can be rotated in place.
+type certCache struct {
+	certFile  string
+	keyFile   string
+	parseFunc func([]byte, []byte) (tls.Certificate, error)
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	certStat os.FileInfo
+	keyStat  os.FileInfo
+}
+
+func (cc *certCache) get() (*tls.Certificate, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	certStat, cerr := os.Stat(cc.certFile)
+	keyStat, kerr := os.Stat(cc.keyFile)
+	if cerr == nil && kerr == nil && cc.cert != nil &&
+		sameFile(certStat, cc.certStat) && sameFile(keyStat, cc.keyStat) {
+		return cc.cert, nil
+	}
+
+	cert, err := tlsutil.NewCert(cc.certFile, cc.keyFile, cc.parseFunc)
+	if err != nil {
+		if cc.cert != nil {
+			// keep serving the last good key pair while the files are
+			// being replaced; retry loading on the next request
+			return cc.cert, nil
+		}
+		return nil, err
+	}
+	cc.cert, cc.certStat, cc.keyStat = cert, certStat, keyStat
+	return cert, nil
+}
+
+func sameFile(a, b os.FileInfo) bool {
+	return a != nil && b != nil && a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
+
 // cafiles returns a list of CA file paths.
 func (info TLSInfo) cafiles() []string {
 	cs := make([]string, 0)
@@ -253,7 +313,12 @@ func (info TLSInfo) ClientConfig() (*tls.Config, error) {
 			return nil, err
 		}
 	} else {
-		cfg = &tls.Config{ServerName: info.ServerName}
+		cfg = &tls.Config{
+			ServerName:   info.ServerName,
+			MinVersion:   info.MinVersion,
+			MaxVersion:   info.MaxVersion,
+			CipherSuites: info.CipherSuites,
+		}
 	}
 	cfg.InsecureSkipVerify = info.InsecureSkipVerify
 
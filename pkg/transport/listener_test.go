@@ -15,11 +15,18 @@
 package transport
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -244,6 +251,62 @@ func TestTLSInfoConfigFuncs(t *testing.T) {
 	}
 }
 
+// TestTLSInfoCipherSuites ensures TLS listeners only accept connections
+// using the configured cipher suites and TLS versions.
+func TestTLSInfoCipherSuites(t *testing.T) {
+	tlsinfo, del, err := createSelfCert()
+	if err != nil {
+		t.Fatalf("unable to create cert: %v", err)
+	}
+	defer del()
+	tlsinfo.CipherSuites = []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}
+	tlsinfo.MaxVersion = tls.VersionTLS12
+
+	ln, err := NewListener("127.0.0.1:0", "https", tlsinfo)
+	if err != nil {
+		t.Fatalf("unexpected NewListener error: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		suite      uint16
+		minVersion uint16
+		maxVersion uint16
+
+		wok bool
+	}{
+		{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, 0, 0, true},
+		{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, 0, 0, false},
+		{tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA, 0, 0, false},
+		// the default minimum version is TLS1.2
+		{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.VersionTLS10, tls.VersionTLS11, false},
+	}
+	for i, tt := range tests {
+		cfg := &tls.Config{
+			InsecureSkipVerify: true,
+			CipherSuites:       []uint16{tt.suite},
+			MinVersion:         tt.minVersion,
+			MaxVersion:         tt.maxVersion,
+		}
+		conn, err := tls.Dial("tcp", ln.Addr().String(), cfg)
+		if (err == nil) != tt.wok {
+			t.Errorf("#%d: handshake error = %v, want ok %v", i, err, tt.wok)
+		}
+		if err == nil {
+			conn.Close()
+		}
+	}
+}
+
 func TestNewListenerUnixSocket(t *testing.T) {
 	l, err := NewListener("testsocket", "unix", nil)
 	if err != nil {
@@ -280,3 +343,153 @@ func TestIsClosedConnError(t *testing.T) {
 		t.Fatalf("expect true, got false (%v)", err)
 	}
 }
+
+// TestTLSInfoReloadCert ensures a changed key pair is picked up by new
+// handshakes without rebuilding the tls.Config.
+func TestTLSInfoReloadCert(t *testing.T) {
+	tlsinfo, del, err := createSelfCert()
+	if err != nil {
+		t.Fatalf("unable to create cert: %v", err)
+	}
+	defer del()
+
+	cfg, err := tlsinfo.ServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1, err := cfg.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2, _ := cfg.GetCertificate(nil); c2 != c1 {
+		t.Fatalf("expected cached certificate when files are unchanged")
+	}
+
+	// rotate the key pair in place
+	newinfo, del2, err := createSelfCert()
+	if err != nil {
+		t.Fatalf("unable to create cert: %v", err)
+	}
+	defer del2()
+	later := time.Now().Add(time.Minute)
+	for src, dst := range map[string]string{newinfo.CertFile: tlsinfo.CertFile, newinfo.KeyFile: tlsinfo.KeyFile} {
+		b, rerr := ioutil.ReadFile(src)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if err = ioutil.WriteFile(dst, b, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Chtimes(dst, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c3, err := cfg.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(c3.Certificate[0]) == string(c1.Certificate[0]) {
+		t.Fatalf("expected reloaded certificate after rotation")
+	}
+
+	// a partially written key pair keeps the last good one
+	if err = ioutil.WriteFile(tlsinfo.KeyFile, []byte("bad"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c4, err := cfg.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c4 != c3 {
+		t.Fatalf("expected last good certificate on reload failure")
+	}
+}
+
+func readTestCert(t *testing.T, path string) *x509.Certificate {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, _ := pem.Decode(b)
+	if blk == nil {
+		t.Fatalf("no PEM data in %s", path)
+	}
+	c, err := x509.ParseCertificate(blk.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCRLCacheReload(t *testing.T) {
+	good := []*x509.Certificate{readTestCert(t, "../../integration/fixtures/server.crt")}
+	revoked := []*x509.Certificate{readTestCert(t, "../../integration/fixtures/server-revoked.crt")}
+
+	crl, err := ioutil.ReadFile("../../integration/fixtures/revoke.crl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ioutil.TempDir("", "etcd-test-crl-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	crlPath := filepath.Join(d, "revoke.crl")
+	if err = ioutil.WriteFile(crlPath, crl, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cc := &crlCache{path: crlPath}
+	if err = cc.check(good); err != nil {
+		t.Fatalf("unexpected error for unrevoked certificate: %v", err)
+	}
+	if err = cc.check(revoked); err == nil {
+		t.Fatalf("expected error for revoked certificate")
+	}
+
+	// replace the list in place with one that revokes nothing
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyCRL, err := ca.CreateCRL(rand.Reader, key, nil, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeCRL := func(b []byte, mtime time.Time) {
+		if err = ioutil.WriteFile(crlPath, b, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Chtimes(crlPath, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCRL(emptyCRL, time.Now().Add(time.Minute))
+	if err = cc.check(revoked); err != nil {
+		t.Fatalf("unexpected error after reloading the CRL: %v", err)
+	}
+
+	// a partially written list keeps the last good one
+	writeCRL(crl[:len(crl)/2], time.Now().Add(2*time.Minute))
+	if err = cc.check(revoked); err != nil {
+		t.Fatalf("expected last good CRL on reload failure, got %v", err)
+	}
+	writeCRL(crl, time.Now().Add(3*time.Minute))
+	if err = cc.check(revoked); err == nil {
+		t.Fatalf("expected error for revoked certificate after reload")
+	}
+
+	// a list that never loaded rejects every certificate
+	if err = (&crlCache{path: filepath.Join(d, "missing.crl")}).check(good); err == nil {
+		t.Fatalf("expected error for missing CRL file")
+	}
+}
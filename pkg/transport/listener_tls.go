@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"os"
 	"strings"
 	"sync"
 )
@@ -61,6 +62,10 @@ func newTLSListener(l net.Listener, tlsinfo *TLSInfo, check tlsCheckFunc) (net.L
 	}
 
 	if len(tlsinfo.CRLFile) > 0 {
+		crls := &crlCache{path: tlsinfo.CRLFile}
+		if _, err = crls.get(); err != nil {
+			return nil, err
+		}
 		prevCheck := check
 		check = func(ctx context.Context, tlsConn *tls.Conn) error {
 			if err := prevCheck(ctx, tlsConn); err != nil {
@@ -68,7 +73,7 @@ func newTLSListener(l net.Listener, tlsinfo *TLSInfo, check tlsCheckFunc) (net.L
 			}
 			st := tlsConn.ConnectionState()
 			if certs := st.PeerCertificates; len(certs) > 0 {
-				return checkCRL(tlsinfo.CRLFile, certs)
+				return crls.check(certs)
 			}
 			return nil
 		}
@@ -166,29 +171,69 @@ func (l *tlsListener) acceptLoop() {
 	}
 }
 
-func checkCRL(crlPath string, cert []*x509.Certificate) error {
-	// TODO: cache
-	crlBytes, err := ioutil.ReadFile(crlPath)
+// crlCache holds the revoked serials loaded from a CRL file, reloading them
+// whenever the file is modified so certificates can be revoked in place.
+type crlCache struct {
+	path string
+
+	mu      sync.Mutex
+	revoked map[string]struct{}
+	stat    os.FileInfo
+}
+
+func (cc *crlCache) get() (map[string]struct{}, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	stat, err := os.Stat(cc.path)
+	if err == nil && cc.revoked != nil && sameFile(stat, cc.stat) {
+		return cc.revoked, nil
+	}
+
+	revoked, err := readCRL(cc.path)
 	if err != nil {
-		return err
+		if cc.revoked != nil {
+			// keep checking against the last good list while the
+			// file is being replaced; retry loading on the next check
+			return cc.revoked, nil
+		}
+		return nil, err
 	}
-	certList, err := x509.ParseCRL(crlBytes)
+	cc.revoked, cc.stat = revoked, stat
+	return revoked, nil
+}
+
+// check returns an error if any of the certificates is revoked.
+func (cc *crlCache) check(certs []*x509.Certificate) error {
+	revoked, err := cc.get()
 	if err != nil {
 		return err
 	}
-	revokedSerials := make(map[string]struct{})
-	for _, rc := range certList.TBSCertList.RevokedCertificates {
-		revokedSerials[string(rc.SerialNumber.Bytes())] = struct{}{}
-	}
-	for _, c := range cert {
+	for _, c := range certs {
 		serial := string(c.SerialNumber.Bytes())
-		if _, ok := revokedSerials[serial]; ok {
+		if _, ok := revoked[serial]; ok {
 			return fmt.Errorf("transport: certificate serial %x revoked", serial)
 		}
 	}
 	return nil
 }
 
+func readCRL(crlPath string) (map[string]struct{}, error) {
+	crlBytes, err := ioutil.ReadFile(crlPath)
+	if err != nil {
+		return nil, err
+	}
+	certList, err := x509.ParseCRL(crlBytes)
+	if err != nil {
+		return nil, err
+	}
+	revokedSerials := make(map[string]struct{})
+	for _, rc := range certList.TBSCertList.RevokedCertificates {
+		revokedSerials[string(rc.SerialNumber.Bytes())] = struct{}{}
+	}
+	return revokedSerials, nil
+}
+
 func checkCertSAN(ctx context.Context, cert *x509.Certificate, remoteAddr string) error {
 	if len(cert.IPAddresses) == 0 && len(cert.DNSNames) == 0 {
 		return nil
@@ -17,10 +17,16 @@
 package wait
 
 import (
+	"errors"
 	"log"
 	"sync"
+	"time"
 )
 
+// ErrDeadlineExceeded is sent on the chan of an ID not triggered by the
+// deadline it was registered with.
+var ErrDeadlineExceeded = errors.New("wait: deadline exceeded")
+
 // Wait is an interface that provides the ability to wait and trigger events that
 // are associated with IDs.
 type Wait interface {
@@ -28,43 +34,101 @@ type Wait interface {
 	// The chan will be triggered when Trigger is called with
 	// the same ID.
 	Register(id uint64) <-chan interface{}
+	// RegisterWithDeadline is like Register, but the chan is triggered
+	// with ErrDeadlineExceeded if the ID is not triggered by the deadline.
+	RegisterWithDeadline(id uint64, deadline time.Time) <-chan interface{}
 	// Trigger triggers the waiting chans with the given ID.
 	Trigger(id uint64, x interface{})
+	// TriggerRegisteredBefore triggers the chans registered before t with x
+	// and returns how many it triggered.
+	TriggerRegisteredBefore(t time.Time, x interface{}) int
 	IsRegistered(id uint64) bool
 }
 
+type waiter struct {
+	ch         chan interface{}
+	registered time.Time
+	timer      *time.Timer
+}
+
 type list struct {
 	l sync.RWMutex
-	m map[uint64]chan interface{}
+	m map[uint64]*waiter
 }
 
 // New creates a Wait.
 func New() Wait {
-	return &list{m: make(map[uint64]chan interface{})}
+	return &list{m: make(map[uint64]*waiter)}
 }
 
 func (w *list) Register(id uint64) <-chan interface{} {
 	w.l.Lock()
 	defer w.l.Unlock()
-	ch := w.m[id]
-	if ch == nil {
-		ch = make(chan interface{}, 1)
-		w.m[id] = ch
-	} else {
+	return w.register(id).ch
+}
+
+func (w *list) RegisterWithDeadline(id uint64, deadline time.Time) <-chan interface{} {
+	w.l.Lock()
+	defer w.l.Unlock()
+	wt := w.register(id)
+	wt.timer = time.AfterFunc(time.Until(deadline), func() { w.expire(id, wt) })
+	return wt.ch
+}
+
+func (w *list) register(id uint64) *waiter {
+	if w.m[id] != nil {
 		log.Panicf("dup id %x", id)
 	}
-	return ch
+	wt := &waiter{ch: make(chan interface{}, 1), registered: time.Now()}
+	w.m[id] = wt
+	return wt
+}
+
+// expire triggers wt if it is still the waiter of id.
+func (w *list) expire(id uint64, wt *waiter) {
+	w.l.Lock()
+	if w.m[id] != wt {
+		w.l.Unlock()
+		return
+	}
+	delete(w.m, id)
+	w.l.Unlock()
+	wt.ch <- ErrDeadlineExceeded
+	close(wt.ch)
 }
 
 func (w *list) Trigger(id uint64, x interface{}) {
 	w.l.Lock()
-	ch := w.m[id]
+	wt := w.m[id]
 	delete(w.m, id)
 	w.l.Unlock()
-	if ch != nil {
-		ch <- x
-		close(ch)
+	if wt != nil {
+		wt.trigger(x)
+	}
+}
+
+func (w *list) TriggerRegisteredBefore(t time.Time, x interface{}) int {
+	var wts []*waiter
+	w.l.Lock()
+	for id, wt := range w.m {
+		if wt.registered.Before(t) {
+			wts = append(wts, wt)
+			delete(w.m, id)
+		}
+	}
+	w.l.Unlock()
+	for _, wt := range wts {
+		wt.trigger(x)
 	}
+	return len(wts)
+}
+
+func (wt *waiter) trigger(x interface{}) {
+	if wt.timer != nil {
+		wt.timer.Stop()
+	}
+	wt.ch <- x
+	close(wt.ch)
 }
 
 func (w *list) IsRegistered(id uint64) bool {
@@ -85,7 +149,13 @@ func NewWithResponse(ch <-chan interface{}) Wait {
 func (w *waitWithResponse) Register(id uint64) <-chan interface{} {
 	return w.ch
 }
+func (w *waitWithResponse) RegisterWithDeadline(id uint64, deadline time.Time) <-chan interface{} {
+	return w.ch
+}
 func (w *waitWithResponse) Trigger(id uint64, x interface{}) {}
+func (w *waitWithResponse) TriggerRegisteredBefore(t time.Time, x interface{}) int {
+	return 0
+}
 func (w *waitWithResponse) IsRegistered(id uint64) bool {
 	panic("waitWithResponse.IsRegistered() shouldn't be called")
 }
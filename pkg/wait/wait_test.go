@@ -100,3 +100,52 @@ func TestIsRegistered(t *testing.T) {
 		t.Errorf("event ID 0 is already triggered, shouldn't be registered")
 	}
 }
+
+func TestRegisterWithDeadline(t *testing.T) {
+	wt := New()
+	ch := wt.RegisterWithDeadline(1, time.Now().Add(10*time.Millisecond))
+	select {
+	case v := <-ch:
+		if v != ErrDeadlineExceeded {
+			t.Errorf("<-ch = %v, want %v", v, ErrDeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait not triggered after its deadline")
+	}
+	if wt.IsRegistered(1) {
+		t.Errorf("event ID 1 expired, shouldn't be registered")
+	}
+
+	// triggered before the deadline
+	ch = wt.RegisterWithDeadline(2, time.Now().Add(10*time.Millisecond))
+	wt.Trigger(2, "foo")
+	if v := <-ch; v != "foo" {
+		t.Errorf("<-ch = %v, want foo", v)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if v, ok := <-ch; ok {
+		t.Errorf("unexpected value after trigger: %v", v)
+	}
+}
+
+func TestTriggerRegisteredBefore(t *testing.T) {
+	wt := New()
+	ch1 := wt.Register(1)
+	ch2 := wt.RegisterWithDeadline(2, time.Now().Add(time.Hour))
+	time.Sleep(time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	wt.Register(3)
+
+	if n := wt.TriggerRegisteredBefore(cutoff, "foo"); n != 2 {
+		t.Errorf("triggered %d, want 2", n)
+	}
+	for i, ch := range []<-chan interface{}{ch1, ch2} {
+		if v := <-ch; v != "foo" {
+			t.Errorf("#%d: <-ch = %v, want foo", i, v)
+		}
+	}
+	if !wt.IsRegistered(3) {
+		t.Errorf("event ID 3 registered after the cutoff, should be registered")
+	}
+}
@@ -16,6 +16,7 @@ package grpcproxy
 
 import (
 	"context"
+	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
@@ -83,9 +84,18 @@ func (p *kvProxy) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*p
 	return (*pb.DeleteRangeResponse)(resp.Del()), err
 }
 
-func (p *kvProxy) txnToCache(reqs []*pb.RequestOp, resps []*pb.ResponseOp) {
-	for i := range resps {
-		switch tv := resps[i].Response.(type) {
+func (p *kvProxy) txnToCache(r *pb.TxnRequest, resp *pb.TxnResponse) {
+	// txn may claim an outdated key is updated; be safe and invalidate
+	for _, cmp := range r.Compare {
+		p.cache.Invalidate(cmp.Key, cmp.RangeEnd)
+	}
+
+	reqs := r.Failure
+	if resp.Succeeded {
+		reqs = r.Success
+	}
+	for i := range resp.Responses {
+		switch tv := resp.Responses[i].Response.(type) {
 		case *pb.ResponseOp_ResponsePut:
 			p.cache.Invalidate(reqs[i].GetRequestPut().Key, nil)
 		case *pb.ResponseOp_ResponseDeleteRange:
@@ -95,6 +105,9 @@ func (p *kvProxy) txnToCache(reqs []*pb.RequestOp, resps []*pb.ResponseOp) {
 			req := *(reqs[i].GetRequestRange())
 			req.Serializable = true
 			p.cache.Add(&req, tv.ResponseRange)
+		case *pb.ResponseOp_ResponseTxn:
+			// nested txns may write keys as well
+			p.txnToCache(reqs[i].GetRequestTxn(), tv.ResponseTxn)
 		}
 	}
 }
@@ -107,16 +120,8 @@ func (p *kvProxy) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, e
 	}
 	resp := opResp.Txn()
 
-	// txn may claim an outdated key is updated; be safe and invalidate
-	for _, cmp := range r.Compare {
-		p.cache.Invalidate(cmp.Key, cmp.RangeEnd)
-	}
 	// update any fetched keys
-	if resp.Succeeded {
-		p.txnToCache(r.Success, resp.Responses)
-	} else {
-		p.txnToCache(r.Failure, resp.Responses)
-	}
+	p.txnToCache(r, (*pb.TxnResponse)(resp))
 
 	cacheKeys.Set(float64(p.cache.Size()))
 
@@ -185,6 +190,9 @@ func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 	if r.Serializable {
 		opts = append(opts, clientv3.WithSerializable())
 	}
+	if r.Index != "" {
+		opts = append(opts, clientv3.WithIndex(r.Index, r.IndexValue))
+	}
 
 	return clientv3.OpGet(string(r.Key), opts...)
 }
@@ -201,6 +209,18 @@ func PutRequestToOp(r *pb.PutRequest) clientv3.Op {
 	if r.PrevKv {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if r.ExpireAt != 0 {
+		opts = append(opts, clientv3.WithExpireAt(time.Unix(r.ExpireAt, 0)))
+	}
+	if r.ExpectedVersion != 0 {
+		opts = append(opts, clientv3.WithExpectedVersion(r.ExpectedVersion))
+	}
+	if r.ExpectedModRevision != 0 {
+		opts = append(opts, clientv3.WithExpectedModRev(r.ExpectedModRevision))
+	}
+	if r.DryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
 	return clientv3.OpPut(string(r.Key), string(r.Value), opts...)
 }
 
@@ -212,6 +232,9 @@ func DelRequestToOp(r *pb.DeleteRangeRequest) clientv3.Op {
 	if r.PrevKv {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if r.DryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
 	return clientv3.OpDelete(string(r.Key), opts...)
 }
 
@@ -228,5 +251,9 @@ func TxnRequestToOp(r *pb.TxnRequest) clientv3.Op {
 	for i := range r.Failure {
 		elseops[i] = requestOpToOp(r.Failure[i])
 	}
-	return clientv3.OpTxn(cmps, thenops, elseops)
+	opts := []clientv3.OpOption{}
+	if r.DryRun {
+		opts = append(opts, clientv3.WithDryRun())
+	}
+	return clientv3.OpTxn(cmps, thenops, elseops, opts...)
 }
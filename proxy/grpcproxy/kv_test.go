@@ -53,6 +53,43 @@ func TestKVProxyRange(t *testing.T) {
 	client.Close()
 }
 
+// TestKVProxyNestedTxnInvalidate ensures a write in a nested txn invalidates
+// cached serializable ranges over the written key.
+func TestKVProxyNestedTxnInvalidate(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvts := newKVProxyServer([]string{clus.Members[0].GRPCAddr()}, t)
+	defer kvts.close()
+
+	ctx := context.Background()
+	if _, err := kvts.kp.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	rreq := &pb.RangeRequest{Key: []byte("foo"), Serializable: true}
+	if _, err := kvts.kp.Range(ctx, rreq); err != nil {
+		t.Fatal(err)
+	}
+
+	put := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{
+		RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz")}}}
+	nested := &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{
+		RequestTxn: &pb.TxnRequest{Success: []*pb.RequestOp{put}}}}
+	if _, err := kvts.kp.Txn(ctx, &pb.TxnRequest{Success: []*pb.RequestOp{nested}}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := kvts.kp.Range(ctx, rreq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "baz" {
+		t.Fatalf("expected value %q, got %+v", "baz", resp.Kvs)
+	}
+}
+
 type kvproxyTestServer struct {
 	kp     pb.KVServer
 	c      *clientv3.Client
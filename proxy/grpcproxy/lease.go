@@ -108,6 +108,7 @@ func (lp *leaseProxy) LeaseTimeToLive(ctx context.Context, rr *pb.LeaseTimeToLiv
 		TTL:        r.TTL,
 		GrantedTTL: r.GrantedTTL,
 		Keys:       r.Keys,
+		Metadata:   r.Metadata,
 	}
 	return rp, err
 }
@@ -119,7 +120,7 @@ func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest
 	}
 	leases := make([]*pb.LeaseStatus, len(r.Leases))
 	for i := range r.Leases {
-		leases[i] = &pb.LeaseStatus{ID: int64(r.Leases[i].ID)}
+		leases[i] = &pb.LeaseStatus{ID: int64(r.Leases[i].ID), Metadata: r.Leases[i].Metadata}
 	}
 	rp := &pb.LeaseLeasesResponse{
 		Header: r.ResponseHeader,
@@ -22,6 +22,9 @@ import (
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 )
 
+// maxHistoryEvents bounds the events a watchBroadcast keeps to replay.
+const maxHistoryEvents = 1024
+
 // watchBroadcast broadcasts a server watcher to many client watchers.
 type watchBroadcast struct {
 	// cancel stops the underlying etcd server watcher and closes ch.
@@ -36,6 +39,16 @@ type watchBroadcast struct {
 	receivers map[*watcher]struct{}
 	// responses counts the number of responses
 	responses int
+
+	// history holds the latest responses with events, oldest first, so
+	// watchers starting at a revision before nextrev can be replayed the
+	// events they missed instead of opening another server watcher.
+	history []clientv3.WatchResponse
+	// historyEvents counts the events in history.
+	historyEvents int
+	// historyrev is the revision history has every event from, or 0 if
+	// history is not yet started.
+	historyrev int64
 }
 
 func newWatchBroadcast(wp *watchProxy, w *watcher, update func(*watchBroadcast)) *watchBroadcast {
@@ -78,6 +91,7 @@ func (wb *watchBroadcast) bcast(wr clientv3.WatchResponse) {
 		wb.nextrev = wr.Header.Revision + 1
 	}
 	wb.responses++
+	wb.record(wr)
 	for r := range wb.receivers {
 		r.send(wr)
 	}
@@ -86,11 +100,48 @@ func (wb *watchBroadcast) bcast(wr clientv3.WatchResponse) {
 	}
 }
 
+// record adds a response to the history, dropping the oldest responses
+// once it holds more than maxHistoryEvents events.
+func (wb *watchBroadcast) record(wr clientv3.WatchResponse) {
+	if wr.Canceled || wr.CompactRevision != 0 {
+		// the server watcher ends; nothing more can be replayed
+		wb.history, wb.historyEvents, wb.historyrev = nil, 0, 0
+		return
+	}
+	if wb.historyrev == 0 {
+		// history starts from the first revision the server watcher sends
+		wb.historyrev = wr.Header.Revision + 1
+		if wb.responses == 1 && wb.nextrev != 0 && len(wr.Events) == 0 {
+			wb.historyrev = wb.nextrev
+		}
+	}
+	if len(wr.Events) == 0 {
+		return
+	}
+	wb.history = append(wb.history, wr)
+	wb.historyEvents += len(wr.Events)
+	for wb.historyEvents > maxHistoryEvents && len(wb.history) > 1 {
+		wb.historyEvents -= len(wb.history[0].Events)
+		wb.historyrev = wb.history[0].Header.Revision + 1
+		wb.history = wb.history[1:]
+	}
+}
+
+// replayable returns true if the history has every event from the given
+// revision on.
+func (wb *watchBroadcast) replayable(rev int64) bool {
+	return wb.responses > 0 && wb.historyrev != 0 && rev >= wb.historyrev
+}
+
 // add puts a watcher into receiving a broadcast if its revision at least
-// meets the broadcast revision. Returns true if added.
+// meets the broadcast revision, or the broadcast history has the events
+// since the watcher revision. Returns true if added.
 func (wb *watchBroadcast) add(w *watcher) bool {
 	wb.mu.Lock()
 	defer wb.mu.Unlock()
+	if wb.nextrev > w.nextrev && w.nextrev != 0 && wb.replayable(w.nextrev) {
+		return wb.replay(w)
+	}
 	if wb.nextrev > w.nextrev || (wb.nextrev == 0 && w.nextrev != 0) {
 		// wb is too far ahead, w will miss events
 		// or wb is being established with a current watcher
@@ -120,6 +171,33 @@ func (wb *watchBroadcast) add(w *watcher) bool {
 
 	return true
 }
+
+// replay sends a watcher the history it missed and starts broadcasting to it.
+func (wb *watchBroadcast) replay(w *watcher) bool {
+	ok := w.post(&pb.WatchResponse{
+		Header:  &pb.ResponseHeader{Revision: w.nextrev},
+		WatchId: w.id,
+		Created: true,
+	})
+	if !ok {
+		return false
+	}
+	for _, wr := range wb.history {
+		if wr.Header.Revision < w.nextrev {
+			continue
+		}
+		for _, ev := range wr.Events {
+			if ev.Kv.ModRevision >= w.nextrev {
+				eventsReplayed.Inc()
+			}
+		}
+		w.send(wr)
+	}
+	wb.receivers[w] = struct{}{}
+	watchersCoalescing.Inc()
+	return true
+}
+
 func (wb *watchBroadcast) delete(w *watcher) {
 	wb.mu.Lock()
 	defer wb.mu.Unlock()
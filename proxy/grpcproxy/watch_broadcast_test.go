@@ -0,0 +1,89 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func TestWatchBroadcastReplay(t *testing.T) {
+	wb := &watchBroadcast{receivers: make(map[*watcher]struct{})}
+	putResp := func(rev int64) clientv3.WatchResponse {
+		return clientv3.WatchResponse{
+			Header: pb.ResponseHeader{Revision: rev},
+			Events: []*clientv3.Event{{
+				Type: mvccpb.PUT,
+				Kv:   &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: rev},
+			}},
+		}
+	}
+	// current watch created at revision 10
+	wb.bcast(clientv3.WatchResponse{Header: pb.ResponseHeader{Revision: 10}, Created: true})
+	for rev := int64(11); rev <= 13; rev++ {
+		wb.bcast(putResp(rev))
+	}
+
+	newWatcher := func(rev int64) (*watcher, chan *pb.WatchResponse) {
+		ch := make(chan *pb.WatchResponse, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		wps := &watchProxyStream{watchCh: ch, ctx: ctx, cancel: cancel}
+		return &watcher{wr: watchRange{key: "foo"}, nextrev: rev, wps: wps}, ch
+	}
+
+	// too old for the history
+	if w, _ := newWatcher(10); wb.add(w) {
+		t.Fatalf("added watcher at revision 10, want not added")
+	}
+
+	w, ch := newWatcher(12)
+	if !wb.add(w) {
+		t.Fatalf("watcher at revision 12 not added")
+	}
+	if resp := <-ch; !resp.Created || resp.Header.Revision != 12 {
+		t.Fatalf("got %+v, want created response at revision 12", resp)
+	}
+	for _, wrev := range []int64{12, 13} {
+		resp := <-ch
+		if len(resp.Events) != 1 || resp.Events[0].Kv.ModRevision != wrev {
+			t.Fatalf("got %+v, want event at revision %d", resp, wrev)
+		}
+	}
+	if w.nextrev != 14 {
+		t.Fatalf("next revision = %d, want 14", w.nextrev)
+	}
+
+	// new events are broadcast to the replayed watcher
+	wb.bcast(putResp(14))
+	if resp := <-ch; len(resp.Events) != 1 || resp.Events[0].Kv.ModRevision != 14 {
+		t.Fatalf("got %+v, want event at revision 14", resp)
+	}
+
+	// the history drops the oldest events past its bound
+	wb.delete(w)
+	for rev := int64(15); rev < 15+maxHistoryEvents; rev++ {
+		wb.bcast(putResp(rev))
+	}
+	if len(wb.history) != maxHistoryEvents {
+		t.Fatalf("history has %d responses, want %d", len(wb.history), maxHistoryEvents)
+	}
+	if wb.replayable(14) || !wb.replayable(15) {
+		t.Fatalf("history revision = %d, want 15", wb.historyrev)
+	}
+}
@@ -139,19 +139,17 @@ func (tp *TCPProxy) pick() *remote {
 		choose := rand.Intn(w)
 		for i := 0; i < len(weighted); i++ {
 			choose -= int(weighted[i].srv.Weight)
-			if choose <= 0 {
+			if choose < 0 {
 				return weighted[i]
 			}
 		}
 	}
 	if unweighted != nil {
-		for i := 0; i < len(tp.remotes); i++ {
-			picked := tp.remotes[tp.pickCount%len(tp.remotes)]
-			tp.pickCount++
-			if picked.isActive() {
-				return picked
-			}
-		}
+		// only round robin over the best priority class; all of its
+		// members are active since inactive remotes are skipped above
+		r := unweighted[tp.pickCount%len(unweighted)]
+		tp.pickCount++
+		return r
 	}
 	return nil
 }
@@ -67,3 +67,51 @@ func TestUserspaceProxy(t *testing.T) {
 		t.Errorf("got = %s, want %s", got, want)
 	}
 }
+
+func TestUserspaceProxyPriority(t *testing.T) {
+	tp := TCPProxy{
+		Endpoints: []*net.SRV{
+			{Target: "low", Port: 1, Priority: 2},
+			{Target: "high", Port: 1, Priority: 1},
+			{Target: "low2", Port: 1, Priority: 2},
+		},
+	}
+	for _, srv := range tp.Endpoints {
+		tp.remotes = append(tp.remotes, &remote{srv: srv, addr: srv.Target})
+	}
+
+	for i := 0; i < 10; i++ {
+		if r := tp.pick(); r.addr != "high" {
+			t.Fatalf("#%d: expected %q, got %q", i, "high", r.addr)
+		}
+	}
+
+	// fall back to the next priority class once the best one is inactive
+	tp.remotes[1].inactivate()
+	for i := 0; i < 10; i++ {
+		if r := tp.pick(); r.addr == "high" {
+			t.Fatalf("#%d: picked inactive remote %q", i, r.addr)
+		}
+	}
+}
+
+func TestUserspaceProxyWeight(t *testing.T) {
+	tp := TCPProxy{
+		Endpoints: []*net.SRV{
+			{Target: "a", Port: 1, Weight: 1},
+			{Target: "b", Port: 1, Weight: 1},
+		},
+	}
+	for _, srv := range tp.Endpoints {
+		tp.remotes = append(tp.remotes, &remote{srv: srv, addr: srv.Target})
+	}
+
+	// with equal weights, both remotes must be picked
+	picked := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		picked[tp.pick().addr]++
+	}
+	if picked["a"] == 0 || picked["b"] == 0 {
+		t.Fatalf("expected both remotes to be picked, got %v", picked)
+	}
+}
@@ -16,7 +16,6 @@ package raft
 
 import (
 	"fmt"
-	"log"
 
 	pb "github.com/coreos/etcd/raft/raftpb"
 )
@@ -44,7 +43,7 @@ type raftLog struct {
 // that it just commits and applies the latest snapshot.
 func newLog(storage Storage, logger Logger) *raftLog {
 	if storage == nil {
-		log.Panic("storage must not be nil")
+		logger.Panic("storage must not be nil")
 	}
 	log := &raftLog{
 		storage: storage,
@@ -187,6 +187,12 @@ type Config struct {
 	// logical clock from assigning the timestamp and then forwarding the data
 	// to the leader.
 	DisableProposalForwarding bool
+
+	// Witness set to true means that the node votes and replicates the log
+	// but never campaigns, so it never becomes the leader. A witness breaks
+	// ties between the other voters, for example in a third location of a
+	// two datacenter deployment, without having to serve as the leader.
+	Witness bool
 }
 
 func (c *Config) validate() error {
@@ -272,6 +278,7 @@ type raft struct {
 	// when raft changes its state to follower or candidate.
 	randomizedElectionTimeout int
 	disableProposalForwarding bool
+	witness                   bool
 
 	tick func()
 	step stepFunc
@@ -312,6 +319,7 @@ func newRaft(c *Config) *raft {
 		preVote:                   c.PreVote,
 		readOnly:                  newReadOnly(c.ReadOnlyOption),
 		disableProposalForwarding: c.DisableProposalForwarding,
+		witness:                   c.Witness,
 	}
 	for _, p := range peers {
 		r.prs[p] = &Progress{Next: 1, ins: newInflights(r.maxInflight)}
@@ -766,7 +774,9 @@ func (r *raft) Step(m pb.Message) error {
 
 	switch m.Type {
 	case pb.MsgHup:
-		if r.state != StateLeader {
+		if r.witness {
+			r.logger.Debugf("%x ignoring MsgHup because it is a witness", r.id)
+		} else if r.state != StateLeader {
 			ents, err := r.raftLog.slice(r.raftLog.applied+1, r.raftLog.committed+1, noLimit)
 			if err != nil {
 				r.logger.Panicf("unexpected error getting unapplied entries (%v)", err)
@@ -1183,10 +1193,10 @@ func (r *raft) restore(s pb.Snapshot) bool {
 }
 
 // promotable indicates whether state machine can be promoted to leader,
-// which is true when its own id is in progress list.
+// which is true when its own id is in progress list and it is not a witness.
 func (r *raft) promotable() bool {
 	_, ok := r.prs[r.id]
-	return ok
+	return ok && !r.witness
 }
 
 func (r *raft) addNode(id uint64) {
@@ -2645,6 +2645,60 @@ func TestPromotable(t *testing.T) {
 	}
 }
 
+func newTestWitness(id uint64, peers []uint64, election, heartbeat int, storage Storage) *raft {
+	c := newTestConfig(id, peers, election, heartbeat, storage)
+	c.Witness = true
+	return newRaft(c)
+}
+
+// TestWitnessNeverCampaigns ensures a witness votes but never campaigns,
+// whether on MsgHup or on its election timeout.
+func TestWitnessNeverCampaigns(t *testing.T) {
+	w := newTestWitness(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	nt := newNetwork(nil, nil, w)
+
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	for i := 0; i < 2*w.electionTimeout; i++ {
+		w.tick()
+	}
+	if w.state != StateFollower || w.Term != 0 {
+		t.Fatalf("witness state = %v at term %d, want %v at term 0", w.state, w.Term, StateFollower)
+	}
+
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if w.lead != 1 {
+		t.Fatalf("witness lead = %x, want 1", w.lead)
+	}
+
+	// the witness is the tiebreaker electing 2 once 1 is gone
+	nt.isolate(1)
+	nt.send(pb.Message{From: 2, To: 2, Type: pb.MsgHup})
+	if n2 := nt.peers[2].(*raft); n2.state != StateLeader {
+		t.Fatalf("node 2 state = %v, want %v", n2.state, StateLeader)
+	}
+	if w.lead != 2 {
+		t.Fatalf("witness lead = %x, want 2", w.lead)
+	}
+}
+
+// TestLeaderTransferToWitness ensures a witness ignores MsgTimeoutNow, so a
+// leadership transfer to it fails and the leader keeps leading.
+func TestLeaderTransferToWitness(t *testing.T) {
+	w := newTestWitness(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	nt := newNetwork(nil, nil, w)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+	nt.send(pb.Message{From: 3, To: 1, Type: pb.MsgTransferLeader})
+	if w.state != StateFollower {
+		t.Fatalf("witness state = %v, want %v", w.state, StateFollower)
+	}
+	for i := 0; i < lead.electionTimeout; i++ {
+		lead.tick()
+	}
+	checkLeaderTransferState(t, lead, StateLeader, 1)
+}
+
 func TestRaftNodes(t *testing.T) {
 	tests := []struct {
 		ids  []uint64
@@ -15,6 +15,8 @@
 package snap
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -25,7 +27,14 @@ import (
 	"github.com/coreos/etcd/pkg/fileutil"
 )
 
-var ErrNoDBSnapshot = errors.New("snap: snapshot file doesn't exist")
+var (
+	ErrNoDBSnapshot = errors.New("snap: snapshot file doesn't exist")
+	// ErrNoDBHash is returned by VerifyDBHash if the database snapshot does
+	// not end with an integrity hash, as when it is truncated or copied
+	// from a data directory.
+	ErrNoDBHash       = errors.New("snap: database snapshot has no integrity hash")
+	ErrDBHashMismatch = errors.New("snap: database snapshot integrity hash mismatch")
+)
 
 // SaveDBFrom saves snapshot of the database from the given reader. It
 // guarantees the save operation is atomic.
@@ -75,3 +84,37 @@ func (s *Snapshotter) DBFilePath(id uint64) (string, error) {
 func (s *Snapshotter) dbFilePath(id uint64) string {
 	return filepath.Join(s.dir, fmt.Sprintf("%016x.snap.db", id))
 }
+
+// VerifyDBHash checks the SHA-256 hash appended to a database snapshot
+// streamed by the Snapshot RPC against the snapshot payload. It returns the
+// size of the payload, which excludes the hash.
+func VerifyDBHash(r io.ReadSeeker) (int64, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	// the database is a whole number of pages; the hash is the remainder
+	if end%512 != sha256.Size {
+		return 0, ErrNoDBHash
+	}
+	size := end - sha256.Size
+
+	sha := make([]byte, sha256.Size)
+	if _, err = r.Seek(size, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err = io.ReadFull(r, sha); err != nil {
+		return 0, err
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	h := sha256.New()
+	if _, err = io.CopyN(h, r, size); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(h.Sum(nil), sha) {
+		return 0, ErrDBHashMismatch
+	}
+	return size, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestVerifyDBHash(t *testing.T) {
+	db := bytes.Repeat([]byte("etcd"), 1024)
+	sha := sha256.Sum256(db)
+	snapshot := append(append([]byte{}, db...), sha[:]...)
+
+	corrupted := append([]byte{}, snapshot...)
+	corrupted[10] ^= 0xff
+
+	tests := []struct {
+		data  []byte
+		wsize int64
+		werr  error
+	}{
+		{snapshot, int64(len(db)), nil},
+		{corrupted, 0, ErrDBHashMismatch},
+		// truncated
+		{snapshot[:len(snapshot)-100], 0, ErrNoDBHash},
+		// copied from a data directory
+		{db, 0, ErrNoDBHash},
+	}
+	for i, tt := range tests {
+		size, err := VerifyDBHash(bytes.NewReader(tt.data))
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if size != tt.wsize {
+			t.Errorf("#%d: size = %d, want %d", i, size, tt.wsize)
+		}
+	}
+}
@@ -17,12 +17,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	v3 "github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/pkg/report"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
 	"gopkg.in/cheggaaa/pb.v1"
 )
 
@@ -35,15 +37,21 @@ var leaseKeepaliveCmd = &cobra.Command{
 
 var (
 	leaseKeepaliveTotal int
+	leaseKeepaliveRate  int
 )
 
 func init() {
 	RootCmd.AddCommand(leaseKeepaliveCmd)
 	leaseKeepaliveCmd.Flags().IntVar(&leaseKeepaliveTotal, "total", 10000, "Total number of lease keepalive requests")
+	leaseKeepaliveCmd.Flags().IntVar(&leaseKeepaliveRate, "rate", 0, "Maximum lease keepalive requests per second (0 is no limit)")
 }
 
 func leaseKeepaliveFunc(cmd *cobra.Command, args []string) {
 	requests := make(chan struct{})
+	if leaseKeepaliveRate == 0 {
+		leaseKeepaliveRate = math.MaxInt32
+	}
+	limit := rate.NewLimiter(rate.Limit(leaseKeepaliveRate), 1)
 	clients := mustCreateClients(totalClients, totalConns)
 
 	bar = pb.New(leaseKeepaliveTotal)
@@ -60,6 +68,7 @@ func leaseKeepaliveFunc(cmd *cobra.Command, args []string) {
 				panic(err)
 			}
 			for range requests {
+				limit.Wait(context.Background())
 				st := time.Now()
 				_, err := c.KeepAliveOnce(context.TODO(), resp.ID)
 				r.Results() <- report.Result{Err: err, Start: st, End: time.Now()}
@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"math/rand"
 	"os"
 	"time"
 
@@ -53,6 +54,7 @@ func init() {
 
 	txnPutCmd.Flags().IntVar(&txnPutTotal, "total", 10000, "Total number of txn requests")
 	txnPutCmd.Flags().IntVar(&keySpaceSize, "key-space-size", 1, "Maximum possible keys")
+	txnPutCmd.Flags().BoolVar(&seqKeys, "sequential-keys", false, "Use sequential keys")
 }
 
 func txnPutFunc(cmd *cobra.Command, args []string) {
@@ -91,8 +93,13 @@ func txnPutFunc(cmd *cobra.Command, args []string) {
 	go func() {
 		for i := 0; i < txnPutTotal; i++ {
 			ops := make([]v3.Op, txnPutOpsPerTxn)
+			// keys of a txn must not overlap, so only the first one is random
+			first := i * txnPutOpsPerTxn
+			if !seqKeys {
+				first = rand.Intn(keySpaceSize)
+			}
 			for j := 0; j < txnPutOpsPerTxn; j++ {
-				binary.PutVarint(k, int64(((i*txnPutOpsPerTxn)+j)%keySpaceSize))
+				binary.PutVarint(k, int64((first+j)%keySpaceSize))
 				ops[j] = v3.OpPut(string(k), v)
 			}
 			requests <- ops
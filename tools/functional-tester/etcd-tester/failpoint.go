@@ -77,6 +77,21 @@ func failpointPaths(endpoint string) ([]string, error) {
 	return fps, nil
 }
 
+// failpointPath resolves a failpoint name to the path gofail registered it
+// under, which is prefixed by the package path.
+func failpointPath(endpoint, name string) (string, error) {
+	fps, err := failpointPaths(endpoint)
+	if err != nil {
+		return "", err
+	}
+	for _, fp := range fps {
+		if strings.HasSuffix(fp, "/"+name) {
+			return fp, nil
+		}
+	}
+	return "", fmt.Errorf("failpoint %q not found at %s", name, endpoint)
+}
+
 // failpoints follows FreeBSD KFAIL_POINT syntax.
 // e.g. panic("etcd-tester"),1*sleep(1000)->panic("etcd-tester")
 func failuresFromFailpoint(fp string, failpoints []string) (fs []failure) {
@@ -0,0 +1,127 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "fmt"
+
+const slowDiskLatency = 500 // 500 millisecond
+
+var (
+	// slowDiskFailpoints delay the WAL fsync and the backend commit. The names
+	// are resolved to their registered paths through failpointPath.
+	slowDiskFailpoints = []string{"walBeforeSync", "beforeCommit"}
+	// diskFullFailpoint fails the WAL fsync as if the disk ran out of space.
+	diskFullFailpoint = "walBeforeSync"
+)
+
+func injectSlowDisk(m *member) error {
+	for _, name := range slowDiskFailpoints {
+		fp, err := failpointPath(m.FailpointURL, name)
+		if err == nil {
+			err = putFailpoint(m.FailpointURL, fp, fmt.Sprintf("sleep(%d)", slowDiskLatency))
+		}
+		if err != nil {
+			recoverSlowDisk(m)
+			return err
+		}
+	}
+	return nil
+}
+
+func recoverSlowDisk(m *member) (err error) {
+	for _, name := range slowDiskFailpoints {
+		fp, ferr := failpointPath(m.FailpointURL, name)
+		if ferr != nil {
+			err = ferr
+			continue
+		}
+		if derr := delFailpoint(m.FailpointURL, fp); derr != nil {
+			err = derr
+		}
+	}
+	return err
+}
+
+func newFailureSlowDiskOneMember() failure {
+	desc := fmt.Sprintf("slow down one member's disk by adding %d ms latency", slowDiskLatency)
+	return &failureDelay{
+		failure: &failureOne{
+			description:   description(desc),
+			injectMember:  injectSlowDisk,
+			recoverMember: recoverSlowDisk,
+		},
+		delayDuration: waitRecover,
+	}
+}
+
+func newFailureSlowDiskLeader() failure {
+	desc := fmt.Sprintf("slow down leader's disk by adding %d ms latency", slowDiskLatency)
+	ff := failureByFunc{
+		description:   description(desc),
+		injectMember:  injectSlowDisk,
+		recoverMember: recoverSlowDisk,
+	}
+	return &failureDelay{&failureLeader{ff, 0}, waitRecover}
+}
+
+func newFailureSlowDiskAll() failure {
+	return &failureDelay{
+		failure: &failureAll{
+			description:   "slow down all members' disk",
+			injectMember:  injectSlowDisk,
+			recoverMember: recoverSlowDisk,
+		},
+		delayDuration: waitRecover,
+	}
+}
+
+// injectDiskFull fails the member's next WAL fsync, which crashes the member;
+// recoverDiskFull restarts it.
+func injectDiskFull(m *member) error {
+	fp, err := failpointPath(m.FailpointURL, diskFullFailpoint)
+	if err != nil {
+		return err
+	}
+	return putFailpoint(m.FailpointURL, fp, `return("no space left on device")`)
+}
+
+func recoverDiskFull(m *member) error {
+	fp, err := failpointPath(m.FailpointURL, diskFullFailpoint)
+	if err != nil {
+		// member crashed on the failpoint; restart
+		return recoverStop(m)
+	}
+	return makeRecoverFailpoint(fp)(m)
+}
+
+func newFailureDiskFullOneMember() failure {
+	return &failureDelay{
+		failure: &failureOne{
+			description:   "fill up one member's disk",
+			injectMember:  injectDiskFull,
+			recoverMember: recoverDiskFull,
+		},
+		delayDuration: waitRecover,
+	}
+}
+
+func newFailureDiskFullLeader() failure {
+	ff := failureByFunc{
+		description:   "fill up leader's disk",
+		injectMember:  injectDiskFull,
+		recoverMember: recoverDiskFull,
+	}
+	return &failureDelay{&failureLeader{ff, 0}, waitRecover}
+}
@@ -52,7 +52,7 @@ func main() {
 	consistencyCheck := flag.Bool("consistency-check", true, "true to check consistency (revision, hash)")
 	stresserType := flag.String("stresser", "keys,lease", "comma separated list of stressers (keys, lease, v2keys, nop, election-runner, watch-runner, lock-racer-runner, lease-runner).")
 	etcdRunnerPath := flag.String("etcd-runner", "", "specify a path of etcd runner binary")
-	failureTypes := flag.String("failures", "default,failpoints", "specify failures (concat of \"default\" and \"failpoints\").")
+	failureTypes := flag.String("failures", "default,failpoints", "specify failures (concat of \"default\", \"disk\" and \"failpoints\").")
 	failpoints := flag.String("failpoints", `panic("etcd-tester")`, `comma separated list of failpoint terms to inject (e.g. 'panic("etcd-tester"),1*sleep(1000)')`)
 	externalFailures := flag.String("external-failures", "", "specify a path of script for enabling/disabling an external fault injector")
 	enablePprof := flag.Bool("enable-pprof", false, "true to enable pprof")
@@ -194,6 +194,19 @@ func makeFailures(types, failpoints []string, c *cluster) []failure {
 			}
 			failures = append(failures, defaultFailures...)
 
+		case "disk":
+			if fpStats.crashes == nil {
+				fpStats.crashes = make(map[string]int)
+			}
+			diskFailures := []failure{
+				newFailureSlowDiskOneMember(),
+				newFailureSlowDiskLeader(),
+				newFailureSlowDiskAll(),
+				newFailureDiskFullOneMember(),
+				newFailureDiskFullLeader(),
+			}
+			failures = append(failures, diskFailures...)
+
 		case "failpoints":
 			fpFailures, fperr := failpointFailures(c, failpoints)
 			if len(fpFailures) == 0 {
@@ -25,7 +25,8 @@ import (
 
 var (
 	// MinClusterVersion is the min cluster version this etcd binary is compatible with.
-	MinClusterVersion = "3.0.0"
+	// Clusters may only be upgraded one minor version at a time.
+	MinClusterVersion = "3.1.0"
 	Version           = "3.2.0+git"
 	APIVersion        = "unknown"
 
@@ -30,16 +30,19 @@ type filePipeline struct {
 	size int64
 	// count number of files generated
 	count int
+	// opts sets how the files are opened
+	opts Options
 
 	filec chan *fileutil.LockedFile
 	errc  chan error
 	donec chan struct{}
 }
 
-func newFilePipeline(dir string, fileSize int64) *filePipeline {
+func newFilePipeline(dir string, fileSize int64, opts Options) *filePipeline {
 	fp := &filePipeline{
 		dir:   dir,
 		size:  fileSize,
+		opts:  opts,
 		filec: make(chan *fileutil.LockedFile),
 		errc:  make(chan error, 1),
 		donec: make(chan struct{}),
@@ -66,7 +69,7 @@ func (fp *filePipeline) Close() error {
 func (fp *filePipeline) alloc() (f *fileutil.LockedFile, err error) {
 	// count % 2 so this file isn't the same as the one last published
 	fpath := filepath.Join(fp.dir, fmt.Sprintf("%d.tmp", fp.count%2))
-	if f, err = fileutil.LockFile(fpath, os.O_CREATE|os.O_WRONLY, fileutil.PrivateFileMode); err != nil {
+	if f, err = fileutil.LockFile(fpath, fp.opts.writeFlag(os.O_CREATE|os.O_WRONLY), fileutil.PrivateFileMode); err != nil {
 		return nil, err
 	}
 	if err = fileutil.Preallocate(f.File, fp.size, true); err != nil {
@@ -87,11 +87,36 @@ type WAL struct {
 
 	locks []*fileutil.LockedFile // the locked files the WAL holds (the name is increasing)
 	fp    *filePipeline
+
+	opts Options
+}
+
+// Options configures a WAL created or opened for appending.
+type Options struct {
+	// DSync opens the wal files appended to with O_DSYNC where supported,
+	// so every write reaches stable storage before it returns instead of
+	// waiting on the page cache to be synced.
+	DSync bool
+}
+
+// writeFlag adds O_DSYNC to the flag of a wal file opened for appending,
+// if the options ask to write synchronously.
+func (o Options) writeFlag(flag int) int {
+	if o.DSync {
+		return flag | fileutil.DSyncFlag
+	}
+	return flag
 }
 
 // Create creates a WAL ready for appending records. The given metadata is
 // recorded at the head of each WAL file, and can be retrieved with ReadAll.
 func Create(dirpath string, metadata []byte) (*WAL, error) {
+	return CreateWithOptions(dirpath, metadata, Options{})
+}
+
+// CreateWithOptions creates a WAL like Create, appending to it as set by
+// the given options.
+func CreateWithOptions(dirpath string, metadata []byte, opts Options) (*WAL, error) {
 	if Exist(dirpath) {
 		return nil, os.ErrExist
 	}
@@ -108,7 +133,7 @@ func Create(dirpath string, metadata []byte) (*WAL, error) {
 	}
 
 	p := filepath.Join(tmpdirpath, walName(0, 0))
-	f, err := fileutil.LockFile(p, os.O_WRONLY|os.O_CREATE, fileutil.PrivateFileMode)
+	f, err := fileutil.LockFile(p, opts.writeFlag(os.O_WRONLY|os.O_CREATE), fileutil.PrivateFileMode)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +147,7 @@ func Create(dirpath string, metadata []byte) (*WAL, error) {
 	w := &WAL{
 		dir:      dirpath,
 		metadata: metadata,
+		opts:     opts,
 	}
 	w.encoder, err = newFileEncoder(f.File, 0)
 	if err != nil {
@@ -173,7 +199,7 @@ func (w *WAL) renameWal(tmpdirpath string) (*WAL, error) {
 		}
 		return nil, err
 	}
-	w.fp = newFilePipeline(w.dir, SegmentSizeBytes)
+	w.fp = newFilePipeline(w.dir, SegmentSizeBytes, w.opts)
 	df, err := fileutil.OpenDir(w.dir)
 	w.dirFile = df
 	return w, err
@@ -188,7 +214,7 @@ func (w *WAL) renameWalUnlock(tmpdirpath string) (*WAL, error) {
 		return nil, err
 	}
 	// reopen and relock
-	newWAL, oerr := Open(w.dir, walpb.Snapshot{})
+	newWAL, oerr := OpenWithOptions(w.dir, walpb.Snapshot{}, w.opts)
 	if oerr != nil {
 		return nil, oerr
 	}
@@ -206,7 +232,13 @@ func (w *WAL) renameWalUnlock(tmpdirpath string) (*WAL, error) {
 // the given snap. The WAL cannot be appended to before reading out all of its
 // previous records.
 func Open(dirpath string, snap walpb.Snapshot) (*WAL, error) {
-	w, err := openAtIndex(dirpath, snap, true)
+	return OpenWithOptions(dirpath, snap, Options{})
+}
+
+// OpenWithOptions opens the WAL at the given snap like Open, appending to
+// it as set by the given options once its records are read out.
+func OpenWithOptions(dirpath string, snap walpb.Snapshot, opts Options) (*WAL, error) {
+	w, err := openAtIndex(dirpath, snap, true, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -219,10 +251,10 @@ func Open(dirpath string, snap walpb.Snapshot) (*WAL, error) {
 // OpenForRead only opens the wal files for read.
 // Write on a read only wal panics.
 func OpenForRead(dirpath string, snap walpb.Snapshot) (*WAL, error) {
-	return openAtIndex(dirpath, snap, false)
+	return openAtIndex(dirpath, snap, false, Options{})
 }
 
-func openAtIndex(dirpath string, snap walpb.Snapshot, write bool) (*WAL, error) {
+func openAtIndex(dirpath string, snap walpb.Snapshot, write bool, opts Options) (*WAL, error) {
 	names, err := readWalNames(dirpath)
 	if err != nil {
 		return nil, err
@@ -240,7 +272,7 @@ func openAtIndex(dirpath string, snap walpb.Snapshot, write bool) (*WAL, error)
 	for _, name := range names[nameIndex:] {
 		p := filepath.Join(dirpath, name)
 		if write {
-			l, err := fileutil.TryLockFile(p, os.O_RDWR, fileutil.PrivateFileMode)
+			l, err := fileutil.TryLockFile(p, opts.writeFlag(os.O_RDWR), fileutil.PrivateFileMode)
 			if err != nil {
 				closeAll(rcs...)
 				return nil, err
@@ -268,6 +300,7 @@ func openAtIndex(dirpath string, snap walpb.Snapshot, write bool) (*WAL, error)
 		decoder:   newDecoder(rs...),
 		readClose: closer,
 		locks:     ls,
+		opts:      opts,
 	}
 
 	if write {
@@ -278,7 +311,7 @@ func openAtIndex(dirpath string, snap walpb.Snapshot, write bool) (*WAL, error)
 			closer()
 			return nil, err
 		}
-		w.fp = newFilePipeline(w.dir, SegmentSizeBytes)
+		w.fp = newFilePipeline(w.dir, SegmentSizeBytes, w.opts)
 	}
 
 	return w, nil
@@ -458,7 +491,7 @@ func (w *WAL) cut() error {
 	// reopen newTail with its new path so calls to Name() match the wal filename format
 	newTail.Close()
 
-	if newTail, err = fileutil.LockFile(fpath, os.O_WRONLY, fileutil.PrivateFileMode); err != nil {
+	if newTail, err = fileutil.LockFile(fpath, w.opts.writeFlag(os.O_WRONLY), fileutil.PrivateFileMode); err != nil {
 		return err
 	}
 	if _, err = newTail.Seek(off, io.SeekStart); err != nil {
@@ -483,6 +516,8 @@ func (w *WAL) sync() error {
 			return err
 		}
 	}
+	// gofail: var walBeforeSync string
+	// return errors.New(walBeforeSync)
 	start := time.Now()
 	err := fileutil.Fdatasync(w.tail().File)
 
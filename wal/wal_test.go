@@ -213,14 +213,20 @@ func TestCut(t *testing.T) {
 	}
 }
 
-func TestSaveWithCut(t *testing.T) {
+func TestSaveWithCut(t *testing.T) { testSaveWithCut(t, Options{}) }
+
+// TestSaveWithCutDSync ensures a wal written with O_DSYNC cuts and reads
+// back like any other.
+func TestSaveWithCutDSync(t *testing.T) { testSaveWithCut(t, Options{DSync: true}) }
+
+func testSaveWithCut(t *testing.T, opts Options) {
 	p, err := ioutil.TempDir(os.TempDir(), "waltest")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(p)
 
-	w, err := Create(p, []byte("metadata"))
+	w, err := CreateWithOptions(p, []byte("metadata"), opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -248,7 +254,7 @@ func TestSaveWithCut(t *testing.T) {
 
 	w.Close()
 
-	neww, err := Open(p, walpb.Snapshot{})
+	neww, err := OpenWithOptions(p, walpb.Snapshot{}, opts)
 	if err != nil {
 		t.Fatalf("err = %v, want nil", err)
 	}